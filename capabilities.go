@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfish
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SupportsResource tells whether the service has the sub-service or
+// collection named name in its service root, such as "TelemetryService" or
+// "Fabrics", and the service answers its link. It is false, without error,
+// for services that do not link to it or answer that it does not exist, so
+// callers may skip what a service does not implement.
+func (serviceroot *Service) SupportsResource(name string) (bool, error) {
+	var properties map[string]json.RawMessage
+	if json.Unmarshal(serviceroot.rawData, &properties) != nil {
+		return false, nil
+	}
+
+	var link common.Link
+	if json.Unmarshal(properties[name], &link) != nil || link == "" {
+		return false, nil
+	}
+
+	_, err := serviceroot.probe(string(link))
+	if isUnsupported(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// SupportsAction tells whether the resource at uri advertises action, such as
+// "Drive.SecureErase" or only "SecureErase". It is false, without error, if
+// the resource does not exist. For resources already got, use the
+// Actions of their entity.
+func (serviceroot *Service) SupportsAction(uri, action string) (bool, error) {
+	body, err := serviceroot.probe(uri)
+	if isUnsupported(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	action = strings.TrimPrefix(action, "#")
+	for name := range common.ParseActions(body) {
+		if name == action || strings.HasSuffix(name, "."+action) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// probe gets the body of the resource at uri.
+func (serviceroot *Service) probe(uri string) ([]byte, error) {
+	resp, err := serviceroot.Client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// isUnsupported tells whether err is the service answering that a resource
+// does not exist or is not implemented.
+func isUnsupported(err error) bool {
+	var wrongResponse ErrorWrongResponse
+	return errors.As(err, &wrongResponse) &&
+		(wrongResponse.Code == http.StatusNotFound || wrongResponse.Code == http.StatusNotImplemented)
+}
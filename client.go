@@ -12,8 +12,10 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httputil"
+	"net/textproto"
 
 	"strings"
 	"time"
@@ -32,7 +34,7 @@ type ErrorWrongResponse struct {
 }
 
 func (e ErrorWrongResponse) Error() string {
-	return fmt.Sprintf("%d: %s", e.Code, string(e.Payload))
+	return fmt.Sprintf("%d: %s", e.Code, string(common.RedactBody(e.Payload)))
 }
 
 // APIClient represents a connection to a Redfish/Swordfish enabled service
@@ -75,11 +77,19 @@ type ClientConfig struct {
 	HTTPClient *http.Client
 
 	// DumpWriter is an optional io.Writer to receive dumps of HTTP
-	// requests and responses.
+	// requests and responses. Credentials, tokens and session URIs are
+	// redacted from the dumps.
 	DumpWriter io.Writer
 
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
 	BasicAuth bool
+
+	// NewPassword, if set, is the password Connect sets when the service
+	// requires the password of the account to be changed, such as on the
+	// first login to a factory-default BMC, before logging in again with
+	// it. Without it Connect fails with a
+	// redfish.ErrorPasswordChangeRequired. It is not used with BasicAuth.
+	NewPassword string
 }
 
 // Connect creates a new client connection to a Redfish service.
@@ -135,6 +145,16 @@ func Connect(config ClientConfig) (c *APIClient, err error) {
 			if err != nil {
 				return nil, err
 			}
+			auth.Username = config.Username
+
+			if auth.PasswordChangeRequired {
+				client.Service = service
+				client.auth = auth
+				auth, err = client.changeRequiredPassword(config)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		client.Service = service
@@ -144,6 +164,69 @@ func Connect(config ClientConfig) (c *APIClient, err error) {
 	return client, err
 }
 
+// changeRequiredPassword sets the NewPassword of config as the password of the
+// account of the session, which the service requires to be changed, and
+// creates a session with it in place of the current one.
+func (c *APIClient) changeRequiredPassword(config ClientConfig) (*redfish.AuthToken, error) {
+	err := c.setRequiredPassword(config)
+
+	// The session only allows changing the password
+	c.Logout()
+	c.auth = nil
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := c.Service.CreateSession(config.Username, config.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	auth.Username = config.Username
+	if auth.PasswordChangeRequired {
+		_ = c.Service.DeleteSession(auth.Session)
+		return nil, redfish.ErrorPasswordChangeRequired{AccountURI: auth.AccountURI}
+	}
+
+	return auth, nil
+}
+
+// setRequiredPassword sets the NewPassword of config as the password of the
+// account of the session. The account is looked up by user name for services
+// that do not tell its URI.
+func (c *APIClient) setRequiredPassword(config ClientConfig) error {
+	required := redfish.ErrorPasswordChangeRequired{AccountURI: c.auth.AccountURI}
+	if config.NewPassword == "" {
+		return required
+	}
+
+	uri := c.auth.AccountURI
+	if uri == "" {
+		accountService, err := c.Service.AccountService()
+		if err != nil {
+			return err
+		}
+		accounts, err := accountService.Accounts()
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if account.UserName == config.Username {
+				uri = account.ODataID
+			}
+		}
+		if uri == "" {
+			return required
+		}
+	}
+
+	account, err := redfish.GetManagerAccount(c, uri)
+	if err != nil {
+		return err
+	}
+
+	return account.ChangePassword(config.NewPassword, config.Password)
+}
+
 // ConnectDefault creates an unauthenticated connection to a Redfish service.
 func ConnectDefault(endpoint string) (c *APIClient, err error) {
 	if !strings.HasPrefix(endpoint, "http") {
@@ -200,19 +283,71 @@ func (c *APIClient) Delete(url string) error {
 	return nil
 }
 
-// runRequest actually performs the REST calls.
-func (c *APIClient) runRequest(method string, url string, payload interface{}) (*http.Response, error) {
-	if url == "" {
-		return nil, fmt.Errorf("unable to execute request, no target provided")
+// PostMultipart performs a multipart/form-data Post request against the
+// Redfish service, such as to push a firmware image to an update service.
+// The parts are streamed to the service as they are read, so images are not
+// held in memory, and are left out of dumps.
+func (c *APIClient) PostMultipart(url string, parts []common.MultipartPart) (*http.Response, error) {
+	payloadReader, payloadPipe := io.Pipe()
+	defer payloadReader.Close()
+
+	payloadWriter := multipart.NewWriter(payloadPipe)
+	go func() {
+		payloadPipe.CloseWithError(writeMultipart(payloadWriter, parts))
+	}()
+
+	return c.runRawRequest("POST", url, payloadReader, payloadWriter.FormDataContentType())
+}
+
+// quoteEscaper escapes the names of multipart parts, as mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// writeMultipart writes the parts of a multipart request and closes it.
+func writeMultipart(payloadWriter *multipart.Writer, parts []common.MultipartPart) error {
+	for _, part := range parts {
+		header := make(textproto.MIMEHeader)
+		disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(part.Name))
+		if part.FileName != "" {
+			disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(part.FileName))
+		}
+		header.Set("Content-Disposition", disposition)
+		if part.ContentType != "" {
+			header.Set("Content-Type", part.ContentType)
+		}
+
+		partWriter, err := payloadWriter.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(partWriter, part.Content)
+		if err != nil {
+			return err
+		}
 	}
 
-	var payloadBuffer io.ReadSeeker
+	return payloadWriter.Close()
+}
+
+// runRequest actually performs the REST calls.
+func (c *APIClient) runRequest(method string, url string, payload interface{}) (*http.Response, error) {
+	var payloadBuffer io.Reader
+	contentType := ""
 	if payload != nil {
 		body, err := json.Marshal(payload)
 		if err != nil {
 			return nil, err
 		}
 		payloadBuffer = bytes.NewReader(body)
+		contentType = applicationJSON
+	}
+
+	return c.runRawRequest(method, url, payloadBuffer, contentType)
+}
+
+// runRawRequest performs the REST calls with an already encoded body.
+func (c *APIClient) runRawRequest(method string, url string, payloadBuffer io.Reader, contentType string) (*http.Response, error) {
+	if url == "" {
+		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
 
 	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
@@ -226,8 +361,8 @@ func (c *APIClient) runRequest(method string, url string, payload interface{}) (
 	req.Header.Set("Accept", applicationJSON)
 
 	// Add content info if present
-	if payload != nil {
-		req.Header.Set("Content-Type", applicationJSON)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	// Add auth info if authenticated
@@ -243,14 +378,15 @@ func (c *APIClient) runRequest(method string, url string, payload interface{}) (
 	}
 	req.Close = true
 
-	// Dump request if needed.
+	// Dump request if needed, without its credentials. Multipart bodies,
+	// such as firmware images, are streamed and left out.
 	if c.dumpWriter != nil {
-		d, err := httputil.DumpRequestOut(req, true)
+		d, err := httputil.DumpRequestOut(req, !strings.HasPrefix(contentType, "multipart/"))
 		if err != nil {
 			return nil, err
 		}
 
-		d = append(d, '\n')
+		d = append(common.RedactDump(d), '\n')
 		_, err = c.dumpWriter.Write(d)
 		if err != nil {
 			panic(err)
@@ -262,7 +398,7 @@ func (c *APIClient) runRequest(method string, url string, payload interface{}) (
 		return nil, err
 	}
 
-	// Dump response if needed.
+	// Dump response if needed, without its credentials.
 	if c.dumpWriter != nil {
 		d, err := httputil.DumpResponse(resp, true)
 		if err != nil {
@@ -270,7 +406,7 @@ func (c *APIClient) runRequest(method string, url string, payload interface{}) (
 			return nil, err
 		}
 
-		d = append(d, '\n')
+		d = append(common.RedactDump(d), '\n')
 		_, err = c.dumpWriter.Write(d)
 		if err != nil {
 			panic(err)
@@ -283,15 +419,32 @@ func (c *APIClient) runRequest(method string, url string, payload interface{}) (
 			return nil, err
 		}
 		defer resp.Body.Close()
-		return nil, ErrorWrongResponse{
+		wrongResponse := ErrorWrongResponse{
 			Code:    resp.StatusCode,
 			Payload: payload,
 		}
+		// The service may deny access until the password is changed
+		if required, ok := redfish.ParsePasswordChangeRequired(payload, wrongResponse); ok {
+			return nil, required
+		}
+		return nil, wrongResponse
 	}
 
 	return resp, err
 }
 
+// RemoteConsole gets a URL to open the graphical remote console of a manager
+// with, for the user of the client. See redfish.Manager.RemoteConsole.
+func (c *APIClient) RemoteConsole(manager *redfish.Manager) (*redfish.RemoteConsole, error) {
+	options := redfish.RemoteConsoleOptions{Endpoint: c.endpoint}
+	if c.auth != nil {
+		options.Username = c.auth.Username
+		options.SessionToken = c.auth.Token
+	}
+
+	return manager.RemoteConsole(options)
+}
+
 // Logout will delete any active session. Useful to defer logout when creating
 // a new connection.
 func (c *APIClient) Logout() {
@@ -0,0 +1,66 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfish
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TestPostMultipart tests streaming a multipart request, with quoted names,
+// and leaving its body out of dumps.
+func TestPostMultipart(t *testing.T) {
+	var fileName, content string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("UpdateFile")
+		if err != nil {
+			t.Errorf("Error reading the file part: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		data, _ := ioutil.ReadAll(file)
+		fileName, content = header.Filename, string(data)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client := &APIClient{endpoint: server.URL, HTTPClient: server.Client(), dumpWriter: &dump}
+
+	resp, err := client.PostMultipart("/redfish/v1/UpdateService/upload", []common.MultipartPart{
+		{
+			Name:        "UpdateParameters",
+			ContentType: "application/json",
+			Content:     strings.NewReader(`{"Targets": []}`),
+		},
+		{
+			Name:        "UpdateFile",
+			FileName:    `bmc "v2".bin`,
+			ContentType: "application/octet-stream",
+			Content:     strings.NewReader("firmware image"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error posting multipart request: %s", err)
+	}
+	resp.Body.Close()
+
+	if fileName != `bmc "v2".bin` || content != "firmware image" {
+		t.Errorf("Unexpected file part: %q %q", fileName, content)
+	}
+
+	if !strings.Contains(dump.String(), "POST /redfish/v1/UpdateService/upload") ||
+		strings.Contains(dump.String(), "firmware image") {
+		t.Errorf("The request should be dumped without its body: %s", dump.String())
+	}
+}
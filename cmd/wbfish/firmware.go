@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// firmwareUsage is the synopsis of the arguments of the firmware command.
+const firmwareUsage = "list | update [-protocol P] [-target URI] <image>"
+
+// runFirmware lists or updates the firmware of the service.
+func runFirmware(c *cli, args []string) error {
+	usage := fmt.Errorf("usage: wbfish firmware %s", firmwareUsage)
+	if len(args) == 0 {
+		return usage
+	}
+
+	flags := flag.NewFlagSet("firmware "+args[0], flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	protocol := flags.String("protocol", "", "protocol the service uses to fetch the image, such as HTTPS")
+	target := flags.String("target", "", "URI of the resource to update, defaults to what the service picks")
+
+	switch args[0] {
+	case "list":
+		_, err := parseFlags(flags, args[1:], 0, "")
+		if err != nil {
+			return err
+		}
+
+		updateService, err := c.client.Service.UpdateService()
+		if err != nil {
+			return err
+		}
+		firmware, err := updateService.FirmwareInventory()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "ID\tNAME\tVERSION\tUPDATEABLE\n")
+		for _, f := range firmware {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", f.ID, f.Name, f.Version, f.Updateable)
+		}
+		return w.Flush()
+	case "update":
+		args, err := parseFlags(flags, args[1:], 1, "[-protocol P] [-target URI] <image>")
+		if err != nil {
+			return err
+		}
+
+		updateService, err := c.client.Service.UpdateService()
+		if err != nil {
+			return err
+		}
+
+		parameters := &redfish.SimpleUpdateParameters{
+			ImageURI:         args[0],
+			TransferProtocol: redfish.TransferProtocolType(strings.ToUpper(*protocol)),
+		}
+		if *target != "" {
+			parameters.Targets = []string{*target}
+		}
+
+		return updateService.SimpleUpdate(parameters)
+	}
+
+	return usage
+}
@@ -0,0 +1,90 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"text/tabwriter"
+)
+
+// inventory is the JSON output of the inventory command.
+type inventory struct {
+	Systems  []json.RawMessage
+	Chassis  []json.RawMessage
+	Managers []json.RawMessage
+}
+
+// inventoryUsage is the synopsis of the arguments of the inventory command.
+const inventoryUsage = "[-json]"
+
+// runInventory shows the systems, chassis and managers of the service.
+func runInventory(c *cli, args []string) error {
+	flags := flag.NewFlagSet("inventory", flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	asJSON := flags.Bool("json", false, "print the resources as JSON")
+	_, err := parseFlags(flags, args, 0, inventoryUsage)
+	if err != nil {
+		return err
+	}
+
+	systems, err := c.client.Service.Systems()
+	if err != nil {
+		return err
+	}
+	chassis, err := c.client.Service.Chassis()
+	if err != nil {
+		return err
+	}
+	managers, err := c.client.Service.Managers()
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		var result inventory
+		for _, system := range systems {
+			result.Systems = append(result.Systems, system.GetRawData())
+		}
+		for _, chass := range chassis {
+			result.Chassis = append(result.Chassis, chass.GetRawData())
+		}
+		for _, manager := range managers {
+			result.Managers = append(result.Managers, manager.GetRawData())
+		}
+
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.out, "%s\n", bytes.TrimSpace(data))
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "SYSTEM\tMANUFACTURER\tMODEL\tSERIAL\tPOWER\tBIOS\tCPUS\tMEMORY\n")
+	for _, system := range systems {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%g GiB\n",
+			system.ID, system.Manufacturer, system.Model, system.SerialNumber, system.PowerState,
+			system.BIOSVersion, system.ProcessorSummary.Count, system.MemorySummary.TotalSystemMemoryGiB)
+	}
+
+	fmt.Fprintf(w, "\nCHASSIS\tTYPE\tMANUFACTURER\tMODEL\tSERIAL\tPOWER\n")
+	for _, chass := range chassis {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			chass.ID, chass.ChassisType, chass.Manufacturer, chass.Model, chass.SerialNumber, chass.PowerState)
+	}
+
+	fmt.Fprintf(w, "\nMANAGER\tMANUFACTURER\tMODEL\tFIRMWARE\tPOWER\n")
+	for _, manager := range managers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			manager.ID, manager.Manufacturer, manager.Model, manager.FirmwareVersion, manager.PowerState)
+	}
+
+	return w.Flush()
+}
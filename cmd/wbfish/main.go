@@ -0,0 +1,232 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Command wbfish manages Redfish services from the command line. It covers
+// the everyday operations on a server: inventory, power control, boot
+// override, virtual media, firmware updates, the system event log and user
+// accounts.
+//
+// Usage:
+//
+//	wbfish [flags] <command> [arguments]
+//
+// The endpoint and user name may also be given with the WBFISH_ENDPOINT and
+// WBFISH_USERNAME environment variables. The password is read from the
+// WBFISH_PASSWORD environment variable or, with -password-stdin, from the
+// first line of the standard input; it is not taken as a flag, which other
+// users could read from the process list. For the same reason, the
+// passwords of the users command are read from the standard input when
+// given as "-". Run "wbfish help" for the list of commands.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// command is a wbfish subcommand.
+type command struct {
+	// usage is the synopsis of the arguments of the command.
+	usage string
+	// summary is a one line description of the command.
+	summary string
+	// run runs the command with its arguments.
+	run func(c *cli, args []string) error
+}
+
+// commands are the subcommands, keyed by name.
+var commands = map[string]command{
+	"inventory": {inventoryUsage, "show the systems, chassis and managers of the service", runInventory},
+	"power":     {powerUsage, "show or change the power state of a system", runPower},
+	"boot":      {bootUsage, "set the boot source override of a system", runBoot},
+	"media":     {mediaUsage, "manage virtual media", runMedia},
+	"firmware":  {firmwareUsage, "list or update firmware", runFirmware},
+	"sel":       {selUsage, "show the system event log", runSEL},
+	"users":     {usersUsage, "manage user accounts", runUsers},
+}
+
+// cli holds the state shared by the commands.
+type cli struct {
+	// client is the connection to the service.
+	client *wbfish.APIClient
+	// in is read for the passwords given as "-".
+	in io.Reader
+	// out receives the output of the commands.
+	out io.Writer
+	// system is the ID of the system to act on, if set.
+	system string
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wbfish: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags, connects to the service and runs a command.
+// in is read for the password with -password-stdin, and for the passwords
+// commands are given as "-".
+func run(args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("wbfish", flag.ContinueOnError)
+	flags.SetOutput(out)
+	endpoint := flags.String("endpoint", os.Getenv("WBFISH_ENDPOINT"), "URL of the Redfish service")
+	username := flags.String("username", os.Getenv("WBFISH_USERNAME"), "user name to log in with")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from the standard input instead of WBFISH_PASSWORD")
+	insecure := flags.Bool("insecure", false, "do not verify the TLS certificate of the service")
+	basicAuth := flags.Bool("basic-auth", false, "use basic authentication instead of a session")
+	system := flags.String("system", "", "ID of the system to act on, when the service has several")
+	flags.Usage = func() {
+		usage(flags, out)
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	name := flags.Arg(0)
+	if name == "" || name == "help" {
+		usage(flags, out)
+		return nil
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run \"wbfish help\" for usage", name)
+	}
+
+	if *endpoint == "" {
+		return fmt.Errorf("no endpoint given, use -endpoint or WBFISH_ENDPOINT")
+	}
+
+	password := os.Getenv("WBFISH_PASSWORD")
+	if *passwordStdin {
+		password, err = readPassword(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := wbfish.Connect(wbfish.ClientConfig{
+		Endpoint:  *endpoint,
+		Username:  *username,
+		Password:  password,
+		Insecure:  *insecure,
+		BasicAuth: *basicAuth,
+	})
+	if err != nil {
+		return err
+	}
+	defer client.Logout()
+
+	if client.Service == nil {
+		// Connect only reads the service root when it logs in
+		client.Service, err = wbfish.ServiceRoot(client)
+		if err != nil {
+			return err
+		}
+	}
+
+	c := &cli{
+		client: client,
+		in:     in,
+		out:    out,
+		system: *system,
+	}
+
+	return cmd.run(c, flags.Args()[1:])
+}
+
+// readPassword reads a password from the first line of in.
+func readPassword(in io.Reader) (string, error) {
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		if scanner.Err() != nil {
+			return "", scanner.Err()
+		}
+		return "", fmt.Errorf("no password on the standard input")
+	}
+
+	return strings.TrimSuffix(scanner.Text(), "\r"), nil
+}
+
+// usage prints the usage of wbfish.
+func usage(flags *flag.FlagSet, out io.Writer) {
+	fmt.Fprintf(out, "Usage: wbfish [flags] <command> [arguments]\n\nCommands:\n")
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-10s %s\n", name, commands[name].summary)
+		fmt.Fprintf(out, "  %-10s   wbfish %s %s\n", "", name, commands[name].usage)
+	}
+
+	fmt.Fprintf(out, "\nFlags:\n")
+	flags.PrintDefaults()
+}
+
+// parseFlags parses the flags of a command and checks the number of its
+// remaining arguments.
+func parseFlags(flags *flag.FlagSet, args []string, count int, usage string) ([]string, error) {
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != count {
+		return nil, fmt.Errorf("usage: wbfish %s", strings.TrimSpace(flags.Name()+" "+usage))
+	}
+
+	return flags.Args(), nil
+}
+
+// computerSystem gets the system selected with the -system flag, or the only
+// system of the service.
+func (c *cli) computerSystem() (*redfish.ComputerSystem, error) {
+	systems, err := c.client.Service.Systems()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.system == "" {
+		if len(systems) != 1 {
+			return nil, fmt.Errorf("the service has %d systems, select one with -system", len(systems))
+		}
+		return systems[0], nil
+	}
+
+	for _, system := range systems {
+		if system.ID == c.system {
+			return system, nil
+		}
+	}
+
+	return nil, fmt.Errorf("system %s not found", c.system)
+}
+
+// matchValue finds the value matching a name, ignoring case. The name is
+// returned as is if no value matches, so values the command does not know of
+// are passed to the service.
+func matchValue(name string, values []string) string {
+	for _, value := range values {
+		if strings.EqualFold(name, value) {
+			return value
+		}
+	}
+
+	return name
+}
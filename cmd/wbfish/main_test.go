@@ -0,0 +1,356 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/wbfishtest"
+)
+
+var resources = map[string]string{
+	"/redfish/v1": `{
+		"@odata.id": "/redfish/v1",
+		"Id": "RootService",
+		"Systems": {"@odata.id": "/redfish/v1/Systems"},
+		"Chassis": {"@odata.id": "/redfish/v1/Chassis"},
+		"Managers": {"@odata.id": "/redfish/v1/Managers"},
+		"AccountService": {"@odata.id": "/redfish/v1/AccountService"},
+		"UpdateService": {"@odata.id": "/redfish/v1/UpdateService"}
+	}`,
+	"/redfish/v1/Systems": `{
+		"@odata.id": "/redfish/v1/Systems",
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]
+	}`,
+	"/redfish/v1/Systems/1": `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Manufacturer": "Contoso",
+		"Model": "3500",
+		"SerialNumber": "437XR1138R2",
+		"PowerState": "On",
+		"LogServices": {"@odata.id": "/redfish/v1/Systems/1/LogServices"},
+		"Links": {
+			"ManagedBy": [{"@odata.id": "/redfish/v1/Managers/BMC"}]
+		},
+		"Actions": {
+			"#ComputerSystem.Reset": {
+				"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"
+			}
+		}
+	}`,
+	"/redfish/v1/Systems/1/LogServices": `{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices",
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL"}]
+	}`,
+	"/redfish/v1/Systems/1/LogServices/SEL": `{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL",
+		"Id": "SEL",
+		"LogEntryType": "SEL",
+		"Entries": {"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries"}
+	}`,
+	"/redfish/v1/Systems/1/LogServices/SEL/Entries": `{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries",
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/1"},
+			{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/2"}
+		]
+	}`,
+	"/redfish/v1/Systems/1/LogServices/SEL/Entries/1": `{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/1",
+		"Id": "1",
+		"Created": "2026-10-01T08:00:00Z",
+		"Severity": "OK",
+		"Message": "System powered on"
+	}`,
+	"/redfish/v1/Systems/1/LogServices/SEL/Entries/2": `{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/2",
+		"Id": "2",
+		"Created": "2026-10-01T09:00:00Z",
+		"Severity": "Critical",
+		"Message": "Fan 3 failed"
+	}`,
+	"/redfish/v1/Chassis": `{
+		"@odata.id": "/redfish/v1/Chassis",
+		"Members@odata.count": 0,
+		"Members": []
+	}`,
+	"/redfish/v1/Managers": `{
+		"@odata.id": "/redfish/v1/Managers",
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/Managers/BMC"}]
+	}`,
+	"/redfish/v1/Managers/BMC": `{
+		"@odata.id": "/redfish/v1/Managers/BMC",
+		"Id": "BMC",
+		"FirmwareVersion": "1.45",
+		"VirtualMedia": {"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia"}
+	}`,
+	"/redfish/v1/Managers/BMC/VirtualMedia": `{
+		"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia",
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia/Floppy1"},
+			{"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia/CD1"}
+		]
+	}`,
+	"/redfish/v1/Managers/BMC/VirtualMedia/Floppy1": `{
+		"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia/Floppy1",
+		"Id": "Floppy1",
+		"MediaTypes": ["Floppy"]
+	}`,
+	"/redfish/v1/Managers/BMC/VirtualMedia/CD1": `{
+		"@odata.id": "/redfish/v1/Managers/BMC/VirtualMedia/CD1",
+		"Id": "CD1",
+		"MediaTypes": ["CD", "DVD"],
+		"Actions": {
+			"#VirtualMedia.InsertMedia": {
+				"target": "/redfish/v1/Managers/BMC/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia"
+			}
+		}
+	}`,
+	"/redfish/v1/AccountService": `{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Accounts": {"@odata.id": "/redfish/v1/AccountService/Accounts"}
+	}`,
+	"/redfish/v1/AccountService/Accounts": `{
+		"@odata.id": "/redfish/v1/AccountService/Accounts",
+		"Members@odata.count": 1,
+		"Members": [{"@odata.id": "/redfish/v1/AccountService/Accounts/1"}]
+	}`,
+	"/redfish/v1/AccountService/Accounts/1": `{
+		"@odata.id": "/redfish/v1/AccountService/Accounts/1",
+		"Id": "1",
+		"UserName": "admin",
+		"RoleId": "Administrator",
+		"Enabled": true
+	}`,
+	"/redfish/v1/UpdateService": `{
+		"@odata.id": "/redfish/v1/UpdateService",
+		"Actions": {
+			"#UpdateService.SimpleUpdate": {
+				"target": "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+			}
+		}
+	}`,
+}
+
+// runCommand runs wbfish against a mock service.
+func runCommand(t *testing.T, server *wbfishtest.Server, args ...string) (string, error) {
+	return runCommandInput(t, server, "", args...)
+}
+
+// runCommandInput runs wbfish against a mock service, with input as its
+// standard input.
+func runCommandInput(t *testing.T, server *wbfishtest.Server, input string, args ...string) (string, error) {
+	var out bytes.Buffer
+	err := run(append([]string{"-endpoint", server.URL}, args...), strings.NewReader(input), &out)
+	return out.String(), err
+}
+
+func newTestServer(t *testing.T) *wbfishtest.Server {
+	server, err := wbfishtest.NewServer(resources)
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+
+	return server
+}
+
+// lastRequest gets the last request that changed the service.
+func lastRequest(server *wbfishtest.Server) wbfishtest.Request {
+	var result wbfishtest.Request
+	for _, request := range server.Requests() {
+		if request.Method != http.MethodGet {
+			result = request
+		}
+	}
+
+	return result
+}
+
+// TestPasswordStdin tests reading the password from the standard input.
+func TestPasswordStdin(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	_, err := runCommandInput(t, server, "s3cret\n", "-basic-auth", "-username", "admin", "-password-stdin", "inventory")
+	if err != nil {
+		t.Fatalf("Error running inventory: %s", err)
+	}
+
+	request := server.Requests()[len(server.Requests())-1]
+	if username, password, ok := (&http.Request{Header: request.Header}).BasicAuth(); !ok || username != "admin" || password != "s3cret" {
+		t.Errorf("The password should have been read from stdin: %v", request.Header)
+	}
+
+	_, err = runCommand(t, server, "-basic-auth", "-username", "admin", "-password-stdin", "inventory")
+	if err == nil {
+		t.Error("An empty standard input should be refused")
+	}
+}
+
+// TestInventory tests the inventory command.
+func TestInventory(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	out, err := runCommand(t, server, "inventory")
+	if err != nil {
+		t.Fatalf("Error running inventory: %s", err)
+	}
+
+	if !strings.Contains(out, "437XR1138R2") || !strings.Contains(out, "1.45") {
+		t.Errorf("Unexpected inventory: %s", out)
+	}
+
+	out, err = runCommand(t, server, "inventory", "-json")
+	if err != nil {
+		t.Fatalf("Error running inventory: %s", err)
+	}
+
+	if !strings.Contains(out, `"SerialNumber": "437XR1138R2"`) {
+		t.Errorf("Unexpected JSON inventory: %s", out)
+	}
+}
+
+// TestPowerAndBoot tests the power and boot commands.
+func TestPowerAndBoot(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	out, err := runCommand(t, server, "power")
+	if err != nil || out != "On\n" {
+		t.Errorf("Unexpected power state: %q %v", out, err)
+	}
+
+	_, err = runCommand(t, server, "power", "forcerestart")
+	if err != nil {
+		t.Fatalf("Error running power: %s", err)
+	}
+
+	request := lastRequest(server)
+	if request.Path != "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset" ||
+		!strings.Contains(string(request.Body), "ForceRestart") {
+		t.Errorf("Unexpected reset request: %s %s", request.Path, request.Body)
+	}
+
+	_, err = runCommand(t, server, "boot", "-mode", "uefi", "pxe")
+	if err != nil {
+		t.Fatalf("Error running boot: %s", err)
+	}
+
+	boot, _ := server.Resource("/redfish/v1/Systems/1")["Boot"].(map[string]interface{})
+	if boot["BootSourceOverrideTarget"] != "Pxe" || boot["BootSourceOverrideEnabled"] != "Once" ||
+		boot["BootSourceOverrideMode"] != "UEFI" {
+		t.Errorf("Unexpected boot override: %v", boot)
+	}
+
+	_, err = runCommand(t, server, "boot")
+	if err == nil {
+		t.Error("A boot target should be required")
+	}
+}
+
+// TestMediaAndFirmware tests the media and firmware commands.
+func TestMediaAndFirmware(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	_, err := runCommand(t, server, "media", "insert", "http://images.example.com/install.iso")
+	if err != nil {
+		t.Fatalf("Error running media: %s", err)
+	}
+
+	request := lastRequest(server)
+	if request.Path != "/redfish/v1/Managers/BMC/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia" {
+		t.Errorf("The image should be inserted in the CD: %s", request.Path)
+	}
+
+	_, err = runCommand(t, server, "media", "eject", "-device", "Floppy1")
+	if err == nil {
+		t.Error("Eject should not be supported by the floppy")
+	}
+
+	_, err = runCommand(t, server, "firmware", "update", "-protocol", "https", "https://images.example.com/bmc.bin")
+	if err != nil {
+		t.Fatalf("Error running firmware: %s", err)
+	}
+
+	request = lastRequest(server)
+	if request.Path != "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" ||
+		!strings.Contains(string(request.Body), `"TransferProtocol":"HTTPS"`) {
+		t.Errorf("Unexpected update request: %s %s", request.Path, request.Body)
+	}
+}
+
+// TestSEL tests the sel command.
+func TestSEL(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	out, err := runCommand(t, server, "sel", "-limit", "1")
+	if err != nil {
+		t.Fatalf("Error running sel: %s", err)
+	}
+
+	if !strings.Contains(out, "Fan 3 failed") || strings.Contains(out, "System powered on") {
+		t.Errorf("Unexpected log: %s", out)
+	}
+}
+
+// TestUsers tests the users command.
+func TestUsers(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	_, err := runCommand(t, server, "users", "add", "-role", "Operator", "automation", "s3cret")
+	if err != nil {
+		t.Fatalf("Error adding user: %s", err)
+	}
+
+	out, err := runCommand(t, server, "users", "list")
+	if err != nil || !strings.Contains(out, "automation") || !strings.Contains(out, "Operator") {
+		t.Errorf("Unexpected users: %s %v", out, err)
+	}
+
+	_, err = runCommand(t, server, "users", "passwd", "admin", "n3w-secret")
+	if err != nil {
+		t.Fatalf("Error changing password: %s", err)
+	}
+
+	if server.Resource("/redfish/v1/AccountService/Accounts/1")["Password"] != "n3w-secret" {
+		t.Errorf("The password should have been changed: %v", server.Resource("/redfish/v1/AccountService/Accounts/1"))
+	}
+
+	_, err = runCommandInput(t, server, "fr0m-stdin\n", "users", "passwd", "admin", "-")
+	if err != nil {
+		t.Fatalf("Error changing password: %s", err)
+	}
+
+	if server.Resource("/redfish/v1/AccountService/Accounts/1")["Password"] != "fr0m-stdin" {
+		t.Errorf("The password should have been read from stdin: %v", server.Resource("/redfish/v1/AccountService/Accounts/1"))
+	}
+
+	_, err = runCommand(t, server, "users", "delete", "admin")
+	if err != nil {
+		t.Fatalf("Error deleting user: %s", err)
+	}
+
+	if server.Resource("/redfish/v1/AccountService/Accounts/1") != nil {
+		t.Error("The user should have been deleted")
+	}
+
+	_, err = runCommand(t, server, "users", "delete", "admin")
+	if err == nil {
+		t.Error("Deleting a missing user should fail")
+	}
+}
@@ -0,0 +1,132 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// mediaUsage is the synopsis of the arguments of the media command.
+const mediaUsage = "list | insert [-device ID] <image> | eject [-device ID]"
+
+// runMedia manages the virtual media of a system.
+func runMedia(c *cli, args []string) error {
+	usage := fmt.Errorf("usage: wbfish media %s", mediaUsage)
+	if len(args) == 0 {
+		return usage
+	}
+
+	flags := flag.NewFlagSet("media "+args[0], flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	device := flags.String("device", "", "ID of the virtual media device, defaults to the first CD or DVD")
+
+	switch args[0] {
+	case "list":
+		_, err := parseFlags(flags, args[1:], 0, "")
+		if err != nil {
+			return err
+		}
+
+		media, err := c.virtualMedia()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "DEVICE\tTYPES\tINSERTED\tIMAGE\n")
+		for _, m := range media {
+			fmt.Fprintf(w, "%s\t%v\t%t\t%s\n", m.ID, m.SupportedMediaTypes, m.Inserted, m.Image)
+		}
+		return w.Flush()
+	case "insert":
+		args, err := parseFlags(flags, args[1:], 1, "[-device ID] <image>")
+		if err != nil {
+			return err
+		}
+
+		m, err := c.virtualMediaDevice(*device)
+		if err != nil {
+			return err
+		}
+
+		return m.InsertMedia(&redfish.VirtualMediaInsertParameters{Image: args[0]})
+	case "eject":
+		_, err := parseFlags(flags, args[1:], 0, "[-device ID]")
+		if err != nil {
+			return err
+		}
+
+		m, err := c.virtualMediaDevice(*device)
+		if err != nil {
+			return err
+		}
+
+		return m.EjectMedia()
+	}
+
+	return usage
+}
+
+// virtualMedia gets the virtual media of the system, and of the managers of
+// the service since most services attach them there.
+func (c *cli) virtualMedia() ([]*redfish.VirtualMedia, error) {
+	system, err := c.computerSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := system.VirtualMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	managers, err := system.ManagedBy()
+	if err != nil {
+		return nil, err
+	}
+	for _, manager := range managers {
+		media, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, media...)
+	}
+
+	return result, nil
+}
+
+// virtualMediaDevice gets a virtual media device by ID, or the first one that
+// takes CD or DVD images.
+func (c *cli) virtualMediaDevice(id string) (*redfish.VirtualMedia, error) {
+	media, err := c.virtualMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range media {
+		if id != "" {
+			if m.ID == id {
+				return m, nil
+			}
+			continue
+		}
+
+		for _, mediaType := range m.SupportedMediaTypes {
+			if mediaType == redfish.CdVirtualMediaType || mediaType == redfish.DvdVirtualMediaType {
+				return m, nil
+			}
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("virtual media %s not found", id)
+	}
+
+	return nil, fmt.Errorf("no virtual CD or DVD found, select a device with -device")
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// powerActions are the short names of the reset types.
+var powerActions = map[string]redfish.ResetType{
+	"on":           redfish.OnResetType,
+	"off":          redfish.GracefulShutdownResetType,
+	"forceoff":     redfish.ForceOffResetType,
+	"restart":      redfish.GracefulRestartResetType,
+	"forcerestart": redfish.ForceRestartResetType,
+	"cycle":        redfish.PowerCycleResetType,
+	"nmi":          redfish.NmiResetType,
+}
+
+// bootTargets are the boot source override targets.
+var bootTargets = []string{
+	string(redfish.NoneBootSourceOverrideTarget),
+	string(redfish.PxeBootSourceOverrideTarget),
+	string(redfish.FloppyBootSourceOverrideTarget),
+	string(redfish.CdBootSourceOverrideTarget),
+	string(redfish.UsbBootSourceOverrideTarget),
+	string(redfish.HddBootSourceOverrideTarget),
+	string(redfish.BiosSetupBootSourceOverrideTarget),
+	string(redfish.UtilitiesBootSourceOverrideTarget),
+	string(redfish.DiagsBootSourceOverrideTarget),
+	string(redfish.UefiShellBootSourceOverrideTarget),
+	string(redfish.UefiTargetBootSourceOverrideTarget),
+	string(redfish.SDCardBootSourceOverrideTarget),
+	string(redfish.UefiHTTPBootSourceOverrideTarget),
+	string(redfish.RemoteDriveBootSourceOverrideTarget),
+	string(redfish.UefiBootNextBootSourceOverrideTarget),
+}
+
+// powerUsage is the synopsis of the arguments of the power command.
+const powerUsage = "[status|on|off|forceoff|restart|forcerestart|cycle|nmi|<ResetType>]"
+
+// runPower shows or changes the power state of a system.
+func runPower(c *cli, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: wbfish power %s", powerUsage)
+	}
+
+	system, err := c.computerSystem()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "status" {
+		_, err = fmt.Fprintf(c.out, "%s\n", system.PowerState)
+		return err
+	}
+
+	resetType, ok := powerActions[strings.ToLower(args[0])]
+	if !ok {
+		resetType = redfish.ResetType(args[0])
+	}
+
+	return system.Reset(resetType)
+}
+
+// bootUsage is the synopsis of the arguments of the boot command.
+const bootUsage = "[-persistent] [-mode UEFI|Legacy] <target>"
+
+// runBoot sets the boot source override of a system.
+func runBoot(c *cli, args []string) error {
+	flags := flag.NewFlagSet("boot", flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	persistent := flags.Bool("persistent", false, "keep the override for every boot instead of the next one")
+	mode := flags.String("mode", "", "boot mode to use, UEFI or Legacy")
+	args, err := parseFlags(flags, args, 1, bootUsage)
+	if err != nil {
+		return err
+	}
+
+	system, err := c.computerSystem()
+	if err != nil {
+		return err
+	}
+
+	boot := redfish.Boot{
+		BootSourceOverrideTarget:  redfish.BootSourceOverrideTarget(matchValue(args[0], bootTargets)),
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+	}
+	if *persistent {
+		boot.BootSourceOverrideEnabled = redfish.ContinuousBootSourceOverrideEnabled
+	}
+	if boot.BootSourceOverrideTarget == redfish.NoneBootSourceOverrideTarget {
+		boot.BootSourceOverrideEnabled = redfish.DisabledBootSourceOverrideEnabled
+	}
+	if *mode != "" {
+		boot.BootSourceOverrideMode = redfish.BootSourceOverrideMode(matchValue(*mode, []string{
+			string(redfish.UEFIBootSourceOverrideMode),
+			string(redfish.LegacyBootSourceOverrideMode),
+		}))
+	}
+
+	return system.SetBoot(boot)
+}
@@ -0,0 +1,95 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// selUsage is the synopsis of the arguments of the sel command.
+const selUsage = "[-log ID] [-limit N]"
+
+// runSEL shows the system event log.
+func runSEL(c *cli, args []string) error {
+	flags := flag.NewFlagSet("sel", flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	logID := flags.String("log", "", "ID of the log service to read, defaults to the SEL")
+	limit := flags.Int("limit", 0, "show only the last N entries")
+	_, err := parseFlags(flags, args, 0, selUsage)
+	if err != nil {
+		return err
+	}
+
+	logService, err := c.logService(*logID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := logService.Entries()
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(entries) > *limit {
+		entries = entries[len(entries)-*limit:]
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tCREATED\tSEVERITY\tMESSAGE\n")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.ID, entry.Created, entry.Severity, entry.Message)
+	}
+
+	return w.Flush()
+}
+
+// logService finds a log service of the system or of its managers by ID, or
+// the one holding the SEL.
+func (c *cli) logService(id string) (*redfish.LogService, error) {
+	system, err := c.computerSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	logServices, err := system.LogServices()
+	if err != nil {
+		return nil, err
+	}
+
+	managers, err := system.ManagedBy()
+	if err != nil {
+		return nil, err
+	}
+	for _, manager := range managers {
+		services, err := manager.LogServices()
+		if err != nil {
+			return nil, err
+		}
+		logServices = append(logServices, services...)
+	}
+
+	for _, logService := range logServices {
+		if id != "" {
+			if logService.ID == id {
+				return logService, nil
+			}
+			continue
+		}
+
+		if logService.LogEntryType == redfish.SELLogEntryTypes || strings.EqualFold(logService.ID, "SEL") {
+			return logService, nil
+		}
+	}
+
+	if id != "" {
+		return nil, fmt.Errorf("log service %s not found", id)
+	}
+
+	return nil, fmt.Errorf("no SEL found, select a log service with -log")
+}
@@ -0,0 +1,127 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// usersUsage is the synopsis of the arguments of the users command.
+const usersUsage = "list | add [-role ID] <name> <password> | delete <name> | passwd <name> <password>"
+
+// runUsers manages the user accounts of the service.
+func runUsers(c *cli, args []string) error {
+	usage := fmt.Errorf("usage: wbfish users %s", usersUsage)
+	if len(args) == 0 {
+		return usage
+	}
+
+	flags := flag.NewFlagSet("users "+args[0], flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	role := flags.String("role", "Administrator", "role of the new account")
+
+	accountService, err := c.client.Service.AccountService()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		_, err := parseFlags(flags, args[1:], 0, "")
+		if err != nil {
+			return err
+		}
+
+		accounts, err := accountService.Accounts()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "ID\tUSER\tROLE\tENABLED\tLOCKED\n")
+		for _, account := range accounts {
+			if account.UserName == "" {
+				// Empty slots of services with a fixed number of accounts
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\n",
+				account.ID, account.UserName, account.RoleID, account.Enabled, account.Locked)
+		}
+		return w.Flush()
+	case "add":
+		args, err := parseFlags(flags, args[1:], 2, "[-role ID] <name> <password>")
+		if err != nil {
+			return err
+		}
+
+		password, err := c.password(args[1])
+		if err != nil {
+			return err
+		}
+
+		return accountService.CreateAccount(args[0], password, *role)
+	case "delete":
+		args, err := parseFlags(flags, args[1:], 1, "<name>")
+		if err != nil {
+			return err
+		}
+
+		account, err := findAccount(accountService, args[0])
+		if err != nil {
+			return err
+		}
+
+		return c.client.Delete(account.ODataID)
+	case "passwd":
+		args, err := parseFlags(flags, args[1:], 2, "<name> <password>")
+		if err != nil {
+			return err
+		}
+
+		account, err := findAccount(accountService, args[0])
+		if err != nil {
+			return err
+		}
+
+		account.Password, err = c.password(args[1])
+		if err != nil {
+			return err
+		}
+
+		return account.Update()
+	}
+
+	return usage
+}
+
+// password gets a password argument, read from the standard input if it is
+// "-".
+func (c *cli) password(arg string) (string, error) {
+	if arg != "-" {
+		return arg, nil
+	}
+
+	return readPassword(c.in)
+}
+
+// findAccount finds an account by user name.
+func findAccount(accountService *redfish.AccountService, userName string) (*redfish.ManagerAccount, error) {
+	accounts, err := accountService.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.UserName == userName {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user %s not found", userName)
+}
@@ -0,0 +1,153 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// allowableValuesSuffix is the suffix of the action properties that list the
+// values a service allows for a parameter.
+const allowableValuesSuffix = "@Redfish.AllowableValues"
+
+// Action is an action a resource advertises in its Actions property.
+type Action struct {
+	// Name is the name of the action without the leading '#', such as
+	// "ComputerSystem.Reset" or "Contoso.ClearCache".
+	Name string
+	// Target is the URI to POST to in order to invoke the action.
+	Target string
+	// ActionInfo is the URI of the ActionInfo resource describing the
+	// parameters of the action, if the service provides one.
+	ActionInfo string
+	// AllowableValues holds the values the service allows for the
+	// parameters of the action, keyed by parameter name.
+	AllowableValues map[string][]string
+	// OEM tells whether the action is a vendor action, advertised under
+	// Actions/Oem.
+	OEM bool
+}
+
+// ParseActions gets the actions advertised in the Actions property of the
+// raw JSON of a resource, including the vendor actions under Actions/Oem,
+// keyed by name without the leading '#'. Values that are not action objects
+// are ignored.
+func ParseActions(b []byte) map[string]Action {
+	var t struct {
+		Actions map[string]json.RawMessage
+	}
+
+	if json.Unmarshal(b, &t) != nil || len(t.Actions) == 0 {
+		return nil
+	}
+
+	actions := make(map[string]Action)
+	parseActions(t.Actions, false, actions)
+
+	var oem map[string]json.RawMessage
+	if json.Unmarshal(t.Actions["Oem"], &oem) == nil {
+		parseActions(oem, true, actions)
+		// Vendors also nest their actions under their own name
+		for vendor, data := range oem {
+			if strings.HasPrefix(vendor, "#") {
+				continue
+			}
+			var vendorActions map[string]json.RawMessage
+			if json.Unmarshal(data, &vendorActions) == nil {
+				parseActions(vendorActions, true, actions)
+			}
+		}
+	}
+
+	return actions
+}
+
+// parseActions adds the action objects of an Actions or Oem object.
+func parseActions(properties map[string]json.RawMessage, oem bool, actions map[string]Action) {
+	for key, data := range properties {
+		if !strings.HasPrefix(key, "#") {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) != nil {
+			continue
+		}
+
+		action := Action{
+			Name: strings.TrimPrefix(key, "#"),
+			OEM:  oem,
+		}
+		_ = json.Unmarshal(fields["target"], &action.Target)
+		_ = json.Unmarshal(fields["@Redfish.ActionInfo"], &action.ActionInfo)
+
+		for property, value := range fields {
+			if !strings.HasSuffix(property, allowableValuesSuffix) {
+				continue
+			}
+			var values []string
+			if json.Unmarshal(value, &values) != nil {
+				continue
+			}
+			if action.AllowableValues == nil {
+				action.AllowableValues = make(map[string][]string)
+			}
+			action.AllowableValues[strings.TrimSuffix(property, allowableValuesSuffix)] = values
+		}
+
+		actions[action.Name] = action
+	}
+}
+
+// SetActions sets the actions the entity advertises. It is called when
+// resources are unmarshaled, with the result of ParseActions.
+func (e *Entity) SetActions(actions map[string]Action) {
+	e.actions = actions
+}
+
+// Actions gets the actions the entity advertises, keyed by name without the
+// leading '#'.
+func (e *Entity) Actions() map[string]Action {
+	result := make(map[string]Action, len(e.actions))
+	for name, action := range e.actions {
+		result[name] = action
+	}
+
+	return result
+}
+
+// ActionNames gets the names of the actions the entity advertises, sorted.
+func (e *Entity) ActionNames() []string {
+	var result []string
+	for name := range e.actions {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// InvokeAction invokes an action the entity advertises, including actions
+// this library has no method for, by posting the payload to its target. The
+// name is the name of the action with or without the leading '#', such as
+// "ComputerSystem.Reset". The caller is responsible for closing the body of
+// the response.
+func (e *Entity) InvokeAction(name string, payload interface{}) (*http.Response, error) {
+	action, ok := e.actions[strings.TrimPrefix(name, "#")]
+	if !ok || action.Target == "" {
+		return nil, fmt.Errorf("%s action is not supported by this resource", name)
+	}
+
+	if payload == nil {
+		// Services expect an object, even for actions without parameters
+		payload = struct{}{}
+	}
+
+	return e.Client.Post(action.Target, payload)
+}
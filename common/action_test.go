@@ -0,0 +1,77 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"testing"
+)
+
+var actionsBody = `{
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Actions": {
+			"#Manager.Reset": {
+				"target": "/redfish/v1/Managers/1/Actions/Manager.Reset",
+				"ResetType@Redfish.AllowableValues": [
+					"ForceRestart",
+					"GracefulRestart"
+				]
+			},
+			"Oem": {
+				"#Contoso.Rotate": {
+					"target": "/redfish/v1/Managers/1/Actions/Oem/Contoso.Rotate"
+				},
+				"Huawei": {
+					"#Manager.RestoreFactory": {
+						"target": "/redfish/v1/Managers/1/Actions/Oem/Huawei/Manager.RestoreFactory"
+					}
+				}
+			}
+		}
+	}`
+
+// TestParseActions tests the parsing of the Actions property.
+func TestParseActions(t *testing.T) {
+	actions := ParseActions([]byte(actionsBody))
+
+	if len(actions) != 3 {
+		t.Errorf("Expected 3 actions, got: %v", actions)
+	}
+
+	reset := actions["Manager.Reset"]
+	if reset.OEM || reset.Target != "/redfish/v1/Managers/1/Actions/Manager.Reset" ||
+		len(reset.AllowableValues["ResetType"]) != 2 {
+		t.Errorf("Invalid reset action: %+v", reset)
+	}
+
+	if !actions["Contoso.Rotate"].OEM {
+		t.Errorf("Invalid OEM action: %+v", actions["Contoso.Rotate"])
+	}
+
+	if actions["Manager.RestoreFactory"].Target != "/redfish/v1/Managers/1/Actions/Oem/Huawei/Manager.RestoreFactory" {
+		t.Errorf("Invalid vendor action: %+v", actions["Manager.RestoreFactory"])
+	}
+
+	if ParseActions([]byte(`{"Id": "1"}`)) != nil {
+		t.Error("Resources without actions should have none")
+	}
+}
+
+// TestInvokeAction tests invoking an action with the entity client.
+func TestInvokeAction(t *testing.T) {
+	testClient := &TestClient{}
+	entity := Entity{Client: testClient}
+	entity.SetActions(ParseActions([]byte(actionsBody)))
+
+	_, err := entity.InvokeAction("Manager.Reset", map[string]string{"ResetType": "GracefulRestart"})
+	if err != nil {
+		t.Errorf("Error invoking action: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Managers/1/Actions/Manager.Reset" ||
+		calls[0].Payload != "map[ResetType:GracefulRestart]" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
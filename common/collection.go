@@ -8,10 +8,72 @@ import (
 	"encoding/json"
 )
 
+// CapabilitiesUseCase is the use case a collection capabilities object
+// describes.
+type CapabilitiesUseCase string
+
+const (
+	// ComputerSystemCompositionCapabilitiesUseCase shall indicate the
+	// capabilities object describes the POST request to compose a computer
+	// system from resource blocks.
+	ComputerSystemCompositionCapabilitiesUseCase CapabilitiesUseCase = "ComputerSystemComposition"
+	// ComputerSystemConstrainedCompositionCapabilitiesUseCase shall indicate
+	// the capabilities object describes the POST request to compose a
+	// computer system from a set of constraints.
+	ComputerSystemConstrainedCompositionCapabilitiesUseCase CapabilitiesUseCase = "ComputerSystemConstrainedComposition"
+	// VolumeCreationCapabilitiesUseCase shall indicate the capabilities
+	// object describes the POST request to create a volume.
+	VolumeCreationCapabilitiesUseCase CapabilitiesUseCase = "VolumeCreation"
+)
+
+// CollectionCapability shall describe a capabilities object for creating
+// members of a collection.
+type CollectionCapability struct {
+	// CapabilitiesObject shall be the URI of the resource that describes the
+	// properties allowed in a POST request to the target collection.
+	CapabilitiesObject string
+	// TargetCollection shall be the URI of the collection the capabilities
+	// object applies to.
+	TargetCollection string
+	// UseCase shall be the use case the capabilities object describes.
+	UseCase CapabilitiesUseCase
+}
+
+// UnmarshalJSON unmarshals a CollectionCapability object from the raw JSON.
+func (capability *CollectionCapability) UnmarshalJSON(b []byte) error {
+	var t struct {
+		CapabilitiesObject Link
+		Links              struct {
+			TargetCollection Link
+		}
+		UseCase CapabilitiesUseCase
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	capability.CapabilitiesObject = string(t.CapabilitiesObject)
+	capability.TargetCollection = string(t.Links.TargetCollection)
+	capability.UseCase = t.UseCase
+
+	return nil
+}
+
 // Collection represents a collection of entity references.
 type Collection struct {
 	Name      string `json:"Name"`
 	ItemLinks []string
+	// Capabilities are the capabilities objects the service advertises for
+	// creating members of this collection.
+	Capabilities []CollectionCapability
+	// expanded holds the JSON of the members the service returned inline,
+	// because of $expand or aggregator behavior, keyed by their link.
+	expanded map[string]json.RawMessage
+	// nextLink is the link to the next page of the collection, if the
+	// service split it in pages and the next ones were not read.
+	nextLink string
 }
 
 // UnmarshalJSON unmarshals a collection from the raw JSON.
@@ -20,7 +82,11 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		LinksCollection
-		Links LinksCollection `json:"Links"`
+		Links                  LinksCollection `json:"Links"`
+		CollectionCapabilities struct {
+			Capabilities []CollectionCapability
+		} `json:"@Redfish.CollectionCapabilities"`
+		NextLink string `json:"Members@odata.nextLink"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -28,21 +94,128 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	// The members themselves, which may have been expanded
+	var members struct {
+		Links struct {
+			Members []json.RawMessage
+		}
+		Members []json.RawMessage
+	}
+	err = json.Unmarshal(b, &members)
+	if err != nil {
+		return err
+	}
+
 	*c = Collection(t.temp)
+	c.Capabilities = t.CollectionCapabilities.Capabilities
+	c.nextLink = t.NextLink
 
 	// Redfish objects store collection items under Links
 	c.ItemLinks = t.Links.ToStrings()
+	c.addExpanded(members.Links.Members)
 
 	// Swordfish has them at the root
 	if len(c.ItemLinks) == 0 && t.Count > 0 {
 		c.ItemLinks = t.Members.ToStrings()
+		c.addExpanded(members.Members)
 	}
 
 	return nil
 }
 
-// GetCollection retrieves a collection from the service.
+// addExpanded keeps the JSON of the members that have more properties than
+// their @odata.id.
+func (c *Collection) addExpanded(members []json.RawMessage) {
+	for _, member := range members {
+		var properties map[string]json.RawMessage
+		if json.Unmarshal(member, &properties) != nil || len(properties) < 2 {
+			continue
+		}
+
+		var link Link
+		if json.Unmarshal(member, &link) != nil || link == "" {
+			continue
+		}
+
+		if c.expanded == nil {
+			c.expanded = make(map[string]json.RawMessage)
+		}
+		c.expanded[string(link)] = member
+	}
+}
+
+// Expanded gets the JSON of a member the service returned inline, or nil if
+// the member has to be read from its link.
+func (c *Collection) Expanded(link string) json.RawMessage {
+	return c.expanded[link]
+}
+
+// DecodeMember decodes a member the service returned inline into v, without
+// reading it from the service, and sets its client. It returns false if the
+// member was not expanded, in which case it has to be read from its link.
+func (c *Collection) DecodeMember(client Client, link string, v interface{ SetClient(c Client) }) (bool, error) {
+	member := c.Expanded(link)
+	if member == nil {
+		return false, nil
+	}
+
+	err := json.Unmarshal(member, v)
+	if err != nil {
+		return true, err
+	}
+
+	setEntityMetadata(v, member, "")
+	v.SetClient(client)
+	return true, nil
+}
+
+// GetCollection retrieves a collection from the service. Collections the
+// service splits in pages are read whole, by following their
+// Members@odata.nextLink.
 func GetCollection(c Client, uri string) (*Collection, error) {
+	result, err := getCollectionPage(c, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	err = result.ReadNextPages(c, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReadNextPages reads the pages that follow a collection decoded from its
+// first page at uri, such as one read along with other properties, if the
+// service split the collection in pages. Their members are added to the
+// collection.
+func (collection *Collection) ReadNextPages(c Client, uri string) error {
+	seen := map[string]bool{uri: true}
+	for collection.nextLink != "" && !seen[collection.nextLink] {
+		seen[collection.nextLink] = true
+
+		page, err := getCollectionPage(c, collection.nextLink)
+		if err != nil {
+			return err
+		}
+
+		collection.ItemLinks = append(collection.ItemLinks, page.ItemLinks...)
+		for link, member := range page.expanded {
+			if collection.expanded == nil {
+				collection.expanded = make(map[string]json.RawMessage)
+			}
+			collection.expanded[link] = member
+		}
+		collection.nextLink = page.nextLink
+	}
+	collection.nextLink = ""
+
+	return nil
+}
+
+// getCollectionPage reads one page of a collection.
+func getCollectionPage(c Client, uri string) (*Collection, error) {
 	resp, err := c.Get(uri)
 	if err != nil {
 		return nil, err
@@ -54,5 +227,6 @@ func GetCollection(c Client, uri string) (*Collection, error) {
 	if err != nil {
 		return nil, err
 	}
+
 	return &result, nil
 }
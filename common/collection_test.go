@@ -55,3 +55,97 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+// TestCollectionCapabilities tests the parsing of collection capabilities.
+func TestCollectionCapabilities(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes",
+		"Name": "Volumes",
+		"Members": [],
+		"Members@odata.count": 0,
+		"@Redfish.CollectionCapabilities": {
+			"@odata.type": "#CollectionCapabilities.v1_2_0.CollectionCapabilities",
+			"Capabilities": [{
+				"CapabilitiesObject": {
+					"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes/Capabilities"
+				},
+				"Links": {
+					"TargetCollection": {
+						"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes"
+					}
+				},
+				"UseCase": "VolumeCreation"
+			}]
+		}
+	}`
+
+	var result Collection
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.Capabilities) != 1 {
+		t.Errorf("Expected 1 capability, got %d", len(result.Capabilities))
+	}
+
+	capability := result.Capabilities[0]
+	if capability.CapabilitiesObject != "/redfish/v1/Systems/1/Storage/1/Volumes/Capabilities" {
+		t.Errorf("Invalid CapabilitiesObject: %s", capability.CapabilitiesObject)
+	}
+
+	if capability.TargetCollection != "/redfish/v1/Systems/1/Storage/1/Volumes" {
+		t.Errorf("Invalid TargetCollection: %s", capability.TargetCollection)
+	}
+
+	if capability.UseCase != VolumeCreationCapabilitiesUseCase {
+		t.Errorf("Invalid UseCase: %s", capability.UseCase)
+	}
+}
+
+// TestCollectionExpandedMembers tests keeping the members a service returned
+// inline.
+func TestCollectionExpandedMembers(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries",
+		"Name": "Log Entries",
+		"Members@odata.count": 2,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries/1",
+				"Id": "1",
+				"Name": "Log Entry 1"
+			},
+			{
+				"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries/2"
+			}
+		]
+	}`
+
+	var result Collection
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.ItemLinks) != 2 {
+		t.Errorf("Expected 2 items in collection, got %d", len(result.ItemLinks))
+	}
+
+	if result.Expanded("/redfish/v1/Managers/1/LogServices/SEL/Entries/2") != nil {
+		t.Error("Members with only a link should not be expanded")
+	}
+
+	testClient := &TestClient{}
+	var entity Entity
+	expanded, err := result.DecodeMember(testClient, "/redfish/v1/Managers/1/LogServices/SEL/Entries/1", &entity)
+	if err != nil || !expanded {
+		t.Fatalf("The first member should be expanded: %v", err)
+	}
+
+	if entity.Name != "Log Entry 1" || entity.Client != testClient {
+		t.Errorf("Invalid expanded member: %v", entity)
+	}
+}
@@ -0,0 +1,89 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// dateTimeLayouts are the layouts DateTime values are parsed with. Services
+// should use RFC 3339, but some send offsets without a colon, no offset at
+// all, a space instead of the 'T' or only a date.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// DateTime is a Redfish date-time property, such as the creation time of a
+// log entry. The various offset formats services send are accepted; values
+// without an offset are read as UTC. Values that can not be parsed, as well
+// as null and empty strings, are read as the zero time.
+type DateTime struct {
+	time.Time
+}
+
+// ParseDateTime parses a Redfish date-time value.
+func ParseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	var err error
+	for _, layout := range dateTimeLayouts {
+		var result time.Time
+		result, err = time.Parse(layout, value)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// UnmarshalJSON unmarshals a DateTime from the raw JSON.
+func (dateTime *DateTime) UnmarshalJSON(b []byte) error {
+	var value *string
+	err := json.Unmarshal(b, &value)
+	if err != nil {
+		return err
+	}
+
+	*dateTime = DateTime{}
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	// Tolerate what can not be parsed rather than failing the resource
+	dateTime.Time, _ = ParseDateTime(*value)
+
+	return nil
+}
+
+// MarshalJSON marshals a DateTime in the RFC 3339 format, or as null if it is
+// the zero time.
+func (dateTime DateTime) MarshalJSON() ([]byte, error) {
+	if dateTime.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(dateTime.Format(time.RFC3339Nano))
+}
+
+// String gets the date-time in the RFC 3339 format, or an empty string if it
+// is the zero time.
+func (dateTime DateTime) String() string {
+	if dateTime.IsZero() {
+		return ""
+	}
+
+	return dateTime.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,63 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestDateTime tests parsing the date-time formats services send.
+func TestDateTime(t *testing.T) {
+	expected := time.Date(2021, 2, 13, 4, 5, 26, 0, time.UTC)
+	values := []string{
+		`"2021-02-13T04:05:26Z"`,
+		`"2021-02-13T06:05:26+02:00"`,
+		`"2021-02-13T06:05:26+0200"`,
+		`"2021-02-13T04:05:26"`,
+		`"2021-02-13 04:05:26"`,
+		`"2021-02-13T04:05:26.000Z"`,
+	}
+
+	for _, value := range values {
+		var result DateTime
+		err := json.Unmarshal([]byte(value), &result)
+		if err != nil {
+			t.Errorf("Error decoding %s: %s", value, err)
+		}
+
+		if !result.Equal(expected) {
+			t.Errorf("Invalid date-time for %s: %s", value, result)
+		}
+	}
+
+	minutes, err := ParseDateTime("2012-03-07T14:44+06:00")
+	if err != nil || !minutes.Equal(time.Date(2012, 3, 7, 8, 44, 0, 0, time.UTC)) {
+		t.Errorf("Invalid date-time without seconds: %s %v", minutes, err)
+	}
+
+	for _, value := range []string{`null`, `""`, `"unknown"`} {
+		var result DateTime
+		err := json.Unmarshal([]byte(value), &result)
+		if err != nil || !result.IsZero() {
+			t.Errorf("Expected the zero time for %s: %s %v", value, result, err)
+		}
+	}
+}
+
+// TestDateTimeMarshal tests marshaling date-times.
+func TestDateTimeMarshal(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Created  DateTime
+		Modified DateTime
+	}{
+		Created: DateTime{time.Date(2021, 2, 13, 4, 5, 26, 0, time.UTC)},
+	})
+
+	if err != nil || string(data) != `{"Created":"2021-02-13T04:05:26Z","Modified":null}` {
+		t.Errorf("Unexpected JSON: %s %v", data, err)
+	}
+}
@@ -0,0 +1,111 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DeepClient is a client serving the resources of a deep GET, such as a
+// $expand with $levels, from the document the service returned. Each
+// resource expanded in the document, at any depth, is served on its own as
+// though it was got from the service; the other requests are sent to the
+// service. The Get functions and link accessors of the resources thus work
+// on the subtree without further requests.
+type DeepClient struct {
+	Client
+	// resources are the expanded resources, keyed by URI.
+	resources map[string]json.RawMessage
+}
+
+// NewDeepClient makes a DeepClient serving the resources expanded in
+// document, sending the other requests with c. An empty document serves no
+// resource.
+func NewDeepClient(c Client, document []byte) (*DeepClient, error) {
+	client := &DeepClient{Client: c, resources: make(map[string]json.RawMessage)}
+	if len(document) == 0 {
+		return client, nil
+	}
+
+	err := client.add(document)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// add keeps the resources expanded in a JSON value.
+func (c *DeepClient) add(value json.RawMessage) error {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 {
+		return nil
+	}
+
+	switch value[0] {
+	case '[':
+		var items []json.RawMessage
+		err := json.Unmarshal(value, &items)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err = c.add(item)
+			if err != nil {
+				return err
+			}
+		}
+	case '{':
+		var properties map[string]json.RawMessage
+		err := json.Unmarshal(value, &properties)
+		if err != nil {
+			return err
+		}
+
+		var uri string
+		_ = json.Unmarshal(properties["@odata.id"], &uri)
+		// Links only have their @odata.id, and the objects of an array
+		// have a fragment such as Power#/PowerSupplies/0
+		if uri != "" && len(properties) > 1 && !strings.Contains(uri, "#") {
+			uri = strings.TrimSuffix(uri, "/")
+			if len(value) > len(c.resources[uri]) {
+				c.resources[uri] = value
+			}
+		}
+
+		for _, property := range properties {
+			err = c.add(property)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Has tells whether the resource at uri is served from the document.
+func (c *DeepClient) Has(uri string) bool {
+	_, ok := c.resources[strings.TrimSuffix(uri, "/")]
+	return ok
+}
+
+// Get gets the resource at uri from the document if it was expanded in it,
+// or from the service.
+func (c *DeepClient) Get(uri string) (*http.Response, error) {
+	resource, ok := c.resources[strings.TrimSuffix(uri, "/")]
+	if !ok {
+		return c.Client.Get(uri)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(resource)),
+	}, nil
+}
@@ -0,0 +1,64 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+var deepBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerSupplies": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0", "Name": "PSU1"}
+		],
+		"Links": {
+			"Chassis": [{"@odata.id": "/redfish/v1/Chassis/1"}]
+		},
+		"Sensors": {
+			"@odata.id": "/redfish/v1/Chassis/1/Sensors/",
+			"Members": [{"@odata.id": "/redfish/v1/Chassis/1/Sensors/PSU1", "Reading": 230}]
+		}
+	}`
+
+// TestDeepClient tests serving the resources of a deep GET.
+func TestDeepClient(t *testing.T) {
+	testClient := &TestClient{}
+	client, err := NewDeepClient(testClient, []byte(deepBody))
+	if err != nil {
+		t.Fatalf("Error decoding deep GET: %s", err)
+	}
+
+	for uri, expected := range map[string]bool{
+		"/redfish/v1/Chassis/1/Power":                  true,
+		"/redfish/v1/Chassis/1/Sensors":                true,
+		"/redfish/v1/Chassis/1/Sensors/PSU1":           true,
+		"/redfish/v1/Chassis/1":                        false,
+		"/redfish/v1/Chassis/1/Power#/PowerSupplies/0": false,
+	} {
+		if client.Has(uri) != expected {
+			t.Errorf("Unexpected serving of %s: %t", uri, !expected)
+		}
+	}
+
+	resp, err := client.Get("/redfish/v1/Chassis/1/Sensors/PSU1")
+	if err != nil {
+		t.Fatalf("Error getting sensor: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != `{"@odata.id": "/redfish/v1/Chassis/1/Sensors/PSU1", "Reading": 230}` {
+		t.Errorf("Unexpected sensor: %s", body)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Expanded resources should not be got from the service: %v", testClient.CapturedCalls())
+	}
+
+	_, _ = client.Get("/redfish/v1/Chassis/1")
+	if calls := testClient.CapturedCalls(); len(calls) != 1 || calls[0].URL != "/redfish/v1/Chassis/1" {
+		t.Errorf("Other resources should be got from the service: %v", calls)
+	}
+}
@@ -0,0 +1,113 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationPattern matches ISO 8601 durations, such as P3Y6M4DT12H30M5S or
+// PT1M30.5S.
+var durationPattern = regexp.MustCompile(`^(-)?P(?:(\d+(?:\.\d+)?)Y)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// durationUnits are the lengths of the units of the duration pattern groups.
+// Years and months have no fixed length, they are counted as 365 and 30 days.
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// Duration is a Redfish duration property, an ISO 8601 duration such as
+// PT1M30S. It keeps the text the service sent; Parse gets its length.
+type Duration string
+
+// NewDuration formats a length of time as a Duration, such as PT1M30S, or
+// P2DT4H for lengths of a day or more.
+func NewDuration(d time.Duration) Duration {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var result strings.Builder
+	if d < 0 {
+		result.WriteString("-")
+		d = -d
+	}
+	result.WriteString("P")
+
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&result, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+
+	if d > 0 {
+		result.WriteString("T")
+		if hours := d / time.Hour; hours > 0 {
+			fmt.Fprintf(&result, "%dH", hours)
+			d -= hours * time.Hour
+		}
+		if minutes := d / time.Minute; minutes > 0 {
+			fmt.Fprintf(&result, "%dM", minutes)
+			d -= minutes * time.Minute
+		}
+		if d > 0 {
+			result.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+
+	return Duration(result.String())
+}
+
+// ParseDuration parses an ISO 8601 duration. Years and months are counted as
+// 365 and 30 days.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %s", value)
+	}
+
+	var seconds float64
+	for i, unit := range durationUnits {
+		if match[i+2] == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(match[i+2], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration: %s", value)
+		}
+		seconds += amount * unit.Seconds()
+	}
+
+	if seconds > math.MaxInt64/float64(time.Second) {
+		return 0, fmt.Errorf("ISO 8601 duration is too long: %s", value)
+	}
+
+	result := time.Duration(math.Round(seconds * float64(time.Second)))
+	if match[1] == "-" {
+		result = -result
+	}
+
+	return result, nil
+}
+
+// Parse gets the length of the duration. An empty duration is zero.
+func (d Duration) Parse() (time.Duration, error) {
+	if d == "" {
+		return 0, nil
+	}
+
+	return ParseDuration(string(d))
+}
@@ -0,0 +1,65 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseDuration tests parsing ISO 8601 durations.
+func TestParseDuration(t *testing.T) {
+	values := map[string]time.Duration{
+		"PT1M30S":        90 * time.Second,
+		"PT0.5S":         500 * time.Millisecond,
+		"P0Y0M0DT0H0M5S": 5 * time.Second,
+		"P1DT12H":        36 * time.Hour,
+		"P2W":            14 * 24 * time.Hour,
+		"P1Y2M":          (365 + 60) * 24 * time.Hour,
+		"-PT10M":         -10 * time.Minute,
+	}
+
+	for value, expected := range values {
+		result, err := ParseDuration(value)
+		if err != nil || result != expected {
+			t.Errorf("Invalid duration for %s: %s %v", value, result, err)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "5S", "PT1H1D", "ISO8601Duration"} {
+		if _, err := ParseDuration(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+
+	result, err := Duration("").Parse()
+	if err != nil || result != 0 {
+		t.Errorf("An empty duration should be zero: %s %v", result, err)
+	}
+}
+
+// TestNewDuration tests formatting ISO 8601 durations.
+func TestNewDuration(t *testing.T) {
+	values := map[time.Duration]Duration{
+		0:                            "PT0S",
+		90 * time.Second:             "PT1M30S",
+		1500 * time.Millisecond:      "PT1.5S",
+		26*time.Hour + 5*time.Minute: "P1DT2H5M",
+		-(2 * time.Hour):             "-PT2H",
+		48 * time.Hour:               "P2D",
+	}
+
+	for value, expected := range values {
+		result := NewDuration(value)
+		if result != expected {
+			t.Errorf("Invalid duration for %s: %s", value, result)
+		}
+
+		parsed, err := result.Parse()
+		if err != nil || parsed != value {
+			t.Errorf("The duration %s should parse back to %s: %s %v", result, value, parsed, err)
+		}
+	}
+}
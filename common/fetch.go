@@ -0,0 +1,89 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResourceError is returned when the service returns a resource that cannot
+// be decoded.
+type ResourceError struct {
+	// URI is the URI of the resource.
+	URI string
+	// Err is the decoding error.
+	Err error
+}
+
+// Error gets the message of the error.
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("invalid resource %s: %s", e.URI, e.Err)
+}
+
+// Unwrap gets the decoding error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// FetchResource gets the resource at uri from the service into v, such as a
+// *redfish.Chassis, and sets its client. Resources keep the JSON they are
+// read from in their UnmarshalJSON. The ETag of the resource, from the ETag
+// header or its @odata.etag, and its schema version are kept on entities, see
+// Entity.ETag and Entity.SchemaVersion.
+//
+// It returns the error of the client if the request fails, and a
+// *ResourceError if the resource cannot be decoded.
+func FetchResource(c Client, uri string, v interface{ SetClient(Client) }) error {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return DecodeResource(c, resp, uri, v)
+}
+
+// DecodeResource decodes the resource in the body of resp, the response of a
+// request to uri, into v as FetchResource does. It is for the requests whose
+// response is needed besides the resource, such as the polls of a task
+// monitor. The body is decoded in a single pass; closing it is left to the
+// caller.
+func DecodeResource(c Client, resp *http.Response, uri string, v interface{ SetClient(Client) }) error {
+	var body bytes.Buffer
+	err := json.NewDecoder(io.TeeReader(resp.Body, &body)).Decode(v)
+	if err != nil {
+		return &ResourceError{URI: uri, Err: err}
+	}
+
+	setEntityMetadata(v, body.Bytes(), resp.Header.Get("ETag"))
+	v.SetClient(c)
+	return nil
+}
+
+// setEntityMetadata keeps the ETag and @odata.type of the JSON an entity was
+// decoded from, the ETag header of the response taking precedence over the
+// @odata.etag.
+func setEntityMetadata(v interface{}, b []byte, etag string) {
+	entity, ok := v.(interface{ setMetadata(etag, odataType string) })
+	if !ok {
+		return
+	}
+
+	var t struct {
+		ODataEtag string `json:"@odata.etag"`
+		ODataType string `json:"@odata.type"`
+	}
+	// Annotations that are not strings are left empty
+	_ = json.Unmarshal(b, &t)
+
+	if etag == "" {
+		etag = t.ODataEtag
+	}
+	entity.setMetadata(etag, t.ODataType)
+}
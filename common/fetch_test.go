@@ -0,0 +1,86 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fetchClient is a test client serving a single resource with its headers.
+type fetchClient struct {
+	TestClient
+	header http.Header
+	body   string
+}
+
+// Get records the call and returns the resource.
+func (c *fetchClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     c.header,
+		Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+// fetchResource is a resource got in the tests.
+type fetchResource struct {
+	Entity
+	Size int
+}
+
+// TestFetchResource tests getting a resource and its ETag.
+func TestFetchResource(t *testing.T) {
+	client := &fetchClient{
+		header: http.Header{"Etag": []string{`W/"header"`}},
+		body: `{"@odata.id": "/redfish/v1/Systems/1", "@odata.etag": "W/\"body\"",
+			"@odata.type": "#ComputerSystem.v1_20_0.ComputerSystem", "Id": "1", "Size": 4}`,
+	}
+
+	var resource fetchResource
+	err := FetchResource(client, "/redfish/v1/Systems/1", &resource)
+	if err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	if resource.ID != "1" || resource.Size != 4 || resource.Client != client {
+		t.Errorf("Invalid resource: %v", resource)
+	}
+
+	if resource.ETag() != `W/"header"` {
+		t.Errorf("The ETag header should be kept: %s", resource.ETag())
+	}
+
+	if version, ok := resource.SchemaVersion(); !ok || version.String() != "1.20.0" {
+		t.Errorf("The schema version should be kept: %v %t", version, ok)
+	}
+
+	client.header = nil
+	err = FetchResource(client, "/redfish/v1/Systems/1", &resource)
+	if err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	if resource.ETag() != `W/"body"` {
+		t.Errorf("The @odata.etag should be kept without an ETag header: %s", resource.ETag())
+	}
+}
+
+// TestFetchResourceInvalid tests getting a resource that cannot be decoded.
+func TestFetchResourceInvalid(t *testing.T) {
+	client := &fetchClient{body: `{"Size": "large"}`}
+
+	var resource fetchResource
+	err := FetchResource(client, "/redfish/v1/Systems/1", &resource)
+
+	var resourceError *ResourceError
+	if !errors.As(err, &resourceError) || resourceError.URI != "/redfish/v1/Systems/1" {
+		t.Errorf("Invalid error: %v", err)
+	}
+}
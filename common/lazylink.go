@@ -0,0 +1,134 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// LazyLink is a link to a resource of type T, such as a Bios, decoded from
+// its {"@odata.id": ...} reference. The resource is got from the service on
+// first access and kept, so accessors of linked resources do not have to
+// keep the URI in a string and get it on every call.
+//
+// The kept resource goes stale when it changes on the service, so LazyLink is
+// for the links to resources that do not change under their caller, such as
+// the chassis a manager is located in. The accessors of resources that can be
+// updated keep getting them on every call, and the existing accessors keep
+// their string links until they are revisited one by one; Refresh and
+// Invalidate are there for callers that know a kept resource changed.
+//
+// Copies of a LazyLink share the kept resource.
+type LazyLink[T any] struct {
+	uri      string
+	resource *lazyResource[T]
+}
+
+// lazyResource is the resource a LazyLink got.
+type lazyResource[T any] struct {
+	lock     sync.Mutex
+	resource *T
+}
+
+// NewLazyLink makes a LazyLink to the resource at uri.
+func NewLazyLink[T any](uri string) LazyLink[T] {
+	return LazyLink[T]{uri: uri, resource: &lazyResource[T]{}}
+}
+
+// UnmarshalJSON unmarshals a LazyLink from its reference.
+func (link *LazyLink[T]) UnmarshalJSON(b []byte) error {
+	var t Link
+	err := t.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	*link = NewLazyLink[T](string(t))
+	return nil
+}
+
+// MarshalJSON marshals a LazyLink as its reference.
+func (link LazyLink[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ODataID string `json:"@odata.id"`
+	}{ODataID: link.uri})
+}
+
+// URI gets the URI of the linked resource, or an empty string if there is no
+// link.
+func (link LazyLink[T]) URI() string {
+	return link.uri
+}
+
+// Get gets the linked resource, from the service on first call. It returns
+// nil if there is no link.
+func (link LazyLink[T]) Get(c Client) (*T, error) {
+	if link.uri == "" {
+		return nil, nil
+	}
+	if link.resource == nil {
+		return fetchLinkedResource[T](c, link.uri)
+	}
+
+	link.resource.lock.Lock()
+	defer link.resource.lock.Unlock()
+	if link.resource.resource != nil {
+		return link.resource.resource, nil
+	}
+
+	resource, err := fetchLinkedResource[T](c, link.uri)
+	if err != nil {
+		return nil, err
+	}
+	link.resource.resource = resource
+
+	return resource, nil
+}
+
+// Refresh gets the linked resource from the service again, and keeps it for
+// the next calls to Get.
+func (link LazyLink[T]) Refresh(c Client) (*T, error) {
+	link.Invalidate()
+	return link.Get(c)
+}
+
+// Invalidate drops the kept resource, so it is got from the service again on
+// the next call to Get.
+func (link LazyLink[T]) Invalidate() {
+	if link.resource == nil {
+		return
+	}
+
+	link.resource.lock.Lock()
+	link.resource.resource = nil
+	link.resource.lock.Unlock()
+}
+
+// fetchLinkedResource gets a resource from the service, with FetchResource
+// if it is an entity.
+func fetchLinkedResource[T any](c Client, uri string) (*T, error) {
+	resource := new(T)
+	if entity, ok := interface{}(resource).(interface{ SetClient(Client) }); ok {
+		err := FetchResource(c, uri, entity)
+		if err != nil {
+			return nil, err
+		}
+		return resource, nil
+	}
+
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
@@ -0,0 +1,84 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// lazyLinkClient is a test client serving a single resource.
+type lazyLinkClient struct {
+	TestClient
+	body string
+}
+
+// Get records the call and returns the resource.
+func (c *lazyLinkClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+// lazyLinkResource is a resource linked to in the tests.
+type lazyLinkResource struct {
+	Entity
+	Size int
+}
+
+// TestLazyLink tests getting a linked resource once.
+func TestLazyLink(t *testing.T) {
+	var result struct {
+		Bios LazyLink[lazyLinkResource]
+		None LazyLink[lazyLinkResource]
+	}
+	err := json.Unmarshal([]byte(`{"Bios": {"@odata.id": "/redfish/v1/Systems/1/Bios"}}`), &result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.Bios.URI() != "/redfish/v1/Systems/1/Bios" {
+		t.Errorf("Invalid link: %s", result.Bios.URI())
+	}
+
+	client := &lazyLinkClient{body: `{"@odata.id": "/redfish/v1/Systems/1/Bios", "Id": "Bios", "Size": 4}`}
+	for i := 0; i < 2; i++ {
+		resource, err := result.Bios.Get(client)
+		if err != nil {
+			t.Fatalf("Error getting linked resource: %s", err)
+		}
+		if resource.ID != "Bios" || resource.Size != 4 || resource.Client != client {
+			t.Errorf("Invalid linked resource: %v", resource)
+		}
+	}
+
+	if calls := client.CapturedCalls(); len(calls) != 1 {
+		t.Errorf("The linked resource should be got once: %v", calls)
+	}
+
+	// Copies share the resource
+	copied := result.Bios
+	copied.Invalidate()
+	client.body = `{"@odata.id": "/redfish/v1/Systems/1/Bios", "Id": "Bios", "Size": 8}`
+	resource, err := result.Bios.Get(client)
+	if err != nil || resource.Size != 8 {
+		t.Errorf("The linked resource should be got again once invalidated: %v %v", resource, err)
+	}
+
+	resource, err = result.None.Get(client)
+	if resource != nil || err != nil {
+		t.Errorf("A missing link should get nothing: %v %v", resource, err)
+	}
+
+	marshaled, err := json.Marshal(result.Bios)
+	if err != nil || string(marshaled) != `{"@odata.id":"/redfish/v1/Systems/1/Bios"}` {
+		t.Errorf("Invalid marshaled link: %s %v", marshaled, err)
+	}
+}
@@ -0,0 +1,117 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalWithRawData marshals a resource that was read from raw, merging the
+// current values of its fields over the raw JSON. Properties the struct does
+// not model, such as vendor extensions and links, are kept as they were read,
+// so serializing a fetched resource does not drop them. Only the properties
+// present in the raw JSON are written; if there is no raw JSON the resource is
+// marshaled as is.
+//
+// The value must not have a MarshalJSON method that calls
+// MarshalWithRawData, which is usually avoided by passing a conversion to a
+// local type with the same underlying type.
+func MarshalWithRawData(raw []byte, v interface{}) ([]byte, error) {
+	current, err := json.Marshal(v)
+	if err != nil || len(raw) == 0 {
+		return current, err
+	}
+
+	var rawValue, currentValue interface{}
+	if decodeJSON(raw, &rawValue) != nil {
+		// Do not fail on raw data that is not JSON, it is only kept
+		return current, nil
+	}
+	err = decodeJSON(current, &currentValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeRawJSON(rawValue, currentValue))
+}
+
+// decodeJSON decodes JSON keeping numbers as they are written.
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// mergeRawJSON merges a current JSON value over a raw one. Objects are merged
+// property by property and arrays of the same length item by item. Values of
+// a different kind are assumed to be modeled differently by the struct, such
+// as links kept as plain URIs, and the raw value is kept.
+func mergeRawJSON(raw, current interface{}) interface{} {
+	switch r := raw.(type) {
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return raw
+		}
+		for key, value := range r {
+			if currentValue, ok := c[key]; ok {
+				r[key] = mergeRawJSON(value, currentValue)
+			}
+		}
+		return r
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok {
+			return raw
+		}
+		if len(c) != len(r) {
+			return c
+		}
+		for i := range r {
+			r[i] = mergeRawJSON(r[i], c[i])
+		}
+		return r
+	case nil:
+		if isZeroJSON(current) {
+			return nil
+		}
+		return current
+	}
+
+	switch current.(type) {
+	case map[string]interface{}, []interface{}, nil:
+		return raw
+	}
+
+	return current
+}
+
+// isZeroJSON checks whether a JSON value is the marshaled zero value of a
+// field.
+func isZeroJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, property := range v {
+			if !isZeroJSON(property) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
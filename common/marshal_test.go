@@ -0,0 +1,125 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var marshalRawBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Name": "Chassis",
+		"AssetTag": "old",
+		"Description": null,
+		"PowerWatts": 120.5,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK",
+			"Oem": {
+				"Contoso": {
+					"Sealed": true
+				}
+			}
+		},
+		"Links": {
+			"ManagedBy": [
+				{
+					"@odata.id": "/redfish/v1/Managers/1"
+				}
+			]
+		},
+		"Sensors": [
+			{
+				"Name": "Inlet",
+				"Vendor": "Contoso"
+			}
+		],
+		"Oem": {
+			"Contoso": {
+				"RackHeight": 42
+			}
+		}
+	}`
+
+type marshalTestResource struct {
+	Entity
+	AssetTag    string
+	Description string
+	PowerWatts  float32
+	Status      Status
+	Links       Link
+	Sensors     []struct{ Name string }
+}
+
+// TestMarshalWithRawData tests merging fields over the raw JSON.
+func TestMarshalWithRawData(t *testing.T) {
+	var resource marshalTestResource
+	err := json.Unmarshal([]byte(marshalRawBody), &resource)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	resource.AssetTag = "new"
+	resource.Status.Health = WarningHealth
+	resource.Sensors[0].Name = "Outlet"
+
+	data, err := MarshalWithRawData([]byte(marshalRawBody), resource)
+	if err != nil {
+		t.Fatalf("Error marshaling: %s", err)
+	}
+
+	var result struct {
+		AssetTag    string
+		Description *string
+		PowerWatts  json.Number
+		Status      struct {
+			Health Health
+			Oem    map[string]interface{}
+		}
+		Links struct {
+			ManagedBy []Link
+		}
+		Sensors []map[string]string
+		Oem     map[string]interface{}
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		t.Fatalf("Error decoding marshaled JSON %s: %s", data, err)
+	}
+
+	if result.AssetTag != "new" || result.Status.Health != WarningHealth {
+		t.Errorf("Changed fields should be written: %s", data)
+	}
+
+	if result.Description != nil {
+		t.Errorf("Null properties should stay null: %s", data)
+	}
+
+	if result.PowerWatts != "120.5" {
+		t.Errorf("Numbers should be kept: %s", result.PowerWatts)
+	}
+
+	if result.Status.Oem["Contoso"] == nil || result.Oem["Contoso"] == nil {
+		t.Errorf("Properties that are not modeled should be kept: %s", data)
+	}
+
+	if len(result.Links.ManagedBy) != 1 || result.Links.ManagedBy[0] != "/redfish/v1/Managers/1" {
+		t.Errorf("Links should be kept as objects: %s", data)
+	}
+
+	if len(result.Sensors) != 1 || result.Sensors[0]["Name"] != "Outlet" || result.Sensors[0]["Vendor"] != "Contoso" {
+		t.Errorf("Array items should be merged: %s", data)
+	}
+}
+
+// TestMarshalWithRawDataNoRaw tests marshaling resources without raw JSON.
+func TestMarshalWithRawDataNoRaw(t *testing.T) {
+	data, err := MarshalWithRawData(nil, struct{ Name string }{Name: "test"})
+	if err != nil || string(data) != `{"Name":"test"}` {
+		t.Errorf("Unexpected JSON: %s %v", data, err)
+	}
+}
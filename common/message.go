@@ -6,6 +6,7 @@ package common
 
 import (
 	"encoding/json"
+	"strings"
 )
 
 // Message is This type shall define a Message as described in the
@@ -67,7 +68,11 @@ func ListReferencedMessages(c Client, link string) ([]*Message, error) {
 	}
 
 	for _, messageLink := range links.ItemLinks {
-		message, err := GetMessage(c, messageLink)
+		message := new(Message)
+		expanded, err := links.DecodeMember(c, messageLink, message)
+		if err == nil && !expanded {
+			message, err = GetMessage(c, messageLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -76,3 +81,34 @@ func ListReferencedMessages(c Client, link string) ([]*Message, error) {
 
 	return result, nil
 }
+
+// ParseMessages gets the messages of the @Message.ExtendedInfo of a response
+// body, at its root as in successful responses or in its error object as in
+// error responses.
+func ParseMessages(body []byte) []Message {
+	var t struct {
+		ExtendedInfo []Message `json:"@Message.ExtendedInfo"`
+		Error        struct {
+			ExtendedInfo []Message `json:"@Message.ExtendedInfo"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &t) != nil {
+		return nil
+	}
+
+	return append(t.ExtendedInfo, t.Error.ExtendedInfo...)
+}
+
+// FindMessage finds the message with key in messages, such as
+// "PasswordChangeRequired" for "Base.1.8.PasswordChangeRequired", whatever
+// the registry and its version. It returns nil if there is none.
+func FindMessage(messages []Message, key string) *Message {
+	for i := range messages {
+		id := messages[i].MessageID
+		if id == key || strings.HasSuffix(id, "."+key) {
+			return &messages[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OEMDecoder decodes the object a vendor adds to the Oem property of a
+// resource into a typed value.
+type OEMDecoder func(data json.RawMessage) (interface{}, error)
+
+var (
+	oemDecodersLock sync.RWMutex
+	// oemDecoders are the registered decoders, keyed by vendor and then by
+	// the normalized @odata.type of the vendor object. The empty type is the
+	// fallback for the vendor.
+	oemDecoders = map[string]map[string]OEMDecoder{}
+)
+
+// RegisterOEMDecoder registers a decoder for the objects a vendor adds to the
+// Oem property of resources. The vendor is the key of the object within Oem,
+// which is usually the manufacturer name, such as "Lenovo". If odataType is
+// set the decoder is only used for vendor objects of that type, with or
+// without the leading '#' and schema version; otherwise it is used for every
+// object of the vendor that has no more specific decoder. Registering a
+// decoder again replaces the previous one.
+//
+// Decoders are usually registered from the init function of an OEM package,
+// so that importing the package is enough to have its data decoded.
+func RegisterOEMDecoder(vendor, odataType string, decoder OEMDecoder) {
+	oemDecodersLock.Lock()
+	defer oemDecodersLock.Unlock()
+
+	if oemDecoders[vendor] == nil {
+		oemDecoders[vendor] = map[string]OEMDecoder{}
+	}
+	oemDecoders[vendor][normalizeODataType(odataType)] = decoder
+}
+
+// UnregisterOEMDecoder removes a decoder registered with RegisterOEMDecoder.
+func UnregisterOEMDecoder(vendor, odataType string) {
+	oemDecodersLock.Lock()
+	defer oemDecodersLock.Unlock()
+
+	delete(oemDecoders[vendor], normalizeODataType(odataType))
+	if len(oemDecoders[vendor]) == 0 {
+		delete(oemDecoders, vendor)
+	}
+}
+
+// lookupOEMDecoder finds the decoder for a vendor object, preferring one
+// registered for its type.
+func lookupOEMDecoder(vendor, odataType string) OEMDecoder {
+	oemDecodersLock.RLock()
+	defer oemDecodersLock.RUnlock()
+
+	decoders := oemDecoders[vendor]
+	if decoder, ok := decoders[normalizeODataType(odataType)]; ok {
+		return decoder
+	}
+
+	return decoders[""]
+}
+
+// normalizeODataType strips the leading '#' and the schema version from an
+// @odata.type, so "#LenovoManager.v1_0_0.LenovoManagerProperties" becomes
+// "LenovoManager.LenovoManagerProperties".
+func normalizeODataType(odataType string) string {
+	var parts []string
+	for _, part := range strings.Split(strings.TrimPrefix(odataType, "#"), ".") {
+		if len(part) > 1 && part[0] == 'v' && part[1] >= '0' && part[1] <= '9' {
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// OEM holds the Oem property of a resource. The object of each vendor with a
+// registered decoder is hydrated into the decoder's type when the resource is
+// unmarshaled; the raw JSON of every vendor is kept as well. A vendor object
+// its decoder fails on does not fail the resource, the error is kept for
+// Error instead.
+type OEM struct {
+	raw     map[string]json.RawMessage
+	decoded map[string]interface{}
+	errors  map[string]error
+}
+
+// UnmarshalJSON unmarshals an OEM object from the raw JSON, running the
+// registered decoders.
+func (oem *OEM) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*oem = OEM{
+		raw:     raw,
+		decoded: map[string]interface{}{},
+		errors:  map[string]error{},
+	}
+
+	for vendor, data := range raw {
+		if strings.HasPrefix(vendor, "@") {
+			// Annotations such as @odata.type of the Oem object itself
+			continue
+		}
+
+		var t struct {
+			ODataType string `json:"@odata.type"`
+		}
+		// Vendors may use values that are not objects, which have no type
+		_ = json.Unmarshal(data, &t)
+
+		decoder := lookupOEMDecoder(vendor, t.ODataType)
+		if decoder == nil {
+			continue
+		}
+
+		value, err := decoder(data)
+		if err != nil {
+			oem.errors[vendor] = fmt.Errorf("failed to decode %s OEM data: %v", vendor, err)
+			continue
+		}
+		oem.decoded[vendor] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON marshals the OEM object back to the JSON it was read from.
+func (oem OEM) MarshalJSON() ([]byte, error) {
+	if oem.raw == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(oem.raw)
+}
+
+// Vendors gets the vendors that have data in the OEM object.
+func (oem OEM) Vendors() []string {
+	var result []string
+	for vendor := range oem.raw {
+		if !strings.HasPrefix(vendor, "@") {
+			result = append(result, vendor)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Get gets the decoded data of a vendor. It returns false if the vendor has
+// no data or no decoder was registered for it.
+func (oem OEM) Get(vendor string) (interface{}, bool) {
+	value, ok := oem.decoded[vendor]
+	return value, ok
+}
+
+// Error gets the error the decoder of a vendor failed with, or nil if the
+// vendor data was decoded or has no decoder.
+func (oem OEM) Error(vendor string) error {
+	return oem.errors[vendor]
+}
+
+// Raw gets the raw JSON data of a vendor, or nil if the vendor has no data.
+func (oem OEM) Raw(vendor string) json.RawMessage {
+	return oem.raw[vendor]
+}
@@ -0,0 +1,105 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var oemBody = `{
+		"@odata.type": "#OemManager.v1_0_0.Oem",
+		"Contoso": {
+			"@odata.type": "#ContosoManager.v1_2_0.ContosoManager",
+			"FanMode": "Quiet"
+		},
+		"Fabrikam": {
+			"@odata.type": "#FabrikamManager.v1_0_0.FabrikamManager",
+			"Zone": 3
+		},
+		"Tailspin": "unstructured"
+	}`
+
+type contosoManager struct {
+	FanMode string
+}
+
+// TestOEMDecoders tests hydrating vendor data with the registered decoders.
+func TestOEMDecoders(t *testing.T) {
+	RegisterOEMDecoder("Contoso", "ContosoManager.ContosoManager", func(data json.RawMessage) (interface{}, error) {
+		var result contosoManager
+		err := json.Unmarshal(data, &result)
+		return &result, err
+	})
+	defer UnregisterOEMDecoder("Contoso", "ContosoManager.ContosoManager")
+	RegisterOEMDecoder("Contoso", "", func(data json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("the typed decoder should be preferred")
+	})
+	defer UnregisterOEMDecoder("Contoso", "")
+
+	var result OEM
+	err := json.NewDecoder(strings.NewReader(oemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	vendors := result.Vendors()
+	if len(vendors) != 3 || vendors[0] != "Contoso" || vendors[2] != "Tailspin" {
+		t.Errorf("Invalid vendors: %v", vendors)
+	}
+
+	value, ok := result.Get("Contoso")
+	if !ok {
+		t.Fatal("Contoso data should have been decoded")
+	}
+
+	if value.(*contosoManager).FanMode != "Quiet" {
+		t.Errorf("Invalid Contoso fan mode: %s", value.(*contosoManager).FanMode)
+	}
+
+	if _, ok := result.Get("Fabrikam"); ok {
+		t.Error("Fabrikam data has no decoder and should not be decoded")
+	}
+
+	if !strings.Contains(string(result.Raw("Fabrikam")), `"Zone": 3`) {
+		t.Errorf("Invalid Fabrikam raw data: %s", result.Raw("Fabrikam"))
+	}
+}
+
+// TestOEMDecoderError tests the error of a failing decoder is kept without
+// failing the decoding.
+func TestOEMDecoderError(t *testing.T) {
+	RegisterOEMDecoder("Fabrikam", "#FabrikamManager.v1_0_0.FabrikamManager", func(data json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("unsupported zone")
+	})
+	defer UnregisterOEMDecoder("Fabrikam", "FabrikamManager.FabrikamManager")
+
+	var result OEM
+	err := json.NewDecoder(strings.NewReader(oemBody)).Decode(&result)
+
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	err = result.Error("Fabrikam")
+	if err == nil || !strings.Contains(err.Error(), "Fabrikam") {
+		t.Errorf("Expected a Fabrikam decoding error, got: %v", err)
+	}
+
+	if _, ok := result.Get("Fabrikam"); ok {
+		t.Error("Fabrikam data failed to decode and should not be returned")
+	}
+
+	if !strings.Contains(string(result.Raw("Fabrikam")), `"Zone": 3`) {
+		t.Errorf("Invalid Fabrikam raw data: %s", result.Raw("Fabrikam"))
+	}
+
+	if result.Error("Contoso") != nil {
+		t.Errorf("Contoso data has no decoder and should have no error: %v", result.Error("Contoso"))
+	}
+}
@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets in diagnostics output, such as HTTP dumps, error
+// messages and recorded interactions.
+const Redacted = "REDACTED"
+
+// redactedHeaders are the request and response headers that carry secrets.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Auth-Token",
+}
+
+// redactedProperties are the words in property names that mark secrets, such
+// as the Password of accounts and sessions, the KeyString of keys, the
+// CommunityString of SNMP communities, the AuthenticationKey and
+// EncryptionKey of SNMP users, and the PrivateKey and CertificateString of
+// certificates.
+var redactedProperties = []string{
+	"authenticationkey",
+	"certificatestring",
+	"communitystring",
+	"encryptionkey",
+	"keystring",
+	"passphrase",
+	"password",
+	"privatekey",
+	"secret",
+	"token",
+}
+
+// sessionURIPattern matches the URIs of sessions, whose identifiers let
+// anyone reading them act on the session.
+var sessionURIPattern = regexp.MustCompile(`(?i)(/SessionService/Sessions/)[^/\s"'?#]+`)
+
+// stringPropertyPattern matches the string properties of JSON text, to
+// redact bodies that cannot be decoded, such as chunked or truncated ones.
+var stringPropertyPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// RedactURI replaces the identifiers of the session URIs in a text.
+func RedactURI(text string) string {
+	return sessionURIPattern.ReplaceAllString(text, "${1}"+Redacted)
+}
+
+// RedactHeader copies headers, replacing the values of secret headers and
+// the session URIs of the other ones, such as the Location of a new session.
+func RedactHeader(header http.Header) http.Header {
+	result := make(http.Header)
+	for key, values := range header {
+		for _, value := range values {
+			if isSecretHeader(key) {
+				value = Redacted
+			}
+			result[key] = append(result[key], RedactURI(value))
+		}
+	}
+
+	return result
+}
+
+// RedactBody replaces the values of secret properties and the session URIs
+// in a request or response body. JSON bodies are redacted property by
+// property; the string properties of other bodies are redacted as they
+// appear in the text.
+func RedactBody(body []byte) []byte {
+	var value interface{}
+	if json.Unmarshal(body, &value) == nil {
+		data, err := json.Marshal(redactValue(value))
+		if err == nil {
+			return []byte(RedactURI(string(data)))
+		}
+	}
+
+	text := stringPropertyPattern.ReplaceAllStringFunc(string(body), func(property string) string {
+		match := stringPropertyPattern.FindStringSubmatch(property)
+		if !isSecret(match[1]) {
+			return property
+		}
+		return `"` + match[1] + `"` + match[2] + `"` + Redacted + `"`
+	})
+
+	return []byte(RedactURI(text))
+}
+
+// RedactDump redacts an HTTP request or response dump, as written by the
+// httputil package: the secret headers, the session URIs and the secret
+// properties of the body.
+func RedactDump(dump []byte) []byte {
+	head, body := dump, []byte(nil)
+	separator := []byte("\r\n\r\n")
+	if i := bytes.Index(dump, separator); i >= 0 {
+		head, body = dump[:i], dump[i+len(separator):]
+	}
+
+	lines := strings.Split(string(head), "\r\n")
+	for i, line := range lines {
+		if colon := strings.Index(line, ":"); i > 0 && colon > 0 && isSecretHeader(line[:colon]) {
+			lines[i] = line[:colon] + ": " + Redacted
+			continue
+		}
+		lines[i] = RedactURI(line)
+	}
+
+	result := []byte(strings.Join(lines, "\r\n"))
+	if body == nil {
+		return result
+	}
+
+	result = append(result, separator...)
+	if len(body) == 0 {
+		return result
+	}
+
+	return append(result, RedactBody(body)...)
+}
+
+// redactValue replaces the string values of secret properties in a JSON
+// value.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, property := range v {
+			if _, ok := property.(string); ok && isSecret(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(property)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+// isSecretHeader checks whether a header carries a secret.
+func isSecretHeader(name string) bool {
+	name = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name))
+	for _, header := range redactedHeaders {
+		if name == header {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSecret checks whether a property name marks a secret.
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "@") {
+		return false
+	}
+
+	for _, word := range redactedProperties {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -80,10 +80,10 @@ type Schedule struct {
 	InitialStartTime string
 	// Lifetime shall be a Redfish Duration describing the time after
 	// provisioning when the schedule expires.
-	Lifetime string
+	Lifetime Duration
 	// MaxOccurrences is Maximum number of scheduled occurrences.
 	MaxOccurrences int
 	// RecurrenceInterval shall be a Redfish Duration describing the time until
 	// the next occurrence.
-	RecurrenceInterval string
+	RecurrenceInterval Duration
 }
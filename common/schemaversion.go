@@ -0,0 +1,52 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// schemaVersionPattern matches the version in an @odata.type, such as the
+// v1_10_0 of "#Chassis.v1_10_0.Chassis".
+var schemaVersionPattern = regexp.MustCompile(`\.v(\d+)_(\d+)_(\d+)(\.|$)`)
+
+// SchemaVersion is the version of the schema of a resource.
+type SchemaVersion struct {
+	Major  int
+	Minor  int
+	Errata int
+}
+
+// ParseSchemaVersion gets the schema version of an @odata.type, so
+// "#Chassis.v1_10_0.Chassis" gives 1.10.0. It returns false if the type has
+// no version, as the ones of collections do.
+func ParseSchemaVersion(odataType string) (SchemaVersion, bool) {
+	match := schemaVersionPattern.FindStringSubmatch(odataType)
+	if match == nil {
+		return SchemaVersion{}, false
+	}
+
+	var version SchemaVersion
+	version.Major, _ = strconv.Atoi(match[1])
+	version.Minor, _ = strconv.Atoi(match[2])
+	version.Errata, _ = strconv.Atoi(match[3])
+	return version, true
+}
+
+// AtLeast tells whether the version is major.minor or a later one, whatever
+// its errata.
+func (version SchemaVersion) AtLeast(major, minor int) bool {
+	if version.Major != major {
+		return version.Major > major
+	}
+	return version.Minor >= minor
+}
+
+// String gets the version as "1.10.0".
+func (version SchemaVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Errata)
+}
@@ -0,0 +1,43 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"testing"
+)
+
+// TestParseSchemaVersion tests getting the schema version of @odata.type.
+func TestParseSchemaVersion(t *testing.T) {
+	version, ok := ParseSchemaVersion("#Chassis.v1_10_2.Chassis")
+	if !ok || version != (SchemaVersion{Major: 1, Minor: 10, Errata: 2}) {
+		t.Errorf("Invalid schema version: %v %t", version, ok)
+	}
+
+	if !version.AtLeast(1, 10) || !version.AtLeast(1, 9) || !version.AtLeast(0, 20) {
+		t.Errorf("%s should be at least 1.10, 1.9 and 0.20", version)
+	}
+
+	if version.AtLeast(1, 11) || version.AtLeast(2, 0) {
+		t.Errorf("%s should not be at least 1.11 nor 2.0", version)
+	}
+
+	_, ok = ParseSchemaVersion("#ChassisCollection.ChassisCollection")
+	if ok {
+		t.Error("Collections should have no schema version")
+	}
+}
+
+// TestEntitySchemaVersionAtLeast tests gating on the version of an entity.
+func TestEntitySchemaVersionAtLeast(t *testing.T) {
+	var entity Entity
+	if !entity.SchemaVersionAtLeast(1, 10) {
+		t.Error("An entity of unknown version should not be gated")
+	}
+
+	entity.setMetadata("", "#Manager.v1_5_0.Manager")
+	if entity.SchemaVersionAtLeast(1, 10) || !entity.SchemaVersionAtLeast(1, 5) {
+		t.Error("Invalid gating of a Manager v1.5")
+	}
+}
@@ -59,6 +59,17 @@ func (c *TestClient) Post(url string, payload interface{}) (*http.Response, erro
 	return nil, nil
 }
 
+// PostMultipart performs a multipart Post request against the Redfish
+// service. The names of the parts are recorded as the payload.
+func (c *TestClient) PostMultipart(url string, parts []MultipartPart) (*http.Response, error) {
+	var names []string
+	for _, part := range parts {
+		names = append(names, part.Name)
+	}
+	c.recordCall("POST", url, names)
+	return nil, nil
+}
+
 // Put performs a Put request against the Redfish service.
 func (c *TestClient) Put(url string, payload interface{}) (*http.Response, error) {
 	c.recordCall("PUT", url, payload)
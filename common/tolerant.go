@@ -0,0 +1,159 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// The types in this file are used to read resource properties that some
+// implementations send with the wrong JSON type, such as numbers sent as
+// strings. Resources keep the schema types on their exported fields and
+// decode such properties through these types in their UnmarshalJSON. They
+// accept the value as a number, a string or a boolean, and null, so a single
+// quirky property does not keep the whole resource from being read. Values
+// that can not be converted are read as zero.
+
+// Int is an integer that is also accepted as a string or a boolean.
+type Int int
+
+// UnmarshalJSON unmarshals an Int from the raw JSON.
+func (i *Int) UnmarshalJSON(b []byte) error {
+	value, err := tolerantFloat(b)
+	*i = Int(value)
+	return err
+}
+
+// Int64 is a 64-bit integer that is also accepted as a string or a boolean.
+type Int64 int64
+
+// UnmarshalJSON unmarshals an Int64 from the raw JSON.
+func (i *Int64) UnmarshalJSON(b []byte) error {
+	text, err := tolerantText(b)
+	if err != nil {
+		return err
+	}
+
+	// Parse integers directly, large values do not fit a float64
+	value, parseErr := strconv.ParseInt(text, 10, 64)
+	if parseErr != nil {
+		float, err := tolerantFloat(b)
+		*i = Int64(float)
+		return err
+	}
+
+	*i = Int64(value)
+	return nil
+}
+
+// Float32 is a number that is also accepted as a string or a boolean.
+type Float32 float32
+
+// UnmarshalJSON unmarshals a Float32 from the raw JSON.
+func (f *Float32) UnmarshalJSON(b []byte) error {
+	value, err := tolerantFloat(b)
+	*f = Float32(value)
+	return err
+}
+
+// Float64 is a number that is also accepted as a string or a boolean.
+type Float64 float64
+
+// UnmarshalJSON unmarshals a Float64 from the raw JSON.
+func (f *Float64) UnmarshalJSON(b []byte) error {
+	value, err := tolerantFloat(b)
+	*f = Float64(value)
+	return err
+}
+
+// Bool is a boolean that is also accepted as a string, such as "true" or
+// "Yes", or as a number, where anything but 0 is true.
+type Bool bool
+
+// UnmarshalJSON unmarshals a Bool from the raw JSON.
+func (v *Bool) UnmarshalJSON(b []byte) error {
+	text, err := tolerantText(b)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(text) {
+	case "true", "yes", "on", "enabled":
+		*v = true
+	case "", "false", "no", "off", "disabled":
+		*v = false
+	default:
+		value, parseErr := strconv.ParseFloat(text, 64)
+		*v = Bool(parseErr == nil && value != 0)
+	}
+
+	return nil
+}
+
+// String is a string that is also accepted as a number or a boolean, which
+// are read as their JSON text.
+type String string
+
+// UnmarshalJSON unmarshals a String from the raw JSON.
+func (s *String) UnmarshalJSON(b []byte) error {
+	text, err := tolerantText(b)
+	*s = String(text)
+	return err
+}
+
+// tolerantText gets the text of a scalar JSON value: the content of a
+// string, or the JSON text of a number or a boolean. Null is read as empty.
+func tolerantText(b []byte) (string, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return "", nil
+	}
+
+	if b[0] == '"' {
+		var text string
+		err := json.Unmarshal(b, &text)
+		return strings.TrimSpace(text), err
+	}
+
+	var value interface{}
+	err := json.Unmarshal(b, &value)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.(type) {
+	case bool, float64:
+		return string(b), nil
+	}
+
+	// Objects and arrays can not be read as a scalar, ignore them
+	return "", nil
+}
+
+// tolerantFloat gets the number of a scalar JSON value. Strings that are not
+// numbers are read as zero, booleans as zero or one.
+func tolerantFloat(b []byte) (float64, error) {
+	text, err := tolerantText(b)
+	if err != nil || text == "" {
+		return 0, err
+	}
+
+	switch strings.ToLower(text) {
+	case "true":
+		return 1, nil
+	case "false":
+		return 0, nil
+	}
+
+	value, parseErr := strconv.ParseFloat(text, 64)
+	if parseErr != nil {
+		return 0, nil
+	}
+
+	return value, nil
+}
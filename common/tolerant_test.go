@@ -0,0 +1,63 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var tolerantBody = `{
+		"Count": "4",
+		"Capacity": "1200000000000",
+		"Reading": " 25.5 ",
+		"Efficiency": true,
+		"Missing": null,
+		"Unknown": "N/A",
+		"Enabled": "Yes",
+		"Disabled": 0,
+		"Serial": 12345
+	}`
+
+// TestTolerantTypes tests reading values sent with the wrong JSON type.
+func TestTolerantTypes(t *testing.T) {
+	var result struct {
+		Count      Int
+		Capacity   Int64
+		Reading    Float32
+		Efficiency Float64
+		Missing    Int
+		Unknown    Float32
+		Enabled    Bool
+		Disabled   Bool
+		Serial     String
+	}
+	err := json.Unmarshal([]byte(tolerantBody), &result)
+
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if result.Count != 4 || result.Capacity != 1200000000000 || result.Reading != 25.5 || result.Efficiency != 1 {
+		t.Errorf("Invalid numbers: %+v", result)
+	}
+
+	if result.Missing != 0 || result.Unknown != 0 {
+		t.Errorf("Null and invalid numbers should be read as zero: %+v", result)
+	}
+
+	if !result.Enabled || result.Disabled {
+		t.Errorf("Invalid booleans: %+v", result)
+	}
+
+	if result.Serial != "12345" {
+		t.Errorf("Invalid string: %s", result.Serial)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil || string(data) != `{"Count":4,"Capacity":1200000000000,"Reading":25.5,"Efficiency":1,"Missing":0,"Unknown":0,"Enabled":true,"Disabled":false,"Serial":"12345"}` {
+		t.Errorf("Unexpected JSON: %s %v", data, err)
+	}
+}
@@ -7,8 +7,10 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strings"
 )
 
 // DefaultServiceRoot is the default path to the Redfish service endpoint.
@@ -23,6 +25,26 @@ type Client interface {
 	Delete(url string) error
 }
 
+// MultipartPart is a part of a multipart/form-data request.
+type MultipartPart struct {
+	// Name is the name of the form field.
+	Name string
+	// FileName is the name of the file the content is sent as, if the part
+	// is a file.
+	FileName string
+	// ContentType is the content type of the part, such as
+	// "application/json".
+	ContentType string
+	// Content is the content of the part.
+	Content io.Reader
+}
+
+// MultipartClient is implemented by clients that can send multipart/form-data
+// requests, such as the firmware images pushed to an update service.
+type MultipartClient interface {
+	PostMultipart(url string, parts []MultipartPart) (*http.Response, error)
+}
+
 // Entity provides the common basis for all Redfish and Swordfish objects.
 type Entity struct {
 	// ODataID is the location of the resource.
@@ -32,7 +54,13 @@ type Entity struct {
 	// Name is the name of the resource or array element.
 	Name string `json:"Name"`
 	// Client is the REST client interface to the system.
-	Client Client
+	Client Client `json:"-"`
+	// actions are the actions the entity advertises, keyed by name.
+	actions map[string]Action
+	// etag is the ETag of the entity when it was read.
+	etag string
+	// odataType is the @odata.type of the entity when it was read.
+	odataType string
 }
 
 // SetClient sets the API client connection to use for accessing this
@@ -41,27 +69,83 @@ func (e *Entity) SetClient(c Client) {
 	e.Client = c
 }
 
-// Update commits changes to an entity.
+// ETag gets the ETag of the entity when it was read from the service, or an
+// empty string if the service did not tell.
+func (e *Entity) ETag() string {
+	return e.etag
+}
+
+// SchemaVersion gets the version of the schema the service implements the
+// entity with, from its @odata.type. It returns false if the service did not
+// tell, or the entity was not read with FetchResource or from an expanded
+// collection.
+func (e *Entity) SchemaVersion() (SchemaVersion, bool) {
+	return ParseSchemaVersion(e.odataType)
+}
+
+// SchemaVersionAtLeast tells whether the service implements the entity with
+// major.minor of its schema or a later version, so callers can avoid the
+// properties and actions an older implementation does not have. It is true
+// when the version is unknown, so services that do not tell are still used.
+func (e *Entity) SchemaVersionAtLeast(major, minor int) bool {
+	version, ok := e.SchemaVersion()
+	return !ok || version.AtLeast(major, minor)
+}
+
+// setMetadata sets the ETag and @odata.type of the entity, which resources
+// have in fields of their own, for FetchResource and DecodeMember.
+func (e *Entity) setMetadata(etag, odataType string) {
+	e.etag = etag
+	e.odataType = odataType
+}
+
+// Update commits changes to an entity. With CheckWriteableProperties set, if
+// the resource kept the JSON it was read from and the service advertised its
+// writable properties through the @Redfish.WriteableProperties annotation,
+// the changed properties are checked against them before anything is sent.
 func (e *Entity) Update(originalEntity reflect.Value, currentEntity reflect.Value,
 	allowedUpdates []string) error {
+	return e.UpdateWithNested(originalEntity, currentEntity, allowedUpdates, nil)
+}
+
+// UpdateWithNested commits changes to an entity like Update, sending the
+// changes to nested objects, which Update does not compare, in the same
+// PATCH. The nested changes are keyed by property name, such as
+// {"Location": {...}}, and are checked with the other changes when
+// CheckWriteableProperties is set.
+func (e *Entity) UpdateWithNested(originalEntity reflect.Value, currentEntity reflect.Value,
+	allowedUpdates []string, nested map[string]interface{}) error {
 
 	payload := make(map[string]interface{})
+	// The Go names of the changed fields, as lists of allowed updates may use
+	// them rather than the JSON names sent to the service
+	goNames := make(map[string]string)
 
 	for i := 0; i < originalEntity.NumField(); i++ {
 		if !originalEntity.Field(i).CanInterface() {
 			// Private field or something that we can't access
 			continue
 		}
+		fieldName := originalEntity.Type().Field(i).Name
+		if tag := strings.Split(originalEntity.Type().Field(i).Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+			goNames[tag] = fieldName
+			fieldName = tag
+		}
+		originalValue := originalEntity.Field(i).Interface()
+		currentValue := currentEntity.Field(i).Interface()
+		if originalDateTime, ok := originalValue.(DateTime); ok {
+			// Date-times are compared as instants, their locations may differ
+			if !originalDateTime.Equal(currentValue.(DateTime).Time) {
+				payload[fieldName] = currentValue
+			}
+			continue
+		}
 		fieldType := originalEntity.Type().Field(i).Type.Kind()
 		if fieldType == reflect.Struct || fieldType == reflect.Ptr || fieldType == reflect.Slice {
 			// TODO: Handle more complicated data types
 			continue
 		}
-		fieldName := originalEntity.Type().Field(i).Name
-		originalValue := originalEntity.Field(i).Interface()
-		currentValue := currentEntity.Field(i).Interface()
 		if originalValue != currentValue {
-			// TODO: Handle JSON name being different than field name
 			payload[fieldName] = currentValue
 		}
 	}
@@ -70,7 +154,7 @@ func (e *Entity) Update(originalEntity reflect.Value, currentEntity reflect.Valu
 	for field := range payload {
 		found := false
 		for _, name := range allowedUpdates {
-			if name == field {
+			if name == field || (goNames[field] != "" && name == goNames[field]) {
 				found = true
 				break
 			}
@@ -81,6 +165,17 @@ func (e *Entity) Update(originalEntity reflect.Value, currentEntity reflect.Valu
 		}
 	}
 
+	for field, value := range nested {
+		payload[field] = value
+	}
+
+	if CheckWriteableProperties {
+		err := ValidateWriteableProperties(entityRawData(originalEntity), payload)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If there are any allowed updates, try to send updates to the system and
 	// return the result.
 	if len(payload) > 0 {
@@ -237,10 +332,61 @@ const (
 	UpdatingState State = "Updating"
 )
 
+// Condition shall describe a condition that requires attention, such as the
+// cause of a degraded health.
+type Condition struct {
+	// LogEntry shall contain a link to the log entry created for this
+	// condition, if any.
+	LogEntry string
+	// Message shall contain a human-readable message describing this
+	// condition.
+	Message string
+	// MessageArgs shall contain the arguments of the message.
+	MessageArgs []string
+	// MessageID shall contain the identifier of the message in a message
+	// registry.
+	MessageID string `json:"MessageId"`
+	// OriginOfCondition shall contain a link to the resource or object that
+	// originated the condition.
+	OriginOfCondition string
+	// Resolution shall contain the suggestions on how to resolve the
+	// condition.
+	Resolution string
+	// Severity shall contain the severity of the condition.
+	Severity Health
+	// Timestamp shall indicate the time the condition occurred.
+	Timestamp DateTime
+}
+
+// UnmarshalJSON unmarshals a Condition object from the raw JSON.
+func (condition *Condition) UnmarshalJSON(b []byte) error {
+	type temp Condition
+	var t struct {
+		temp
+		LogEntry          Link
+		OriginOfCondition Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*condition = Condition(t.temp)
+	condition.LogEntry = string(t.LogEntry)
+	condition.OriginOfCondition = string(t.OriginOfCondition)
+
+	return nil
+}
+
 // Status describes the status and health of a resource and its children.
 type Status struct {
-	Health Health `json:"Health"`
-	State  State  `json:"State"`
+	// Conditions shall contain the conditions that require attention,
+	// such as the root causes of a degraded health of the resource or its
+	// children.
+	Conditions []Condition `json:",omitempty"`
+	Health     Health      `json:"Health"`
+	State      State       `json:"State"`
 }
 
 // LocationType shall name the type of location in use.
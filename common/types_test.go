@@ -0,0 +1,72 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var statusBody = `{
+		"State": "Enabled",
+		"Health": "Warning",
+		"Conditions": [
+			{
+				"MessageId": "Base.1.9.SensorThresholdWarning",
+				"Message": "The fan speed of Fan 2 is below the warning threshold.",
+				"MessageArgs": [
+					"Fan 2"
+				],
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Chassis/1/Thermal#/Fans/1"
+				},
+				"LogEntry": {
+					"@odata.id": "/redfish/v1/Managers/1/LogServices/SEL/Entries/12"
+				},
+				"Severity": "Warning",
+				"Timestamp": "2021-02-13T04:05:26+00:00"
+			}
+		]
+	}`
+
+// TestStatusConditions tests the parsing of the conditions of a status.
+func TestStatusConditions(t *testing.T) {
+	var result Status
+	err := json.NewDecoder(strings.NewReader(statusBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.Health != WarningHealth || len(result.Conditions) != 1 {
+		t.Fatalf("Invalid status: %v", result)
+	}
+
+	condition := result.Conditions[0]
+	if condition.MessageID != "Base.1.9.SensorThresholdWarning" {
+		t.Errorf("Invalid MessageID: %s", condition.MessageID)
+	}
+
+	if condition.OriginOfCondition != "/redfish/v1/Chassis/1/Thermal#/Fans/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", condition.OriginOfCondition)
+	}
+
+	if condition.LogEntry != "/redfish/v1/Managers/1/LogServices/SEL/Entries/12" {
+		t.Errorf("Invalid LogEntry: %s", condition.LogEntry)
+	}
+
+	if condition.Severity != WarningHealth {
+		t.Errorf("Invalid Severity: %s", condition.Severity)
+	}
+
+	if len(condition.MessageArgs) != 1 || condition.MessageArgs[0] != "Fan 2" {
+		t.Errorf("Invalid MessageArgs: %v", condition.MessageArgs)
+	}
+
+	if condition.Timestamp.String() != "2021-02-13T04:05:26Z" {
+		t.Errorf("Invalid Timestamp: %s", condition.Timestamp)
+	}
+}
@@ -0,0 +1,112 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CheckWriteableProperties makes Entity.Update and Entity.UpdateWithNested
+// check the changed properties against the @Redfish.WriteableProperties
+// annotation of the resource, see ValidateWriteableProperties, and refuse
+// the update rather than letting the service reject it. It is off by
+// default, as services are not required to keep the annotation accurate.
+// It is meant to be set once, before updates are made.
+var CheckWriteableProperties bool
+
+// ErrorUnwritableProperties is returned when an update changes properties the
+// service does not allow to be written. The update is not sent.
+type ErrorUnwritableProperties struct {
+	// Properties are the names of the properties that can not be written,
+	// sorted.
+	Properties []string
+	// Reason tells where the service advertised what it allows, such as
+	// "@Redfish.WriteableProperties".
+	Reason string
+}
+
+// Error implements the error interface.
+func (e ErrorUnwritableProperties) Error() string {
+	noun := "property is"
+	if len(e.Properties) > 1 {
+		noun = "properties are"
+	}
+
+	message := fmt.Sprintf("%s %s not writable on this implementation", strings.Join(e.Properties, ", "), noun)
+	if e.Reason != "" {
+		message += " (" + e.Reason + ")"
+	}
+
+	return message
+}
+
+// WriteableProperties gets the properties the service reports as writable
+// through the @Redfish.WriteableProperties annotation of a resource. It
+// returns nil if the resource has no such annotation, in which case the
+// schema alone says what can be written.
+func WriteableProperties(raw []byte) []string {
+	var t struct {
+		WriteableProperties []string `json:"@Redfish.WriteableProperties"`
+	}
+
+	if len(raw) == 0 || json.Unmarshal(raw, &t) != nil {
+		return nil
+	}
+
+	return t.WriteableProperties
+}
+
+// ValidateWriteableProperties checks the properties of an update payload
+// against the @Redfish.WriteableProperties annotation of the raw JSON of the
+// resource. An ErrorUnwritableProperties listing every property that is not
+// advertised as writable is returned; nothing is checked if the resource has
+// no annotation.
+func ValidateWriteableProperties(raw []byte, payload map[string]interface{}) error {
+	writeable := WriteableProperties(raw)
+	if writeable == nil {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(writeable))
+	for _, name := range writeable {
+		allowed[name] = true
+	}
+
+	var unwritable []string
+	for name := range payload {
+		if !allowed[name] {
+			unwritable = append(unwritable, name)
+		}
+	}
+
+	if len(unwritable) == 0 {
+		return nil
+	}
+
+	sort.Strings(unwritable)
+	return ErrorUnwritableProperties{
+		Properties: unwritable,
+		Reason:     "@Redfish.WriteableProperties",
+	}
+}
+
+// entityRawData gets the raw JSON kept by a resource in its rawData field, or
+// nil if it has none.
+func entityRawData(entity reflect.Value) []byte {
+	if entity.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := entity.FieldByName("rawData")
+	if !field.IsValid() || field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+
+	return field.Bytes()
+}
@@ -0,0 +1,134 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var writeableBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Name": "Chassis",
+		"AssetTag": "old",
+		"IndicatorLED": "Off",
+		"Model": "X1",
+		"@Redfish.WriteableProperties": [
+			"IndicatorLED"
+		]
+	}`
+
+type writeableTestResource struct {
+	Entity
+	AssetTag     string
+	IndicatorLED string
+	Model        string
+	rawData      []byte
+}
+
+func (resource *writeableTestResource) update() error {
+	var original writeableTestResource
+	json.Unmarshal(resource.rawData, &original)
+	original.rawData = resource.rawData
+
+	return resource.Entity.Update(reflect.ValueOf(&original).Elem(),
+		reflect.ValueOf(resource).Elem(), []string{"AssetTag", "IndicatorLED"})
+}
+
+// TestEntityUpdateWriteableProperties tests that updates are checked against
+// the @Redfish.WriteableProperties annotation when asked to.
+func TestEntityUpdateWriteableProperties(t *testing.T) {
+	CheckWriteableProperties = true
+	defer func() { CheckWriteableProperties = false }()
+
+	var resource writeableTestResource
+	err := json.Unmarshal([]byte(writeableBody), &resource)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	resource.rawData = []byte(writeableBody)
+
+	testClient := &TestClient{}
+	resource.SetClient(testClient)
+
+	resource.AssetTag = "new"
+	resource.IndicatorLED = "Lit"
+	err = resource.update()
+
+	var unwritable ErrorUnwritableProperties
+	if !errors.As(err, &unwritable) {
+		t.Fatalf("Expected an unwritable properties error, got: %v", err)
+	}
+
+	if len(unwritable.Properties) != 1 || unwritable.Properties[0] != "AssetTag" {
+		t.Errorf("Unexpected unwritable properties: %v", unwritable.Properties)
+	}
+
+	if err.Error() != "AssetTag property is not writable on this implementation (@Redfish.WriteableProperties)" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("No update should have been sent: %v", testClient.CapturedCalls())
+	}
+
+	resource.AssetTag = "old"
+	err = resource.update()
+	if err != nil {
+		t.Errorf("Error updating writable property: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Payload != "map[IndicatorLED:Lit]" {
+		t.Errorf("Unexpected update calls: %v", calls)
+	}
+}
+
+// TestEntityUpdateUnchecked tests that updates are sent as they are by
+// default.
+func TestEntityUpdateUnchecked(t *testing.T) {
+	var resource writeableTestResource
+	err := json.Unmarshal([]byte(writeableBody), &resource)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+	resource.rawData = []byte(writeableBody)
+
+	testClient := &TestClient{}
+	resource.SetClient(testClient)
+
+	resource.AssetTag = "new"
+	err = resource.update()
+	if err != nil {
+		t.Errorf("Error updating property: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Payload != "map[AssetTag:new]" {
+		t.Errorf("Unexpected update calls: %v", calls)
+	}
+}
+
+// TestValidateWriteableProperties tests listing every unwritable property.
+func TestValidateWriteableProperties(t *testing.T) {
+	payload := map[string]interface{}{
+		"Model":        "X2",
+		"AssetTag":     "new",
+		"IndicatorLED": "Lit",
+	}
+
+	err := ValidateWriteableProperties([]byte(writeableBody), payload)
+	if err == nil || err.Error() != "AssetTag, Model properties are not writable on this implementation (@Redfish.WriteableProperties)" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	err = ValidateWriteableProperties([]byte(`{"Id": "1"}`), payload)
+	if err != nil {
+		t.Errorf("Resources without the annotation should not be checked: %s", err)
+	}
+}
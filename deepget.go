@@ -0,0 +1,75 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfish
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// DeepGet gets the resource at uri with its subordinate resources down to
+// levels, such as a Storage with its controllers, volumes and drives, in a
+// single request on services that support $expand with $levels. The levels
+// are capped to the MaxLevels the service advertises for $expand and deep
+// operations.
+//
+// The returned client serves the resources of the subtree from that single
+// response, so the Get functions and accessors of the resources decompose
+// it without further requests:
+//
+//	client, err := service.DeepGet("/redfish/v1/Systems/1/Storage/1", 2)
+//	storage, err := redfish.GetStorage(client, "/redfish/v1/Systems/1/Storage/1")
+//	volumes, err := storage.Volumes()
+//
+// The resources the service did not expand, and all of them on services
+// that do not support $levels, are got from the service as usual.
+func (serviceroot *Service) DeepGet(uri string, levels int) (*common.DeepClient, error) {
+	query := serviceroot.deepQuery(levels)
+	if query == "" {
+		return common.NewDeepClient(serviceroot.Client, nil)
+	}
+
+	resp, err := serviceroot.Client.Get(uri + query)
+	if err != nil {
+		// Services may advertise more than they support, the resources are
+		// got one by one then
+		return common.NewDeepClient(serviceroot.Client, nil)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.NewDeepClient(serviceroot.Client, body)
+}
+
+// deepQuery gets the query that expands the subordinate resources of a
+// resource down to levels, as the service supports it, or an empty string if
+// it does not support $levels.
+func (serviceroot *Service) deepQuery(levels int) string {
+	features := serviceroot.ProtocolFeaturesSupported
+	expand := features.ExpandQuery
+	if !expand.Levels || levels < 1 {
+		return ""
+	}
+
+	for _, maxLevels := range []int{expand.MaxLevels, features.DeepOperations.MaxLevels} {
+		if maxLevels > 0 && levels > maxLevels {
+			levels = maxLevels
+		}
+	}
+
+	switch {
+	case expand.NoLinks:
+		return fmt.Sprintf("?$expand=.($levels=%d)", levels)
+	case expand.ExpandAll:
+		return fmt.Sprintf("?$expand=*($levels=%d)", levels)
+	}
+	return ""
+}
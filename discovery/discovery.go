@@ -0,0 +1,156 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package discovery finds the Redfish services of a network, such as the
+// BMCs of a lab, so they can be connected to without knowing their
+// addresses beforehand.
+//
+// Services are found with SSDP, as described in the Redfish specification,
+// or with mDNS and DNS-SD, which some BMCs and the Redfish host interface
+// advertise with. Each answer is a candidate endpoint: a Service whose
+// Connect method connects to it with the credentials of a ClientConfig.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/LRichi/WBfish"
+)
+
+// Source is the protocol a service was found with.
+type Source string
+
+const (
+	// SSDPSource is used for services that answered an SSDP M-SEARCH.
+	SSDPSource Source = "SSDP"
+	// MDNSSource is used for services that answered an mDNS query for
+	// their DNS-SD service type.
+	MDNSSource Source = "mDNS"
+)
+
+// Service is a Redfish service found on the network, a candidate endpoint to
+// connect to.
+type Service struct {
+	// UUID is the UUID of the service, which is the UUID of its service
+	// root. It is empty if the service does not advertise it.
+	UUID string
+	// Endpoint is the scheme and host of the service, such as
+	// https://192.168.0.10, as ClientConfig.Endpoint takes it.
+	Endpoint string
+	// ServiceRoot is the URI of the service root, such as /redfish/v1/.
+	ServiceRoot string
+	// Source is the protocol the service was found with.
+	Source Source
+	// MaxAge is how long the advertisement of the service is valid for, zero
+	// if the service does not tell.
+	MaxAge time.Duration
+}
+
+// Connect connects to the service, with the settings and credentials of
+// config. The endpoint of config is replaced with the one of the service.
+func (service Service) Connect(config wbfish.ClientConfig) (*wbfish.APIClient, error) {
+	config.Endpoint = service.Endpoint
+	return wbfish.Connect(config)
+}
+
+// newService makes a service from the URL of its service root.
+func newService(source Source, location string) (*Service, error) {
+	root, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if (root.Scheme != "http" && root.Scheme != "https") || root.Host == "" {
+		return nil, fmt.Errorf("invalid service root location: %s", location)
+	}
+
+	path := root.Path
+	if path == "" {
+		path = "/redfish/v1/"
+	}
+
+	return &Service{
+		Endpoint:    root.Scheme + "://" + root.Host,
+		ServiceRoot: path,
+		Source:      source,
+	}, nil
+}
+
+// key identifies a service among the ones found, to drop the duplicate
+// answers of a service.
+func (service *Service) key() string {
+	if service.UUID != "" {
+		return service.UUID
+	}
+	return service.Endpoint + service.ServiceRoot
+}
+
+// query sends a request to address over UDP and parses the answers with
+// parse until the timeout or the end of ctx. The services found are returned
+// in the order they answered, once each.
+func query(ctx context.Context, address, local string, timeout time.Duration, request []byte,
+	parse func(data []byte, from net.Addr) []*Service) ([]Service, error) {
+
+	destination, err := net.ResolveUDPAddr("udp4", address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenPacket("udp4", local)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	_, err = conn.WriteTo(request, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	err = conn.SetReadDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reads are interrupted when ctx ends
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	var result []Service
+	seen := make(map[string]bool)
+	buffer := make([]byte, 9000)
+	for {
+		n, from, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				break
+			}
+			return result, err
+		}
+
+		for _, service := range parse(buffer[:n], from) {
+			if seen[service.key()] {
+				continue
+			}
+			seen[service.key()] = true
+			result = append(result, *service)
+		}
+	}
+
+	return result, ctx.Err()
+}
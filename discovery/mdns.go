@@ -0,0 +1,369 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package discovery
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MDNSServiceType is the DNS-SD service type of Redfish services.
+const MDNSServiceType = "_redfish._tcp.local."
+
+// MDNSAddress is the IPv4 multicast address of mDNS.
+const MDNSAddress = "224.0.0.251:5353"
+
+// defaultMDNSTimeout is how long answers are waited for by default.
+const defaultMDNSTimeout = 3 * time.Second
+
+// The DNS record types read from answers.
+const (
+	dnsTypeA    = 1
+	dnsTypePTR  = 12
+	dnsTypeTXT  = 16
+	dnsTypeAAAA = 28
+	dnsTypeSRV  = 33
+	dnsClassIN  = 1
+)
+
+// MDNSOptions controls an mDNS query.
+type MDNSOptions struct {
+	// Timeout is how long answers are waited for. It defaults to three
+	// seconds.
+	Timeout time.Duration
+	// Address is the address the query is sent to. It defaults to
+	// MDNSAddress; a unicast address queries a single host.
+	Address string
+	// LocalAddress is the local address the query is sent from, to choose
+	// the network interface of the query. It defaults to any address. Its
+	// port must not be 5353, so that responders answer the query directly.
+	LocalAddress string
+}
+
+// MDNS queries the network for the instances of the Redfish DNS-SD service
+// type, and returns the services that answered before the timeout or the end
+// of ctx, in the order they answered. The query is a one-shot query: the
+// responders answer it directly rather than to the multicast group.
+//
+// The host and port of a service are read from its SRV record and its
+// address from the A or AAAA record of the host, or the address it answered
+// from. The TXT keys path and uuid are read, when present, for the service
+// root and the UUID. Instances answered without their SRV record are
+// skipped.
+func MDNS(ctx context.Context, options MDNSOptions) ([]Service, error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = defaultMDNSTimeout
+	}
+	address := options.Address
+	if address == "" {
+		address = MDNSAddress
+	}
+	local := options.LocalAddress
+	if local == "" {
+		local = ":0"
+	}
+
+	request := mdnsQuery(uint16(time.Now().UnixNano()), MDNSServiceType)
+	return query(ctx, address, local, timeout, request,
+		func(data []byte, from net.Addr) []*Service {
+			var ip net.IP
+			if udp, ok := from.(*net.UDPAddr); ok {
+				ip = udp.IP
+			}
+
+			// Answers about other services or malformed ones are skipped
+			services, err := ParseMDNSResponse(data, ip)
+			if err != nil {
+				return nil
+			}
+			return services
+		})
+}
+
+// mdnsQuery makes a DNS query for the PTR records of a service type.
+func mdnsQuery(id uint16, serviceType string) []byte {
+	message := make([]byte, 12)
+	binary.BigEndian.PutUint16(message[0:], id)
+	binary.BigEndian.PutUint16(message[4:], 1)
+
+	message = append(message, dnsName(serviceType)...)
+	message = binary.BigEndian.AppendUint16(message, dnsTypePTR)
+	return binary.BigEndian.AppendUint16(message, dnsClassIN)
+}
+
+// dnsName encodes a domain name as DNS labels.
+func dnsName(name string) []byte {
+	var result []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" {
+			continue
+		}
+		result = append(result, byte(len(label)))
+		result = append(result, label...)
+	}
+
+	return append(result, 0)
+}
+
+// dnsRecord is a resource record of a DNS message.
+type dnsRecord struct {
+	name string
+	kind uint16
+	ttl  uint32
+	// data is the record data, which starts at offset in the message.
+	data   []byte
+	offset int
+}
+
+// srvRecord is the data of a SRV record.
+type srvRecord struct {
+	port   uint16
+	target string
+}
+
+// ParseMDNSResponse parses an answer to an mDNS query for the Redfish
+// service type. from is the address the answer came from, used for the
+// services whose host address is not in the answer; it may be nil.
+func ParseMDNSResponse(data []byte, from net.IP) ([]*Service, error) {
+	records, err := parseDNSMessage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances, srvNames []string
+	ttls := make(map[string]uint32)
+	services := make(map[string]srvRecord)
+	texts := make(map[string]map[string]string)
+	addresses := make(map[string][]net.IP)
+	for _, record := range records {
+		name := strings.ToLower(record.name)
+		switch record.kind {
+		case dnsTypePTR:
+			if name != MDNSServiceType {
+				continue
+			}
+			instance, _, err := readDNSName(data, record.offset)
+			if err != nil {
+				return nil, err
+			}
+			instance = strings.ToLower(instance)
+			if _, ok := ttls[instance]; !ok {
+				instances = append(instances, instance)
+			}
+			ttls[instance] = record.ttl
+		case dnsTypeSRV:
+			if len(record.data) < 7 {
+				return nil, errors.New("invalid SRV record")
+			}
+			target, _, err := readDNSName(data, record.offset+6)
+			if err != nil {
+				return nil, err
+			}
+			srvNames = append(srvNames, name)
+			services[name] = srvRecord{
+				port:   binary.BigEndian.Uint16(record.data[4:]),
+				target: strings.ToLower(target),
+			}
+		case dnsTypeTXT:
+			texts[name] = parseTXT(record.data)
+		case dnsTypeA, dnsTypeAAAA:
+			if len(record.data) == net.IPv4len || len(record.data) == net.IPv6len {
+				addresses[name] = append(addresses[name], append(net.IP(nil), record.data...))
+			}
+		}
+	}
+
+	// Services answered without their PTR record are instances too
+	for _, name := range srvNames {
+		if _, ok := ttls[name]; !ok && strings.HasSuffix(name, "."+MDNSServiceType) {
+			ttls[name] = 0
+			instances = append(instances, name)
+		}
+	}
+
+	var result []*Service
+	for _, instance := range instances {
+		srv, ok := services[instance]
+		if !ok {
+			continue
+		}
+
+		ip := from
+		if ips := addresses[srv.target]; len(ips) > 0 {
+			ip = ips[0]
+		}
+		if ip == nil {
+			continue
+		}
+
+		service, err := mdnsService(ip, srv.port, texts[instance])
+		if err != nil {
+			continue
+		}
+		service.MaxAge = time.Duration(ttls[instance]) * time.Second
+		result = append(result, service)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no Redfish service in mDNS response")
+	}
+
+	return result, nil
+}
+
+// mdnsService makes the service reached at ip and port, with the keys of its
+// TXT record.
+func mdnsService(ip net.IP, port uint16, text map[string]string) (*Service, error) {
+	scheme := "https"
+	if port == 80 {
+		scheme = "http"
+	}
+
+	host := ip.String()
+	if ip.To4() == nil {
+		host = "[" + host + "]"
+	}
+	if port != 0 && port != 80 && port != 443 {
+		host = net.JoinHostPort(ip.String(), strconv.Itoa(int(port)))
+	}
+
+	path := text["path"]
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	service, err := newService(MDNSSource, scheme+"://"+host+path)
+	if err != nil {
+		return nil, err
+	}
+	service.UUID = text["uuid"]
+
+	return service, nil
+}
+
+// parseTXT parses the key=value strings of a TXT record. Keys are lower
+// cased, as they are case insensitive.
+func parseTXT(data []byte) map[string]string {
+	result := make(map[string]string)
+	for len(data) > 0 {
+		length := int(data[0])
+		if length+1 > len(data) {
+			break
+		}
+		entry := string(data[1 : length+1])
+		data = data[length+1:]
+
+		key, value := entry, ""
+		if i := strings.Index(entry, "="); i >= 0 {
+			key, value = entry[:i], entry[i+1:]
+		}
+		if key != "" {
+			result[strings.ToLower(key)] = value
+		}
+	}
+
+	return result
+}
+
+// parseDNSMessage reads the resource records of the answer, authority and
+// additional sections of a DNS response.
+func parseDNSMessage(data []byte) ([]dnsRecord, error) {
+	if len(data) < 12 {
+		return nil, errors.New("DNS message too short")
+	}
+	if data[2]&0x80 == 0 {
+		return nil, errors.New("not a DNS response")
+	}
+
+	questions := int(binary.BigEndian.Uint16(data[4:]))
+	count := int(binary.BigEndian.Uint16(data[6:])) +
+		int(binary.BigEndian.Uint16(data[8:])) +
+		int(binary.BigEndian.Uint16(data[10:]))
+
+	offset := 12
+	for i := 0; i < questions; i++ {
+		_, next, err := readDNSName(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset = next + 4
+	}
+
+	var result []dnsRecord
+	for i := 0; i < count; i++ {
+		name, next, err := readDNSName(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		if next+10 > len(data) {
+			return nil, errors.New("truncated DNS record")
+		}
+
+		length := int(binary.BigEndian.Uint16(data[next+8:]))
+		start := next + 10
+		if start+length > len(data) {
+			return nil, errors.New("truncated DNS record data")
+		}
+
+		result = append(result, dnsRecord{
+			name:   name,
+			kind:   binary.BigEndian.Uint16(data[next:]),
+			ttl:    binary.BigEndian.Uint32(data[next+4:]),
+			data:   data[start : start+length],
+			offset: start,
+		})
+		offset = start + length
+	}
+
+	return result, nil
+}
+
+// readDNSName reads the domain name at offset in a DNS message, following
+// compression pointers, and returns it with the offset that follows it.
+func readDNSName(data []byte, offset int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; ; {
+		if offset >= len(data) {
+			return "", 0, errors.New("truncated DNS name")
+		}
+
+		length := int(data[offset])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = offset + 1
+			}
+			return strings.Join(labels, ".") + ".", next, nil
+		case length&0xC0 == 0xC0:
+			if offset+1 >= len(data) {
+				return "", 0, errors.New("truncated DNS name pointer")
+			}
+			jumps++
+			if jumps > 16 {
+				return "", 0, errors.New("DNS name pointer loop")
+			}
+			if next < 0 {
+				next = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(data[offset:]) & 0x3FFF)
+		case length&0xC0 != 0:
+			return "", 0, fmt.Errorf("invalid DNS label length %#x", length)
+		default:
+			if offset+1+length > len(data) {
+				return "", 0, errors.New("truncated DNS label")
+			}
+			labels = append(labels, string(data[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+}
@@ -0,0 +1,125 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package discovery
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// mdnsRecord encodes a resource record for test answers.
+func mdnsRecord(name string, kind uint16, ttl uint32, data []byte) []byte {
+	record := dnsName(name)
+	record = binary.BigEndian.AppendUint16(record, kind)
+	record = binary.BigEndian.AppendUint16(record, dnsClassIN)
+	record = binary.BigEndian.AppendUint32(record, ttl)
+	record = binary.BigEndian.AppendUint16(record, uint16(len(data)))
+	return append(record, data...)
+}
+
+// mdnsResponse makes the answer of a BMC advertising its Redfish service,
+// with the SRV, TXT and A records in the additional section. The PTR record
+// points to the service type with a compression pointer.
+func mdnsResponse(id uint16) []byte {
+	message := make([]byte, 12)
+	binary.BigEndian.PutUint16(message[0:], id)
+	binary.BigEndian.PutUint16(message[2:], 0x8400)
+	binary.BigEndian.PutUint16(message[6:], 1)
+	binary.BigEndian.PutUint16(message[10:], 3)
+
+	instance := append([]byte{5}, "bmc-1"...)
+	instance = append(instance, 0xC0, 12)
+	message = append(message, mdnsRecord(MDNSServiceType, dnsTypePTR, 4500, instance)...)
+
+	srv := []byte{0, 0, 0, 0, 0x1F, 0x90}
+	srv = append(srv, dnsName("bmc-1.local.")...)
+	message = append(message, mdnsRecord("bmc-1."+MDNSServiceType, dnsTypeSRV, 120, srv)...)
+
+	txt := append([]byte{17}, "path=/redfish/v1/"...)
+	txt = append(txt, 41)
+	txt = append(txt, "UUID=92384634-2938-2342-8820-489239905423"...)
+	message = append(message, mdnsRecord("bmc-1."+MDNSServiceType, dnsTypeTXT, 4500, txt)...)
+
+	return append(message, mdnsRecord("bmc-1.local.", dnsTypeA, 120, []byte{192, 168, 0, 10})...)
+}
+
+// TestParseMDNSResponse tests the parsing of the answers to mDNS queries.
+func TestParseMDNSResponse(t *testing.T) {
+	services, err := ParseMDNSResponse(mdnsResponse(0), nil)
+	if err != nil {
+		t.Fatalf("Error parsing response: %s", err)
+	}
+
+	if len(services) != 1 {
+		t.Fatalf("Invalid services: %+v", services)
+	}
+	service := services[0]
+
+	if service.Endpoint != "https://192.168.0.10:8080" || service.ServiceRoot != "/redfish/v1/" {
+		t.Errorf("Invalid location: %s %s", service.Endpoint, service.ServiceRoot)
+	}
+
+	if service.UUID != "92384634-2938-2342-8820-489239905423" {
+		t.Errorf("Invalid UUID: %s", service.UUID)
+	}
+
+	if service.Source != MDNSSource || service.MaxAge != 4500*time.Second {
+		t.Errorf("Invalid service: %+v", service)
+	}
+
+	_, err = ParseMDNSResponse(mdnsQuery(1, MDNSServiceType), nil)
+	if err == nil {
+		t.Errorf("Queries should be refused")
+	}
+
+	_, err = ParseMDNSResponse(mdnsResponse(0)[:40], nil)
+	if err == nil {
+		t.Errorf("Truncated responses should be refused")
+	}
+}
+
+// TestMDNS tests querying for services.
+func TestMDNS(t *testing.T) {
+	responder, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error starting responder: %s", err)
+	}
+	defer responder.Close()
+
+	queries := make(chan []byte, 1)
+	go func() {
+		buffer := make([]byte, 1024)
+		n, addr, err := responder.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		queries <- append([]byte(nil), buffer[:n]...)
+
+		id := binary.BigEndian.Uint16(buffer)
+		responder.WriteTo(mdnsResponse(id), addr)
+		responder.WriteTo(mdnsResponse(id), addr)
+	}()
+
+	services, err := MDNS(context.Background(), MDNSOptions{
+		Address: responder.LocalAddr().String(),
+		Timeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Error querying services: %s", err)
+	}
+
+	query := <-queries
+	name, next, err := readDNSName(query, 12)
+	if err != nil || name != MDNSServiceType || binary.BigEndian.Uint16(query[next:]) != dnsTypePTR {
+		t.Errorf("Invalid query: %q %v", name, err)
+	}
+
+	if len(services) != 1 || services[0].Endpoint != "https://192.168.0.10:8080" {
+		t.Errorf("Invalid services: %+v", services)
+	}
+}
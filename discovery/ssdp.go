@@ -0,0 +1,141 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSDPSearchTarget is the search target of Redfish services. Services may
+// answer with a minor version appended, such as
+// urn:dmtf-org:service:redfish-rest:1:4.
+const SSDPSearchTarget = "urn:dmtf-org:service:redfish-rest:1"
+
+// SSDPAddress is the IPv4 multicast address of SSDP.
+const SSDPAddress = "239.255.255.250:1900"
+
+// defaultSSDPTimeout is how long responses are waited for by default.
+const defaultSSDPTimeout = 3 * time.Second
+
+// SSDPOptions controls an SSDP search.
+type SSDPOptions struct {
+	// Timeout is how long responses are waited for. It defaults to three
+	// seconds.
+	Timeout time.Duration
+	// Address is the address the M-SEARCH request is sent to. It defaults
+	// to SSDPAddress; a unicast address searches a single host.
+	Address string
+	// LocalAddress is the local address the request is sent from, to choose
+	// the network interface of the search. It defaults to any address.
+	LocalAddress string
+}
+
+// SSDP searches the network for Redfish services with an SSDP M-SEARCH
+// request, and returns the services that answered before the timeout or the
+// end of ctx, in the order they answered. A service answering several times
+// is returned once.
+func SSDP(ctx context.Context, options SSDPOptions) ([]Service, error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = defaultSSDPTimeout
+	}
+	address := options.Address
+	if address == "" {
+		address = SSDPAddress
+	}
+	local := options.LocalAddress
+	if local == "" {
+		local = ":0"
+	}
+
+	return query(ctx, address, local, timeout, ssdpSearchRequest(address, timeout),
+		func(data []byte, from net.Addr) []*Service {
+			// Answers from other services or malformed ones are skipped
+			service, err := ParseSSDPResponse(data)
+			if err != nil {
+				return nil
+			}
+			return []*Service{service}
+		})
+}
+
+// ssdpSearchRequest makes the M-SEARCH request for Redfish services. The MX
+// header, the time services may wait before answering, is kept within the
+// timeout.
+func ssdpSearchRequest(address string, timeout time.Duration) []byte {
+	mx := int(timeout / time.Second)
+	if mx < 1 {
+		mx = 1
+	}
+	if mx > 5 {
+		mx = 5
+	}
+
+	return []byte("M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + address + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: " + strconv.Itoa(mx) + "\r\n" +
+		"ST: " + SSDPSearchTarget + "\r\n" +
+		"\r\n")
+}
+
+// ParseSSDPResponse parses the answer of a service to an M-SEARCH request.
+// The service root of the service is read from the AL header and its UUID
+// from the USN header.
+func ParseSSDPResponse(data []byte) (*Service, error) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected SSDP response status: %s", resp.Status)
+	}
+
+	target := resp.Header.Get("ST")
+	if target != SSDPSearchTarget && !strings.HasPrefix(target, SSDPSearchTarget+":") {
+		return nil, fmt.Errorf("not a Redfish service: %s", target)
+	}
+
+	location := resp.Header.Get("AL")
+	if location == "" {
+		return nil, fmt.Errorf("SSDP response without a service root location")
+	}
+
+	service, err := newService(SSDPSource, location)
+	if err != nil {
+		return nil, err
+	}
+
+	// The USN is uuid:<UUID>::urn:dmtf-org:service:redfish-rest:1
+	usn := resp.Header.Get("USN")
+	if strings.HasPrefix(strings.ToLower(usn), "uuid:") {
+		service.UUID = strings.SplitN(usn[len("uuid:"):], "::", 2)[0]
+	}
+
+	for _, directive := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		name, value := directive, ""
+		if i := strings.Index(directive, "="); i >= 0 {
+			name, value = directive[:i], directive[i+1:]
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "max-age") {
+			seconds, err := strconv.Atoi(strings.TrimSpace(value))
+			if err == nil && seconds > 0 {
+				service.MaxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return service, nil
+}
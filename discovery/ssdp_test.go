@@ -0,0 +1,129 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package discovery
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/wbfishtest"
+)
+
+var ssdpResponse = "HTTP/1.1 200 OK\r\n" +
+	"CACHE-CONTROL: max-age=1800\r\n" +
+	"ST: urn:dmtf-org:service:redfish-rest:1:4\r\n" +
+	"USN: uuid:92384634-2938-2342-8820-489239905423::urn:dmtf-org:service:redfish-rest:1:4\r\n" +
+	"AL: https://192.168.0.10/redfish/v1/\r\n" +
+	"EXT:\r\n" +
+	"\r\n"
+
+// TestParseSSDPResponse tests the parsing of the answers to M-SEARCH
+// requests.
+func TestParseSSDPResponse(t *testing.T) {
+	service, err := ParseSSDPResponse([]byte(ssdpResponse))
+	if err != nil {
+		t.Fatalf("Error parsing response: %s", err)
+	}
+
+	if service.UUID != "92384634-2938-2342-8820-489239905423" {
+		t.Errorf("Invalid UUID: %s", service.UUID)
+	}
+
+	if service.Endpoint != "https://192.168.0.10" || service.ServiceRoot != "/redfish/v1/" {
+		t.Errorf("Invalid location: %s %s", service.Endpoint, service.ServiceRoot)
+	}
+
+	if service.Source != SSDPSource || service.MaxAge != 30*time.Minute {
+		t.Errorf("Invalid service: %+v", service)
+	}
+
+	_, err = ParseSSDPResponse([]byte("HTTP/1.1 200 OK\r\n" +
+		"ST: urn:schemas-upnp-org:device:MediaRenderer:1\r\n" +
+		"LOCATION: http://192.168.0.20/description.xml\r\n" +
+		"\r\n"))
+	if err == nil {
+		t.Errorf("Other services should be refused")
+	}
+
+	_, err = ParseSSDPResponse([]byte("HTTP/1.1 200 OK\r\nST: " + SSDPSearchTarget + "\r\n\r\n"))
+	if err == nil {
+		t.Errorf("Responses without a location should be refused")
+	}
+}
+
+// TestSSDP tests searching for services, with a responder answering twice
+// and with another service.
+func TestSSDP(t *testing.T) {
+	responder, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error starting responder: %s", err)
+	}
+	defer responder.Close()
+
+	requests := make(chan string, 1)
+	go func() {
+		buffer := make([]byte, 1024)
+		n, addr, err := responder.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		requests <- string(buffer[:n])
+
+		responder.WriteTo([]byte(ssdpResponse), addr)
+		responder.WriteTo([]byte(ssdpResponse), addr)
+		responder.WriteTo([]byte("HTTP/1.1 200 OK\r\nST: upnp:rootdevice\r\n\r\n"), addr)
+	}()
+
+	services, err := SSDP(context.Background(), SSDPOptions{
+		Address: responder.LocalAddr().String(),
+		Timeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Error searching services: %s", err)
+	}
+
+	request := <-requests
+	if !strings.HasPrefix(request, "M-SEARCH * HTTP/1.1\r\n") || !strings.Contains(request, "ST: "+SSDPSearchTarget+"\r\n") {
+		t.Errorf("Invalid M-SEARCH request: %q", request)
+	}
+
+	if len(services) != 1 || services[0].UUID != "92384634-2938-2342-8820-489239905423" {
+		t.Errorf("Invalid services: %+v", services)
+	}
+}
+
+// TestServiceConnect tests connecting to a service found.
+func TestServiceConnect(t *testing.T) {
+	server, err := wbfishtest.NewServer(map[string]string{
+		"/redfish/v1": `{
+			"@odata.id": "/redfish/v1",
+			"Id": "RootService",
+			"UUID": "92384634-2938-2342-8820-489239905423"
+		}`,
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+
+	service := Service{Endpoint: server.URL, ServiceRoot: "/redfish/v1/"}
+	client, err := service.Connect(wbfish.ClientConfig{Endpoint: "https://ignored"})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	root, err := wbfish.ServiceRoot(client)
+	if err != nil {
+		t.Fatalf("Error getting service root: %s", err)
+	}
+
+	if root.UUID != "92384634-2938-2342-8820-489239905423" {
+		t.Errorf("Invalid service root: %s", root.UUID)
+	}
+}
@@ -0,0 +1,71 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+package main
+
+import (
+	"fmt"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+func main() {
+	// Create a new instance of gofish client, ignoring self-signed certs
+	config := wbfish.ClientConfig{
+		Endpoint: "https://bmc-ip",
+		Username: "my-username",
+		Password: "my-password",
+		Insecure: true,
+	}
+	c, err := wbfish.Connect(config)
+	if err != nil {
+		panic(err)
+	}
+	defer c.Logout()
+
+	// Retrieve the service root
+	service := c.Service
+
+	// Query the computer systems
+	ss, err := service.Systems()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, system := range ss {
+		media, err := systemVirtualMedia(system)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, vm := range media {
+			fmt.Printf("System %s virtual media: %#v\n\n", system.ID, vm)
+		}
+	}
+}
+
+// systemVirtualMedia prefers the system-scoped virtual media collection and
+// falls back to the collections of the managers of the system, since some
+// firmware only populates one of them.
+func systemVirtualMedia(system *redfish.ComputerSystem) ([]*redfish.VirtualMedia, error) {
+	media, err := system.VirtualMedia()
+	if err != nil || len(media) > 0 {
+		return media, err
+	}
+
+	managers, err := system.ManagedBy()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manager := range managers {
+		managerMedia, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, managerMedia...)
+	}
+
+	return media, nil
+}
@@ -31,19 +31,10 @@ func main() {
 		panic(err)
 	}
 
-	// Creates a boot override to pxe once
-	bootOverride := redfish.Boot{
-		BootSourceOverrideTarget:  redfish.PxeBootSourceOverrideTarget,
-		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
-	}
-
 	for _, system := range ss {
 		fmt.Printf("System: %#v\n\n", system)
-		err := system.SetBoot(bootOverride)
-		if err != nil {
-			panic(err)
-		}
-		err = system.Reset(redfish.ForceRestartResetType)
+		// Boot from pxe once
+		err := system.BootOnce(redfish.PxeBootSourceOverrideTarget, redfish.ForceRestartResetType)
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,213 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package mockup exports the resource tree of a live service as a mockup: a
+// directory of index.json files laid out like the DMTF Redfish mockups. The
+// mockups are useful for bug reports and offline analysis, and can be served
+// with wbfishtest.NewServerFromDirectory.
+package mockup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// Options controls a crawl.
+type Options struct {
+	// Root is the URI the crawl starts from. It defaults to the service
+	// root.
+	Root string
+	// MaxResources limits the number of resources that are read. Zero means
+	// no limit.
+	MaxResources int
+	// Skip, if set, is called for each URI found. Resources it returns true
+	// for are not read.
+	Skip func(uri string) bool
+}
+
+// Result is the outcome of a crawl.
+type Result struct {
+	// Resources holds the JSON documents read, keyed by their URI.
+	Resources map[string]json.RawMessage
+	// Errors holds the errors of the resources that could not be read, keyed
+	// by their URI. The crawl continues past them.
+	Errors map[string]error
+}
+
+// URIs gets the URIs of the resources read, sorted.
+func (result *Result) URIs() []string {
+	var uris []string
+	for uri := range result.Resources {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	return uris
+}
+
+// Crawl reads every resource reachable from the root through @odata.id
+// references.
+func Crawl(c common.Client, options Options) *Result {
+	root := options.Root
+	if root == "" {
+		root = common.DefaultServiceRoot
+	}
+	root = normalizeURI(root)
+
+	result := &Result{
+		Resources: make(map[string]json.RawMessage),
+		Errors:    make(map[string]error),
+	}
+
+	seen := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		if options.MaxResources > 0 && len(result.Resources)+len(result.Errors) >= options.MaxResources {
+			break
+		}
+
+		uri := queue[0]
+		queue = queue[1:]
+
+		document, err := get(c, uri)
+		if err != nil {
+			result.Errors[uri] = err
+			continue
+		}
+		result.Resources[uri] = document
+
+		var value interface{}
+		if json.Unmarshal(document, &value) != nil {
+			continue
+		}
+
+		for _, reference := range references(value) {
+			reference = normalizeURI(reference)
+			if seen[reference] || !strings.HasPrefix(reference, "/redfish/") ||
+				strings.Contains(reference, "$metadata") {
+				continue
+			}
+			seen[reference] = true
+
+			if options.Skip != nil && options.Skip(reference) {
+				continue
+			}
+			queue = append(queue, reference)
+		}
+	}
+
+	return result
+}
+
+// Export crawls the service and writes the resources to a mockup directory.
+// Each resource is written to an index.json file in the directory matching its
+// URI, such as redfish/v1/Systems/1/index.json. Resources that could not be
+// read are listed in the result and are not written.
+//
+// The mockups are meant to be shared, so the resources are redacted as the
+// diagnostics of the client are, see common.RedactBody: the values of secret
+// properties, such as passwords, and the session URIs are replaced. Sessions
+// are not written, since their URI and Id let anyone act on them; the
+// session collection lists them with redacted URIs.
+func Export(c common.Client, dir string, options Options) (*Result, error) {
+	result := Crawl(c, options)
+
+	for _, uri := range result.URIs() {
+		if common.RedactURI(uri) != uri {
+			continue
+		}
+
+		path, err := filePath(dir, uri)
+		if err != nil {
+			return result, err
+		}
+
+		redacted := common.RedactBody(result.Resources[uri])
+		var document bytes.Buffer
+		err = json.Indent(&document, redacted, "", "    ")
+		if err != nil {
+			// Keep what the service sent, even if it is not valid JSON
+			document.Reset()
+			document.Write(redacted)
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return result, err
+		}
+
+		err = ioutil.WriteFile(path, document.Bytes(), 0644)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// get reads a resource.
+func get(c common.Client, uri string) (json.RawMessage, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// references finds the @odata.id references in a JSON value.
+func references(value interface{}) []string {
+	var result []string
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, property := range v {
+			if uri, ok := property.(string); ok && key == "@odata.id" {
+				result = append(result, uri)
+				continue
+			}
+			result = append(result, references(property)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			result = append(result, references(item)...)
+		}
+	}
+
+	return result
+}
+
+// normalizeURI removes the fragment, query and trailing slash of a URI.
+func normalizeURI(uri string) string {
+	if i := strings.IndexAny(uri, "#?"); i >= 0 {
+		uri = uri[:i]
+	}
+	if len(uri) > 1 {
+		uri = strings.TrimSuffix(uri, "/")
+	}
+
+	return uri
+}
+
+// filePath gets the path of the index.json file of a resource.
+func filePath(dir, uri string) (string, error) {
+	parts := []string{dir}
+	for _, segment := range strings.Split(strings.Trim(uri, "/"), "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, `\:`) {
+			return "", fmt.Errorf("unable to export resource %s, its URI is not a valid path", uri)
+		}
+		parts = append(parts, segment)
+	}
+
+	return filepath.Join(append(parts, "index.json")...), nil
+}
@@ -0,0 +1,280 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package mockup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/wbfishtest"
+)
+
+var serviceRootBody = `{
+		"@odata.type": "#ServiceRoot.v1_5_0.ServiceRoot",
+		"@odata.id": "/redfish/v1/",
+		"Id": "RootService",
+		"Name": "Root Service",
+		"RedfishVersion": "1.6.0",
+		"Systems": {
+			"@odata.id": "/redfish/v1/Systems"
+		},
+		"Chassis": {
+			"@odata.id": "/redfish/v1/Chassis"
+		}
+	}`
+
+var systemsBody = `{
+		"@odata.type": "#ComputerSystemCollection.ComputerSystemCollection",
+		"@odata.id": "/redfish/v1/Systems",
+		"Name": "Computer System Collection",
+		"Members@odata.count": 1,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Systems/1"
+			}
+		]
+	}`
+
+var systemBody = `{
+		"@odata.type": "#ComputerSystem.v1_10_0.ComputerSystem",
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"PowerState": "On",
+		"Links": {
+			"Chassis": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/1"
+				}
+			]
+		}
+	}`
+
+var chassisBody = `{
+		"@odata.type": "#Chassis.v1_10_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Name": "Chassis",
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1/Power"
+		},
+		"Links": {
+			"ComputerSystems": [
+				{
+					"@odata.id": "/redfish/v1/Systems/1"
+				}
+			]
+		}
+	}`
+
+var powerBody = `{
+		"@odata.type": "#Power.v1_5_0.Power",
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerControl": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerControl/0",
+				"PowerConsumedWatts": 120
+			}
+		]
+	}`
+
+func newTestServer(t *testing.T) *wbfishtest.Server {
+	server, err := wbfishtest.NewServer(map[string]string{
+		"/redfish/v1":                 serviceRootBody,
+		"/redfish/v1/Systems":         systemsBody,
+		"/redfish/v1/Systems/1":       systemBody,
+		"/redfish/v1/Chassis/1":       chassisBody,
+		"/redfish/v1/Chassis/1/Power": powerBody,
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+
+	return server
+}
+
+// TestCrawl tests walking the references of a service.
+func TestCrawl(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	result := Crawl(client, Options{})
+
+	expected := []string{
+		"/redfish/v1",
+		"/redfish/v1/Chassis/1",
+		"/redfish/v1/Chassis/1/Power",
+		"/redfish/v1/Systems",
+		"/redfish/v1/Systems/1",
+	}
+	if strings.Join(result.URIs(), " ") != strings.Join(expected, " ") {
+		t.Errorf("Unexpected resources: %v", result.URIs())
+	}
+
+	// The chassis collection is referenced but missing from the service
+	if len(result.Errors) != 1 || result.Errors["/redfish/v1/Chassis"] == nil {
+		t.Errorf("Unexpected errors: %v", result.Errors)
+	}
+
+	result = Crawl(client, Options{
+		Skip: func(uri string) bool {
+			return strings.HasPrefix(uri, "/redfish/v1/Chassis")
+		},
+	})
+	if len(result.Resources) != 3 || len(result.Errors) != 0 {
+		t.Errorf("Skipped resources should not be read: %v %v", result.URIs(), result.Errors)
+	}
+
+	result = Crawl(client, Options{MaxResources: 2})
+	if len(result.Resources)+len(result.Errors) != 2 {
+		t.Errorf("The crawl should stop at the limit: %v %v", result.URIs(), result.Errors)
+	}
+}
+
+// TestExport tests writing a mockup and serving it back.
+func TestExport(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "mockup")
+	if err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = Export(client, dir, Options{})
+	if err != nil {
+		t.Fatalf("Error exporting mockup: %s", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "redfish", "v1", "Chassis", "1", "Power", "index.json"))
+	if err != nil {
+		t.Errorf("The power resource should have been written: %s", err)
+	}
+
+	mockup, err := wbfishtest.NewServerFromDirectory(dir)
+	if err != nil {
+		t.Fatalf("Error serving mockup: %s", err)
+	}
+	defer mockup.Close()
+
+	if mockup.Resource("/redfish/v1/Systems/1")["PowerState"] != "On" {
+		t.Errorf("Unexpected system: %v", mockup.Resource("/redfish/v1/Systems/1"))
+	}
+}
+
+// TestExportRedaction tests that passwords and sessions are not written to
+// mockups.
+func TestExportRedaction(t *testing.T) {
+	server, err := wbfishtest.NewServer(map[string]string{
+		"/redfish/v1": `{
+			"@odata.id": "/redfish/v1",
+			"AccountService": {"@odata.id": "/redfish/v1/AccountService/Accounts/1"},
+			"SessionService": {"@odata.id": "/redfish/v1/SessionService/Sessions"}
+		}`,
+		"/redfish/v1/AccountService/Accounts/1": `{
+			"@odata.id": "/redfish/v1/AccountService/Accounts/1",
+			"Id": "1",
+			"UserName": "admin",
+			"Password": "account-secret"
+		}`,
+		"/redfish/v1/SessionService/Sessions": `{
+			"@odata.id": "/redfish/v1/SessionService/Sessions",
+			"Members@odata.count": 1,
+			"Members": [
+				{"@odata.id": "/redfish/v1/SessionService/Sessions/session-secret"}
+			]
+		}`,
+		"/redfish/v1/SessionService/Sessions/session-secret": `{
+			"@odata.id": "/redfish/v1/SessionService/Sessions/session-secret",
+			"Id": "session-secret",
+			"UserName": "admin",
+			"Password": null
+		}`,
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "mockup")
+	if err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	result, err := Export(client, dir, Options{})
+	if err != nil {
+		t.Fatalf("Error exporting mockup: %s", err)
+	}
+	if len(result.Resources) != 4 {
+		t.Errorf("Unexpected resources: %v %v", result.URIs(), result.Errors)
+	}
+
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.Contains(path, "session-secret") {
+			t.Errorf("The session should not be written: %s", path)
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		files = append(files, path)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, secret := range []string{"account-secret", "session-secret"} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("%s should be redacted from %s: %s", secret, path, data)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error reading mockup: %s", err)
+	}
+
+	if len(files) != 3 {
+		t.Errorf("Unexpected files: %v", files)
+	}
+}
+
+// TestFilePath tests that URIs cannot escape the mockup directory.
+func TestFilePath(t *testing.T) {
+	path, err := filePath("out", "/redfish/v1/Systems/1")
+	if err != nil || path != filepath.Join("out", "redfish", "v1", "Systems", "1", "index.json") {
+		t.Errorf("Unexpected path: %s %v", path, err)
+	}
+
+	_, err = filePath("out", "/redfish/v1/../../etc")
+	if err == nil {
+		t.Error("URIs with parent segments should be rejected")
+	}
+}
@@ -0,0 +1,97 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package cisco
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var (
+	biosTokensLock sync.RWMutex
+	// biosTokens maps the BIOS token names used by the Cisco IMC XML API and
+	// UCS Manager policies to the names of the Redfish BIOS attributes.
+	biosTokens = map[string]string{
+		"vpCPUPerformance":                "CpuPerformance",
+		"vpEnhancedIntelSpeedStepTech":    "EnhancedIntelSpeedStep",
+		"vpIntelHyperThreadingTech":       "IntelHyperThread",
+		"vpIntelTurboBoostTech":           "IntelTurboBoostTech",
+		"vpIntelVTForDirectedIO":          "IntelVTD",
+		"vpIntelVirtualizationTechnology": "IntelVT",
+		"vpPackageCStateLimit":            "PackageCstateLimit",
+		"vpProcessorC1E":                  "ProcessorC1E",
+		"vpProcessorC6Report":             "ProcessorC6Report",
+		"vpPwrPerfTuning":                 "PwrPerfTuning",
+		"vpSrIov":                         "SrIov",
+		"vpTPMControl":                    "TPMControl",
+		"vpUsbPortFront":                  "UsbPortFront",
+	}
+)
+
+// RegisterBIOSToken adds or replaces the mapping of a Cisco IMC BIOS token to
+// a Redfish BIOS attribute, for tokens not known to this package.
+func RegisterBIOSToken(token, attribute string) {
+	biosTokensLock.Lock()
+	defer biosTokensLock.Unlock()
+
+	biosTokens[token] = attribute
+}
+
+// BIOSAttribute gets the name of the Redfish BIOS attribute for a Cisco IMC
+// BIOS token.
+func BIOSAttribute(token string) (string, bool) {
+	biosTokensLock.RLock()
+	defer biosTokensLock.RUnlock()
+
+	attribute, ok := biosTokens[token]
+	return attribute, ok
+}
+
+// BIOSToken gets the Cisco IMC BIOS token for a Redfish BIOS attribute.
+func BIOSToken(attribute string) (string, bool) {
+	biosTokensLock.RLock()
+	defer biosTokensLock.RUnlock()
+
+	for token, name := range biosTokens {
+		if name == attribute {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+// BIOSTokens gets the BIOS attributes keyed by their Cisco IMC BIOS token.
+// Attributes without a known token are kept under their Redfish name.
+func BIOSTokens(bios *redfish.Bios) map[string]interface{} {
+	result := make(map[string]interface{})
+	for attribute, value := range bios.Attributes {
+		if token, ok := BIOSToken(attribute); ok {
+			result[token] = value
+		} else {
+			result[attribute] = value
+		}
+	}
+
+	return result
+}
+
+// UpdateBIOSTokens sets BIOS attributes given by their Cisco IMC BIOS token,
+// so settings exported from UCS Manager policies can be applied over Redfish.
+// The values are sent as given and shall use the Redfish attribute values.
+func UpdateBIOSTokens(bios *redfish.Bios, tokens map[string]interface{}) error {
+	attrs := make(redfish.BiosAttributes)
+	for token, value := range tokens {
+		attribute, ok := BIOSAttribute(token)
+		if !ok {
+			return fmt.Errorf("unknown BIOS token %s", token)
+		}
+		attrs[attribute] = value
+	}
+
+	return bios.UpdateBiosAttributes(attrs)
+}
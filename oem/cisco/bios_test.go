@@ -0,0 +1,81 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package cisco
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var biosBody = `{
+		"@odata.type": "#Bios.v1_1_0.Bios",
+		"@odata.id": "/redfish/v1/Systems/WZP21330T9K/Bios",
+		"Id": "Bios",
+		"Name": "BIOS Configuration Current Settings",
+		"AttributeRegistry": "CiscoBiosAttributeRegistry.v1_0_0",
+		"Attributes": {
+			"IntelHyperThread": "Enabled",
+			"IntelVTD": "Disabled",
+			"CiscoAdaptiveMemTraining": "Enabled"
+		}
+	}`
+
+// TestBIOSTokens tests reading BIOS attributes by their Cisco IMC token.
+func TestBIOSTokens(t *testing.T) {
+	var bios redfish.Bios
+	err := json.NewDecoder(strings.NewReader(biosBody)).Decode(&bios)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	tokens := BIOSTokens(&bios)
+
+	if tokens["vpIntelHyperThreadingTech"] != "Enabled" {
+		t.Errorf("Invalid hyper-threading token: %v", tokens["vpIntelHyperThreadingTech"])
+	}
+
+	if tokens["CiscoAdaptiveMemTraining"] != "Enabled" {
+		t.Errorf("Attributes without a token should keep their name: %v", tokens)
+	}
+
+	if attribute, ok := BIOSAttribute("vpIntelVTForDirectedIO"); !ok || attribute != "IntelVTD" {
+		t.Errorf("Invalid VT-d attribute: %s", attribute)
+	}
+}
+
+// TestUpdateBIOSTokens tests setting BIOS attributes by their Cisco IMC
+// token.
+func TestUpdateBIOSTokens(t *testing.T) {
+	var bios redfish.Bios
+	err := json.NewDecoder(strings.NewReader(biosBody)).Decode(&bios)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	bios.SetClient(testClient)
+
+	err = UpdateBIOSTokens(&bios, map[string]interface{}{"vpIntelVTForDirectedIO": "Enabled"})
+	if err != nil {
+		t.Errorf("Error making UpdateBIOSTokens call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Payload != "{map[IntelVTD:Enabled]}" {
+		t.Errorf("Unexpected UpdateBIOSTokens calls: %v", calls)
+	}
+
+	err = UpdateBIOSTokens(&bios, map[string]interface{}{"vpUnknownToken": "Enabled"})
+	if err == nil {
+		t.Error("Unknown tokens should be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package cisco
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+func init() {
+	common.RegisterOEMDecoder("Cisco", "CiscoNetworkAdapter.CiscoNetworkAdapter", decodeNetworkAdapterOEM)
+}
+
+// VICConfiguration is the configuration of a Cisco Virtual Interface Card.
+// Changes are applied on the next host reboot.
+type VICConfiguration struct {
+	// FIPMode is whether FCoE Initialization Protocol mode is enabled.
+	FIPMode string `json:"FipMode"`
+	// LLDPEnabled is whether the Link Layer Discovery Protocol is enabled.
+	LLDPEnabled bool `json:"LldpEnabled"`
+	// NIVMode is whether Network Interface Virtualization mode is enabled.
+	NIVMode string `json:"NivMode"`
+	// PhysicalNICMode is whether the uplink ports are passed to the host as
+	// physical NICs.
+	PhysicalNICMode string `json:"PhysicalNicMode"`
+	// PortChannelEnabled is whether the uplink ports are bundled into a
+	// port channel.
+	PortChannelEnabled bool
+}
+
+// NetworkAdapterOEM is the Cisco object in the Oem property of a network
+// adapter.
+type NetworkAdapterOEM struct {
+	// VICConfiguration is the configuration of the adapter if it is a Cisco
+	// Virtual Interface Card.
+	VICConfiguration VICConfiguration `json:"VicConfiguration"`
+}
+
+// decodeNetworkAdapterOEM decodes the Cisco object of a network adapter.
+func decodeNetworkAdapterOEM(data json.RawMessage) (interface{}, error) {
+	var result NetworkAdapterOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// networkAdapterOEM gets the Cisco object of a network adapter, decoding it
+// directly if the service did not give it the registered @odata.type.
+func networkAdapterOEM(oem common.OEM) (*NetworkAdapterOEM, error) {
+	err := oem.Error("Cisco")
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := oem.Get("Cisco"); ok {
+		if result, ok := value.(*NetworkAdapterOEM); ok {
+			return result, nil
+		}
+	}
+
+	if oem.Raw("Cisco") == nil {
+		return &NetworkAdapterOEM{}, nil
+	}
+
+	value, err := decodeNetworkAdapterOEM(oem.Raw("Cisco"))
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*NetworkAdapterOEM), nil
+}
+
+// NetworkAdapter is a Cisco network adapter with the Cisco OEM extensions.
+type NetworkAdapter struct {
+	*redfish.NetworkAdapter
+
+	// VICConfiguration is the configuration of the adapter if it is a Cisco
+	// Virtual Interface Card.
+	VICConfiguration VICConfiguration
+	// original is the VIC configuration as read from the service.
+	original VICConfiguration
+}
+
+// FromNetworkAdapter gets the Cisco OEM data of a network adapter.
+func FromNetworkAdapter(networkadapter *redfish.NetworkAdapter) (*NetworkAdapter, error) {
+	var t struct {
+		Oem common.OEM
+	}
+
+	err := json.Unmarshal(networkadapter.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	oem, err := networkAdapterOEM(t.Oem)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NetworkAdapter{
+		NetworkAdapter:   networkadapter,
+		VICConfiguration: oem.VICConfiguration,
+		original:         oem.VICConfiguration,
+	}, nil
+}
+
+// Update commits changes to the VIC configuration to the running system.
+func (networkadapter *NetworkAdapter) Update() error {
+	changes := make(map[string]interface{})
+
+	originalElement := reflect.ValueOf(networkadapter.original)
+	currentElement := reflect.ValueOf(networkadapter.VICConfiguration)
+	for i := 0; i < currentElement.NumField(); i++ {
+		if originalElement.Field(i).Interface() == currentElement.Field(i).Interface() {
+			continue
+		}
+
+		field := currentElement.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		changes[name] = currentElement.Field(i).Interface()
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"Oem": map[string]interface{}{
+			"Cisco": map[string]interface{}{
+				"VicConfiguration": changes,
+			},
+		},
+	}
+
+	_, err := networkadapter.Client.Patch(networkadapter.ODataID, payload)
+	if err != nil {
+		return err
+	}
+
+	networkadapter.original = networkadapter.VICConfiguration
+	return nil
+}
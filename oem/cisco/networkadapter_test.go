@@ -0,0 +1,128 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package cisco
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var networkAdapterBody = `{
+		"@odata.type": "#NetworkAdapter.v1_0_1.NetworkAdapter",
+		"@odata.id": "/redfish/v1/Chassis/1/NetworkAdapters/UCSC-PCIE-C25Q-04_FCH233770L3",
+		"Id": "UCSC-PCIE-C25Q-04_FCH233770L3",
+		"Name": "Cisco VIC 1455",
+		"Manufacturer": "Cisco Systems Inc",
+		"Oem": {
+			"Cisco": {
+				"VicConfiguration": {
+					"FipMode": "Enabled",
+					"LldpEnabled": true,
+					"NivMode": "Disabled",
+					"PhysicalNicMode": "Disabled",
+					"PortChannelEnabled": true
+				}
+			}
+		}
+	}`
+
+// TestNetworkAdapter tests getting the Cisco OEM data of a network adapter.
+func TestNetworkAdapter(t *testing.T) {
+	var networkadapter redfish.NetworkAdapter
+	err := json.NewDecoder(strings.NewReader(networkAdapterBody)).Decode(&networkadapter)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromNetworkAdapter(&networkadapter)
+	if err != nil {
+		t.Errorf("Error getting Cisco network adapter: %s", err)
+	}
+
+	if result.Manufacturer != "Cisco Systems Inc" {
+		t.Errorf("Invalid manufacturer: %s", result.Manufacturer)
+	}
+
+	if result.VICConfiguration.FIPMode != "Enabled" {
+		t.Errorf("Invalid FIP mode: %s", result.VICConfiguration.FIPMode)
+	}
+
+	if !result.VICConfiguration.LLDPEnabled || !result.VICConfiguration.PortChannelEnabled {
+		t.Errorf("Invalid VIC configuration: %+v", result.VICConfiguration)
+	}
+}
+
+// TestNetworkAdapterUpdate tests updating the VIC configuration.
+func TestNetworkAdapterUpdate(t *testing.T) {
+	var networkadapter redfish.NetworkAdapter
+	err := json.NewDecoder(strings.NewReader(networkAdapterBody)).Decode(&networkadapter)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromNetworkAdapter(&networkadapter)
+	if err != nil {
+		t.Errorf("Error getting Cisco network adapter: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Update()
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Nothing should be sent without changes: %v", testClient.CapturedCalls())
+	}
+
+	result.VICConfiguration.PortChannelEnabled = false
+	err = result.Update()
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Payload != "map[Oem:map[Cisco:map[VicConfiguration:map[PortChannelEnabled:false]]]]" {
+		t.Errorf("Unexpected Update calls: %v", calls)
+	}
+}
+
+// TestNetworkAdapterOEMDecoder tests the registered decoder hydrates Cisco
+// objects of the network adapter type.
+func TestNetworkAdapterOEMDecoder(t *testing.T) {
+	var result common.OEM
+	err := json.NewDecoder(strings.NewReader(`{
+			"Cisco": {
+				"@odata.type": "#CiscoNetworkAdapter.v1_0_0.CiscoNetworkAdapter",
+				"VicConfiguration": {
+					"FipMode": "Disabled",
+					"PortChannelEnabled": true
+				}
+			}
+		}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	value, ok := result.Get("Cisco")
+	if !ok {
+		t.Fatal("Cisco data should be decoded")
+	}
+
+	oem, ok := value.(*NetworkAdapterOEM)
+	if !ok || oem.VICConfiguration.FIPMode != "Disabled" || !oem.VICConfiguration.PortChannelEnabled {
+		t.Errorf("Invalid decoded Cisco data: %+v", value)
+	}
+}
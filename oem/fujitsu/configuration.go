@@ -0,0 +1,115 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// Configuration holds the iRMC settings. The available settings depend on
+// the iRMC generation and firmware, so they are kept by name.
+type Configuration struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Settings are the iRMC settings by name.
+	Settings map[string]interface{}
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (configuration *Configuration) GetRawData() []byte {
+	return configuration.rawData
+}
+
+// UnmarshalJSON unmarshals a Configuration object from the raw JSON.
+func (configuration *Configuration) UnmarshalJSON(b []byte) error {
+	type temp Configuration
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	var properties map[string]interface{}
+	err = json.Unmarshal(b, &properties)
+	if err != nil {
+		return err
+	}
+
+	*configuration = Configuration(t.temp)
+
+	configuration.Settings = make(map[string]interface{})
+	for name, value := range properties {
+		switch {
+		case strings.Contains(name, "@"),
+			name == "Id", name == "Name", name == "Description", name == "Actions", name == "Links":
+			continue
+		}
+		configuration.Settings[name] = value
+	}
+
+	configuration.rawData = b
+	configuration.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// String gets the value of a string setting.
+func (configuration *Configuration) String(name string) string {
+	if value, ok := configuration.Settings[name].(string); ok {
+		return value
+	}
+
+	return ""
+}
+
+// Bool gets the value of a boolean setting.
+func (configuration *Configuration) Bool(name string) bool {
+	if value, ok := configuration.Settings[name].(bool); ok {
+		return value
+	}
+
+	return false
+}
+
+// UpdateSettings changes the given iRMC settings.
+func (configuration *Configuration) UpdateSettings(settings map[string]interface{}) error {
+	_, err := configuration.Client.Patch(configuration.ODataID, settings)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range settings {
+		configuration.Settings[name] = value
+	}
+
+	return nil
+}
+
+// GetConfiguration will get a Configuration instance from the service.
+func GetConfiguration(c common.Client, uri string) (*Configuration, error) {
+	var configuration Configuration
+	err := common.FetchResource(c, uri, &configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
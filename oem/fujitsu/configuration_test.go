@@ -0,0 +1,73 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var configurationBody = `{
+		"@odata.type": "#FTSiRMCConfiguration.v1_0_0.FTSiRMCConfiguration",
+		"@odata.id": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/iRMCConfiguration",
+		"Id": "iRMCConfiguration",
+		"Name": "iRMC Configuration",
+		"HostName": "irmc-rack12",
+		"DhcpEnabled": false,
+		"AssetTag@Redfish.AllowableValues": []
+	}`
+
+// TestConfiguration tests the parsing of Configuration objects.
+func TestConfiguration(t *testing.T) {
+	var result Configuration
+	err := json.NewDecoder(strings.NewReader(configurationBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.Settings) != 2 {
+		t.Errorf("Invalid settings: %v", result.Settings)
+	}
+
+	if result.String("HostName") != "irmc-rack12" {
+		t.Errorf("Invalid host name: %s", result.String("HostName"))
+	}
+
+	if result.Bool("DhcpEnabled") {
+		t.Error("DHCP should be disabled")
+	}
+}
+
+// TestConfigurationUpdateSettings tests changing iRMC settings.
+func TestConfigurationUpdateSettings(t *testing.T) {
+	var result Configuration
+	err := json.NewDecoder(strings.NewReader(configurationBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.UpdateSettings(map[string]interface{}{"DhcpEnabled": true})
+	if err != nil {
+		t.Errorf("Error making UpdateSettings call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != result.ODataID || calls[0].Payload != "map[DhcpEnabled:true]" {
+		t.Errorf("Unexpected UpdateSettings calls: %v", calls)
+	}
+
+	if !result.Bool("DhcpEnabled") {
+		t.Error("DHCP should be enabled after the update")
+	}
+}
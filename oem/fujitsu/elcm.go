@@ -0,0 +1,162 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ELCMDeployParameters are the parameters of the FTSeLCMService.Deploy
+// action, which provisions an operating system.
+type ELCMDeployParameters struct {
+	// ProfileName is the name of the stored provisioning profile describing
+	// the installation.
+	ProfileName string
+	// Profile is an inline provisioning profile, used instead of a stored
+	// one.
+	Profile interface{} `json:",omitempty"`
+}
+
+// ELCMService is the embedded Lifecycle Management service of an iRMC. It
+// provisions operating systems and updates firmware and drivers from the
+// update repository.
+type ELCMService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Licensed is whether an eLCM license is installed.
+	Licensed bool
+	// ProvisioningStatus is the status of the last provisioning operation.
+	ProvisioningStatus string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UpdateRepositoryURL is the URL of the repository firmware and driver
+	// updates are read from.
+	UpdateRepositoryURL string
+	// deployTarget is the URL to send Deploy actions to.
+	deployTarget string
+	// offlineUpdateTarget is the URL to send OfflineUpdate actions to.
+	offlineUpdateTarget string
+	// onlineUpdateTarget is the URL to send OnlineUpdate actions to.
+	onlineUpdateTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (elcmservice *ELCMService) GetRawData() []byte {
+	return elcmservice.rawData
+}
+
+// UnmarshalJSON unmarshals an ELCMService object from the raw JSON.
+func (elcmservice *ELCMService) UnmarshalJSON(b []byte) error {
+	type temp ELCMService
+	type Actions struct {
+		Deploy struct {
+			Target string
+		} `json:"#FTSeLCMService.Deploy"`
+		OfflineUpdate struct {
+			Target string
+		} `json:"#FTSeLCMService.OfflineUpdate"`
+		OnlineUpdate struct {
+			Target string
+		} `json:"#FTSeLCMService.OnlineUpdate"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*elcmservice = ELCMService(t.temp)
+
+	// Extract the links to other entities for later
+	elcmservice.deployTarget = t.Actions.Deploy.Target
+	elcmservice.offlineUpdateTarget = t.Actions.OfflineUpdate.Target
+	elcmservice.onlineUpdateTarget = t.Actions.OnlineUpdate.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	elcmservice.rawData = b
+	elcmservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (elcmservice *ELCMService) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(ELCMService)
+	original.UnmarshalJSON(elcmservice.rawData)
+
+	readWriteFields := []string{
+		"UpdateRepositoryURL",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(elcmservice).Elem()
+
+	return elcmservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetELCMService will get an ELCMService instance from the service.
+func GetELCMService(c common.Client, uri string) (*ELCMService, error) {
+	var elcmservice ELCMService
+	err := common.FetchResource(c, uri, &elcmservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &elcmservice, nil
+}
+
+// Deploy shall provision an operating system as described by a provisioning
+// profile. The system is rebooted into the eLCM deployment environment.
+func (elcmservice *ELCMService) Deploy(parameters *ELCMDeployParameters) error {
+	if elcmservice.deployTarget == "" {
+		return fmt.Errorf("Deploy is not supported by this eLCM service")
+	}
+
+	_, err := elcmservice.Client.Post(elcmservice.deployTarget, parameters)
+	return err
+}
+
+// OfflineUpdate shall update firmware from the update repository after
+// rebooting the system into the eLCM update environment.
+func (elcmservice *ELCMService) OfflineUpdate() error {
+	if elcmservice.offlineUpdateTarget == "" {
+		return fmt.Errorf("OfflineUpdate is not supported by this eLCM service")
+	}
+
+	_, err := elcmservice.Client.Post(elcmservice.offlineUpdateTarget, struct{}{})
+	return err
+}
+
+// OnlineUpdate shall update firmware and drivers from the update repository
+// while the operating system is running.
+func (elcmservice *ELCMService) OnlineUpdate() error {
+	if elcmservice.onlineUpdateTarget == "" {
+		return fmt.Errorf("OnlineUpdate is not supported by this eLCM service")
+	}
+
+	_, err := elcmservice.Client.Post(elcmservice.onlineUpdateTarget, struct{}{})
+	return err
+}
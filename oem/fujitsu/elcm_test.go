@@ -0,0 +1,125 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var elcmServiceBody = `{
+		"@odata.type": "#FTSeLCMService.v1_0_0.FTSeLCMService",
+		"@odata.id": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM",
+		"Id": "eLCM",
+		"Name": "eLCM Service",
+		"Licensed": true,
+		"ProvisioningStatus": "Idle",
+		"UpdateRepositoryURL": "https://support.ts.fujitsu.com",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Actions": {
+			"#FTSeLCMService.Deploy": {
+				"target": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM/Actions/FTSeLCMService.Deploy"
+			},
+			"#FTSeLCMService.OnlineUpdate": {
+				"target": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM/Actions/FTSeLCMService.OnlineUpdate"
+			}
+		}
+	}`
+
+// TestELCMService tests the parsing of ELCMService objects.
+func TestELCMService(t *testing.T) {
+	var result ELCMService
+	err := json.NewDecoder(strings.NewReader(elcmServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if !result.Licensed {
+		t.Error("eLCM should be licensed")
+	}
+
+	if result.ProvisioningStatus != "Idle" {
+		t.Errorf("Invalid provisioning status: %s", result.ProvisioningStatus)
+	}
+
+	if result.deployTarget != "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM/Actions/FTSeLCMService.Deploy" {
+		t.Errorf("Invalid Deploy target: %s", result.deployTarget)
+	}
+}
+
+// TestELCMServiceActions tests the eLCM provisioning and update actions.
+func TestELCMServiceActions(t *testing.T) {
+	var result ELCMService
+	err := json.NewDecoder(strings.NewReader(elcmServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Deploy(&ELCMDeployParameters{ProfileName: "RHEL9"})
+	if err != nil {
+		t.Errorf("Error making Deploy call: %s", err)
+	}
+
+	err = result.OnlineUpdate()
+	if err != nil {
+		t.Errorf("Error making OnlineUpdate call: %s", err)
+	}
+
+	err = result.OfflineUpdate()
+	if err == nil {
+		t.Error("OfflineUpdate should fail when the action is not advertised")
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.deployTarget || calls[0].Payload != "&{RHEL9 <nil>}" {
+		t.Errorf("Unexpected Deploy call: %v", calls[0])
+	}
+
+	if calls[1].URL != result.onlineUpdateTarget {
+		t.Errorf("Unexpected OnlineUpdate target: %s", calls[1].URL)
+	}
+}
+
+// TestELCMServiceUpdate tests the Update call.
+func TestELCMServiceUpdate(t *testing.T) {
+	var result ELCMService
+	err := json.NewDecoder(strings.NewReader(elcmServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.UpdateRepositoryURL = "https://repo.example.org/primergy"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "UpdateRepositoryURL:https://repo.example.org/primergy") {
+		t.Errorf("Unexpected UpdateRepositoryURL update payload: %s", calls[0].Payload)
+	}
+}
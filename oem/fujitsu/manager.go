@@ -0,0 +1,110 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+func init() {
+	common.RegisterOEMDecoder("ts_fujitsu", "FTSManager.FTSManager", decodeManagerOEM)
+}
+
+// ManagerOEM is the Fujitsu object in the Oem property of a manager.
+type ManagerOEM struct {
+	// FirmwareRunning is the iRMC firmware image that is running.
+	FirmwareRunning string
+	// SDCardMounted is whether the SD card holding the eLCM data is present.
+	SDCardMounted bool
+	// IRMCConfiguration is a link to the iRMC settings.
+	IRMCConfiguration common.Link `json:"iRMCConfiguration"`
+	// ELCM is a link to the embedded Lifecycle Management service.
+	ELCM common.Link `json:"eLCM"`
+}
+
+// decodeManagerOEM decodes the Fujitsu object of a manager.
+func decodeManagerOEM(data json.RawMessage) (interface{}, error) {
+	var result ManagerOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// Manager is an iRMC manager with the Fujitsu OEM extensions. Fujitsu keeps
+// its OEM data under the "ts_fujitsu" key.
+type Manager struct {
+	*redfish.Manager
+
+	// FirmwareRunning is the iRMC firmware image that is running.
+	FirmwareRunning string
+	// SDCardMounted is whether the SD card holding the eLCM data is present.
+	SDCardMounted bool
+	// configuration is a link to the iRMC settings.
+	configuration string
+	// elcm is a link to the embedded Lifecycle Management service.
+	elcm string
+}
+
+// FromManager gets the Fujitsu OEM data of a manager.
+func FromManager(manager *redfish.Manager) (*Manager, error) {
+	oem, err := managerOEM(manager.Oem)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		Manager:         manager,
+		FirmwareRunning: oem.FirmwareRunning,
+		SDCardMounted:   oem.SDCardMounted,
+		configuration:   string(oem.IRMCConfiguration),
+		elcm:            string(oem.ELCM),
+	}, nil
+}
+
+// managerOEM gets the Fujitsu object of a manager, decoding it directly if
+// the service did not give it the registered @odata.type.
+func managerOEM(oem common.OEM) (*ManagerOEM, error) {
+	err := oem.Error("ts_fujitsu")
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := oem.Get("ts_fujitsu"); ok {
+		if result, ok := value.(*ManagerOEM); ok {
+			return result, nil
+		}
+	}
+
+	if oem.Raw("ts_fujitsu") == nil {
+		return &ManagerOEM{}, nil
+	}
+
+	value, err := decodeManagerOEM(oem.Raw("ts_fujitsu"))
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*ManagerOEM), nil
+}
+
+// Configuration gets the iRMC settings.
+func (manager *Manager) Configuration() (*Configuration, error) {
+	if manager.configuration == "" {
+		return nil, nil
+	}
+
+	return GetConfiguration(manager.Client, manager.configuration)
+}
+
+// ELCMService gets the embedded Lifecycle Management service.
+func (manager *Manager) ELCMService() (*ELCMService, error) {
+	if manager.elcm == "" {
+		return nil, nil
+	}
+
+	return GetELCMService(manager.Client, manager.elcm)
+}
@@ -0,0 +1,87 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package fujitsu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var managerBody = `{
+		"@odata.type": "#Manager.v1_5_0.Manager",
+		"@odata.id": "/redfish/v1/Managers/iRMC",
+		"Id": "iRMC",
+		"Name": "Manager",
+		"ManagerType": "BMC",
+		"Model": "iRMC S5",
+		"Oem": {
+			"ts_fujitsu": {
+				"@odata.type": "#FTSManager.v1_0_0.FTSManager",
+				"FirmwareRunning": "LowFWImage",
+				"SDCardMounted": true,
+				"iRMCConfiguration": {
+					"@odata.id": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/iRMCConfiguration"
+				},
+				"eLCM": {
+					"@odata.id": "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM"
+				}
+			}
+		}
+	}`
+
+// TestFromManager tests getting the Fujitsu OEM data of a manager.
+func TestFromManager(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Errorf("Error getting Fujitsu manager: %s", err)
+	}
+
+	if result.Model != "iRMC S5" {
+		t.Errorf("Invalid model: %s", result.Model)
+	}
+
+	if result.FirmwareRunning != "LowFWImage" || !result.SDCardMounted {
+		t.Errorf("Invalid Fujitsu manager data: %s %t", result.FirmwareRunning, result.SDCardMounted)
+	}
+
+	if result.configuration != "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/iRMCConfiguration" {
+		t.Errorf("Invalid configuration link: %s", result.configuration)
+	}
+
+	if result.elcm != "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM" {
+		t.Errorf("Invalid eLCM link: %s", result.elcm)
+	}
+}
+
+// TestManagerOEMDecoder tests the registered decoder hydrates the Fujitsu
+// object of a manager.
+func TestManagerOEMDecoder(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	value, ok := manager.Oem.Get("ts_fujitsu")
+	if !ok {
+		t.Fatal("Fujitsu data should be decoded")
+	}
+
+	oem, ok := value.(*ManagerOEM)
+	if !ok || oem.FirmwareRunning != "LowFWImage" || oem.ELCM != "/redfish/v1/Managers/iRMC/Oem/ts_fujitsu/eLCM" {
+		t.Errorf("Invalid decoded Fujitsu data: %+v", value)
+	}
+}
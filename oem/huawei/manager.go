@@ -0,0 +1,61 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// ManagerOEM holds the properties Huawei adds to managers.
+type ManagerOEM struct {
+	// BMCUpTime is how long the iBMC has been running.
+	BMCUpTime string
+	// ProductUniqueID is the unique identifier of the server product.
+	ProductUniqueID string
+	// SPService is a link to the Smart Provisioning service.
+	SPService common.Link
+}
+
+// Manager is an iBMC manager with the Huawei OEM extensions.
+type Manager struct {
+	*redfish.Manager
+
+	// BMCUpTime is how long the iBMC has been running.
+	BMCUpTime string
+	// ProductUniqueID is the unique identifier of the server product.
+	ProductUniqueID string
+	// spService is a link to the Smart Provisioning service.
+	spService string
+}
+
+// FromManager gets the Huawei OEM data of a manager.
+func FromManager(manager *redfish.Manager) (*Manager, error) {
+	value, err := huaweiOEM(manager.Oem, decodeManagerOEM)
+	if err != nil {
+		return nil, err
+	}
+
+	oem, ok := value.(*ManagerOEM)
+	if !ok {
+		return nil, unexpectedOEMError(value)
+	}
+
+	return &Manager{
+		Manager:         manager,
+		BMCUpTime:       oem.BMCUpTime,
+		ProductUniqueID: oem.ProductUniqueID,
+		spService:       string(oem.SPService),
+	}, nil
+}
+
+// SPService gets the Smart Provisioning service of the iBMC.
+func (manager *Manager) SPService() (*SPService, error) {
+	if manager.spService == "" {
+		return nil, nil
+	}
+
+	return GetSPService(manager.Client, manager.spService)
+}
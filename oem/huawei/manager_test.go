@@ -0,0 +1,86 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var managerBody = `{
+		"@odata.type": "#Manager.v1_3_1.Manager",
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"Name": "Manager",
+		"ManagerType": "BMC",
+		"Oem": {
+			"Huawei": {
+				"BMCUpTime": "12 days 04:11:53",
+				"ProductUniqueID": "0x02010101",
+				"SPService": {
+					"@odata.id": "/redfish/v1/Managers/1/SPService"
+				}
+			}
+		}
+	}`
+
+// TestFromManager tests getting the Huawei OEM data of a manager.
+func TestFromManager(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Errorf("Error getting Huawei manager: %s", err)
+	}
+
+	if result.ProductUniqueID != "0x02010101" {
+		t.Errorf("Invalid product unique ID: %s", result.ProductUniqueID)
+	}
+
+	if result.spService != "/redfish/v1/Managers/1/SPService" {
+		t.Errorf("Invalid SP service link: %s", result.spService)
+	}
+}
+
+// TestManagerOEMDecoder tests the registered decoder hydrates the Huawei
+// object of a manager that has the Huawei manager type.
+func TestManagerOEMDecoder(t *testing.T) {
+	body := strings.Replace(managerBody, `"Huawei": {`,
+		`"Huawei": {
+				"@odata.type": "#HuaweiManager.v1_0_0.HuaweiManager",`, 1)
+
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	value, ok := manager.Oem.Get("Huawei")
+	if !ok {
+		t.Fatal("Huawei data should be decoded")
+	}
+
+	if oem, ok := value.(*ManagerOEM); !ok || oem.BMCUpTime != "12 days 04:11:53" {
+		t.Errorf("Invalid decoded Huawei data: %+v", value)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Fatalf("Error getting Huawei manager: %s", err)
+	}
+
+	if result.spService != "/redfish/v1/Managers/1/SPService" {
+		t.Errorf("Invalid SP service link: %s", result.spService)
+	}
+}
@@ -0,0 +1,68 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+func init() {
+	common.RegisterOEMDecoder("Huawei", "HuaweiManager.HuaweiManager", decodeManagerOEM)
+	common.RegisterOEMDecoder("Huawei", "HuaweiStorageController.HuaweiStorageController", decodeStorageControllerOEM)
+	common.RegisterOEMDecoder("Huawei", "HuaweiVolume.HuaweiVolume", decodeVolumeOEM)
+}
+
+// decodeManagerOEM decodes the Huawei object of a manager.
+func decodeManagerOEM(data json.RawMessage) (interface{}, error) {
+	var result ManagerOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// decodeStorageControllerOEM decodes the Huawei object of a storage
+// controller.
+func decodeStorageControllerOEM(data json.RawMessage) (interface{}, error) {
+	var result StorageControllerOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// decodeVolumeOEM decodes the Huawei object of a volume.
+func decodeVolumeOEM(data json.RawMessage) (interface{}, error) {
+	var result VolumeOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// huaweiOEM gets the Huawei object of an Oem property. The value hydrated by
+// the registered decoders is used if there is one; otherwise the service did
+// not give the object a registered @odata.type and the raw JSON is decoded
+// with decoder.
+func huaweiOEM(oem common.OEM, decoder common.OEMDecoder) (interface{}, error) {
+	err := oem.Error("Huawei")
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := oem.Get("Huawei"); ok {
+		return value, nil
+	}
+
+	data := oem.Raw("Huawei")
+	if data == nil {
+		data = json.RawMessage("{}")
+	}
+
+	return decoder(data)
+}
+
+// unexpectedOEMError is returned when the Huawei object of a resource was
+// hydrated into the type registered for another kind of resource.
+func unexpectedOEMError(value interface{}) error {
+	return fmt.Errorf("unexpected Huawei OEM data of type %T", value)
+}
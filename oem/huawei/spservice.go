@@ -0,0 +1,152 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SPVersion holds the versions of the Smart Provisioning components.
+type SPVersion struct {
+	// APPVersion is the version of the Smart Provisioning application.
+	APPVersion string
+	// DataVersion is the version of the Smart Provisioning data package.
+	DataVersion string
+	// OSVersion is the version of the Smart Provisioning operating system.
+	OSVersion string
+}
+
+// SPService is the Smart Provisioning service of an iBMC. Smart Provisioning
+// is started on the next boot to install operating systems, configure RAID
+// and update firmware with the parameters stored on the iBMC.
+type SPService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// SPFinished is whether Smart Provisioning finished its last run.
+	SPFinished bool
+	// SPStartEnabled is whether Smart Provisioning starts on the next boot.
+	SPStartEnabled bool
+	// SPTimeout is the time, in seconds, Smart Provisioning may run before
+	// the system is restarted.
+	SPTimeout int
+	// SysRestartDelaySeconds is the delay, in seconds, before the system is
+	// restarted once Smart Provisioning has finished.
+	SysRestartDelaySeconds int
+	// Version holds the versions of the Smart Provisioning components.
+	Version SPVersion
+	// osInstallParameters is a link to the collection of OS installation
+	// parameters.
+	osInstallParameters string
+	// raidParameters is a link to the collection of RAID configuration
+	// parameters.
+	raidParameters string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (spservice *SPService) GetRawData() []byte {
+	return spservice.rawData
+}
+
+// UnmarshalJSON unmarshals an SPService object from the raw JSON.
+func (spservice *SPService) UnmarshalJSON(b []byte) error {
+	type temp SPService
+	var t struct {
+		temp
+		SPOSInstallPara common.Link
+		SPRAID          common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*spservice = SPService(t.temp)
+
+	// Extract the links to other entities for later
+	spservice.osInstallParameters = string(t.SPOSInstallPara)
+	spservice.raidParameters = string(t.SPRAID)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	spservice.rawData = b
+	spservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (spservice *SPService) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(SPService)
+	original.UnmarshalJSON(spservice.rawData)
+
+	readWriteFields := []string{
+		"SPFinished",
+		"SPStartEnabled",
+		"SPTimeout",
+		"SysRestartDelaySeconds",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(spservice).Elem()
+
+	return spservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetSPService will get an SPService instance from the service.
+func GetSPService(c common.Client, uri string) (*SPService, error) {
+	var spservice SPService
+	err := common.FetchResource(c, uri, &spservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spservice, nil
+}
+
+// Start enables Smart Provisioning to run on the next boot of the system.
+func (spservice *SPService) Start() error {
+	spservice.SPStartEnabled = true
+	spservice.SPFinished = false
+	return spservice.Update()
+}
+
+// AddOSInstallParameters stores the parameters of an operating system
+// installation, which Smart Provisioning runs when it is next started.
+func (spservice *SPService) AddOSInstallParameters(parameters interface{}) error {
+	return spservice.addParameters(spservice.osInstallParameters, parameters, "OS installation")
+}
+
+// AddRAIDParameters stores a RAID configuration, which Smart Provisioning
+// applies when it is next started.
+func (spservice *SPService) AddRAIDParameters(parameters interface{}) error {
+	return spservice.addParameters(spservice.raidParameters, parameters, "RAID")
+}
+
+// addParameters posts Smart Provisioning parameters to a collection.
+func (spservice *SPService) addParameters(collection string, parameters interface{}, kind string) error {
+	if collection == "" {
+		return fmt.Errorf("this SP service does not support %s parameters", kind)
+	}
+
+	_, err := spservice.Client.Post(collection, parameters)
+	return err
+}
@@ -0,0 +1,96 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var spServiceBody = `{
+		"@odata.type": "#HwSPService.v1_0_0.HwSPService",
+		"@odata.id": "/redfish/v1/Managers/1/SPService",
+		"Id": "SPService",
+		"Name": "SP Service",
+		"SPStartEnabled": false,
+		"SysRestartDelaySeconds": 30,
+		"SPTimeout": 7200,
+		"SPFinished": true,
+		"Version": {
+			"APPVersion": "1.09",
+			"OSVersion": "1.09",
+			"DataVersion": "1.09"
+		},
+		"SPOSInstallPara": {
+			"@odata.id": "/redfish/v1/Managers/1/SPService/SPOSInstallPara"
+		},
+		"SPRAID": {
+			"@odata.id": "/redfish/v1/Managers/1/SPService/SPRAID"
+		}
+	}`
+
+// TestSPService tests the parsing of SPService objects.
+func TestSPService(t *testing.T) {
+	var result SPService
+	err := json.NewDecoder(strings.NewReader(spServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.SPTimeout != 7200 {
+		t.Errorf("Invalid SP timeout: %d", result.SPTimeout)
+	}
+
+	if result.Version.APPVersion != "1.09" {
+		t.Errorf("Invalid SP version: %s", result.Version.APPVersion)
+	}
+
+	if result.raidParameters != "/redfish/v1/Managers/1/SPService/SPRAID" {
+		t.Errorf("Invalid RAID parameters link: %s", result.raidParameters)
+	}
+}
+
+// TestSPServiceStart tests enabling Smart Provisioning for the next boot and
+// storing its parameters.
+func TestSPServiceStart(t *testing.T) {
+	var result SPService
+	err := json.NewDecoder(strings.NewReader(spServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.AddOSInstallParameters(map[string]interface{}{"OSType": "CentOS7U6"})
+	if err != nil {
+		t.Errorf("Error making AddOSInstallParameters call: %s", err)
+	}
+
+	err = result.Start()
+	if err != nil {
+		t.Errorf("Error making Start call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.osInstallParameters {
+		t.Errorf("Unexpected AddOSInstallParameters target: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[1].Payload, "SPStartEnabled:true") ||
+		!strings.Contains(calls[1].Payload, "SPFinished:false") {
+		t.Errorf("Unexpected Start payload: %s", calls[1].Payload)
+	}
+}
@@ -0,0 +1,93 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// StorageControllerOEM holds the properties Huawei adds to RAID controllers.
+type StorageControllerOEM struct {
+	// CopyBackState is whether data is copied back from a hot spare once
+	// the failed drive is replaced.
+	CopyBackState bool
+	// DDRECCCount is the number of ECC errors of the controller cache.
+	DDRECCCount int
+	// JBODState is whether drives that are not in a volume are exposed to the
+	// host.
+	JBODState bool
+	// MaintainPDFailHistory is whether the controller remembers failed
+	// drives.
+	MaintainPDFailHistory bool
+	// MemorySizeMiB is the size of the controller cache.
+	MemorySizeMiB int
+	// Mode is the mode the controller runs in, such as RAID or JBOD.
+	Mode string
+	// SmarterCopyBackState is whether data is copied to a hot spare when a
+	// drive reports predictive failures.
+	SmarterCopyBackState bool
+	// SupportedModes are the modes the controller can run in.
+	SupportedModes []string
+	// SupportedRAIDLevels are the RAID levels the controller can create
+	// volumes with.
+	SupportedRAIDLevels []string
+}
+
+// StorageController is a RAID controller with the Huawei OEM extensions.
+type StorageController struct {
+	*redfish.StorageController
+
+	// Huawei holds the properties Huawei adds to the controller.
+	Huawei StorageControllerOEM
+}
+
+// FromStorageController gets the Huawei OEM data of a storage controller.
+func FromStorageController(storagecontroller *redfish.StorageController) (*StorageController, error) {
+	var t struct {
+		Oem common.OEM
+	}
+
+	err := json.Unmarshal(storagecontroller.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := huaweiOEM(t.Oem, decodeStorageControllerOEM)
+	if err != nil {
+		return nil, err
+	}
+
+	oem, ok := value.(*StorageControllerOEM)
+	if !ok {
+		return nil, unexpectedOEMError(value)
+	}
+
+	return &StorageController{
+		StorageController: storagecontroller,
+		Huawei:            *oem,
+	}, nil
+}
+
+// SupportsRAIDLevel checks whether the controller can create volumes with a
+// RAID level. Huawei lists the levels in its OEM data as well as in the
+// standard SupportedRAIDTypes, so both are checked.
+func (storagecontroller *StorageController) SupportsRAIDLevel(raidType redfish.RAIDType) bool {
+	for _, level := range storagecontroller.Huawei.SupportedRAIDLevels {
+		if level == string(raidType) {
+			return true
+		}
+	}
+
+	for _, supported := range storagecontroller.SupportedRAIDTypes {
+		if supported == raidType {
+			return true
+		}
+	}
+
+	return false
+}
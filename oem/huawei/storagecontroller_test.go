@@ -0,0 +1,59 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var storageControllerBody = `{
+		"@odata.id": "/redfish/v1/Systems/1/Storages/RAIDStorage0#/StorageControllers/0",
+		"MemberId": "0",
+		"Name": "LSI SAS3508",
+		"SupportedRAIDTypes": ["RAID0", "RAID1"],
+		"Oem": {
+			"Huawei": {
+				"SupportedModes": ["RAID", "JBOD"],
+				"Mode": "RAID",
+				"CopyBackState": true,
+				"SmarterCopyBackState": false,
+				"JBODState": false,
+				"MemorySizeMiB": 2048,
+				"SupportedRAIDLevels": ["RAID0", "RAID1", "RAID5", "RAID50"]
+			}
+		}
+	}`
+
+// TestStorageController tests getting the Huawei OEM data of a storage
+// controller.
+func TestStorageController(t *testing.T) {
+	var storagecontroller redfish.StorageController
+	err := json.NewDecoder(strings.NewReader(storageControllerBody)).Decode(&storagecontroller)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromStorageController(&storagecontroller)
+	if err != nil {
+		t.Errorf("Error getting Huawei storage controller: %s", err)
+	}
+
+	if result.Huawei.Mode != "RAID" || result.Huawei.MemorySizeMiB != 2048 {
+		t.Errorf("Invalid Huawei controller data: %+v", result.Huawei)
+	}
+
+	if !result.SupportsRAIDLevel(redfish.RAID50RAIDType) {
+		t.Error("RAID50 should be supported")
+	}
+
+	if result.SupportsRAIDLevel(redfish.RAID6RAIDType) {
+		t.Error("RAID6 should not be supported")
+	}
+}
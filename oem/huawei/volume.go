@@ -0,0 +1,162 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// ReadPolicy is the read policy of a RAID volume.
+type ReadPolicy string
+
+const (
+	// NoReadAheadReadPolicy data is not read ahead.
+	NoReadAheadReadPolicy ReadPolicy = "NoReadAhead"
+	// ReadAheadReadPolicy data is read ahead into the controller cache.
+	ReadAheadReadPolicy ReadPolicy = "ReadAhead"
+)
+
+// WritePolicy is the write policy of a RAID volume.
+type WritePolicy string
+
+const (
+	// WriteThroughWritePolicy writes complete once the data is on the
+	// drives.
+	WriteThroughWritePolicy WritePolicy = "WriteThrough"
+	// WriteBackWithBBUWritePolicy writes complete once the data is in the
+	// controller cache, falling back to write through without a battery.
+	WriteBackWithBBUWritePolicy WritePolicy = "WriteBackWithBBU"
+	// WriteBackWritePolicy writes complete once the data is in the
+	// controller cache.
+	WriteBackWritePolicy WritePolicy = "WriteBack"
+)
+
+// CachePolicy is the IO cache policy of a RAID volume.
+type CachePolicy string
+
+const (
+	// CachedIOCachePolicy reads and writes go through the controller cache.
+	CachedIOCachePolicy CachePolicy = "CachedIO"
+	// DirectIOCachePolicy reads and writes bypass the controller cache.
+	DirectIOCachePolicy CachePolicy = "DirectIO"
+)
+
+// VolumeOEM holds the properties Huawei adds to RAID volumes.
+type VolumeOEM struct {
+	// AccessPolicy is the access policy of the volume, such as ReadWrite.
+	AccessPolicy string
+	// BootEnable is whether the volume is the boot device of the controller.
+	BootEnable bool
+	// ConsistencyCheck is whether a consistency check is running.
+	ConsistencyCheck bool
+	// CurrentCachePolicy is the IO cache policy in effect.
+	CurrentCachePolicy CachePolicy
+	// CurrentReadPolicy is the read policy in effect.
+	CurrentReadPolicy ReadPolicy
+	// CurrentWritePolicy is the write policy in effect.
+	CurrentWritePolicy WritePolicy
+	// DefaultCachePolicy is the configured IO cache policy.
+	DefaultCachePolicy CachePolicy
+	// DefaultReadPolicy is the configured read policy.
+	DefaultReadPolicy ReadPolicy
+	// DefaultWritePolicy is the configured write policy.
+	DefaultWritePolicy WritePolicy
+	// InitializationMode is the initialization mode of the volume.
+	InitializationMode string
+	// NumDrivePerSpan is the number of drives in each span of the volume.
+	NumDrivePerSpan int
+	// SpanNumber is the number of spans of the volume.
+	SpanNumber int
+	// VolumeRaidLevel is the RAID level of the volume, such as RAID5.
+	VolumeRaidLevel string
+}
+
+// Volume is a RAID volume with the Huawei OEM extensions.
+type Volume struct {
+	*redfish.Volume
+
+	// Huawei holds the properties Huawei adds to the volume.
+	Huawei VolumeOEM
+	// original is the OEM data as read from the service.
+	original VolumeOEM
+}
+
+// FromVolume gets the Huawei OEM data of a volume.
+func FromVolume(volume *redfish.Volume) (*Volume, error) {
+	var t struct {
+		Oem common.OEM
+	}
+
+	err := json.Unmarshal(volume.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := huaweiOEM(t.Oem, decodeVolumeOEM)
+	if err != nil {
+		return nil, err
+	}
+
+	oem, ok := value.(*VolumeOEM)
+	if !ok {
+		return nil, unexpectedOEMError(value)
+	}
+
+	return &Volume{
+		Volume:   volume,
+		Huawei:   *oem,
+		original: *oem,
+	}, nil
+}
+
+// Update commits changes to the Huawei volume properties to the running
+// system. Only the boot device and the default policies can be changed.
+func (volume *Volume) Update() error {
+	readWriteFields := map[string]bool{
+		"BootEnable":         true,
+		"DefaultCachePolicy": true,
+		"DefaultReadPolicy":  true,
+		"DefaultWritePolicy": true,
+	}
+
+	changes := make(map[string]interface{})
+
+	originalElement := reflect.ValueOf(volume.original)
+	currentElement := reflect.ValueOf(volume.Huawei)
+	for i := 0; i < currentElement.NumField(); i++ {
+		if originalElement.Field(i).Interface() == currentElement.Field(i).Interface() {
+			continue
+		}
+
+		name := currentElement.Type().Field(i).Name
+		if !readWriteFields[name] {
+			return fmt.Errorf("%s field is read only", name)
+		}
+		changes[name] = currentElement.Field(i).Interface()
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"Oem": map[string]interface{}{
+			"Huawei": changes,
+		},
+	}
+
+	_, err := volume.Client.Patch(volume.ODataID, payload)
+	if err != nil {
+		return err
+	}
+
+	volume.original = volume.Huawei
+	return nil
+}
@@ -0,0 +1,98 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package huawei
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var volumeBody = `{
+		"@odata.type": "#Volume.v1_0_1.Volume",
+		"@odata.id": "/redfish/v1/Systems/1/Storages/RAIDStorage0/Volumes/LogicalDrive0",
+		"Id": "LogicalDrive0",
+		"Name": "LogicalDrive0",
+		"CapacityBytes": 598879502336,
+		"Oem": {
+			"Huawei": {
+				"VolumeRaidLevel": "RAID1",
+				"DefaultReadPolicy": "ReadAhead",
+				"DefaultWritePolicy": "WriteBackWithBBU",
+				"DefaultCachePolicy": "DirectIO",
+				"CurrentReadPolicy": "ReadAhead",
+				"CurrentWritePolicy": "WriteThrough",
+				"CurrentCachePolicy": "DirectIO",
+				"AccessPolicy": "ReadWrite",
+				"BootEnable": true,
+				"SpanNumber": 1,
+				"NumDrivePerSpan": 2,
+				"InitializationMode": "UnInit",
+				"ConsistencyCheck": false
+			}
+		}
+	}`
+
+// TestVolume tests getting the Huawei OEM data of a volume.
+func TestVolume(t *testing.T) {
+	var volume redfish.Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&volume)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromVolume(&volume)
+	if err != nil {
+		t.Errorf("Error getting Huawei volume: %s", err)
+	}
+
+	if result.Huawei.DefaultWritePolicy != WriteBackWithBBUWritePolicy {
+		t.Errorf("Invalid default write policy: %s", result.Huawei.DefaultWritePolicy)
+	}
+
+	if result.Huawei.NumDrivePerSpan != 2 || !result.Huawei.BootEnable {
+		t.Errorf("Invalid Huawei volume data: %+v", result.Huawei)
+	}
+}
+
+// TestVolumeUpdate tests changing the policies of a volume.
+func TestVolumeUpdate(t *testing.T) {
+	var volume redfish.Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&volume)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromVolume(&volume)
+	if err != nil {
+		t.Errorf("Error getting Huawei volume: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Huawei.DefaultReadPolicy = NoReadAheadReadPolicy
+	err = result.Update()
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Payload != "map[Oem:map[Huawei:map[DefaultReadPolicy:NoReadAhead]]]" {
+		t.Errorf("Unexpected Update calls: %v", calls)
+	}
+
+	result.Huawei.SpanNumber = 2
+	err = result.Update()
+	if err == nil {
+		t.Error("Read only fields should not be updated")
+	}
+}
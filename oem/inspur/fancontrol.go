@@ -0,0 +1,112 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package inspur
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// FanMode is the mode the fans are controlled in.
+type FanMode string
+
+const (
+	// AutomaticFanMode the BMC controls the fan speed from the temperatures.
+	AutomaticFanMode FanMode = "Automatic"
+	// ManualFanMode the fans run at the speed set in FanSpeedPercent.
+	ManualFanMode FanMode = "Manual"
+)
+
+// FanControl holds the fan control settings of an Inspur server.
+type FanControl struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FanMode is the mode the fans are controlled in.
+	FanMode FanMode
+	// FanSpeedPercent is the speed of the fans, in percent of their maximum
+	// speed, in manual mode.
+	FanSpeedPercent int
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (fancontrol *FanControl) GetRawData() []byte {
+	return fancontrol.rawData
+}
+
+// UnmarshalJSON unmarshals a FanControl object from the raw JSON.
+func (fancontrol *FanControl) UnmarshalJSON(b []byte) error {
+	type temp FanControl
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*fancontrol = FanControl(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	fancontrol.rawData = b
+	fancontrol.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (fancontrol *FanControl) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(FanControl)
+	original.UnmarshalJSON(fancontrol.rawData)
+
+	readWriteFields := []string{
+		"FanMode",
+		"FanSpeedPercent",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(fancontrol).Elem()
+
+	return fancontrol.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// SetManualSpeed switches the fans to manual mode and runs them at a fixed
+// speed.
+func (fancontrol *FanControl) SetManualSpeed(percent int) error {
+	if percent < 1 || percent > 100 {
+		return fmt.Errorf("fan speed %d is not between 1 and 100 percent", percent)
+	}
+
+	fancontrol.FanMode = ManualFanMode
+	fancontrol.FanSpeedPercent = percent
+	return fancontrol.Update()
+}
+
+// GetFanControl will get a FanControl instance from the service.
+func GetFanControl(c common.Client, uri string) (*FanControl, error) {
+	var fancontrol FanControl
+	err := common.FetchResource(c, uri, &fancontrol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fancontrol, nil
+}
@@ -0,0 +1,74 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package inspur
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var fanControlBody = `{
+		"@odata.type": "#InspurFanControl.v1_0_0.InspurFanControl",
+		"@odata.id": "/redfish/v1/Managers/1/Oem/Inspur/FanControl",
+		"Id": "FanControl",
+		"Name": "Fan Control",
+		"FanMode": "Automatic",
+		"FanSpeedPercent": 35
+	}`
+
+// TestFanControl tests the parsing of FanControl objects.
+func TestFanControl(t *testing.T) {
+	var result FanControl
+	err := json.NewDecoder(strings.NewReader(fanControlBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.FanMode != AutomaticFanMode {
+		t.Errorf("Invalid fan mode: %s", result.FanMode)
+	}
+
+	if result.FanSpeedPercent != 35 {
+		t.Errorf("Invalid fan speed: %d", result.FanSpeedPercent)
+	}
+}
+
+// TestFanControlSetManualSpeed tests setting a fixed fan speed.
+func TestFanControlSetManualSpeed(t *testing.T) {
+	var result FanControl
+	err := json.NewDecoder(strings.NewReader(fanControlBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetManualSpeed(120)
+	if err == nil {
+		t.Error("Fan speeds above 100 percent should be rejected")
+	}
+
+	err = result.SetManualSpeed(80)
+	if err != nil {
+		t.Errorf("Error making SetManualSpeed call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "FanMode:Manual") ||
+		!strings.Contains(calls[0].Payload, "FanSpeedPercent:80") {
+		t.Errorf("Unexpected SetManualSpeed payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,126 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package inspur
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+func init() {
+	common.RegisterOEMDecoder("Inspur", "InspurManager.InspurManager", decodeManagerOEM)
+}
+
+// ManagerOEM is the Inspur object in the Oem property of a manager.
+type ManagerOEM struct {
+	// BMCPartition is the firmware partition the BMC booted from.
+	BMCPartition string
+	// NCSIMode is the NC-SI mode of the shared network port.
+	NCSIMode string
+	// FanControl is a link to the fan control settings.
+	FanControl common.Link
+}
+
+// decodeManagerOEM decodes the Inspur object of a manager.
+func decodeManagerOEM(data json.RawMessage) (interface{}, error) {
+	var result ManagerOEM
+	err := json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// managerOEM gets the Inspur object of a manager, decoding it directly if the
+// service did not give it the registered @odata.type.
+func managerOEM(oem common.OEM) (*ManagerOEM, error) {
+	err := oem.Error("Inspur")
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := oem.Get("Inspur"); ok {
+		if result, ok := value.(*ManagerOEM); ok {
+			return result, nil
+		}
+	}
+
+	if oem.Raw("Inspur") == nil {
+		return &ManagerOEM{}, nil
+	}
+
+	value, err := decodeManagerOEM(oem.Raw("Inspur"))
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*ManagerOEM), nil
+}
+
+// Manager is an Inspur BMC manager with the Inspur OEM extensions.
+type Manager struct {
+	*redfish.Manager
+
+	// BMCPartition is the firmware partition the BMC booted from.
+	BMCPartition string
+	// NCSIMode is the NC-SI mode of the shared network port.
+	NCSIMode string
+	// fanControl is a link to the fan control settings.
+	fanControl string
+	// collectLogsTarget is the URL to send OneKeyLogCollect actions to.
+	collectLogsTarget string
+}
+
+// FromManager gets the Inspur OEM data of a manager.
+func FromManager(manager *redfish.Manager) (*Manager, error) {
+	oem, err := managerOEM(manager.Oem)
+	if err != nil {
+		return nil, err
+	}
+
+	// The OEM actions are not part of the Oem property
+	var t struct {
+		Actions struct {
+			Oem struct {
+				OneKeyLogCollect struct {
+					Target string
+				} `json:"#InspurManager.OneKeyLogCollect"`
+			}
+		}
+	}
+
+	err = json.Unmarshal(manager.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		Manager:           manager,
+		BMCPartition:      oem.BMCPartition,
+		NCSIMode:          oem.NCSIMode,
+		fanControl:        string(oem.FanControl),
+		collectLogsTarget: t.Actions.Oem.OneKeyLogCollect.Target,
+	}, nil
+}
+
+// FanControl gets the fan control settings of the server.
+func (manager *Manager) FanControl() (*FanControl, error) {
+	if manager.fanControl == "" {
+		return nil, nil
+	}
+
+	return GetFanControl(manager.Client, manager.fanControl)
+}
+
+// CollectLogs shall collect the BMC, BIOS and hardware logs into a single
+// archive. The service creates a task to track the collection.
+func (manager *Manager) CollectLogs() error {
+	if manager.collectLogsTarget == "" {
+		return fmt.Errorf("OneKeyLogCollect is not supported by this manager")
+	}
+
+	_, err := manager.Client.Post(manager.collectLogsTarget, struct{}{})
+	return err
+}
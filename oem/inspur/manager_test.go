@@ -0,0 +1,127 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package inspur
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var managerBody = `{
+		"@odata.type": "#Manager.v1_5_1.Manager",
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"Name": "Manager",
+		"ManagerType": "BMC",
+		"Oem": {
+			"Inspur": {
+				"BMCPartition": "Primary",
+				"NCSIMode": "Auto Failover",
+				"FanControl": {
+					"@odata.id": "/redfish/v1/Managers/1/Oem/Inspur/FanControl"
+				}
+			}
+		},
+		"Actions": {
+			"Oem": {
+				"#InspurManager.OneKeyLogCollect": {
+					"target": "/redfish/v1/Managers/1/Actions/Oem/InspurManager.OneKeyLogCollect"
+				}
+			}
+		}
+	}`
+
+// TestFromManager tests getting the Inspur OEM data of a manager.
+func TestFromManager(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Errorf("Error getting Inspur manager: %s", err)
+	}
+
+	if result.BMCPartition != "Primary" {
+		t.Errorf("Invalid BMC partition: %s", result.BMCPartition)
+	}
+
+	if result.fanControl != "/redfish/v1/Managers/1/Oem/Inspur/FanControl" {
+		t.Errorf("Invalid fan control link: %s", result.fanControl)
+	}
+
+	if result.collectLogsTarget != "/redfish/v1/Managers/1/Actions/Oem/InspurManager.OneKeyLogCollect" {
+		t.Errorf("Invalid OneKeyLogCollect target: %s", result.collectLogsTarget)
+	}
+}
+
+// TestManagerCollectLogs tests the log collection action.
+func TestManagerCollectLogs(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Errorf("Error getting Inspur manager: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CollectLogs()
+	if err != nil {
+		t.Errorf("Error making CollectLogs call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != result.collectLogsTarget {
+		t.Errorf("Unexpected CollectLogs calls: %v", calls)
+	}
+}
+
+// TestManagerOEMDecoder tests the registered decoder hydrates the Inspur
+// object of a manager that has the Inspur manager type.
+func TestManagerOEMDecoder(t *testing.T) {
+	body := strings.Replace(managerBody, `"Inspur": {`,
+		`"Inspur": {
+				"@odata.type": "#InspurManager.v1_0_0.InspurManager",`, 1)
+
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	value, ok := manager.Oem.Get("Inspur")
+	if !ok {
+		t.Fatal("Inspur data should be decoded")
+	}
+
+	if oem, ok := value.(*ManagerOEM); !ok || oem.NCSIMode != "Auto Failover" {
+		t.Errorf("Invalid decoded Inspur data: %+v", value)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Fatalf("Error getting Inspur manager: %s", err)
+	}
+
+	if result.BMCPartition != "Primary" || result.fanControl != "/redfish/v1/Managers/1/Oem/Inspur/FanControl" {
+		t.Errorf("Invalid Inspur manager: %s %s", result.BMCPartition, result.fanControl)
+	}
+}
@@ -0,0 +1,46 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// Chassis is a Lenovo chassis with the Lenovo OEM extensions.
+type Chassis struct {
+	*redfish.Chassis
+
+	// leds is a link to the collection of LEDs on the chassis.
+	leds string
+}
+
+// FromChassis gets the Lenovo OEM data of a chassis.
+func FromChassis(chassis *redfish.Chassis) (*Chassis, error) {
+	var t struct {
+		Oem struct {
+			Lenovo struct {
+				LEDs common.Link
+			}
+		}
+	}
+
+	err := json.Unmarshal(chassis.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chassis{
+		Chassis: chassis,
+		leds:    string(t.Oem.Lenovo.LEDs),
+	}, nil
+}
+
+// LEDs gets the LEDs on the chassis.
+func (chassis *Chassis) LEDs() ([]*LED, error) {
+	return ListReferencedLEDs(chassis.Client, chassis.leds)
+}
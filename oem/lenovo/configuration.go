@@ -0,0 +1,130 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ConfigurationBackupParameters are the parameters of the
+// LenovoConfigurationService.BackupConfiguration action.
+type ConfigurationBackupParameters struct {
+	// Passphrase is used to encrypt the sensitive settings in the backup.
+	Passphrase string
+	// TargetURI is the URI the backup file is uploaded to. If empty, the
+	// backup is kept on the controller for download.
+	TargetURI string `json:",omitempty"`
+}
+
+// ConfigurationRestoreParameters are the parameters of the
+// LenovoConfigurationService.RestoreConfiguration action.
+type ConfigurationRestoreParameters struct {
+	// Passphrase is the passphrase the backup was created with.
+	Passphrase string
+	// ConfigContent is the content of a backup file. Either ConfigContent or
+	// SourceURI shall be provided.
+	ConfigContent string `json:",omitempty"`
+	// SourceURI is the URI the backup file is read from.
+	SourceURI string `json:",omitempty"`
+}
+
+// ConfigurationService is the XClarity Controller service that backs up and
+// restores the controller configuration.
+type ConfigurationService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BackupStatus is the status of the last configuration backup.
+	BackupStatus string
+	// Description provides a description of this resource.
+	Description string
+	// RestoreStatus is the status of the last configuration restore.
+	RestoreStatus string
+	// backupTarget is the URL to send BackupConfiguration actions to.
+	backupTarget string
+	// restoreTarget is the URL to send RestoreConfiguration actions to.
+	restoreTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (configurationservice *ConfigurationService) GetRawData() []byte {
+	return configurationservice.rawData
+}
+
+// UnmarshalJSON unmarshals a ConfigurationService object from the raw JSON.
+func (configurationservice *ConfigurationService) UnmarshalJSON(b []byte) error {
+	type temp ConfigurationService
+	type Actions struct {
+		BackupConfiguration struct {
+			Target string
+		} `json:"#LenovoConfigurationService.BackupConfiguration"`
+		RestoreConfiguration struct {
+			Target string
+		} `json:"#LenovoConfigurationService.RestoreConfiguration"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*configurationservice = ConfigurationService(t.temp)
+
+	// Extract the links to other entities for later
+	configurationservice.backupTarget = t.Actions.BackupConfiguration.Target
+	configurationservice.restoreTarget = t.Actions.RestoreConfiguration.Target
+
+	configurationservice.rawData = b
+	configurationservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// GetConfigurationService will get a ConfigurationService instance from the
+// service.
+func GetConfigurationService(c common.Client, uri string) (*ConfigurationService, error) {
+	var configurationservice ConfigurationService
+	err := common.FetchResource(c, uri, &configurationservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &configurationservice, nil
+}
+
+// BackupConfiguration shall back up the controller configuration.
+func (configurationservice *ConfigurationService) BackupConfiguration(parameters *ConfigurationBackupParameters) error {
+	if configurationservice.backupTarget == "" {
+		return fmt.Errorf("BackupConfiguration is not supported by this configuration service")
+	}
+
+	_, err := configurationservice.Client.Post(configurationservice.backupTarget, parameters)
+	return err
+}
+
+// RestoreConfiguration shall restore the controller configuration from a
+// backup.
+func (configurationservice *ConfigurationService) RestoreConfiguration(parameters *ConfigurationRestoreParameters) error {
+	if configurationservice.restoreTarget == "" {
+		return fmt.Errorf("RestoreConfiguration is not supported by this configuration service")
+	}
+
+	_, err := configurationservice.Client.Post(configurationservice.restoreTarget, parameters)
+	return err
+}
@@ -0,0 +1,98 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var configurationServiceBody = `{
+		"@odata.type": "#LenovoConfigurationService.v1_0_0.LenovoConfigurationService",
+		"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/Configuration",
+		"Id": "Configuration",
+		"Name": "Configuration Service",
+		"BackupStatus": "Successful",
+		"RestoreStatus": "NotStarted",
+		"Actions": {
+			"#LenovoConfigurationService.BackupConfiguration": {
+				"target": "/redfish/v1/Managers/1/Oem/Lenovo/Configuration/Actions/LenovoConfigurationService.BackupConfiguration"
+			},
+			"#LenovoConfigurationService.RestoreConfiguration": {
+				"target": "/redfish/v1/Managers/1/Oem/Lenovo/Configuration/Actions/LenovoConfigurationService.RestoreConfiguration"
+			}
+		}
+	}`
+
+// TestConfigurationService tests the parsing of ConfigurationService objects.
+func TestConfigurationService(t *testing.T) {
+	var result ConfigurationService
+	err := json.NewDecoder(strings.NewReader(configurationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.BackupStatus != "Successful" {
+		t.Errorf("Invalid backup status: %s", result.BackupStatus)
+	}
+
+	if result.backupTarget != "/redfish/v1/Managers/1/Oem/Lenovo/Configuration/Actions/LenovoConfigurationService.BackupConfiguration" {
+		t.Errorf("Invalid BackupConfiguration target: %s", result.backupTarget)
+	}
+
+	if result.restoreTarget != "/redfish/v1/Managers/1/Oem/Lenovo/Configuration/Actions/LenovoConfigurationService.RestoreConfiguration" {
+		t.Errorf("Invalid RestoreConfiguration target: %s", result.restoreTarget)
+	}
+}
+
+// TestConfigurationServiceActions tests the backup and restore actions.
+func TestConfigurationServiceActions(t *testing.T) {
+	var result ConfigurationService
+	err := json.NewDecoder(strings.NewReader(configurationServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.BackupConfiguration(&ConfigurationBackupParameters{Passphrase: "secret"})
+	if err != nil {
+		t.Errorf("Error making BackupConfiguration call: %s", err)
+	}
+
+	err = result.RestoreConfiguration(&ConfigurationRestoreParameters{
+		Passphrase: "secret",
+		SourceURI:  "https://files.example.org/xcc.json",
+	})
+	if err != nil {
+		t.Errorf("Error making RestoreConfiguration call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.backupTarget {
+		t.Errorf("Unexpected BackupConfiguration target: %s", calls[0].URL)
+	}
+
+	if calls[1].URL != result.restoreTarget {
+		t.Errorf("Unexpected RestoreConfiguration target: %s", calls[1].URL)
+	}
+
+	result.backupTarget = ""
+	err = result.BackupConfiguration(&ConfigurationBackupParameters{})
+	if err == nil {
+		t.Error("BackupConfiguration should fail when the action is not advertised")
+	}
+}
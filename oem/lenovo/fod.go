@@ -0,0 +1,190 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// FoDService is the Features on Demand service of a XClarity Controller. It
+// holds the activation keys that unlock optional controller features.
+type FoDService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// keys is a link to the collection of installed activation keys.
+	keys string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (fodservice *FoDService) GetRawData() []byte {
+	return fodservice.rawData
+}
+
+// UnmarshalJSON unmarshals a FoDService object from the raw JSON.
+func (fodservice *FoDService) UnmarshalJSON(b []byte) error {
+	type temp FoDService
+	var t struct {
+		temp
+		Keys common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*fodservice = FoDService(t.temp)
+
+	// Extract the links to other entities for later
+	fodservice.keys = string(t.Keys)
+
+	fodservice.rawData = b
+	fodservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// GetFoDService will get a FoDService instance from the service.
+func GetFoDService(c common.Client, uri string) (*FoDService, error) {
+	var fodservice FoDService
+	err := common.FetchResource(c, uri, &fodservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fodservice, nil
+}
+
+// Keys gets the activation keys installed on the controller.
+func (fodservice *FoDService) Keys() ([]*FoDKey, error) {
+	return ListReferencedFoDKeys(fodservice.Client, fodservice.keys)
+}
+
+// InstallKey installs an activation key by posting its content, as a
+// Base64-encoded string, to the keys collection.
+func (fodservice *FoDService) InstallKey(key string) error {
+	if fodservice.keys == "" {
+		return fmt.Errorf("this FoD service does not have a keys collection")
+	}
+
+	type temp struct {
+		Key string
+	}
+	t := temp{
+		Key: key,
+	}
+
+	_, err := fodservice.Client.Post(fodservice.keys, t)
+	return err
+}
+
+// FoDKey is a Features on Demand activation key.
+type FoDKey struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DescTypeCode is the type code of the feature the key unlocks.
+	DescTypeCode string
+	// Expires is the date the key expires, or "NotExpired" for keys without
+	// an expiration date.
+	Expires string
+	// FeatureDescription describes the feature the key unlocks.
+	FeatureDescription string
+	// FeatureStatus is the activation status of the feature.
+	FeatureStatus string
+	// UseCount is the number of times the key may still be used.
+	UseCount int
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a FoDKey object from the raw JSON.
+func (fodkey *FoDKey) UnmarshalJSON(b []byte) error {
+	type temp FoDKey
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*fodkey = FoDKey(t.temp)
+	fodkey.rawData = b
+
+	return nil
+}
+
+// GetRawData get raw data json
+func (fodkey *FoDKey) GetRawData() []byte {
+	return fodkey.rawData
+}
+
+// Delete removes the activation key from the controller.
+func (fodkey *FoDKey) Delete() error {
+	return fodkey.Client.Delete(fodkey.ODataID)
+}
+
+// GetFoDKey will get a FoDKey instance from the service.
+func GetFoDKey(c common.Client, uri string) (*FoDKey, error) {
+	var fodkey FoDKey
+	err := common.FetchResource(c, uri, &fodkey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fodkey, nil
+}
+
+// ListReferencedFoDKeys gets the collection of FoDKey from a provided
+// reference.
+func ListReferencedFoDKeys(c common.Client, link string) ([]*FoDKey, error) {
+	var result []*FoDKey
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, fodkeyLink := range links.ItemLinks {
+		fodkey := new(FoDKey)
+		expanded, err := links.DecodeMember(c, fodkeyLink, fodkey)
+		if err == nil && !expanded {
+			fodkey, err = GetFoDKey(c, fodkeyLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, fodkey)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var fodServiceBody = `{
+		"@odata.type": "#LenovoFoDService.v1_0_0.LenovoFoDService",
+		"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/FoD",
+		"Id": "FoD",
+		"Name": "Features on Demand",
+		"Keys": {
+			"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/FoD/Keys"
+		}
+	}`
+
+var fodKeyBody = `{
+		"@odata.type": "#LenovoFoDKey.v1_0_0.LenovoFoDKey",
+		"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/FoD/Keys/4ee80e",
+		"Id": "4ee80e",
+		"Name": "Lenovo XClarity Controller Enterprise Upgrade",
+		"DescTypeCode": "004f",
+		"Expires": "NotExpired",
+		"FeatureDescription": "Lenovo XClarity Controller Enterprise Upgrade",
+		"FeatureStatus": "Active",
+		"UseCount": 0,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestFoDService tests the parsing of FoDService objects.
+func TestFoDService(t *testing.T) {
+	var result FoDService
+	err := json.NewDecoder(strings.NewReader(fodServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "FoD" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.keys != "/redfish/v1/Managers/1/Oem/Lenovo/FoD/Keys" {
+		t.Errorf("Invalid keys link: %s", result.keys)
+	}
+}
+
+// TestFoDServiceInstallKey tests installing an activation key.
+func TestFoDServiceInstallKey(t *testing.T) {
+	var result FoDService
+	err := json.NewDecoder(strings.NewReader(fodServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.InstallKey("QUJDREVG")
+	if err != nil {
+		t.Errorf("Error making InstallKey call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != result.keys {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if calls[0].Payload != "{QUJDREVG}" {
+		t.Errorf("Unexpected InstallKey payload: %s", calls[0].Payload)
+	}
+}
+
+// TestFoDKey tests the parsing of FoDKey objects.
+func TestFoDKey(t *testing.T) {
+	var result FoDKey
+	err := json.NewDecoder(strings.NewReader(fodKeyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.DescTypeCode != "004f" {
+		t.Errorf("Invalid type code: %s", result.DescTypeCode)
+	}
+
+	if result.Expires != "NotExpired" {
+		t.Errorf("Invalid expiration: %s", result.Expires)
+	}
+
+	if result.FeatureStatus != "Active" {
+		t.Errorf("Invalid feature status: %s", result.FeatureStatus)
+	}
+}
@@ -0,0 +1,133 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// LEDState is the state of a LED.
+type LEDState string
+
+const (
+	// OffLEDState the LED is off.
+	OffLEDState LEDState = "Off"
+	// OnLEDState the LED is lit.
+	OnLEDState LEDState = "On"
+	// BlinkingLEDState the LED is blinking.
+	BlinkingLEDState LEDState = "Blinking"
+)
+
+// LED is a front panel or component LED of a Lenovo chassis.
+type LED struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Color is the color of the LED when it is lit.
+	Color string
+	// Description provides a description of this resource.
+	Description string
+	// State is the state of the LED. Only LEDs used to identify the system
+	// can be changed.
+	State LEDState
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (led *LED) GetRawData() []byte {
+	return led.rawData
+}
+
+// UnmarshalJSON unmarshals a LED object from the raw JSON.
+func (led *LED) UnmarshalJSON(b []byte) error {
+	type temp LED
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*led = LED(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	led.rawData = b
+	led.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (led *LED) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(LED)
+	original.UnmarshalJSON(led.rawData)
+
+	readWriteFields := []string{
+		"State",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(led).Elem()
+
+	return led.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// SetState changes the state of the LED.
+func (led *LED) SetState(state LEDState) error {
+	led.State = state
+	return led.Update()
+}
+
+// GetLED will get a LED instance from the service.
+func GetLED(c common.Client, uri string) (*LED, error) {
+	var led LED
+	err := common.FetchResource(c, uri, &led)
+	if err != nil {
+		return nil, err
+	}
+
+	return &led, nil
+}
+
+// ListReferencedLEDs gets the collection of LED from a provided reference.
+func ListReferencedLEDs(c common.Client, link string) ([]*LED, error) {
+	var result []*LED
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, ledLink := range links.ItemLinks {
+		led := new(LED)
+		expanded, err := links.DecodeMember(c, ledLink, led)
+		if err == nil && !expanded {
+			led, err = GetLED(c, ledLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, led)
+	}
+
+	return result, nil
+}
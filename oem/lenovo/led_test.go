@@ -0,0 +1,73 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var ledBody = `{
+		"@odata.type": "#LenovoLED.v1_0_0.LenovoLED",
+		"@odata.id": "/redfish/v1/Chassis/1/Oem/Lenovo/LEDs/1",
+		"Id": "1",
+		"Name": "Identify",
+		"Description": "Front panel identification LED",
+		"Color": "Blue",
+		"State": "Off"
+	}`
+
+// TestLED tests the parsing of LED objects.
+func TestLED(t *testing.T) {
+	var result LED
+	err := json.NewDecoder(strings.NewReader(ledBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.Name != "Identify" {
+		t.Errorf("Received invalid name: %s", result.Name)
+	}
+
+	if result.Color != "Blue" {
+		t.Errorf("Invalid color: %s", result.Color)
+	}
+
+	if result.State != OffLEDState {
+		t.Errorf("Invalid state: %s", result.State)
+	}
+}
+
+// TestLEDSetState tests changing the state of a LED.
+func TestLEDSetState(t *testing.T) {
+	var result LED
+	err := json.NewDecoder(strings.NewReader(ledBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetState(BlinkingLEDState)
+	if err != nil {
+		t.Errorf("Error making SetState call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Chassis/1/Oem/Lenovo/LEDs/1" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "State:Blinking") {
+		t.Errorf("Unexpected State update payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,64 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// Manager is a XClarity Controller manager with the Lenovo OEM extensions.
+type Manager struct {
+	*redfish.Manager
+
+	// configuration is a link to the Lenovo configuration service.
+	configuration string
+	// fod is a link to the Lenovo Features on Demand service.
+	fod string
+}
+
+// FromManager gets the Lenovo OEM data of a manager.
+func FromManager(manager *redfish.Manager) (*Manager, error) {
+	var t struct {
+		Oem struct {
+			Lenovo struct {
+				Configuration common.Link
+				FoD           common.Link
+			}
+		}
+	}
+
+	err := json.Unmarshal(manager.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		Manager:       manager,
+		configuration: string(t.Oem.Lenovo.Configuration),
+		fod:           string(t.Oem.Lenovo.FoD),
+	}, nil
+}
+
+// ConfigurationService gets the service used to back up and restore the
+// controller configuration.
+func (manager *Manager) ConfigurationService() (*ConfigurationService, error) {
+	if manager.configuration == "" {
+		return nil, nil
+	}
+
+	return GetConfigurationService(manager.Client, manager.configuration)
+}
+
+// FoDService gets the Features on Demand service of the controller.
+func (manager *Manager) FoDService() (*FoDService, error) {
+	if manager.fod == "" {
+		return nil, nil
+	}
+
+	return GetFoDService(manager.Client, manager.fod)
+}
@@ -0,0 +1,98 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package lenovo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var managerBody = `{
+		"@odata.type": "#Manager.v1_10_0.Manager",
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"Name": "Manager",
+		"ManagerType": "BMC",
+		"Model": "Lenovo XClarity Controller",
+		"Oem": {
+			"Lenovo": {
+				"@odata.type": "#LenovoManager.v1_0_0.LenovoManagerProperties",
+				"Configuration": {
+					"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/Configuration"
+				},
+				"FoD": {
+					"@odata.id": "/redfish/v1/Managers/1/Oem/Lenovo/FoD"
+				}
+			}
+		}
+	}`
+
+var chassisBody = `{
+		"@odata.type": "#Chassis.v1_11_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"Name": "Chassis",
+		"ChassisType": "RackMount",
+		"Oem": {
+			"Lenovo": {
+				"LEDs": {
+					"@odata.id": "/redfish/v1/Chassis/1/Oem/Lenovo/LEDs"
+				}
+			}
+		}
+	}`
+
+// TestFromManager tests getting the Lenovo OEM data of a manager.
+func TestFromManager(t *testing.T) {
+	var manager redfish.Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&manager)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromManager(&manager)
+	if err != nil {
+		t.Errorf("Error getting Lenovo manager: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.configuration != "/redfish/v1/Managers/1/Oem/Lenovo/Configuration" {
+		t.Errorf("Invalid configuration link: %s", result.configuration)
+	}
+
+	if result.fod != "/redfish/v1/Managers/1/Oem/Lenovo/FoD" {
+		t.Errorf("Invalid FoD link: %s", result.fod)
+	}
+}
+
+// TestFromChassis tests getting the Lenovo OEM data of a chassis.
+func TestFromChassis(t *testing.T) {
+	var chassis redfish.Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&chassis)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromChassis(&chassis)
+	if err != nil {
+		t.Errorf("Error getting Lenovo chassis: %s", err)
+	}
+
+	if result.ChassisType != redfish.RackMountChassisType {
+		t.Errorf("Invalid chassis type: %s", result.ChassisType)
+	}
+
+	if result.leds != "/redfish/v1/Chassis/1/Oem/Lenovo/LEDs" {
+		t.Errorf("Invalid LEDs link: %s", result.leds)
+	}
+}
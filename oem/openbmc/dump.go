@@ -0,0 +1,91 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// DumpService is an OpenBMC dump log service, such as the BMC dump service
+// of the manager or the host dump service of the system.
+type DumpService struct {
+	*redfish.LogService
+
+	// SupportedDiagnosticDataTypes, if provided, are the dump types that can
+	// be collected.
+	SupportedDiagnosticDataTypes []DiagnosticDataType
+	// collectDiagnosticDataTarget is the URL to send CollectDiagnosticData
+	// actions to.
+	collectDiagnosticDataTarget string
+}
+
+// FromLogService gets the OpenBMC dump data of a log service.
+func FromLogService(logservice *redfish.LogService) (*DumpService, error) {
+	var t struct {
+		Actions struct {
+			CollectDiagnosticData struct {
+				AllowedDiagnosticDataTypes []DiagnosticDataType `json:"DiagnosticDataType@Redfish.AllowableValues"`
+				Target                     string
+			} `json:"#LogService.CollectDiagnosticData"`
+		}
+	}
+
+	err := json.Unmarshal(logservice.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DumpService{
+		LogService:                   logservice,
+		SupportedDiagnosticDataTypes: t.Actions.CollectDiagnosticData.AllowedDiagnosticDataTypes,
+		collectDiagnosticDataTarget:  t.Actions.CollectDiagnosticData.Target,
+	}, nil
+}
+
+// CreateDump starts the collection of a dump. The oemDiagnosticDataType is
+// only sent for OEM dumps, e.g. SystemOEMDiagnosticDataType for a host dump.
+// The service creates a task to track the collection.
+func (dumpservice *DumpService) CreateDump(diagnosticDataType DiagnosticDataType, oemDiagnosticDataType string) error {
+	if dumpservice.collectDiagnosticDataTarget == "" {
+		return fmt.Errorf("CollectDiagnosticData is not supported by this log service")
+	}
+
+	type temp struct {
+		DiagnosticDataType    DiagnosticDataType
+		OEMDiagnosticDataType string `json:",omitempty"`
+	}
+	t := temp{
+		DiagnosticDataType: diagnosticDataType,
+	}
+	if diagnosticDataType == OEMDiagnosticDataType {
+		t.OEMDiagnosticDataType = oemDiagnosticDataType
+	}
+
+	_, err := dumpservice.Client.Post(dumpservice.collectDiagnosticDataTarget, t)
+	return err
+}
+
+// Dumps gets the dumps held by the service.
+func (dumpservice *DumpService) Dumps() ([]*LogEntry, error) {
+	var result []*LogEntry
+
+	entries, err := dumpservice.Entries()
+	if err != nil {
+		return result, err
+	}
+
+	for _, entry := range entries {
+		dump, err := FromLogEntry(entry)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, dump)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,104 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var dumpServiceBody = `{
+		"@odata.type": "#LogService.v1_2_0.LogService",
+		"@odata.id": "/redfish/v1/Systems/system/LogServices/Dump",
+		"Id": "Dump",
+		"Name": "Dump LogService",
+		"OverWritePolicy": "WrapsWhenFull",
+		"Entries": {
+			"@odata.id": "/redfish/v1/Systems/system/LogServices/Dump/Entries"
+		},
+		"Actions": {
+			"#LogService.ClearLog": {
+				"target": "/redfish/v1/Systems/system/LogServices/Dump/Actions/LogService.ClearLog"
+			},
+			"#LogService.CollectDiagnosticData": {
+				"target": "/redfish/v1/Systems/system/LogServices/Dump/Actions/LogService.CollectDiagnosticData",
+				"DiagnosticDataType@Redfish.AllowableValues": ["OEM"]
+			}
+		}
+	}`
+
+// TestDumpService tests getting the OpenBMC dump data of a log service.
+func TestDumpService(t *testing.T) {
+	var logservice redfish.LogService
+	err := json.NewDecoder(strings.NewReader(dumpServiceBody)).Decode(&logservice)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromLogService(&logservice)
+	if err != nil {
+		t.Errorf("Error getting OpenBMC dump service: %s", err)
+	}
+
+	if result.ID != "Dump" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if len(result.SupportedDiagnosticDataTypes) != 1 ||
+		result.SupportedDiagnosticDataTypes[0] != OEMDiagnosticDataType {
+		t.Errorf("Invalid supported dump types: %v", result.SupportedDiagnosticDataTypes)
+	}
+
+	if result.collectDiagnosticDataTarget != "/redfish/v1/Systems/system/LogServices/Dump/Actions/LogService.CollectDiagnosticData" {
+		t.Errorf("Invalid CollectDiagnosticData target: %s", result.collectDiagnosticDataTarget)
+	}
+}
+
+// TestDumpServiceCreateDump tests starting the collection of dumps.
+func TestDumpServiceCreateDump(t *testing.T) {
+	var logservice redfish.LogService
+	err := json.NewDecoder(strings.NewReader(dumpServiceBody)).Decode(&logservice)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromLogService(&logservice)
+	if err != nil {
+		t.Errorf("Error getting OpenBMC dump service: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateDump(OEMDiagnosticDataType, SystemOEMDiagnosticDataType)
+	if err != nil {
+		t.Errorf("Error making CreateDump call: %s", err)
+	}
+
+	err = result.CreateDump(ManagerDiagnosticDataType, SystemOEMDiagnosticDataType)
+	if err != nil {
+		t.Errorf("Error making CreateDump call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.collectDiagnosticDataTarget || calls[0].Payload != "{OEM System}" {
+		t.Errorf("Unexpected host dump call: %v", calls[0])
+	}
+
+	if calls[1].Payload != "{Manager }" {
+		t.Errorf("Unexpected BMC dump payload: %s", calls[1].Payload)
+	}
+}
@@ -0,0 +1,72 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// LEDGroup is a phosphor-led-manager group. OpenBMC drives the physical LEDs
+// through groups, which bmcweb maps onto the indicator LED properties.
+type LEDGroup string
+
+const (
+	// EnclosureIdentifyLEDGroup lights the enclosure identify LEDs.
+	EnclosureIdentifyLEDGroup LEDGroup = "enclosure_identify"
+	// EnclosureIdentifyBlinkLEDGroup blinks the enclosure identify LEDs.
+	EnclosureIdentifyBlinkLEDGroup LEDGroup = "enclosure_identify_blink"
+)
+
+// ChassisLEDGroups gets the LED groups asserted on a chassis.
+func ChassisLEDGroups(chassis *redfish.Chassis) []LEDGroup {
+	var result []LEDGroup
+
+	switch chassis.IndicatorLED {
+	case common.LitIndicatorLED:
+		result = append(result, EnclosureIdentifyLEDGroup)
+	case common.BlinkingIndicatorLED:
+		result = append(result, EnclosureIdentifyBlinkLEDGroup)
+	default:
+		if chassis.LocationIndicatorActive {
+			result = append(result, EnclosureIdentifyBlinkLEDGroup)
+		}
+	}
+
+	return result
+}
+
+// SetChassisLEDGroup asserts or deasserts a LED group on a chassis. The
+// identify groups are exclusive, so asserting one deasserts the other.
+// Deasserting a group that is not asserted does nothing.
+func SetChassisLEDGroup(chassis *redfish.Chassis, group LEDGroup, asserted bool) error {
+	var state common.IndicatorLED
+	switch group {
+	case EnclosureIdentifyLEDGroup:
+		state = common.LitIndicatorLED
+	case EnclosureIdentifyBlinkLEDGroup:
+		state = common.BlinkingIndicatorLED
+	default:
+		return fmt.Errorf("LED group %s is not supported", group)
+	}
+
+	if !asserted {
+		current := false
+		for _, assertedGroup := range ChassisLEDGroups(chassis) {
+			current = current || assertedGroup == group
+		}
+		if !current {
+			return nil
+		}
+
+		state = common.OffIndicatorLED
+		chassis.LocationIndicatorActive = false
+	}
+
+	chassis.IndicatorLED = state
+	return chassis.Update()
+}
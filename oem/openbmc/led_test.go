@@ -0,0 +1,70 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var chassisBody = `{
+		"@odata.type": "#Chassis.v1_14_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/chassis",
+		"Id": "chassis",
+		"Name": "chassis",
+		"ChassisType": "RackMount",
+		"IndicatorLED": "Lit",
+		"LocationIndicatorActive": false
+	}`
+
+// TestChassisLEDGroups tests setting the LED groups of a chassis.
+func TestChassisLEDGroups(t *testing.T) {
+	var chassis redfish.Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&chassis)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	groups := ChassisLEDGroups(&chassis)
+	if len(groups) != 1 || groups[0] != EnclosureIdentifyLEDGroup {
+		t.Errorf("Invalid LED groups: %v", groups)
+	}
+
+	testClient := &common.TestClient{}
+	chassis.SetClient(testClient)
+
+	err = SetChassisLEDGroup(&chassis, EnclosureIdentifyBlinkLEDGroup, false)
+	if err != nil {
+		t.Errorf("Error deasserting LED group: %s", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Deasserting an unasserted group should not update the chassis: %v", testClient.CapturedCalls())
+	}
+
+	err = SetChassisLEDGroup(&chassis, EnclosureIdentifyLEDGroup, false)
+	if err != nil {
+		t.Errorf("Error deasserting LED group: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || !strings.Contains(calls[0].Payload, "IndicatorLED:Off") {
+		t.Errorf("Unexpected LED group calls: %v", calls)
+	}
+
+	if chassis.IndicatorLED != common.OffIndicatorLED {
+		t.Errorf("Invalid indicator LED state: %s", chassis.IndicatorLED)
+	}
+
+	err = SetChassisLEDGroup(&chassis, "power_button", true)
+	if err == nil {
+		t.Error("Unsupported LED groups should be rejected")
+	}
+}
@@ -0,0 +1,92 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// DiagnosticDataType is the type of diagnostic data held by a dump.
+type DiagnosticDataType string
+
+const (
+	// ManagerDiagnosticDataType the dump contains BMC diagnostic data.
+	ManagerDiagnosticDataType DiagnosticDataType = "Manager"
+	// OSDiagnosticDataType the dump contains operating system diagnostic
+	// data.
+	OSDiagnosticDataType DiagnosticDataType = "OS"
+	// OEMDiagnosticDataType the dump contains OEM diagnostic data, such as a
+	// host system dump. OEMDiagnosticDataType further describes its content.
+	OEMDiagnosticDataType DiagnosticDataType = "OEM"
+)
+
+// SystemOEMDiagnosticDataType is the OEM diagnostic data type OpenBMC uses
+// for host system dumps.
+const SystemOEMDiagnosticDataType = "System"
+
+// LogEntry is an OpenBMC log or dump entry with the properties phosphor-logging
+// adds to the standard log entry.
+type LogEntry struct {
+	*redfish.LogEntry
+
+	// AdditionalDataSizeBytes is the size of the data attached to the entry.
+	AdditionalDataSizeBytes int64
+	// AdditionalDataURI is the URI to download the data attached to the
+	// entry, such as a dump archive.
+	AdditionalDataURI string
+	// DiagnosticDataType is the type of data held by a dump entry.
+	DiagnosticDataType DiagnosticDataType
+	// OEMDiagnosticDataType further describes the data of a dump entry when
+	// DiagnosticDataType is OEM.
+	OEMDiagnosticDataType string
+	// Resolved indicates whether the condition that caused the entry has been
+	// resolved.
+	Resolved bool
+}
+
+// FromLogEntry gets the OpenBMC data of a log entry.
+func FromLogEntry(logentry *redfish.LogEntry) (*LogEntry, error) {
+	var t struct {
+		AdditionalDataSizeBytes int64
+		AdditionalDataURI       string
+		DiagnosticDataType      DiagnosticDataType
+		OEMDiagnosticDataType   string
+		Resolved                bool
+	}
+
+	err := json.Unmarshal(logentry.GetRawData(), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogEntry{
+		LogEntry:                logentry,
+		AdditionalDataSizeBytes: t.AdditionalDataSizeBytes,
+		AdditionalDataURI:       t.AdditionalDataURI,
+		DiagnosticDataType:      t.DiagnosticDataType,
+		OEMDiagnosticDataType:   t.OEMDiagnosticDataType,
+		Resolved:                t.Resolved,
+	}, nil
+}
+
+// Resolve marks the condition that caused the entry as resolved.
+func (logentry *LogEntry) Resolve() error {
+	type temp struct {
+		Resolved bool
+	}
+	t := temp{
+		Resolved: true,
+	}
+
+	_, err := logentry.Client.Patch(logentry.ODataID, t)
+	if err != nil {
+		return err
+	}
+
+	logentry.Resolved = true
+	return nil
+}
@@ -0,0 +1,87 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package openbmc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var logEntryBody = `{
+		"@odata.type": "#LogEntry.v1_9_0.LogEntry",
+		"@odata.id": "/redfish/v1/Systems/system/LogServices/EventLog/Entries/12",
+		"Id": "12",
+		"Name": "System Event Log Entry",
+		"EntryType": "Event",
+		"Severity": "Critical",
+		"Message": "xyz.openbmc_project.Power.Error.PowerSupplyFault",
+		"Created": "2026-09-02T11:08:51+00:00",
+		"AdditionalDataURI": "/redfish/v1/Systems/system/LogServices/EventLog/Entries/12/attachment",
+		"Resolved": false
+	}`
+
+// TestLogEntry tests getting the OpenBMC data of a log entry.
+func TestLogEntry(t *testing.T) {
+	var entry redfish.LogEntry
+	err := json.NewDecoder(strings.NewReader(logEntryBody)).Decode(&entry)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromLogEntry(&entry)
+	if err != nil {
+		t.Errorf("Error getting OpenBMC log entry: %s", err)
+	}
+
+	if result.Severity != redfish.CriticalEventSeverity {
+		t.Errorf("Invalid severity: %s", result.Severity)
+	}
+
+	if result.AdditionalDataURI != "/redfish/v1/Systems/system/LogServices/EventLog/Entries/12/attachment" {
+		t.Errorf("Invalid additional data URI: %s", result.AdditionalDataURI)
+	}
+
+	if result.Resolved {
+		t.Error("Entry should not be resolved")
+	}
+}
+
+// TestLogEntryResolve tests resolving a log entry.
+func TestLogEntryResolve(t *testing.T) {
+	var entry redfish.LogEntry
+	err := json.NewDecoder(strings.NewReader(logEntryBody)).Decode(&entry)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result, err := FromLogEntry(&entry)
+	if err != nil {
+		t.Errorf("Error getting OpenBMC log entry: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Resolve()
+	if err != nil {
+		t.Errorf("Error making Resolve call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != result.ODataID || calls[0].Payload != "{true}" {
+		t.Errorf("Unexpected Resolve calls: %v", calls)
+	}
+
+	if !result.Resolved {
+		t.Error("Entry should be resolved")
+	}
+}
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -129,10 +129,10 @@ type ExternalAccountProvider struct {
 	// Collection of certificates of the CertificateCollection type that the
 	// external account provider uses.
 	certificates string
-	// ldapService shall contain any additional mapping
+	// LDAPService shall contain any additional mapping
 	// information needed to parse a generic LDAP service.  This property
 	// should only be present inside the LDAP property.
-	ldapService string
+	LDAPService LDAPService
 	// PasswordSet shall contain `true` if a valid value was
 	// provided for the Password property.  Otherwise, the property shall
 	// contain `false`.
@@ -151,6 +151,59 @@ type ExternalAccountProvider struct {
 	ServiceEnabled bool
 }
 
+// UnmarshalJSON unmarshals an ExternalAccountProvider object from the raw
+// JSON.
+func (provider *ExternalAccountProvider) UnmarshalJSON(b []byte) error {
+	type temp ExternalAccountProvider
+	var t struct {
+		temp
+		Certificates common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*provider = ExternalAccountProvider(t.temp)
+
+	// Extract the links to other entities for later
+	provider.certificates = string(t.Certificates)
+
+	return nil
+}
+
+// Certificates gets the certificates the external account provider uses,
+// such as the certificate authority of its LDAP servers.
+func (provider *ExternalAccountProvider) Certificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, provider.certificates)
+}
+
+// LDAPSearchSettings shall contain all required settings to search a generic
+// LDAP service.
+type LDAPSearchSettings struct {
+	// BaseDistinguishedNames shall contain an array of base distinguished
+	// names to use to search an external LDAP service.
+	BaseDistinguishedNames []string
+	// GroupNameAttribute shall contain the attribute name that contains the
+	// LDAP group name.
+	GroupNameAttribute string
+	// GroupsAttribute shall contain the attribute name that contains the
+	// groups for an LDAP user entry.
+	GroupsAttribute string
+	// UsernameAttribute shall contain the attribute name that contains the
+	// LDAP user name.
+	UsernameAttribute string
+}
+
+// LDAPService shall contain all required settings to parse a generic LDAP
+// service.
+type LDAPService struct {
+	// SearchSettings shall contain the required settings to search an
+	// external LDAP service.
+	SearchSettings LDAPSearchSettings
+}
+
 // AccountService contains properties for managing user accounts. The
 // properties are common to all user accounts, such as password requirements,
 // and control features such as account lockout. The schema also contains links
@@ -198,13 +251,13 @@ type AccountService struct {
 	// accounts shall contain a link to a Resource Collection of type
 	// ManagerAccountCollection.
 	accounts string
-	// activeDirectory shall contain the first Active
+	// ActiveDirectory shall contain the first Active
 	// Directory external account provider that this Account Service
 	// supports.  If the Account Service supports one or more Active
 	// Directory services as an external account provider, this entity shall
 	// be populated by default.  This entity shall not be present in the
 	// AdditionalExternalAccountProviders Resource Collection.
-	activeDirectory string
+	ActiveDirectory ExternalAccountProvider
 	// additionalExternalAccountProviders shall contain the
 	// additional external account providers that this Account Service uses.
 	additionalExternalAccountProviders string
@@ -270,6 +323,8 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		Links AccountLinks
+		// AccountLockoutCounterResetEnabled defaults to true when absent
+		AccountLockoutCounterResetEnabled *bool
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -282,6 +337,8 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 	}
 
 	*accountservice = AccountService(t.temp)
+	accountservice.AccountLockoutCounterResetEnabled = t.AccountLockoutCounterResetEnabled == nil ||
+		*t.AccountLockoutCounterResetEnabled
 
 	// Extract the links to other entities for later
 	accountservice.accounts = string(t.Links.Accounts)
@@ -289,10 +346,18 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	accountservice.rawData = b
+	accountservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the account service to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (accountservice AccountService) MarshalJSON() ([]byte, error) {
+	type temp AccountService
+	return common.MarshalWithRawData(accountservice.rawData, temp(accountservice))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (accountservice *AccountService) Update() error {
 
@@ -320,31 +385,219 @@ func (accountservice *AccountService) Update() error {
 // GetAccountService will get the AccountService instance from the Redfish
 // service.
 func GetAccountService(c common.Client, uri string) (*AccountService, error) {
-	resp, err := c.Get(uri)
+	var accountService AccountService
+	err := common.FetchResource(c, uri, &accountService)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var accountService AccountService
-	rawData, err := ioutil.ReadAll(resp.Body)
+	return &accountService, nil
+}
+
+// Accounts get the accounts from the account service
+func (accountservice *AccountService) Accounts() ([]*ManagerAccount, error) {
+	return ListReferencedManagerAccounts(accountservice.Client, accountservice.accounts)
+}
+
+// LockoutEnabled tells whether the service locks accounts after
+// AccountLockoutThreshold failed login attempts. Accounts stay locked for
+// AccountLockoutDuration, or until they are unlocked if the counter is not
+// reset.
+func (accountservice *AccountService) LockoutEnabled() bool {
+	return accountservice.AccountLockoutThreshold > 0 &&
+		(accountservice.AccountLockoutDuration > 0 || !accountservice.AccountLockoutCounterResetEnabled)
+}
+
+// LockedAccounts gets the accounts that the service locked after too many
+// failed login attempts.
+func (accountservice *AccountService) LockedAccounts() ([]*ManagerAccount, error) {
+	accounts, err := accountservice.Accounts()
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &accountService)
+	var result []*ManagerAccount
+	for _, account := range accounts {
+		if account.Locked {
+			result = append(result, account)
+		}
+	}
+
+	return result, nil
+}
+
+// UnlockAccounts unlocks the accounts that the service locked, such as after
+// a storm of failed logins, and returns them.
+func (accountservice *AccountService) UnlockAccounts() ([]*ManagerAccount, error) {
+	accounts, err := accountservice.LockedAccounts()
 	if err != nil {
 		return nil, err
 	}
 
-	accountService.rawData = rawData
-	accountService.SetClient(c)
-	return &accountService, nil
+	for i, account := range accounts {
+		err = account.Unlock()
+		if err != nil {
+			return accounts[:i], err
+		}
+	}
+
+	return accounts, nil
 }
 
-// Accounts get the accounts from the account service
-func (accountservice *AccountService) Accounts() ([]*ManagerAccount, error) {
-	return ListReferencedManagerAccounts(accountservice.Client, accountservice.accounts)
+// CreateAccount creates an enabled account with the given user name, password
+// and role by posting it to the accounts collection.
+func (accountservice *AccountService) CreateAccount(userName, password, roleID string) error {
+	if accountservice.accounts == "" {
+		return fmt.Errorf("this account service does not have an accounts collection")
+	}
+
+	type temp struct {
+		UserName string
+		Password string
+		RoleID   string `json:"RoleId"`
+		Enabled  bool
+	}
+	t := temp{
+		UserName: userName,
+		Password: password,
+		RoleID:   roleID,
+		Enabled:  true,
+	}
+
+	_, err := accountservice.Client.Post(accountservice.accounts, t)
+	return err
+}
+
+// AccountChanges tells what AccountService.EnsureAccount changed.
+type AccountChanges struct {
+	// Account is the ensured account.
+	Account *ManagerAccount
+	// Created tells whether the account was created, or an empty account
+	// slot was used for it.
+	Created bool
+	// RoleChanged tells whether the role of the account was changed.
+	RoleChanged bool
+	// PasswordSet tells whether the password of the account was set.
+	PasswordSet bool
+	// Enabled tells whether the account was enabled.
+	Enabled bool
+}
+
+// Changed tells whether EnsureAccount changed anything.
+func (changes *AccountChanges) Changed() bool {
+	return changes.Created || changes.RoleChanged || changes.PasswordSet || changes.Enabled
+}
+
+// EnsureAccount makes sure an enabled account with the given user name and
+// role exists, creating it if it is missing. Services with a fixed set of
+// account slots, which have empty user names when they are unused, get the
+// account in the first slot that accepts it; other services get it posted
+// to the accounts collection.
+//
+// Services do not return passwords, so their drift can not be detected: the
+// password of an existing account is set if password is not empty, and left
+// as is otherwise. The role is left as is if roleID is empty.
+func (accountservice *AccountService) EnsureAccount(userName, password, roleID string) (*AccountChanges, error) {
+	if userName == "" {
+		return nil, fmt.Errorf("a user name is required to ensure an account")
+	}
+
+	accounts, err := accountservice.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &AccountChanges{}
+	var slots []*ManagerAccount
+	for _, account := range accounts {
+		if account.UserName == userName {
+			changes.Account = account
+			break
+		}
+		if account.UserName == "" {
+			slots = append(slots, account)
+		}
+	}
+
+	if changes.Account == nil {
+		if password == "" {
+			return nil, fmt.Errorf("a password is required to create account %s", userName)
+		}
+
+		changes.Account, err = accountservice.createAccount(userName, password, roleID, slots)
+		if err != nil {
+			return nil, err
+		}
+		changes.Created = true
+		changes.PasswordSet = true
+		changes.Enabled = true
+		return changes, nil
+	}
+
+	account := changes.Account
+	if roleID != "" && account.RoleID != roleID {
+		account.RoleID = roleID
+		changes.RoleChanged = true
+	}
+	if password != "" {
+		account.Password = password
+		changes.PasswordSet = true
+	}
+	if !account.Enabled {
+		account.Enabled = true
+		changes.Enabled = true
+	}
+
+	if !changes.Changed() {
+		return changes, nil
+	}
+
+	err = account.Update()
+	if err != nil {
+		return nil, err
+	}
+	account.Password = ""
+
+	return changes, nil
+}
+
+// createAccount creates an enabled account, in the first of the empty
+// account slots that accepts it if there are any, and returns it.
+func (accountservice *AccountService) createAccount(userName, password, roleID string, slots []*ManagerAccount) (*ManagerAccount, error) {
+	if len(slots) == 0 {
+		err := accountservice.CreateAccount(userName, password, roleID)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts, err := accountservice.Accounts()
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			if account.UserName == userName {
+				return account, nil
+			}
+		}
+		return nil, fmt.Errorf("account %s was not created", userName)
+	}
+
+	// Some slots are reserved, such as the first one of iDRAC, and reject
+	// being set
+	var err error
+	for _, slot := range slots {
+		slot.UserName = userName
+		slot.Password = password
+		slot.RoleID = roleID
+		slot.Enabled = true
+		err = slot.Update()
+		if err == nil {
+			slot.Password = ""
+			return slot, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no account slot accepted account %s: %s", userName, err)
 }
 
 // Roles gets the roles from the account service
@@ -65,6 +65,14 @@ func TestAccountService(t *testing.T) {
 	if result.roles != "/redfish/v1/AccountService/Roles" {
 		t.Errorf("Received invalid Roles: %s", result.roles)
 	}
+
+	if !result.AccountLockoutCounterResetEnabled {
+		t.Errorf("The lockout counter reset should default to enabled")
+	}
+
+	if result.LockoutEnabled() {
+		t.Errorf("Lockout should be disabled without a lockout threshold")
+	}
 }
 
 // TestAccountServiceUpdate tests the Update call for the account service.
@@ -97,3 +105,91 @@ func TestAccountServiceUpdate(t *testing.T) {
 		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestAccountServiceCreateAccount tests the CreateAccount call.
+func TestAccountServiceCreateAccount(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateAccount("automation", "s3cret", "Operator")
+	if err != nil {
+		t.Errorf("Error making CreateAccount call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/AccountService/Accounts" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if calls[0].Payload != "{automation s3cret Operator true}" {
+		t.Errorf("Unexpected create payload: %s", calls[0].Payload)
+	}
+}
+
+// TestAccountServiceUnlockAccounts tests unlocking the locked accounts.
+func TestAccountServiceUnlockAccounts(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/AccountService/Accounts": `{
+			"Members": [
+				{"@odata.id": "/redfish/v1/AccountService/Accounts/1"},
+				{"@odata.id": "/redfish/v1/AccountService/Accounts/2"}
+			],
+			"Members@odata.count": 2
+		}`,
+		"/redfish/v1/AccountService/Accounts/1": `{
+			"@odata.id": "/redfish/v1/AccountService/Accounts/1",
+			"Id": "1",
+			"UserName": "Administrator",
+			"Locked": false
+		}`,
+		"/redfish/v1/AccountService/Accounts/2": `{
+			"@odata.id": "/redfish/v1/AccountService/Accounts/2",
+			"Id": "2",
+			"UserName": "operator",
+			"Locked": true
+		}`,
+	}}
+
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Id": "AccountService",
+		"AccountLockoutThreshold": 5,
+		"AccountLockoutDuration": 600,
+		"AccountLockoutCounterResetEnabled": false,
+		"Accounts": {
+			"@odata.id": "/redfish/v1/AccountService/Accounts"
+		}
+	}`)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	result.SetClient(testClient)
+
+	if result.AccountLockoutCounterResetEnabled || !result.LockoutEnabled() {
+		t.Errorf("Invalid lockout policy: %v", result)
+	}
+
+	accounts, err := result.UnlockAccounts()
+	if err != nil {
+		t.Fatalf("Error unlocking accounts: %s", err)
+	}
+
+	if len(accounts) != 1 || accounts[0].ID != "2" || accounts[0].Locked {
+		t.Errorf("Invalid unlocked accounts: %v", accounts)
+	}
+
+	calls := testClient.CapturedCalls()
+	last := calls[len(calls)-1]
+	if last.Action != "PATH" || last.URL != "/redfish/v1/AccountService/Accounts/2" {
+		t.Errorf("Unexpected unlock call: %v", last)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -55,10 +54,18 @@ func (assembly *Assembly) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	assembly.rawData = b
+	assembly.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the assembly to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (assembly Assembly) MarshalJSON() ([]byte, error) {
+	type temp Assembly
+	return common.MarshalWithRawData(assembly.rawData, temp(assembly))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (assembly *Assembly) Update() error {
 
@@ -79,25 +86,12 @@ func (assembly *Assembly) Update() error {
 
 // GetAssembly will get a Assembly instance from the service.
 func GetAssembly(c common.Client, uri string) (*Assembly, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var assembly Assembly
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &assembly)
+	err := common.FetchResource(c, uri, &assembly)
 	if err != nil {
 		return nil, err
 	}
 
-	assembly.rawData = rawData
-	assembly.SetClient(c)
 	return &assembly, nil
 }
 
@@ -115,7 +109,11 @@ func ListReferencedAssemblys(c common.Client, link string) ([]*Assembly, error)
 	}
 
 	for _, assemblyLink := range links.ItemLinks {
-		assembly, err := GetAssembly(c, assemblyLink)
+		assembly := new(Assembly)
+		expanded, err := links.DecodeMember(c, assemblyLink, assembly)
+		if err == nil && !expanded {
+			assembly, err = GetAssembly(c, assemblyLink)
+		}
 		if err != nil {
 			return result, err
 		}
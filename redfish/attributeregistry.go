@@ -0,0 +1,214 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// AttributeType is the data type of an attribute.
+type AttributeType string
+
+const (
+	// BooleanAttributeType is a flag with a true or false value.
+	BooleanAttributeType AttributeType = "Boolean"
+	// EnumerationAttributeType is a list of the known possible enumerated
+	// values.
+	EnumerationAttributeType AttributeType = "Enumeration"
+	// IntegerAttributeType is an integer value.
+	IntegerAttributeType AttributeType = "Integer"
+	// PasswordAttributeType is a password value that shall be null in
+	// responses.
+	PasswordAttributeType AttributeType = "Password"
+	// StringAttributeType is a free-form text value.
+	StringAttributeType AttributeType = "String"
+)
+
+// AttributeValue shall describe a possible enumeration attribute value.
+type AttributeValue struct {
+	// ValueDisplayName shall contain a user-readable display string of the
+	// value for the attribute in the defined language.
+	ValueDisplayName string
+	// ValueName shall contain the unique value name of the attribute.
+	ValueName string
+}
+
+// Attribute shall describe an attribute and its possible values and other
+// metadata.
+type Attribute struct {
+	// AttributeName shall contain the name of the attribute and shall be
+	// unique within the attribute registry.
+	AttributeName string
+	// DisplayName shall contain the user-readable display string for the
+	// attribute in the defined language.
+	DisplayName string
+	// HelpText shall contain help text for the attribute.
+	HelpText string
+	// Hidden shall indicate whether this attribute is hidden in user
+	// interfaces.
+	Hidden bool
+	// Immutable shall indicate whether this attribute is immutable. Immutable
+	// attributes shall not be modified and typically reflect a hardware value.
+	Immutable bool
+	// LowerBound shall contain the lower limit of the value of an attribute
+	// of type Integer.
+	LowerBound int
+	// MaxLength shall contain the maximum character length of an attribute
+	// of type String.
+	MaxLength int
+	// MinLength shall contain the minimum character length of an attribute
+	// of type String.
+	MinLength int
+	// ReadOnly shall indicate whether this attribute is read-only. A
+	// read-only attribute cannot be modified, and should be grayed out in
+	// user interfaces.
+	ReadOnly bool
+	// ResetRequired shall indicate whether a system or device reset is
+	// required for this attribute value change to take effect.
+	ResetRequired bool
+	// Type shall contain the type of the attribute.
+	Type AttributeType
+	// UpperBound shall contain the upper limit of the value of an attribute
+	// of type Integer.
+	UpperBound int
+	// Value shall contain an array containing the possible values of an
+	// attribute of type Enumeration.
+	Value []AttributeValue
+	// WriteOnly shall indicate whether the attribute is write-only, such as
+	// a password that can be set but not read.
+	WriteOnly bool
+}
+
+// RegistryEntries shall list attributes for this component, along with
+// their possible values, dependencies, and other metadata.
+type RegistryEntries struct {
+	// Attributes shall contain an array containing the attributes and their
+	// possible values and other metadata in the attribute registry.
+	Attributes []Attribute
+}
+
+// SupportedSystems shall describe a system for which this attribute registry
+// is applicable.
+type SupportedSystems struct {
+	// FirmwareVersion shall contain the version of the component firmware
+	// image to which this attribute registry applies.
+	FirmwareVersion string
+	// ProductName shall contain the product name of the computer system to
+	// which this attribute registry applies.
+	ProductName string
+	// SystemID shall contain the system ID that identifies the computer
+	// system model to which this attribute registry applies.
+	SystemID string `json:"SystemId"`
+}
+
+// AttributeRegistry shall contain a set of key-value pairs that represent
+// the structure of an attribute registry, such as the one describing the
+// attributes of a Bios resource.
+type AttributeRegistry struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Language shall contain an RFC5646-conformant language code.
+	Language string
+	// OwningEntity shall represent the publisher of this attribute registry.
+	OwningEntity string
+	// RegistryEntries shall list attributes for this component, along with
+	// their possible values, dependencies, and other metadata.
+	RegistryEntries RegistryEntries
+	// RegistryVersion shall contain the version of this attribute registry.
+	RegistryVersion string
+	// SupportedSystems shall contain an array containing a list of systems
+	// that this attribute registry supports.
+	SupportedSystems []SupportedSystems
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (attributeregistry *AttributeRegistry) GetRawData() []byte {
+	return attributeregistry.rawData
+}
+
+// UnmarshalJSON unmarshals an AttributeRegistry object from the raw JSON.
+func (attributeregistry *AttributeRegistry) UnmarshalJSON(b []byte) error {
+	type temp AttributeRegistry
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*attributeregistry = AttributeRegistry(t.temp)
+	attributeregistry.rawData = b
+	attributeregistry.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the attribute registry to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (attributeregistry AttributeRegistry) MarshalJSON() ([]byte, error) {
+	type temp AttributeRegistry
+	return common.MarshalWithRawData(attributeregistry.rawData, temp(attributeregistry))
+}
+
+// GetAttributeRegistry will get an AttributeRegistry instance from the
+// service.
+func GetAttributeRegistry(c common.Client, uri string) (*AttributeRegistry, error) {
+	var attributeregistry AttributeRegistry
+	err := common.FetchResource(c, uri, &attributeregistry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attributeregistry, nil
+}
+
+// Attribute gets the registry entry of an attribute, or nil if the registry
+// does not describe it.
+func (attributeregistry *AttributeRegistry) Attribute(name string) *Attribute {
+	for i := range attributeregistry.RegistryEntries.Attributes {
+		if attributeregistry.RegistryEntries.Attributes[i].AttributeName == name {
+			return &attributeregistry.RegistryEntries.Attributes[i]
+		}
+	}
+
+	return nil
+}
+
+// ValidateAttributes checks attribute changes against the registry before
+// they are sent with Bios.UpdateBiosAttributes. A
+// common.ErrorUnwritableProperties listing every attribute the registry does
+// not describe, or marks as read only or immutable, is returned.
+func (attributeregistry *AttributeRegistry) ValidateAttributes(attrs BiosAttributes) error {
+	var unwritable []string
+	for name := range attrs {
+		attribute := attributeregistry.Attribute(name)
+		if attribute == nil || attribute.ReadOnly || attribute.Immutable {
+			unwritable = append(unwritable, name)
+		}
+	}
+
+	if len(unwritable) == 0 {
+		return nil
+	}
+
+	sort.Strings(unwritable)
+	return common.ErrorUnwritableProperties{
+		Properties: unwritable,
+		Reason:     "attribute registry " + attributeregistry.ID,
+	}
+}
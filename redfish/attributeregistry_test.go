@@ -0,0 +1,117 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var attributeRegistryBody = `{
+		"@odata.type": "#AttributeRegistry.v1_3_0.AttributeRegistry",
+		"Id": "BiosAttributeRegistryP89.v1_0_0",
+		"Name": "BIOS Attribute Registry",
+		"Language": "en",
+		"OwningEntity": "Contoso",
+		"RegistryVersion": "1.0.0",
+		"SupportedSystems": [
+			{
+				"ProductName": "Contoso Server",
+				"SystemId": "P89",
+				"FirmwareVersion": "P89 v1.00 (06/02/2014)"
+			}
+		],
+		"RegistryEntries": {
+			"Attributes": [
+				{
+					"AttributeName": "BootMode",
+					"DisplayName": "Boot Mode",
+					"Type": "Enumeration",
+					"ReadOnly": false,
+					"ResetRequired": true,
+					"Value": [
+						{
+							"ValueName": "Uefi",
+							"ValueDisplayName": "UEFI"
+						},
+						{
+							"ValueName": "LegacyBios",
+							"ValueDisplayName": "Legacy BIOS"
+						}
+					]
+				},
+				{
+					"AttributeName": "ProcCores",
+					"DisplayName": "Processor Cores",
+					"Type": "Integer",
+					"ReadOnly": true,
+					"LowerBound": 1,
+					"UpperBound": 32
+				}
+			]
+		}
+	}`
+
+// TestAttributeRegistry tests the parsing of AttributeRegistry objects.
+func TestAttributeRegistry(t *testing.T) {
+	var result AttributeRegistry
+	err := json.NewDecoder(strings.NewReader(attributeRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BiosAttributeRegistryP89.v1_0_0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.SupportedSystems[0].SystemID != "P89" {
+		t.Errorf("Invalid supported system: %s", result.SupportedSystems[0].SystemID)
+	}
+
+	bootMode := result.Attribute("BootMode")
+	if bootMode == nil || bootMode.Type != EnumerationAttributeType || len(bootMode.Value) != 2 {
+		t.Errorf("Invalid BootMode attribute: %v", bootMode)
+	}
+
+	if result.Attribute("Missing") != nil {
+		t.Error("Attributes missing from the registry should not be found")
+	}
+}
+
+// TestAttributeRegistryValidateAttributes tests checking attribute changes
+// against the registry.
+func TestAttributeRegistryValidateAttributes(t *testing.T) {
+	var result AttributeRegistry
+	err := json.NewDecoder(strings.NewReader(attributeRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	err = result.ValidateAttributes(BiosAttributes{"BootMode": "Uefi"})
+	if err != nil {
+		t.Errorf("Writable attributes should be valid: %s", err)
+	}
+
+	err = result.ValidateAttributes(BiosAttributes{
+		"BootMode":  "Uefi",
+		"ProcCores": 4,
+		"Missing":   true,
+	})
+
+	var unwritable common.ErrorUnwritableProperties
+	if !errors.As(err, &unwritable) {
+		t.Fatalf("Expected an unwritable properties error, got: %v", err)
+	}
+
+	if strings.Join(unwritable.Properties, ",") != "Missing,ProcCores" {
+		t.Errorf("Unexpected unwritable attributes: %v", unwritable.Properties)
+	}
+}
@@ -0,0 +1,385 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ChargeState is the charge state of a battery.
+type ChargeState string
+
+const (
+	// IdleChargeState shall indicate the battery is idle and energy is not
+	// entering or leaving the battery. Small amounts of energy may enter or
+	// leave the battery while in this state if the battery is regulating
+	// itself.
+	IdleChargeState ChargeState = "Idle"
+	// ChargingChargeState shall indicate the battery is charging and energy
+	// is entering the battery.
+	ChargingChargeState ChargeState = "Charging"
+	// DischargingChargeState shall indicate the battery is discharging and
+	// energy is leaving the battery.
+	DischargingChargeState ChargeState = "Discharging"
+)
+
+// Battery shall describe a battery unit, such as those used to provide
+// systems with power during a power loss event.
+type Battery struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// CapacityActualAmpHours shall contain the actual maximum capacity of
+	// this battery in amp-hour units.
+	CapacityActualAmpHours float32
+	// CapacityActualWattHours shall contain the actual maximum capacity of
+	// this battery in watt-hour units.
+	CapacityActualWattHours float32
+	// CapacityRatedAmpHours shall contain the rated maximum capacity of this
+	// battery in amp-hour units.
+	CapacityRatedAmpHours float32
+	// CapacityRatedWattHours shall contain the rated maximum capacity of
+	// this battery in watt-hour units.
+	CapacityRatedWattHours float32
+	// ChargeState shall contain the charge state of this battery.
+	ChargeState ChargeState
+	// Description provides a description of this resource.
+	Description string
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this battery.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain location information of this battery.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the battery.
+	Manufacturer string
+	// MaxChargeRateAmps shall contain the maximum charge rate at the input
+	// of this battery in amp units.
+	MaxChargeRateAmps float32
+	// MaxChargeVoltage shall contain the maximum charge voltage across the
+	// cell pack of this battery when it is fully charged.
+	MaxChargeVoltage float32
+	// MaxDischargeRateAmps shall contain the maximum discharge rate at the
+	// output of this battery in amp units.
+	MaxDischargeRateAmps float32
+	// metrics shall be a link to a resource of type BatteryMetrics.
+	metrics string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this battery.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this battery.
+	PartNumber string
+	// ProductionDate shall contain the date of production or manufacture
+	// for this battery.
+	ProductionDate string
+	// Replaceable shall indicate whether this component can be independently
+	// replaced as allowed by the vendor's replacement policy.
+	Replaceable bool
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this battery.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this battery.
+	SparePartNumber string
+	// StateOfHealthPercent shall contain the state of health, in percent
+	// units, of this battery.
+	StateOfHealthPercent SensorExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Version shall contain the hardware version of this battery as
+	// determined by the vendor or supplier.
+	Version string
+	// calibrateTarget is the URL to send Calibrate actions to.
+	calibrateTarget string
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
+	// SupportedResetTypes, if provided, is the reset types this battery
+	// supports.
+	SupportedResetTypes []ResetType
+	// selfTestTarget is the URL to send SelfTest actions to.
+	selfTestTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (battery *Battery) GetRawData() []byte {
+	return battery.rawData
+}
+
+// UnmarshalJSON unmarshals a Battery object from the raw JSON.
+func (battery *Battery) UnmarshalJSON(b []byte) error {
+	type temp Battery
+	type Actions struct {
+		Calibrate struct {
+			Target string
+		} `json:"#Battery.Calibrate"`
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#Battery.Reset"`
+		SelfTest struct {
+			Target string
+		} `json:"#Battery.SelfTest"`
+	}
+	var t struct {
+		temp
+		Actions  Actions
+		Assembly common.Link
+		Metrics  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*battery = Battery(t.temp)
+
+	// Extract the links to other entities for later
+	battery.assembly = string(t.Assembly)
+	battery.metrics = string(t.Metrics)
+	battery.calibrateTarget = t.Actions.Calibrate.Target
+	battery.resetTarget = t.Actions.Reset.Target
+	battery.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+	battery.selfTestTarget = t.Actions.SelfTest.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	battery.rawData = b
+	battery.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the battery to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (battery Battery) MarshalJSON() ([]byte, error) {
+	type temp Battery
+	return common.MarshalWithRawData(battery.rawData, temp(battery))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (battery *Battery) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Battery)
+	original.UnmarshalJSON(battery.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(battery).Elem()
+
+	return battery.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetBattery will get a Battery instance from the service.
+func GetBattery(c common.Client, uri string) (*Battery, error) {
+	var battery Battery
+	err := common.FetchResource(c, uri, &battery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &battery, nil
+}
+
+// ListReferencedBatteries gets the collection of Battery from
+// a provided reference.
+func ListReferencedBatteries(c common.Client, link string) ([]*Battery, error) {
+	var result []*Battery
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, batteryLink := range links.ItemLinks {
+		battery := new(Battery)
+		expanded, err := links.DecodeMember(c, batteryLink, battery)
+		if err == nil && !expanded {
+			battery, err = GetBattery(c, batteryLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, battery)
+	}
+
+	return result, nil
+}
+
+// Assembly gets the Assembly for this battery.
+func (battery *Battery) Assembly() (*Assembly, error) {
+	if battery.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(battery.Client, battery.assembly)
+}
+
+// Metrics gets the metrics associated with this battery.
+func (battery *Battery) Metrics() (*BatteryMetrics, error) {
+	if battery.metrics == "" {
+		return nil, nil
+	}
+
+	return GetBatteryMetrics(battery.Client, battery.metrics)
+}
+
+// Calibrate shall perform a self-calibration, or learn cycle, of the battery.
+func (battery *Battery) Calibrate() error {
+	if battery.calibrateTarget == "" {
+		return fmt.Errorf("Calibrate is not supported by this battery")
+	}
+
+	_, err := battery.Client.Post(battery.calibrateTarget, struct{}{})
+	return err
+}
+
+// Reset shall reset the battery.
+func (battery *Battery) Reset(resetType ResetType) error {
+	if battery.resetTarget == "" {
+		return fmt.Errorf("Reset is not supported by this battery")
+	}
+
+	err := ValidateResetType(resetType, battery.SupportedResetTypes, "battery")
+	if err != nil {
+		return err
+	}
+
+	type temp struct {
+		ResetType ResetType `json:",omitempty"`
+	}
+	t := temp{
+		ResetType: resetType,
+	}
+
+	_, err = battery.Client.Post(battery.resetTarget, t)
+	return err
+}
+
+// SelfTest shall perform a self-test of the battery.
+func (battery *Battery) SelfTest() error {
+	if battery.selfTestTarget == "" {
+		return fmt.Errorf("SelfTest is not supported by this battery")
+	}
+
+	_, err := battery.Client.Post(battery.selfTestTarget, struct{}{})
+	return err
+}
+
+// BatteryMetrics shall contain the metrics of a battery unit.
+type BatteryMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CellVoltages shall contain the cell voltages, in volt units, for this
+	// battery.
+	CellVoltages []SensorVoltageExcerpt
+	// ChargePercent shall contain the amount of charge available, in percent
+	// units, in this battery.
+	ChargePercent SensorExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// DischargeCycles shall contain the number of discharges this battery
+	// has sustained.
+	DischargeCycles float32
+	// InputCurrentAmps shall contain the current, in ampere units, entering
+	// the battery.
+	InputCurrentAmps SensorCurrentExcerpt
+	// InputVoltage shall contain the voltage, in volt units, entering the
+	// battery.
+	InputVoltage SensorVoltageExcerpt
+	// OutputCurrentAmps shall contain the output currents, in ampere units,
+	// for this battery.
+	OutputCurrentAmps []SensorCurrentExcerpt
+	// OutputVoltages shall contain the output voltages, in volt units, for
+	// this battery.
+	OutputVoltages []SensorVoltageExcerpt
+	// StoredChargeAmpHours shall contain the stored charge, in amp-hour
+	// units, in this battery.
+	StoredChargeAmpHours SensorExcerpt
+	// StoredEnergyWattHours shall contain the stored energy, in watt-hour
+	// units, in this battery.
+	StoredEnergyWattHours SensorExcerpt
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this battery.
+	TemperatureCelsius SensorExcerpt
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (batterymetrics *BatteryMetrics) GetRawData() []byte {
+	return batterymetrics.rawData
+}
+
+// UnmarshalJSON unmarshals a BatteryMetrics object from the raw JSON.
+func (batterymetrics *BatteryMetrics) UnmarshalJSON(b []byte) error {
+	type temp BatteryMetrics
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*batterymetrics = BatteryMetrics(t.temp)
+	batterymetrics.rawData = b
+	batterymetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the battery metrics to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (batterymetrics BatteryMetrics) MarshalJSON() ([]byte, error) {
+	type temp BatteryMetrics
+	return common.MarshalWithRawData(batterymetrics.rawData, temp(batterymetrics))
+}
+
+// GetBatteryMetrics will get a BatteryMetrics instance from the service.
+func GetBatteryMetrics(c common.Client, uri string) (*BatteryMetrics, error) {
+	var batterymetrics BatteryMetrics
+	err := common.FetchResource(c, uri, &batterymetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batterymetrics, nil
+}
@@ -0,0 +1,222 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var batteryBody = `{
+		"@odata.type": "#Battery.v1_0_0.Battery",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1",
+		"Id": "Module1",
+		"Name": "Battery 1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Location": {
+			"PartLocation": {
+				"ServiceLabel": "Battery 1",
+				"LocationType": "Bay",
+				"LocationOrdinalValue": 0
+			}
+		},
+		"Model": "RKS-440DC",
+		"Manufacturer": "Contoso Power",
+		"FirmwareVersion": "1.00",
+		"Version": "A05",
+		"ProductionDate": "2019-10-01T06:00:00Z",
+		"SerialNumber": "3488247",
+		"PartNumber": "23456-133",
+		"SparePartNumber": "93284-133",
+		"LocationIndicatorActive": false,
+		"HotPluggable": true,
+		"CapacityRatedWattHours": 20,
+		"CapacityActualWattHours": 19.41,
+		"MaxDischargeRateAmps": 1,
+		"StateOfHealthPercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryHealth",
+			"Reading": 91
+		},
+		"ChargeState": "Idle",
+		"Metrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics"
+		},
+		"Actions": {
+			"#Battery.Calibrate": {
+				"target": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Actions/Battery.Calibrate"
+			},
+			"#Battery.SelfTest": {
+				"target": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Actions/Battery.SelfTest"
+			}
+		}
+	}`
+
+var batteryMetricsBody = `{
+		"@odata.type": "#BatteryMetrics.v1_0_0.BatteryMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics",
+		"Id": "Metrics",
+		"Name": "Metrics for Battery 1",
+		"InputVoltage": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryInputVoltage",
+			"Reading": 12.22
+		},
+		"ChargePercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryCharge",
+			"Reading": 68.3
+		},
+		"CellVoltages": [
+			{
+				"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryCell1Voltage",
+				"Reading": 3.86
+			},
+			{
+				"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryCell2Voltage",
+				"Reading": 3.85
+			}
+		],
+		"DischargeCycles": 5.6,
+		"TemperatureCelsius": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/BatteryTemp",
+			"Reading": 33
+		}
+	}`
+
+// TestBattery tests the parsing of Battery objects.
+func TestBattery(t *testing.T) {
+	var result Battery
+	err := json.NewDecoder(strings.NewReader(batteryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Module1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ChargeState != IdleChargeState {
+		t.Errorf("Invalid charge state: %s", result.ChargeState)
+	}
+
+	if result.StateOfHealthPercent.Reading != 91 {
+		t.Errorf("Invalid state of health: %f", result.StateOfHealthPercent.Reading)
+	}
+
+	if result.CapacityActualWattHours != 19.41 {
+		t.Errorf("Invalid actual capacity: %f", result.CapacityActualWattHours)
+	}
+
+	if result.metrics != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Metrics" {
+		t.Errorf("Invalid metrics link: %s", result.metrics)
+	}
+
+	if result.calibrateTarget != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Actions/Battery.Calibrate" {
+		t.Errorf("Invalid Calibrate target: %s", result.calibrateTarget)
+	}
+
+	if result.selfTestTarget != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries/Module1/Actions/Battery.SelfTest" {
+		t.Errorf("Invalid SelfTest target: %s", result.selfTestTarget)
+	}
+}
+
+// TestBatteryActions tests the Calibrate and SelfTest calls.
+func TestBatteryActions(t *testing.T) {
+	var result Battery
+	err := json.NewDecoder(strings.NewReader(batteryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Calibrate()
+	if err != nil {
+		t.Errorf("Error making Calibrate call: %s", err)
+	}
+
+	err = result.SelfTest()
+	if err != nil {
+		t.Errorf("Error making SelfTest call: %s", err)
+	}
+
+	err = result.Reset(ForceRestartResetType)
+	if err == nil {
+		t.Error("Reset should fail when the action is not advertised")
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.calibrateTarget {
+		t.Errorf("Unexpected Calibrate target: %s", calls[0].URL)
+	}
+
+	if calls[1].URL != result.selfTestTarget {
+		t.Errorf("Unexpected SelfTest target: %s", calls[1].URL)
+	}
+}
+
+// TestBatteryUpdate tests the Update call.
+func TestBatteryUpdate(t *testing.T) {
+	var result Battery
+	err := json.NewDecoder(strings.NewReader(batteryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestBatteryMetrics tests the parsing of BatteryMetrics objects.
+func TestBatteryMetrics(t *testing.T) {
+	var result BatteryMetrics
+	err := json.NewDecoder(strings.NewReader(batteryMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Metrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ChargePercent.Reading != 68.3 {
+		t.Errorf("Invalid charge percent: %f", result.ChargePercent.Reading)
+	}
+
+	if len(result.CellVoltages) != 2 {
+		t.Errorf("Expected 2 cell voltages, got %d", len(result.CellVoltages))
+	}
+
+	if result.TemperatureCelsius.DataSourceURI != "/redfish/v1/Chassis/1U/Sensors/BatteryTemp" {
+		t.Errorf("Invalid temperature data source: %s", result.TemperatureCelsius.DataSourceURI)
+	}
+}
@@ -7,7 +7,7 @@ package redfish
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"sort"
 	"strings"
 
 	"github.com/LRichi/WBfish/common"
@@ -82,6 +82,12 @@ type Bios struct {
 	changePasswordTarget string
 	// resetBiosTarget is the URL to send ResetBios requests.
 	resetBiosTarget string
+	// settingsTarget is the URL of the settings resource to send pending
+	// attribute changes to, if the service uses one.
+	settingsTarget string
+	// supportedApplyTimes are the apply times the settings resource can be
+	// given, if the service lists them.
+	supportedApplyTimes []common.ApplyTime
 	// rawData holds the original serialized JSON
 	rawData []byte
 }
@@ -104,7 +110,11 @@ func (bios *Bios) UnmarshalJSON(b []byte) error {
 	}
 	var t struct {
 		temp
-		Actions Actions
+		Actions  Actions
+		Settings struct {
+			SettingsObject      common.Link
+			SupportedApplyTimes []common.ApplyTime
+		} `json:"@Redfish.Settings"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -117,31 +127,29 @@ func (bios *Bios) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	bios.changePasswordTarget = t.Actions.ChangePassword.Target
 	bios.resetBiosTarget = t.Actions.ResetBios.Target
+	bios.settingsTarget = string(t.Settings.SettingsObject)
+	bios.supportedApplyTimes = t.Settings.SupportedApplyTimes
+	bios.rawData = b
+	bios.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the BIOS settings to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (bios Bios) MarshalJSON() ([]byte, error) {
+	type temp Bios
+	return common.MarshalWithRawData(bios.rawData, temp(bios))
+}
+
 // GetBios will get a Bios instance from the service.
 func GetBios(c common.Client, uri string) (*Bios, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var bios Bios
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &bios)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &bios)
-	if err != nil {
-		return nil, err
-	}
-
-	bios.rawData = rawData
-	bios.SetClient(c)
 	return &bios, nil
 }
 
@@ -158,7 +166,11 @@ func ListReferencedBioss(c common.Client, link string) ([]*Bios, error) {
 	}
 
 	for _, biosLink := range links.ItemLinks {
-		bios, err := GetBios(c, biosLink)
+		bios := new(Bios)
+		expanded, err := links.DecodeMember(c, biosLink, bios)
+		if err == nil && !expanded {
+			bios, err = GetBios(c, biosLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -202,3 +214,140 @@ func (bios *Bios) ResetBios() error {
 	_, err := bios.Client.Post(bios.resetBiosTarget, nil)
 	return err
 }
+
+// UpdateBiosAttributes sets the given BIOS attributes. If the service uses a
+// settings resource the changes are sent there and are typically applied on
+// the next system reset. The changes can be checked first with
+// AttributeRegistry.ValidateAttributes against the registry named by
+// AttributeRegistry.
+func (bios *Bios) UpdateBiosAttributes(attrs BiosAttributes) error {
+	target := bios.settingsTarget
+	if target == "" {
+		target = bios.ODataID
+	}
+
+	type temp struct {
+		Attributes BiosAttributes
+	}
+	t := temp{
+		Attributes: attrs,
+	}
+
+	_, err := bios.Client.Patch(target, t)
+	return err
+}
+
+// PendingAttributes gets the BIOS attributes of the settings resource, that
+// are to be applied on the next system reset. It returns nil if the service
+// does not use a settings resource.
+func (bios *Bios) PendingAttributes() (BiosAttributes, error) {
+	if !bios.usesSettings() {
+		return nil, nil
+	}
+
+	resp, err := bios.Client.Get(bios.settingsTarget)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var t struct {
+		Attributes BiosAttributes
+	}
+	err = json.NewDecoder(resp.Body).Decode(&t)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Attributes, nil
+}
+
+// usesSettings tells whether attribute changes are sent to a settings
+// resource rather than to the BIOS itself.
+func (bios *Bios) usesSettings() bool {
+	return bios.settingsTarget != "" && bios.settingsTarget != bios.ODataID
+}
+
+// BiosAttributesChange is the outcome of Bios.ApplyAttributes.
+type BiosAttributesChange struct {
+	// Changed are the attributes that were sent to the service, the ones
+	// neither set nor pending already.
+	Changed BiosAttributes
+	// RebootRequired are the names of the attributes, in order, that are
+	// pending in the settings resource and take effect on the next system
+	// reset, whether they were just changed or already pending.
+	RebootRequired []string
+}
+
+// ApplyAttributes sets the BIOS attributes that differ from the desired
+// ones. The desired attributes are compared with the current and pending
+// ones, and only those neither set nor pending already are sent, to the
+// settings resource if the service uses one, with applyTime if it is set.
+// Values are compared by their string representation, so that 3 and 3.0 or
+// true and "true" are the same.
+func (bios *Bios) ApplyAttributes(desired BiosAttributes, applyTime common.ApplyTime) (*BiosAttributesChange, error) {
+	if applyTime != "" && len(bios.supportedApplyTimes) > 0 {
+		supported := false
+		for _, supportedApplyTime := range bios.supportedApplyTimes {
+			supported = supported || supportedApplyTime == applyTime
+		}
+		if !supported {
+			return nil, fmt.Errorf("apply time '%s' is not supported by this BIOS", applyTime)
+		}
+	}
+
+	pending, err := bios.PendingAttributes()
+	if err != nil {
+		return nil, err
+	}
+
+	change := &BiosAttributesChange{Changed: BiosAttributes{}}
+	for name, value := range desired {
+		pendingValue, isPending := pending[name]
+		switch {
+		case isPending && biosAttributeEqual(pendingValue, value):
+		case !isPending && biosAttributeEqual(bios.Attributes[name], value):
+		default:
+			change.Changed[name] = value
+		}
+
+		if bios.usesSettings() && !biosAttributeEqual(bios.Attributes[name], value) {
+			change.RebootRequired = append(change.RebootRequired, name)
+		}
+	}
+	sort.Strings(change.RebootRequired)
+
+	if len(change.Changed) == 0 {
+		return change, nil
+	}
+
+	target := bios.settingsTarget
+	if target == "" {
+		target = bios.ODataID
+	}
+
+	payload := map[string]interface{}{
+		"Attributes": change.Changed,
+	}
+	if applyTime != "" {
+		payload["@Redfish.SettingsApplyTime"] = map[string]interface{}{
+			"ApplyTime": applyTime,
+		}
+	}
+
+	_, err = bios.Client.Patch(target, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// biosAttributeEqual tells whether two BIOS attribute values are the same.
+func biosAttributeEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/LRichi/WBfish/common"
 )
 
 var biosBody = strings.NewReader(
@@ -41,6 +43,12 @@ var biosBody = strings.NewReader(
 			"#Bios.ChangePassword": {
 				"target": "/redfish/v1/Systems/437XR1138R2/BIOS/Actions/Bios.ChangePassword"
 			}
+		},
+		"@Redfish.Settings": {
+			"@odata.type": "#Settings.v1_0_0.Settings",
+			"SettingsObject": {
+				"@odata.id": "/redfish/v1/Systems/437XR1138R2/BIOS/SD"
+			}
 		}
 	}`)
 
@@ -73,6 +81,10 @@ func TestBios(t *testing.T) {
 		t.Errorf("Invalid ChangePassword target: %s", result.changePasswordTarget)
 	}
 
+	if result.settingsTarget != "/redfish/v1/Systems/437XR1138R2/BIOS/SD" {
+		t.Errorf("Invalid settings object: %s", result.settingsTarget)
+	}
+
 	if result.Attributes.String("AdminPhone") != "" {
 		t.Errorf("Invalid 'AdminPhone' attribute: %s", result.Attributes["AdminPhone"])
 	}
@@ -97,3 +109,92 @@ func TestBios(t *testing.T) {
 		t.Errorf("Expected False boolean value for 'BoolTest3': %v", result.Attributes["BoolTest1"])
 	}
 }
+
+var biosApplyBody = `{
+		"@odata.id": "/redfish/v1/Systems/437XR1138R2/BIOS",
+		"Id": "BIOS",
+		"Attributes": {
+			"BootMode": "Uefi",
+			"NicBoot1": "NetworkBoot",
+			"PowerProfile": "MaxPerf",
+			"ProcCoreDisable": 3
+		},
+		"@Redfish.Settings": {
+			"SettingsObject": {
+				"@odata.id": "/redfish/v1/Systems/437XR1138R2/BIOS/SD"
+			},
+			"SupportedApplyTimes": ["OnReset", "AtMaintenanceWindowStart"]
+		}
+	}`
+
+// TestBiosApplyAttributes tests setting only the BIOS attributes that are
+// neither set nor pending.
+func TestBiosApplyAttributes(t *testing.T) {
+	var result Bios
+	err := json.NewDecoder(strings.NewReader(biosApplyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/437XR1138R2/BIOS/SD": `{
+			"@odata.id": "/redfish/v1/Systems/437XR1138R2/BIOS/SD",
+			"Attributes": {
+				"NicBoot1": "Disabled",
+				"PowerProfile": "Efficiency"
+			}
+		}`,
+	}}
+	result.SetClient(testClient)
+
+	desired := BiosAttributes{
+		"BootMode":        "Uefi",
+		"NicBoot1":        "NetworkBoot",
+		"PowerProfile":    "Efficiency",
+		"ProcCoreDisable": 2,
+	}
+
+	_, err = result.ApplyAttributes(desired, common.ImmediateApplyTime)
+	if err == nil {
+		t.Error("Applying attributes with an unsupported apply time should fail")
+	}
+
+	change, err := result.ApplyAttributes(desired, common.OnResetApplyTime)
+	if err != nil {
+		t.Fatalf("Error applying attributes: %s", err)
+	}
+
+	if len(change.Changed) != 2 || change.Changed["NicBoot1"] != "NetworkBoot" || change.Changed["ProcCoreDisable"] != 2 {
+		t.Errorf("Unexpected changed attributes: %v", change.Changed)
+	}
+
+	if strings.Join(change.RebootRequired, ",") != "PowerProfile,ProcCoreDisable" {
+		t.Errorf("Unexpected attributes requiring a reboot: %v", change.RebootRequired)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected the pending settings to be read and patched: %v", calls)
+	}
+
+	if calls[1].URL != "/redfish/v1/Systems/437XR1138R2/BIOS/SD" ||
+		calls[1].Payload != "map[@Redfish.SettingsApplyTime:map[ApplyTime:OnReset] Attributes:map[NicBoot1:NetworkBoot ProcCoreDisable:2]]" {
+		t.Errorf("Unexpected settings update: %v", calls[1])
+	}
+
+	testClient.Reset()
+	change, err = result.ApplyAttributes(BiosAttributes{"BootMode": "Uefi", "ProcCoreDisable": 3.0}, "")
+	if err != nil {
+		t.Fatalf("Error applying attributes: %s", err)
+	}
+
+	if len(change.Changed) != 0 || len(change.RebootRequired) != 0 {
+		t.Errorf("Attributes already set should not be changed: %v", change)
+	}
+
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("Attributes already set should not be patched: %v", testClient.CapturedCalls())
+	}
+}
@@ -0,0 +1,164 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// CertificateType is the format of a certificate.
+type CertificateType string
+
+const (
+	// PEMCertificateType shall indicate a Privacy Enhanced Mail (PEM)-encoded
+	// single certificate.
+	PEMCertificateType CertificateType = "PEM"
+	// PEMchainCertificateType shall indicate a Privacy Enhanced Mail
+	// (PEM)-encoded certificate chain.
+	PEMchainCertificateType CertificateType = "PEMchain"
+	// PKCS7CertificateType shall indicate a Privacy Enhanced Mail
+	// (PEM)-encoded PKCS7 certificate.
+	PKCS7CertificateType CertificateType = "PKCS7"
+)
+
+// CertificateIdentifier shall contain the properties that identifies the
+// issuer or subject of a certificate.
+type CertificateIdentifier struct {
+	// City shall contain the city or locality of the organization of the
+	// entity.
+	City string
+	// CommonName shall contain the fully qualified domain name of the entity.
+	CommonName string
+	// Country shall contain the two-letter ISO code for the country of the
+	// organization of the entity.
+	Country string
+	// Email shall contain the email address of the contact within the
+	// organization of the entity.
+	Email string
+	// Organization shall contain the name of the organization of the entity.
+	Organization string
+	// OrganizationalUnit shall contain the name of the unit or division of
+	// the organization of the entity.
+	OrganizationalUnit string
+	// State shall contain the state, province, or region of the organization
+	// of the entity.
+	State string
+}
+
+// Certificate shall represent a certificate for a Redfish implementation.
+type Certificate struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CertificateString shall contain the certificate, and the format shall
+	// follow the requirements specified by the CertificateType property.
+	CertificateString string
+	// CertificateType shall contain the format type for the certificate.
+	CertificateType CertificateType
+	// Description provides a description of this resource.
+	Description string
+	// Fingerprint shall be a string containing the ASCII representation of
+	// the fingerprint of the certificate.
+	Fingerprint string
+	// FingerprintHashAlgorithm shall be a string containing the hash
+	// algorithm used for generating the Fingerprint property.
+	FingerprintHashAlgorithm string
+	// Issuer shall contain an object containing information about the issuer
+	// of the certificate.
+	Issuer CertificateIdentifier
+	// SerialNumber shall be the serial number of the certificate.
+	SerialNumber string
+	// SignatureAlgorithm shall be a string containing the algorithm used for
+	// generating the signature of the certificate.
+	SignatureAlgorithm string
+	// Subject shall contain an object containing information about the
+	// subject of the certificate.
+	Subject CertificateIdentifier
+	// ValidNotAfter shall contain the date when the certificate validity
+	// period ends.
+	ValidNotAfter common.DateTime
+	// ValidNotBefore shall contain the date when the certificate validity
+	// period begins.
+	ValidNotBefore common.DateTime
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (certificate *Certificate) GetRawData() []byte {
+	return certificate.rawData
+}
+
+// UnmarshalJSON unmarshals a Certificate object from the raw JSON.
+func (certificate *Certificate) UnmarshalJSON(b []byte) error {
+	type temp Certificate
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*certificate = Certificate(t.temp)
+	certificate.rawData = b
+	certificate.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the certificate to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (certificate Certificate) MarshalJSON() ([]byte, error) {
+	type temp Certificate
+	return common.MarshalWithRawData(certificate.rawData, temp(certificate))
+}
+
+// GetCertificate will get a Certificate instance from the service.
+func GetCertificate(c common.Client, uri string) (*Certificate, error) {
+	var certificate Certificate
+	err := common.FetchResource(c, uri, &certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// ListReferencedCertificates gets the collection of Certificate from a
+// provided reference.
+func ListReferencedCertificates(c common.Client, link string) ([]*Certificate, error) {
+	var result []*Certificate
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, certificateLink := range links.ItemLinks {
+		certificate := new(Certificate)
+		expanded, err := links.DecodeMember(c, certificateLink, certificate)
+		if err == nil && !expanded {
+			certificate, err = GetCertificate(c, certificateLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, certificate)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,190 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateRotationOptions controls CertificateService.RotateCertificate.
+type CertificateRotationOptions struct {
+	// Certificate is the URI of the certificate to replace, such as
+	// "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1".
+	Certificate string
+	// CSR are the parameters of the certificate signing request. The
+	// CertificateCollection defaults to the collection of Certificate.
+	CSR GenerateCSRParameters
+	// Sign has the certificate signing request, PEM-encoded, signed by the
+	// certificate authority. It returns the PEM-encoded certificate, which
+	// may be followed by its chain.
+	Sign func(csr string) (string, error)
+	// Address is the host and port the service serves HTTPS on, such as
+	// "bmc1.example.com:443". If it is set, the new certificate is checked
+	// to be the one served on it.
+	Address string
+	// VerifyTimeout is how long the service may take to serve the new
+	// certificate, as while its web server restarts. It defaults to five
+	// minutes.
+	VerifyTimeout time.Duration
+	// VerifyInterval is the time between the checks of the served
+	// certificate. It defaults to 5 seconds.
+	VerifyInterval time.Duration
+}
+
+// RotateCertificate replaces a certificate, such as the HTTPS certificate of
+// a BMC, with a newly signed one: it generates a certificate signing request,
+// has it signed by the Sign callback, replaces the certificate with the
+// signed one and checks that the service kept it and, if Address is set,
+// serves it.
+//
+// It returns the new certificate. It fails if the service still reports or
+// serves the previous certificate once the replacement is done, which is how
+// services that reject or roll back a certificate behave.
+func (certificateservice *CertificateService) RotateCertificate(ctx context.Context, options CertificateRotationOptions) (*Certificate, error) {
+	if options.Certificate == "" {
+		return nil, fmt.Errorf("the certificate to replace is required")
+	}
+	if options.Sign == nil {
+		return nil, fmt.Errorf("a Sign callback is required to sign the certificate")
+	}
+	if options.VerifyTimeout <= 0 {
+		options.VerifyTimeout = 5 * time.Minute
+	}
+	if options.VerifyInterval <= 0 {
+		options.VerifyInterval = 5 * time.Second
+	}
+
+	previous, err := GetCertificate(certificateservice.Client, options.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	previousDER := certificateDER(previous.CertificateString)
+
+	parameters := options.CSR
+	if parameters.CertificateCollection == "" && strings.LastIndex(options.Certificate, "/") > 0 {
+		parameters.CertificateCollection = options.Certificate[:strings.LastIndex(options.Certificate, "/")]
+	}
+	csr, err := certificateservice.GenerateCSR(&parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := options.Sign(csr.CSRString)
+	if err != nil {
+		return nil, err
+	}
+	signedDER := certificateDER(signed)
+	if signedDER == nil {
+		return nil, fmt.Errorf("the signed certificate is not a PEM-encoded certificate")
+	}
+
+	certificateType := PEMCertificateType
+	if strings.Count(signed, "-----BEGIN CERTIFICATE-----") > 1 {
+		certificateType = PEMchainCertificateType
+	}
+	err = certificateservice.ReplaceCertificate(options.Certificate, signed, certificateType)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Address != "" {
+		err = verifyServedCertificate(ctx, options, signedDER, previousDER)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := GetCertificate(certificateservice.Client, options.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(certificateDER(current.CertificateString), signedDER) {
+		return current, fmt.Errorf("certificate %s was rolled back by the service", options.Certificate)
+	}
+
+	return current, nil
+}
+
+// verifyServedCertificate waits for the new certificate to be served on the
+// address of the service.
+func verifyServedCertificate(ctx context.Context, options CertificateRotationOptions, signedDER, previousDER []byte) error {
+	deadline := time.Now().Add(options.VerifyTimeout)
+	var served []byte
+	var err error
+	for {
+		served, err = servedCertificate(ctx, options.Address, options.VerifyTimeout)
+		if err == nil && bytes.Equal(served, signedDER) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(options.VerifyInterval):
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("the new certificate is not served on %s: %s", options.Address, err)
+	}
+	if bytes.Equal(served, previousDER) {
+		return fmt.Errorf("%s still serves the previous certificate, it was rolled back or not applied", options.Address)
+	}
+	return fmt.Errorf("%s serves an unexpected certificate", options.Address)
+}
+
+// servedCertificate gets the DER encoding of the certificate served on an
+// address.
+func servedCertificate(ctx context.Context, address string, timeout time.Duration) ([]byte, error) {
+	// The certificate is checked, not trusted, so it is not verified
+	dialer := &tls.Dialer{
+		Config: &tls.Config{InsecureSkipVerify: true},
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certificates := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificate is served on %s", address)
+	}
+
+	return certificates[0].Raw, nil
+}
+
+// certificateDER gets the DER encoding of the first certificate of a
+// PEM-encoded string, or nil if it has none.
+func certificateDER(certificateString string) []byte {
+	rest := []byte(certificateString)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil
+		}
+
+		if block.Type == "CERTIFICATE" {
+			if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+				return nil
+			}
+			return block.Bytes
+		}
+	}
+}
@@ -0,0 +1,264 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// GenerateCSRParameters are the parameters of the
+// CertificateService.GenerateCSR action.
+type GenerateCSRParameters struct {
+	// AlternativeNames shall contain an array of additional host names of
+	// the component to secure.
+	AlternativeNames []string `json:",omitempty"`
+	// CertificateCollection shall contain the URI of the certificate
+	// collection where the certificate is installed after the certificate
+	// authority (CA) signs the certificate.
+	CertificateCollection string `json:"-"`
+	// City shall contain the city or locality of the organization making the
+	// request.
+	City string
+	// CommonName shall contain the fully qualified domain name of the
+	// component to secure.
+	CommonName string
+	// Country shall contain the two-letter ISO code for the country of the
+	// organization making the request.
+	Country string
+	// Email shall contain the email address of the contact within the
+	// organization making the request.
+	Email string `json:",omitempty"`
+	// KeyBitLength shall contain the length of the key, in bits, if needed
+	// based on the KeyPairAlgorithm parameter value.
+	KeyBitLength int `json:",omitempty"`
+	// KeyCurveID shall contain the curve ID to use with the key, if needed
+	// based on the KeyPairAlgorithm parameter value.
+	KeyCurveID string `json:"KeyCurveId,omitempty"`
+	// KeyPairAlgorithm shall contain the type of key-pair for use with
+	// signing algorithms, such as "TPM_ALG_RSA" or "TPM_ALG_ECDSA".
+	KeyPairAlgorithm string `json:",omitempty"`
+	// Organization shall contain the name of the organization making the
+	// request.
+	Organization string
+	// OrganizationalUnit shall contain the name of the unit or division of
+	// the organization making the request.
+	OrganizationalUnit string
+	// State shall contain the state, province, or region of the
+	// organization making the request.
+	State string
+}
+
+// GenerateCSRResponse shall contain the properties found in the response
+// body for the GenerateCSR action.
+type GenerateCSRResponse struct {
+	// CSRString shall contain the Privacy Enhanced Mail (PEM)-encoded string,
+	// which contains RFC2986-specified structures, of the certificate signing
+	// request.
+	CSRString string
+	// CertificateCollection shall contain the URI of the certificate
+	// collection where the certificate is installed after the certificate
+	// authority (CA) has signed the certificate.
+	CertificateCollection string
+}
+
+// UnmarshalJSON unmarshals a GenerateCSRResponse object from the raw JSON.
+func (response *GenerateCSRResponse) UnmarshalJSON(b []byte) error {
+	var t struct {
+		CSRString             string
+		CertificateCollection common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	response.CSRString = t.CSRString
+	response.CertificateCollection = string(t.CertificateCollection)
+
+	return nil
+}
+
+// CertificateService shall describe the certificate service properties for
+// a Redfish implementation.
+type CertificateService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// certificateLocations shall contain a link to a resource of type
+	// CertificateLocations.
+	certificateLocations string
+	// generateCSRTarget is the URL to send GenerateCSR actions to.
+	generateCSRTarget string
+	// replaceCertificateTarget is the URL to send ReplaceCertificate
+	// actions to.
+	replaceCertificateTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (certificateservice *CertificateService) GetRawData() []byte {
+	return certificateservice.rawData
+}
+
+// UnmarshalJSON unmarshals a CertificateService object from the raw JSON.
+func (certificateservice *CertificateService) UnmarshalJSON(b []byte) error {
+	type temp CertificateService
+	type Actions struct {
+		GenerateCSR struct {
+			Target string
+		} `json:"#CertificateService.GenerateCSR"`
+		ReplaceCertificate struct {
+			Target string
+		} `json:"#CertificateService.ReplaceCertificate"`
+	}
+	var t struct {
+		temp
+		Actions              Actions
+		CertificateLocations common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*certificateservice = CertificateService(t.temp)
+
+	// Extract the links to other entities for later
+	certificateservice.certificateLocations = string(t.CertificateLocations)
+	certificateservice.generateCSRTarget = t.Actions.GenerateCSR.Target
+	certificateservice.replaceCertificateTarget = t.Actions.ReplaceCertificate.Target
+
+	certificateservice.rawData = b
+	certificateservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the certificate service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (certificateservice CertificateService) MarshalJSON() ([]byte, error) {
+	type temp CertificateService
+	return common.MarshalWithRawData(certificateservice.rawData, temp(certificateservice))
+}
+
+// GetCertificateService will get a CertificateService instance from the
+// service.
+func GetCertificateService(c common.Client, uri string) (*CertificateService, error) {
+	var certificateservice CertificateService
+	err := common.FetchResource(c, uri, &certificateservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateservice, nil
+}
+
+// CertificateLocations gets the certificates installed on the service, as
+// listed by its CertificateLocations resource.
+func (certificateservice *CertificateService) CertificateLocations() ([]*Certificate, error) {
+	var result []*Certificate
+	if certificateservice.certificateLocations == "" {
+		return result, nil
+	}
+
+	resp, err := certificateservice.Client.Get(certificateservice.certificateLocations)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	var t struct {
+		Links struct {
+			Certificates common.Links
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&t)
+	if err != nil {
+		return result, err
+	}
+
+	for _, uri := range t.Links.Certificates.ToStrings() {
+		certificate, err := GetCertificate(certificateservice.Client, uri)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, certificate)
+	}
+
+	return result, nil
+}
+
+// GenerateCSR makes a certificate signing request, for the certificate
+// authority to sign. The certificate is then installed by posting it to the
+// certificate collection, or with ReplaceCertificate.
+func (certificateservice *CertificateService) GenerateCSR(parameters *GenerateCSRParameters) (*GenerateCSRResponse, error) {
+	if certificateservice.generateCSRTarget == "" {
+		return nil, fmt.Errorf("GenerateCSR is not supported by this certificate service")
+	}
+
+	type collection struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		*GenerateCSRParameters
+		CertificateCollection collection
+	}
+	t := temp{
+		GenerateCSRParameters: parameters,
+		CertificateCollection: collection{ODataID: parameters.CertificateCollection},
+	}
+
+	resp, err := certificateservice.Client.Post(certificateservice.generateCSRTarget, t)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result GenerateCSRResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ReplaceCertificate replaces the certificate at certificateURI, such as the
+// HTTPS certificate of a manager, with a new one.
+func (certificateservice *CertificateService) ReplaceCertificate(certificateURI, certificateString string, certificateType CertificateType) error {
+	if certificateservice.replaceCertificateTarget == "" {
+		return fmt.Errorf("ReplaceCertificate is not supported by this certificate service")
+	}
+
+	type uri struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		CertificateString string
+		CertificateType   CertificateType
+		CertificateURI    uri `json:"CertificateUri"`
+	}
+	t := temp{
+		CertificateString: certificateString,
+		CertificateType:   certificateType,
+		CertificateURI:    uri{ODataID: certificateURI},
+	}
+
+	_, err := certificateservice.Client.Post(certificateservice.replaceCertificateTarget, t)
+	return err
+}
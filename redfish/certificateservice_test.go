@@ -0,0 +1,138 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var certificateServiceBody = `{
+		"@odata.type": "#CertificateService.v1_0_4.CertificateService",
+		"@odata.id": "/redfish/v1/CertificateService",
+		"Id": "CertificateService",
+		"Name": "Certificate Service",
+		"Description": "Actions available to manage certificates",
+		"Actions": {
+			"#CertificateService.GenerateCSR": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR"
+			},
+			"#CertificateService.ReplaceCertificate": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+			}
+		},
+		"CertificateLocations": {
+			"@odata.id": "/redfish/v1/CertificateService/CertificateLocations"
+		}
+	}`
+
+var certificateBody = `{
+		"@odata.type": "#Certificate.v1_2_4.Certificate",
+		"@odata.id": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1",
+		"Id": "1",
+		"Name": "HTTPS Certificate",
+		"CertificateString": "-----BEGIN CERTIFICATE-----\nMIIFsTCC [**truncated example**] GXG5zljlu\n-----END CERTIFICATE-----",
+		"CertificateType": "PEM",
+		"Issuer": {
+			"Country": "US",
+			"State": "Oregon",
+			"City": "Portland",
+			"Organization": "Contoso",
+			"OrganizationalUnit": "ABC",
+			"CommonName": "manager.contoso.org"
+		},
+		"Subject": {
+			"Country": "US",
+			"CommonName": "manager.contoso.org"
+		},
+		"ValidNotBefore": "2018-09-07T13:22:05Z",
+		"ValidNotAfter": "2019-09-07T13:22:05Z",
+		"SerialNumber": "5d:7a:d8:df:f6:fc:c1:b3:ba:52:4d:1e:f7:3d:c0:3c",
+		"Fingerprint": "A6:E9:D2:5C:C5:4E:12:43:33:A5:A4:C5:CE:74:CC:A8:74:B3:2E:E2",
+		"FingerprintHashAlgorithm": "TPM_ALG_SHA1"
+	}`
+
+// TestCertificateService tests the parsing of CertificateService objects.
+func TestCertificateService(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CertificateService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.certificateLocations != "/redfish/v1/CertificateService/CertificateLocations" {
+		t.Errorf("Invalid CertificateLocations link: %s", result.certificateLocations)
+	}
+
+	if result.generateCSRTarget != "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR" {
+		t.Errorf("Invalid GenerateCSR target: %s", result.generateCSRTarget)
+	}
+
+	if result.replaceCertificateTarget != "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate" {
+		t.Errorf("Invalid ReplaceCertificate target: %s", result.replaceCertificateTarget)
+	}
+}
+
+// TestCertificateServiceReplaceCertificate tests the ReplaceCertificate call.
+func TestCertificateServiceReplaceCertificate(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ReplaceCertificate("/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1", "CERT", PEMCertificateType)
+	if err != nil {
+		t.Errorf("Error making ReplaceCertificate call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{CERT PEM {/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1}}" {
+		t.Errorf("Unexpected ReplaceCertificate payload: %s", calls[0].Payload)
+	}
+}
+
+// TestCertificate tests the parsing of Certificate objects.
+func TestCertificate(t *testing.T) {
+	var result Certificate
+	err := json.NewDecoder(strings.NewReader(certificateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.CertificateType != PEMCertificateType {
+		t.Errorf("Invalid CertificateType: %s", result.CertificateType)
+	}
+
+	if result.Issuer.Organization != "Contoso" {
+		t.Errorf("Invalid issuer organization: %s", result.Issuer.Organization)
+	}
+
+	if result.Subject.CommonName != "manager.contoso.org" {
+		t.Errorf("Invalid subject common name: %s", result.Subject.CommonName)
+	}
+
+	if result.ValidNotAfter.Year() != 2019 {
+		t.Errorf("Invalid ValidNotAfter: %s", result.ValidNotAfter)
+	}
+
+	if certificateDER(result.CertificateString) != nil {
+		t.Error("A truncated certificate should not be decoded")
+	}
+}
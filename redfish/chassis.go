@@ -7,7 +7,6 @@ package redfish
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -74,6 +73,67 @@ const (
 	ZoneChassisType ChassisType = "Zone"
 )
 
+// EnvironmentalClass is the ASHRAE environmental class of a chassis.
+type EnvironmentalClass string
+
+const (
+	// A1EnvironmentalClass ASHRAE Environmental Class 'A1'.
+	A1EnvironmentalClass EnvironmentalClass = "A1"
+	// A2EnvironmentalClass ASHRAE Environmental Class 'A2'.
+	A2EnvironmentalClass EnvironmentalClass = "A2"
+	// A3EnvironmentalClass ASHRAE Environmental Class 'A3'.
+	A3EnvironmentalClass EnvironmentalClass = "A3"
+	// A4EnvironmentalClass ASHRAE Environmental Class 'A4'.
+	A4EnvironmentalClass EnvironmentalClass = "A4"
+)
+
+// IntrusionSensor is the state of a physical security sensor.
+type IntrusionSensor string
+
+const (
+	// NormalIntrusionSensor No abnormal physical security condition is
+	// detected at this time.
+	NormalIntrusionSensor IntrusionSensor = "Normal"
+	// HardwareIntrusionIntrusionSensor A door, lock, or other mechanism
+	// protecting the internal system hardware from being accessed is
+	// detected to be in an insecure state.
+	HardwareIntrusionIntrusionSensor IntrusionSensor = "HardwareIntrusion"
+	// TamperingDetectedIntrusionSensor Physical tampering of the monitored
+	// entity is detected.
+	TamperingDetectedIntrusionSensor IntrusionSensor = "TamperingDetected"
+)
+
+// IntrusionSensorReArm is the method used to re-arm a physical security
+// sensor.
+type IntrusionSensorReArm string
+
+const (
+	// ManualIntrusionSensorReArm shall indicate a user is required to set
+	// the IntrusionSensor property to Normal to restore the sensor to its
+	// normal state.
+	ManualIntrusionSensorReArm IntrusionSensorReArm = "Manual"
+	// AutomaticIntrusionSensorReArm shall indicate the service sets the
+	// IntrusionSensor property to Normal when no security condition is
+	// detected.
+	AutomaticIntrusionSensorReArm IntrusionSensorReArm = "Automatic"
+)
+
+// PhysicalSecurity shall describe the physical security state of the
+// chassis.
+type PhysicalSecurity struct {
+	// IntrusionSensor shall represent the state of this physical security
+	// sensor. Hardware intrusion indicates the internal hardware is detected
+	// as being accessed in an insecure state. Tampering detected indicates
+	// the physical tampering of the monitored entity is detected.
+	IntrusionSensor IntrusionSensor
+	// IntrusionSensorNumber shall contain a numerical identifier for this
+	// physical security sensor that is unique within this resource.
+	IntrusionSensorNumber int
+	// IntrusionSensorReArm shall represent the method that restores this
+	// physical security sensor to the normal state.
+	IntrusionSensorReArm IntrusionSensorReArm
+}
+
 // Chassis represents the physical components of a system. This
 // resource represents the sheet-metal confined spaces and logical zones such
 // as racks, enclosures, chassis and all other containers. Subsystems (like sensors)
@@ -82,21 +142,94 @@ const (
 // indirectly through this resource.
 type Chassis struct {
 	common.Entity
-	ChassisType     ChassisType   `json:"ChassisType"`
-	Manufacturer    string        `json:"Manufacturer"`
-	Model           string        `json:"Model"`
-	SKU             string        `json:"SKU"`
-	SerialNumber    string        `json:"SerialNumber"`
-	Version         string        `json:"Version"`
-	PartNumber      string        `json:"PartNumber"`
-	AssetTag        string        `json:"AssetTag"`
-	Status          common.Status `json:"Status"`
-	thermal         string
-	power           string
-	networkAdapters string
-	computerSystems []string
-	resourceBlocks  []string
-	managedBy       []string
+	ChassisType  ChassisType   `json:"ChassisType"`
+	Manufacturer string        `json:"Manufacturer"`
+	Model        string        `json:"Model"`
+	SKU          string        `json:"SKU"`
+	SerialNumber string        `json:"SerialNumber"`
+	Version      string        `json:"Version"`
+	PartNumber   string        `json:"PartNumber"`
+	AssetTag     string        `json:"AssetTag"`
+	Status       common.Status `json:"Status"`
+	// DepthMm shall represent the depth (length) of the chassis, in
+	// millimeters, as specified by the manufacturer.
+	DepthMm float32 `json:"DepthMm"`
+	// Description provides a description of this resource.
+	Description string `json:"Description"`
+	// Oem contains the OEM extensions of the chassis, decoded by the
+	// decoders registered with common.RegisterOEMDecoder.
+	Oem common.OEM `json:"Oem"`
+	// EnvironmentalClass shall contain the ASHRAE Environmental Class for
+	// this chassis, as defined by ASHRAE Thermal Guidelines for Data
+	// Processing Environments.
+	EnvironmentalClass EnvironmentalClass `json:"EnvironmentalClass"`
+	// HeightMm shall represent the height of the chassis, in millimeters, as
+	// specified by the manufacturer.
+	HeightMm float32 `json:"HeightMm"`
+	// IndicatorLED shall contain the indicator light state for the indicator
+	// light associated with this chassis.
+	IndicatorLED common.IndicatorLED `json:"IndicatorLED"`
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool `json:"LocationIndicatorActive"`
+	// MaxPowerWatts shall contain the upper bound of the total power
+	// consumed by the chassis.
+	MaxPowerWatts float32 `json:"MaxPowerWatts"`
+	// MinPowerWatts shall contain the lower bound of the total power
+	// consumed by the chassis.
+	MinPowerWatts float32 `json:"MinPowerWatts"`
+	// PowerState shall contain the power state of the chassis.
+	PowerState PowerState `json:"PowerState"`
+	// UUID shall contain the universal unique identifier number for the
+	// chassis.
+	UUID string `json:"UUID"`
+	// WeightKg shall represent the published mass, commonly referred to as
+	// weight, of the chassis, in kilograms.
+	WeightKg float32 `json:"WeightKg"`
+	// WidthMm shall represent the width of the chassis, in millimeters, as
+	// specified by the manufacturer.
+	WidthMm float32 `json:"WidthMm"`
+	// Location shall contain location information of the associated chassis.
+	Location common.Location `json:"Location"`
+	// PhysicalSecurity shall contain the physical security state of the
+	// chassis.
+	PhysicalSecurity PhysicalSecurity `json:"PhysicalSecurity"`
+	assembly         string
+	thermal          string
+	power            string
+	powerSubsystem   string
+	networkAdapters  string
+	computerSystems  []string
+	resourceBlocks   []string
+	managedBy        []string
+	// drives are the drives contained within this chassis.
+	drives []string
+	// DrivesCount is the number of drives contained within this chassis.
+	DrivesCount int
+	// drivesCollection is the link to the collection of drives within this
+	// chassis, used when the drives are not listed under Links.
+	drivesCollection string
+	// storage are the storage subsystems connected to or inside this chassis.
+	storage []string
+	// StorageCount is the number of storage subsystems.
+	StorageCount int
+	// pcieDevices are the PCIe devices located in this chassis.
+	pcieDevices []string
+	// PCIeDevicesCount is the number of PCIe devices.
+	PCIeDevicesCount int
+	// trustedComponents is the link to the collection of trusted components
+	// in this chassis.
+	trustedComponents string
+	// thermalSubsystem is the link to the thermal subsystem of this chassis.
+	thermalSubsystem string
+	// environmentMetrics is the link to the environment metrics of this
+	// chassis.
+	environmentMetrics string
+	// sensors is the link to the collection of sensors of this chassis.
+	sensors string
+	// mediaControllers is the link to the collection of media controllers,
+	// such as the memory controllers of a memory appliance, of this chassis.
+	mediaControllers string
 	// resetTarget is the internal URL to send reset actions to.
 	resetTarget string
 	// SupportedResetTypes, if provided, is the reset types this chassis supports.
@@ -114,9 +247,15 @@ func (chassis *Chassis) GetRawData() []byte {
 func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	type temp Chassis
 	type linkReference struct {
-		ComputerSystems common.Links
-		ResourceBlocks  common.Links
-		ManagedBy       common.Links
+		ComputerSystems  common.Links
+		ResourceBlocks   common.Links
+		ManagedBy        common.Links
+		Drives           common.Links
+		DrivesCount      int `json:"Drives@odata.count"`
+		Storage          common.Links
+		StorageCount     int `json:"Storage@odata.count"`
+		PCIeDevices      common.Links
+		PCIeDevicesCount int `json:"PCIeDevices@odata.count"`
 	}
 	type Actions struct {
 		ChassisReset struct {
@@ -127,11 +266,26 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 
 	var t struct {
 		temp
+		Assembly        common.Link
 		Thermal         common.Link
 		Power           common.Link
+		PowerSubsystem  common.Link
 		NetworkAdapters common.Link
+		Drives          common.Link
 		Links           linkReference
 		Actions         Actions
+		// TrustedComponents is the collection of trusted components, such
+		// as roots of trust, in this chassis.
+		TrustedComponents common.Link
+		// ThermalSubsystem is the thermal subsystem of this chassis.
+		ThermalSubsystem common.Link
+		// EnvironmentMetrics are the environment metrics of this chassis.
+		EnvironmentMetrics common.Link
+		// Sensors is the collection of sensors of this chassis.
+		Sensors common.Link
+		// MediaControllers is the collection of media controllers of this
+		// chassis.
+		MediaControllers common.Link
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -142,21 +296,43 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	*chassis = Chassis(t.temp)
 
 	// Extract the links to other entities for later
+	chassis.assembly = string(t.Assembly)
 	chassis.thermal = string(t.Thermal)
 	chassis.power = string(t.Power)
+	chassis.powerSubsystem = string(t.PowerSubsystem)
 	chassis.networkAdapters = string(t.NetworkAdapters)
 	chassis.computerSystems = t.Links.ComputerSystems.ToStrings()
 	chassis.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
 	chassis.managedBy = t.Links.ManagedBy.ToStrings()
+	chassis.drives = t.Links.Drives.ToStrings()
+	chassis.DrivesCount = t.Links.DrivesCount
+	chassis.drivesCollection = string(t.Drives)
+	chassis.storage = t.Links.Storage.ToStrings()
+	chassis.StorageCount = t.Links.StorageCount
+	chassis.pcieDevices = t.Links.PCIeDevices.ToStrings()
+	chassis.PCIeDevicesCount = t.Links.PCIeDevicesCount
+	chassis.trustedComponents = string(t.TrustedComponents)
+	chassis.thermalSubsystem = string(t.ThermalSubsystem)
+	chassis.environmentMetrics = string(t.EnvironmentMetrics)
+	chassis.sensors = string(t.Sensors)
+	chassis.mediaControllers = string(t.MediaControllers)
 	chassis.resetTarget = t.Actions.ChassisReset.Target
 	chassis.SupportedResetTypes = t.Actions.ChassisReset.AllowedResetTypes
 
 	// This is a read/write object, so we need to save the raw object data for later
 	chassis.rawData = b
+	chassis.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the chassis to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (chassis Chassis) MarshalJSON() ([]byte, error) {
+	type temp Chassis
+	return common.MarshalWithRawData(chassis.rawData, temp(chassis))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (chassis *Chassis) Update() error {
 
@@ -167,36 +343,142 @@ func (chassis *Chassis) Update() error {
 
 	readWriteFields := []string{
 		"AssetTag",
+		"EnvironmentalClass",
 		"IndicatorLED",
+		"LocationIndicatorActive",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(chassis).Elem()
 
-	return chassis.Entity.Update(originalElement, currentElement, readWriteFields)
+	// Location is a nested object, which the generic update skips, so its
+	// writable properties are added to the payload.
+	nested := make(map[string]interface{})
+	if location := locationChanges(original.Location, chassis.Location); len(location) > 0 {
+		nested["Location"] = location
+	}
+
+	err := chassis.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
+	if err != nil {
+		return err
+	}
+
+	// PartLocation is read only, keep what the service reported.
+	chassis.Location.PartLocation = original.Location.PartLocation
+	return nil
 }
 
-// GetChassis will get a Chassis instance from the Redfish service.
-func GetChassis(c common.Client, uri string) (*Chassis, error) {
-	resp, err := c.Get(uri)
+// locationChanges gets the writable location properties that differ between
+// two locations, in the shape of a Location PATCH payload.
+func locationChanges(original, current common.Location) map[string]interface{} {
+	payload := changedFields(
+		reflect.ValueOf(original), reflect.ValueOf(current),
+		"AltitudeMeters", "Info", "InfoFormat", "Latitude", "Longitude")
+
+	if placement := changedFields(
+		reflect.ValueOf(original.Placement),
+		reflect.ValueOf(current.Placement)); len(placement) > 0 {
+		payload["Placement"] = placement
+	}
+
+	if address := changedFields(
+		reflect.ValueOf(original.PostalAddress),
+		reflect.ValueOf(current.PostalAddress)); len(address) > 0 {
+		payload["PostalAddress"] = address
+	}
+
+	if !reflect.DeepEqual(original.Contacts, current.Contacts) {
+		payload["Contacts"] = current.Contacts
+	}
+
+	return payload
+}
+
+// SetLocation updates the writable location properties of the chassis, such
+// as the rack placement, postal address and contacts. Only the properties
+// that differ from the current location are sent to the service.
+func (chassis *Chassis) SetLocation(location common.Location) error {
+	payload := locationChanges(chassis.Location, location)
+	if len(payload) == 0 {
+		return nil
+	}
+
+	type temp struct {
+		Location map[string]interface{}
+	}
+	t := temp{
+		Location: payload,
+	}
+
+	_, err := chassis.Client.Patch(chassis.ODataID, t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
-	var chassis Chassis
-	rawData, err := ioutil.ReadAll(resp.Body)
+	// PartLocation is read only, keep what the service reported.
+	location.PartLocation = chassis.Location.PartLocation
+	chassis.Location = location
+	return nil
+}
+
+// ReArmIntrusionSensor restores the physical security sensor of the chassis
+// to its normal state. This is required for sensors using the Manual re-arm
+// method.
+func (chassis *Chassis) ReArmIntrusionSensor() error {
+	type temp struct {
+		PhysicalSecurity struct {
+			IntrusionSensor IntrusionSensor
+		}
+	}
+	var t temp
+	t.PhysicalSecurity.IntrusionSensor = NormalIntrusionSensor
+
+	_, err := chassis.Client.Patch(chassis.ODataID, t)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	chassis.PhysicalSecurity.IntrusionSensor = NormalIntrusionSensor
+	return nil
+}
+
+// changedFields compares two values of the same flat struct type and returns
+// the fields that differ, keyed by field name. If names are provided only
+// those fields are compared.
+func changedFields(original reflect.Value, current reflect.Value, names ...string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for i := 0; i < original.NumField(); i++ {
+		field := original.Type().Field(i)
+		if len(names) > 0 {
+			found := false
+			for _, name := range names {
+				if name == field.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+
+		currentValue := current.Field(i).Interface()
+		if !reflect.DeepEqual(original.Field(i).Interface(), currentValue) {
+			result[field.Name] = currentValue
+		}
 	}
 
-	err = json.Unmarshal(rawData, &chassis)
+	return result
+}
+
+// GetChassis will get a Chassis instance from the Redfish service.
+func GetChassis(c common.Client, uri string) (*Chassis, error) {
+	var chassis Chassis
+	err := common.FetchResource(c, uri, &chassis)
 	if err != nil {
 		return nil, err
 	}
 
-	chassis.rawData = rawData
-	chassis.SetClient(c)
 	return &chassis, nil
 }
 
@@ -209,7 +491,11 @@ func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
 	}
 
 	for _, chassisLink := range links.ItemLinks {
-		chassis, err := GetChassis(c, chassisLink)
+		chassis := new(Chassis)
+		expanded, err := links.DecodeMember(c, chassisLink, chassis)
+		if err == nil && !expanded {
+			chassis, err = GetChassis(c, chassisLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -219,25 +505,31 @@ func ListReferencedChassis(c common.Client, link string) ([]*Chassis, error) {
 	return result, nil
 }
 
-// Thermal gets the thermal temperature and cooling information for the chassis
-func (chassis *Chassis) Thermal() (*Thermal, error) {
-	if chassis.thermal == "" {
+// Assembly gets the assembly for this chassis.
+func (chassis *Chassis) Assembly() (*Assembly, error) {
+	if chassis.assembly == "" {
 		return nil, nil
 	}
 
-	resp, err := chassis.Client.Get(chassis.thermal)
-	if err != nil {
-		return nil, err
+	return GetAssembly(chassis.Client, chassis.assembly)
+}
+
+// ThermalSubsystem gets the thermal subsystem for the chassis
+func (chassis *Chassis) ThermalSubsystem() (*ThermalSubsystem, error) {
+	if chassis.thermalSubsystem == "" {
+		return nil, nil
 	}
-	defer resp.Body.Close()
 
-	var thermal Thermal
-	err = json.NewDecoder(resp.Body).Decode(&thermal)
-	if err != nil {
-		return nil, err
+	return GetThermalSubsystem(chassis.Client, chassis.thermalSubsystem)
+}
+
+// Thermal gets the thermal temperature and cooling information for the chassis
+func (chassis *Chassis) Thermal() (*Thermal, error) {
+	if chassis.thermal == "" {
+		return nil, nil
 	}
 
-	return &thermal, nil
+	return GetThermal(chassis.Client, chassis.thermal)
 }
 
 // Power gets the power information for the chassis
@@ -246,19 +538,67 @@ func (chassis *Chassis) Power() (*Power, error) {
 		return nil, nil
 	}
 
-	resp, err := chassis.Client.Get(chassis.power)
+	return GetPower(chassis.Client, chassis.power)
+}
+
+// SetPowerLimit configures the power limit of the chassis through its Power
+// resource. Only the non-zero fields of limit are applied.
+func (chassis *Chassis) SetPowerLimit(limit PowerLimit) error {
+	power, err := chassis.Power()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if power == nil {
+		return fmt.Errorf("chassis %s does not have a Power resource", chassis.ID)
 	}
-	defer resp.Body.Close()
 
-	var power Power
-	err = json.NewDecoder(resp.Body).Decode(&power)
+	return power.SetPowerLimit(limit)
+}
+
+// DisablePowerLimit disables power capping of the chassis through its Power
+// resource.
+func (chassis *Chassis) DisablePowerLimit() error {
+	power, err := chassis.Power()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if power == nil {
+		return fmt.Errorf("chassis %s does not have a Power resource", chassis.ID)
+	}
+
+	return power.DisablePowerLimit()
+}
+
+// PowerSubsystem gets the power subsystem for the chassis
+func (chassis *Chassis) PowerSubsystem() (*PowerSubsystem, error) {
+	if chassis.powerSubsystem == "" {
+		return nil, nil
 	}
 
-	return &power, nil
+	return GetPowerSubsystem(chassis.Client, chassis.powerSubsystem)
+}
+
+// EnvironmentMetrics gets the environment metrics, such as the power
+// consumption, of the chassis.
+func (chassis *Chassis) EnvironmentMetrics() (*EnvironmentMetrics, error) {
+	if chassis.environmentMetrics == "" {
+		return nil, nil
+	}
+
+	return GetEnvironmentMetrics(chassis.Client, chassis.environmentMetrics)
+}
+
+// Sensors gets the sensors of the chassis.
+func (chassis *Chassis) Sensors() ([]*Sensor, error) {
+	return ListReferencedSensors(chassis.Client, chassis.sensors)
+}
+
+// MediaControllers gets the media controllers of the chassis, such as the
+// controllers of the memory media of a memory appliance.
+func (chassis *Chassis) MediaControllers() ([]*MediaController, error) {
+	return ListReferencedMediaControllers(chassis.Client, chassis.mediaControllers)
 }
 
 // ComputerSystems returns the collection of systems from this chassis
@@ -291,31 +631,73 @@ func (chassis *Chassis) ManagedBy() ([]*Manager, error) {
 	return result, nil
 }
 
+// Drives gets the drives contained within this chassis.
+func (chassis *Chassis) Drives() ([]*Drive, error) {
+	if len(chassis.drives) == 0 && chassis.drivesCollection != "" {
+		return ListReferencedDrives(chassis.Client, chassis.drivesCollection)
+	}
+
+	var result []*Drive
+	for _, uri := range chassis.drives {
+		drive, err := GetDrive(chassis.Client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, drive)
+	}
+
+	return result, nil
+}
+
+// Storage gets the storage subsystems connected to or inside this chassis.
+func (chassis *Chassis) Storage() ([]*Storage, error) {
+	var result []*Storage
+	for _, uri := range chassis.storage {
+		storage, err := GetStorage(chassis.Client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, storage)
+	}
+
+	return result, nil
+}
+
+// PCIeDevices gets the PCIe devices located in this chassis.
+func (chassis *Chassis) PCIeDevices() ([]*PCIeDevice, error) {
+	var result []*PCIeDevice
+	for _, uri := range chassis.pcieDevices {
+		pciedevice, err := GetPCIeDevice(chassis.Client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, pciedevice)
+	}
+
+	return result, nil
+}
+
 // NetworkAdapters gets the collection of network adapters of this chassis
 func (chassis *Chassis) NetworkAdapters() ([]*NetworkAdapter, error) {
 	return ListReferencedNetworkAdapter(chassis.Client, chassis.networkAdapters)
 }
 
+// TrustedComponents gets the trusted components, such as roots of trust,
+// contained in this chassis
+func (chassis *Chassis) TrustedComponents() ([]*TrustedComponent, error) {
+	return ListReferencedTrustedComponents(chassis.Client, chassis.trustedComponents)
+}
+
 // Reset shall reset the chassis. This action shall not reset Systems or other
 // contained resource, although side effects may occur which affect those resources.
 func (chassis *Chassis) Reset(resetType ResetType) error {
 	// Make sure the requested reset type is supported by the chassis
-	valid := false
-	if len(chassis.SupportedResetTypes) > 0 {
-		for _, allowed := range chassis.SupportedResetTypes {
-			if resetType == allowed {
-				valid = true
-				break
-			}
-		}
-	} else {
-		// No allowed values supplied, assume we are OK
-		valid = true
-	}
-
-	if !valid {
-		return fmt.Errorf("reset type '%s' is not supported by this chassis",
-			resetType)
+	err := ValidateResetType(resetType, chassis.SupportedResetTypes, "chassis")
+	if err != nil {
+		return err
 	}
 
 	type temp struct {
@@ -325,6 +707,6 @@ func (chassis *Chassis) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	_, err := chassis.Client.Post(chassis.resetTarget, t)
+	_, err = chassis.Client.Post(chassis.resetTarget, t)
 	return err
 }
@@ -26,16 +26,54 @@ var chassisBody = `{
 		"Version": "1.02",
 		"PartNumber": "224071-J23",
 		"AssetTag": "Chicago-45Z-2381",
+		"IndicatorLED": "Lit",
+		"PowerState": "On",
+		"EnvironmentalClass": "A3",
+		"HeightMm": 44.45,
+		"WidthMm": 431.8,
+		"DepthMm": 711,
+		"WeightKg": 15.31,
+		"UUID": "38947555-7742-3448-3784-823347823834",
+		"MaxPowerWatts": 800,
+		"MinPowerWatts": 150,
 		"Status": {
 			"State": "Enabled",
 			"Health": "OK"
 		},
+		"Location": {
+			"PartLocation": {
+				"ServiceLabel": "Chassis 1",
+				"LocationType": "Slot",
+				"LocationOrdinalValue": 1
+			},
+			"Placement": {
+				"Row": "North",
+				"Rack": "WEB43",
+				"RackOffset": 12,
+				"RackOffsetUnits": "EIA_310"
+			},
+			"PostalAddress": {
+				"Country": 1,
+				"City": "Chicago"
+			}
+		},
+		"PhysicalSecurity": {
+			"IntrusionSensorNumber": 123,
+			"IntrusionSensor": "HardwareIntrusion",
+			"IntrusionSensorReArm": "Manual"
+		},
+		"Assembly": {
+			"@odata.id": "/redfish/v1/Chassis/Chassis-1/Assembly"
+		},
 		"Thermal": {
 			"@odata.id": "/redfish/v1/Chassis/Chassis-1/Thermal"
 		},
 		"Power": {
 			"@odata.id": "/redfish/v1/Chassis/Chassis-1/Power"
 		},
+		"TrustedComponents": {
+			"@odata.id": "/redfish/v1/Chassis/Chassis-1/TrustedComponents"
+		},
 		"Links": {
 			"ComputerSystems": [
 				{
@@ -43,6 +81,25 @@ var chassisBody = `{
 				}
 			],
 			"ResourceBlocks": [],
+			"Drives": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/Chassis-1/Drives/Disk.Bay.0"
+				},
+				{
+					"@odata.id": "/redfish/v1/Chassis/Chassis-1/Drives/Disk.Bay.1"
+				}
+			],
+			"Drives@odata.count": 2,
+			"Storage": [
+				{
+					"@odata.id": "/redfish/v1/Systems/System-1/Storage/RAID.Integrated.1-1"
+				}
+			],
+			"PCIeDevices": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/Chassis-1/PCIeDevices/NIC"
+				}
+			],
 			"ManagedBy": [
 				{
 					"@odata.id": "/redfish/v1/Managers/BMC-1"
@@ -85,10 +142,62 @@ func TestChassis(t *testing.T) {
 		t.Errorf("Received invalid chassis type: %s", result.ChassisType)
 	}
 
+	if result.IndicatorLED != common.LitIndicatorLED {
+		t.Errorf("Received invalid indicator LED: %s", result.IndicatorLED)
+	}
+
+	if result.PowerState != OnPowerState {
+		t.Errorf("Received invalid power state: %s", result.PowerState)
+	}
+
+	if result.EnvironmentalClass != A3EnvironmentalClass {
+		t.Errorf("Received invalid environmental class: %s", result.EnvironmentalClass)
+	}
+
+	if result.HeightMm != 44.45 || result.WidthMm != 431.8 || result.DepthMm != 711 {
+		t.Errorf("Received invalid dimensions: %fx%fx%f", result.HeightMm, result.WidthMm, result.DepthMm)
+	}
+
+	if result.WeightKg != 15.31 {
+		t.Errorf("Received invalid weight: %f", result.WeightKg)
+	}
+
+	if result.UUID != "38947555-7742-3448-3784-823347823834" {
+		t.Errorf("Received invalid UUID: %s", result.UUID)
+	}
+
+	if result.MaxPowerWatts != 800 || result.MinPowerWatts != 150 {
+		t.Errorf("Received invalid power bounds: %f-%f", result.MinPowerWatts, result.MaxPowerWatts)
+	}
+
 	if result.Status.Health != common.OKHealth {
 		t.Errorf("Received invalid health status: %s", result.Status.Health)
 	}
 
+	if result.Location.Placement.Rack != "WEB43" {
+		t.Errorf("Received invalid rack: %s", result.Location.Placement.Rack)
+	}
+
+	if result.Location.Placement.RackOffset != 12 {
+		t.Errorf("Received invalid rack offset: %d", result.Location.Placement.RackOffset)
+	}
+
+	if result.Location.PartLocation.LocationType != common.SlotLocationType {
+		t.Errorf("Received invalid part location type: %s", result.Location.PartLocation.LocationType)
+	}
+
+	if result.PhysicalSecurity.IntrusionSensor != HardwareIntrusionIntrusionSensor {
+		t.Errorf("Received invalid intrusion sensor state: %s", result.PhysicalSecurity.IntrusionSensor)
+	}
+
+	if result.PhysicalSecurity.IntrusionSensorReArm != ManualIntrusionSensorReArm {
+		t.Errorf("Received invalid intrusion sensor re-arm: %s", result.PhysicalSecurity.IntrusionSensorReArm)
+	}
+
+	if result.assembly != "/redfish/v1/Chassis/Chassis-1/Assembly" {
+		t.Errorf("Received invalid assembly reference: %s", result.assembly)
+	}
+
 	if result.thermal != "/redfish/v1/Chassis/Chassis-1/Thermal" {
 		t.Errorf("Received invalid thermal reference: %s", result.thermal)
 	}
@@ -97,6 +206,10 @@ func TestChassis(t *testing.T) {
 		t.Errorf("Received invalid power reference: %s", result.power)
 	}
 
+	if result.trustedComponents != "/redfish/v1/Chassis/Chassis-1/TrustedComponents" {
+		t.Errorf("Received invalid trusted components reference: %s", result.trustedComponents)
+	}
+
 	if len(result.computerSystems) != 1 {
 		t.Errorf("Expected 1 computer system, got %d", len(result.computerSystems))
 	}
@@ -117,6 +230,22 @@ func TestChassis(t *testing.T) {
 		t.Errorf("Invalid managed by reference: %s", result.managedBy[0])
 	}
 
+	if len(result.drives) != result.DrivesCount || result.DrivesCount != 2 {
+		t.Errorf("Expected 2 drive references, got %d", len(result.drives))
+	}
+
+	if result.drives[1] != "/redfish/v1/Chassis/Chassis-1/Drives/Disk.Bay.1" {
+		t.Errorf("Invalid drive reference: %s", result.drives[1])
+	}
+
+	if len(result.storage) != 1 {
+		t.Errorf("Expected 1 storage reference, got %d", len(result.storage))
+	}
+
+	if len(result.pcieDevices) != 1 {
+		t.Errorf("Expected 1 PCIe device reference, got %d", len(result.pcieDevices))
+	}
+
 	if result.resetTarget != "/redfish/v1/Chassis/System.Embedded.1/Actions/Chassis.Reset" {
 		t.Errorf("Invalid reset action target: %s", result.resetTarget)
 	}
@@ -156,3 +285,193 @@ func TestChassisUpdate(t *testing.T) {
 		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestChassisUpdateLocation tests the Update call with changes to the
+// environmental class and the location.
+func TestChassisUpdateLocation(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.EnvironmentalClass = A4EnvironmentalClass
+	result.LocationIndicatorActive = true
+	result.Location.Placement.Rack = "WEB44"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "EnvironmentalClass:A4") ||
+		!strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "Location:map[Placement:map[Rack:WEB44]]") {
+		t.Errorf("Unexpected location payload: %s", calls[0].Payload)
+	}
+
+	if result.Location.Placement.Rack != "WEB44" {
+		t.Errorf("The location should be kept: %s", result.Location.Placement.Rack)
+	}
+}
+
+// TestChassisUpdateWriteableProperties tests the location is checked against
+// the @Redfish.WriteableProperties annotation.
+func TestChassisUpdateWriteableProperties(t *testing.T) {
+	common.CheckWriteableProperties = true
+	defer func() { common.CheckWriteableProperties = false }()
+
+	body := strings.Replace(chassisBody, `"AssetTag": "Chicago-45Z-2381",`,
+		`"AssetTag": "Chicago-45Z-2381",
+		"@Redfish.WriteableProperties": ["AssetTag"],`, 1)
+
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Location.Placement.Rack = "WEB44"
+	err = result.Update()
+
+	if _, ok := err.(common.ErrorUnwritableProperties); !ok {
+		t.Errorf("Expected an unwritable properties error, got: %v", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Nothing should be sent: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestChassisSetLocation tests the SetLocation call.
+func TestChassisSetLocation(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	location := result.Location
+	location.Placement.Rack = "WEB44"
+	location.Placement.RackOffset = 0
+	err = result.SetLocation(location)
+
+	if err != nil {
+		t.Errorf("Error making SetLocation call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Errorf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if calls[0].Payload != "{map[Placement:map[Rack:WEB44 RackOffset:0]]}" {
+		t.Errorf("Unexpected location payload: %s", calls[0].Payload)
+	}
+
+	if result.Location.Placement.Rack != "WEB44" {
+		t.Errorf("Location was not updated: %s", result.Location.Placement.Rack)
+	}
+
+	testClient.Reset()
+	err = result.SetLocation(result.Location)
+
+	if err != nil {
+		t.Errorf("Error making SetLocation call: %s", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Expected no calls for an unchanged location: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestChassisReArmIntrusionSensor tests the ReArmIntrusionSensor call.
+func TestChassisReArmIntrusionSensor(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ReArmIntrusionSensor()
+
+	if err != nil {
+		t.Errorf("Error making ReArmIntrusionSensor call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, string(NormalIntrusionSensor)) {
+		t.Errorf("Unexpected re-arm payload: %s", calls[0].Payload)
+	}
+
+	if result.PhysicalSecurity.IntrusionSensor != NormalIntrusionSensor {
+		t.Errorf("Intrusion sensor state was not updated: %s", result.PhysicalSecurity.IntrusionSensor)
+	}
+}
+
+// TestChassisMarshalJSON tests that marshaling a chassis keeps the properties
+// that are not modeled.
+func TestChassisMarshalJSON(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(chassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result.AssetTag = "TestAssetTag"
+
+	data, err := json.Marshal(&result)
+	if err != nil {
+		t.Fatalf("Error marshaling JSON: %s", err)
+	}
+
+	var chassis map[string]interface{}
+	err = json.Unmarshal(data, &chassis)
+	if err != nil {
+		t.Fatalf("Error decoding marshaled JSON: %s", err)
+	}
+
+	if chassis["AssetTag"] != "TestAssetTag" {
+		t.Errorf("The changed asset tag should be written: %v", chassis["AssetTag"])
+	}
+
+	if chassis["SKU"] != "8675309" {
+		t.Errorf("Unchanged properties should be kept: %v", chassis["SKU"])
+	}
+
+	if _, ok := chassis["Links"].(map[string]interface{}); !ok {
+		t.Errorf("Properties that are not modeled should be kept: %v", chassis["Links"])
+	}
+
+	if _, ok := chassis["SupportedResetTypes"]; ok {
+		t.Error("Fields that are not in the raw JSON should not be written")
+	}
+}
@@ -0,0 +1,483 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// BreakerState is the state of the over current protection device of a
+// circuit.
+type BreakerState string
+
+const (
+	// NormalBreakerState The breaker is powered on.
+	NormalBreakerState BreakerState = "Normal"
+	// TrippedBreakerState The breaker is open due to an over current
+	// condition.
+	TrippedBreakerState BreakerState = "Tripped"
+	// OffBreakerState The breaker is manually turned off.
+	OffBreakerState BreakerState = "Off"
+)
+
+// CircuitType is the type of a circuit.
+type CircuitType string
+
+const (
+	// MainsCircuitType A mains input or utility circuit.
+	MainsCircuitType CircuitType = "Mains"
+	// BranchCircuitType A branch (output) circuit.
+	BranchCircuitType CircuitType = "Branch"
+	// SubfeedCircuitType A subfeed (output) circuit.
+	SubfeedCircuitType CircuitType = "Subfeed"
+	// FeederCircuitType A feeder (output) circuit.
+	FeederCircuitType CircuitType = "Feeder"
+	// BusCircuitType An electrical bus circuit.
+	BusCircuitType CircuitType = "Bus"
+)
+
+// PlugType is the type of plug of a circuit.
+type PlugType string
+
+const (
+	// NEMA515PPlugType NEMA 5-15P (2P3W; 120V; 15A).
+	NEMA515PPlugType PlugType = "NEMA_5_15P"
+	// NEMAL515PPlugType NEMA L5-15P (2P3W; 120V; 15A).
+	NEMAL515PPlugType PlugType = "NEMA_L5_15P"
+	// NEMA520PPlugType NEMA 5-20P (2P3W; 120V; 20A).
+	NEMA520PPlugType PlugType = "NEMA_5_20P"
+	// NEMAL520PPlugType NEMA L5-20P (2P3W; 120V; 20A).
+	NEMAL520PPlugType PlugType = "NEMA_L5_20P"
+	// NEMAL530PPlugType NEMA L5-30P (2P3W; 120V; 30A).
+	NEMAL530PPlugType PlugType = "NEMA_L5_30P"
+	// NEMA615PPlugType NEMA 6-15P (2P3W; 250V; 15A).
+	NEMA615PPlugType PlugType = "NEMA_6_15P"
+	// NEMAL615PPlugType NEMA L6-15P (2P3W; 250V; 15A).
+	NEMAL615PPlugType PlugType = "NEMA_L6_15P"
+	// NEMA620PPlugType NEMA 6-20P (2P3W; 250V; 20A).
+	NEMA620PPlugType PlugType = "NEMA_6_20P"
+	// NEMAL620PPlugType NEMA L6-20P (2P3W; 250V; 20A).
+	NEMAL620PPlugType PlugType = "NEMA_L6_20P"
+	// NEMAL630PPlugType NEMA L6-30P (2P3W; 250V; 30A).
+	NEMAL630PPlugType PlugType = "NEMA_L6_30P"
+	// NEMAL1420PPlugType NEMA L14-20P (3P4W; 120/240V; 20A).
+	NEMAL1420PPlugType PlugType = "NEMA_L14_20P"
+	// NEMAL1430PPlugType NEMA L14-30P (3P4W; 120/240V; 30A).
+	NEMAL1430PPlugType PlugType = "NEMA_L14_30P"
+	// NEMAL1520PPlugType NEMA L15-20P (3P4W; 250V; 20A).
+	NEMAL1520PPlugType PlugType = "NEMA_L15_20P"
+	// NEMAL1530PPlugType NEMA L15-30P (3P4W; 250V; 30A).
+	NEMAL1530PPlugType PlugType = "NEMA_L15_30P"
+	// NEMAL2120PPlugType NEMA L21-20P (4P5W; 120/208V; 20A).
+	NEMAL2120PPlugType PlugType = "NEMA_L21_20P"
+	// NEMAL2130PPlugType NEMA L21-30P (4P5W; 120/208V; 30A).
+	NEMAL2130PPlugType PlugType = "NEMA_L21_30P"
+	// NEMAL2220PPlugType NEMA L22-20P (4P5W; 277/480V; 20A).
+	NEMAL2220PPlugType PlugType = "NEMA_L22_20P"
+	// NEMAL2230PPlugType NEMA L22-30P (4P5W; 277/480V; 30A).
+	NEMAL2230PPlugType PlugType = "NEMA_L22_30P"
+	// CaliforniaCS8265PlugType California Standard CS8265 (Single-phase
+	// 250V; 50A; 2P3W).
+	CaliforniaCS8265PlugType PlugType = "California_CS8265"
+	// CaliforniaCS8365PlugType California Standard CS8365 (Three-phase 250V;
+	// 50A; 3P4W).
+	CaliforniaCS8365PlugType PlugType = "California_CS8365"
+	// IEC60320C14PlugType IEC C14 (2P3W; 250V; 10A).
+	IEC60320C14PlugType PlugType = "IEC_60320_C14"
+	// IEC60320C20PlugType IEC C20 (2P3W; 250V; 16A).
+	IEC60320C20PlugType PlugType = "IEC_60320_C20"
+	// IEC60309316P6PlugType IEC 60309 316P6 (2P3W; 250V; 16A).
+	IEC60309316P6PlugType PlugType = "IEC_60309_316P6"
+	// IEC60309332P6PlugType IEC 60309 332P6 (2P3W; 250V; 32A).
+	IEC60309332P6PlugType PlugType = "IEC_60309_332P6"
+	// IEC60309363P6PlugType IEC 60309 363P6 (2P3W; 250V; 63A).
+	IEC60309363P6PlugType PlugType = "IEC_60309_363P6"
+	// IEC60309516P6PlugType IEC 60309 516P6 (4P5W; 415V; 16A).
+	IEC60309516P6PlugType PlugType = "IEC_60309_516P6"
+	// IEC60309532P6PlugType IEC 60309 532P6 (4P5W; 415V; 32A).
+	IEC60309532P6PlugType PlugType = "IEC_60309_532P6"
+	// IEC60309563P6PlugType IEC 60309 563P6 (4P5W; 415V; 63A).
+	IEC60309563P6PlugType PlugType = "IEC_60309_563P6"
+	// IEC60309460P9PlugType IEC 60309 460P9 (3P4W; 480V; 60A).
+	IEC60309460P9PlugType PlugType = "IEC_60309_460P9"
+	// IEC60309560P9PlugType IEC 60309 560P9 (4P5W; 480V; 60A).
+	IEC60309560P9PlugType PlugType = "IEC_60309_560P9"
+	// Field208V3P4W60APlugType Field-wired; Three-phase 200-250V; 60A; 3P4W.
+	Field208V3P4W60APlugType PlugType = "Field_208V_3P4W_60A"
+	// Field400V3P5W32APlugType Field-wired; Three-phase 200-240/346-415V;
+	// 32A; 3P5W.
+	Field400V3P5W32APlugType PlugType = "Field_400V_3P5W_32A"
+)
+
+// Circuit shall be used to represent an electrical circuit, such as a mains,
+// branch, subfeed or feeder, for a Redfish implementation.
+type Circuit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BreakerState shall contain the state of the over current protection
+	// device.
+	BreakerState BreakerState
+	// CircuitType shall contain the type of circuit.
+	CircuitType CircuitType
+	// ConfigurationLocked shall indicate whether modification requests to
+	// this resource are not permitted.
+	ConfigurationLocked bool
+	// CriticalCircuit shall indicate whether the circuit is designated as a
+	// critical circuit, and therefore is excluded from autonomous logic
+	// that could affect the state of the circuit.
+	CriticalCircuit bool
+	// CurrentAmps shall contain the current, in ampere units, for this
+	// single phase circuit.
+	CurrentAmps SensorCurrentExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// ElectricalConsumerNames shall contain an array of user-assigned
+	// identifying strings that describe the functions or devices that this
+	// circuit supplies power to.
+	ElectricalConsumerNames []string
+	// ElectricalContext shall contain the combination of current-carrying
+	// conductors that distribute power.
+	ElectricalContext ElectricalContext
+	// ElectricalSourceManagerURI shall contain a URI to the management
+	// application or device that provides monitoring or control of the
+	// external equipment that provides power to this circuit.
+	ElectricalSourceManagerURI string
+	// ElectricalSourceName shall contain a string that identifies the
+	// external source of power for this circuit.
+	ElectricalSourceName string
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this circuit.
+	EnergykWh SensorEnergykWhExcerpt
+	// FrequencyHz shall contain the frequency, in hertz units, for this
+	// circuit.
+	FrequencyHz SensorExcerpt
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// NominalVoltage shall contain the nominal voltage for this circuit.
+	NominalVoltage NominalVoltageType
+	// PhaseWiringType shall contain the number of ungrounded
+	// current-carrying conductors (phases) and the total number of
+	// conductors (wires).
+	PhaseWiringType PhaseWiringType
+	// PlugType shall contain the type of physical plug used for this
+	// circuit.
+	PlugType PlugType
+	// PolyPhaseCurrentAmps shall contain the current sensors for this
+	// circuit. For single phase circuits this property shall be absent.
+	PolyPhaseCurrentAmps CurrentSensors
+	// PolyPhaseEnergykWh shall contain the energy sensors for this circuit.
+	// For single phase circuits this property shall be absent.
+	PolyPhaseEnergykWh EnergySensors
+	// PolyPhasePowerWatts shall contain the power sensors for this circuit.
+	// For single phase circuits this property shall be absent.
+	PolyPhasePowerWatts PowerSensors
+	// PolyPhaseVoltage shall contain the voltage sensors for this circuit.
+	// For single phase circuits this property shall be absent.
+	PolyPhaseVoltage VoltageSensors
+	// PowerControlLocked shall indicate whether requests to the PowerControl
+	// action are locked.
+	PowerControlLocked bool
+	// PowerCycleDelaySeconds shall contain the number of seconds to delay
+	// power on after a PowerControl action to cycle power.
+	PowerCycleDelaySeconds float32
+	// PowerEnabled shall indicate the power enable state of the circuit.
+	PowerEnabled bool
+	// PowerLoadPercent shall contain the power load, in percent units, for
+	// this circuit that represents the Total ElectricalContext for this
+	// circuit.
+	PowerLoadPercent SensorExcerpt
+	// PowerOffDelaySeconds shall contain the number of seconds to delay
+	// power off after a PowerControl action.
+	PowerOffDelaySeconds float32
+	// PowerOnDelaySeconds shall contain the number of seconds to delay power
+	// up after a power cycle or a PowerControl action.
+	PowerOnDelaySeconds float32
+	// PowerRestoreDelaySeconds shall contain the number of seconds to delay
+	// power on after a power fault.
+	PowerRestoreDelaySeconds float32
+	// PowerRestorePolicy shall contain the desired PowerState of the circuit
+	// when power is applied.
+	PowerRestorePolicy PowerRestorePolicyTypes
+	// PowerState shall contain the power state of the circuit.
+	PowerState PowerState
+	// PowerStateInTransition shall indicate whether the PowerState property
+	// will undergo a transition between on and off states due to a
+	// configured delay.
+	PowerStateInTransition bool
+	// PowerWatts shall contain the total power, in watt units, for this
+	// circuit that represents the Total ElectricalContext sensor when
+	// multiple power sensors exist.
+	PowerWatts SensorPowerExcerpt
+	// RatedCurrentAmps shall contain the rated maximum current for this
+	// circuit, in ampere units, after any required de-rating.
+	RatedCurrentAmps float32
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Voltage shall contain the voltage, in volt units, for this single
+	// phase circuit.
+	Voltage SensorVoltageExcerpt
+	// VoltageType shall contain the type of voltage applied to the circuit.
+	VoltageType VoltageType
+	// branchCircuit is the branch circuit related to this circuit.
+	branchCircuit string
+	// distributionCircuits are the circuits powered by this circuit.
+	distributionCircuits []string
+	// outlets are the outlets powered by this circuit.
+	outlets []string
+	// powerOutlet is the outlet that provides power to this circuit.
+	powerOutlet string
+	// sourceCircuit is the circuit that provides power to this circuit.
+	sourceCircuit string
+	// breakerControlTarget is the URL to send BreakerControl actions to.
+	breakerControlTarget string
+	// powerControlTarget is the URL to send PowerControl actions to.
+	powerControlTarget string
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (circuit *Circuit) GetRawData() []byte {
+	return circuit.rawData
+}
+
+// UnmarshalJSON unmarshals a Circuit object from the raw JSON.
+func (circuit *Circuit) UnmarshalJSON(b []byte) error {
+	type temp Circuit
+	type Actions struct {
+		BreakerControl struct {
+			Target string
+		} `json:"#Circuit.BreakerControl"`
+		PowerControl struct {
+			Target string
+		} `json:"#Circuit.PowerControl"`
+		ResetMetrics struct {
+			Target string
+		} `json:"#Circuit.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+		Links   struct {
+			BranchCircuit        common.Link
+			DistributionCircuits common.Links
+			Outlets              common.Links
+			PowerOutlet          common.Link
+			SourceCircuit        common.Link
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*circuit = Circuit(t.temp)
+
+	// Extract the links to other entities for later
+	circuit.branchCircuit = string(t.Links.BranchCircuit)
+	circuit.distributionCircuits = t.Links.DistributionCircuits.ToStrings()
+	circuit.outlets = t.Links.Outlets.ToStrings()
+	circuit.powerOutlet = string(t.Links.PowerOutlet)
+	circuit.sourceCircuit = string(t.Links.SourceCircuit)
+	circuit.breakerControlTarget = t.Actions.BreakerControl.Target
+	circuit.powerControlTarget = t.Actions.PowerControl.Target
+	circuit.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	circuit.rawData = b
+	circuit.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the circuit to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (circuit Circuit) MarshalJSON() ([]byte, error) {
+	type temp Circuit
+	return common.MarshalWithRawData(circuit.rawData, temp(circuit))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (circuit *Circuit) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Circuit)
+	original.UnmarshalJSON(circuit.rawData)
+
+	readWriteFields := []string{
+		"ConfigurationLocked",
+		"CriticalCircuit",
+		"ElectricalSourceManagerURI",
+		"ElectricalSourceName",
+		"LocationIndicatorActive",
+		"PowerCycleDelaySeconds",
+		"PowerOffDelaySeconds",
+		"PowerOnDelaySeconds",
+		"PowerRestoreDelaySeconds",
+		"PowerRestorePolicy",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(circuit).Elem()
+
+	return circuit.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// BranchCircuit gets the branch circuit related to this circuit.
+func (circuit *Circuit) BranchCircuit() (*Circuit, error) {
+	if circuit.branchCircuit == "" {
+		return nil, nil
+	}
+
+	return GetCircuit(circuit.Client, circuit.branchCircuit)
+}
+
+// DistributionCircuits gets the circuits powered by this circuit.
+func (circuit *Circuit) DistributionCircuits() ([]*Circuit, error) {
+	var result []*Circuit
+	for _, circuitLink := range circuit.distributionCircuits {
+		distributionCircuit, err := GetCircuit(circuit.Client, circuitLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, distributionCircuit)
+	}
+
+	return result, nil
+}
+
+// Outlets gets the outlets powered by this circuit.
+func (circuit *Circuit) Outlets() ([]*Outlet, error) {
+	var result []*Outlet
+	for _, outletLink := range circuit.outlets {
+		outlet, err := GetOutlet(circuit.Client, outletLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, outlet)
+	}
+
+	return result, nil
+}
+
+// PowerOutlet gets the outlet that provides power to this circuit.
+func (circuit *Circuit) PowerOutlet() (*Outlet, error) {
+	if circuit.powerOutlet == "" {
+		return nil, nil
+	}
+
+	return GetOutlet(circuit.Client, circuit.powerOutlet)
+}
+
+// SourceCircuit gets the circuit that provides power to this circuit.
+func (circuit *Circuit) SourceCircuit() (*Circuit, error) {
+	if circuit.sourceCircuit == "" {
+		return nil, nil
+	}
+
+	return GetCircuit(circuit.Client, circuit.sourceCircuit)
+}
+
+// BreakerControl turns the breaker of the circuit on or off.
+func (circuit *Circuit) BreakerControl(powerState PowerState) error {
+	if circuit.breakerControlTarget == "" {
+		return fmt.Errorf("BreakerControl is not supported by this circuit")
+	}
+
+	type temp struct {
+		PowerState PowerState
+	}
+	t := temp{
+		PowerState: powerState,
+	}
+
+	_, err := circuit.Client.Post(circuit.breakerControlTarget, t)
+	return err
+}
+
+// PowerControl turns the circuit on or off, or cycles its power.
+func (circuit *Circuit) PowerControl(powerState PowerState) error {
+	if circuit.powerControlTarget == "" {
+		return fmt.Errorf("PowerControl is not supported by this circuit")
+	}
+
+	type temp struct {
+		PowerState PowerState
+	}
+	t := temp{
+		PowerState: powerState,
+	}
+
+	_, err := circuit.Client.Post(circuit.powerControlTarget, t)
+	return err
+}
+
+// ResetMetrics resets the summary metrics related to this circuit.
+func (circuit *Circuit) ResetMetrics() error {
+	if circuit.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this circuit")
+	}
+
+	_, err := circuit.Client.Post(circuit.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// GetCircuit will get a Circuit instance from the service.
+func GetCircuit(c common.Client, uri string) (*Circuit, error) {
+	var circuit Circuit
+	err := common.FetchResource(c, uri, &circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &circuit, nil
+}
+
+// ListReferencedCircuits gets the collection of Circuit from
+// a provided reference.
+func ListReferencedCircuits(c common.Client, link string) ([]*Circuit, error) {
+	var result []*Circuit
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, circuitLink := range links.ItemLinks {
+		circuit := new(Circuit)
+		expanded, err := links.DecodeMember(c, circuitLink, circuit)
+		if err == nil && !expanded {
+			circuit, err = GetCircuit(c, circuitLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, circuit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var circuitBody = `{
+		"@odata.type": "#Circuit.v1_7_0.Circuit",
+		"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1",
+		"Id": "AC1",
+		"Name": "Mains Input AC1",
+		"CircuitType": "Mains",
+		"PhaseWiringType": "ThreePhase5Wire",
+		"NominalVoltage": "AC200To240V",
+		"RatedCurrentAmps": 32,
+		"PlugType": "IEC_60309_532P6",
+		"BreakerState": "Normal",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"PolyPhaseVoltage": {
+			"Line1ToNeutral": {
+				"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/VoltageL1N",
+				"Reading": 118.2
+			}
+		},
+		"PolyPhaseCurrentAmps": {
+			"Line1": {
+				"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/CurrentL1",
+				"Reading": 1.68
+			}
+		},
+		"PolyPhasePowerWatts": {
+			"Line1ToNeutral": {
+				"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/PowerL1N",
+				"Reading": 197.42
+			}
+		},
+		"Links": {
+			"DistributionCircuits": [{
+				"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Branches/A"
+			}]
+		},
+		"Actions": {
+			"#Circuit.BreakerControl": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1/Circuit.BreakerControl"
+			},
+			"#Circuit.ResetMetrics": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/Mains/AC1/Circuit.ResetMetrics"
+			}
+		}
+	}`
+
+// TestCircuit tests the parsing of Circuit objects.
+func TestCircuit(t *testing.T) {
+	var result Circuit
+	err := json.NewDecoder(strings.NewReader(circuitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "AC1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CircuitType != MainsCircuitType {
+		t.Errorf("Invalid circuit type: %s", result.CircuitType)
+	}
+
+	if result.PlugType != IEC60309532P6PlugType {
+		t.Errorf("Invalid plug type: %s", result.PlugType)
+	}
+
+	if result.BreakerState != NormalBreakerState {
+		t.Errorf("Invalid breaker state: %s", result.BreakerState)
+	}
+
+	if result.PolyPhaseVoltage.Line1ToNeutral.Reading != 118.2 {
+		t.Errorf("Invalid L1-N voltage: %f", result.PolyPhaseVoltage.Line1ToNeutral.Reading)
+	}
+
+	if result.PolyPhasePowerWatts.Line1ToNeutral.Reading != 197.42 {
+		t.Errorf("Invalid L1-N power: %f", result.PolyPhasePowerWatts.Line1ToNeutral.Reading)
+	}
+
+	if len(result.distributionCircuits) != 1 {
+		t.Errorf("Invalid distribution circuit links: %v", result.distributionCircuits)
+	}
+
+	if result.powerControlTarget != "" {
+		t.Errorf("Invalid PowerControl target: %s", result.powerControlTarget)
+	}
+}
+
+// TestCircuitBreakerControl tests the BreakerControl action.
+func TestCircuitBreakerControl(t *testing.T) {
+	var result Circuit
+	err := json.NewDecoder(strings.NewReader(circuitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.BreakerControl(OffPowerState)
+
+	if err != nil {
+		t.Errorf("Error making BreakerControl call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{Off}" {
+		t.Errorf("Unexpected BreakerControl payload: %s", calls[0].Payload)
+	}
+
+	if err := result.PowerControl(OnPowerState); err == nil {
+		t.Error("PowerControl should not be supported by this circuit")
+	}
+}
@@ -0,0 +1,535 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ComponentIntegrityType is the type of security technology used to report
+// the integrity of a component.
+type ComponentIntegrityType string
+
+const (
+	// SPDMComponentIntegrityType shall indicate the integrity information is
+	// obtained through the Security Protocol and Data Model (SPDM) protocol
+	// as defined in DMTF DSP0274.
+	SPDMComponentIntegrityType ComponentIntegrityType = "SPDM"
+	// TPMComponentIntegrityType shall indicate the integrity information is
+	// related to a Trusted Platform Module (TPM) as defined by the Trusted
+	// Computing Group (TCG).
+	TPMComponentIntegrityType ComponentIntegrityType = "TPM"
+	// TCMComponentIntegrityType shall indicate the integrity information is
+	// related to a Trusted Cryptography Module (TCM) as defined by the China
+	// TCM Union (TCMU).
+	TCMComponentIntegrityType ComponentIntegrityType = "TCM"
+	// TPCMComponentIntegrityType shall indicate the integrity information is
+	// related to a Trusted Platform Control Module (TPCM) as defined by the
+	// Zhongguancun Trusted Computing Industry Alliance (ZTCIA).
+	TPCMComponentIntegrityType ComponentIntegrityType = "TPCM"
+	// OEMComponentIntegrityType shall indicate the integrity information is
+	// OEM-specific and the OEM section may include additional information.
+	OEMComponentIntegrityType ComponentIntegrityType = "OEM"
+)
+
+// MeasurementSpecification is the type of measurement specification.
+type MeasurementSpecification string
+
+const (
+	// DMTFMeasurementSpecification shall indicate the measurement
+	// specification is defined by DMTF in DSP0274.
+	DMTFMeasurementSpecification MeasurementSpecification = "DMTF"
+)
+
+// SPDMmeasurementSummaryType is the type of SPDM measurement summary.
+type SPDMmeasurementSummaryType string
+
+const (
+	// TCBSPDMmeasurementSummaryType The measurement summary covers the TCB.
+	TCBSPDMmeasurementSummaryType SPDMmeasurementSummaryType = "TCB"
+	// AllSPDMmeasurementSummaryType The measurement summary covers all
+	// measurements in SPDM.
+	AllSPDMmeasurementSummaryType SPDMmeasurementSummaryType = "All"
+)
+
+// VerificationStatus is the status of a verification.
+type VerificationStatus string
+
+const (
+	// SuccessVerificationStatus Successful verification.
+	SuccessVerificationStatus VerificationStatus = "Success"
+	// FailedVerificationStatus Unsuccessful verification.
+	FailedVerificationStatus VerificationStatus = "Failed"
+)
+
+// SecureSessionType is the type of an SPDM secure session.
+type SecureSessionType string
+
+const (
+	// PlainSecureSessionType A plain text session without any protection.
+	PlainSecureSessionType SecureSessionType = "Plain"
+	// EncryptedAuthenticatedSecureSessionType An established session where
+	// both encryption and authentication are protecting the communication.
+	EncryptedAuthenticatedSecureSessionType SecureSessionType = "EncryptedAuthenticated"
+	// AuthenticatedOnlySecureSessionType An established session where only
+	// authentication is protecting the communication.
+	AuthenticatedOnlySecureSessionType SecureSessionType = "AuthenticatedOnly"
+)
+
+// SingleSessionInfo shall contain information about a single communication
+// channel or session between two components.
+type SingleSessionInfo struct {
+	// SessionID shall contain the unique identifier for the active session or
+	// communication channel between two components.
+	SessionID int `json:"SessionId"`
+	// SessionType shall contain the type of session or communication channel
+	// between two components.
+	SessionType SecureSessionType
+}
+
+// ComponentCommunication shall contain information about communication
+// between two components.
+type ComponentCommunication struct {
+	// Sessions shall contain an array of the active sessions or communication
+	// channels between two components.
+	Sessions []SingleSessionInfo
+}
+
+// CommonAuthInfo shall contain common identity-related authentication
+// information.
+type CommonAuthInfo struct {
+	// ComponentCertificate shall contain a link to a resource of type
+	// Certificate that represents the identity of the component referenced
+	// by the TargetComponentURI property.
+	ComponentCertificate string
+	// VerificationStatus shall contain the status of the verification of
+	// the identity of the component referenced by the TargetComponentURI
+	// property.
+	VerificationStatus VerificationStatus
+}
+
+// UnmarshalJSON unmarshals a CommonAuthInfo object from the raw JSON.
+func (authinfo *CommonAuthInfo) UnmarshalJSON(b []byte) error {
+	type temp CommonAuthInfo
+	var t struct {
+		temp
+		ComponentCertificate common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*authinfo = CommonAuthInfo(t.temp)
+	authinfo.ComponentCertificate = string(t.ComponentCertificate)
+
+	return nil
+}
+
+// SPDMidentity shall contain identity authentication information about the
+// SPDM Requester and SPDM Responder.
+type SPDMidentity struct {
+	// RequesterAuthentication shall contain authentication information of
+	// the identity of the SPDM Requester. The ProvidingCertificate property
+	// shall contain a link to the Certificate the requester provided.
+	RequesterAuthentication struct {
+		ProvidingCertificate common.Link
+	}
+	// ResponderAuthentication shall contain the authentication information
+	// of the identity of the SPDM Responder.
+	ResponderAuthentication CommonAuthInfo
+}
+
+// SPDMsingleMeasurement shall contain a single SPDM measurement for an SPDM
+// Responder.
+type SPDMsingleMeasurement struct {
+	// LastUpdated shall contain the date and time when information for the
+	// measurement was last updated.
+	LastUpdated common.DateTime
+	// Measurement shall contain the Base64-encoded measurement using the
+	// format specified by the DMTFSpecMeasurementValueType field.
+	Measurement string
+	// MeasurementHashAlgorithm shall contain the hash algorithm used to
+	// compute the measurement.
+	MeasurementHashAlgorithm string
+	// MeasurementIndex shall contain the index of the measurement.
+	MeasurementIndex int
+	// MeasurementType shall contain the type or characteristics of the data
+	// that this measurement represents.
+	MeasurementType string
+	// PartofSummaryHash shall indicate whether this measurement is part of
+	// the measurement summary in the MeasurementSummary property.
+	PartofSummaryHash bool
+	// SecurityVersionNumber shall contain an 8-byte hex-encoded string of the
+	// security version number the measurement represents.
+	SecurityVersionNumber string
+}
+
+// SPDMmeasurementSet shall contain the SPDM Responder's measurements.
+type SPDMmeasurementSet struct {
+	// MeasurementSpecification shall contain the measurement specification
+	// negotiated between the SPDM Requester and SPDM Responder.
+	MeasurementSpecification MeasurementSpecification
+	// MeasurementSummary shall contain the Base64-encoded measurement summary
+	// using the hash algorithm indicated by the
+	// MeasurementSummaryHashAlgorithm property.
+	MeasurementSummary string
+	// MeasurementSummaryHashAlgorithm shall contain the hash algorithm used
+	// to compute the measurement summary.
+	MeasurementSummaryHashAlgorithm string
+	// MeasurementSummaryType shall contain the type of measurement summary.
+	MeasurementSummaryType SPDMmeasurementSummaryType
+	// Measurements shall contain measurements from an SPDM Responder.
+	Measurements []SPDMsingleMeasurement
+}
+
+// SPDMinfo shall contain integrity information about an SPDM Responder as
+// reported by an SPDM Requester.
+type SPDMinfo struct {
+	// ComponentCommunication shall contain information about communication
+	// between the SPDM Requester and SPDM Responder.
+	ComponentCommunication ComponentCommunication
+	// IdentityAuthentication shall contain identity authentication
+	// information about the SPDM Requester and SPDM Responder.
+	IdentityAuthentication SPDMidentity
+	// MeasurementSet shall contain measurement information for the SPDM
+	// Responder.
+	MeasurementSet SPDMmeasurementSet
+	// Requester shall contain a link to the resource representing the SPDM
+	// Requester that is reporting the integrity of the SPDM Responder
+	// identified by the TargetComponentURI property.
+	Requester common.Link
+}
+
+// CommonMeasurement shall describe a TPM measurement.
+type CommonMeasurement struct {
+	// LastUpdated shall contain the date and time when information for the
+	// measurement was last updated.
+	LastUpdated common.DateTime
+	// Measurement shall contain a Base64-encoded measurement.
+	Measurement string
+	// MeasurementHashAlgorithm shall contain the hash algorithm used to
+	// compute the measurement.
+	MeasurementHashAlgorithm string
+	// PCR shall contain the Platform Configuration Register (PCR) bank of
+	// the measurement.
+	PCR int
+}
+
+// TPMinfo shall contain integrity information about a Trusted Platform
+// Module (TPM).
+type TPMinfo struct {
+	// ComponentCommunication shall contain information about communication
+	// with the TPM.
+	ComponentCommunication ComponentCommunication
+	// IdentityAuthentication shall contain identity authentication
+	// information about the TPM.
+	IdentityAuthentication CommonAuthInfo
+	// MeasurementSet shall contain measurement information from the TPM.
+	MeasurementSet struct {
+		Measurements []CommonMeasurement
+	}
+	// NonceSizeBytesMaximum shall contain the maximum number of bytes that
+	// can be specified in the Nonce parameter of the TPMGetSignedMeasurements
+	// action.
+	NonceSizeBytesMaximum int
+}
+
+// SPDMGetSignedMeasurementsParameters are the parameters of the
+// ComponentIntegrity.SPDMGetSignedMeasurements action.
+type SPDMGetSignedMeasurementsParameters struct {
+	// MeasurementIndices shall contain an array of indices that identify the
+	// measurement blocks to sign. An empty array or absent value requests
+	// all measurement blocks.
+	MeasurementIndices []int `json:",omitempty"`
+	// Nonce shall contain a 32-byte hex-encoded string that is signed with
+	// the measurements. If not provided, the SPDM Requester shall generate
+	// a nonce.
+	Nonce string `json:",omitempty"`
+	// SlotID shall contain the SPDM slot identifier for the certificate
+	// containing the private key used to generate the signature.
+	SlotID int `json:"SlotId"`
+}
+
+// SPDMGetSignedMeasurementsResponse shall contain the SPDM signed
+// measurements from an SPDM Responder.
+type SPDMGetSignedMeasurementsResponse struct {
+	// Certificate shall contain a link to a resource of type Certificate
+	// that represents the certificate corresponding to the SPDM slot
+	// identifier that can be used to validate the signature.
+	Certificate common.Link
+	// HashingAlgorithm shall contain the hashing algorithm negotiated
+	// between the SPDM Requester and the SPDM Responder.
+	HashingAlgorithm string
+	// PublicKey shall contain a Privacy Enhanced Mail (PEM)-encoded public
+	// key that can be used to validate the signature.
+	PublicKey string
+	// SignedMeasurements shall contain the cryptographic signed statement
+	// over the given nonce and measurement blocks.
+	SignedMeasurements string
+	// SigningAlgorithm shall contain the asymmetric signing algorithm
+	// negotiated between the SPDM Requester and the SPDM Responder.
+	SigningAlgorithm string
+	// Version shall contain the SPDM version negotiated between the SPDM
+	// Requester and the SPDM Responder to generate the cryptographic signed
+	// statement.
+	Version string
+}
+
+// TPMGetSignedMeasurementsParameters are the parameters of the
+// ComponentIntegrity.TPMGetSignedMeasurements action.
+type TPMGetSignedMeasurementsParameters struct {
+	// Certificate shall contain the URI of the resource of type Certificate
+	// that represents the certificate used to sign the quote.
+	Certificate string `json:"-"`
+	// Nonce shall contain a set of bytes as a hex-encoded string that is
+	// signed with the measurements.
+	Nonce string
+	// PCRSelection shall contain a Base64-encoded string, in binary format,
+	// containing the TPML_PCR_SELECTION object as defined by the TPM 2.0
+	// Library Specification.
+	PCRSelection string
+	// Scope shall contain a Base64-encoded string, in binary format,
+	// containing the TPM2B_NAME of the signing key.
+	Scope string `json:",omitempty"`
+}
+
+// TPMGetSignedMeasurementsResponse shall contain the TPM signed PCR
+// measurements, or quote, from a TPM.
+type TPMGetSignedMeasurementsResponse struct {
+	// SignedMeasurements shall contain a Base64-encoded cryptographic signed
+	// statement generated by the signer, the TPMS_ATTEST structure.
+	SignedMeasurements string
+	// SigningAlgorithm shall contain the asymmetric signing algorithm used
+	// to generate the signature.
+	SigningAlgorithm string
+}
+
+// ComponentIntegrity shall represent critical and pertinent security
+// information about a specific device, system, software element, or other
+// managed entity.
+type ComponentIntegrity struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ComponentIntegrityEnabled shall indicate whether security protocols are
+	// enabled for the component.
+	ComponentIntegrityEnabled bool
+	// ComponentIntegrityType shall contain the underlying security technology
+	// providing integrity information for the component.
+	ComponentIntegrityType ComponentIntegrityType
+	// ComponentIntegrityTypeVersion shall contain the version of the security
+	// technology indicated by the ComponentIntegrityType property.
+	ComponentIntegrityTypeVersion string
+	// Description provides a description of this resource.
+	Description string
+	// LastUpdated shall contain the date and time when information for the
+	// component was last updated.
+	LastUpdated common.DateTime
+	// SPDM shall contain integrity information about the SPDM Responder
+	// identified by the TargetComponentURI property as reported by an SPDM
+	// Requester.
+	SPDM SPDMinfo
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TPM shall contain integrity information about the Trusted Platform
+	// Module (TPM) identified by the TargetComponentURI property.
+	TPM TPMinfo
+	// TargetComponentURI shall contain a link to the resource whose integrity
+	// information is reported in this resource.
+	TargetComponentURI string
+	// componentsProtected are the resources that the target component
+	// protects.
+	componentsProtected []string
+	// spdmGetSignedMeasurementsTarget is the URL to send
+	// SPDMGetSignedMeasurements actions to.
+	spdmGetSignedMeasurementsTarget string
+	// tpmGetSignedMeasurementsTarget is the URL to send
+	// TPMGetSignedMeasurements actions to.
+	tpmGetSignedMeasurementsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (componentintegrity *ComponentIntegrity) GetRawData() []byte {
+	return componentintegrity.rawData
+}
+
+// UnmarshalJSON unmarshals a ComponentIntegrity object from the raw JSON.
+func (componentintegrity *ComponentIntegrity) UnmarshalJSON(b []byte) error {
+	type temp ComponentIntegrity
+	type Actions struct {
+		SPDMGetSignedMeasurements struct {
+			Target string
+		} `json:"#ComponentIntegrity.SPDMGetSignedMeasurements"`
+		TPMGetSignedMeasurements struct {
+			Target string
+		} `json:"#ComponentIntegrity.TPMGetSignedMeasurements"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+		Links   struct {
+			ComponentsProtected common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*componentintegrity = ComponentIntegrity(t.temp)
+
+	// Extract the links to other entities for later
+	componentintegrity.componentsProtected = t.Links.ComponentsProtected.ToStrings()
+	componentintegrity.spdmGetSignedMeasurementsTarget = t.Actions.SPDMGetSignedMeasurements.Target
+	componentintegrity.tpmGetSignedMeasurementsTarget = t.Actions.TPMGetSignedMeasurements.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	componentintegrity.rawData = b
+	componentintegrity.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the component integrity to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (componentintegrity ComponentIntegrity) MarshalJSON() ([]byte, error) {
+	type temp ComponentIntegrity
+	return common.MarshalWithRawData(componentintegrity.rawData, temp(componentintegrity))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (componentintegrity *ComponentIntegrity) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(ComponentIntegrity)
+	original.UnmarshalJSON(componentintegrity.rawData)
+
+	readWriteFields := []string{
+		"ComponentIntegrityEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(componentintegrity).Elem()
+
+	return componentintegrity.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// ComponentsProtected gets the URIs of the resources that the target
+// component protects.
+func (componentintegrity *ComponentIntegrity) ComponentsProtected() []string {
+	return componentintegrity.componentsProtected
+}
+
+// SPDMGetSignedMeasurements generates a cryptographic signed statement over
+// the given nonce and measurements corresponding to the SPDM Responder.
+func (componentintegrity *ComponentIntegrity) SPDMGetSignedMeasurements(parameters *SPDMGetSignedMeasurementsParameters) (*SPDMGetSignedMeasurementsResponse, error) {
+	if componentintegrity.spdmGetSignedMeasurementsTarget == "" {
+		return nil, fmt.Errorf("SPDMGetSignedMeasurements is not supported by this component")
+	}
+
+	resp, err := componentintegrity.Client.Post(componentintegrity.spdmGetSignedMeasurementsTarget, parameters)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result SPDMGetSignedMeasurementsResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// TPMGetSignedMeasurements generates a cryptographic signed statement over
+// the given nonce and PCRs of the TPM for TPM 2.0 devices.
+func (componentintegrity *ComponentIntegrity) TPMGetSignedMeasurements(parameters *TPMGetSignedMeasurementsParameters) (*TPMGetSignedMeasurementsResponse, error) {
+	if componentintegrity.tpmGetSignedMeasurementsTarget == "" {
+		return nil, fmt.Errorf("TPMGetSignedMeasurements is not supported by this component")
+	}
+
+	type certificate struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		*TPMGetSignedMeasurementsParameters
+		Certificate *certificate `json:",omitempty"`
+	}
+	t := temp{
+		TPMGetSignedMeasurementsParameters: parameters,
+	}
+	if parameters.Certificate != "" {
+		t.Certificate = &certificate{ODataID: parameters.Certificate}
+	}
+
+	resp, err := componentintegrity.Client.Post(componentintegrity.tpmGetSignedMeasurementsTarget, t)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result TPMGetSignedMeasurementsResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetComponentIntegrity will get a ComponentIntegrity instance from the service.
+func GetComponentIntegrity(c common.Client, uri string) (*ComponentIntegrity, error) {
+	var componentintegrity ComponentIntegrity
+	err := common.FetchResource(c, uri, &componentintegrity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &componentintegrity, nil
+}
+
+// ListReferencedComponentIntegrities gets the collection of ComponentIntegrity
+// from a provided reference.
+func ListReferencedComponentIntegrities(c common.Client, link string) ([]*ComponentIntegrity, error) {
+	var result []*ComponentIntegrity
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, componentintegrityLink := range links.ItemLinks {
+		componentintegrity := new(ComponentIntegrity)
+		expanded, err := links.DecodeMember(c, componentintegrityLink, componentintegrity)
+		if err == nil && !expanded {
+			componentintegrity, err = GetComponentIntegrity(c, componentintegrityLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, componentintegrity)
+	}
+
+	return result, nil
+}
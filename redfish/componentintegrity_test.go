@@ -0,0 +1,178 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var componentIntegrityBody = `{
+		"@odata.type": "#ComponentIntegrity.v1_2_0.ComponentIntegrity",
+		"@odata.id": "/redfish/v1/ComponentIntegrity/BMC",
+		"Id": "BMC",
+		"Name": "SPDM Integrity for BMC",
+		"ComponentIntegrityType": "SPDM",
+		"ComponentIntegrityTypeVersion": "1.1.0",
+		"ComponentIntegrityEnabled": true,
+		"TargetComponentURI": "/redfish/v1/Managers/BMC",
+		"LastUpdated": "2026-10-01T15:37:23-05:00",
+		"SPDM": {
+			"Requester": {
+				"@odata.id": "/redfish/v1/Managers/BMC"
+			},
+			"IdentityAuthentication": {
+				"ResponderAuthentication": {
+					"ComponentCertificate": {
+						"@odata.id": "/redfish/v1/Chassis/1/Certificates/BMC"
+					},
+					"VerificationStatus": "Success"
+				}
+			},
+			"MeasurementSet": {
+				"MeasurementSpecification": "DMTF",
+				"MeasurementSummaryType": "All",
+				"MeasurementSummaryHashAlgorithm": "TPM_ALG_SHA_512",
+				"Measurements": [
+					{
+						"MeasurementIndex": 0,
+						"MeasurementType": "ImmutableROM",
+						"Measurement": "Rm9vQmFy",
+						"MeasurementHashAlgorithm": "TPM_ALG_SHA_512",
+						"PartofSummaryHash": true
+					}
+				]
+			},
+			"ComponentCommunication": {
+				"Sessions": [
+					{
+						"SessionId": 1145,
+						"SessionType": "EncryptedAuthenticated"
+					}
+				]
+			}
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"ComponentsProtected": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC"
+				}
+			]
+		},
+		"Actions": {
+			"#ComponentIntegrity.SPDMGetSignedMeasurements": {
+				"target": "/redfish/v1/ComponentIntegrity/BMC/Actions/ComponentIntegrity.SPDMGetSignedMeasurements"
+			}
+		}
+	}`
+
+// signedMeasurementsClient is a test client that answers POST requests with
+// a canned SPDMGetSignedMeasurements response.
+type signedMeasurementsClient struct {
+	common.TestClient
+}
+
+// Post records the call and returns a signed measurements response.
+func (c *signedMeasurementsClient) Post(url string, payload interface{}) (*http.Response, error) {
+	c.TestClient.Post(url, payload)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(strings.NewReader(`{
+			"Version": "1.1.0",
+			"HashingAlgorithm": "TPM_ALG_SHA_512",
+			"SigningAlgorithm": "TPM_ALG_ECDSA_ECC_NIST_P384",
+			"SignedMeasurements": "c2lnbmVk",
+			"Certificate": {
+				"@odata.id": "/redfish/v1/Chassis/1/Certificates/BMC"
+			}
+		}`)),
+	}, nil
+}
+
+// TestComponentIntegrity tests the parsing of ComponentIntegrity objects.
+func TestComponentIntegrity(t *testing.T) {
+	var result ComponentIntegrity
+	err := json.NewDecoder(strings.NewReader(componentIntegrityBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BMC" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ComponentIntegrityType != SPDMComponentIntegrityType {
+		t.Errorf("Invalid component integrity type: %s", result.ComponentIntegrityType)
+	}
+
+	if result.SPDM.IdentityAuthentication.ResponderAuthentication.ComponentCertificate != "/redfish/v1/Chassis/1/Certificates/BMC" {
+		t.Errorf("Invalid responder certificate: %s",
+			result.SPDM.IdentityAuthentication.ResponderAuthentication.ComponentCertificate)
+	}
+
+	if len(result.SPDM.MeasurementSet.Measurements) != 1 ||
+		result.SPDM.MeasurementSet.Measurements[0].Measurement != "Rm9vQmFy" {
+		t.Errorf("Invalid measurements: %v", result.SPDM.MeasurementSet.Measurements)
+	}
+
+	if result.SPDM.ComponentCommunication.Sessions[0].SessionType != EncryptedAuthenticatedSecureSessionType {
+		t.Errorf("Invalid session type: %s", result.SPDM.ComponentCommunication.Sessions[0].SessionType)
+	}
+
+	if len(result.ComponentsProtected()) != 1 {
+		t.Errorf("Invalid components protected: %v", result.ComponentsProtected())
+	}
+}
+
+// TestComponentIntegritySPDMGetSignedMeasurements tests the
+// SPDMGetSignedMeasurements call.
+func TestComponentIntegritySPDMGetSignedMeasurements(t *testing.T) {
+	var result ComponentIntegrity
+	err := json.NewDecoder(strings.NewReader(componentIntegrityBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &signedMeasurementsClient{}
+	result.SetClient(testClient)
+
+	response, err := result.SPDMGetSignedMeasurements(&SPDMGetSignedMeasurementsParameters{
+		Nonce:  "86aeb7d4c2d5bca4ba2d8dfd8b8c99b5fd81aeb0d4af1c3e8f2f2b0ac6d3e7b1",
+		SlotID: 0,
+	})
+	if err != nil {
+		t.Errorf("Error making SPDMGetSignedMeasurements call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != result.spdmGetSignedMeasurementsTarget {
+		t.Errorf("Unexpected SPDMGetSignedMeasurements call: %v", calls)
+	}
+
+	if response.SignedMeasurements != "c2lnbmVk" {
+		t.Errorf("Invalid signed measurements: %s", response.SignedMeasurements)
+	}
+
+	if response.Certificate != "/redfish/v1/Chassis/1/Certificates/BMC" {
+		t.Errorf("Invalid certificate: %s", response.Certificate)
+	}
+
+	_, err = result.TPMGetSignedMeasurements(&TPMGetSignedMeasurementsParameters{})
+	if err == nil {
+		t.Error("TPMGetSignedMeasurements should fail when the action is not supported")
+	}
+}
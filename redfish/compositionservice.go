@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -70,10 +69,18 @@ func (compositionservice *CompositionService) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	compositionservice.rawData = b
+	compositionservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the composition service to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (compositionservice CompositionService) MarshalJSON() ([]byte, error) {
+	type temp CompositionService
+	return common.MarshalWithRawData(compositionservice.rawData, temp(compositionservice))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (compositionservice *CompositionService) Update() error {
 
@@ -95,25 +102,12 @@ func (compositionservice *CompositionService) Update() error {
 
 // GetCompositionService will get a CompositionService instance from the service.
 func GetCompositionService(c common.Client, uri string) (*CompositionService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var compositionservice CompositionService
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &compositionservice)
+	err := common.FetchResource(c, uri, &compositionservice)
 	if err != nil {
 		return nil, err
 	}
 
-	compositionservice.rawData = rawData
-	compositionservice.SetClient(c)
 	return &compositionservice, nil
 }
 
@@ -131,7 +125,11 @@ func ListReferencedCompositionServices(c common.Client, link string) ([]*Composi
 	}
 
 	for _, compositionserviceLink := range links.ItemLinks {
-		compositionservice, err := GetCompositionService(c, compositionserviceLink)
+		compositionservice := new(CompositionService)
+		expanded, err := links.DecodeMember(c, compositionserviceLink, compositionservice)
+		if err == nil && !expanded {
+			compositionservice, err = GetCompositionService(c, compositionserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
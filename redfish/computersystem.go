@@ -5,10 +5,11 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"reflect"
+	"time"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -194,6 +195,9 @@ const (
 	// PoweringOffPowerState A temporary state between On and Off. The power
 	// off action can take time while the OS is in the shutdown process.
 	PoweringOffPowerState PowerState = "PoweringOff"
+	// PowerCyclePowerState is used by the power control actions of outlets
+	// and circuits to turn the power off and back on.
+	PowerCyclePowerState PowerState = "PowerCycle"
 )
 
 // SystemType is the type of system.
@@ -323,6 +327,9 @@ type Boot struct {
 	// one time boot only. Changes to this property do not alter the BIOS
 	// persistent boot order configuration.
 	UefiTargetBootSourceOverride string `json:",omitempty"`
+	// allowedTargets are the boot source override targets the system
+	// advertises in its AllowableValues.
+	allowedTargets []BootSourceOverrideTarget
 }
 
 // UnmarshalJSON unmarshals a Boot object from the raw JSON.
@@ -330,7 +337,8 @@ func (boot *Boot) UnmarshalJSON(b []byte) error {
 	type temp Boot
 	var t struct {
 		temp
-		BootOptions common.Link
+		BootOptions    common.Link
+		AllowedTargets []BootSourceOverrideTarget `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -342,10 +350,26 @@ func (boot *Boot) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	boot.bootOptions = string(t.BootOptions)
+	boot.allowedTargets = t.AllowedTargets
 
 	return nil
 }
 
+// AllowedBootSourceOverrideTargets gets the boot source override targets the
+// system supports, or nil if it does not advertise them.
+func (boot *Boot) AllowedBootSourceOverrideTargets() []BootSourceOverrideTarget {
+	return boot.allowedTargets
+}
+
+// uefiOnlyBootSourceOverrideTargets are the boot source override targets that
+// can only be used in the UEFI boot mode.
+var uefiOnlyBootSourceOverrideTargets = []BootSourceOverrideTarget{
+	UefiShellBootSourceOverrideTarget,
+	UefiTargetBootSourceOverrideTarget,
+	UefiHTTPBootSourceOverrideTarget,
+	UefiBootNextBootSourceOverrideTarget,
+}
+
 // ResetType describe the type off reset to be issue by the resource
 type ResetType string
 
@@ -368,8 +392,58 @@ const (
 	PowerCycleResetType ResetType = "PowerCycle"
 	// NmiResetType shall be used to trigger a crash/core dump file
 	NmiResetType ResetType = "Nmi"
+	// SuspendResetType shall be used to write the state of the unit to disk
+	// before powering off, so it can be resumed later
+	SuspendResetType ResetType = "Suspend"
+	// PauseResetType shall be used to pause execution on the unit but not
+	// remove power
+	PauseResetType ResetType = "Pause"
+	// ResumeResetType shall be used to resume execution on the paused unit
+	ResumeResetType ResetType = "Resume"
+	// FullPowerCycleResetType shall be used to power cycle the unit, including
+	// the removal of auxiliary power
+	FullPowerCycleResetType ResetType = "FullPowerCycle"
 )
 
+// ErrorUnsupportedResetType is returned when a reset type is requested that
+// the resource does not advertise in its AllowableValues.
+type ErrorUnsupportedResetType struct {
+	// ResetType is the requested reset type.
+	ResetType ResetType
+	// Resource is the kind of resource the reset was requested on, such as
+	// "system".
+	Resource string
+	// Allowed are the reset types the resource supports.
+	Allowed []ResetType
+}
+
+// Error implements the error interface.
+func (e ErrorUnsupportedResetType) Error() string {
+	return fmt.Sprintf("reset type '%s' is not supported by this %s", e.ResetType, e.Resource)
+}
+
+// ValidateResetType checks a reset type against the reset types a resource
+// advertises, returning an ErrorUnsupportedResetType if it is not one of them.
+// Resources that do not advertise their reset types are assumed to support
+// any of them.
+func ValidateResetType(resetType ResetType, allowed []ResetType, resource string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, allowedType := range allowed {
+		if resetType == allowedType {
+			return nil
+		}
+	}
+
+	return ErrorUnsupportedResetType{
+		ResetType: resetType,
+		Resource:  resource,
+		Allowed:   allowed,
+	}
+}
+
 // ComputerSystem is used to represent resources that represent a
 // computing system in the Redfish specification.
 type ComputerSystem struct {
@@ -399,6 +473,9 @@ type ComputerSystem struct {
 	// EthernetInterfaces shall be a link to a
 	// collection of type EthernetInterfaceCollection.
 	ethernetInterfaces string
+	// GraphicalConsole shall contain the information about the graphical
+	// console (KVM-IP) service of this system.
+	GraphicalConsole HostGraphicalConsole
 	// HostName shall be the host name for this
 	// system, as reported by the operating system or hypervisor. This value
 	// is typically provided to the Manager by a service running in the host
@@ -416,8 +493,14 @@ type ComputerSystem struct {
 	// IndicatorLED shall contain the indicator
 	// light state for the indicator light associated with this system.
 	IndicatorLED common.IndicatorLED
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
 	// logServices shall be a link to a collection of type LogServiceCollection.
 	logServices string
+	// graphicsControllers shall be a link to a collection of type
+	// GraphicsControllerCollection.
+	graphicsControllers string
 	// Manufacturer shall contain a value that represents the manufacturer of the system.
 	Manufacturer string
 	// Memory shall be a link to a collection of type MemoryCollection.
@@ -433,6 +516,9 @@ type ComputerSystem struct {
 	Model string
 	// Name is the resource name.
 	Name string
+	// Oem contains the OEM extensions of the system, decoded by the
+	// decoders registered with common.RegisterOEMDecoder.
+	Oem common.OEM
 	// networkInterfaces shall be a link to a collection of type
 	// NetworkInterfaceCollection.
 	networkInterfaces string
@@ -470,6 +556,9 @@ type ComputerSystem struct {
 	SKU string
 	// secureBoot shall be a link to a resource of type SecureBoot.
 	secureBoot string
+	// SerialConsole shall contain information about the serial console
+	// services of this system.
+	SerialConsole HostSerialConsole
 	// SerialNumber shall contain the serial number for the system.
 	SerialNumber string
 	// SimpleStorage shall be a link to a collection of type SimpleStorageCollection.
@@ -489,6 +578,9 @@ type ComputerSystem struct {
 	// TrustedModules shall contain an array of objects with
 	// properties which describe the trusted modules for the current resource.
 	TrustedModules []TrustedModules
+	// usbControllers shall be a link to a collection of type
+	// USBControllerCollection.
+	usbControllers string
 	// UUID is used to contain a universal unique identifier number for the
 	// system. RFC4122 describes methods that can be used to create the
 	// value. The value should be considered to be opaque. Client software
@@ -502,12 +594,21 @@ type ComputerSystem struct {
 	UUID string
 	// Chassis is an array of references to the chassis in which this system is contained.
 	chassis []string
+	// managedBy is an array of references to the managers responsible for
+	// this system.
+	managedBy []string
+	// virtualMedia shall be a link to a collection of type
+	// VirtualMediaCollection that this system uses.
+	virtualMedia string
 	// resetTarget is the internal URL to send reset targets to.
 	resetTarget string
 	// SupportedResetTypes, if provided, is the reset types this system supports.
 	SupportedResetTypes []ResetType
 	// setDefaultBootOrderTarget is the URL to send SetDefaultBootOrder actions to.
 	setDefaultBootOrderTarget string
+	// settingsTarget is the URL of the settings resource that some services
+	// require changes of the system, such as boot overrides, to be sent to.
+	settingsTarget string
 	// rawData holds the original serialized JSON
 	rawData []byte
 }
@@ -545,7 +646,15 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 		MemoryDomains      common.Link
 		PCIeDevices        common.Links
 		PCIeFunctions      common.Links
+		USBControllers     common.Link
+		VirtualMedia       common.Link
 		Links              CSLinks
+		// GraphicsControllers is the collection of graphics controllers
+		// that can output video for this system.
+		GraphicsControllers common.Link
+		Settings            struct {
+			SettingsObject common.Link
+		} `json:"@Redfish.Settings"`
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -568,17 +677,30 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 	computersystem.memoryDomains = string(t.MemoryDomains)
 	computersystem.pcieDevices = t.PCIeDevices.ToStrings()
 	computersystem.pcieFunctions = t.PCIeFunctions.ToStrings()
+	computersystem.usbControllers = string(t.USBControllers)
+	computersystem.graphicsControllers = string(t.GraphicsControllers)
 	computersystem.chassis = t.Links.Chassis.ToStrings()
+	computersystem.managedBy = t.Links.ManagedBy.ToStrings()
+	computersystem.virtualMedia = string(t.VirtualMedia)
 	computersystem.resetTarget = t.Actions.ComputerSystemReset.Target
 	computersystem.SupportedResetTypes = t.Actions.ComputerSystemReset.AllowedResetTypes
 	computersystem.setDefaultBootOrderTarget = t.Actions.SetDefaultBootOrder.Target
+	computersystem.settingsTarget = string(t.Settings.SettingsObject)
 
 	// This is a read/write object, so we need to save the raw object data for later
 	computersystem.rawData = b
+	computersystem.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the computer system to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (computersystem ComputerSystem) MarshalJSON() ([]byte, error) {
+	type temp ComputerSystem
+	return common.MarshalWithRawData(computersystem.rawData, temp(computersystem))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (computersystem *ComputerSystem) Update() error {
 	// Get a representation of the object's original state so we can find what
@@ -590,36 +712,35 @@ func (computersystem *ComputerSystem) Update() error {
 		"AssetTag",
 		"HostName",
 		"IndicatorLED",
+		"LocationIndicatorActive",
 		"PowerRestorePolicy",
 	}
 
 	originalElement := reflect.ValueOf(cs).Elem()
 	currentElement := reflect.ValueOf(computersystem).Elem()
 
-	return computersystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	// The host watchdog timer is a nested object, which the generic update
+	// skips, so its writable properties are added to the payload.
+	nested := make(map[string]interface{})
+	timer := changedFields(
+		reflect.ValueOf(cs.HostWatchdogTimer),
+		reflect.ValueOf(computersystem.HostWatchdogTimer),
+		"FunctionEnabled", "TimeoutAction", "WarningAction")
+	if len(timer) > 0 {
+		nested["HostWatchdogTimer"] = timer
+	}
+
+	return computersystem.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
 }
 
 // GetComputerSystem will get a ComputerSystem instance from the service.
 func GetComputerSystem(c common.Client, uri string) (*ComputerSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var computersystem ComputerSystem
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &computersystem)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &computersystem)
-	if err != nil {
-		return nil, err
-	}
-
-	computersystem.rawData = rawData
-	computersystem.SetClient(c)
 	return &computersystem, nil
 }
 
@@ -633,7 +754,11 @@ func ListReferencedComputerSystems(c common.Client, link string) ([]*ComputerSys
 	}
 
 	for _, computersystemLink := range links.ItemLinks {
-		computersystem, err := GetComputerSystem(c, computersystemLink)
+		computersystem := new(ComputerSystem)
+		expanded, err := links.DecodeMember(c, computersystemLink, computersystem)
+		if err == nil && !expanded {
+			computersystem, err = GetComputerSystem(c, computersystemLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -657,6 +782,11 @@ func (computersystem *ComputerSystem) EthernetInterfaces() ([]*EthernetInterface
 	return ListReferencedEthernetInterfaces(computersystem.Client, computersystem.ethernetInterfaces)
 }
 
+// GraphicsControllers gets the graphics controllers of this system.
+func (computersystem *ComputerSystem) GraphicsControllers() ([]*GraphicsController, error) {
+	return ListReferencedGraphicsControllers(computersystem.Client, computersystem.graphicsControllers)
+}
+
 // LogServices get this system's log services.
 func (computersystem *ComputerSystem) LogServices() ([]*LogService, error) {
 	return ListReferencedLogServices(computersystem.Client, computersystem.logServices)
@@ -667,6 +797,16 @@ func (computersystem *ComputerSystem) Memory() ([]*Memory, error) {
 	return ListReferencedMemorys(computersystem.Client, computersystem.memory)
 }
 
+// MemorySummaryMetrics gets the metrics summarizing all memory of this
+// system, as linked from its MemorySummary.
+func (computersystem *ComputerSystem) MemorySummaryMetrics() (*MemoryMetrics, error) {
+	if computersystem.MemorySummary.metrics == "" {
+		return nil, nil
+	}
+
+	return GetMemoryMetrics(computersystem.Client, computersystem.MemorySummary.metrics)
+}
+
 // MemoryDomains gets this system's memory domains.
 func (computersystem *ComputerSystem) MemoryDomains() ([]*MemoryDomain, error) {
 	return ListReferencedMemoryDomains(computersystem.Client, computersystem.memoryDomains)
@@ -703,6 +843,16 @@ func (computersystem *ComputerSystem) PCIeFunctions() ([]*PCIeFunction, error) {
 	return result, nil
 }
 
+// ProcessorSummaryMetrics gets the metrics summarizing all processors of this
+// system, as linked from its ProcessorSummary.
+func (computersystem *ComputerSystem) ProcessorSummaryMetrics() (*ProcessorMetrics, error) {
+	if computersystem.ProcessorSummary.metrics == "" {
+		return nil, nil
+	}
+
+	return GetProcessorMetrics(computersystem.Client, computersystem.ProcessorSummary.metrics)
+}
+
 // Processors returns a collection of processors from this system
 func (computersystem *ComputerSystem) Processors() ([]*Processor, error) {
 	return ListReferencedProcessors(computersystem.Client, computersystem.processors)
@@ -730,6 +880,90 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error {
 	return err
 }
 
+// BootOnce boots the system once from a boot source override target, by
+// setting the boot override for the next boot and resetting the system.
+//
+// The boot mode is switched to UEFI for the targets only UEFI supports, when
+// the system is set to Legacy. The override is sent to the settings resource
+// of the system if it has one, as some vendors require. For the UefiTarget
+// and UefiBootNext targets, the UefiTargetBootSourceOverride and BootNext
+// values of the system's Boot are used. A system that is off is powered on
+// rather than restarted.
+func (computersystem *ComputerSystem) BootOnce(target BootSourceOverrideTarget, resetType ResetType) error {
+	if computersystem.PowerState == OffPowerState &&
+		(resetType == ForceRestartResetType || resetType == GracefulRestartResetType || resetType == PowerCycleResetType) {
+		resetType = OnResetType
+	}
+
+	// Check everything before changing the boot override
+	err := ValidateResetType(resetType, computersystem.SupportedResetTypes, "system")
+	if err != nil {
+		return err
+	}
+
+	allowed := computersystem.Boot.allowedTargets
+	if len(allowed) > 0 {
+		supported := false
+		for _, allowedTarget := range allowed {
+			supported = supported || allowedTarget == target
+		}
+		if !supported {
+			return fmt.Errorf("boot source override target '%s' is not supported by this system", target)
+		}
+	}
+
+	boot := Boot{
+		BootSourceOverrideTarget:  target,
+		BootSourceOverrideEnabled: OnceBootSourceOverrideEnabled,
+	}
+
+	switch target {
+	case UefiTargetBootSourceOverrideTarget:
+		if computersystem.Boot.UefiTargetBootSourceOverride == "" {
+			return fmt.Errorf("a UefiTargetBootSourceOverride is required to boot from a UEFI target")
+		}
+		boot.UefiTargetBootSourceOverride = computersystem.Boot.UefiTargetBootSourceOverride
+	case UefiBootNextBootSourceOverrideTarget:
+		if !computersystem.SchemaVersionAtLeast(1, 5) {
+			// BootNext came with ComputerSystem v1.5
+			return fmt.Errorf("booting from the next UEFI boot option is not supported by this system")
+		}
+		if computersystem.Boot.BootNext == "" {
+			return fmt.Errorf("a BootNext is required to boot from the next UEFI boot option")
+		}
+		boot.BootNext = computersystem.Boot.BootNext
+	}
+
+	if computersystem.Boot.BootSourceOverrideMode == LegacyBootSourceOverrideMode {
+		for _, uefiTarget := range uefiOnlyBootSourceOverrideTargets {
+			if target == uefiTarget {
+				boot.BootSourceOverrideMode = UEFIBootSourceOverrideMode
+			}
+		}
+	}
+
+	settingsTarget := computersystem.settingsTarget
+	if settingsTarget == "" {
+		settingsTarget = computersystem.ODataID
+	}
+
+	type temp struct {
+		Boot Boot
+	}
+	_, err = computersystem.Client.Patch(settingsTarget, temp{Boot: boot})
+	if err != nil {
+		return err
+	}
+
+	computersystem.Boot.BootSourceOverrideTarget = boot.BootSourceOverrideTarget
+	computersystem.Boot.BootSourceOverrideEnabled = boot.BootSourceOverrideEnabled
+	if boot.BootSourceOverrideMode != "" {
+		computersystem.Boot.BootSourceOverrideMode = boot.BootSourceOverrideMode
+	}
+
+	return computersystem.Reset(resetType)
+}
+
 // Reset shall perform a reset of the ComputerSystem. For systems which implement
 // ACPI Power Button functionality, the PushPowerButton value shall perform or
 // emulate an ACPI Power Button push. The ForceOff value shall remove power from
@@ -738,22 +972,9 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error {
 // ForceOff action followed by a On action.
 func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
 	// Make sure the requested reset type is supported by the system
-	valid := false
-	if len(computersystem.SupportedResetTypes) > 0 {
-		for _, allowed := range computersystem.SupportedResetTypes {
-			if resetType == allowed {
-				valid = true
-				break
-			}
-		}
-	} else {
-		// No allowed values supplied, assume we are OK
-		valid = true
-	}
-
-	if !valid {
-		return fmt.Errorf("reset type '%s' is not supported by this service",
-			resetType)
+	err := ValidateResetType(resetType, computersystem.SupportedResetTypes, "system")
+	if err != nil {
+		return err
 	}
 
 	type temp struct {
@@ -763,10 +984,128 @@ func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	_, err := computersystem.Client.Post(computersystem.resetTarget, t)
+	_, err = computersystem.Client.Post(computersystem.resetTarget, t)
 	return err
 }
 
+// PowerStateOptions controls how EnsurePowerState changes the power state of
+// a system.
+type PowerStateOptions struct {
+	// Interval is the time between the polls of the power state. It defaults
+	// to 5 seconds.
+	Interval time.Duration
+	// ForceOffAfter, if set, turns the system off with a ForceOff reset when
+	// a GracefulShutdown has not turned it off after this time, such as
+	// when the operating system ignores the shutdown request.
+	ForceOffAfter time.Duration
+	// Force, if set, turns the system off with a ForceOff reset rather than
+	// a GracefulShutdown.
+	Force bool
+}
+
+// EnsurePowerState brings the system to the On or Off power state, and polls
+// it until the state is reached or the context is done. No reset is issued if
+// the system already is in the state or transitioning to it. A system is
+// turned off with a GracefulShutdown, unless the options ask for a ForceOff or
+// the system does not support it.
+func (computersystem *ComputerSystem) EnsurePowerState(ctx context.Context, desired PowerState, options PowerStateOptions) error {
+	if desired != OnPowerState && desired != OffPowerState {
+		return fmt.Errorf("power state '%s' can not be requested, only On or Off", desired)
+	}
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Second
+	}
+
+	state, err := computersystem.pollPowerState()
+	if err != nil {
+		return err
+	}
+	if state == desired {
+		return nil
+	}
+
+	transitioning := (desired == OnPowerState && state == PoweringOnPowerState) ||
+		(desired == OffPowerState && state == PoweringOffPowerState)
+
+	var resetType ResetType
+	switch {
+	case desired == OnPowerState:
+		resetType = computersystem.firstSupportedResetType(OnResetType, ForceOnResetType, PushPowerButtonResetType)
+	case options.Force:
+		resetType = ForceOffResetType
+	default:
+		resetType = computersystem.firstSupportedResetType(GracefulShutdownResetType, ForceOffResetType)
+	}
+
+	if !transitioning {
+		err = computersystem.Reset(resetType)
+		if err != nil {
+			return err
+		}
+	}
+
+	var forceOff <-chan time.Time
+	if resetType == GracefulShutdownResetType && options.ForceOffAfter > 0 {
+		forceOff = time.After(options.ForceOffAfter)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-forceOff:
+			forceOff = nil
+			err = computersystem.Reset(ForceOffResetType)
+			if err != nil {
+				return err
+			}
+			continue
+		case <-time.After(options.Interval):
+		}
+
+		state, err = computersystem.pollPowerState()
+		if err != nil {
+			return err
+		}
+		if state == desired {
+			return nil
+		}
+	}
+}
+
+// pollPowerState reads the current power state of the system from the
+// service.
+func (computersystem *ComputerSystem) pollPowerState() (PowerState, error) {
+	resp, err := computersystem.Client.Get(computersystem.ODataID)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var t struct {
+		PowerState PowerState
+	}
+	err = json.NewDecoder(resp.Body).Decode(&t)
+	if err != nil {
+		return "", err
+	}
+
+	computersystem.PowerState = t.PowerState
+	return t.PowerState, nil
+}
+
+// firstSupportedResetType gets the first of the reset types the system
+// supports, or the first one if none is.
+func (computersystem *ComputerSystem) firstSupportedResetType(resetTypes ...ResetType) ResetType {
+	for _, resetType := range resetTypes {
+		if ValidateResetType(resetType, computersystem.SupportedResetTypes, "system") == nil {
+			return resetType
+		}
+	}
+
+	return resetTypes[0]
+}
+
 // SetDefaultBootOrder shall set the BootOrder array to the default settings.
 func (computersystem *ComputerSystem) SetDefaultBootOrder() error {
 	// This action wasn't added until 1.5.0, make sure this is supported.
@@ -788,6 +1127,48 @@ func (computersystem *ComputerSystem) Storage() ([]*Storage, error) {
 	return ListReferencedStorages(computersystem.Client, computersystem.storage)
 }
 
+// ManagedBy gets the managers responsible for this system.
+func (computersystem *ComputerSystem) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+	for _, uri := range computersystem.managedBy {
+		manager, err := GetManager(computersystem.Client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// Chassis gets the chassis containing this system.
+func (computersystem *ComputerSystem) Chassis() ([]*Chassis, error) {
+	var result []*Chassis
+	for _, uri := range computersystem.chassis {
+		chassis, err := GetChassis(computersystem.Client, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, chassis)
+	}
+
+	return result, nil
+}
+
+// VirtualMedia gets the virtual media collection scoped to this system.
+// Services implementing Redfish 1.13 or later may expose virtual media here
+// instead of, or in addition to, the manager of the system.
+func (computersystem *ComputerSystem) VirtualMedia() ([]*VirtualMedia, error) {
+	return ListReferencedVirtualMedia(computersystem.Client, computersystem.virtualMedia)
+}
+
+// USBControllers gets the USB controllers of this system.
+func (computersystem *ComputerSystem) USBControllers() ([]*USBController, error) {
+	return ListReferencedUSBControllers(computersystem.Client, computersystem.usbControllers)
+}
+
 // CSLinks are references to resources that are related to, but not contained
 // by (subordinate to), this resource.
 type CSLinks struct {
@@ -843,6 +1224,9 @@ type CSLinks struct {
 type MemorySummary struct {
 	// MemoryMirroring is the ability and type of memory mirroring supported by this system.
 	MemoryMirroring MemoryMirroring
+	// metrics is a link to the MemoryMetrics summarizing all memory of the
+	// system.
+	metrics string
 	// Status is the status or health properties of the resource.
 	Status common.Status
 	// TotalSystemMemoryGiB is the amount of configured system general purpose
@@ -853,19 +1237,78 @@ type MemorySummary struct {
 	TotalSystemPersistentMemoryGiB float32
 }
 
+// UnmarshalJSON unmarshals a MemorySummary object from the raw JSON.
+func (memorysummary *MemorySummary) UnmarshalJSON(b []byte) error {
+	type temp MemorySummary
+	var t struct {
+		temp
+		Metrics common.Link
+
+		// Some implementations send these with the wrong JSON type
+		TotalSystemMemoryGiB           common.Float32
+		TotalSystemPersistentMemoryGiB common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*memorysummary = MemorySummary(t.temp)
+	memorysummary.TotalSystemMemoryGiB = float32(t.TotalSystemMemoryGiB)
+	memorysummary.TotalSystemPersistentMemoryGiB = float32(t.TotalSystemPersistentMemoryGiB)
+	memorysummary.metrics = string(t.Metrics)
+
+	return nil
+}
+
 // ProcessorSummary is This type shall contain properties which describe
 // the central processors for a system.
 type ProcessorSummary struct {
+	// CoreCount is the number of processor cores in the system.
+	CoreCount int
 	// Count is the number of physical central processors in the system.
 	Count int
 	// LogicalProcessorCount is the number of logical central processors in the system.
 	LogicalProcessorCount int
+	// metrics is a link to the ProcessorMetrics summarizing all processors
+	// of the system.
+	metrics string
 	// Model is the processor model for the central processors in the system,
 	// per the description in the Processor Information - Processor Family
 	// section of the SMBIOS Specification DSP0134 2.8 or later.
 	Model string
 	// Status is any status or health properties of the resource.
 	Status common.Status
+	// ThreadingEnabled shall indicate that all Processor resources in this
+	// system where the ProcessorType property contains CPU have multiple
+	// threading support enabled.
+	ThreadingEnabled bool
+}
+
+// UnmarshalJSON unmarshals a ProcessorSummary object from the raw JSON.
+func (processorsummary *ProcessorSummary) UnmarshalJSON(b []byte) error {
+	type temp ProcessorSummary
+	var t struct {
+		temp
+		Metrics common.Link
+
+		// Some implementations send these with the wrong JSON type
+		Count                 common.Int
+		LogicalProcessorCount common.Int
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*processorsummary = ProcessorSummary(t.temp)
+	processorsummary.Count = int(t.Count)
+	processorsummary.LogicalProcessorCount = int(t.LogicalProcessorCount)
+	processorsummary.metrics = string(t.Metrics)
+
+	return nil
 }
 
 // TrustedModules is This type shall describe a trusted module for a system.
@@ -903,10 +1346,10 @@ type WatchdogTimer struct {
 	}
 	// TimeoutAction is the action to perform
 	// upon the  expiration of the Watchdog Timer.
-	TimeoutAction string
+	TimeoutAction WatchdogTimeoutActions
 	// WarningAction is the action to perform
 	// prior to the expiration of the Watchdog Timer. This action typically
 	// occurs 3-10 seconds prior to the timeout value, but the exact timing
 	// is dependent on the implementation.
-	WarningAction string
+	WarningAction WatchdogWarningActions
 }
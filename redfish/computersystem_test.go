@@ -5,9 +5,15 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -35,6 +41,14 @@ var computerSystemBody = `{
 		},
 		"IndicatorLED": "Off",
 		"PowerState": "On",
+		"HostWatchdogTimer": {
+			"FunctionEnabled": false,
+			"TimeoutAction": "None",
+			"WarningAction": "None",
+			"Status": {
+				"State": "Disabled"
+			}
+		},
 		"Boot": {
 			"BootSourceOverrideEnabled": "Once",
 			"BootSourceOverrideMode": "UEFI",
@@ -62,7 +76,13 @@ var computerSystemBody = `{
 				"State": "Enabled"
 			},
 			"Count": 2,
-			"Model": "Multi-Core Intel(R) Xeon(R) processor 7500 Series"
+			"CoreCount": 16,
+			"LogicalProcessorCount": 32,
+			"ThreadingEnabled": true,
+			"Model": "Multi-Core Intel(R) Xeon(R) processor 7500 Series",
+			"Metrics": {
+				"@odata.id": "/redfish/v1/Systems/System-1/ProcessorSummary/ProcessorMetrics"
+			}
 		},
 		"MemorySummary": {
 			"Status": {
@@ -70,7 +90,10 @@ var computerSystemBody = `{
 				"State": "Enabled"
 			},
 			"TotalSystemMemoryGiB": 65536,
-			"TotalSystemPersistentMemoryGiB": 262144
+			"TotalSystemPersistentMemoryGiB": 262144,
+			"Metrics": {
+				"@odata.id": "/redfish/v1/Systems/System-1/MemorySummary/MemoryMetrics"
+			}
 		},
 		"TrustedModules": [
 			{
@@ -96,6 +119,15 @@ var computerSystemBody = `{
 		"SimpleStorage": {
 			"@odata.id": "/redfish/v1/Systems/System-1/SimpleStorage"
 		},
+		"USBControllers": {
+			"@odata.id": "/redfish/v1/Systems/System-1/USBControllers"
+		},
+		"GraphicsControllers": {
+			"@odata.id": "/redfish/v1/Systems/System-1/GraphicsControllers"
+		},
+		"VirtualMedia": {
+			"@odata.id": "/redfish/v1/Systems/System-1/VirtualMedia"
+		},
 		"Links": {
 			"Chassis": [
 				{
@@ -124,6 +156,11 @@ var computerSystemBody = `{
 			},
 			"#ComputerSystem.SetDefaultBootOrder": {
 				"target": "/redfish/v1/Systems/System-1/Actions/ComputerSystem.SetDefaultBootOrder"
+			},
+			"Oem": {
+				"#Contoso.ClearNvram": {
+					"target": "/redfish/v1/Systems/System-1/Actions/Oem/Contoso.ClearNvram"
+				}
 			}
 		}
 	}`
@@ -189,6 +226,18 @@ func TestComputerSystem(t *testing.T) {
 		t.Errorf("Received invalid processor count: %d", result.ProcessorSummary.Count)
 	}
 
+	if result.ProcessorSummary.CoreCount != 16 || !result.ProcessorSummary.ThreadingEnabled {
+		t.Errorf("Received invalid processor summary: %v", result.ProcessorSummary)
+	}
+
+	if result.ProcessorSummary.metrics != "/redfish/v1/Systems/System-1/ProcessorSummary/ProcessorMetrics" {
+		t.Errorf("Received invalid processor summary metrics: %s", result.ProcessorSummary.metrics)
+	}
+
+	if result.MemorySummary.metrics != "/redfish/v1/Systems/System-1/MemorySummary/MemoryMetrics" {
+		t.Errorf("Received invalid memory summary metrics: %s", result.MemorySummary.metrics)
+	}
+
 	if result.MemorySummary.Status.State != common.EnabledState {
 		t.Errorf("Received invalid memory summary state: %s", result.MemorySummary.Status.State)
 	}
@@ -232,6 +281,18 @@ func TestComputerSystem(t *testing.T) {
 		t.Errorf("Received invalid simple storage reference: %s", result.simpleStorage)
 	}
 
+	if result.usbControllers != "/redfish/v1/Systems/System-1/USBControllers" {
+		t.Errorf("Received invalid USB controllers reference: %s", result.usbControllers)
+	}
+
+	if result.graphicsControllers != "/redfish/v1/Systems/System-1/GraphicsControllers" {
+		t.Errorf("Received invalid graphics controllers reference: %s", result.graphicsControllers)
+	}
+
+	if result.virtualMedia != "/redfish/v1/Systems/System-1/VirtualMedia" {
+		t.Errorf("Received invalid virtual media reference: %s", result.virtualMedia)
+	}
+
 	if len(result.chassis) != 1 {
 		t.Errorf("Received invalid number of chassis: %d", len(result.chassis))
 	}
@@ -285,3 +346,342 @@ func TestComputerSystemUpdate(t *testing.T) {
 		t.Errorf("Unexpected IndicatorLED update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestComputerSystemUpdateHostWatchdogTimer tests updating the host watchdog
+// timer through the Update call.
+func TestComputerSystemUpdateHostWatchdogTimer(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.HostWatchdogTimer.TimeoutAction != NoneWatchdogTimeoutActions {
+		t.Errorf("Invalid watchdog timeout action: %s", result.HostWatchdogTimer.TimeoutAction)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.AssetTag = "TestAssetTag"
+	result.HostWatchdogTimer.FunctionEnabled = true
+	result.HostWatchdogTimer.TimeoutAction = PowerCycleWatchdogTimeoutActions
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if calls[0].Payload != "map[AssetTag:TestAssetTag HostWatchdogTimer:map[FunctionEnabled:true TimeoutAction:PowerCycle]]" {
+		t.Errorf("Unexpected HostWatchdogTimer update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestComputerSystemUpdateWriteableProperties tests the host watchdog timer
+// is checked against the @Redfish.WriteableProperties annotation.
+func TestComputerSystemUpdateWriteableProperties(t *testing.T) {
+	common.CheckWriteableProperties = true
+	defer func() { common.CheckWriteableProperties = false }()
+
+	body := strings.Replace(computerSystemBody, `"HostWatchdogTimer": {`,
+		`"@Redfish.WriteableProperties": ["AssetTag"],
+		"HostWatchdogTimer": {`, 1)
+
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.HostWatchdogTimer.FunctionEnabled = true
+	err = result.Update()
+
+	if _, ok := err.(common.ErrorUnwritableProperties); !ok {
+		t.Errorf("Expected an unwritable properties error, got: %v", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Nothing should be sent: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestComputerSystemReset tests that Reset checks the advertised reset types.
+func TestComputerSystemReset(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	err = result.Reset(FullPowerCycleResetType)
+
+	var unsupported ErrorUnsupportedResetType
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Expected an unsupported reset type error: %v", err)
+	}
+
+	if unsupported.ResetType != FullPowerCycleResetType || len(unsupported.Allowed) != 6 {
+		t.Errorf("Unexpected error details: %+v", unsupported)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Payload != "{ForceRestart}" {
+		t.Errorf("Only the supported reset should be sent: %v", calls)
+	}
+}
+
+// TestComputerSystemBootOnce tests booting once from an override target.
+func TestComputerSystemBootOnce(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.BootOnce(UefiShellBootSourceOverrideTarget, ForceRestartResetType)
+	if err == nil {
+		t.Error("Booting from a target the system does not allow should fail")
+	}
+
+	result.Boot.BootSourceOverrideMode = LegacyBootSourceOverrideMode
+	result.settingsTarget = "/redfish/v1/Systems/System-1/Settings"
+	err = result.BootOnce(UefiHTTPBootSourceOverrideTarget, ForceRestartResetType)
+	if err != nil {
+		t.Fatalf("Error making BootOnce call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected a boot override and a reset: %v", calls)
+	}
+
+	if calls[0].URL != "/redfish/v1/Systems/System-1/Settings" {
+		t.Errorf("Boot override should be sent to the settings resource: %v", calls[0])
+	}
+
+	if !strings.Contains(calls[0].Payload, "Once UEFI UefiHttp") {
+		t.Errorf("Unexpected boot override payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].URL != result.resetTarget || calls[1].Payload != "{ForceRestart}" {
+		t.Errorf("Unexpected reset call: %v", calls[1])
+	}
+
+	if result.Boot.BootSourceOverrideMode != UEFIBootSourceOverrideMode {
+		t.Errorf("Boot mode should be updated: %s", result.Boot.BootSourceOverrideMode)
+	}
+}
+
+// TestComputerSystemBootOnceSchemaVersion tests not booting from the next
+// UEFI boot option on systems predating BootNext.
+func TestComputerSystemBootOnceSchemaVersion(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/System-1": computerSystemBody,
+	}}
+
+	result, err := GetComputerSystem(testClient, "/redfish/v1/Systems/System-1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+
+	if version, ok := result.SchemaVersion(); !ok || version.String() != "1.3.0" {
+		t.Errorf("Invalid schema version: %v %t", version, ok)
+	}
+
+	result.Boot.allowedTargets = nil
+	result.Boot.BootNext = "0001"
+	err = result.BootOnce(UefiBootNextBootSourceOverrideTarget, ForceRestartResetType)
+	if err == nil {
+		t.Error("Booting from BootNext should fail on a ComputerSystem v1.3")
+	}
+
+	if calls := testClient.CapturedCalls(); len(calls) != 1 {
+		t.Errorf("Nothing should be sent to the system: %v", calls)
+	}
+}
+
+// TestComputerSystemEnsurePowerState tests powering a system on and off.
+func TestComputerSystemEnsurePowerState(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &taskPollClient{bodies: []string{
+		`{"PowerState": "Off"}`,
+		`{"PowerState": "PoweringOn"}`,
+		`{"PowerState": "On"}`,
+	}}
+	result.SetClient(testClient)
+
+	err = result.EnsurePowerState(context.Background(), OnPowerState, PowerStateOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Error powering on: %s", err)
+	}
+
+	if result.PowerState != OnPowerState {
+		t.Errorf("Invalid power state: %s", result.PowerState)
+	}
+
+	var resets []string
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == "POST" {
+			resets = append(resets, call.Payload)
+		}
+	}
+
+	if len(resets) != 1 || resets[0] != "{On}" {
+		t.Errorf("Unexpected resets: %v", resets)
+	}
+
+	// The system is already on
+	testClient.Reset()
+	err = result.EnsurePowerState(context.Background(), OnPowerState, PowerStateOptions{Interval: time.Millisecond})
+	if err != nil || len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("A system that is on should not be reset: %v %v", err, testClient.CapturedCalls())
+	}
+}
+
+// TestComputerSystemEnsurePowerStateForceOff tests escalating a graceful
+// shutdown the system ignores.
+func TestComputerSystemEnsurePowerStateForceOff(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result.SupportedResetTypes = append(result.SupportedResetTypes, GracefulShutdownResetType)
+	testClient := &forceOffClient{}
+	result.SetClient(testClient)
+
+	err = result.EnsurePowerState(context.Background(), OffPowerState, PowerStateOptions{
+		Interval:      time.Millisecond,
+		ForceOffAfter: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Error powering off: %s", err)
+	}
+
+	if len(testClient.resets) != 2 || testClient.resets[0] != "{GracefulShutdown}" || testClient.resets[1] != "{ForceOff}" {
+		t.Errorf("Unexpected resets: %v", testClient.resets)
+	}
+
+	// A system that never turns off
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result.SetClient(&taskPollClient{bodies: []string{`{"PowerState": "On"}`}})
+
+	err = result.EnsurePowerState(ctx, OffPowerState, PowerStateOptions{Interval: time.Millisecond})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the context error: %v", err)
+	}
+}
+
+// forceOffClient is a test client for a system that only turns off with a
+// ForceOff reset.
+type forceOffClient struct {
+	common.TestClient
+	resets []string
+}
+
+// Get records the call and returns the power state of the system.
+func (c *forceOffClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+	state := OnPowerState
+	if len(c.resets) > 0 && c.resets[len(c.resets)-1] == "{ForceOff}" {
+		state = OffPowerState
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"PowerState": "%s"}`, state))),
+	}, nil
+}
+
+// Post records the reset.
+func (c *forceOffClient) Post(url string, payload interface{}) (*http.Response, error) {
+	c.TestClient.Post(url, payload)
+	c.resets = append(c.resets, fmt.Sprintf("%v", payload))
+	return nil, nil
+}
+
+// TestComputerSystemActions tests discovering and invoking the advertised
+// actions.
+func TestComputerSystemActions(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	names := strings.Join(result.ActionNames(), ",")
+	if names != "ComputerSystem.Reset,ComputerSystem.SetDefaultBootOrder,Contoso.ClearNvram" {
+		t.Errorf("Invalid actions: %s", names)
+	}
+
+	reset := result.Actions()["ComputerSystem.Reset"]
+	if reset.ActionInfo != "/redfish/v1/Systems/System-1/ResetActionInfo" || len(reset.AllowableValues["ResetType"]) != 6 {
+		t.Errorf("Invalid reset action: %+v", reset)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	_, err = result.InvokeAction("#Contoso.ClearNvram", nil)
+	if err != nil {
+		t.Errorf("Error invoking OEM action: %s", err)
+	}
+
+	_, err = result.InvokeAction("ComputerSystem.AddResourceBlock", nil)
+	if err == nil {
+		t.Error("Actions that are not advertised should not be invoked")
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Systems/System-1/Actions/Oem/Contoso.ClearNvram" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+}
+
+// TestValidateResetType tests checking reset types against allowable values.
+func TestValidateResetType(t *testing.T) {
+	if ValidateResetType(SuspendResetType, nil, "system") != nil {
+		t.Error("Any reset type should be allowed when none are advertised")
+	}
+
+	err := ValidateResetType(PauseResetType, []ResetType{OnResetType, ResumeResetType}, "system")
+	if err == nil || err.Error() != "reset type 'Pause' is not supported by this system" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
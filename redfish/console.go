@@ -0,0 +1,140 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// SerialConsoleProtocol shall describe a serial console service over a
+// specific protocol.
+type SerialConsoleProtocol struct {
+	// ConsoleEntryCommand shall contain a command string that can be
+	// provided by a client to select or enter the system's serial console,
+	// when the console is shared among several systems or a manager CLI.
+	ConsoleEntryCommand string
+	// HotKeySequenceDisplay shall contain a string that can be provided to a
+	// user to describe the hotkey sequence used to exit the serial console
+	// session, or, if shared with a manager CLI, to return to the CLI.
+	HotKeySequenceDisplay string
+	// Port shall contain the protocol port.
+	Port int
+	// ServiceEnabled shall indicate whether the protocol for the service is
+	// enabled.
+	ServiceEnabled bool
+	// SharedWithManagerCLI shall indicate whether the serial console service
+	// is shared with access to the manager's command-line interface (CLI).
+	SharedWithManagerCLI bool
+}
+
+// HostSerialConsole shall describe the serial console services of a
+// computer system.
+type HostSerialConsole struct {
+	// IPMI shall contain connection details for a serial console service
+	// that uses the IPMI Serial-over-LAN (SOL) protocol.
+	IPMI SerialConsoleProtocol
+	// MaxConcurrentSessions shall contain the maximum number of concurrent
+	// service sessions that this implementation supports.
+	MaxConcurrentSessions int
+	// SSH shall contain connection details for a serial console service that
+	// uses the Secure Shell (SSH) protocol.
+	SSH SerialConsoleProtocol
+	// Telnet shall contain connection details for a serial console service
+	// that uses the Telnet protocol.
+	Telnet SerialConsoleProtocol
+}
+
+// HostGraphicalConsole shall describe a graphical console service for a
+// computer system.
+type HostGraphicalConsole struct {
+	// ConnectTypesSupported shall contain an array of the enumerations.
+	// KVMIP shall be included if a vendor-defined KVM-IP protocol is
+	// supported.
+	ConnectTypesSupported []GraphicalConnectTypesSupported
+	// MaxConcurrentSessions shall contain the maximum number of concurrent
+	// service sessions that this implementation supports.
+	MaxConcurrentSessions int
+	// Port shall contain the port assigned to the service.
+	Port int
+	// ServiceEnabled shall indicate whether the protocol for the service is
+	// enabled.
+	ServiceEnabled bool
+}
+
+// consoleURLKeyHints are the fragments, in lower case, of OEM property names
+// that vendors commonly use to advertise a remote console launch URL, such
+// as Dell's DelliDRACCard.URLString.
+var consoleURLKeyHints = []string{"console", "kvm", "launch", "urlstring"}
+
+// ConsoleURLs gets the remote console launch URLs this manager advertises.
+// The Redfish schema does not define a standard location for them, so the
+// Oem section of the manager is searched for URL values stored under
+// property names vendors commonly use for their console or KVM launchers.
+func (manager *Manager) ConsoleURLs() []string {
+	return oemConsoleURLs(manager.rawData)
+}
+
+// ConsoleURLs gets the remote console launch URLs this system advertises in
+// its Oem section. Most implementations only advertise them on the manager,
+// see Manager.ConsoleURLs.
+func (computersystem *ComputerSystem) ConsoleURLs() []string {
+	return oemConsoleURLs(computersystem.rawData)
+}
+
+// oemConsoleURLs extracts the console launch URLs from the Oem section of
+// a raw resource payload.
+func oemConsoleURLs(rawData []byte) []string {
+	var t struct {
+		Oem interface{}
+	}
+	if err := json.Unmarshal(rawData, &t); err != nil {
+		return nil
+	}
+
+	found := map[string]bool{}
+	collectConsoleURLs(t.Oem, false, found)
+
+	result := make([]string, 0, len(found))
+	for url := range found {
+		result = append(result, url)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// collectConsoleURLs walks an OEM value and records every http(s) URL found
+// under, or nested within, a property whose name hints at a console.
+func collectConsoleURLs(value interface{}, hinted bool, found map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			collectConsoleURLs(child, hinted || isConsoleURLKey(key), found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectConsoleURLs(child, hinted, found)
+		}
+	case string:
+		lower := strings.ToLower(v)
+		if hinted && (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) {
+			found[v] = true
+		}
+	}
+}
+
+// isConsoleURLKey reports whether an OEM property name hints at a console.
+func isConsoleURLKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, hint := range consoleURLKeyHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+
+	return false
+}
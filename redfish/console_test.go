@@ -0,0 +1,167 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var consoleManagerBody = `{
+		"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1",
+		"Id": "iDRAC.Embedded.1",
+		"Name": "Manager",
+		"GraphicalConsole": {
+			"ServiceEnabled": true,
+			"MaxConcurrentSessions": 6,
+			"ConnectTypesSupported": [
+				"KVMIP"
+			]
+		},
+		"Oem": {
+			"Dell": {
+				"DelliDRACCard": {
+					"IPMIVersion": "2.0",
+					"URLString": "https://10.0.0.120:443"
+				},
+				"Links": {
+					"HelpURL": "https://www.dell.com/support"
+				}
+			}
+		}
+	}`
+
+var consoleSystemBody = `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"GraphicalConsole": {
+			"ServiceEnabled": true,
+			"Port": 5900,
+			"MaxConcurrentSessions": 2,
+			"ConnectTypesSupported": [
+				"KVMIP"
+			]
+		},
+		"SerialConsole": {
+			"MaxConcurrentSessions": 3,
+			"SSH": {
+				"ServiceEnabled": true,
+				"Port": 2200,
+				"SharedWithManagerCLI": true,
+				"ConsoleEntryCommand": "console connect",
+				"HotKeySequenceDisplay": "Press ~. to exit console"
+			},
+			"IPMI": {
+				"ServiceEnabled": true,
+				"HotKeySequenceDisplay": "Press ~. to exit console"
+			}
+		}
+	}`
+
+// TestManagerConsoleURLs tests extracting console URLs from OEM data.
+func TestManagerConsoleURLs(t *testing.T) {
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(consoleManagerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	urls := result.ConsoleURLs()
+	if len(urls) != 1 || urls[0] != "https://10.0.0.120:443" {
+		t.Errorf("Invalid console URLs: %v", urls)
+	}
+}
+
+// TestComputerSystemConsoles tests the parsing of system console services.
+func TestComputerSystemConsoles(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(consoleSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.GraphicalConsole.Port != 5900 {
+		t.Errorf("Invalid graphical console port: %d", result.GraphicalConsole.Port)
+	}
+
+	if result.GraphicalConsole.ConnectTypesSupported[0] != KVMIPGraphicalConnectTypesSupported {
+		t.Errorf("Invalid graphical console connect types: %v", result.GraphicalConsole.ConnectTypesSupported)
+	}
+
+	if result.SerialConsole.MaxConcurrentSessions != 3 {
+		t.Errorf("Invalid serial console max sessions: %d", result.SerialConsole.MaxConcurrentSessions)
+	}
+
+	if !result.SerialConsole.SSH.SharedWithManagerCLI || result.SerialConsole.SSH.Port != 2200 {
+		t.Errorf("Invalid SSH serial console: %v", result.SerialConsole.SSH)
+	}
+
+	if result.SerialConsole.Telnet.ServiceEnabled {
+		t.Error("Telnet serial console should not be enabled")
+	}
+
+	if len(result.ConsoleURLs()) != 0 {
+		t.Errorf("Unexpected console URLs: %v", result.ConsoleURLs())
+	}
+}
+
+// TestManagerRemoteConsole tests getting the remote console URL of a manager.
+func TestManagerRemoteConsole(t *testing.T) {
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(consoleManagerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	// Without an OEM endpoint, the advertised URL is used
+	console, err := result.RemoteConsole(RemoteConsoleOptions{})
+	if err != nil {
+		t.Fatalf("Error getting remote console: %s", err)
+	}
+	if console.URL != "https://10.0.0.120:443" || console.Vendor != "" {
+		t.Errorf("Invalid remote console: %v", console)
+	}
+
+	var ilo Manager
+	err = json.Unmarshal([]byte(`{
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"GraphicalConsole": {"ServiceEnabled": true, "ConnectTypesSupported": ["KVMIP"]},
+		"Oem": {"Hpe": {"License": {"LicenseString": "iLO Advanced"}}}
+	}`), &ilo)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	console, err = ilo.RemoteConsole(RemoteConsoleOptions{
+		Endpoint:     "https://ilo1.example.com/",
+		SessionToken: "c3e4f9a0",
+	})
+	if err != nil {
+		t.Fatalf("Error getting remote console: %s", err)
+	}
+	if console.URL != "https://ilo1.example.com/irc.html?sessionKey=c3e4f9a0" || console.Vendor != "Hpe" {
+		t.Errorf("Invalid iLO remote console: %v", console)
+	}
+
+	_, err = ilo.RemoteConsole(RemoteConsoleOptions{Endpoint: "https://ilo1.example.com"})
+	if err == nil {
+		t.Error("The iLO console should need a session token")
+	}
+
+	ilo.GraphicalConsole.ServiceEnabled = false
+	_, err = ilo.RemoteConsole(RemoteConsoleOptions{
+		Endpoint:     "https://ilo1.example.com",
+		SessionToken: "c3e4f9a0",
+	})
+	if err == nil {
+		t.Error("A disabled graphical console should not be opened")
+	}
+}
@@ -0,0 +1,197 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerSupplyReading is a power supply of a chassis, whichever of the power
+// schemas its service implements. Values the service does not report are
+// zero.
+type PowerSupplyReading struct {
+	// URI is the URI of the power supply, or of the Power array element for
+	// power supplies from the Power resource.
+	URI string
+	// Name is the name of the power supply.
+	Name string
+	// CapacityWatts is the power the power supply can provide.
+	CapacityWatts float32
+	// InputWatts is the power the power supply draws from its input.
+	InputWatts float32
+	// OutputWatts is the power the power supply provides.
+	OutputWatts float32
+	// InputVoltage is the voltage of the input of the power supply.
+	InputVoltage float32
+	// Health is the health of the power supply.
+	Health common.Health
+	// State is the state of the power supply, which is absent for empty
+	// bays.
+	State common.State
+}
+
+// CoolingAndPower holds the power and thermal readings of a chassis, read
+// from the PowerSubsystem, ThermalSubsystem and Sensors or from the
+// deprecated Power and Thermal resources, whichever its service implements,
+// so callers have a single code path for both.
+type CoolingAndPower struct {
+	// Power is the power consumption of the chassis, nil if the chassis
+	// does not report it.
+	Power *PowerReading
+	// PowerSupplies are the power supplies of the chassis.
+	PowerSupplies []PowerSupplyReading
+	// Thermal holds the temperature and fan readings of the chassis, nil if
+	// the chassis does not report them.
+	Thermal *ThermalSummary
+}
+
+// CoolingAndPower gets the power and thermal readings of the chassis, as
+// PowerReading and ThermalSummary do, along with its power supplies. The
+// chassis has to report at least one of them.
+func (chassis *Chassis) CoolingAndPower() (*CoolingAndPower, error) {
+	result := &CoolingAndPower{}
+
+	var err error
+	if chassis.environmentMetrics != "" || chassis.power != "" {
+		result.Power, err = chassis.PowerReading()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.PowerSupplies, err = chassis.powerSupplyReadings()
+	if err != nil {
+		return nil, err
+	}
+
+	if chassis.sensors != "" || chassis.thermal != "" {
+		result.Thermal, err = chassis.ThermalSummary()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Power == nil && len(result.PowerSupplies) == 0 && result.Thermal == nil {
+		return nil, fmt.Errorf("chassis %s does not report its power or thermal readings", chassis.ID)
+	}
+
+	return result, nil
+}
+
+// subsystemPowerSupply is the part of a power supply of a PowerSubsystem
+// that is summarized.
+type subsystemPowerSupply struct {
+	common.Entity
+	// PowerCapacityWatts is the power the power supply can provide.
+	PowerCapacityWatts float32
+	// Metrics is the link to the metrics of the power supply.
+	Metrics common.Link
+	// Status is the status of the power supply.
+	Status common.Status
+}
+
+// subsystemPowerSupplyMetrics is the part of the metrics of a power supply
+// that is summarized.
+type subsystemPowerSupplyMetrics struct {
+	common.Entity
+	// InputPowerWatts is the power the power supply draws.
+	InputPowerWatts struct {
+		Reading common.Float32
+	}
+	// OutputPowerWatts is the power the power supply provides.
+	OutputPowerWatts struct {
+		Reading common.Float32
+	}
+	// InputVoltage is the voltage of the input of the power supply.
+	InputVoltage struct {
+		Reading common.Float32
+	}
+}
+
+// powerSupplyReadings gets the power supplies of the PowerSubsystem of the
+// chassis, or of its Power resource for services that do not implement the
+// PowerSubsystem power supplies.
+func (chassis *Chassis) powerSupplyReadings() ([]PowerSupplyReading, error) {
+	subsystem, err := chassis.PowerSubsystem()
+	if err != nil {
+		return nil, err
+	}
+	if subsystem != nil && subsystem.PowerSupplies() != "" {
+		return chassis.powerSubsystemSupplies(subsystem.PowerSupplies())
+	}
+
+	if chassis.power == "" {
+		return nil, nil
+	}
+	power, err := chassis.Power()
+	if err != nil || power == nil {
+		return nil, err
+	}
+
+	var result []PowerSupplyReading
+	for i := range power.PowerSupplies {
+		supply := &power.PowerSupplies[i]
+		uri := supply.ODataID
+		if uri == "" {
+			uri = fmt.Sprintf("%s#/PowerSupplies/%d", power.ODataID, i)
+		}
+		result = append(result, PowerSupplyReading{
+			URI:           uri,
+			Name:          supply.Name,
+			CapacityWatts: supply.PowerCapacityWatts,
+			InputWatts:    supply.PowerInputWatts,
+			OutputWatts:   supply.PowerOutputWatts,
+			InputVoltage:  supply.LineInputVoltage,
+			Health:        supply.Status.Health,
+			State:         supply.Status.State,
+		})
+	}
+
+	return result, nil
+}
+
+// powerSubsystemSupplies gets the readings of the collection of power
+// supplies of a PowerSubsystem, with their metrics.
+func (chassis *Chassis) powerSubsystemSupplies(link string) ([]PowerSupplyReading, error) {
+	links, err := common.GetCollection(chassis.Client, link)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PowerSupplyReading
+	for _, supplyLink := range links.ItemLinks {
+		supply := new(subsystemPowerSupply)
+		expanded, err := links.DecodeMember(chassis.Client, supplyLink, supply)
+		if err == nil && !expanded {
+			err = common.FetchResource(chassis.Client, supplyLink, supply)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		reading := PowerSupplyReading{
+			URI:           supplyLink,
+			Name:          supply.Name,
+			CapacityWatts: supply.PowerCapacityWatts,
+			Health:        supply.Status.Health,
+			State:         supply.Status.State,
+		}
+		if supply.Metrics != "" {
+			var metrics subsystemPowerSupplyMetrics
+			err = common.FetchResource(chassis.Client, string(supply.Metrics), &metrics)
+			if err != nil {
+				return nil, err
+			}
+			reading.InputWatts = float32(metrics.InputPowerWatts.Reading)
+			reading.OutputWatts = float32(metrics.OutputPowerWatts.Reading)
+			reading.InputVoltage = float32(metrics.InputVoltage.Reading)
+		}
+		result = append(result, reading)
+	}
+
+	return result, nil
+}
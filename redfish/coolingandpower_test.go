@@ -0,0 +1,172 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"testing"
+)
+
+// TestChassisCoolingAndPowerSubsystems tests reading a chassis implementing
+// the PowerSubsystem, ThermalSubsystem and Sensors schemas.
+func TestChassisCoolingAndPowerSubsystems(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/EnvironmentMetrics": environmentMetricsBody,
+		"/redfish/v1/Chassis/1U/PowerSubsystem": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem",
+			"Id": "PowerSubsystem",
+			"CapacityWatts": 1600,
+			"PowerSupplies": {
+				"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies"
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies": `{
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1"}
+			],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1",
+			"Id": "Bay1",
+			"Name": "Power Supply Bay 1",
+			"PowerCapacityWatts": 800,
+			"Status": {
+				"State": "Enabled",
+				"Health": "OK"
+			},
+			"Metrics": {
+				"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics"
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics",
+			"Id": "Metrics",
+			"InputPowerWatts": {
+				"Reading": 374.4
+			},
+			"OutputPowerWatts": {
+				"Reading": 350.4
+			},
+			"InputVoltage": {
+				"Reading": 230.2
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/Sensors": `{
+			"Members": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/1U/Sensors/Intake",
+					"Id": "Intake",
+					"Name": "Intake Temperature",
+					"ReadingType": "Temperature",
+					"Reading": 24.8
+				},
+				{
+					"@odata.id": "/redfish/v1/Chassis/1U/Sensors/Fan1",
+					"Id": "Fan1",
+					"Name": "Fan 1",
+					"ReadingType": "Rotational",
+					"Reading": 3200
+				}
+			],
+			"Members@odata.count": 2
+		}`,
+	}}
+
+	chassis := &Chassis{
+		environmentMetrics: "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+		powerSubsystem:     "/redfish/v1/Chassis/1U/PowerSubsystem",
+		sensors:            "/redfish/v1/Chassis/1U/Sensors",
+	}
+	chassis.SetClient(testClient)
+
+	result, err := chassis.CoolingAndPower()
+	if err != nil {
+		t.Fatalf("Error reading cooling and power: %s", err)
+	}
+
+	if result.Power == nil || result.Power.Source != EnvironmentMetricsPowerReadingSource {
+		t.Errorf("Invalid power reading: %v", result.Power)
+	}
+
+	if len(result.PowerSupplies) != 1 {
+		t.Fatalf("Invalid power supplies: %v", result.PowerSupplies)
+	}
+	supply := result.PowerSupplies[0]
+	if supply.URI != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1" || supply.CapacityWatts != 800 {
+		t.Errorf("Invalid power supply: %v", supply)
+	}
+	if supply.InputWatts != 374.4 || supply.OutputWatts != 350.4 || supply.InputVoltage != 230.2 {
+		t.Errorf("Invalid power supply metrics: %v", supply)
+	}
+
+	if result.Thermal == nil || len(result.Thermal.Temperatures) != 1 || len(result.Thermal.Fans) != 1 {
+		t.Errorf("Invalid thermal summary: %v", result.Thermal)
+	}
+}
+
+// TestChassisCoolingAndPowerLegacy tests reading a chassis implementing the
+// deprecated Power and Thermal schemas.
+func TestChassisCoolingAndPowerLegacy(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/Power": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power",
+			"Id": "Power",
+			"PowerControl": [
+				{
+					"MemberId": "0",
+					"PowerConsumedWatts": 344
+				}
+			],
+			"PowerSupplies": [
+				{
+					"MemberId": "0",
+					"Name": "Power Supply 1",
+					"PowerCapacityWatts": 800,
+					"PowerInputWatts": 180,
+					"LineInputVoltage": 120,
+					"Status": {
+						"State": "Enabled",
+						"Health": "Warning"
+					}
+				}
+			]
+		}`,
+		"/redfish/v1/Thermal": thermalBody,
+	}}
+
+	chassis := &Chassis{
+		power:   "/redfish/v1/Chassis/1U/Power",
+		thermal: "/redfish/v1/Thermal",
+	}
+	chassis.SetClient(testClient)
+
+	result, err := chassis.CoolingAndPower()
+	if err != nil {
+		t.Fatalf("Error reading cooling and power: %s", err)
+	}
+
+	if result.Power == nil || result.Power.Source != PowerPowerReadingSource || result.Power.ConsumedWatts != 344 {
+		t.Errorf("Invalid power reading: %v", result.Power)
+	}
+
+	if len(result.PowerSupplies) != 1 {
+		t.Fatalf("Invalid power supplies: %v", result.PowerSupplies)
+	}
+	supply := result.PowerSupplies[0]
+	if supply.URI != "/redfish/v1/Chassis/1U/Power#/PowerSupplies/0" || supply.InputWatts != 180 || supply.InputVoltage != 120 {
+		t.Errorf("Invalid power supply: %v", supply)
+	}
+
+	if result.Thermal == nil || result.Thermal.Source != ThermalThermalSummarySource {
+		t.Errorf("Invalid thermal summary: %v", result.Thermal)
+	}
+
+	chassis = &Chassis{}
+	chassis.SetClient(testClient)
+	_, err = chassis.CoolingAndPower()
+	if err == nil {
+		t.Errorf("A chassis without readings should be refused")
+	}
+}
@@ -0,0 +1,260 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// CoolantType is the type of coolant.
+type CoolantType string
+
+const (
+	// WaterCoolantType Water or glycol mixture, including additives.
+	WaterCoolantType CoolantType = "Water"
+	// HydrocarbonCoolantType Hydrocarbon-based.
+	HydrocarbonCoolantType CoolantType = "Hydrocarbon"
+	// FluorocarbonCoolantType Fluorocarbon-based.
+	FluorocarbonCoolantType CoolantType = "Fluorocarbon"
+	// DielectricCoolantType Dielectric fluid.
+	DielectricCoolantType CoolantType = "Dielectric"
+)
+
+// Coolant shall describe the coolant used with a device.
+type Coolant struct {
+	// AdditiveName shall contain the name of the additive contained in the
+	// coolant.
+	AdditiveName string
+	// AdditivePercent shall contain the percent of additives, 0 to 100, by
+	// volume, contained in the coolant mixture.
+	AdditivePercent float32
+	// CoolantType shall contain the type of coolant used by this resource.
+	CoolantType CoolantType
+	// DensityKgPerCubicMeter shall contain the density, in kilograms per
+	// cubic meter units, for the coolant.
+	DensityKgPerCubicMeter float32
+	// RatedServiceHours shall contain the number of hours of service that
+	// the coolant is rated to provide before servicing or replacement is
+	// necessary.
+	RatedServiceHours float32
+	// ServiceHours shall contain the number of hours of service that the
+	// coolant has provided.
+	ServiceHours float32
+	// ServicedDate shall contain the date the coolant was last serviced or
+	// tested for quality.
+	ServicedDate string
+	// SpecificHeatkJoulesPerKgK shall contain the specific heat capacity, in
+	// kilojoules per kilogram per degree kelvin units, for the coolant.
+	SpecificHeatkJoulesPerKgK float32
+}
+
+// CoolingLoop shall be used to represent a cooling loop for a Redfish
+// implementation.
+type CoolingLoop struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ConsumingEquipmentNames shall contain an array of user-assigned
+	// identifying strings that describe downstream devices that receive
+	// coolant from this cooling loop.
+	ConsumingEquipmentNames []string
+	// Coolant shall contain details regarding the coolant contained or used
+	// by this cooling loop.
+	Coolant Coolant
+	// CoolantLevelStatus shall indicate the status of the coolant level in
+	// this cooling loop.
+	CoolantLevelStatus common.Health
+	// CoolantQuality shall indicate the quality of the coolant contained in
+	// this cooling loop.
+	CoolantQuality common.Health
+	// CoolingManagerURI shall contain a URI to the application or device
+	// that provides administration or management of the cooling loop.
+	CoolingManagerURI string
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SupplyEquipmentNames shall contain an array of user-assigned
+	// identifying strings that describe upstream devices that supply coolant
+	// to this cooling loop.
+	SupplyEquipmentNames []string
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// environmentMetrics shall be a link to a resource of type
+	// EnvironmentMetrics that specifies the environment metrics for this
+	// cooling loop.
+	environmentMetrics string
+	// primaryCoolantConnectors shall be a link to a resource collection of
+	// type CoolantConnectorCollection.
+	primaryCoolantConnectors string
+	// secondaryCoolantConnectors shall be a link to a resource collection of
+	// type CoolantConnectorCollection.
+	secondaryCoolantConnectors string
+	// chassis is the chassis related to this cooling loop.
+	chassis string
+	// facility is the facility that contains this cooling loop.
+	facility string
+	// managedBy are the managers that manage this cooling loop.
+	managedBy []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (coolingloop *CoolingLoop) GetRawData() []byte {
+	return coolingloop.rawData
+}
+
+// UnmarshalJSON unmarshals a CoolingLoop object from the raw JSON.
+func (coolingloop *CoolingLoop) UnmarshalJSON(b []byte) error {
+	type temp CoolingLoop
+	var t struct {
+		temp
+		EnvironmentMetrics         common.Link
+		PrimaryCoolantConnectors   common.Link
+		SecondaryCoolantConnectors common.Link
+		Links                      struct {
+			Chassis   common.Link
+			Facility  common.Link
+			ManagedBy common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*coolingloop = CoolingLoop(t.temp)
+
+	// Extract the links to other entities for later
+	coolingloop.environmentMetrics = string(t.EnvironmentMetrics)
+	coolingloop.primaryCoolantConnectors = string(t.PrimaryCoolantConnectors)
+	coolingloop.secondaryCoolantConnectors = string(t.SecondaryCoolantConnectors)
+	coolingloop.chassis = string(t.Links.Chassis)
+	coolingloop.facility = string(t.Links.Facility)
+	coolingloop.managedBy = t.Links.ManagedBy.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	coolingloop.rawData = b
+	coolingloop.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the cooling loop to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (coolingloop CoolingLoop) MarshalJSON() ([]byte, error) {
+	type temp CoolingLoop
+	return common.MarshalWithRawData(coolingloop.rawData, temp(coolingloop))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (coolingloop *CoolingLoop) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(CoolingLoop)
+	original.UnmarshalJSON(coolingloop.rawData)
+
+	readWriteFields := []string{
+		"CoolingManagerURI",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(coolingloop).Elem()
+
+	return coolingloop.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// EnvironmentMetrics gets the URI of the environment metrics of this cooling
+// loop.
+func (coolingloop *CoolingLoop) EnvironmentMetrics() string {
+	return coolingloop.environmentMetrics
+}
+
+// PrimaryCoolantConnectors gets the URI of the collection of primary coolant
+// connectors of this cooling loop.
+func (coolingloop *CoolingLoop) PrimaryCoolantConnectors() string {
+	return coolingloop.primaryCoolantConnectors
+}
+
+// SecondaryCoolantConnectors gets the URI of the collection of secondary
+// coolant connectors of this cooling loop.
+func (coolingloop *CoolingLoop) SecondaryCoolantConnectors() string {
+	return coolingloop.secondaryCoolantConnectors
+}
+
+// Chassis gets the chassis related to this cooling loop.
+func (coolingloop *CoolingLoop) Chassis() (*Chassis, error) {
+	if coolingloop.chassis == "" {
+		return nil, nil
+	}
+
+	return GetChassis(coolingloop.Client, coolingloop.chassis)
+}
+
+// Facility gets the facility that contains this cooling loop.
+func (coolingloop *CoolingLoop) Facility() (*Facility, error) {
+	if coolingloop.facility == "" {
+		return nil, nil
+	}
+
+	return GetFacility(coolingloop.Client, coolingloop.facility)
+}
+
+// ManagedBy gets the URIs of the managers that manage this cooling loop.
+func (coolingloop *CoolingLoop) ManagedBy() []string {
+	return coolingloop.managedBy
+}
+
+// GetCoolingLoop will get a CoolingLoop instance from the service.
+func GetCoolingLoop(c common.Client, uri string) (*CoolingLoop, error) {
+	var coolingloop CoolingLoop
+	err := common.FetchResource(c, uri, &coolingloop)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coolingloop, nil
+}
+
+// ListReferencedCoolingLoops gets the collection of CoolingLoop from
+// a provided reference.
+func ListReferencedCoolingLoops(c common.Client, link string) ([]*CoolingLoop, error) {
+	var result []*CoolingLoop
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, coolingloopLink := range links.ItemLinks {
+		coolingloop := new(CoolingLoop)
+		expanded, err := links.DecodeMember(c, coolingloopLink, coolingloop)
+		if err == nil && !expanded {
+			coolingloop, err = GetCoolingLoop(c, coolingloopLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, coolingloop)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,75 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var coolingLoopBody = `{
+		"@odata.type": "#CoolingLoop.v1_0_0.CoolingLoop",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CoolingLoops/BuildingChiller",
+		"Id": "BuildingChiller",
+		"Name": "Feed from building chiller",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"CoolantLevelStatus": "OK",
+		"CoolantQuality": "Warning",
+		"Coolant": {
+			"CoolantType": "Water",
+			"RatedServiceHours": 3000,
+			"ServiceHours": 2912
+		},
+		"SupplyEquipmentNames": ["Chiller A"],
+		"ConsumingEquipmentNames": ["Rack 1 CDU", "Rack 2 CDU"],
+		"PrimaryCoolantConnectors": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CoolingLoops/BuildingChiller/PrimaryCoolantConnectors"
+		},
+		"Links": {
+			"Facility": {
+				"@odata.id": "/redfish/v1/Facilities/Room237"
+			}
+		}
+	}`
+
+// TestCoolingLoop tests the parsing of CoolingLoop objects.
+func TestCoolingLoop(t *testing.T) {
+	var result CoolingLoop
+	err := json.NewDecoder(strings.NewReader(coolingLoopBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BuildingChiller" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CoolantQuality != common.WarningHealth {
+		t.Errorf("Invalid coolant quality: %s", result.CoolantQuality)
+	}
+
+	if result.Coolant.ServiceHours != 2912 {
+		t.Errorf("Invalid coolant service hours: %f", result.Coolant.ServiceHours)
+	}
+
+	if len(result.ConsumingEquipmentNames) != 2 {
+		t.Errorf("Invalid consuming equipment names: %v", result.ConsumingEquipmentNames)
+	}
+
+	if result.PrimaryCoolantConnectors() != "/redfish/v1/ThermalEquipment/CoolingLoops/BuildingChiller/PrimaryCoolantConnectors" {
+		t.Errorf("Invalid primary coolant connectors link: %s", result.PrimaryCoolantConnectors())
+	}
+
+	if result.facility != "/redfish/v1/Facilities/Room237" {
+		t.Errorf("Invalid facility link: %s", result.facility)
+	}
+}
@@ -0,0 +1,308 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// CoolingEquipmentType is the type of cooling equipment.
+type CoolingEquipmentType string
+
+const (
+	// CDUCoolingEquipmentType A coolant distribution unit (CDU).
+	CDUCoolingEquipmentType CoolingEquipmentType = "CDU"
+	// HeatExchangerCoolingEquipmentType A heat exchanger.
+	HeatExchangerCoolingEquipmentType CoolingEquipmentType = "HeatExchanger"
+	// ImmersionUnitCoolingEquipmentType An immersion cooling unit.
+	ImmersionUnitCoolingEquipmentType CoolingEquipmentType = "ImmersionUnit"
+)
+
+// CoolingUnit shall be used to represent a cooling system component or unit,
+// such as a coolant distribution unit (CDU), for a Redfish implementation.
+type CoolingUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag shall contain the user-assigned asset tag, which is an
+	// identifying string that tracks the equipment for inventory purposes.
+	AssetTag string
+	// Coolant shall contain details regarding the coolant contained or used
+	// by this unit.
+	Coolant Coolant
+	// CoolingCapacityWatts shall contain the manufacturer-provided cooling
+	// capacity, in watt units, of this equipment.
+	CoolingCapacityWatts int
+	// Description provides a description of this resource.
+	Description string
+	// EquipmentType shall contain the type of equipment this resource
+	// represents.
+	EquipmentType CoolingEquipmentType
+	// FirmwareVersion shall contain a string describing the firmware version
+	// of this equipment as provided by the manufacturer.
+	FirmwareVersion string
+	// Location shall contain the location information of the associated
+	// equipment.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the equipment.
+	Manufacturer string
+	// Model shall contain the manufacturer-provided model information of
+	// this equipment.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// equipment.
+	PartNumber string
+	// ProductionDate shall contain the date of production or manufacture for
+	// this equipment.
+	ProductionDate string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the equipment.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Version shall contain the hardware version of this equipment as
+	// determined by the vendor or supplier.
+	Version string
+	// environmentMetrics shall be a link to a resource of type
+	// EnvironmentMetrics that specifies the environment metrics for this
+	// equipment.
+	environmentMetrics string
+	// filters shall be a link to a resource collection of type
+	// FilterCollection.
+	filters string
+	// leakDetection shall be a link to a resource of type LeakDetection.
+	leakDetection string
+	// primaryCoolantConnectors shall be a link to a resource collection of
+	// type CoolantConnectorCollection.
+	primaryCoolantConnectors string
+	// pumps shall be a link to a resource collection of type
+	// PumpCollection.
+	pumps string
+	// reservoirs shall be a link to a resource collection of type
+	// ReservoirCollection.
+	reservoirs string
+	// secondaryCoolantConnectors shall be a link to a resource collection of
+	// type CoolantConnectorCollection.
+	secondaryCoolantConnectors string
+	// chassis are the chassis related to this equipment.
+	chassis []string
+	// facility is the facility that contains this equipment.
+	facility string
+	// managedBy are the managers that manage this equipment.
+	managedBy []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (coolingunit *CoolingUnit) GetRawData() []byte {
+	return coolingunit.rawData
+}
+
+// UnmarshalJSON unmarshals a CoolingUnit object from the raw JSON.
+func (coolingunit *CoolingUnit) UnmarshalJSON(b []byte) error {
+	type temp CoolingUnit
+	var t struct {
+		temp
+		EnvironmentMetrics         common.Link
+		Filters                    common.Link
+		LeakDetection              common.Link
+		PrimaryCoolantConnectors   common.Link
+		Pumps                      common.Link
+		Reservoirs                 common.Link
+		SecondaryCoolantConnectors common.Link
+		Links                      struct {
+			Chassis   common.Links
+			Facility  common.Link
+			ManagedBy common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*coolingunit = CoolingUnit(t.temp)
+
+	// Extract the links to other entities for later
+	coolingunit.environmentMetrics = string(t.EnvironmentMetrics)
+	coolingunit.filters = string(t.Filters)
+	coolingunit.leakDetection = string(t.LeakDetection)
+	coolingunit.primaryCoolantConnectors = string(t.PrimaryCoolantConnectors)
+	coolingunit.pumps = string(t.Pumps)
+	coolingunit.reservoirs = string(t.Reservoirs)
+	coolingunit.secondaryCoolantConnectors = string(t.SecondaryCoolantConnectors)
+	coolingunit.chassis = t.Links.Chassis.ToStrings()
+	coolingunit.facility = string(t.Links.Facility)
+	coolingunit.managedBy = t.Links.ManagedBy.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	coolingunit.rawData = b
+	coolingunit.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the cooling unit to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (coolingunit CoolingUnit) MarshalJSON() ([]byte, error) {
+	type temp CoolingUnit
+	return common.MarshalWithRawData(coolingunit.rawData, temp(coolingunit))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (coolingunit *CoolingUnit) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(CoolingUnit)
+	original.UnmarshalJSON(coolingunit.rawData)
+
+	readWriteFields := []string{
+		"AssetTag",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(coolingunit).Elem()
+
+	return coolingunit.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// EnvironmentMetrics gets the URI of the environment metrics of this
+// equipment.
+func (coolingunit *CoolingUnit) EnvironmentMetrics() string {
+	return coolingunit.environmentMetrics
+}
+
+// Filters gets the filters of this equipment.
+func (coolingunit *CoolingUnit) Filters() ([]*Filter, error) {
+	return ListReferencedFilters(coolingunit.Client, coolingunit.filters)
+}
+
+// LeakDetection gets the leak detection of this equipment.
+func (coolingunit *CoolingUnit) LeakDetection() (*LeakDetection, error) {
+	if coolingunit.leakDetection == "" {
+		return nil, nil
+	}
+
+	return GetLeakDetection(coolingunit.Client, coolingunit.leakDetection)
+}
+
+// PrimaryCoolantConnectors gets the URI of the collection of primary coolant
+// connectors of this equipment.
+func (coolingunit *CoolingUnit) PrimaryCoolantConnectors() string {
+	return coolingunit.primaryCoolantConnectors
+}
+
+// Pumps gets the pumps of this equipment.
+func (coolingunit *CoolingUnit) Pumps() ([]*Pump, error) {
+	return ListReferencedPumps(coolingunit.Client, coolingunit.pumps)
+}
+
+// Reservoirs gets the reservoirs of this equipment.
+func (coolingunit *CoolingUnit) Reservoirs() ([]*Reservoir, error) {
+	return ListReferencedReservoirs(coolingunit.Client, coolingunit.reservoirs)
+}
+
+// SecondaryCoolantConnectors gets the URI of the collection of secondary
+// coolant connectors of this equipment.
+func (coolingunit *CoolingUnit) SecondaryCoolantConnectors() string {
+	return coolingunit.secondaryCoolantConnectors
+}
+
+// Chassis gets the chassis related to this equipment.
+func (coolingunit *CoolingUnit) Chassis() ([]*Chassis, error) {
+	var result []*Chassis
+	for _, chassisLink := range coolingunit.chassis {
+		chassis, err := GetChassis(coolingunit.Client, chassisLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, chassis)
+	}
+
+	return result, nil
+}
+
+// Facility gets the facility that contains this equipment.
+func (coolingunit *CoolingUnit) Facility() (*Facility, error) {
+	if coolingunit.facility == "" {
+		return nil, nil
+	}
+
+	return GetFacility(coolingunit.Client, coolingunit.facility)
+}
+
+// ManagedBy gets the URIs of the managers that manage this equipment.
+func (coolingunit *CoolingUnit) ManagedBy() []string {
+	return coolingunit.managedBy
+}
+
+// GetCoolingUnit will get a CoolingUnit instance from the service.
+func GetCoolingUnit(c common.Client, uri string) (*CoolingUnit, error) {
+	var coolingunit CoolingUnit
+	err := common.FetchResource(c, uri, &coolingunit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coolingunit, nil
+}
+
+// getCoolingUnits gets the CoolingUnit instances of a list of links.
+func getCoolingUnits(c common.Client, links []string) ([]*CoolingUnit, error) {
+	var result []*CoolingUnit
+	for _, coolingunitLink := range links {
+		coolingunit, err := GetCoolingUnit(c, coolingunitLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, coolingunit)
+	}
+
+	return result, nil
+}
+
+// ListReferencedCoolingUnits gets the collection of CoolingUnit from
+// a provided reference.
+func ListReferencedCoolingUnits(c common.Client, link string) ([]*CoolingUnit, error) {
+	var result []*CoolingUnit
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, coolingunitLink := range links.ItemLinks {
+		coolingunit := new(CoolingUnit)
+		expanded, err := links.DecodeMember(c, coolingunitLink, coolingunit)
+		if err == nil && !expanded {
+			coolingunit, err = GetCoolingUnit(c, coolingunitLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, coolingunit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var coolingUnitBody = `{
+		"@odata.type": "#CoolingUnit.v1_0_0.CoolingUnit",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1",
+		"Id": "1",
+		"Name": "Rack Coolant Distribution Unit",
+		"EquipmentType": "CDU",
+		"Manufacturer": "Contoso",
+		"Model": "MoarCooling",
+		"SerialNumber": "29347ZT536",
+		"CoolingCapacityWatts": 4000,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Coolant": {
+			"CoolantType": "Water",
+			"AdditiveName": "Generic cooling water biocide",
+			"AdditivePercent": 0
+		},
+		"Filters": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Filters"
+		},
+		"Pumps": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Pumps"
+		},
+		"Reservoirs": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Reservoirs"
+		},
+		"Links": {
+			"Chassis": [{
+				"@odata.id": "/redfish/v1/Chassis/CDU1"
+			}],
+			"Facility": {
+				"@odata.id": "/redfish/v1/Facilities/Room237"
+			}
+		}
+	}`
+
+// TestCoolingUnit tests the parsing of CoolingUnit objects.
+func TestCoolingUnit(t *testing.T) {
+	var result CoolingUnit
+	err := json.NewDecoder(strings.NewReader(coolingUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.EquipmentType != CDUCoolingEquipmentType {
+		t.Errorf("Invalid equipment type: %s", result.EquipmentType)
+	}
+
+	if result.Coolant.CoolantType != WaterCoolantType {
+		t.Errorf("Invalid coolant type: %s", result.Coolant.CoolantType)
+	}
+
+	if result.pumps != "/redfish/v1/ThermalEquipment/CDUs/1/Pumps" {
+		t.Errorf("Invalid pumps link: %s", result.pumps)
+	}
+
+	if result.reservoirs != "/redfish/v1/ThermalEquipment/CDUs/1/Reservoirs" {
+		t.Errorf("Invalid reservoirs link: %s", result.reservoirs)
+	}
+
+	if result.facility != "/redfish/v1/Facilities/Room237" {
+		t.Errorf("Invalid facility link: %s", result.facility)
+	}
+}
+
+// TestCoolingUnitUpdate tests the Update call.
+func TestCoolingUnitUpdate(t *testing.T) {
+	var result CoolingUnit
+	err := json.NewDecoder(strings.NewReader(coolingUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.UserLabel = "Row 2 CDU"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "UserLabel:Row 2 CDU") {
+		t.Errorf("Unexpected UserLabel update payload: %s", calls[0].Payload)
+	}
+}
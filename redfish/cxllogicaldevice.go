@@ -0,0 +1,260 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// CXLSemantic is a CXL protocol semantic.
+type CXLSemantic string
+
+const (
+	// CXLioCXLSemantic shall indicate the device conforms with the CXL
+	// Specification-defined 'CXL.io' semantic.
+	CXLioCXLSemantic CXLSemantic = "CXLio"
+	// CXLcacheCXLSemantic shall indicate the device conforms with the CXL
+	// Specification-defined 'CXL.cache' semantic.
+	CXLcacheCXLSemantic CXLSemantic = "CXLcache"
+	// CXLmemCXLSemantic shall indicate the device conforms with the CXL
+	// Specification-defined 'CXL.mem' semantic.
+	CXLmemCXLSemantic CXLSemantic = "CXLmem"
+)
+
+// CXLQoS shall contain the quality of service properties of a CXL logical
+// device.
+type CXLQoS struct {
+	// AllocatedBandwidth shall contain the bandwidth allocated for this
+	// logical device in multiples of 256 bytes.
+	AllocatedBandwidth int
+	// LimitPercent shall contain the bandwidth limit, in percent, for this
+	// logical device.
+	LimitPercent int
+}
+
+// QoSTelemetryCapabilities shall contain the quality of service telemetry
+// capabilities of a CXL device or port.
+type QoSTelemetryCapabilities struct {
+	// EgressPortBackpressureSupported shall indicate whether the device
+	// supports the CXL Specification-defined 'Egress Port Backpressure'
+	// mechanism.
+	EgressPortBackpressureSupported bool
+	// TemporaryThroughputReductionSupported shall indicate whether the device
+	// supports the CXL Specification-defined 'Temporary Throughput Reduction'
+	// mechanism.
+	TemporaryThroughputReductionSupported bool
+}
+
+// CXLLogicalDevice shall represent a CXL logical device that is a part of a
+// PCIe device, such as one of the logical devices of a multi-logical-device
+// memory expander, that can be bound to a host.
+type CXLLogicalDevice struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Identifiers shall contain a list of all known durable names for the
+	// logical device.
+	Identifiers []common.Identifier
+	// MemorySizeMiB shall contain the memory region size defined in this CXL
+	// logical device in mebibytes (MiB).
+	MemorySizeMiB int
+	// QoS shall contain the quality of service properties of this CXL
+	// logical device.
+	QoS CXLQoS
+	// QoSTelemetryCapabilities shall contain the quality of service
+	// telemetry capabilities for this CXL logical device.
+	QoSTelemetryCapabilities QoSTelemetryCapabilities
+	// SemanticsSupported shall contain the CXL Specification-defined
+	// semantics that are supported by this CXL logical device.
+	SemanticsSupported []CXLSemantic
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// log is the link to the log service of this CXL logical device.
+	log string
+	// endpoints are the endpoints associated with this CXL logical device.
+	endpoints []string
+	// memoryDomains are the memory domains associated with this CXL logical
+	// device.
+	memoryDomains []string
+	// pcieFunctions are the PCIe functions assigned to this CXL logical
+	// device.
+	pcieFunctions []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (cxllogicaldevice *CXLLogicalDevice) GetRawData() []byte {
+	return cxllogicaldevice.rawData
+}
+
+// UnmarshalJSON unmarshals a CXLLogicalDevice object from the raw JSON.
+func (cxllogicaldevice *CXLLogicalDevice) UnmarshalJSON(b []byte) error {
+	type temp CXLLogicalDevice
+	var t struct {
+		temp
+		Log   common.Link
+		Links struct {
+			Endpoints     common.Links
+			MemoryDomains common.Links
+			PCIeFunctions common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*cxllogicaldevice = CXLLogicalDevice(t.temp)
+
+	// Extract the links to other entities for later
+	cxllogicaldevice.log = string(t.Log)
+	cxllogicaldevice.endpoints = t.Links.Endpoints.ToStrings()
+	cxllogicaldevice.memoryDomains = t.Links.MemoryDomains.ToStrings()
+	cxllogicaldevice.pcieFunctions = t.Links.PCIeFunctions.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	cxllogicaldevice.rawData = b
+	cxllogicaldevice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the CXL logical device to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (cxllogicaldevice CXLLogicalDevice) MarshalJSON() ([]byte, error) {
+	type temp CXLLogicalDevice
+	return common.MarshalWithRawData(cxllogicaldevice.rawData, temp(cxllogicaldevice))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (cxllogicaldevice *CXLLogicalDevice) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(CXLLogicalDevice)
+	original.UnmarshalJSON(cxllogicaldevice.rawData)
+
+	readWriteFields := []string{
+		"QoS",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(cxllogicaldevice).Elem()
+
+	// The QoS is a nested object, which the generic update skips, so its
+	// writable properties are added to the payload.
+	nested := make(map[string]interface{})
+	qos := changedFields(
+		reflect.ValueOf(original.QoS), reflect.ValueOf(cxllogicaldevice.QoS),
+		"AllocatedBandwidth", "LimitPercent")
+	if len(qos) > 0 {
+		nested["QoS"] = qos
+	}
+
+	return cxllogicaldevice.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
+}
+
+// Log gets the log service of this CXL logical device.
+func (cxllogicaldevice *CXLLogicalDevice) Log() (*LogService, error) {
+	if cxllogicaldevice.log == "" {
+		return nil, nil
+	}
+
+	return GetLogService(cxllogicaldevice.Client, cxllogicaldevice.log)
+}
+
+// Endpoints gets the endpoints associated with this CXL logical device.
+func (cxllogicaldevice *CXLLogicalDevice) Endpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+	for _, endpointLink := range cxllogicaldevice.endpoints {
+		endpoint, err := GetEndpoint(cxllogicaldevice.Client, endpointLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, endpoint)
+	}
+
+	return result, nil
+}
+
+// MemoryDomains gets the memory domains associated with this CXL logical
+// device.
+func (cxllogicaldevice *CXLLogicalDevice) MemoryDomains() ([]*MemoryDomain, error) {
+	var result []*MemoryDomain
+	for _, memorydomainLink := range cxllogicaldevice.memoryDomains {
+		memorydomain, err := GetMemoryDomain(cxllogicaldevice.Client, memorydomainLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, memorydomain)
+	}
+
+	return result, nil
+}
+
+// PCIeFunctions gets the PCIe functions assigned to this CXL logical device.
+func (cxllogicaldevice *CXLLogicalDevice) PCIeFunctions() ([]*PCIeFunction, error) {
+	var result []*PCIeFunction
+	for _, pciefunctionLink := range cxllogicaldevice.pcieFunctions {
+		pciefunction, err := GetPCIeFunction(cxllogicaldevice.Client, pciefunctionLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, pciefunction)
+	}
+
+	return result, nil
+}
+
+// GetCXLLogicalDevice will get a CXLLogicalDevice instance from the service.
+func GetCXLLogicalDevice(c common.Client, uri string) (*CXLLogicalDevice, error) {
+	var cxllogicaldevice CXLLogicalDevice
+	err := common.FetchResource(c, uri, &cxllogicaldevice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cxllogicaldevice, nil
+}
+
+// ListReferencedCXLLogicalDevices gets the collection of CXLLogicalDevice from
+// a provided reference.
+func ListReferencedCXLLogicalDevices(c common.Client, link string) ([]*CXLLogicalDevice, error) {
+	var result []*CXLLogicalDevice
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, cxllogicaldeviceLink := range links.ItemLinks {
+		cxllogicaldevice := new(CXLLogicalDevice)
+		expanded, err := links.DecodeMember(c, cxllogicaldeviceLink, cxllogicaldevice)
+		if err == nil && !expanded {
+			cxllogicaldevice, err = GetCXLLogicalDevice(c, cxllogicaldeviceLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, cxllogicaldevice)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,166 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var cxlLogicalDeviceBody = `{
+		"@odata.type": "#CXLLogicalDevice.v1_1_0.CXLLogicalDevice",
+		"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices/1",
+		"Id": "1",
+		"Name": "CXL Logical Device Type 3 MLD",
+		"Identifiers": [
+			{
+				"DurableNameFormat": "GCXLID",
+				"DurableName": "4C-1D-96-FF-FE-DD-D8-35:0001"
+			}
+		],
+		"SemanticsSupported": ["CXLio", "CXLmem"],
+		"MemorySizeMiB": 32768,
+		"QoS": {
+			"AllocatedBandwidth": 64,
+			"LimitPercent": 50
+		},
+		"QoSTelemetryCapabilities": {
+			"EgressPortBackpressureSupported": true,
+			"TemporaryThroughputReductionSupported": false
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Log": {
+			"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices/1/Log"
+		},
+		"Links": {
+			"Endpoints": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/CXL/Endpoints/T1"
+				}
+			],
+			"MemoryDomains": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/CXL1/MemoryDomains/1"
+				}
+			],
+			"PCIeFunctions": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/PCIeFunctions/1"
+				}
+			]
+		}
+	}`
+
+// TestCXLLogicalDevice tests the parsing of CXLLogicalDevice objects.
+func TestCXLLogicalDevice(t *testing.T) {
+	var result CXLLogicalDevice
+	err := json.NewDecoder(strings.NewReader(cxlLogicalDeviceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MemorySizeMiB != 32768 {
+		t.Errorf("Invalid memory size: %d", result.MemorySizeMiB)
+	}
+
+	if len(result.SemanticsSupported) != 2 || result.SemanticsSupported[1] != CXLmemCXLSemantic {
+		t.Errorf("Invalid semantics: %v", result.SemanticsSupported)
+	}
+
+	if result.QoS.LimitPercent != 50 {
+		t.Errorf("Invalid QoS: %v", result.QoS)
+	}
+
+	if result.log != "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices/1/Log" {
+		t.Errorf("Invalid log link: %s", result.log)
+	}
+
+	if len(result.endpoints) != 1 || len(result.memoryDomains) != 1 || len(result.pcieFunctions) != 1 {
+		t.Errorf("Invalid links: %v %v %v", result.endpoints, result.memoryDomains, result.pcieFunctions)
+	}
+}
+
+// TestCXLLogicalDeviceUpdate tests the Update call.
+func TestCXLLogicalDeviceUpdate(t *testing.T) {
+	var result CXLLogicalDevice
+	err := json.NewDecoder(strings.NewReader(cxlLogicalDeviceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.QoS.LimitPercent = 25
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "QoS:map[LimitPercent:25]") {
+		t.Errorf("Unexpected QoS update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestPCIeDeviceCXLLogicalDevices tests getting the CXL logical devices of
+// a CXL memory expander.
+func TestPCIeDeviceCXLLogicalDevices(t *testing.T) {
+	var result PCIeDevice
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1",
+		"Id": "DEV1",
+		"CXLDevice": {
+			"DeviceType": "Type3",
+			"MaxNumberLogicalDevices": 4,
+			"EgressPortCongestionSupported": true
+		},
+		"CXLLogicalDevices": {
+			"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices"
+		}
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.CXLDevice.DeviceType != Type3CXLDeviceType || result.CXLDevice.MaxNumberLogicalDevices != 4 {
+		t.Errorf("Invalid CXL device: %v", result.CXLDevice)
+	}
+
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices": `{
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices/1"}
+			],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Chassis/CXL1/PCIeDevices/DEV1/CXLLogicalDevices/1": cxlLogicalDeviceBody,
+	}}
+	result.SetClient(testClient)
+
+	logicalDevices, err := result.CXLLogicalDevices()
+	if err != nil {
+		t.Fatalf("Error getting CXL logical devices: %s", err)
+	}
+
+	if len(logicalDevices) != 1 || logicalDevices[0].MemorySizeMiB != 32768 {
+		t.Errorf("Invalid CXL logical devices: %v", logicalDevices)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -164,6 +163,9 @@ type Drive struct {
 	IndicatorLED common.IndicatorLED
 	// Location shall contain location information of the associated drive.
 	Location []common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
 	// Manufacturer shall be the name of the organization responsible for
 	// producing the drive. This organization might be the entity from whom the
 	// drive is purchased, but this is not necessarily true.
@@ -232,6 +234,8 @@ type Drive struct {
 	PCIeFunctionCount int
 	storagePools      []string
 	StoragePoolsCount int
+	// metrics shall be a link to a resource of type DriveMetrics.
+	metrics string
 	// secureEraseTarget is the URL for SecureErase actions.
 	secureEraseTarget string
 	// rawData holds the original serialized JSON
@@ -271,6 +275,11 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 		Links    links
 		Actions  Actions
 		Assembly common.Link
+		Metrics  common.Link
+
+		// Some implementations send these with the wrong JSON type
+		CapacityBytes    common.Int64
+		RotationSpeedRPM common.Int
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -280,7 +289,10 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*drive = Drive(t.temp)
+	drive.CapacityBytes = int64(t.CapacityBytes)
+	drive.RotationSpeedRPM = int(t.RotationSpeedRPM)
 	drive.assembly = string(t.Assembly)
+	drive.metrics = string(t.Metrics)
 	drive.chassis = string(t.Links.Chassis)
 	drive.endpoints = t.Links.Endpoints.ToStrings()
 	drive.EndpointsCount = t.Links.EndpointCount
@@ -292,10 +304,18 @@ func (drive *Drive) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	drive.rawData = b
+	drive.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the drive to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (drive Drive) MarshalJSON() ([]byte, error) {
+	type temp Drive
+	return common.MarshalWithRawData(drive.rawData, temp(drive))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (drive *Drive) Update() error {
 
@@ -307,7 +327,9 @@ func (drive *Drive) Update() error {
 	readWriteFields := []string{
 		"AssetTag",
 		"HotspareReplacementMode",
+		"HotspareType",
 		"IndicatorLED",
+		"LocationIndicatorActive",
 		"StatusIndicator",
 		"WriteCacheEnabled",
 	}
@@ -320,25 +342,12 @@ func (drive *Drive) Update() error {
 
 // GetDrive will get a Drive instance from the service.
 func GetDrive(c common.Client, uri string) (*Drive, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var drive Drive
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &drive)
+	err := common.FetchResource(c, uri, &drive)
 	if err != nil {
 		return nil, err
 	}
 
-	drive.rawData = rawData
-	drive.SetClient(c)
 	return &drive, nil
 }
 
@@ -355,7 +364,11 @@ func ListReferencedDrives(c common.Client, link string) ([]*Drive, error) {
 	}
 
 	for _, driveLink := range links.ItemLinks {
-		drive, err := GetDrive(c, driveLink)
+		drive := new(Drive)
+		expanded, err := links.DecodeMember(c, driveLink, drive)
+		if err == nil && !expanded {
+			drive, err = GetDrive(c, driveLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -374,6 +387,15 @@ func (drive *Drive) Assembly() (*Assembly, error) {
 	return GetAssembly(drive.Client, drive.assembly)
 }
 
+// Metrics gets the usage and health statistics of this drive.
+func (drive *Drive) Metrics() (*DriveMetrics, error) {
+	if drive.metrics == "" {
+		return nil, nil
+	}
+
+	return GetDriveMetrics(drive.Client, drive.metrics)
+}
+
 // Chassis gets the containing chassis for this drive.
 func (drive *Drive) Chassis() (*Chassis, error) {
 	if drive.chassis == "" {
@@ -398,6 +420,11 @@ func (drive *Drive) Endpoints() ([]*Endpoint, error) {
 	return result, nil
 }
 
+// VolumeURIs gets the URIs of the Volumes that this drive is associated with.
+func (drive *Drive) VolumeURIs() []string {
+	return drive.volumes
+}
+
 // Volumes references the Volumes that this drive is associated with.
 func (drive *Drive) Volumes() ([]*Volume, error) {
 	var result []*Volume
@@ -24,6 +24,9 @@ var driveBody = `{
 				"target": "/redfish/v1/Chassis/NVMeChassis/Disk.Bay.0/Actions/Drive.SecureErase"
 			}
 		},
+		"Metrics": {
+			"@odata.id": "/redfish/v1/Chassis/1/Drives/1/Metrics"
+		},
 		"Assembly": {
 			"@odata.id": "/redfish/v1/Assembly/Assembly-1"
 		},
@@ -106,6 +109,10 @@ func TestDrive(t *testing.T) {
 		t.Errorf("Received invalid name: %s", result.Name)
 	}
 
+	if result.metrics != "/redfish/v1/Chassis/1/Drives/1/Metrics" {
+		t.Errorf("Invalid metrics link: %s", result.metrics)
+	}
+
 	if result.assembly != "/redfish/v1/Assembly/Assembly-1" {
 		t.Errorf("Incorrect assembly link: %s", result.assembly)
 	}
@@ -159,6 +166,7 @@ func TestDriveUpdate(t *testing.T) {
 	result.IndicatorLED = common.LitIndicatorLED
 	result.StatusIndicator = HotspareStatusIndicator
 	result.WriteCacheEnabled = false
+	result.HotspareType = GlobalHotspareType
 	err = result.Update()
 
 	if err != nil {
@@ -182,4 +190,8 @@ func TestDriveUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "WriteCacheEnabled:false") {
 		t.Errorf("Unexpected WriteCacheEnabled update payload: %s", calls[0].Payload)
 	}
+
+	if !strings.Contains(calls[0].Payload, "HotspareType:Global") {
+		t.Errorf("Unexpected HotspareType update payload: %s", calls[0].Payload)
+	}
 }
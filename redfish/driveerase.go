@@ -0,0 +1,147 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// DriveEraseOptions controls ComputerSystem.EraseAllDrives.
+type DriveEraseOptions struct {
+	// Wait controls the polling of the erase tasks.
+	Wait TaskWaitOptions
+}
+
+// DriveEraseResult is the outcome of erasing one drive with
+// ComputerSystem.EraseAllDrives.
+type DriveEraseResult struct {
+	// Drive is the URI of the drive, or of the SimpleStorage device.
+	Drive string
+	// Model and SerialNumber identify the drive in the report.
+	Model        string
+	SerialNumber string
+	// Supported tells whether the drive supports the SecureErase action.
+	// SimpleStorage devices never do.
+	Supported bool
+	// Task is the erase task once it finished, if the drive was erased.
+	Task *Task
+	// Err is why the drive could not be read or erased.
+	Err error
+}
+
+// Erased tells whether the drive was erased.
+func (result *DriveEraseResult) Erased() bool {
+	return result.Supported && result.Task != nil && result.Err == nil
+}
+
+// EraseAllDrives securely erases every drive of the system, for
+// decommissioning. The drives of all the Storage resources of the system
+// are erased at the same time, and their erase tasks polled until they
+// finish. The devices of the SimpleStorage resources are reported as not
+// supported, as they have no SecureErase action.
+//
+// It returns a result for each drive, and an error if the drives could not
+// be listed or if any drive could not be read or erased.
+func (computersystem *ComputerSystem) EraseAllDrives(ctx context.Context, options DriveEraseOptions) ([]DriveEraseResult, error) {
+	storages, err := computersystem.Storage()
+	if err != nil {
+		return nil, err
+	}
+
+	simpleStorages, err := computersystem.SimpleStorages()
+	if err != nil {
+		return nil, err
+	}
+
+	// Drives shared by storage subsystems are only erased once
+	var results []DriveEraseResult
+	drives := make(map[int]*Drive)
+	seen := make(map[string]bool)
+	for _, storage := range storages {
+		for _, driveLink := range storage.drives {
+			if seen[driveLink] {
+				continue
+			}
+			seen[driveLink] = true
+
+			drive, err := GetDrive(computersystem.Client, driveLink)
+			if err != nil {
+				results = append(results, DriveEraseResult{Drive: driveLink, Err: err})
+				continue
+			}
+
+			supported := drive.secureEraseTarget != ""
+			if supported {
+				drives[len(results)] = drive
+			}
+			results = append(results, DriveEraseResult{
+				Drive:        driveLink,
+				Model:        drive.Model,
+				SerialNumber: drive.SerialNumber,
+				Supported:    supported,
+			})
+		}
+	}
+
+	for _, simpleStorage := range simpleStorages {
+		for i, device := range simpleStorage.Devices {
+			results = append(results, DriveEraseResult{
+				Drive: fmt.Sprintf("%s#/Devices/%d", simpleStorage.ODataID, i),
+				Model: device.Model,
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, drive := range drives {
+		wg.Add(1)
+		go func(result *DriveEraseResult, drive *Drive) {
+			defer wg.Done()
+			result.Task, result.Err = drive.eraseAndWait(ctx, options.Wait)
+		}(&results[i], drive)
+	}
+	wg.Wait()
+
+	failed := 0
+	for i := range results {
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of the %d drives of system %s were not erased", failed, len(results), computersystem.ID)
+	}
+
+	return results, nil
+}
+
+// eraseAndWait securely erases the drive and waits for the erase task to
+// finish. It returns the task, and an error if the erase failed.
+func (drive *Drive) eraseAndWait(ctx context.Context, options TaskWaitOptions) (*Task, error) {
+	resp, err := drive.Client.Post(drive.secureEraseTarget, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := taskFromResponse(drive.Client, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err = task.Wait(ctx, options)
+	if err != nil {
+		return task, err
+	}
+
+	if task.TaskState != CompletedTaskState || task.TaskStatus == common.CriticalHealth {
+		return task, fmt.Errorf("secure erase task ended %s: %s", task.TaskState, taskMessages(task))
+	}
+
+	return task, nil
+}
@@ -0,0 +1,97 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// DriveMetrics shall contain the usage and health statistics for a drive in
+// a Redfish implementation.
+type DriveMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BadBlockCount shall contain the total number of bad blocks reported by
+	// the drive.
+	BadBlockCount int64
+	// CorrectableIOReadErrorCount shall contain the number of correctable
+	// read errors for the lifetime of the drive.
+	CorrectableIOReadErrorCount int64
+	// CorrectableIOWriteErrorCount shall contain the number of correctable
+	// write errors for the lifetime of the drive.
+	CorrectableIOWriteErrorCount int64
+	// Description provides a description of this resource.
+	Description string
+	// NativeCommandQueueDepth shall contain the current depth of the Native
+	// Command Queue as defined by the SATA Specification.
+	NativeCommandQueueDepth int
+	// PowerOnHours shall contain the number of power-on hours for the
+	// lifetime of the drive.
+	PowerOnHours float32
+	// ReadIOKiBytes shall contain the total number of kibibytes read from the
+	// time of last reset or wrap.
+	ReadIOKiBytes int64
+	// UncorrectableIOReadErrorCount shall contain the number of uncorrectable
+	// read errors for the lifetime of the drive.
+	UncorrectableIOReadErrorCount int64
+	// UncorrectableIOWriteErrorCount shall contain the number of
+	// uncorrectable write errors for the lifetime of the drive.
+	UncorrectableIOWriteErrorCount int64
+	// WriteIOKiBytes shall contain the total number of kibibytes written from
+	// the time of last reset or wrap.
+	WriteIOKiBytes int64
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (drivemetrics *DriveMetrics) GetRawData() []byte {
+	return drivemetrics.rawData
+}
+
+// UnmarshalJSON unmarshals a DriveMetrics object from the raw JSON.
+func (drivemetrics *DriveMetrics) UnmarshalJSON(b []byte) error {
+	type temp DriveMetrics
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*drivemetrics = DriveMetrics(t.temp)
+	drivemetrics.rawData = b
+	drivemetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the drive metrics to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (drivemetrics DriveMetrics) MarshalJSON() ([]byte, error) {
+	type temp DriveMetrics
+	return common.MarshalWithRawData(drivemetrics.rawData, temp(drivemetrics))
+}
+
+// GetDriveMetrics will get a DriveMetrics instance from the service.
+func GetDriveMetrics(c common.Client, uri string) (*DriveMetrics, error) {
+	var drivemetrics DriveMetrics
+	err := common.FetchResource(c, uri, &drivemetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &drivemetrics, nil
+}
@@ -0,0 +1,211 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+// ElectricalContext is the combination of current-carrying conductors that
+// distribute power.
+type ElectricalContext string
+
+const (
+	// Line1ElectricalContext The circuits that share the L1 current-carrying
+	// conductor.
+	Line1ElectricalContext ElectricalContext = "Line1"
+	// Line2ElectricalContext The circuits that share the L2 current-carrying
+	// conductor.
+	Line2ElectricalContext ElectricalContext = "Line2"
+	// Line3ElectricalContext The circuits that share the L3 current-carrying
+	// conductor.
+	Line3ElectricalContext ElectricalContext = "Line3"
+	// NeutralElectricalContext The grounded current-carrying return circuit
+	// of current-carrying conductors.
+	NeutralElectricalContext ElectricalContext = "Neutral"
+	// LineToLineElectricalContext The circuit formed by two
+	// current-carrying conductors.
+	LineToLineElectricalContext ElectricalContext = "LineToLine"
+	// Line1ToLine2ElectricalContext The circuit formed by L1 and L2
+	// current-carrying conductors.
+	Line1ToLine2ElectricalContext ElectricalContext = "Line1ToLine2"
+	// Line2ToLine3ElectricalContext The circuit formed by L2 and L3
+	// current-carrying conductors.
+	Line2ToLine3ElectricalContext ElectricalContext = "Line2ToLine3"
+	// Line3ToLine1ElectricalContext The circuit formed by L3 and L1
+	// current-carrying conductors.
+	Line3ToLine1ElectricalContext ElectricalContext = "Line3ToLine1"
+	// LineToNeutralElectricalContext The circuit formed by a line and
+	// neutral current-carrying conductor.
+	LineToNeutralElectricalContext ElectricalContext = "LineToNeutral"
+	// Line1ToNeutralElectricalContext The circuit formed by L1 and neutral
+	// current-carrying conductors.
+	Line1ToNeutralElectricalContext ElectricalContext = "Line1ToNeutral"
+	// Line2ToNeutralElectricalContext The circuit formed by L2 and neutral
+	// current-carrying conductors.
+	Line2ToNeutralElectricalContext ElectricalContext = "Line2ToNeutral"
+	// Line3ToNeutralElectricalContext The circuit formed by L3 and neutral
+	// current-carrying conductors.
+	Line3ToNeutralElectricalContext ElectricalContext = "Line3ToNeutral"
+	// TotalElectricalContext The circuit formed by all current-carrying
+	// conductors for any electrical phasing.
+	TotalElectricalContext ElectricalContext = "Total"
+)
+
+// NominalVoltageType is the nominal voltage of a power input or output.
+type NominalVoltageType string
+
+const (
+	// AC100To127VNominalVoltageType AC 100-127V nominal.
+	AC100To127VNominalVoltageType NominalVoltageType = "AC100To127V"
+	// AC100To240VNominalVoltageType AC 100-240V nominal.
+	AC100To240VNominalVoltageType NominalVoltageType = "AC100To240V"
+	// AC100To277VNominalVoltageType AC 100-277V nominal.
+	AC100To277VNominalVoltageType NominalVoltageType = "AC100To277V"
+	// AC120VNominalVoltageType AC 120V nominal.
+	AC120VNominalVoltageType NominalVoltageType = "AC120V"
+	// AC200To240VNominalVoltageType AC 200-240V nominal.
+	AC200To240VNominalVoltageType NominalVoltageType = "AC200To240V"
+	// AC200To277VNominalVoltageType AC 200-277V nominal.
+	AC200To277VNominalVoltageType NominalVoltageType = "AC200To277V"
+	// AC208VNominalVoltageType AC 208V nominal.
+	AC208VNominalVoltageType NominalVoltageType = "AC208V"
+	// AC230VNominalVoltageType AC 230V nominal.
+	AC230VNominalVoltageType NominalVoltageType = "AC230V"
+	// AC240VNominalVoltageType AC 240V nominal.
+	AC240VNominalVoltageType NominalVoltageType = "AC240V"
+	// AC240AndDC380VNominalVoltageType AC 200-240V and DC 380V.
+	AC240AndDC380VNominalVoltageType NominalVoltageType = "AC240AndDC380V"
+	// AC277VNominalVoltageType AC 277V nominal.
+	AC277VNominalVoltageType NominalVoltageType = "AC277V"
+	// AC277AndDC380VNominalVoltageType AC 277V and DC 380V.
+	AC277AndDC380VNominalVoltageType NominalVoltageType = "AC277AndDC380V"
+	// AC400VNominalVoltageType AC 400V or 415V nominal.
+	AC400VNominalVoltageType NominalVoltageType = "AC400V"
+	// AC480VNominalVoltageType AC 480V nominal.
+	AC480VNominalVoltageType NominalVoltageType = "AC480V"
+	// DC48VNominalVoltageType DC 48V nominal.
+	DC48VNominalVoltageType NominalVoltageType = "DC48V"
+	// DC240VNominalVoltageType DC 240V nominal.
+	DC240VNominalVoltageType NominalVoltageType = "DC240V"
+	// DC380VNominalVoltageType High Voltage DC (380V).
+	DC380VNominalVoltageType NominalVoltageType = "DC380V"
+	// DCNeg48VNominalVoltageType -48V DC.
+	DCNeg48VNominalVoltageType NominalVoltageType = "DCNeg48V"
+)
+
+// PhaseWiringType is the number of ungrounded current-carrying conductors
+// (phases) and the total number of conductors (wires).
+type PhaseWiringType string
+
+const (
+	// OnePhase3WirePhaseWiringType Single or Two-Phase / 3-Wire (Line1,
+	// Line2 or Neutral, Protective Earth).
+	OnePhase3WirePhaseWiringType PhaseWiringType = "OnePhase3Wire"
+	// TwoPhase3WirePhaseWiringType Two-Phase / 3-Wire (Line1, Line2,
+	// Protective Earth).
+	TwoPhase3WirePhaseWiringType PhaseWiringType = "TwoPhase3Wire"
+	// OneOrTwoPhase3WirePhaseWiringType Single or Two-Phase / 3-Wire
+	// (Line1, Line2 or Neutral, Protective Earth).
+	OneOrTwoPhase3WirePhaseWiringType PhaseWiringType = "OneOrTwoPhase3Wire"
+	// TwoPhase4WirePhaseWiringType Two-Phase / 4-Wire (Line1, Line2,
+	// Neutral, Protective Earth).
+	TwoPhase4WirePhaseWiringType PhaseWiringType = "TwoPhase4Wire"
+	// ThreePhase4WirePhaseWiringType Three-Phase / 4-Wire (Line1, Line2,
+	// Line3, Protective Earth).
+	ThreePhase4WirePhaseWiringType PhaseWiringType = "ThreePhase4Wire"
+	// ThreePhase5WirePhaseWiringType Three-Phase / 5-Wire (Line1, Line2,
+	// Line3, Neutral, Protective Earth).
+	ThreePhase5WirePhaseWiringType PhaseWiringType = "ThreePhase5Wire"
+)
+
+// VoltageType is the type of voltage.
+type VoltageType string
+
+const (
+	// ACVoltageType Alternating Current (AC).
+	ACVoltageType VoltageType = "AC"
+	// DCVoltageType Direct Current (DC).
+	DCVoltageType VoltageType = "DC"
+)
+
+// CurrentSensors shall contain properties that describe current sensor
+// readings for each line of a poly-phase circuit.
+type CurrentSensors struct {
+	// Line1 shall contain the line current, in ampere units, for L1.
+	Line1 SensorCurrentExcerpt
+	// Line2 shall contain the line current, in ampere units, for L2.
+	Line2 SensorCurrentExcerpt
+	// Line3 shall contain the line current, in ampere units, for L3.
+	Line3 SensorCurrentExcerpt
+	// Neutral shall contain the line current, in ampere units, for the
+	// neutral line.
+	Neutral SensorCurrentExcerpt
+}
+
+// VoltageSensors shall contain properties that describe voltage sensor
+// readings between the lines of a poly-phase circuit.
+type VoltageSensors struct {
+	// Line1ToLine2 shall contain the line-to-line voltage, in volt units,
+	// between L1 and L2.
+	Line1ToLine2 SensorVoltageExcerpt
+	// Line1ToNeutral shall contain the line-to-neutral voltage, in volt
+	// units, between L1 and neutral.
+	Line1ToNeutral SensorVoltageExcerpt
+	// Line2ToLine3 shall contain the line-to-line voltage, in volt units,
+	// between L2 and L3.
+	Line2ToLine3 SensorVoltageExcerpt
+	// Line2ToNeutral shall contain the line-to-neutral voltage, in volt
+	// units, between L2 and neutral.
+	Line2ToNeutral SensorVoltageExcerpt
+	// Line3ToLine1 shall contain the line-to-line voltage, in volt units,
+	// between L3 and L1.
+	Line3ToLine1 SensorVoltageExcerpt
+	// Line3ToNeutral shall contain the line-to-neutral voltage, in volt
+	// units, between L3 and neutral.
+	Line3ToNeutral SensorVoltageExcerpt
+}
+
+// EnergySensors shall contain properties that describe energy sensor
+// readings between the lines of a poly-phase circuit.
+type EnergySensors struct {
+	// Line1ToLine2 shall contain the energy, in kilowatt-hour units, between
+	// L1 and L2.
+	Line1ToLine2 SensorEnergykWhExcerpt
+	// Line1ToNeutral shall contain the energy, in kilowatt-hour units,
+	// between L1 and neutral.
+	Line1ToNeutral SensorEnergykWhExcerpt
+	// Line2ToLine3 shall contain the energy, in kilowatt-hour units, between
+	// L2 and L3.
+	Line2ToLine3 SensorEnergykWhExcerpt
+	// Line2ToNeutral shall contain the energy, in kilowatt-hour units,
+	// between L2 and neutral.
+	Line2ToNeutral SensorEnergykWhExcerpt
+	// Line3ToLine1 shall contain the energy, in kilowatt-hour units, between
+	// L3 and L1.
+	Line3ToLine1 SensorEnergykWhExcerpt
+	// Line3ToNeutral shall contain the energy, in kilowatt-hour units,
+	// between L3 and neutral.
+	Line3ToNeutral SensorEnergykWhExcerpt
+}
+
+// PowerSensors shall contain properties that describe power sensor readings
+// between the lines of a poly-phase circuit.
+type PowerSensors struct {
+	// Line1ToLine2 shall contain the power, in watt units, between L1 and
+	// L2.
+	Line1ToLine2 SensorPowerExcerpt
+	// Line1ToNeutral shall contain the power, in watt units, between L1 and
+	// neutral.
+	Line1ToNeutral SensorPowerExcerpt
+	// Line2ToLine3 shall contain the power, in watt units, between L2 and
+	// L3.
+	Line2ToLine3 SensorPowerExcerpt
+	// Line2ToNeutral shall contain the power, in watt units, between L2 and
+	// neutral.
+	Line2ToNeutral SensorPowerExcerpt
+	// Line3ToLine1 shall contain the power, in watt units, between L3 and
+	// L1.
+	Line3ToLine1 SensorPowerExcerpt
+	// Line3ToNeutral shall contain the power, in watt units, between L3 and
+	// neutral.
+	Line3ToNeutral SensorPowerExcerpt
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -221,31 +220,27 @@ func (endpoint *Endpoint) UnmarshalJSON(b []byte) error {
 	endpoint.NetworkDeviceFunctionCount = t.Links.NetworkDeviceFunctionCount
 	endpoint.ports = t.Links.Ports.ToStrings()
 	endpoint.PortsCount = t.Links.PortsCount
+	endpoint.rawData = b
+	endpoint.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the endpoint to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (endpoint Endpoint) MarshalJSON() ([]byte, error) {
+	type temp Endpoint
+	return common.MarshalWithRawData(endpoint.rawData, temp(endpoint))
+}
+
 // GetEndpoint will get a Endpoint instance from the service.
 func GetEndpoint(c common.Client, uri string) (*Endpoint, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var endpoint Endpoint
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &endpoint)
+	err := common.FetchResource(c, uri, &endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoint.rawData = rawData
-	endpoint.SetClient(c)
 	return &endpoint, nil
 }
 
@@ -263,7 +258,11 @@ func ListReferencedEndpoints(c common.Client, link string) ([]*Endpoint, error)
 	}
 
 	for _, endpointLink := range links.ItemLinks {
-		endpoint, err := GetEndpoint(c, endpointLink)
+		endpoint := new(Endpoint)
+		expanded, err := links.DecodeMember(c, endpointLink, endpoint)
+		if err == nil && !expanded {
+			endpoint, err = GetEndpoint(c, endpointLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,138 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ControlSingleLoopExcerpt shall contain the properties of a control, such as
+// a power limit, that uses a single set point.
+type ControlSingleLoopExcerpt struct {
+	// AllowableMax shall contain the maximum possible value of the SetPoint.
+	AllowableMax float32
+	// AllowableMin shall contain the minimum possible value of the SetPoint.
+	AllowableMin float32
+	// ControlMode shall contain the operating mode of the control, such as
+	// Automatic, Override, Manual or Disabled.
+	ControlMode string
+	// DataSourceURI shall contain a URI to the Control resource that
+	// provides the data for this excerpt.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the value of the sensor the control operates on.
+	Reading float32
+	// ReadingUnits shall contain the units of the sensor reading.
+	ReadingUnits string
+	// SetPoint shall contain the desired value of the control.
+	SetPoint float32
+}
+
+// EnvironmentMetrics shall contain the environmental metrics, such as the
+// power consumption and the temperature, of a device.
+type EnvironmentMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DewPointCelsius shall contain the dew point, in degree Celsius units,
+	// based on the temperature and humidity values for this device.
+	DewPointCelsius SensorExcerpt
+	// EnergyJoules shall contain the total energy, in joule units, for this
+	// device, for devices that report energy in joules.
+	EnergyJoules SensorExcerpt
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this device.
+	EnergykWh SensorEnergykWhExcerpt
+	// FanSpeedsPercent shall contain the fan speeds, in percent units, for
+	// this device.
+	FanSpeedsPercent []SensorExcerpt
+	// HumidityPercent shall contain the humidity, in percent units, for
+	// this device.
+	HumidityPercent SensorExcerpt
+	// PowerLimitWatts shall contain the power limit control, in watt units,
+	// for this device.
+	PowerLimitWatts ControlSingleLoopExcerpt
+	// PowerWatts shall contain the total power, in watt units, for this
+	// device.
+	PowerWatts SensorPowerExcerpt
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this device.
+	TemperatureCelsius SensorExcerpt
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (environmentmetrics *EnvironmentMetrics) GetRawData() []byte {
+	return environmentmetrics.rawData
+}
+
+// UnmarshalJSON unmarshals an EnvironmentMetrics object from the raw JSON.
+func (environmentmetrics *EnvironmentMetrics) UnmarshalJSON(b []byte) error {
+	type temp EnvironmentMetrics
+	type Actions struct {
+		ResetMetrics struct {
+			Target string
+		} `json:"#EnvironmentMetrics.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*environmentmetrics = EnvironmentMetrics(t.temp)
+	environmentmetrics.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	environmentmetrics.rawData = b
+	environmentmetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the environment metrics to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (environmentmetrics EnvironmentMetrics) MarshalJSON() ([]byte, error) {
+	type temp EnvironmentMetrics
+	return common.MarshalWithRawData(environmentmetrics.rawData, temp(environmentmetrics))
+}
+
+// ResetMetrics resets the summary metrics, such as the energy counters, of
+// this device.
+func (environmentmetrics *EnvironmentMetrics) ResetMetrics() error {
+	if environmentmetrics.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this device")
+	}
+
+	_, err := environmentmetrics.Client.Post(environmentmetrics.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// GetEnvironmentMetrics will get an EnvironmentMetrics instance from the
+// service.
+func GetEnvironmentMetrics(c common.Client, uri string) (*EnvironmentMetrics, error) {
+	var environmentmetrics EnvironmentMetrics
+	err := common.FetchResource(c, uri, &environmentmetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &environmentmetrics, nil
+}
@@ -0,0 +1,105 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var environmentMetricsBody = `{
+		"@odata.type": "#EnvironmentMetrics.v1_3_0.EnvironmentMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+		"Id": "EnvironmentMetrics",
+		"Name": "Chassis Environment Metrics",
+		"TemperatureCelsius": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/AmbientTemp",
+			"Reading": 25.4
+		},
+		"HumidityPercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/Humidity",
+			"Reading": 42.5
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/TotalPower",
+			"Reading": 374.2
+		},
+		"EnergykWh": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/TotalEnergy",
+			"Reading": 1261.2
+		},
+		"PowerLimitWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Controls/PowerLimit",
+			"SetPoint": 500,
+			"AllowableMin": 150,
+			"AllowableMax": 800,
+			"ControlMode": "Automatic"
+		},
+		"FanSpeedsPercent": [
+			{
+				"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/Fan1",
+				"Reading": 45
+			}
+		],
+		"Actions": {
+			"#EnvironmentMetrics.ResetMetrics": {
+				"target": "/redfish/v1/Chassis/1U/EnvironmentMetrics/Actions/EnvironmentMetrics.ResetMetrics"
+			}
+		}
+	}`
+
+// TestEnvironmentMetrics tests the parsing of EnvironmentMetrics objects.
+func TestEnvironmentMetrics(t *testing.T) {
+	var result EnvironmentMetrics
+	err := json.NewDecoder(strings.NewReader(environmentMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "EnvironmentMetrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.PowerWatts.Reading != 374.2 {
+		t.Errorf("Invalid power reading: %f", result.PowerWatts.Reading)
+	}
+
+	if result.PowerLimitWatts.SetPoint != 500 || result.PowerLimitWatts.AllowableMax != 800 {
+		t.Errorf("Invalid power limit: %v", result.PowerLimitWatts)
+	}
+
+	if len(result.FanSpeedsPercent) != 1 || result.FanSpeedsPercent[0].Reading != 45 {
+		t.Errorf("Invalid fan speeds: %v", result.FanSpeedsPercent)
+	}
+}
+
+// TestEnvironmentMetricsResetMetrics tests the ResetMetrics call.
+func TestEnvironmentMetricsResetMetrics(t *testing.T) {
+	var result EnvironmentMetrics
+	err := json.NewDecoder(strings.NewReader(environmentMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ResetMetrics()
+
+	if err != nil {
+		t.Errorf("Error making ResetMetrics call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.HasSuffix(calls[0].URL, "EnvironmentMetrics.ResetMetrics") {
+		t.Errorf("Unexpected ResetMetrics URL: %s", calls[0].URL)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -235,6 +234,12 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 		temp
 		Links links
 		VLANs common.Link
+
+		// Some implementations send these with the wrong JSON type
+		AutoNeg          common.Bool
+		FullDuplex       common.Bool
+		InterfaceEnabled common.Bool
+		SpeedMbps        common.Int
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -243,6 +248,10 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 	}
 
 	*ethernetinterface = EthernetInterface(t.temp)
+	ethernetinterface.AutoNeg = bool(t.AutoNeg)
+	ethernetinterface.FullDuplex = bool(t.FullDuplex)
+	ethernetinterface.InterfaceEnabled = bool(t.InterfaceEnabled)
+	ethernetinterface.SpeedMbps = int(t.SpeedMbps)
 
 	// Extract the links to other entities for later
 	ethernetinterface.chassis = string(t.Links.Chassis)
@@ -253,10 +262,18 @@ func (ethernetinterface *EthernetInterface) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	ethernetinterface.rawData = b
+	ethernetinterface.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the ethernet interface to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (ethernetinterface EthernetInterface) MarshalJSON() ([]byte, error) {
+	type temp EthernetInterface
+	return common.MarshalWithRawData(ethernetinterface.rawData, temp(ethernetinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (ethernetinterface *EthernetInterface) Update() error {
 
@@ -285,25 +302,12 @@ func (ethernetinterface *EthernetInterface) Update() error {
 
 // GetEthernetInterface will get a EthernetInterface instance from the service.
 func GetEthernetInterface(c common.Client, uri string) (*EthernetInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var ethernetInterface EthernetInterface
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &ethernetInterface)
+	err := common.FetchResource(c, uri, &ethernetInterface)
 	if err != nil {
 		return nil, err
 	}
 
-	ethernetInterface.rawData = rawData
-	ethernetInterface.SetClient(c)
 	return &ethernetInterface, nil
 }
 
@@ -321,7 +325,11 @@ func ListReferencedEthernetInterfaces(c common.Client, link string) ([]*Ethernet
 	}
 
 	for _, ethernetinterfaceLink := range links.ItemLinks {
-		ethernetinterface, err := GetEthernetInterface(c, ethernetinterfaceLink)
+		ethernetinterface := new(EthernetInterface)
+		expanded, err := links.DecodeMember(c, ethernetinterfaceLink, ethernetinterface)
+		if err == nil && !expanded {
+			ethernetinterface, err = GetEthernetInterface(c, ethernetinterfaceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,110 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// EventRecord shall contain the properties of an event record, one of the
+// occurrences an Event reports.
+type EventRecord struct {
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is sent.
+	Context string
+	// EventGroupID shall indicate that events are related and shall have the
+	// same value in the case where multiple event messages are produced by
+	// the same root cause.
+	EventGroupID int `json:"EventGroupId"`
+	// EventID shall be a service defined unique identifier for the event.
+	EventID string `json:"EventId"`
+	// EventTimestamp shall be the time the event occurred.
+	EventTimestamp string
+	// EventType shall indicate the type of event, such as Alert or
+	// StatusChange. It is deprecated in newer schemas, which send Other.
+	EventType string
+	// MemberID shall uniquely identify the member within the collection.
+	MemberID string `json:"MemberId"`
+	// Message shall contain a human readable event message.
+	Message string
+	// MessageArgs shall contain the message substitution arguments for the
+	// message referenced by the MessageID.
+	MessageArgs []string
+	// MessageID shall be the key for the message in a message registry, such
+	// as Base.1.8.ResourceChanged.
+	MessageID string `json:"MessageId"`
+	// MessageSeverity shall contain the severity of the message.
+	MessageSeverity common.Health
+	// OriginOfCondition shall be the URI of the resource that originated the
+	// condition that caused the event to be generated.
+	OriginOfCondition string
+	// Severity shall be the severity of the event. It is deprecated in favor
+	// of MessageSeverity.
+	Severity string
+}
+
+// UnmarshalJSON unmarshals an EventRecord object from the raw JSON.
+func (eventrecord *EventRecord) UnmarshalJSON(b []byte) error {
+	type temp EventRecord
+	var t struct {
+		temp
+		OriginOfCondition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*eventrecord = EventRecord(t.temp)
+	eventrecord.OriginOfCondition = string(t.OriginOfCondition)
+
+	return nil
+}
+
+// Event shall be the payload a service sends to the event destinations
+// subscribed with the Event event format, holding one or more event records.
+type Event struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is sent.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// Events shall contain the event records of the event.
+	Events []EventRecord
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (event *Event) GetRawData() []byte {
+	return event.rawData
+}
+
+// UnmarshalJSON unmarshals an Event object from the raw JSON.
+func (event *Event) UnmarshalJSON(b []byte) error {
+	type temp Event
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*event = Event(t.temp)
+	event.rawData = b
+
+	return nil
+}
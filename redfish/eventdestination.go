@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -75,6 +74,9 @@ type SNMPEncryptionProtocols string
 
 const (
 
+	// AccountSNMPEncryptionProtocols shall indicate encryption is
+	// determined by account settings.
+	AccountSNMPEncryptionProtocols SNMPEncryptionProtocols = "Account"
 	// NoneSNMPEncryptionProtocols shall indicate there is no encryption.
 	NoneSNMPEncryptionProtocols SNMPEncryptionProtocols = "None"
 	// CBCDESSNMPEncryptionProtocols shall indicate encryption conforms to
@@ -236,10 +238,18 @@ func (eventdestination *EventDestination) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	eventdestination.rawData = b
+	eventdestination.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the event destination to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (eventdestination EventDestination) MarshalJSON() ([]byte, error) {
+	type temp EventDestination
+	return common.MarshalWithRawData(eventdestination.rawData, temp(eventdestination))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (eventdestination *EventDestination) Update() error {
 
@@ -261,25 +271,12 @@ func (eventdestination *EventDestination) Update() error {
 
 // GetEventDestination will get a EventDestination instance from the service.
 func GetEventDestination(c common.Client, uri string) (*EventDestination, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var eventDestination EventDestination
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &eventDestination)
+	err := common.FetchResource(c, uri, &eventDestination)
 	if err != nil {
 		return nil, err
 	}
 
-	eventDestination.rawData = rawData
-	eventDestination.SetClient(c)
 	return &eventDestination, nil
 }
 
@@ -297,7 +294,11 @@ func ListReferencedEventDestinations(c common.Client, link string) ([]*EventDest
 	}
 
 	for _, eventdestinationLink := range links.ItemLinks {
-		eventdestination, err := GetEventDestination(c, eventdestinationLink)
+		eventdestination := new(EventDestination)
+		expanded, err := links.DecodeMember(c, eventdestinationLink, eventdestination)
+		if err == nil && !expanded {
+			eventdestination, err = GetEventDestination(c, eventdestinationLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,209 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxEventSize is the largest server-sent event read from an event stream.
+const maxEventSize = 4 * 1024 * 1024
+
+// DecodeEventPayload decodes a payload a service sent to an event
+// destination. Destinations subscribed with the MetricReport event format
+// receive metric reports instead of events; either the event or the metric
+// report is returned, told apart by their @odata.type, or by their
+// properties for services that leave it out.
+func DecodeEventPayload(payload []byte) (*Event, *MetricReport, error) {
+	var t struct {
+		ODataType    string `json:"@odata.type"`
+		Events       json.RawMessage
+		MetricValues json.RawMessage
+	}
+	err := json.Unmarshal(payload, &t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(t.ODataType, "#MetricReport."),
+		t.ODataType == "" && t.MetricValues != nil:
+		var metricReport MetricReport
+		err = json.Unmarshal(payload, &metricReport)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &metricReport, nil
+	case strings.HasPrefix(t.ODataType, "#Event."),
+		t.ODataType == "" && t.Events != nil:
+		var event Event
+		err = json.Unmarshal(payload, &event)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &event, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("the payload is neither an event nor a metric report: %s", t.ODataType)
+}
+
+// EventReceiver receives the payloads a service sends to an event
+// destination, and sends the events and the metric reports on their own
+// channels. It serves the destination URI of push subscriptions as an
+// http.Handler, and reads the server-sent event stream of an event service
+// with ReadEventStream.
+type EventReceiver struct {
+	// Events receives the events.
+	Events chan *Event
+	// MetricReports receives the metric reports, sent to the subscriptions
+	// with the MetricReport event format.
+	MetricReports chan *MetricReport
+}
+
+// NewEventReceiver makes an EventReceiver whose channels buffer size
+// payloads. The receiver blocks once a channel is full, so both channels
+// have to be read.
+func NewEventReceiver(size int) *EventReceiver {
+	return &EventReceiver{
+		Events:        make(chan *Event, size),
+		MetricReports: make(chan *MetricReport, size),
+	}
+}
+
+// Receive decodes a payload and sends it on its channel, waiting for it to
+// be received until ctx is done.
+func (receiver *EventReceiver) Receive(ctx context.Context, payload []byte) error {
+	event, metricReport, err := DecodeEventPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	if metricReport != nil {
+		select {
+		case receiver.MetricReports <- metricReport:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	select {
+	case receiver.Events <- event:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// ServeHTTP receives the payloads the service posts to the destination.
+func (receiver *EventReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = receiver.Receive(r.Context(), payload)
+	if err != nil && r.Context().Err() == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReadEventStream reads the server-sent events of stream and receives their
+// data until the stream ends or ctx is done. The event stream comments,
+// used as keep-alives, and the other fields of the events are skipped.
+func (receiver *EventReceiver) ReadEventStream(ctx context.Context, stream io.Reader) error {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(nil, maxEventSize)
+
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line ends the event
+			if data.Len() > 0 {
+				err := receiver.Receive(ctx, data.Bytes())
+				if err != nil {
+					return err
+				}
+				data.Reset()
+			}
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		if field != "data" {
+			continue
+		}
+		if data.Len() > 0 {
+			data.WriteByte('\n')
+		}
+		data.WriteString(strings.TrimPrefix(value, " "))
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// StreamEvents opens the server-sent event stream of the event service and
+// receives its events and metric reports with receiver, until the service
+// ends the stream or ctx is done. The filter, if set, is sent as the $filter
+// of the stream, such as "EventFormatType eq 'MetricReport'".
+func (eventservice *EventService) StreamEvents(ctx context.Context, filter string, receiver *EventReceiver) error {
+	if eventservice.ServerSentEventURI == "" {
+		return fmt.Errorf("this event service does not have a server-sent event stream")
+	}
+
+	uri := eventservice.ServerSentEventURI
+	if parsed, err := url.Parse(uri); err == nil && parsed.IsAbs() {
+		uri = parsed.RequestURI()
+	}
+	if filter != "" {
+		separator := "?"
+		if strings.Contains(uri, "?") {
+			separator = "&"
+		}
+		uri += separator + "$filter=" + url.QueryEscape(filter)
+	}
+
+	resp, err := eventservice.Client.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Closing the body stops reading the stream when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			resp.Body.Close()
+		case <-done:
+		}
+	}()
+
+	return receiver.ReadEventStream(ctx, resp.Body)
+}
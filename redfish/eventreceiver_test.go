@@ -0,0 +1,174 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var eventBody = `{
+		"@odata.type": "#Event.v1_7_0.Event",
+		"Id": "1",
+		"Name": "Event Array",
+		"Context": "ContosoWebClient",
+		"Events": [
+			{
+				"EventType": "Other",
+				"EventId": "4593",
+				"Severity": "Warning",
+				"MessageSeverity": "Warning",
+				"Message": "The LAN has been disconnected",
+				"MessageId": "Alert.1.0.LanDisconnect",
+				"MessageArgs": ["EthernetInterface 1", "/redfish/v1/Systems/1"],
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"
+				},
+				"Context": "ContosoWebClient"
+			}
+		]
+	}`
+
+var metricReportBody = `{
+		"@odata.type": "#MetricReport.v1_4_2.MetricReport",
+		"@odata.id": "/redfish/v1/TelemetryService/MetricReports/AvgPlatformPowerUsage",
+		"Id": "AvgPlatformPowerUsage",
+		"Name": "Average Platform Power Usage metric report",
+		"ReportSequence": "127",
+		"Timestamp": "2016-11-08T12:25:00-05:00",
+		"MetricReportDefinition": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions/AvgPlatformPowerUsage"
+		},
+		"MetricValues": [
+			{
+				"MetricId": "AverageConsumedWatts",
+				"MetricValue": "100",
+				"Timestamp": "2016-11-08T12:25:00-05:00",
+				"MetricProperty": "/redfish/v1/Chassis/Tray_1/Power#/0/PowerConsumedWatts"
+			}
+		]
+	}`
+
+// TestDecodeEventPayload tests telling events and metric reports apart.
+func TestDecodeEventPayload(t *testing.T) {
+	event, metricReport, err := DecodeEventPayload([]byte(eventBody))
+	if err != nil {
+		t.Fatalf("Error decoding event: %s", err)
+	}
+	if event == nil || metricReport != nil {
+		t.Fatalf("The payload should be an event: %v %v", event, metricReport)
+	}
+	if len(event.Events) != 1 || event.Events[0].MessageID != "Alert.1.0.LanDisconnect" {
+		t.Errorf("Invalid event records: %v", event.Events)
+	}
+	if event.Events[0].OriginOfCondition != "/redfish/v1/Systems/1/EthernetInterfaces/1" {
+		t.Errorf("Invalid origin of condition: %s", event.Events[0].OriginOfCondition)
+	}
+
+	event, metricReport, err = DecodeEventPayload([]byte(metricReportBody))
+	if err != nil {
+		t.Fatalf("Error decoding metric report: %s", err)
+	}
+	if event != nil || metricReport == nil {
+		t.Fatalf("The payload should be a metric report: %v %v", event, metricReport)
+	}
+	if metricReport.ReportSequence != "127" ||
+		metricReport.MetricReportDefinition != "/redfish/v1/TelemetryService/MetricReportDefinitions/AvgPlatformPowerUsage" {
+		t.Errorf("Invalid metric report: %v", metricReport)
+	}
+	if len(metricReport.MetricValues) != 1 || metricReport.MetricValues[0].MetricValue != "100" {
+		t.Errorf("Invalid metric values: %v", metricReport.MetricValues)
+	}
+
+	// Without @odata.type, the properties tell them apart
+	_, metricReport, err = DecodeEventPayload([]byte(`{"Id": "1", "MetricValues": []}`))
+	if err != nil || metricReport == nil {
+		t.Errorf("The untyped payload should be a metric report: %v", err)
+	}
+
+	_, _, err = DecodeEventPayload([]byte(`{"@odata.type": "#Chassis.v1_10_0.Chassis"}`))
+	if err == nil {
+		t.Errorf("Other resources should not be decoded")
+	}
+}
+
+// TestEventReceiverServeHTTP tests receiving pushed payloads.
+func TestEventReceiverServeHTTP(t *testing.T) {
+	receiver := NewEventReceiver(1)
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(metricReportBody))
+	if err != nil {
+		t.Fatalf("Error posting metric report: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Invalid status: %d", resp.StatusCode)
+	}
+
+	select {
+	case metricReport := <-receiver.MetricReports:
+		if metricReport.ID != "AvgPlatformPowerUsage" {
+			t.Errorf("Invalid metric report: %s", metricReport.ID)
+		}
+	default:
+		t.Errorf("The metric report should be received")
+	}
+	if len(receiver.Events) != 0 {
+		t.Errorf("No event should be received")
+	}
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatalf("Error posting invalid payload: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Invalid status for an invalid payload: %d", resp.StatusCode)
+	}
+}
+
+// TestEventReceiverReadEventStream tests reading a server-sent event stream.
+func TestEventReceiverReadEventStream(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"id: 1\ndata: " + strings.ReplaceAll(eventBody, "\n", "\ndata: ") + "\n\n" +
+		"id: 2\ndata:" + strings.ReplaceAll(metricReportBody, "\n", " ") + "\n\n"
+
+	receiver := NewEventReceiver(1)
+	err := receiver.ReadEventStream(context.Background(), strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Error reading event stream: %s", err)
+	}
+
+	if len(receiver.Events) != 1 || len(receiver.MetricReports) != 1 {
+		t.Fatalf("Invalid payloads: %d events, %d metric reports", len(receiver.Events), len(receiver.MetricReports))
+	}
+	if event := <-receiver.Events; event.Context != "ContosoWebClient" {
+		t.Errorf("Invalid event: %v", event)
+	}
+}
+
+// TestEventServiceStreamEvents tests opening the event stream of the service.
+func TestEventServiceStreamEvents(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/events?$filter=EventFormatType+eq+%27MetricReport%27": "data: " + strings.ReplaceAll(metricReportBody, "\n", " ") + "\n\n",
+	}}
+
+	eventService := &EventService{ServerSentEventURI: "http://example.com/events"}
+	eventService.SetClient(testClient)
+
+	receiver := NewEventReceiver(1)
+	err := eventService.StreamEvents(context.Background(), "EventFormatType eq 'MetricReport'", receiver)
+	if err != nil {
+		t.Fatalf("Error streaming events: %s", err)
+	}
+	if len(receiver.MetricReports) != 1 {
+		t.Errorf("The metric report should be received")
+	}
+}
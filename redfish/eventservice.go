@@ -6,7 +6,8 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"net/url"
 	"reflect"
 	"time"
 
@@ -166,10 +167,18 @@ func (eventservice *EventService) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	eventservice.rawData = b
+	eventservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the event service to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (eventservice EventService) MarshalJSON() ([]byte, error) {
+	type temp EventService
+	return common.MarshalWithRawData(eventservice.rawData, temp(eventservice))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (eventservice *EventService) Update() error {
 
@@ -192,25 +201,12 @@ func (eventservice *EventService) Update() error {
 
 // GetEventService will get a EventService instance from the service.
 func GetEventService(c common.Client, uri string) (*EventService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var eventService EventService
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &eventService)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &eventService)
-	if err != nil {
-		return nil, err
-	}
-
-	eventService.rawData = rawData
-	eventService.SetClient(c)
 	return &eventService, nil
 }
 
@@ -228,7 +224,11 @@ func ListReferencedEventServices(c common.Client, link string) ([]*EventService,
 	}
 
 	for _, eventserviceLink := range links.ItemLinks {
-		eventservice, err := GetEventService(c, eventserviceLink)
+		eventservice := new(EventService)
+		expanded, err := links.DecodeMember(c, eventserviceLink, eventservice)
+		if err == nil && !expanded {
+			eventservice, err = GetEventService(c, eventserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -238,6 +238,92 @@ func ListReferencedEventServices(c common.Client, link string) ([]*EventService,
 	return result, nil
 }
 
+// Subscriptions gets the event subscriptions of the event service.
+func (eventservice *EventService) Subscriptions() ([]*EventDestination, error) {
+	return ListReferencedEventDestinations(eventservice.Client, eventservice.subscriptions)
+}
+
+// EventSubscriptionParameters are the properties of a new event
+// subscription.
+type EventSubscriptionParameters struct {
+	// Destination is the URI the service sends events to.
+	Destination string
+	// Context is a client-supplied string that the service sends back with
+	// the events, to tell subscriptions apart.
+	Context string `json:",omitempty"`
+	// Protocol is the protocol used to send events. It defaults to Redfish.
+	Protocol EventDestinationProtocol
+	// EventFormatType is the content type of the events, Event or
+	// MetricReport.
+	EventFormatType EventFormatType `json:",omitempty"`
+	// HTTPHeaders are HTTP headers the service adds to the events, such as
+	// an authorization header for the listener.
+	HTTPHeaders []HTTPHeaderProperty `json:"HttpHeaders,omitempty"`
+	// RegistryPrefixes are the prefixes of the message registries of the
+	// events to send. All messages are sent if unset.
+	RegistryPrefixes []string `json:",omitempty"`
+	// ResourceTypes are the resource types of the events to send. Events of
+	// all resources are sent if unset.
+	ResourceTypes []string `json:",omitempty"`
+	// SubscriptionType is the type of the subscription. It defaults to
+	// SNMPTrap for the SNMP protocols, and to the service default otherwise.
+	SubscriptionType SubscriptionType `json:",omitempty"`
+	// SNMP are the settings of SNMP subscriptions, such as the trap
+	// community or the SNMPv3 keys.
+	SNMP *SNMPSettings `json:",omitempty"`
+}
+
+// CreateEventSubscription creates an event subscription and returns its URI.
+func (eventservice *EventService) CreateEventSubscription(parameters EventSubscriptionParameters) (string, error) {
+	if eventservice.subscriptions == "" {
+		return "", fmt.Errorf("this event service does not have a subscriptions collection")
+	}
+	if parameters.Destination == "" {
+		return "", fmt.Errorf("event subscription destination must be supplied")
+	}
+	if parameters.Protocol == "" {
+		parameters.Protocol = RedfishEventDestinationProtocol
+	}
+	if parameters.SubscriptionType == "" {
+		switch parameters.Protocol {
+		case SNMPv1EventDestinationProtocol, SNMPv2cEventDestinationProtocol, SNMPv3EventDestinationProtocol:
+			parameters.SubscriptionType = SNMPTrapSubscriptionType
+		}
+	}
+
+	resp, err := eventservice.Client.Post(eventservice.subscriptions, parameters)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// The URI is in the Location header, or in the body for services that
+	// return the new subscription instead
+	uri := resp.Header.Get("Location")
+	if uri == "" {
+		var t struct {
+			ODataID string `json:"@odata.id"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&t) == nil {
+			uri = t.ODataID
+		}
+	}
+	if uri == "" {
+		return "", fmt.Errorf("the service did not return the URI of the event subscription")
+	}
+
+	if parsed, err := url.Parse(uri); err == nil && parsed.IsAbs() {
+		uri = parsed.RequestURI()
+	}
+
+	return uri, nil
+}
+
+// DeleteEventSubscription deletes an event subscription.
+func (eventservice *EventService) DeleteEventSubscription(uri string) error {
+	return eventservice.Client.Delete(uri)
+}
+
 // SubmitTestEvent shall add a test event to the event service with the event
 // data specified in the action parameters. This message should then be sent to
 // any appropriate ListenerDestination targets.
@@ -0,0 +1,314 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// FacilityType is the type of a facility.
+type FacilityType string
+
+const (
+	// RoomFacilityType A room inside of a building or floor.
+	RoomFacilityType FacilityType = "Room"
+	// FloorFacilityType A floor inside of a building.
+	FloorFacilityType FacilityType = "Floor"
+	// BuildingFacilityType A structure with a roof and walls.
+	BuildingFacilityType FacilityType = "Building"
+	// SiteFacilityType A small area consisting of several buildings.
+	SiteFacilityType FacilityType = "Site"
+)
+
+// Facility shall be used to represent a location containing equipment, such
+// as a room, building or campus, for a Redfish implementation.
+type Facility struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FacilityType shall contain the type of location this resource
+	// represents.
+	FacilityType FacilityType
+	// Location shall contain the location information of the facility.
+	Location common.Location
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// ambientMetrics shall be a link to a resource of type EnvironmentMetrics
+	// that specifies the outdoor environment metrics for this facility.
+	ambientMetrics string
+	// environmentMetrics shall be a link to a resource of type
+	// EnvironmentMetrics that specifies the environment metrics for this
+	// facility.
+	environmentMetrics string
+	// powerDomains shall be a link to a resource collection of type
+	// PowerDomainCollection.
+	powerDomains string
+	// cdus are the coolant distribution units in this facility.
+	cdus []string
+	// containedByFacility is the facility that contains this facility.
+	containedByFacility string
+	// containsChassis are the chassis within this facility.
+	containsChassis []string
+	// containsFacilities are the facilities within this facility.
+	containsFacilities []string
+	// coolingLoops are the cooling loops in this facility.
+	coolingLoops []string
+	// electricalBuses are the electrical buses in this facility.
+	electricalBuses []string
+	// floorPDUs are the floor power distribution units in this facility.
+	floorPDUs []string
+	// immersionUnits are the immersion cooling units in this facility.
+	immersionUnits []string
+	// managedBy are the managers that manage this facility.
+	managedBy []string
+	// powerShelves are the power shelves in this facility.
+	powerShelves []string
+	// rackPDUs are the rack power distribution units in this facility.
+	rackPDUs []string
+	// switchgear are the switchgear in this facility.
+	switchgear []string
+	// transferSwitches are the transfer switches in this facility.
+	transferSwitches []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (facility *Facility) GetRawData() []byte {
+	return facility.rawData
+}
+
+// UnmarshalJSON unmarshals a Facility object from the raw JSON.
+func (facility *Facility) UnmarshalJSON(b []byte) error {
+	type temp Facility
+	var t struct {
+		temp
+		AmbientMetrics     common.Link
+		EnvironmentMetrics common.Link
+		PowerDomains       common.Link
+		Links              struct {
+			CDUs                common.Links
+			ContainedByFacility common.Link
+			ContainsChassis     common.Links
+			ContainsFacilities  common.Links
+			CoolingLoops        common.Links
+			ElectricalBuses     common.Links
+			FloorPDUs           common.Links
+			ImmersionUnits      common.Links
+			ManagedBy           common.Links
+			PowerShelves        common.Links
+			RackPDUs            common.Links
+			Switchgear          common.Links
+			TransferSwitches    common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*facility = Facility(t.temp)
+
+	// Extract the links to other entities for later
+	facility.ambientMetrics = string(t.AmbientMetrics)
+	facility.environmentMetrics = string(t.EnvironmentMetrics)
+	facility.powerDomains = string(t.PowerDomains)
+	facility.cdus = t.Links.CDUs.ToStrings()
+	facility.containedByFacility = string(t.Links.ContainedByFacility)
+	facility.containsChassis = t.Links.ContainsChassis.ToStrings()
+	facility.containsFacilities = t.Links.ContainsFacilities.ToStrings()
+	facility.coolingLoops = t.Links.CoolingLoops.ToStrings()
+	facility.electricalBuses = t.Links.ElectricalBuses.ToStrings()
+	facility.floorPDUs = t.Links.FloorPDUs.ToStrings()
+	facility.immersionUnits = t.Links.ImmersionUnits.ToStrings()
+	facility.managedBy = t.Links.ManagedBy.ToStrings()
+	facility.powerShelves = t.Links.PowerShelves.ToStrings()
+	facility.rackPDUs = t.Links.RackPDUs.ToStrings()
+	facility.switchgear = t.Links.Switchgear.ToStrings()
+	facility.transferSwitches = t.Links.TransferSwitches.ToStrings()
+
+	facility.rawData = b
+	facility.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the facility to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (facility Facility) MarshalJSON() ([]byte, error) {
+	type temp Facility
+	return common.MarshalWithRawData(facility.rawData, temp(facility))
+}
+
+// AmbientMetrics gets the URI of the outdoor environment metrics of this
+// facility.
+func (facility *Facility) AmbientMetrics() string {
+	return facility.ambientMetrics
+}
+
+// EnvironmentMetrics gets the URI of the environment metrics of this
+// facility.
+func (facility *Facility) EnvironmentMetrics() string {
+	return facility.environmentMetrics
+}
+
+// PowerDomains gets the power domains of this facility.
+func (facility *Facility) PowerDomains() ([]*PowerDomain, error) {
+	return ListReferencedPowerDomains(facility.Client, facility.powerDomains)
+}
+
+// CDUs gets the coolant distribution units in this facility.
+func (facility *Facility) CDUs() ([]*CoolingUnit, error) {
+	return getCoolingUnits(facility.Client, facility.cdus)
+}
+
+// ContainedByFacility gets the facility that contains this facility.
+func (facility *Facility) ContainedByFacility() (*Facility, error) {
+	if facility.containedByFacility == "" {
+		return nil, nil
+	}
+
+	return GetFacility(facility.Client, facility.containedByFacility)
+}
+
+// ContainsChassis gets the chassis within this facility.
+func (facility *Facility) ContainsChassis() ([]*Chassis, error) {
+	var result []*Chassis
+	for _, chassisLink := range facility.containsChassis {
+		chassis, err := GetChassis(facility.Client, chassisLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, chassis)
+	}
+
+	return result, nil
+}
+
+// ContainsFacilities gets the facilities within this facility.
+func (facility *Facility) ContainsFacilities() ([]*Facility, error) {
+	var result []*Facility
+	for _, facilityLink := range facility.containsFacilities {
+		contained, err := GetFacility(facility.Client, facilityLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, contained)
+	}
+
+	return result, nil
+}
+
+// CoolingLoops gets the cooling loops in this facility.
+func (facility *Facility) CoolingLoops() ([]*CoolingLoop, error) {
+	var result []*CoolingLoop
+	for _, coolingloopLink := range facility.coolingLoops {
+		coolingloop, err := GetCoolingLoop(facility.Client, coolingloopLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, coolingloop)
+	}
+
+	return result, nil
+}
+
+// ElectricalBuses gets the electrical buses in this facility.
+func (facility *Facility) ElectricalBuses() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.electricalBuses)
+}
+
+// FloorPDUs gets the floor power distribution units in this facility.
+func (facility *Facility) FloorPDUs() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.floorPDUs)
+}
+
+// ImmersionUnits gets the immersion cooling units in this facility.
+func (facility *Facility) ImmersionUnits() ([]*CoolingUnit, error) {
+	return getCoolingUnits(facility.Client, facility.immersionUnits)
+}
+
+// ManagedBy gets the managers that manage this facility.
+func (facility *Facility) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+	for _, managerLink := range facility.managedBy {
+		manager, err := GetManager(facility.Client, managerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// PowerShelves gets the power shelves in this facility.
+func (facility *Facility) PowerShelves() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.powerShelves)
+}
+
+// RackPDUs gets the rack power distribution units in this facility.
+func (facility *Facility) RackPDUs() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.rackPDUs)
+}
+
+// Switchgear gets the switchgear in this facility.
+func (facility *Facility) Switchgear() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.switchgear)
+}
+
+// TransferSwitches gets the transfer switches in this facility.
+func (facility *Facility) TransferSwitches() ([]*PowerDistribution, error) {
+	return getPowerDistributions(facility.Client, facility.transferSwitches)
+}
+
+// GetFacility will get a Facility instance from the service.
+func GetFacility(c common.Client, uri string) (*Facility, error) {
+	var facility Facility
+	err := common.FetchResource(c, uri, &facility)
+	if err != nil {
+		return nil, err
+	}
+
+	return &facility, nil
+}
+
+// ListReferencedFacilities gets the collection of Facility from
+// a provided reference.
+func ListReferencedFacilities(c common.Client, link string) ([]*Facility, error) {
+	var result []*Facility
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, facilityLink := range links.ItemLinks {
+		facility := new(Facility)
+		expanded, err := links.DecodeMember(c, facilityLink, facility)
+		if err == nil && !expanded {
+			facility, err = GetFacility(c, facilityLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, facility)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var facilityBody = `{
+		"@odata.type": "#Facility.v1_3_0.Facility",
+		"@odata.id": "/redfish/v1/Facilities/Room237",
+		"Id": "Room237",
+		"Name": "Room #237, 2nd Floor",
+		"FacilityType": "Room",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"PowerDomains": {
+			"@odata.id": "/redfish/v1/Facilities/Room237/PowerDomains"
+		},
+		"EnvironmentMetrics": {
+			"@odata.id": "/redfish/v1/Facilities/Room237/EnvironmentMetrics"
+		},
+		"Links": {
+			"ContainedByFacility": {
+				"@odata.id": "/redfish/v1/Facilities/Building1"
+			},
+			"RackPDUs": [{
+				"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1"
+			}, {
+				"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/2"
+			}],
+			"TransferSwitches": [{
+				"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1"
+			}]
+		}
+	}`
+
+// TestFacility tests the parsing of Facility objects.
+func TestFacility(t *testing.T) {
+	var result Facility
+	err := json.NewDecoder(strings.NewReader(facilityBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Room237" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.FacilityType != RoomFacilityType {
+		t.Errorf("Invalid facility type: %s", result.FacilityType)
+	}
+
+	if result.powerDomains != "/redfish/v1/Facilities/Room237/PowerDomains" {
+		t.Errorf("Invalid power domains link: %s", result.powerDomains)
+	}
+
+	if result.EnvironmentMetrics() != "/redfish/v1/Facilities/Room237/EnvironmentMetrics" {
+		t.Errorf("Invalid environment metrics link: %s", result.EnvironmentMetrics())
+	}
+
+	if result.containedByFacility != "/redfish/v1/Facilities/Building1" {
+		t.Errorf("Invalid contained by facility link: %s", result.containedByFacility)
+	}
+
+	if len(result.rackPDUs) != 2 {
+		t.Errorf("Invalid rack PDU links: %v", result.rackPDUs)
+	}
+
+	if len(result.transferSwitches) != 1 {
+		t.Errorf("Invalid transfer switch links: %v", result.transferSwitches)
+	}
+}
@@ -0,0 +1,182 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// Filter shall describe a filter unit for a cooling system or similar
+// device for a Redfish implementation.
+type Filter struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain the location information of this filter.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the filter.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this filter.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this filter.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected device or
+	// region within the chassis with which this filter is associated.
+	PhysicalContext common.PhysicalContext
+	// RatedServiceHours shall contain the number of hours of service that
+	// the filter is rated to provide before servicing or replacement is
+	// necessary.
+	RatedServiceHours float32
+	// Replaceable shall indicate whether this component can be independently
+	// replaced as allowed by the vendor's replacement policy.
+	Replaceable bool
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this filter.
+	SerialNumber string
+	// ServiceHours shall contain the number of hours of service that the
+	// filter has provided.
+	ServiceHours float32
+	// ServicedDate shall contain the date the filter was put into active
+	// service.
+	ServicedDate string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this filter.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (filter *Filter) GetRawData() []byte {
+	return filter.rawData
+}
+
+// UnmarshalJSON unmarshals a Filter object from the raw JSON.
+func (filter *Filter) UnmarshalJSON(b []byte) error {
+	type temp Filter
+	var t struct {
+		temp
+		Assembly common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*filter = Filter(t.temp)
+
+	// Extract the links to other entities for later
+	filter.assembly = string(t.Assembly)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	filter.rawData = b
+	filter.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the filter to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (filter Filter) MarshalJSON() ([]byte, error) {
+	type temp Filter
+	return common.MarshalWithRawData(filter.rawData, temp(filter))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (filter *Filter) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Filter)
+	original.UnmarshalJSON(filter.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+		"ServiceHours",
+		"ServicedDate",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(filter).Elem()
+
+	return filter.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Assembly gets the assembly for this filter.
+func (filter *Filter) Assembly() (*Assembly, error) {
+	if filter.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(filter.Client, filter.assembly)
+}
+
+// GetFilter will get a Filter instance from the service.
+func GetFilter(c common.Client, uri string) (*Filter, error) {
+	var filter Filter
+	err := common.FetchResource(c, uri, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+// ListReferencedFilters gets the collection of Filter from
+// a provided reference.
+func ListReferencedFilters(c common.Client, link string) ([]*Filter, error) {
+	var result []*Filter
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, filterLink := range links.ItemLinks {
+		filter := new(Filter)
+		expanded, err := links.DecodeMember(c, filterLink, filter)
+		if err == nil && !expanded {
+			filter, err = GetFilter(c, filterLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, filter)
+	}
+
+	return result, nil
+}
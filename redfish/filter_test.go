@@ -0,0 +1,77 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var filterBody = `{
+		"@odata.type": "#Filter.v1_0_0.Filter",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Filters/1",
+		"Id": "1",
+		"Name": "Cooling Loop Filter",
+		"RatedServiceHours": 5000,
+		"ServiceHours": 4321,
+		"ServicedDate": "2020-12-24T08:00:00Z",
+		"HotPluggable": true,
+		"Replaceable": true,
+		"Status": {
+			"State": "Enabled",
+			"Health": "Warning"
+		}
+	}`
+
+// TestFilter tests the parsing of Filter objects.
+func TestFilter(t *testing.T) {
+	var result Filter
+	err := json.NewDecoder(strings.NewReader(filterBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.RatedServiceHours != 5000 {
+		t.Errorf("Invalid rated service hours: %f", result.RatedServiceHours)
+	}
+
+	if !result.HotPluggable {
+		t.Error("Filter should be hot pluggable")
+	}
+}
+
+// TestFilterUpdate tests the Update call.
+func TestFilterUpdate(t *testing.T) {
+	var result Filter
+	err := json.NewDecoder(strings.NewReader(filterBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.ServicedDate = "2021-06-01T08:00:00Z"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "ServicedDate:2021-06-01T08:00:00Z") {
+		t.Errorf("Unexpected ServicedDate update payload: %s", calls[0].Payload)
+	}
+}
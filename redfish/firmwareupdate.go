@@ -0,0 +1,292 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// FirmwareUpdateOptions controls UpdateService.UpdateFirmware.
+type FirmwareUpdateOptions struct {
+	// Targets are the URIs of the resources to apply the image to, such as
+	// items of the firmware inventory. The service chooses them if unset.
+	Targets []string
+	// TransferProtocol is the protocol the service uses to pull an image
+	// from a URI, if the URI does not tell it.
+	TransferProtocol TransferProtocolType
+	// Username and Password are the credentials the service uses to pull an
+	// image from a URI.
+	Username string
+	Password string
+	// ApplyTime is when the service applies the image, such as Immediate or
+	// OnReset. The service default is used if unset.
+	ApplyTime common.OperationApplyTime
+	// ExpectedVersion, if set, is the version the firmware inventory must
+	// report once the update is done: every target that is a firmware
+	// inventory item or, if there are none, an item whose version changed to
+	// it. Reinstalling the version an item already has therefore needs the
+	// item in Targets.
+	ExpectedVersion string
+	// Wait controls the polling of the update task.
+	Wait TaskWaitOptions
+	// RebootTimeout is how long the service may stay unreachable, as while
+	// a BMC reboots to activate its new firmware. It defaults to ten
+	// minutes.
+	RebootTimeout time.Duration
+}
+
+// UpdateFirmware updates firmware with an image and waits for the update to
+// be done. An image given as a URI, such as "https://server/bmc.bin", is
+// pulled by the service with the SimpleUpdate action; any other image is
+// the path of a local file, pushed to the service with a multipart request.
+//
+// The update task is polled until it finishes. Errors reading it are retried
+// for up to RebootTimeout, as services commonly reboot while updating their
+// own firmware; the client must then be able to authenticate again, which
+// clients using a session usually can not, as sessions are lost on reboot.
+// If ExpectedVersion is set, the firmware inventory is then checked for it.
+// It returns the update task, and an error if the update failed, the
+// version is not reported in time or the context is done.
+func (updateservice *UpdateService) UpdateFirmware(ctx context.Context, image string, options FirmwareUpdateOptions) (*Task, error) {
+	if options.RebootTimeout <= 0 {
+		options.RebootTimeout = 10 * time.Minute
+	}
+	if options.Wait.Interval <= 0 {
+		options.Wait.Interval = 5 * time.Second
+	}
+
+	var check *firmwareVersionCheck
+	if options.ExpectedVersion != "" {
+		var err error
+		check, err = updateservice.newFirmwareVersionCheck(options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := updateservice.startFirmwareUpdate(image, options)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := taskFromResponse(updateservice.Client, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	for {
+		task, err = task.Wait(ctx, options.Wait)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+
+		// The service is rebooting, or lost the task when it did
+		if deadline.IsZero() {
+			deadline = time.Now().Add(options.RebootTimeout)
+		}
+		if check != nil {
+			if installed, _ := check.installed(); installed {
+				return task, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return task, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-time.After(options.Wait.Interval):
+		}
+	}
+	if err != nil {
+		return task, err
+	}
+
+	if task.TaskState != CompletedTaskState || task.TaskStatus == common.CriticalHealth {
+		return task, fmt.Errorf("firmware update task ended %s: %s", task.TaskState, taskMessages(task))
+	}
+
+	if check == nil {
+		return task, nil
+	}
+
+	// The service may reboot to activate the firmware once the task is done
+	deadline = time.Now().Add(options.RebootTimeout)
+	for {
+		installed, err := check.installed()
+		if installed {
+			return task, nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return task, err
+			}
+			return task, fmt.Errorf("firmware inventory does not report version %s", options.ExpectedVersion)
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-time.After(options.Wait.Interval):
+		}
+	}
+}
+
+// startFirmwareUpdate sends the image to the service, or its URI.
+func (updateservice *UpdateService) startFirmwareUpdate(image string, options FirmwareUpdateOptions) (*http.Response, error) {
+	if parsed, err := url.Parse(image); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		if updateservice.simpleUpdateTarget == "" {
+			return nil, fmt.Errorf("SimpleUpdate is not supported by this update service")
+		}
+
+		return updateservice.Client.Post(updateservice.simpleUpdateTarget, &SimpleUpdateParameters{
+			ImageURI:         image,
+			Password:         options.Password,
+			Targets:          options.Targets,
+			TransferProtocol: options.TransferProtocol,
+			Username:         options.Username,
+
+			OperationApplyTime: options.ApplyTime,
+		})
+	}
+
+	if updateservice.MultipartHTTPPushURI == "" {
+		return nil, fmt.Errorf("multipart HTTP push updates are not supported by this update service")
+	}
+
+	client, ok := updateservice.Client.(common.MultipartClient)
+	if !ok {
+		return nil, fmt.Errorf("the client does not support multipart requests")
+	}
+
+	file, err := os.Open(image)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	type temp struct {
+		Targets            []string                  `json:",omitempty"`
+		OperationApplyTime common.OperationApplyTime `json:"@Redfish.OperationApplyTime,omitempty"`
+	}
+	parameters, err := json.Marshal(temp{
+		Targets:            options.Targets,
+		OperationApplyTime: options.ApplyTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.PostMultipart(updateservice.MultipartHTTPPushURI, []common.MultipartPart{
+		{
+			Name:        "UpdateParameters",
+			ContentType: "application/json",
+			Content:     bytes.NewReader(parameters),
+		},
+		{
+			Name:        "UpdateFile",
+			FileName:    filepath.Base(image),
+			ContentType: "application/octet-stream",
+			Content:     file,
+		},
+	})
+}
+
+// firmwareVersionCheck tells whether the firmware inventory reports the
+// expected version of an update.
+type firmwareVersionCheck struct {
+	updateservice *UpdateService
+	expected      string
+	// targets are the targets of the update that are firmware inventory
+	// items.
+	targets []string
+	// versions are the versions of the firmware inventory items before the
+	// update, keyed by URI, when no target is an item.
+	versions map[string]string
+}
+
+// newFirmwareVersionCheck makes the version check of an update, before it is
+// started.
+func (updateservice *UpdateService) newFirmwareVersionCheck(options FirmwareUpdateOptions) (*firmwareVersionCheck, error) {
+	check := &firmwareVersionCheck{
+		updateservice: updateservice,
+		expected:      options.ExpectedVersion,
+	}
+	for _, target := range options.Targets {
+		if updateservice.firmwareInventory != "" && strings.HasPrefix(target, updateservice.firmwareInventory+"/") {
+			check.targets = append(check.targets, target)
+		}
+	}
+	if len(check.targets) > 0 {
+		return check, nil
+	}
+
+	inventory, err := updateservice.FirmwareInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	check.versions = make(map[string]string)
+	for _, item := range inventory {
+		check.versions[item.ODataID] = item.Version
+	}
+
+	return check, nil
+}
+
+// installed tells whether every target reports the expected version or,
+// without targets, whether an item of the firmware inventory changed to it.
+func (check *firmwareVersionCheck) installed() (bool, error) {
+	if len(check.targets) > 0 {
+		for _, target := range check.targets {
+			item, err := GetSoftwareInventory(check.updateservice.Client, target)
+			if err != nil {
+				return false, err
+			}
+			if item.Version != check.expected {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	inventory, err := check.updateservice.FirmwareInventory()
+	if err != nil {
+		return false, err
+	}
+
+	// Items that were not in the inventory before the update changed too
+	for _, item := range inventory {
+		previous, ok := check.versions[item.ODataID]
+		if item.Version == check.expected && (!ok || previous != check.expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// taskMessages gets the messages of a task as text.
+func taskMessages(task *Task) string {
+	var messages []string
+	for _, message := range task.Messages {
+		messages = append(messages, message.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,216 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// GraphicsController shall represent a graphics output device in a Redfish
+// implementation.
+type GraphicsController struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag shall contain the user-assigned asset tag, which is an
+	// identifying string that tracks the drive for inventory purposes.
+	AssetTag string
+	// BIOSVersion shall contain the version string of the currently installed
+	// and running BIOS or firmware for the graphics controller.
+	BIOSVersion string `json:"BiosVersion"`
+	// Description provides a description of this resource.
+	Description string
+	// DriverVersion shall contain the version string of the currently loaded
+	// driver for this graphics controller.
+	DriverVersion string
+	// Location shall contain location information of the associated graphics
+	// controller.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the graphics controller.
+	Manufacturer string
+	// Model shall contain the manufacturer-provided model information of
+	// this graphics controller.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// graphics controller.
+	PartNumber string
+	// ports shall be a link to a resource collection of type PortCollection.
+	ports string
+	// SKU shall contain the SKU number for this graphics controller.
+	SKU string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the graphics controller.
+	SerialNumber string
+	// SparePartNumber shall contain the spare part number of the graphics
+	// controller.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// pcieDevice is the PCIe device that represents this graphics controller.
+	pcieDevice string
+	// pcieFunctions are the PCIe functions that represent this graphics
+	// controller.
+	pcieFunctions []string
+	// processors are the processors that are associated with this graphics
+	// controller.
+	processors []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (graphicscontroller *GraphicsController) GetRawData() []byte {
+	return graphicscontroller.rawData
+}
+
+// UnmarshalJSON unmarshals a GraphicsController object from the raw JSON.
+func (graphicscontroller *GraphicsController) UnmarshalJSON(b []byte) error {
+	type temp GraphicsController
+	var t struct {
+		temp
+		Ports common.Link
+		Links struct {
+			PCIeDevice    common.Link
+			PCIeFunctions common.Links
+			Processors    common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*graphicscontroller = GraphicsController(t.temp)
+
+	// Extract the links to other entities for later
+	graphicscontroller.ports = string(t.Ports)
+	graphicscontroller.pcieDevice = string(t.Links.PCIeDevice)
+	graphicscontroller.pcieFunctions = t.Links.PCIeFunctions.ToStrings()
+	graphicscontroller.processors = t.Links.Processors.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	graphicscontroller.rawData = b
+	graphicscontroller.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the graphics controller to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (graphicscontroller GraphicsController) MarshalJSON() ([]byte, error) {
+	type temp GraphicsController
+	return common.MarshalWithRawData(graphicscontroller.rawData, temp(graphicscontroller))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (graphicscontroller *GraphicsController) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(GraphicsController)
+	original.UnmarshalJSON(graphicscontroller.rawData)
+
+	readWriteFields := []string{
+		"AssetTag",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(graphicscontroller).Elem()
+
+	return graphicscontroller.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Ports gets the display ports of this graphics controller.
+func (graphicscontroller *GraphicsController) Ports() ([]*Port, error) {
+	return ListReferencedPorts(graphicscontroller.Client, graphicscontroller.ports)
+}
+
+// PCIeDevice gets the PCIe device that represents this graphics controller.
+func (graphicscontroller *GraphicsController) PCIeDevice() (*PCIeDevice, error) {
+	if graphicscontroller.pcieDevice == "" {
+		return nil, nil
+	}
+
+	return GetPCIeDevice(graphicscontroller.Client, graphicscontroller.pcieDevice)
+}
+
+// PCIeFunctions gets the PCIe functions that represent this graphics
+// controller.
+func (graphicscontroller *GraphicsController) PCIeFunctions() ([]*PCIeFunction, error) {
+	var result []*PCIeFunction
+	for _, pciefunctionLink := range graphicscontroller.pcieFunctions {
+		pciefunction, err := GetPCIeFunction(graphicscontroller.Client, pciefunctionLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, pciefunction)
+	}
+
+	return result, nil
+}
+
+// Processors gets the processors that are associated with this graphics
+// controller.
+func (graphicscontroller *GraphicsController) Processors() ([]*Processor, error) {
+	var result []*Processor
+	for _, processorLink := range graphicscontroller.processors {
+		processor, err := GetProcessor(graphicscontroller.Client, processorLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, processor)
+	}
+
+	return result, nil
+}
+
+// GetGraphicsController will get a GraphicsController instance from the service.
+func GetGraphicsController(c common.Client, uri string) (*GraphicsController, error) {
+	var graphicscontroller GraphicsController
+	err := common.FetchResource(c, uri, &graphicscontroller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphicscontroller, nil
+}
+
+// ListReferencedGraphicsControllers gets the collection of GraphicsController
+// from a provided reference.
+func ListReferencedGraphicsControllers(c common.Client, link string) ([]*GraphicsController, error) {
+	var result []*GraphicsController
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, graphicscontrollerLink := range links.ItemLinks {
+		graphicscontroller := new(GraphicsController)
+		expanded, err := links.DecodeMember(c, graphicscontrollerLink, graphicscontroller)
+		if err == nil && !expanded {
+			graphicscontroller, err = GetGraphicsController(c, graphicscontrollerLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, graphicscontroller)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var graphicsControllerBody = `{
+		"@odata.type": "#GraphicsController.v1_0_0.GraphicsController",
+		"@odata.id": "/redfish/v1/Systems/1/GraphicsControllers/GPU1",
+		"Id": "GPU1",
+		"Name": "Contoso Graphics Controller 1",
+		"AssetTag": "GPU-1",
+		"BiosVersion": "90.04.8C.00.0F",
+		"DriverVersion": "27.21.14.5638",
+		"Manufacturer": "Contoso",
+		"Model": "GPU1",
+		"PartNumber": "G1-001",
+		"SerialNumber": "29348576",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Ports": {
+			"@odata.id": "/redfish/v1/Systems/1/GraphicsControllers/GPU1/Ports"
+		},
+		"Links": {
+			"PCIeDevice": {
+				"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/GPU1"
+			},
+			"PCIeFunctions": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/GPU1/PCIeFunctions/0"
+				}
+			],
+			"Processors": [
+				{
+					"@odata.id": "/redfish/v1/Systems/1/Processors/GPU1"
+				}
+			]
+		}
+	}`
+
+// TestGraphicsController tests the parsing of GraphicsController objects.
+func TestGraphicsController(t *testing.T) {
+	var result GraphicsController
+	err := json.NewDecoder(strings.NewReader(graphicsControllerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "GPU1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.BIOSVersion != "90.04.8C.00.0F" {
+		t.Errorf("Invalid BIOS version: %s", result.BIOSVersion)
+	}
+
+	if result.DriverVersion != "27.21.14.5638" {
+		t.Errorf("Invalid driver version: %s", result.DriverVersion)
+	}
+
+	if result.pcieDevice != "/redfish/v1/Chassis/1/PCIeDevices/GPU1" {
+		t.Errorf("Invalid PCIe device link: %s", result.pcieDevice)
+	}
+
+	if len(result.pcieFunctions) != 1 {
+		t.Errorf("Invalid PCIe function links: %v", result.pcieFunctions)
+	}
+
+	if len(result.processors) != 1 {
+		t.Errorf("Invalid processor links: %v", result.processors)
+	}
+}
+
+// TestGraphicsControllerUpdate tests the Update call.
+func TestGraphicsControllerUpdate(t *testing.T) {
+	var result GraphicsController
+	err := json.NewDecoder(strings.NewReader(graphicsControllerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.AssetTag = "GPU-2"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "AssetTag:GPU-2") {
+		t.Errorf("Unexpected AssetTag update payload: %s", calls[0].Payload)
+	}
+}
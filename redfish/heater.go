@@ -0,0 +1,372 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// Heater shall represent the management properties for monitoring and
+// management of heaters for a Redfish implementation.
+type Heater struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// Description provides a description of this resource.
+	Description string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain location information of this heater.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the heater.
+	Manufacturer string
+	// metrics shall be a link to a resource of type HeaterMetrics.
+	metrics string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this heater.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this heater.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected device or
+	// region within the chassis with which this heater is associated.
+	PhysicalContext common.PhysicalContext
+	// ProductionDate shall contain the date of production or manufacture
+	// for this heater.
+	ProductionDate string
+	// Replaceable shall indicate whether this component can be independently
+	// replaced as allowed by the vendor's replacement policy.
+	Replaceable bool
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this heater.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this heater.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// managers are the managers that this heater heats.
+	managers []string
+	// memory are the memory devices that this heater heats.
+	memory []string
+	// networkAdapters are the network adapters that this heater heats.
+	networkAdapters []string
+	// processors are the processors that this heater heats.
+	processors []string
+	// storageControllers are the storage controllers that this heater heats.
+	storageControllers []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (heater *Heater) GetRawData() []byte {
+	return heater.rawData
+}
+
+// UnmarshalJSON unmarshals a Heater object from the raw JSON.
+func (heater *Heater) UnmarshalJSON(b []byte) error {
+	type temp Heater
+	var t struct {
+		temp
+		Assembly common.Link
+		Metrics  common.Link
+		Links    struct {
+			Managers           common.Links
+			Memory             common.Links
+			NetworkAdapters    common.Links
+			Processors         common.Links
+			StorageControllers common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*heater = Heater(t.temp)
+
+	// Extract the links to other entities for later
+	heater.assembly = string(t.Assembly)
+	heater.metrics = string(t.Metrics)
+	heater.managers = t.Links.Managers.ToStrings()
+	heater.memory = t.Links.Memory.ToStrings()
+	heater.networkAdapters = t.Links.NetworkAdapters.ToStrings()
+	heater.processors = t.Links.Processors.ToStrings()
+	heater.storageControllers = t.Links.StorageControllers.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	heater.rawData = b
+	heater.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the heater to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (heater Heater) MarshalJSON() ([]byte, error) {
+	type temp Heater
+	return common.MarshalWithRawData(heater.rawData, temp(heater))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (heater *Heater) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Heater)
+	original.UnmarshalJSON(heater.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(heater).Elem()
+
+	return heater.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetHeater will get a Heater instance from the service.
+func GetHeater(c common.Client, uri string) (*Heater, error) {
+	var heater Heater
+	err := common.FetchResource(c, uri, &heater)
+	if err != nil {
+		return nil, err
+	}
+
+	return &heater, nil
+}
+
+// ListReferencedHeaters gets the collection of Heater from
+// a provided reference.
+func ListReferencedHeaters(c common.Client, link string) ([]*Heater, error) {
+	var result []*Heater
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, heaterLink := range links.ItemLinks {
+		heater := new(Heater)
+		expanded, err := links.DecodeMember(c, heaterLink, heater)
+		if err == nil && !expanded {
+			heater, err = GetHeater(c, heaterLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, heater)
+	}
+
+	return result, nil
+}
+
+// Assembly gets the Assembly for this heater.
+func (heater *Heater) Assembly() (*Assembly, error) {
+	if heater.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(heater.Client, heater.assembly)
+}
+
+// Metrics gets the metrics associated with this heater.
+func (heater *Heater) Metrics() (*HeaterMetrics, error) {
+	if heater.metrics == "" {
+		return nil, nil
+	}
+
+	return GetHeaterMetrics(heater.Client, heater.metrics)
+}
+
+// Managers gets the managers that this heater heats.
+func (heater *Heater) Managers() ([]*Manager, error) {
+	var result []*Manager
+	for _, managerLink := range heater.managers {
+		manager, err := GetManager(heater.Client, managerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// Memory gets the memory devices that this heater heats.
+func (heater *Heater) Memory() ([]*Memory, error) {
+	var result []*Memory
+	for _, memoryLink := range heater.memory {
+		memory, err := GetMemory(heater.Client, memoryLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, memory)
+	}
+
+	return result, nil
+}
+
+// NetworkAdapters gets the network adapters that this heater heats.
+func (heater *Heater) NetworkAdapters() ([]*NetworkAdapter, error) {
+	var result []*NetworkAdapter
+	for _, networkAdapterLink := range heater.networkAdapters {
+		networkAdapter, err := GetNetworkAdapter(heater.Client, networkAdapterLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, networkAdapter)
+	}
+
+	return result, nil
+}
+
+// Processors gets the processors that this heater heats.
+func (heater *Heater) Processors() ([]*Processor, error) {
+	var result []*Processor
+	for _, processorLink := range heater.processors {
+		processor, err := GetProcessor(heater.Client, processorLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, processor)
+	}
+
+	return result, nil
+}
+
+// StorageControllers gets the storage controllers that this heater heats.
+func (heater *Heater) StorageControllers() ([]*StorageController, error) {
+	var result []*StorageController
+	for _, storageControllerLink := range heater.storageControllers {
+		storageController, err := GetStorageController(heater.Client, storageControllerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, storageController)
+	}
+
+	return result, nil
+}
+
+// HeaterMetrics shall contain the metrics of a heater unit.
+type HeaterMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// PowerWatts shall contain the total power, in watt units, for this
+	// heater.
+	PowerWatts SensorPowerExcerpt
+	// PrePowerOnHeatingTimeSeconds shall contain the total number of seconds
+	// the heater was active while the device it heats was powered off.
+	PrePowerOnHeatingTimeSeconds int64
+	// RuntimeHeatingTimeSeconds shall contain the total number of seconds
+	// the heater was active while the device it heats was powered on.
+	RuntimeHeatingTimeSeconds int64
+	// TemperatureReadingsCelsius shall contain the temperatures, in degree
+	// Celsius units, for this heater.
+	TemperatureReadingsCelsius []SensorExcerpt
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (heatermetrics *HeaterMetrics) GetRawData() []byte {
+	return heatermetrics.rawData
+}
+
+// UnmarshalJSON unmarshals a HeaterMetrics object from the raw JSON.
+func (heatermetrics *HeaterMetrics) UnmarshalJSON(b []byte) error {
+	type temp HeaterMetrics
+	type Actions struct {
+		ResetMetrics struct {
+			Target string
+		} `json:"#HeaterMetrics.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*heatermetrics = HeaterMetrics(t.temp)
+	heatermetrics.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	heatermetrics.rawData = b
+	heatermetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the heater metrics to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (heatermetrics HeaterMetrics) MarshalJSON() ([]byte, error) {
+	type temp HeaterMetrics
+	return common.MarshalWithRawData(heatermetrics.rawData, temp(heatermetrics))
+}
+
+// PreHeating reports whether the heater has been active while the device it
+// heats was still powered off, as happens when equipment is brought up in a
+// cold environment.
+func (heatermetrics *HeaterMetrics) PreHeating() bool {
+	return heatermetrics.PrePowerOnHeatingTimeSeconds > 0
+}
+
+// ResetMetrics resets the summary metrics related to this heater.
+func (heatermetrics *HeaterMetrics) ResetMetrics() error {
+	if heatermetrics.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this heater")
+	}
+
+	_, err := heatermetrics.Client.Post(heatermetrics.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// GetHeaterMetrics will get a HeaterMetrics instance from the service.
+func GetHeaterMetrics(c common.Client, uri string) (*HeaterMetrics, error) {
+	var heatermetrics HeaterMetrics
+	err := common.FetchResource(c, uri, &heatermetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &heatermetrics, nil
+}
@@ -0,0 +1,160 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var heaterBody = `{
+		"@odata.type": "#Heater.v1_0_0.Heater",
+		"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters/CPU1",
+		"Id": "CPU1",
+		"Name": "Heater for CPU1",
+		"PhysicalContext": "CPU",
+		"LocationIndicatorActive": false,
+		"Manufacturer": "Contoso",
+		"HotPluggable": false,
+		"Metrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters/CPU1/Metrics"
+		},
+		"Links": {
+			"Processors": [
+				{
+					"@odata.id": "/redfish/v1/Systems/1/Processors/CPU1"
+				}
+			]
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+var heaterMetricsBody = `{
+		"@odata.type": "#HeaterMetrics.v1_0_0.HeaterMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters/CPU1/Metrics",
+		"Id": "Metrics",
+		"Name": "Heater Metrics for CPU1",
+		"PrePowerOnHeatingTimeSeconds": 600,
+		"RuntimeHeatingTimeSeconds": 1200,
+		"PowerWatts": {
+			"Reading": 15.5
+		},
+		"TemperatureReadingsCelsius": [
+			{
+				"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/CPU1Temp",
+				"Reading": -5
+			}
+		],
+		"Actions": {
+			"#HeaterMetrics.ResetMetrics": {
+				"target": "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters/CPU1/Metrics/Actions/HeaterMetrics.ResetMetrics"
+			}
+		}
+	}`
+
+// TestHeater tests the parsing of Heater objects.
+func TestHeater(t *testing.T) {
+	var result Heater
+	err := json.NewDecoder(strings.NewReader(heaterBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CPU1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.PhysicalContext != common.CPUPhysicalContext {
+		t.Errorf("Invalid physical context: %s", result.PhysicalContext)
+	}
+
+	if result.metrics != "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters/CPU1/Metrics" {
+		t.Errorf("Invalid metrics link: %s", result.metrics)
+	}
+
+	if len(result.processors) != 1 {
+		t.Errorf("Invalid number of processors: %d", len(result.processors))
+	}
+}
+
+// TestHeaterUpdate tests the Update call.
+func TestHeaterUpdate(t *testing.T) {
+	var result Heater
+	err := json.NewDecoder(strings.NewReader(heaterBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestHeaterMetrics tests the parsing of HeaterMetrics objects.
+func TestHeaterMetrics(t *testing.T) {
+	var result HeaterMetrics
+	err := json.NewDecoder(strings.NewReader(heaterMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.PrePowerOnHeatingTimeSeconds != 600 {
+		t.Errorf("Invalid pre-power-on heating time: %d", result.PrePowerOnHeatingTimeSeconds)
+	}
+
+	if !result.PreHeating() {
+		t.Error("Heater should report pre-heating")
+	}
+
+	if len(result.TemperatureReadingsCelsius) != 1 || result.TemperatureReadingsCelsius[0].Reading != -5 {
+		t.Errorf("Invalid temperature readings: %v", result.TemperatureReadingsCelsius)
+	}
+}
+
+// TestHeaterMetricsResetMetrics tests the ResetMetrics call.
+func TestHeaterMetricsResetMetrics(t *testing.T) {
+	var result HeaterMetrics
+	err := json.NewDecoder(strings.NewReader(heaterMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ResetMetrics()
+
+	if err != nil {
+		t.Errorf("Error making ResetMetrics call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.HasSuffix(calls[0].URL, "HeaterMetrics.ResetMetrics") {
+		t.Errorf("Unexpected ResetMetrics URL: %s", calls[0].URL)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -159,10 +158,18 @@ func (hostinterface *HostInterface) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	hostinterface.rawData = b
+	hostinterface.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the host interface to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (hostinterface HostInterface) MarshalJSON() ([]byte, error) {
+	type temp HostInterface
+	return common.MarshalWithRawData(hostinterface.rawData, temp(hostinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (hostinterface *HostInterface) Update() error {
 
@@ -189,25 +196,12 @@ func (hostinterface *HostInterface) Update() error {
 
 // GetHostInterface will get a HostInterface instance from the service.
 func GetHostInterface(c common.Client, uri string) (*HostInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var hostInterface HostInterface
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &hostInterface)
+	err := common.FetchResource(c, uri, &hostInterface)
 	if err != nil {
 		return nil, err
 	}
 
-	hostInterface.rawData = rawData
-	hostInterface.SetClient(c)
 	return &hostInterface, nil
 }
 
@@ -225,7 +219,11 @@ func ListReferencedHostInterfaces(c common.Client, link string) ([]*HostInterfac
 	}
 
 	for _, hostinterfaceLink := range links.ItemLinks {
-		hostinterface, err := GetHostInterface(c, hostinterfaceLink)
+		hostinterface := new(HostInterface)
+		expanded, err := links.DecodeMember(c, hostinterfaceLink, hostinterface)
+		if err == nil && !expanded {
+			hostinterface, err = GetHostInterface(c, hostinterfaceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,214 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// KeyType is the format of a key.
+type KeyType string
+
+const (
+	// NVMeoFKeyType shall indicate the format of the key is defined by one
+	// of the NVMe specifications.
+	NVMeoFKeyType KeyType = "NVMeoF"
+	// SSHKeyType shall indicate the format of the key is defined by one of
+	// the SSH public key formats as defined in, but not limited to,
+	// RFC4253, RFC4716, or RFC8709.
+	SSHKeyType KeyType = "SSH"
+)
+
+// NVMeoFSecurityProtocolType is the NVMe security protocol a key is used
+// with.
+type NVMeoFSecurityProtocolType string
+
+const (
+	// DHHCNVMeoFSecurityProtocolType Diffie-Hellman Hashed Message
+	// Authentication Code Challenge Handshake Authentication Protocol
+	// (DH-HMAC-CHAP).
+	DHHCNVMeoFSecurityProtocolType NVMeoFSecurityProtocolType = "DHHC"
+	// TLSPSKNVMeoFSecurityProtocolType Transport Layer Security Pre-Shared
+	// Key (TLS PSK).
+	TLSPSKNVMeoFSecurityProtocolType NVMeoFSecurityProtocolType = "TLS_PSK"
+	// OEMNVMeoFSecurityProtocolType OEM.
+	OEMNVMeoFSecurityProtocolType NVMeoFSecurityProtocolType = "OEM"
+)
+
+// NVMeoFSecureHashType is a secure hash algorithm.
+type NVMeoFSecureHashType string
+
+const (
+	// SHA256NVMeoFSecureHashType SHA-256.
+	SHA256NVMeoFSecureHashType NVMeoFSecureHashType = "SHA256"
+	// SHA384NVMeoFSecureHashType SHA-384.
+	SHA384NVMeoFSecureHashType NVMeoFSecureHashType = "SHA384"
+	// SHA512NVMeoFSecureHashType SHA-512.
+	SHA512NVMeoFSecureHashType NVMeoFSecureHashType = "SHA512"
+)
+
+// KeyNVMeoF shall contain NVMe-oF specific properties for a key.
+type KeyNVMeoF struct {
+	// HostKeyID shall contain the value of the Id property of the Key
+	// resource representing the host key paired with this target key.
+	HostKeyID string `json:"HostKeyId,omitempty"`
+	// NQN shall contain the NVMe Qualified Name (NQN) of the host or target
+	// subsystem associated with this key.
+	NQN string `json:",omitempty"`
+	// OEMSecurityProtocolType shall contain the OEM-defined security
+	// protocol that this key uses.
+	OEMSecurityProtocolType string `json:",omitempty"`
+	// SecureHashAllowList shall contain the secure hash algorithms allowed
+	// with the usage of this key.
+	SecureHashAllowList []NVMeoFSecureHashType `json:",omitempty"`
+	// SecurityProtocolType shall contain the security protocol that this key
+	// uses.
+	SecurityProtocolType NVMeoFSecurityProtocolType `json:",omitempty"`
+}
+
+// Key shall represent a key for a Redfish implementation.
+type Key struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// KeyString shall contain the key. This property shall be null in
+	// responses for NVMe-oF secrets.
+	KeyString string
+	// KeyType shall contain the format type for the key.
+	KeyType KeyType
+	// NVMeoF shall contain NVMe-oF specific properties for this key. This
+	// property shall be present if KeyType contains the value NVMeoF.
+	NVMeoF KeyNVMeoF
+	// UserDescription shall contain a user-provided string that describes
+	// the key.
+	UserDescription string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (key *Key) GetRawData() []byte {
+	return key.rawData
+}
+
+// UnmarshalJSON unmarshals a Key object from the raw JSON.
+func (key *Key) UnmarshalJSON(b []byte) error {
+	type temp Key
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*key = Key(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	key.rawData = b
+	key.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the key to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (key Key) MarshalJSON() ([]byte, error) {
+	type temp Key
+	return common.MarshalWithRawData(key.rawData, temp(key))
+}
+
+// createPayload builds the body used to create this key in a collection.
+func (key *Key) createPayload() interface{} {
+	type temp struct {
+		KeyString       string
+		KeyType         KeyType
+		NVMeoF          *KeyNVMeoF `json:",omitempty"`
+		UserDescription string     `json:",omitempty"`
+	}
+	t := temp{
+		KeyString:       key.KeyString,
+		KeyType:         key.KeyType,
+		UserDescription: key.UserDescription,
+	}
+	if key.KeyType == NVMeoFKeyType {
+		t.NVMeoF = &key.NVMeoF
+	}
+
+	return t
+}
+
+// Update commits updates to this object's properties to the running system.
+func (key *Key) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Key)
+	original.UnmarshalJSON(key.rawData)
+
+	readWriteFields := []string{
+		"UserDescription",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(key).Elem()
+
+	return key.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Delete removes the key from the service.
+func (key *Key) Delete() error {
+	return key.Client.Delete(key.ODataID)
+}
+
+// GetKey will get a Key instance from the service.
+func GetKey(c common.Client, uri string) (*Key, error) {
+	var key Key
+	err := common.FetchResource(c, uri, &key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ListReferencedKeys gets the collection of Key from
+// a provided reference.
+func ListReferencedKeys(c common.Client, link string) ([]*Key, error) {
+	var result []*Key
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, keyLink := range links.ItemLinks {
+		key := new(Key)
+		expanded, err := links.DecodeMember(c, keyLink, key)
+		if err == nil && !expanded {
+			key, err = GetKey(c, keyLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, key)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var keyBody = `{
+		"@odata.type": "#Key.v1_0_0.Key",
+		"@odata.id": "/redfish/v1/KeyService/NVMeoFSecrets/0",
+		"Id": "0",
+		"Name": "NVMe-oF Key",
+		"KeyType": "NVMeoF",
+		"KeyString": null,
+		"UserDescription": "Target subsystem key",
+		"NVMeoF": {
+			"NQN": "nqn.corp.com:nvme:target-subsystem-0001",
+			"SecurityProtocolType": "DHHC",
+			"SecureHashAllowList": [
+				"SHA384",
+				"SHA512"
+			]
+		}
+	}`
+
+// TestKey tests the parsing of Key objects.
+func TestKey(t *testing.T) {
+	var result Key
+	err := json.NewDecoder(strings.NewReader(keyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.KeyType != NVMeoFKeyType {
+		t.Errorf("Invalid key type: %s", result.KeyType)
+	}
+
+	if result.NVMeoF.SecurityProtocolType != DHHCNVMeoFSecurityProtocolType {
+		t.Errorf("Invalid security protocol type: %s", result.NVMeoF.SecurityProtocolType)
+	}
+
+	if len(result.NVMeoF.SecureHashAllowList) != 2 {
+		t.Errorf("Invalid secure hash allow list: %v", result.NVMeoF.SecureHashAllowList)
+	}
+}
+
+// TestKeyUpdate tests the Update call.
+func TestKeyUpdate(t *testing.T) {
+	var result Key
+	err := json.NewDecoder(strings.NewReader(keyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.UserDescription = "Rotated key"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "UserDescription:Rotated key") {
+		t.Errorf("Unexpected UserDescription update payload: %s", calls[0].Payload)
+	}
+}
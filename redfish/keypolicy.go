@@ -0,0 +1,176 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// KeyPolicyType is the type of a key policy.
+type KeyPolicyType string
+
+const (
+	// NVMeoFKeyPolicyType shall indicate the key policy is for an NVMe-oF key.
+	NVMeoFKeyPolicyType KeyPolicyType = "NVMeoF"
+)
+
+// KeyPolicyNVMeoF shall contain NVMe-oF specific properties for a key
+// policy.
+type KeyPolicyNVMeoF struct {
+	// CipherSuiteAllowList shall contain the cipher suites that this policy
+	// allows.
+	CipherSuiteAllowList []string `json:",omitempty"`
+	// DHGroupAllowList shall contain the Diffie-Hellman (DH) groups that this
+	// policy allows.
+	DHGroupAllowList []string `json:",omitempty"`
+	// OEMSecurityProtocolAllowList shall contain the OEM-defined security
+	// protocols that this policy allows.
+	OEMSecurityProtocolAllowList []string `json:",omitempty"`
+	// SecureHashAllowList shall contain the secure hash algorithms that this
+	// policy allows.
+	SecureHashAllowList []NVMeoFSecureHashType `json:",omitempty"`
+	// SecurityProtocolAllowList shall contain the security protocols that
+	// this policy allows.
+	SecurityProtocolAllowList []NVMeoFSecurityProtocolType `json:",omitempty"`
+	// SecurityTransportAllowList shall contain the security transports that
+	// this policy allows.
+	SecurityTransportAllowList []string `json:",omitempty"`
+}
+
+// KeyPolicy shall represent a key policy for a Redfish implementation.
+type KeyPolicy struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// IsDefault shall indicate if this key policy is the policy applied when
+	// no other policies are specified.
+	IsDefault bool
+	// KeyPolicyType shall contain the type of key policy.
+	KeyPolicyType KeyPolicyType
+	// NVMeoF shall contain NVMe-oF specific properties for this key policy.
+	NVMeoF KeyPolicyNVMeoF
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (keypolicy *KeyPolicy) GetRawData() []byte {
+	return keypolicy.rawData
+}
+
+// UnmarshalJSON unmarshals a KeyPolicy object from the raw JSON.
+func (keypolicy *KeyPolicy) UnmarshalJSON(b []byte) error {
+	type temp KeyPolicy
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*keypolicy = KeyPolicy(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	keypolicy.rawData = b
+	keypolicy.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the key policy to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (keypolicy KeyPolicy) MarshalJSON() ([]byte, error) {
+	type temp KeyPolicy
+	return common.MarshalWithRawData(keypolicy.rawData, temp(keypolicy))
+}
+
+// createPayload builds the body used to create this policy in a collection.
+func (keypolicy *KeyPolicy) createPayload() interface{} {
+	type temp struct {
+		IsDefault     bool
+		KeyPolicyType KeyPolicyType
+		NVMeoF        KeyPolicyNVMeoF
+	}
+
+	return temp{
+		IsDefault:     keypolicy.IsDefault,
+		KeyPolicyType: keypolicy.KeyPolicyType,
+		NVMeoF:        keypolicy.NVMeoF,
+	}
+}
+
+// Update commits updates to this object's properties to the running system.
+func (keypolicy *KeyPolicy) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(KeyPolicy)
+	original.UnmarshalJSON(keypolicy.rawData)
+
+	readWriteFields := []string{
+		"IsDefault",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(keypolicy).Elem()
+
+	return keypolicy.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Delete removes the key policy from the service.
+func (keypolicy *KeyPolicy) Delete() error {
+	return keypolicy.Client.Delete(keypolicy.ODataID)
+}
+
+// GetKeyPolicy will get a KeyPolicy instance from the service.
+func GetKeyPolicy(c common.Client, uri string) (*KeyPolicy, error) {
+	var keypolicy KeyPolicy
+	err := common.FetchResource(c, uri, &keypolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keypolicy, nil
+}
+
+// ListReferencedKeyPolicies gets the collection of KeyPolicy from
+// a provided reference.
+func ListReferencedKeyPolicies(c common.Client, link string) ([]*KeyPolicy, error) {
+	var result []*KeyPolicy
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, keypolicyLink := range links.ItemLinks {
+		keypolicy := new(KeyPolicy)
+		expanded, err := links.DecodeMember(c, keypolicyLink, keypolicy)
+		if err == nil && !expanded {
+			keypolicy, err = GetKeyPolicy(c, keypolicyLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, keypolicy)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,60 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var keyPolicyBody = `{
+		"@odata.type": "#KeyPolicy.v1_0_0.KeyPolicy",
+		"@odata.id": "/redfish/v1/KeyService/NVMeoFKeyPolicies/0",
+		"Id": "0",
+		"Name": "Default NVMe-oF Key Policy",
+		"IsDefault": true,
+		"KeyPolicyType": "NVMeoF",
+		"NVMeoF": {
+			"SecurityProtocolAllowList": [
+				"DHHC",
+				"TLS_PSK"
+			],
+			"SecureHashAllowList": [
+				"SHA384",
+				"SHA512"
+			],
+			"DHGroupAllowList": [
+				"FFDHE2048"
+			]
+		}
+	}`
+
+// TestKeyPolicy tests the parsing of KeyPolicy objects.
+func TestKeyPolicy(t *testing.T) {
+	var result KeyPolicy
+	err := json.NewDecoder(strings.NewReader(keyPolicyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if !result.IsDefault {
+		t.Error("IsDefault should be true")
+	}
+
+	if result.KeyPolicyType != NVMeoFKeyPolicyType {
+		t.Errorf("Invalid key policy type: %s", result.KeyPolicyType)
+	}
+
+	if len(result.NVMeoF.SecurityProtocolAllowList) != 2 ||
+		result.NVMeoF.SecurityProtocolAllowList[1] != TLSPSKNVMeoFSecurityProtocolType {
+		t.Errorf("Invalid security protocol allow list: %v", result.NVMeoF.SecurityProtocolAllowList)
+	}
+}
@@ -0,0 +1,120 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// KeyService shall represent the key service properties for a Redfish
+// implementation.
+type KeyService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// nvmeofKeyPolicies shall be a link to a resource collection of type
+	// KeyPolicyCollection that contains the NVMe-oF key policies maintained
+	// by this service.
+	nvmeofKeyPolicies string
+	// nvmeofSecrets shall be a link to a resource collection of type
+	// KeyCollection that contains the NVMe-oF keys maintained by this
+	// service.
+	nvmeofSecrets string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (keyservice *KeyService) GetRawData() []byte {
+	return keyservice.rawData
+}
+
+// UnmarshalJSON unmarshals a KeyService object from the raw JSON.
+func (keyservice *KeyService) UnmarshalJSON(b []byte) error {
+	type temp KeyService
+	var t struct {
+		temp
+		NVMeoFKeyPolicies common.Link
+		NVMeoFSecrets     common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*keyservice = KeyService(t.temp)
+
+	// Extract the links to other entities for later
+	keyservice.nvmeofKeyPolicies = string(t.NVMeoFKeyPolicies)
+	keyservice.nvmeofSecrets = string(t.NVMeoFSecrets)
+
+	keyservice.rawData = b
+	keyservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the key service to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (keyservice KeyService) MarshalJSON() ([]byte, error) {
+	type temp KeyService
+	return common.MarshalWithRawData(keyservice.rawData, temp(keyservice))
+}
+
+// GetKeyService will get a KeyService instance from the service.
+func GetKeyService(c common.Client, uri string) (*KeyService, error) {
+	var keyservice KeyService
+	err := common.FetchResource(c, uri, &keyservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyservice, nil
+}
+
+// NVMeoFKeyPolicies gets the NVMe-oF key policies maintained by this service.
+func (keyservice *KeyService) NVMeoFKeyPolicies() ([]*KeyPolicy, error) {
+	return ListReferencedKeyPolicies(keyservice.Client, keyservice.nvmeofKeyPolicies)
+}
+
+// NVMeoFSecrets gets the NVMe-oF keys maintained by this service.
+func (keyservice *KeyService) NVMeoFSecrets() ([]*Key, error) {
+	return ListReferencedKeys(keyservice.Client, keyservice.nvmeofSecrets)
+}
+
+// CreateNVMeoFSecret creates a new NVMe-oF key. The KeyString of the key
+// shall contain the secret in the format defined by the NVMe Base
+// Specification.
+func (keyservice *KeyService) CreateNVMeoFSecret(key *Key) error {
+	if keyservice.nvmeofSecrets == "" {
+		return fmt.Errorf("this key service does not support NVMe-oF secrets")
+	}
+
+	key.KeyType = NVMeoFKeyType
+	_, err := keyservice.Client.Post(keyservice.nvmeofSecrets, key.createPayload())
+	return err
+}
+
+// CreateNVMeoFKeyPolicy creates a new NVMe-oF key policy.
+func (keyservice *KeyService) CreateNVMeoFKeyPolicy(policy *KeyPolicy) error {
+	if keyservice.nvmeofKeyPolicies == "" {
+		return fmt.Errorf("this key service does not support NVMe-oF key policies")
+	}
+
+	policy.KeyPolicyType = NVMeoFKeyPolicyType
+	_, err := keyservice.Client.Post(keyservice.nvmeofKeyPolicies, policy.createPayload())
+	return err
+}
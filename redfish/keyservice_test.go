@@ -0,0 +1,96 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var keyServiceBody = `{
+		"@odata.type": "#KeyService.v1_0_0.KeyService",
+		"@odata.id": "/redfish/v1/KeyService",
+		"Id": "KeyService",
+		"Name": "Key Service",
+		"NVMeoFSecrets": {
+			"@odata.id": "/redfish/v1/KeyService/NVMeoFSecrets"
+		},
+		"NVMeoFKeyPolicies": {
+			"@odata.id": "/redfish/v1/KeyService/NVMeoFKeyPolicies"
+		}
+	}`
+
+// TestKeyService tests the parsing of KeyService objects.
+func TestKeyService(t *testing.T) {
+	var result KeyService
+	err := json.NewDecoder(strings.NewReader(keyServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "KeyService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.nvmeofSecrets != "/redfish/v1/KeyService/NVMeoFSecrets" {
+		t.Errorf("Invalid NVMeoFSecrets link: %s", result.nvmeofSecrets)
+	}
+
+	if result.nvmeofKeyPolicies != "/redfish/v1/KeyService/NVMeoFKeyPolicies" {
+		t.Errorf("Invalid NVMeoFKeyPolicies link: %s", result.nvmeofKeyPolicies)
+	}
+}
+
+// TestKeyServiceCreate tests creating keys and key policies.
+func TestKeyServiceCreate(t *testing.T) {
+	var result KeyService
+	err := json.NewDecoder(strings.NewReader(keyServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateNVMeoFSecret(&Key{
+		KeyString: "DHHC-1:00:ia6zGodOr4SEG0Zzaw398rpY0wqipUWj4jWjUh4HWUz6aQ2n:",
+		NVMeoF: KeyNVMeoF{
+			NQN:                  "nqn.corp.com:nvme:target-subsystem-0001",
+			SecurityProtocolType: DHHCNVMeoFSecurityProtocolType,
+		},
+	})
+	if err != nil {
+		t.Errorf("Error making CreateNVMeoFSecret call: %s", err)
+	}
+
+	err = result.CreateNVMeoFKeyPolicy(&KeyPolicy{
+		NVMeoF: KeyPolicyNVMeoF{
+			SecureHashAllowList: []NVMeoFSecureHashType{SHA384NVMeoFSecureHashType},
+		},
+	})
+	if err != nil {
+		t.Errorf("Error making CreateNVMeoFKeyPolicy call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.nvmeofSecrets || !strings.Contains(calls[0].Payload, "NVMeoF") ||
+		!strings.Contains(calls[0].Payload, "DHHC-1:00:") {
+		t.Errorf("Unexpected CreateNVMeoFSecret call: %v", calls[0])
+	}
+
+	if calls[1].URL != result.nvmeofKeyPolicies || !strings.Contains(calls[1].Payload, "SHA384") {
+		t.Errorf("Unexpected CreateNVMeoFKeyPolicy call: %v", calls[1])
+	}
+}
@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LDAPConfiguration is the configuration of an external LDAP or Active
+// Directory account provider, for AccountService.ConfigureLDAP.
+type LDAPConfiguration struct {
+	// ActiveDirectory configures the ActiveDirectory account provider
+	// rather than the LDAP one.
+	ActiveDirectory bool
+	// Servers are the addresses of the directory servers, such as
+	// "ldaps://ldap.example.com:636".
+	Servers []string
+	// BindDN and BindPassword are the credentials the service binds to the
+	// directory with. The service binds anonymously if BindDN is empty.
+	BindDN       string
+	BindPassword string
+	// BaseDNs are the base distinguished names users and groups are
+	// searched under.
+	BaseDNs []string
+	// UsernameAttribute and GroupsAttribute are the attributes of the user
+	// entries holding the user name and groups. The service defaults are
+	// used if they are empty.
+	UsernameAttribute string
+	GroupsAttribute   string
+	// RoleMappings map the directory groups or users to local roles. They
+	// replace the mappings of the account provider.
+	RoleMappings []RoleMapping
+	// CACertificate is the PEM-encoded certificate of the certificate
+	// authority of the servers, added to the certificates of the account
+	// provider if it is not there yet.
+	CACertificate string
+}
+
+// ConfigureLDAP configures and enables the LDAP, or Active Directory,
+// account provider of the account service.
+//
+// Services differ in what they accept in a single PATCH, so the
+// configuration is sent in steps: the servers, credentials and search
+// settings, then the role mappings, which some services only accept on
+// their own, then the certificate authority, and last the enablement of the
+// provider, once it can be used.
+func (accountservice *AccountService) ConfigureLDAP(configuration LDAPConfiguration) error {
+	if len(configuration.Servers) == 0 {
+		return fmt.Errorf("at least one directory server is required")
+	}
+
+	providerName := "LDAP"
+	provider := accountservice.LDAP
+	if configuration.ActiveDirectory {
+		providerName = "ActiveDirectory"
+		provider = accountservice.ActiveDirectory
+	}
+
+	settings := map[string]interface{}{
+		"ServiceAddresses": configuration.Servers,
+	}
+	if configuration.BindDN != "" {
+		settings["Authentication"] = map[string]interface{}{
+			"AuthenticationType": UsernameAndPasswordAuthenticationTypes,
+			"Username":           configuration.BindDN,
+			"Password":           configuration.BindPassword,
+		}
+	}
+	searchSettings := make(map[string]interface{})
+	if len(configuration.BaseDNs) > 0 {
+		searchSettings["BaseDistinguishedNames"] = configuration.BaseDNs
+	}
+	if configuration.UsernameAttribute != "" {
+		searchSettings["UsernameAttribute"] = configuration.UsernameAttribute
+	}
+	if configuration.GroupsAttribute != "" {
+		searchSettings["GroupsAttribute"] = configuration.GroupsAttribute
+	}
+	if len(searchSettings) > 0 && !configuration.ActiveDirectory {
+		settings["LDAPService"] = map[string]interface{}{
+			"SearchSettings": searchSettings,
+		}
+	}
+
+	err := accountservice.patchAccountProvider(providerName, settings)
+	if err != nil {
+		return err
+	}
+
+	if configuration.RoleMappings != nil {
+		mappings := make([]interface{}, 0, len(configuration.RoleMappings))
+		for _, roleMapping := range configuration.RoleMappings {
+			mapping := map[string]interface{}{
+				"LocalRole": roleMapping.LocalRole,
+			}
+			if roleMapping.RemoteGroup != "" {
+				mapping["RemoteGroup"] = roleMapping.RemoteGroup
+			}
+			if roleMapping.RemoteUser != "" {
+				mapping["RemoteUser"] = roleMapping.RemoteUser
+			}
+			mappings = append(mappings, mapping)
+		}
+
+		err = accountservice.patchAccountProvider(providerName, map[string]interface{}{
+			"RemoteRoleMapping": mappings,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if configuration.CACertificate != "" {
+		err = accountservice.addProviderCertificate(provider, configuration.CACertificate)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = accountservice.patchAccountProvider(providerName, map[string]interface{}{
+		"ServiceEnabled": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	provider.ServiceAddresses = configuration.Servers
+	provider.RemoteRoleMapping = configuration.RoleMappings
+	provider.ServiceEnabled = true
+	if configuration.ActiveDirectory {
+		accountservice.ActiveDirectory = provider
+	} else {
+		accountservice.LDAP = provider
+	}
+
+	return nil
+}
+
+// patchAccountProvider sends a change of an external account provider.
+func (accountservice *AccountService) patchAccountProvider(providerName string, settings map[string]interface{}) error {
+	_, err := accountservice.Client.Patch(accountservice.ODataID, map[string]interface{}{
+		providerName: settings,
+	})
+	return err
+}
+
+// addProviderCertificate adds a certificate to the certificates of an
+// external account provider, unless it is there already.
+func (accountservice *AccountService) addProviderCertificate(provider ExternalAccountProvider, certificateString string) error {
+	if provider.certificates == "" {
+		return fmt.Errorf("the account provider does not accept certificates")
+	}
+
+	certificates, err := provider.Certificates(accountservice.Client)
+	if err != nil {
+		return err
+	}
+
+	for _, certificate := range certificates {
+		if strings.TrimSpace(certificate.CertificateString) == strings.TrimSpace(certificateString) {
+			return nil
+		}
+	}
+
+	type temp struct {
+		CertificateString string
+		CertificateType   CertificateType
+	}
+	t := temp{
+		CertificateString: certificateString,
+		CertificateType:   PEMCertificateType,
+	}
+
+	_, err = accountservice.Client.Post(provider.certificates, t)
+	return err
+}
@@ -0,0 +1,127 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// LeakDetectorExcerpt shall contain the state of a leak detector within a
+// group.
+type LeakDetectorExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// DetectorState shall contain the state of the leak detector.
+	DetectorState common.Health
+}
+
+// LeakDetectorGroup shall contain a group of leak detectors.
+type LeakDetectorGroup struct {
+	// Detectors shall contain the states of the leak detectors in this
+	// group.
+	Detectors []LeakDetectorExcerpt
+	// GroupName shall contain the name used to describe the leak detectors
+	// in this group.
+	GroupName string
+	// HumidityPercent shall contain the humidity, in percent units, for this
+	// group of leak detectors.
+	HumidityPercent SensorExcerpt
+	// Status shall contain any status or health properties of the group.
+	Status common.Status
+}
+
+// LeakDetection shall describe the leak detection functionality of a
+// cooling equipment or chassis for a Redfish implementation.
+type LeakDetection struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// LeakDetectorGroups shall contain an array of groups of leak detectors.
+	LeakDetectorGroups []LeakDetectorGroup
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// leakDetectors shall be a link to a resource collection of type
+	// LeakDetectorCollection.
+	leakDetectors string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (leakdetection *LeakDetection) GetRawData() []byte {
+	return leakdetection.rawData
+}
+
+// UnmarshalJSON unmarshals a LeakDetection object from the raw JSON.
+func (leakdetection *LeakDetection) UnmarshalJSON(b []byte) error {
+	type temp LeakDetection
+	var t struct {
+		temp
+		LeakDetectors common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*leakdetection = LeakDetection(t.temp)
+
+	// Extract the links to other entities for later
+	leakdetection.leakDetectors = string(t.LeakDetectors)
+
+	leakdetection.rawData = b
+	leakdetection.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the leak detection to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (leakdetection LeakDetection) MarshalJSON() ([]byte, error) {
+	type temp LeakDetection
+	return common.MarshalWithRawData(leakdetection.rawData, temp(leakdetection))
+}
+
+// LeakDetectors gets the leak detectors of this equipment.
+func (leakdetection *LeakDetection) LeakDetectors() ([]*LeakDetector, error) {
+	return ListReferencedLeakDetectors(leakdetection.Client, leakdetection.leakDetectors)
+}
+
+// LeakingDetectors gets the URIs of the detectors of all groups that report
+// a leak, without having to retrieve every leak detector.
+func (leakdetection *LeakDetection) LeakingDetectors() []string {
+	var result []string
+	for _, group := range leakdetection.LeakDetectorGroups {
+		for _, detector := range group.Detectors {
+			if detector.DetectorState != "" && detector.DetectorState != common.OKHealth {
+				result = append(result, detector.DataSourceURI)
+			}
+		}
+	}
+
+	return result
+}
+
+// GetLeakDetection will get a LeakDetection instance from the service.
+func GetLeakDetection(c common.Client, uri string) (*LeakDetection, error) {
+	var leakdetection LeakDetection
+	err := common.FetchResource(c, uri, &leakdetection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leakdetection, nil
+}
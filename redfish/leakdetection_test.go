@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var leakDetectionBody = `{
+		"@odata.type": "#LeakDetection.v1_0_0.LeakDetection",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection",
+		"Id": "LeakDetection",
+		"Name": "Leak Detection Systems",
+		"LeakDetectors": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors"
+		},
+		"LeakDetectorGroups": [
+			{
+				"GroupName": "Detectors under and around the CDU",
+				"Detectors": [
+					{
+						"DataSourceUri": "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors/Moisture",
+						"DetectorState": "Critical"
+					},
+					{
+						"DataSourceUri": "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors/Overflow",
+						"DetectorState": "OK"
+					}
+				],
+				"HumidityPercent": {
+					"DataSourceUri": "/redfish/v1/Chassis/CDU1/Sensors/Humidity",
+					"Reading": 45
+				},
+				"Status": {
+					"State": "Enabled",
+					"Health": "Critical"
+				}
+			}
+		],
+		"Status": {
+			"State": "Enabled",
+			"Health": "Critical"
+		}
+	}`
+
+// TestLeakDetection tests the parsing of LeakDetection objects.
+func TestLeakDetection(t *testing.T) {
+	var result LeakDetection
+	err := json.NewDecoder(strings.NewReader(leakDetectionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "LeakDetection" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.leakDetectors != "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors" {
+		t.Errorf("Invalid leak detectors link: %s", result.leakDetectors)
+	}
+
+	if len(result.LeakDetectorGroups) != 1 {
+		t.Fatalf("Invalid number of leak detector groups: %d", len(result.LeakDetectorGroups))
+	}
+
+	if result.LeakDetectorGroups[0].HumidityPercent.Reading != 45 {
+		t.Errorf("Invalid humidity reading: %f", result.LeakDetectorGroups[0].HumidityPercent.Reading)
+	}
+
+	leaking := result.LeakingDetectors()
+	if len(leaking) != 1 || leaking[0] != "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors/Moisture" {
+		t.Errorf("Invalid leaking detectors: %v", leaking)
+	}
+}
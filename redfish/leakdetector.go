@@ -0,0 +1,155 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// LeakDetectorType is the type of a leak detector.
+type LeakDetectorType string
+
+const (
+	// MoistureLeakDetectorType A moisture sensor.
+	MoistureLeakDetectorType LeakDetectorType = "Moisture"
+	// FloatSwitchLeakDetectorType A float switch.
+	FloatSwitchLeakDetectorType LeakDetectorType = "FloatSwitch"
+)
+
+// LeakDetector shall describe a state-based or digital-value leak detector
+// for a Redfish implementation.
+type LeakDetector struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DetectorState shall contain the state of the leak detector. OK
+	// indicates no leak is detected.
+	DetectorState common.Health
+	// LeakDetectorType shall contain the reading type of the leak detection
+	// sensor.
+	LeakDetectorType LeakDetectorType
+	// Location shall indicate the location information for this leak
+	// detector.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the leak detector.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this leak detector.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this leak detector.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this leak detector applies.
+	PhysicalContext common.PhysicalContext
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this leak detector.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this leak detector.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (leakdetector *LeakDetector) GetRawData() []byte {
+	return leakdetector.rawData
+}
+
+// UnmarshalJSON unmarshals a LeakDetector object from the raw JSON.
+func (leakdetector *LeakDetector) UnmarshalJSON(b []byte) error {
+	type temp LeakDetector
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*leakdetector = LeakDetector(t.temp)
+	leakdetector.rawData = b
+	leakdetector.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the leak detector to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (leakdetector LeakDetector) MarshalJSON() ([]byte, error) {
+	type temp LeakDetector
+	return common.MarshalWithRawData(leakdetector.rawData, temp(leakdetector))
+}
+
+// LeakDetected reports whether the detector is reporting a leak.
+func (leakdetector *LeakDetector) LeakDetected() bool {
+	return leakdetector.DetectorState != "" && leakdetector.DetectorState != common.OKHealth
+}
+
+// GetLeakDetector will get a LeakDetector instance from the service.
+func GetLeakDetector(c common.Client, uri string) (*LeakDetector, error) {
+	var leakdetector LeakDetector
+	err := common.FetchResource(c, uri, &leakdetector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leakdetector, nil
+}
+
+// ListReferencedLeakDetectors gets the collection of LeakDetector from
+// a provided reference.
+func ListReferencedLeakDetectors(c common.Client, link string) ([]*LeakDetector, error) {
+	var result []*LeakDetector
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, leakdetectorLink := range links.ItemLinks {
+		leakdetector := new(LeakDetector)
+		expanded, err := links.DecodeMember(c, leakdetectorLink, leakdetector)
+		if err == nil && !expanded {
+			leakdetector, err = GetLeakDetector(c, leakdetectorLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, leakdetector)
+	}
+
+	return result, nil
+}
+
+// IsLeakDetectionMessage reports whether an event or log entry message ID,
+// such as "Environmental.1.0.LeakDetectedCritical", reports a change of the
+// state of a leak detector.
+func IsLeakDetectionMessage(messageID string) bool {
+	parts := strings.Split(messageID, ".")
+	if len(parts) < 2 || parts[0] != "Environmental" {
+		return false
+	}
+
+	return strings.HasPrefix(parts[len(parts)-1], "LeakDetected")
+}
@@ -0,0 +1,66 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var leakDetectorBody = `{
+		"@odata.type": "#LeakDetector.v1_0_0.LeakDetector",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/LeakDetection/LeakDetectors/Moisture",
+		"Id": "Moisture",
+		"Name": "Moisture-type Leak Detector",
+		"LeakDetectorType": "Moisture",
+		"DetectorState": "Critical",
+		"PhysicalContext": "Chassis",
+		"Manufacturer": "Contoso",
+		"Status": {
+			"State": "Enabled",
+			"Health": "Critical"
+		}
+	}`
+
+// TestLeakDetector tests the parsing of LeakDetector objects.
+func TestLeakDetector(t *testing.T) {
+	var result LeakDetector
+	err := json.NewDecoder(strings.NewReader(leakDetectorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Moisture" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.LeakDetectorType != MoistureLeakDetectorType {
+		t.Errorf("Invalid leak detector type: %s", result.LeakDetectorType)
+	}
+
+	if !result.LeakDetected() {
+		t.Error("Leak detector should report a leak")
+	}
+}
+
+// TestIsLeakDetectionMessage tests matching leak detection message IDs.
+func TestIsLeakDetectionMessage(t *testing.T) {
+	messages := map[string]bool{
+		"Environmental.1.0.LeakDetectedCritical": true,
+		"Environmental.1.0.LeakDetectedNormal":   true,
+		"Environmental.LeakDetectedWarning":      true,
+		"Environmental.1.0.TemperatureHigh":      false,
+		"Base.1.8.LeakDetectedCritical":          false,
+		"":                                       false,
+	}
+
+	for messageID, expected := range messages {
+		if IsLeakDetectionMessage(messageID) != expected {
+			t.Errorf("Unexpected result for message ID %q", messageID)
+		}
+	}
+}
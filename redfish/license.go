@@ -0,0 +1,215 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// AuthorizationScope is the scope of the authorization of a license.
+type AuthorizationScope string
+
+const (
+	// DeviceAuthorizationScope shall indicate the license authorizes
+	// functionality for one or more specific device instances, listed as
+	// values of the AuthorizedDevices property.
+	DeviceAuthorizationScope AuthorizationScope = "Device"
+	// CapacityAuthorizationScope shall indicate the license authorizes
+	// functionality for one or more device instances limited to a maximum
+	// number of devices specified by the value of the MaxAuthorizedDevices
+	// property.
+	CapacityAuthorizationScope AuthorizationScope = "Capacity"
+	// ServiceAuthorizationScope shall indicate the license authorizes
+	// product-level or service-level functionality for a service.
+	ServiceAuthorizationScope AuthorizationScope = "Service"
+)
+
+// LicenseOrigin is the origin of a license.
+type LicenseOrigin string
+
+const (
+	// BuiltInLicenseOrigin A license was provided with the product.
+	BuiltInLicenseOrigin LicenseOrigin = "BuiltIn"
+	// InstalledLicenseOrigin A license installed by user.
+	InstalledLicenseOrigin LicenseOrigin = "Installed"
+)
+
+// LicenseType is the type of a license.
+type LicenseType string
+
+const (
+	// ProductionLicenseType shall indicate a license purchased or obtained
+	// for use in production environments.
+	ProductionLicenseType LicenseType = "Production"
+	// PrototypeLicenseType shall indicate a license that is designed for
+	// development or internal use.
+	PrototypeLicenseType LicenseType = "Prototype"
+	// TrialLicenseType shall indicate a trial version of a license.
+	TrialLicenseType LicenseType = "Trial"
+)
+
+// License shall represent a license for a Redfish implementation.
+type License struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AuthorizationScope shall contain the authorization scope of the
+	// license.
+	AuthorizationScope AuthorizationScope
+	// Contact shall contain an object containing information about the
+	// contact of the license.
+	Contact common.ContactInfo
+	// Description provides a description of this resource.
+	Description string
+	// DownloadURI shall contain the URI from which to download the license
+	// file, using the Redfish protocol and authentication methods.
+	DownloadURI string
+	// EntitlementID shall contain the entitlement identifier for this
+	// license, used to display a customer reference or other identifier.
+	EntitlementID string `json:"EntitlementId"`
+	// ExpirationDate shall contain the date and time when the license
+	// expires.
+	ExpirationDate common.DateTime
+	// GracePeriodDays shall contain the number of days that the license is
+	// still usable after the date and time specified by the ExpirationDate
+	// property.
+	GracePeriodDays int
+	// InstallDate shall contain the date and time when the license was
+	// installed.
+	InstallDate common.DateTime
+	// LicenseInfoURI shall contain the URI at which more information about
+	// this license can be obtained.
+	LicenseInfoURI string
+	// LicenseOrigin shall contain the origin for the license.
+	LicenseOrigin LicenseOrigin
+	// LicenseString shall contain the Base64-encoded string of the license.
+	// This property shall not appear in response payloads.
+	LicenseString string
+	// LicenseType shall contain the type for the license.
+	LicenseType LicenseType
+	// Manufacturer shall contain the name of the manufacturer or producer of
+	// this license.
+	Manufacturer string
+	// MaxAuthorizedDevices shall contain the maximum number of devices that
+	// are authorized by the license.
+	MaxAuthorizedDevices int
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// license.
+	PartNumber string
+	// RemainingDuration shall contain the remaining usage duration before
+	// the license expires.
+	RemainingDuration common.Duration
+	// RemainingUseCount shall contain the remaining usage count before the
+	// license expires.
+	RemainingUseCount int
+	// Removable shall indicate whether a user can remove the license with an
+	// HTTP DELETE operation.
+	Removable bool
+	// SKU shall contain the SKU number for this license.
+	SKU string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the license.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// authorizedDevices are the devices authorized by the license.
+	authorizedDevices []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (license *License) GetRawData() []byte {
+	return license.rawData
+}
+
+// UnmarshalJSON unmarshals a License object from the raw JSON.
+func (license *License) UnmarshalJSON(b []byte) error {
+	type temp License
+	var t struct {
+		temp
+		Links struct {
+			AuthorizedDevices common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*license = License(t.temp)
+
+	// Extract the links to other entities for later
+	license.authorizedDevices = t.Links.AuthorizedDevices.ToStrings()
+
+	license.rawData = b
+	license.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the license to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (license License) MarshalJSON() ([]byte, error) {
+	type temp License
+	return common.MarshalWithRawData(license.rawData, temp(license))
+}
+
+// AuthorizedDevices gets the URIs of the devices authorized by this license.
+func (license *License) AuthorizedDevices() []string {
+	return license.authorizedDevices
+}
+
+// Delete removes the license from the service.
+func (license *License) Delete() error {
+	return license.Client.Delete(license.ODataID)
+}
+
+// GetLicense will get a License instance from the service.
+func GetLicense(c common.Client, uri string) (*License, error) {
+	var license License
+	err := common.FetchResource(c, uri, &license)
+	if err != nil {
+		return nil, err
+	}
+
+	return &license, nil
+}
+
+// ListReferencedLicenses gets the collection of License from
+// a provided reference.
+func ListReferencedLicenses(c common.Client, link string) ([]*License, error) {
+	var result []*License
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, licenseLink := range links.ItemLinks {
+		license := new(License)
+		expanded, err := links.DecodeMember(c, licenseLink, license)
+		if err == nil && !expanded {
+			license, err = GetLicense(c, licenseLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, license)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var licenseBody = `{
+		"@odata.type": "#License.v1_0_0.License",
+		"@odata.id": "/redfish/v1/LicenseService/Licenses/KVM",
+		"Id": "KVM",
+		"Name": "Advanced KVM License",
+		"EntitlementId": "LIC09113",
+		"LicenseType": "Production",
+		"LicenseOrigin": "Installed",
+		"Removable": true,
+		"AuthorizationScope": "Device",
+		"ExpirationDate": "2027-05-05T00:00:00Z",
+		"InstallDate": "2026-05-05T00:00:00Z",
+		"Manufacturer": "Contoso",
+		"SerialNumber": "5XJ3HJ",
+		"Contact": {
+			"ContactName": "Licensing Department",
+			"EmailAddress": "licensing@contoso.org"
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"AuthorizedDevices": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC"
+				}
+			]
+		}
+	}`
+
+// TestLicense tests the parsing of License objects.
+func TestLicense(t *testing.T) {
+	var result License
+	err := json.NewDecoder(strings.NewReader(licenseBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "KVM" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.EntitlementID != "LIC09113" {
+		t.Errorf("Invalid entitlement ID: %s", result.EntitlementID)
+	}
+
+	if result.LicenseType != ProductionLicenseType {
+		t.Errorf("Invalid license type: %s", result.LicenseType)
+	}
+
+	if result.AuthorizationScope != DeviceAuthorizationScope {
+		t.Errorf("Invalid authorization scope: %s", result.AuthorizationScope)
+	}
+
+	if result.Contact.EmailAddress != "licensing@contoso.org" {
+		t.Errorf("Invalid contact email: %s", result.Contact.EmailAddress)
+	}
+
+	if len(result.AuthorizedDevices()) != 1 || result.AuthorizedDevices()[0] != "/redfish/v1/Managers/BMC" {
+		t.Errorf("Invalid authorized devices: %v", result.AuthorizedDevices())
+	}
+}
@@ -0,0 +1,210 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TransferProtocolType is the network protocol used to retrieve a remote
+// file.
+type TransferProtocolType string
+
+const (
+	// CIFSTransferProtocolType Common Internet File System (CIFS).
+	CIFSTransferProtocolType TransferProtocolType = "CIFS"
+	// FTPTransferProtocolType File Transfer Protocol (FTP).
+	FTPTransferProtocolType TransferProtocolType = "FTP"
+	// SFTPTransferProtocolType SSH File Transfer Protocol (SFTP).
+	SFTPTransferProtocolType TransferProtocolType = "SFTP"
+	// HTTPTransferProtocolType Hypertext Transfer Protocol (HTTP).
+	HTTPTransferProtocolType TransferProtocolType = "HTTP"
+	// HTTPSTransferProtocolType Hypertext Transfer Protocol Secure (HTTPS).
+	HTTPSTransferProtocolType TransferProtocolType = "HTTPS"
+	// NFSTransferProtocolType Network File System (NFS).
+	NFSTransferProtocolType TransferProtocolType = "NFS"
+	// SCPTransferProtocolType Secure Copy Protocol (SCP).
+	SCPTransferProtocolType TransferProtocolType = "SCP"
+	// TFTPTransferProtocolType Trivial File Transfer Protocol (TFTP).
+	TFTPTransferProtocolType TransferProtocolType = "TFTP"
+	// OEMTransferProtocolType A manufacturer-defined protocol.
+	OEMTransferProtocolType TransferProtocolType = "OEM"
+)
+
+// LicenseInstallParameters are the parameters of the LicenseService.Install
+// action.
+type LicenseInstallParameters struct {
+	// LicenseFileURI shall contain an RFC3986-defined URI that links to a
+	// file that the license service retrieves to install the license in
+	// that file.
+	LicenseFileURI string
+	// Password shall contain the password to access the URI specified by
+	// the LicenseFileURI parameter.
+	Password string `json:",omitempty"`
+	// Targets shall contain an array of links to resources of type Manager
+	// that represent the devices to apply the license to.
+	Targets []string `json:"-"`
+	// TransferProtocol shall contain the network protocol that the license
+	// service uses to retrieve the license file.
+	TransferProtocol TransferProtocolType `json:",omitempty"`
+	// Username shall contain the username to access the URI specified by
+	// the LicenseFileURI parameter.
+	Username string `json:",omitempty"`
+}
+
+// LicenseService shall represent a license service and the properties that
+// affect the service itself for a Redfish implementation.
+type LicenseService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// LicenseExpirationWarningDays shall contain the number of days prior to
+	// a license expiration that the service shall send the DaysBeforeExpiration
+	// message from the License Message Registry at least once.
+	LicenseExpirationWarningDays int
+	// licenses shall be a link to a resource collection of type
+	// LicenseCollection.
+	licenses string
+	// ServiceEnabled shall indicate whether this service is enabled.
+	ServiceEnabled bool
+	// installTarget is the URL to send Install actions to.
+	installTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (licenseservice *LicenseService) GetRawData() []byte {
+	return licenseservice.rawData
+}
+
+// UnmarshalJSON unmarshals a LicenseService object from the raw JSON.
+func (licenseservice *LicenseService) UnmarshalJSON(b []byte) error {
+	type temp LicenseService
+	type Actions struct {
+		Install struct {
+			Target string
+		} `json:"#LicenseService.Install"`
+	}
+	var t struct {
+		temp
+		Licenses common.Link
+		Actions  Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*licenseservice = LicenseService(t.temp)
+
+	// Extract the links to other entities for later
+	licenseservice.licenses = string(t.Licenses)
+	licenseservice.installTarget = t.Actions.Install.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	licenseservice.rawData = b
+	licenseservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the license service to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (licenseservice LicenseService) MarshalJSON() ([]byte, error) {
+	type temp LicenseService
+	return common.MarshalWithRawData(licenseservice.rawData, temp(licenseservice))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (licenseservice *LicenseService) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(LicenseService)
+	original.UnmarshalJSON(licenseservice.rawData)
+
+	readWriteFields := []string{
+		"LicenseExpirationWarningDays",
+		"ServiceEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(licenseservice).Elem()
+
+	return licenseservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetLicenseService will get a LicenseService instance from the service.
+func GetLicenseService(c common.Client, uri string) (*LicenseService, error) {
+	var licenseservice LicenseService
+	err := common.FetchResource(c, uri, &licenseservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &licenseservice, nil
+}
+
+// Licenses gets the licenses installed on this service.
+func (licenseservice *LicenseService) Licenses() ([]*License, error) {
+	return ListReferencedLicenses(licenseservice.Client, licenseservice.licenses)
+}
+
+// Install shall install one or more licenses from a remote file. The service
+// may create one or more License resources as a result of installing the
+// license file.
+func (licenseservice *LicenseService) Install(parameters *LicenseInstallParameters) error {
+	if licenseservice.installTarget == "" {
+		return fmt.Errorf("Install is not supported by this license service")
+	}
+
+	type target struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		*LicenseInstallParameters
+		Targets []target `json:",omitempty"`
+	}
+	t := temp{
+		LicenseInstallParameters: parameters,
+	}
+	for _, uri := range parameters.Targets {
+		t.Targets = append(t.Targets, target{ODataID: uri})
+	}
+
+	_, err := licenseservice.Client.Post(licenseservice.installTarget, t)
+	return err
+}
+
+// AddLicense installs a license by posting its content, as a Base64-encoded
+// string, to the licenses collection.
+func (licenseservice *LicenseService) AddLicense(licenseString string) error {
+	if licenseservice.licenses == "" {
+		return fmt.Errorf("this license service does not have a licenses collection")
+	}
+
+	type temp struct {
+		LicenseString string
+	}
+	t := temp{
+		LicenseString: licenseString,
+	}
+
+	_, err := licenseservice.Client.Post(licenseservice.licenses, t)
+	return err
+}
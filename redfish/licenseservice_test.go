@@ -0,0 +1,131 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var licenseServiceBody = `{
+		"@odata.type": "#LicenseService.v1_0_0.LicenseService",
+		"@odata.id": "/redfish/v1/LicenseService",
+		"Id": "LicenseService",
+		"Name": "License Service",
+		"Description": "Actions and collection of Licenses",
+		"ServiceEnabled": true,
+		"LicenseExpirationWarningDays": 14,
+		"Licenses": {
+			"@odata.id": "/redfish/v1/LicenseService/Licenses"
+		},
+		"Actions": {
+			"#LicenseService.Install": {
+				"target": "/redfish/v1/LicenseService/Actions/LicenseService.Install",
+				"@Redfish.ActionInfo": "/redfish/v1/LicenseService/InstallActionInfo"
+			}
+		}
+	}`
+
+// TestLicenseService tests the parsing of LicenseService objects.
+func TestLicenseService(t *testing.T) {
+	var result LicenseService
+	err := json.NewDecoder(strings.NewReader(licenseServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "LicenseService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.LicenseExpirationWarningDays != 14 {
+		t.Errorf("Invalid expiration warning days: %d", result.LicenseExpirationWarningDays)
+	}
+
+	if result.licenses != "/redfish/v1/LicenseService/Licenses" {
+		t.Errorf("Invalid licenses link: %s", result.licenses)
+	}
+
+	if result.installTarget != "/redfish/v1/LicenseService/Actions/LicenseService.Install" {
+		t.Errorf("Invalid Install target: %s", result.installTarget)
+	}
+}
+
+// TestLicenseServiceUpdate tests the Update call.
+func TestLicenseServiceUpdate(t *testing.T) {
+	var result LicenseService
+	err := json.NewDecoder(strings.NewReader(licenseServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LicenseExpirationWarningDays = 30
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LicenseExpirationWarningDays:30") {
+		t.Errorf("Unexpected LicenseExpirationWarningDays update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestLicenseServiceInstall tests the Install and AddLicense calls.
+func TestLicenseServiceInstall(t *testing.T) {
+	var result LicenseService
+	err := json.NewDecoder(strings.NewReader(licenseServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Install(&LicenseInstallParameters{
+		LicenseFileURI:   "https://licenses.contoso.org/kvm.lic",
+		TransferProtocol: HTTPSTransferProtocolType,
+		Targets:          []string{"/redfish/v1/Managers/BMC"},
+	})
+
+	if err != nil {
+		t.Errorf("Error making Install call: %s", err)
+	}
+
+	err = result.AddLicense("T1RBMzUyMjY2Ng==")
+
+	if err != nil {
+		t.Errorf("Error making AddLicense call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].URL != result.installTarget {
+		t.Errorf("Unexpected Install target: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "/redfish/v1/Managers/BMC") {
+		t.Errorf("Unexpected Install payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].URL != result.licenses || !strings.Contains(calls[1].Payload, "T1RBMzUyMjY2Ng==") {
+		t.Errorf("Unexpected AddLicense call: %v", calls[1])
+	}
+}
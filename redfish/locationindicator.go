@@ -0,0 +1,103 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// setLocationIndicator turns the locating indicator of a resource on or off.
+// Resources implementing newer schema versions report LocationIndicatorActive,
+// older ones only the deprecated IndicatorLED, so the property the resource
+// reported in its raw payload is the one that is updated. It returns the
+// property that was written so the caller can keep its fields in sync.
+func setLocationIndicator(c common.Client, uri string, rawData []byte, active bool) (string, error) {
+	var t map[string]json.RawMessage
+	if err := json.Unmarshal(rawData, &t); err != nil {
+		return "", err
+	}
+
+	if _, ok := t["LocationIndicatorActive"]; ok {
+		type temp struct {
+			LocationIndicatorActive bool
+		}
+		_, err := c.Patch(uri, temp{LocationIndicatorActive: active})
+		return "LocationIndicatorActive", err
+	}
+
+	if _, ok := t["IndicatorLED"]; ok {
+		type temp struct {
+			IndicatorLED common.IndicatorLED
+		}
+		_, err := c.Patch(uri, temp{IndicatorLED: indicatorLEDState(active)})
+		return "IndicatorLED", err
+	}
+
+	return "", fmt.Errorf("resource %s does not report a location indicator", uri)
+}
+
+// indicatorLEDState gets the IndicatorLED value equivalent to a
+// LocationIndicatorActive value.
+func indicatorLEDState(active bool) common.IndicatorLED {
+	if active {
+		return common.BlinkingIndicatorLED
+	}
+
+	return common.OffIndicatorLED
+}
+
+// SetLocationIndicator turns the indicator used to physically locate this
+// chassis on or off, using LocationIndicatorActive or IndicatorLED depending
+// on which the service implements.
+func (chassis *Chassis) SetLocationIndicator(active bool) error {
+	property, err := setLocationIndicator(chassis.Client, chassis.ODataID, chassis.rawData, active)
+	if err != nil {
+		return err
+	}
+
+	if property == "IndicatorLED" {
+		chassis.IndicatorLED = indicatorLEDState(active)
+	} else {
+		chassis.LocationIndicatorActive = active
+	}
+	return nil
+}
+
+// SetLocationIndicator turns the indicator used to physically locate this
+// system on or off, using LocationIndicatorActive or IndicatorLED depending
+// on which the service implements.
+func (computersystem *ComputerSystem) SetLocationIndicator(active bool) error {
+	property, err := setLocationIndicator(computersystem.Client, computersystem.ODataID, computersystem.rawData, active)
+	if err != nil {
+		return err
+	}
+
+	if property == "IndicatorLED" {
+		computersystem.IndicatorLED = indicatorLEDState(active)
+	} else {
+		computersystem.LocationIndicatorActive = active
+	}
+	return nil
+}
+
+// SetLocationIndicator turns the indicator used to physically locate this
+// drive on or off, using LocationIndicatorActive or IndicatorLED depending
+// on which the service implements.
+func (drive *Drive) SetLocationIndicator(active bool) error {
+	property, err := setLocationIndicator(drive.Client, drive.ODataID, drive.rawData, active)
+	if err != nil {
+		return err
+	}
+
+	if property == "IndicatorLED" {
+		drive.IndicatorLED = indicatorLEDState(active)
+	} else {
+		drive.LocationIndicatorActive = active
+	}
+	return nil
+}
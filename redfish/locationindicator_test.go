@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TestChassisSetLocationIndicator tests that LocationIndicatorActive is used
+// when the service reports it.
+func TestChassisSetLocationIndicator(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Chassis/1",
+		"Id": "1",
+		"IndicatorLED": "Off",
+		"LocationIndicatorActive": false
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetLocationIndicator(true)
+	if err != nil {
+		t.Errorf("Error setting location indicator: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call, got: %d", len(calls))
+	}
+
+	if calls[0].Payload != "{true}" {
+		t.Errorf("Unexpected LocationIndicatorActive payload: %s", calls[0].Payload)
+	}
+
+	if !result.LocationIndicatorActive {
+		t.Error("LocationIndicatorActive should be updated")
+	}
+
+	if result.IndicatorLED != common.OffIndicatorLED {
+		t.Errorf("IndicatorLED should not be changed: %s", result.IndicatorLED)
+	}
+}
+
+// TestDriveSetLocationIndicator tests that the deprecated IndicatorLED is
+// used when it is the only indicator the service reports.
+func TestDriveSetLocationIndicator(t *testing.T) {
+	var result Drive
+	err := json.NewDecoder(strings.NewReader(driveBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetLocationIndicator(false)
+	if err != nil {
+		t.Errorf("Error setting location indicator: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{Off}" {
+		t.Errorf("Unexpected IndicatorLED payload: %s", calls[0].Payload)
+	}
+
+	if result.IndicatorLED != common.OffIndicatorLED {
+		t.Errorf("IndicatorLED should be updated: %s", result.IndicatorLED)
+	}
+}
+
+// TestSystemSetLocationIndicatorUnsupported tests that an error is returned
+// when the service reports no indicator.
+func TestSystemSetLocationIndicatorUnsupported(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1"
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	if err := result.SetLocationIndicator(true); err == nil {
+		t.Error("Expected an error for a system without a location indicator")
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -317,7 +316,7 @@ type LogEntry struct {
 	// ODataType is the odata type.
 	ODataType string `json:"@odata.type"`
 	// Created shall be the time at which the log entry was created.
-	Created string
+	Created common.DateTime
 	// Description provides a description of this resource.
 	Description string
 	// EntryCode shall be present if the EntryType value is
@@ -340,7 +339,7 @@ type LogEntry struct {
 	EventID string `json:"EventId"`
 	// EventTimestamp records an Event and the value shall be the time the event
 	// occurred.
-	EventTimestamp string
+	EventTimestamp common.DateTime
 	// GeneratorId if EntryType is `SEL`, this property shall contain the
 	// 'Generator ID' field of the IPMI SEL Event Record. If EntryType is
 	// not `SEL`, this property should not be present.
@@ -365,7 +364,7 @@ type LogEntry struct {
 	// Modified shall contain the date and time when the log
 	// entry was last modified. This property shall not appear if the log
 	// entry has not been modified since it was created.
-	Modified string
+	Modified common.DateTime
 	// OemLogEntryCode shall represent the OEM
 	// specific Log Entry Code type of the Entry. This property shall only
 	// be present if the value of EntryType is SEL and the value of
@@ -423,30 +422,27 @@ func (logentry *LogEntry) UnmarshalJSON(b []byte) error {
 	*logentry = LogEntry(t.temp)
 	logentry.originOfCondition = string(t.Links.OriginOfCondition)
 
+	logentry.rawData = b
+	logentry.SetActions(common.ParseActions(b))
+
 	return nil
 }
 
+// MarshalJSON marshals the log entry to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (logentry LogEntry) MarshalJSON() ([]byte, error) {
+	type temp LogEntry
+	return common.MarshalWithRawData(logentry.rawData, temp(logentry))
+}
+
 // GetLogEntry will get a LogEntry instance from the service.
 func GetLogEntry(c common.Client, uri string) (*LogEntry, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var logEntry LogEntry
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &logEntry)
+	err := common.FetchResource(c, uri, &logEntry)
 	if err != nil {
 		return nil, err
 	}
 
-	logEntry.rawData = rawData
-	logEntry.SetClient(c)
 	return &logEntry, nil
 }
 
@@ -464,7 +460,11 @@ func ListReferencedLogEntrys(c common.Client, link string) ([]*LogEntry, error)
 	}
 
 	for _, logentryLink := range links.ItemLinks {
-		logentry, err := GetLogEntry(c, logentryLink)
+		logentry := new(LogEntry)
+		expanded, err := links.DecodeMember(c, logentryLink, logentry)
+		if err == nil && !expanded {
+			logentry, err = GetLogEntry(c, logentryLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,141 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// LogExportFormat is the format LogService.Export writes entries in.
+type LogExportFormat string
+
+const (
+	// JSONLogExportFormat writes the entries as a JSON array.
+	JSONLogExportFormat LogExportFormat = "json"
+	// CSVLogExportFormat writes the entries as CSV, with a header row.
+	CSVLogExportFormat LogExportFormat = "csv"
+)
+
+// LogExportOptions controls LogService.Export.
+type LogExportOptions struct {
+	// Format is the format to write the entries in. It defaults to JSON.
+	Format LogExportFormat
+	// Registries are the message registries used to resolve the message of
+	// the entries that have a MessageId but no Message.
+	Registries []*MessageRegistry
+}
+
+// ExportedLogEntry is a log entry as written by LogService.Export.
+type ExportedLogEntry struct {
+	ID string `json:"Id"`
+	// Created is the time the entry was created, or the time of its event
+	// if the service does not tell, as RFC 3339 in UTC. It is empty if the
+	// service reports neither.
+	Created      string
+	EntryType    LogEntryType
+	Severity     EventSeverity
+	MessageID    string `json:"MessageId"`
+	Message      string
+	EntryCode    LogEntryCode `json:",omitempty"`
+	SensorType   SensorType   `json:",omitempty"`
+	SensorNumber int          `json:",omitempty"`
+}
+
+// exportedLogEntryColumns are the CSV columns of an exported log entry.
+var exportedLogEntryColumns = []string{
+	"Id", "Created", "EntryType", "Severity", "MessageId", "Message",
+	"EntryCode", "SensorType", "SensorNumber",
+}
+
+// Export writes all the entries of the log service, such as the SEL, for
+// support. The entries collection is read whole, following its pages. The
+// timestamps are normalized to RFC 3339 in UTC, and the message of the
+// entries that only have a MessageId is resolved with the registries.
+func (logservice *LogService) Export(w io.Writer, options LogExportOptions) error {
+	format := options.Format
+	if format == "" {
+		format = JSONLogExportFormat
+	}
+	if format != JSONLogExportFormat && format != CSVLogExportFormat {
+		return fmt.Errorf("log export format '%s' is not supported", format)
+	}
+
+	entries, err := logservice.Entries()
+	if err != nil {
+		return err
+	}
+
+	exported := make([]ExportedLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		exported = append(exported, exportLogEntry(entry, options.Registries))
+	}
+
+	if format == JSONLogExportFormat {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exported)
+	}
+
+	writer := csv.NewWriter(w)
+	err = writer.Write(exportedLogEntryColumns)
+	if err != nil {
+		return err
+	}
+	for _, entry := range exported {
+		sensorNumber := ""
+		if entry.SensorNumber != 0 {
+			sensorNumber = strconv.Itoa(entry.SensorNumber)
+		}
+		err = writer.Write([]string{
+			entry.ID, entry.Created, string(entry.EntryType), string(entry.Severity),
+			entry.MessageID, entry.Message, string(entry.EntryCode),
+			string(entry.SensorType), sensorNumber,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// exportLogEntry normalizes a log entry for export.
+func exportLogEntry(entry *LogEntry, registries []*MessageRegistry) ExportedLogEntry {
+	created := entry.Created.Time
+	if created.IsZero() {
+		created = entry.EventTimestamp.Time
+	}
+
+	exported := ExportedLogEntry{
+		ID:           entry.ID,
+		EntryType:    entry.EntryType,
+		Severity:     entry.Severity,
+		MessageID:    entry.MessageID,
+		Message:      entry.Message,
+		EntryCode:    entry.EntryCode,
+		SensorType:   entry.SensorType,
+		SensorNumber: entry.SensorNumber,
+	}
+	if !created.IsZero() {
+		exported.Created = created.UTC().Format(time.RFC3339)
+	}
+
+	if exported.Message == "" && exported.MessageID != "" {
+		for _, registry := range registries {
+			if message, ok := registry.ResolveMessage(entry.MessageID, entry.MessageArgs); ok {
+				exported.Message = message
+				break
+			}
+		}
+	}
+
+	return exported
+}
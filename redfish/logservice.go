@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -55,7 +54,7 @@ type LogService struct {
 	ODataType string `json:"@odata.type"`
 	// DateTime shall represent the current DateTime value that the log service
 	// is using, with offset from UTC, in Redfish Timestamp format.
-	DateTime string
+	DateTime common.DateTime
 	// DateTimeLocalOffset shall represent the offset from UTC time that the
 	// current value of DataTime property contains.
 	DateTimeLocalOffset string
@@ -122,10 +121,18 @@ func (logservice *LogService) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	logservice.rawData = b
+	logservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the log service to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (logservice LogService) MarshalJSON() ([]byte, error) {
+	type temp LogService
+	return common.MarshalWithRawData(logservice.rawData, temp(logservice))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (logservice *LogService) Update() error {
 
@@ -148,25 +155,12 @@ func (logservice *LogService) Update() error {
 
 // GetLogService will get a LogService instance from the service.
 func GetLogService(c common.Client, uri string) (*LogService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var logService LogService
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &logService)
+	err := common.FetchResource(c, uri, &logService)
 	if err != nil {
 		return nil, err
 	}
 
-	logService.rawData = rawData
-	logService.SetClient(c)
 	return &logService, nil
 }
 
@@ -183,7 +177,11 @@ func ListReferencedLogServices(c common.Client, link string) ([]*LogService, err
 	}
 
 	for _, logserviceLink := range links.ItemLinks {
-		logservice, err := GetLogService(c, logserviceLink)
+		logservice := new(LogService)
+		expanded, err := links.DecodeMember(c, logserviceLink, logservice)
+		if err == nil && !expanded {
+			logservice, err = GetLogService(c, logserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -105,3 +105,89 @@ func TestLogServiceUpdate(t *testing.T) {
 		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestLogServiceExport tests exporting the entries of a log service.
+func TestLogServiceExport(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/LogEntryCollection": `{
+			"@odata.id": "/redfish/v1/LogEntryCollection",
+			"Members@odata.count": 2,
+			"Members": [{
+				"@odata.id": "/redfish/v1/LogEntryCollection/1",
+				"Id": "1",
+				"Created": "2021-06-01T10:00:00+02:00",
+				"EntryType": "SEL",
+				"Severity": "Warning",
+				"Message": "Fan 1, \"speed\" low",
+				"SensorType": "Fan",
+				"SensorNumber": 12
+			}],
+			"Members@odata.nextLink": "/redfish/v1/LogEntryCollection?$skip=1"
+		}`,
+		"/redfish/v1/LogEntryCollection?$skip=1": `{
+			"@odata.id": "/redfish/v1/LogEntryCollection",
+			"Members@odata.count": 2,
+			"Members": [{
+				"@odata.id": "/redfish/v1/LogEntryCollection/2",
+				"Id": "2",
+				"EventTimestamp": "2021-06-01 09:30:00",
+				"EntryType": "Event",
+				"Severity": "OK",
+				"MessageId": "Base.1.8.PropertyValueModified",
+				"MessageArgs": ["AssetTag", "Rack4"]
+			}]
+		}`,
+	}}
+	result.SetClient(testClient)
+
+	var registry MessageRegistry
+	err = json.NewDecoder(strings.NewReader(messageRegistryBody)).Decode(&registry)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	var output strings.Builder
+	err = result.Export(&output, LogExportOptions{
+		Format:     CSVLogExportFormat,
+		Registries: []*MessageRegistry{&registry},
+	})
+	if err != nil {
+		t.Fatalf("Error exporting log: %s", err)
+	}
+
+	expected := `Id,Created,EntryType,Severity,MessageId,Message,EntryCode,SensorType,SensorNumber
+1,2021-06-01T08:00:00Z,SEL,Warning,,"Fan 1, ""speed"" low",,Fan,12
+2,2021-06-01T09:30:00Z,Event,OK,Base.1.8.PropertyValueModified,The property AssetTag was assigned the value Rack4.,,,
+`
+	if output.String() != expected {
+		t.Errorf("Unexpected CSV export:\n%s", output.String())
+	}
+
+	output.Reset()
+	err = result.Export(&output, LogExportOptions{})
+	if err != nil {
+		t.Fatalf("Error exporting log: %s", err)
+	}
+
+	var exported []ExportedLogEntry
+	err = json.Unmarshal([]byte(output.String()), &exported)
+	if err != nil {
+		t.Fatalf("Error decoding JSON export: %s", err)
+	}
+
+	if len(exported) != 2 || exported[1].Created != "2021-06-01T09:30:00Z" || exported[1].Message != "" {
+		t.Errorf("Unexpected JSON export: %s", output.String())
+	}
+
+	err = result.Export(&output, LogExportOptions{Format: "xml"})
+	if err == nil {
+		t.Error("Exporting to an unsupported format should fail")
+	}
+}
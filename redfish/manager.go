@@ -7,7 +7,6 @@ package redfish
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -182,7 +181,7 @@ type Manager struct {
 	CommandShell CommandShell
 	// DateTime shall represent the current DateTime value for the manager, with
 	// offset from UTC, in Redfish Timestamp format.
-	DateTime string
+	DateTime common.DateTime
 	// DateTimeLocalOffset is The value is property shall represent the offset
 	// from UTC time that the current value of DataTime property contains.
 	DateTimeLocalOffset string
@@ -216,6 +215,9 @@ type Manager struct {
 	// Model shall contain the information about how the manufacturer references
 	// this manager.
 	Model string
+	// Oem contains the OEM extensions of the manager, decoded by the
+	// decoders registered with common.RegisterOEMDecoder.
+	Oem common.OEM
 	// networkProtocol shall contain a reference to a resource of type
 	// ManagerNetworkProtocol which represents the network services for this
 	// manager.
@@ -240,6 +242,10 @@ type Manager struct {
 	// resource. This property shall only be present when providing
 	// aggregation of Redfish services.
 	RemoteRedfishServiceURI string `json:"RemoteRedfishServiceUri"`
+	// securityPolicy shall contain a link to a resource of type
+	// SecurityPolicy that contains the security policy settings for this
+	// manager.
+	securityPolicy string
 	// SerialConsole shall contain information about the Serial Console service
 	// of this manager.
 	SerialConsole SerialConsole
@@ -258,6 +264,9 @@ type Manager struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// TimeZoneName shall contain the time zone of the manager, as a name
+	// from the IANA time zone database, such as "Europe/Paris".
+	TimeZoneName string
 	// UUID shall contain the universal unique
 	// identifier number for the manager.
 	UUID string
@@ -281,7 +290,7 @@ type Manager struct {
 	ManagerForSwitchesCount int
 	// managerInChassis shall contain a reference to the chassis that this
 	// manager is located in.
-	managerInChassis string
+	managerInChassis common.LazyLink[Chassis]
 	// resetTarget is the internal URL to send reset targets to.
 	resetTarget string
 	// SupportedResetTypes, if provided, is the reset types this system supports.
@@ -311,7 +320,7 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 		ManagerForServersCount  int `json:"ManagerForServers@odata.count"`
 		ManagerForSwitches      common.Links
 		ManagerForSwitchesCount int `json:"ManagerForSwitches@odata.count"`
-		ManagerInChassis        common.Link
+		ManagerInChassis        common.LazyLink[Chassis]
 	}
 	var t struct {
 		temp
@@ -319,6 +328,7 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 		LogServices          common.Link
 		NetworkProtocol      common.Link
 		RemoteAccountService common.Link
+		SecurityPolicy       common.Link
 		SerialInterfaces     common.Link
 		VirtualMedia         common.Link
 		Links                linkReference
@@ -336,6 +346,7 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 	manager.logServices = string(t.LogServices)
 	manager.networkProtocol = string(t.NetworkProtocol)
 	manager.remoteAccountService = string(t.RemoteAccountService)
+	manager.securityPolicy = string(t.SecurityPolicy)
 	manager.serialInterfaces = string(t.SerialInterfaces)
 	manager.virtualMedia = string(t.VirtualMedia)
 	manager.managerForServers = t.Links.ManagerForServers.ToStrings()
@@ -344,16 +355,24 @@ func (manager *Manager) UnmarshalJSON(b []byte) error {
 	manager.ManagerForChassisCount = t.Links.ManagerForChassisCount
 	manager.ManagerForSwitchesCount = t.Links.ManagerForSwitchesCount
 	manager.managerForSwitches = t.Links.ManagerForSwitches.ToStrings()
-	manager.managerInChassis = string(t.Links.ManagerInChassis)
+	manager.managerInChassis = t.Links.ManagerInChassis
 	manager.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
 	manager.resetTarget = t.Actions.Reset.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	manager.rawData = b
+	manager.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the manager to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (manager Manager) MarshalJSON() ([]byte, error) {
+	type temp Manager
+	return common.MarshalWithRawData(manager.rawData, temp(manager))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (manager *Manager) Update() error {
 
@@ -366,6 +385,7 @@ func (manager *Manager) Update() error {
 		"AutoDSTEnabled",
 		"DateTime",
 		"DateTimeLocalOffset",
+		"TimeZoneName",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
@@ -376,38 +396,33 @@ func (manager *Manager) Update() error {
 
 // GetManager will get a Manager instance from the Swordfish service.
 func GetManager(c common.Client, uri string) (*Manager, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var manager Manager
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &manager)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &manager)
-	if err != nil {
-		return nil, err
-	}
-
-	manager.rawData = rawData
-	manager.SetClient(c)
 	return &manager, nil
 }
 
 // ListReferencedManagers gets the collection of Managers
 func ListReferencedManagers(c common.Client, link string) ([]*Manager, error) {
 	var result []*Manager
+	if link == "" {
+		return result, nil
+	}
+
 	links, err := common.GetCollection(c, link)
 	if err != nil {
 		return result, err
 	}
 
 	for _, managerLink := range links.ItemLinks {
-		manager, err := GetManager(c, managerLink)
+		manager := new(Manager)
+		expanded, err := links.DecodeMember(c, managerLink, manager)
+		if err == nil && !expanded {
+			manager, err = GetManager(c, managerLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -417,7 +432,10 @@ func ListReferencedManagers(c common.Client, link string) ([]*Manager, error) {
 	return result, nil
 }
 
-// Reset shall perform a reset of the manager.
+// Reset shall perform a reset of the manager. The reset type must be one of
+// the SupportedResetTypes, otherwise an ErrorUnsupportedResetType is
+// returned. Managers that do not advertise their reset types are reset
+// without one, so resetType is not sent to them.
 func (manager *Manager) Reset(resetType ResetType) error {
 	if len(manager.SupportedResetTypes) == 0 {
 		// reset directly without reset type. HPE server has the behavior
@@ -431,18 +449,11 @@ func (manager *Manager) Reset(resetType ResetType) error {
 		_, err := manager.Client.Post(manager.resetTarget, t)
 		return err
 	}
-	// Make sure the requested reset type is supported by the manager.
-	valid := false
-	for _, allowed := range manager.SupportedResetTypes {
-		if resetType == allowed {
-			valid = true
-			break
-		}
-	}
-
-	if !valid {
-		return fmt.Errorf("reset type '%s' is not supported by this manager",
-			resetType)
+	// Make sure the requested reset type is supported by the manager. The list
+	// is not empty here, so ValidateResetType does not accept any type.
+	err := ValidateResetType(resetType, manager.SupportedResetTypes, "manager")
+	if err != nil {
+		return err
 	}
 
 	type temp struct {
@@ -452,7 +463,7 @@ func (manager *Manager) Reset(resetType ResetType) error {
 		ResetType: resetType,
 	}
 
-	_, err := manager.Client.Post(manager.resetTarget, t)
+	_, err = manager.Client.Post(manager.resetTarget, t)
 	return err
 }
 
@@ -470,3 +481,27 @@ func (manager *Manager) VirtualMedia() ([]*VirtualMedia, error) {
 func (manager *Manager) LogServices() ([]*LogService, error) {
 	return ListReferencedLogServices(manager.Client, manager.logServices)
 }
+
+// NetworkProtocol gets the network service settings of this manager.
+func (manager *Manager) NetworkProtocol() (*ManagerNetworkProtocol, error) {
+	if manager.networkProtocol == "" {
+		return nil, fmt.Errorf("manager %s does not report its network protocols", manager.ID)
+	}
+	return GetManagerNetworkProtocol(manager.Client, manager.networkProtocol)
+}
+
+// SecurityPolicy gets the security policy of this manager, such as the TLS
+// versions and cipher suites it accepts.
+func (manager *Manager) SecurityPolicy() (*SecurityPolicy, error) {
+	if manager.securityPolicy == "" {
+		return nil, fmt.Errorf("manager %s does not report its security policy", manager.ID)
+	}
+	return GetSecurityPolicy(manager.Client, manager.securityPolicy)
+}
+
+// ManagerInChassis gets the chassis this manager is located in, or nil if the
+// manager does not tell. It is got from the service on first call and kept,
+// as a manager does not move; get the manager again to get it again.
+func (manager *Manager) ManagerInChassis() (*Chassis, error) {
+	return manager.managerInChassis.Get(manager.Client)
+}
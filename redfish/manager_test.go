@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -32,6 +33,12 @@ var managerBody = `{
 			"State": "Enabled",
 			"Health": "OK"
 		},
+		"Oem": {
+			"Contoso": {
+				"@odata.type": "#ContosoManager.v1_0_0.ContosoManager",
+				"FanMode": "Quiet"
+			}
+		},
 		"GraphicalConsole": {
 			"ServiceEnabled": true,
 			"MaxConcurrentSessions": 2,
@@ -146,6 +153,80 @@ func TestManager(t *testing.T) {
 	if result.resetTarget != "/redfish/v1/Managers/BMC-1/Actions/Manager.Reset" {
 		t.Errorf("Invalid Reset target: %s", result.resetTarget)
 	}
+
+	if len(result.Oem.Vendors()) != 1 || result.Oem.Vendors()[0] != "Contoso" {
+		t.Errorf("Invalid OEM vendors: %v", result.Oem.Vendors())
+	}
+}
+
+// TestManagerOemDecoder tests decoding manager OEM data with a registered
+// decoder.
+func TestManagerOemDecoder(t *testing.T) {
+	type contosoManager struct {
+		FanMode string
+	}
+	common.RegisterOEMDecoder("Contoso", "ContosoManager.ContosoManager", func(data json.RawMessage) (interface{}, error) {
+		var result contosoManager
+		err := json.Unmarshal(data, &result)
+		return result, err
+	})
+	defer common.UnregisterOEMDecoder("Contoso", "ContosoManager.ContosoManager")
+
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	value, ok := result.Oem.Get("Contoso")
+	if !ok || value.(contosoManager).FanMode != "Quiet" {
+		t.Errorf("Invalid decoded OEM data: %v", value)
+	}
+}
+
+// TestManagerReset tests that Reset only sends the advertised reset types.
+func TestManagerReset(t *testing.T) {
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(PowerCycleResetType)
+	if _, ok := err.(ErrorUnsupportedResetType); !ok {
+		t.Errorf("Expected an unsupported reset type error, got: %v", err)
+	}
+
+	err = result.Reset(ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	// Without advertised reset types the manager is reset without one
+	result.SupportedResetTypes = nil
+	err = result.Reset(PowerCycleResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[0].Payload != "{ForceRestart}" {
+		t.Errorf("Unexpected Reset payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "{Manager.Reset}" {
+		t.Errorf("Unexpected Reset payload without reset types: %s", calls[1].Payload)
+	}
 }
 
 // TestManagerUpdate tests the Update call.
@@ -177,4 +258,72 @@ func TestManagerUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "DateTimeLocalOffset:+05:00") {
 		t.Errorf("Unexpected DateTimeLocalOffset update payload: %s", calls[0].Payload)
 	}
+
+	if strings.Contains(calls[0].Payload, "DateTime:") {
+		t.Errorf("The unchanged DateTime should not be sent: %s", calls[0].Payload)
+	}
+}
+
+// TestManagerUpdateDateTime tests updating the date-time of the manager.
+func TestManagerUpdateDateTime(t *testing.T) {
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.DateTime.Year() != 2015 || result.DateTime.UTC().Hour() != 22 {
+		t.Errorf("Invalid DateTime: %s", result.DateTime)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.DateTime = common.DateTime{Time: time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)}
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Payload != "map[DateTime:2021-06-01T12:00:00Z]" {
+		t.Errorf("Unexpected DateTime update payload: %v", calls)
+	}
+}
+
+// TestManagerLinkedResources tests getting the resources linked to a manager.
+func TestManagerLinkedResources(t *testing.T) {
+	var result Manager
+	err := json.NewDecoder(strings.NewReader(managerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/Chassis-1":              `{"@odata.id": "/redfish/v1/Chassis/Chassis-1", "Id": "Chassis-1"}`,
+		"/redfish/v1/Managers/BMC-1/NetworkProtocol": managerNetworkProtocolBody,
+	}}
+	result.SetClient(testClient)
+
+	for i := 0; i < 2; i++ {
+		chassis, err := result.ManagerInChassis()
+		if err != nil || chassis.ID != "Chassis-1" {
+			t.Errorf("Error getting the chassis of the manager: %v %v", chassis, err)
+		}
+
+		networkProtocol, err := result.NetworkProtocol()
+		if err != nil || networkProtocol.HostName != "web483-bmc" {
+			t.Errorf("Error getting the network protocol of the manager: %v %v", networkProtocol, err)
+		}
+	}
+
+	// The chassis is kept, the network protocol, which can be updated, is
+	// got on every call
+	if calls := testClient.CapturedCalls(); len(calls) != 3 {
+		t.Errorf("Only the chassis should be got once: %v", calls)
+	}
 }
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -72,7 +72,7 @@ type ManagerAccount struct {
 	// PasswordExpiration shall contain the date and time
 	// when this account password expires. If the value is `null`, the
 	// account password never expires.
-	PasswordExpiration string
+	PasswordExpiration common.DateTime
 	// RoleID shall contain the RoleId of the Role Resource
 	// configured for this account. The Service shall reject POST, PATCH, or
 	// PUT operations that provide a RoleId that does not exist by returning
@@ -85,6 +85,8 @@ type ManagerAccount struct {
 	UserName string
 	// role is a link the the user roles.
 	role string
+	// changePasswordTarget is the URL to send ChangePassword actions to.
+	changePasswordTarget string
 	// rawData holds the original serialized JSON
 	rawData []byte
 }
@@ -101,10 +103,16 @@ func (manageraccount *ManagerAccount) UnmarshalJSON(b []byte) error {
 	type AccountLinks struct {
 		Role common.Link
 	}
+	type Actions struct {
+		ChangePassword struct {
+			Target string
+		} `json:"#ManagerAccount.ChangePassword"`
+	}
 	var t struct {
 		temp
 		Links        AccountLinks
 		Certificates common.Link
+		Actions      Actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -117,13 +125,22 @@ func (manageraccount *ManagerAccount) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	manageraccount.role = string(t.Links.Role)
 	manageraccount.certificates = string(t.Certificates)
+	manageraccount.changePasswordTarget = t.Actions.ChangePassword.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	manageraccount.rawData = b
+	manageraccount.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the manager account to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (manageraccount ManagerAccount) MarshalJSON() ([]byte, error) {
+	type temp ManagerAccount
+	return common.MarshalWithRawData(manageraccount.rawData, temp(manageraccount))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (manageraccount *ManagerAccount) Update() error {
 
@@ -132,6 +149,10 @@ func (manageraccount *ManagerAccount) Update() error {
 	original := new(ManagerAccount)
 	original.UnmarshalJSON(manageraccount.rawData)
 
+	if manageraccount.Locked && !original.Locked {
+		return fmt.Errorf("account %s can only be unlocked, the service locks accounts", manageraccount.UserName)
+	}
+
 	readWriteFields := []string{
 		"AccountTypes",
 		"Enabled",
@@ -147,30 +168,74 @@ func (manageraccount *ManagerAccount) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(manageraccount).Elem()
 
-	return manageraccount.Entity.Update(originalElement, currentElement, readWriteFields)
+	// The SNMPv3 settings are a nested object, which the generic update
+	// skips, so its writable properties are added to the payload. The keys
+	// are null in responses, so they are only sent when set.
+	nested := make(map[string]interface{})
+	snmp := changedFields(
+		reflect.ValueOf(original.SNMP), reflect.ValueOf(manageraccount.SNMP),
+		"AuthenticationKey", "AuthenticationProtocol", "EncryptionKey", "EncryptionProtocol")
+	if len(snmp) > 0 {
+		nested["SNMP"] = snmp
+	}
+
+	return manageraccount.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
 }
 
-// GetManagerAccount will get a ManagerAccount instance from the service.
-func GetManagerAccount(c common.Client, uri string) (*ManagerAccount, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// Unlock clears the lock the account service set on the account after too
+// many failed login attempts, before its AccountLockoutDuration ends.
+func (manageraccount *ManagerAccount) Unlock() error {
+	if !manageraccount.Locked {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	var managerAccount ManagerAccount
-	rawData, err := ioutil.ReadAll(resp.Body)
+	type temp struct {
+		Locked bool
+	}
+	_, err := manageraccount.Client.Patch(manageraccount.ODataID, temp{Locked: false})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = json.Unmarshal(rawData, &managerAccount)
+	manageraccount.Locked = false
+	return nil
+}
+
+// ChangePassword changes the password of the account, as required before
+// first access on accounts with PasswordChangeRequired. The
+// sessionAccountPassword is the password of the account of the session making
+// the change, which services implementing the ChangePassword action check;
+// the Password is patched on the others.
+func (manageraccount *ManagerAccount) ChangePassword(newPassword, sessionAccountPassword string) error {
+	if manageraccount.changePasswordTarget != "" {
+		type temp struct {
+			NewPassword            string
+			SessionAccountPassword string
+		}
+		t := temp{
+			NewPassword:            newPassword,
+			SessionAccountPassword: sessionAccountPassword,
+		}
+
+		_, err := manageraccount.Client.Post(manageraccount.changePasswordTarget, t)
+		return err
+	}
+
+	type temp struct {
+		Password string
+	}
+	_, err := manageraccount.Client.Patch(manageraccount.ODataID, temp{Password: newPassword})
+	return err
+}
+
+// GetManagerAccount will get a ManagerAccount instance from the service.
+func GetManagerAccount(c common.Client, uri string) (*ManagerAccount, error) {
+	var managerAccount ManagerAccount
+	err := common.FetchResource(c, uri, &managerAccount)
 	if err != nil {
 		return nil, err
 	}
 
-	managerAccount.rawData = rawData
-	managerAccount.SetClient(c)
 	return &managerAccount, nil
 }
 
@@ -188,7 +253,11 @@ func ListReferencedManagerAccounts(c common.Client, link string) ([]*ManagerAcco
 	}
 
 	for _, manageraccountLink := range links.ItemLinks {
-		manageraccount, err := GetManagerAccount(c, manageraccountLink)
+		manageraccount := new(ManagerAccount)
+		expanded, err := links.DecodeMember(c, manageraccountLink, manageraccount)
+		if err == nil && !expanded {
+			manageraccount, err = GetManagerAccount(c, manageraccountLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -73,6 +73,9 @@ func TestManagerAccountUpdate(t *testing.T) {
 	result.Enabled = false
 	result.Locked = false
 	result.Password = "Test"
+	result.RoleID = "Operator"
+	result.SNMP.AuthenticationKey = "Passphrase:secret"
+	result.SNMP.AuthenticationProtocol = HMACSHA96SNMPAuthenticationProtocols
 	err = result.Update()
 
 	if err != nil {
@@ -92,4 +95,110 @@ func TestManagerAccountUpdate(t *testing.T) {
 	if !strings.Contains(calls[0].Payload, "Password:Test") {
 		t.Errorf("Unexpected Password update payload: %s", calls[0].Payload)
 	}
+
+	if !strings.Contains(calls[0].Payload, "RoleId:Operator") {
+		t.Errorf("RoleID should be sent with its JSON name: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "SNMP:map[AuthenticationKey:Passphrase:secret AuthenticationProtocol:HMAC_SHA96]") {
+		t.Errorf("Unexpected SNMP update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestManagerAccountChangePassword tests the ChangePassword call, with and
+// without the ChangePassword action.
+func TestManagerAccountChangePassword(t *testing.T) {
+	var result ManagerAccount
+	err := json.NewDecoder(strings.NewReader(managerAccountBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ChangePassword("n3wPassw0rd", "calvin")
+	if err != nil {
+		t.Errorf("Error making ChangePassword call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != "PATH" || calls[0].URL != "/redfish/v1/AccountService/Accounts/1" {
+		t.Errorf("The password should be patched without the action: %v", calls)
+	}
+	if !strings.Contains(calls[0].Payload, "n3wPassw0rd") {
+		t.Errorf("Unexpected ChangePassword payload: %s", calls[0].Payload)
+	}
+
+	err = json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/AccountService/Accounts/1",
+		"Id": "1",
+		"UserName": "Administrator",
+		"PasswordChangeRequired": true,
+		"Actions": {
+			"#ManagerAccount.ChangePassword": {
+				"target": "/redfish/v1/AccountService/Accounts/1/Actions/ManagerAccount.ChangePassword"
+			}
+		}
+	}`)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient.Reset()
+	result.SetClient(testClient)
+	err = result.ChangePassword("n3wPassw0rd", "calvin")
+	if err != nil {
+		t.Errorf("Error making ChangePassword call: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != "POST" ||
+		calls[0].URL != "/redfish/v1/AccountService/Accounts/1/Actions/ManagerAccount.ChangePassword" {
+		t.Errorf("The ChangePassword action should be used: %v", calls)
+	}
+	if !strings.Contains(calls[0].Payload, "n3wPassw0rd calvin") {
+		t.Errorf("Unexpected ChangePassword payload: %s", calls[0].Payload)
+	}
+}
+
+// TestManagerAccountUnlock tests the Unlock call.
+func TestManagerAccountUnlock(t *testing.T) {
+	var result ManagerAccount
+	err := json.NewDecoder(strings.NewReader(managerAccountBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Locked = true
+	err = result.Update()
+	if err == nil {
+		t.Errorf("Locking an account should be refused")
+	}
+
+	result.Locked = false
+	err = result.Unlock()
+	if err != nil || len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unlocking an unlocked account should do nothing: %v", err)
+	}
+
+	result.Locked = true
+	err = result.Unlock()
+	if err != nil {
+		t.Errorf("Error making Unlock call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].Action != "PATH" || calls[0].URL != "/redfish/v1/AccountService/Accounts/1" {
+		t.Errorf("Unexpected Unlock calls: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "false") || result.Locked {
+		t.Errorf("Unexpected Unlock payload: %s", calls[0].Payload)
+	}
 }
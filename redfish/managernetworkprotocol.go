@@ -0,0 +1,341 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SNMPAuthenticationMode is how the SNMP service authenticates requests.
+type SNMPAuthenticationMode string
+
+const (
+	// AccountSNMPAuthenticationMode shall indicate authentication uses the
+	// SNMP settings of the manager accounts, for SNMPv3.
+	AccountSNMPAuthenticationMode SNMPAuthenticationMode = "Account"
+	// CommunityStringSNMPAuthenticationMode shall indicate authentication
+	// uses SNMP community strings, for SNMPv1 and SNMPv2c.
+	CommunityStringSNMPAuthenticationMode SNMPAuthenticationMode = "CommunityString"
+	// AllSNMPAuthenticationMode shall indicate authentication uses either
+	// the manager accounts or the community strings.
+	AllSNMPAuthenticationMode SNMPAuthenticationMode = "All"
+)
+
+// SNMPCommunityAccessMode is the access an SNMP community string grants.
+type SNMPCommunityAccessMode string
+
+const (
+	// FullSNMPCommunityAccessMode shall indicate the community string grants
+	// READ-WRITE access.
+	FullSNMPCommunityAccessMode SNMPCommunityAccessMode = "Full"
+	// LimitedSNMPCommunityAccessMode shall indicate the community string
+	// grants READ-ONLY access.
+	LimitedSNMPCommunityAccessMode SNMPCommunityAccessMode = "Limited"
+)
+
+// ProtocolSettings shall describe the settings of a network protocol of a
+// manager.
+type ProtocolSettings struct {
+	// Port shall contain the port assigned to the protocol.
+	Port int
+	// ProtocolEnabled shall indicate whether the protocol is enabled.
+	ProtocolEnabled bool
+}
+
+// NTPProtocol shall describe the NTP settings of a manager.
+type NTPProtocol struct {
+	ProtocolSettings
+	// NTPServers shall contain all the NTP servers for which this manager is
+	// using to obtain time.
+	NTPServers []string
+}
+
+// SNMPCommunity shall describe an SNMP community string.
+type SNMPCommunity struct {
+	// AccessMode shall contain the access the community grants.
+	AccessMode SNMPCommunityAccessMode
+	// CommunityString shall contain the SNMP community string. The services
+	// that hide their community strings report it as null.
+	CommunityString string
+	// IPv4AddressRangeLower and IPv4AddressRangeUpper shall contain the
+	// range of the IPv4 addresses the community is restricted to, if
+	// RestrictCommunityToIPv4AddressRange is true.
+	IPv4AddressRangeLower string `json:",omitempty"`
+	IPv4AddressRangeUpper string `json:",omitempty"`
+	// Name shall contain the name of the community.
+	Name string
+	// RestrictCommunityToIPv4AddressRange shall indicate whether the
+	// community is restricted to an IPv4 address range.
+	RestrictCommunityToIPv4AddressRange bool
+}
+
+// SNMPEngineID shall describe the SNMPv3 engine identifier.
+type SNMPEngineID struct {
+	// ArchitectureID shall contain the architecture identifier of the engine.
+	ArchitectureID string `json:"ArchitectureId"`
+	// EnterpriseSpecificMethod shall contain the enterprise specific method
+	// of the engine identifier.
+	EnterpriseSpecificMethod string
+	// PrivateEnterpriseID shall contain the private enterprise identifier of
+	// the engine.
+	PrivateEnterpriseID string `json:"PrivateEnterpriseId"`
+}
+
+// SNMPProtocol shall describe the SNMP settings of a manager. Traps are sent
+// to the SNMP subscriptions of the event service, see
+// EventService.CreateEventSubscription.
+type SNMPProtocol struct {
+	ProtocolSettings
+	// AuthenticationProtocol shall contain how the SNMP service authenticates
+	// requests.
+	AuthenticationProtocol SNMPAuthenticationMode
+	// CommunityAccessMode shall contain the access the community strings
+	// grant, on services with a single access mode for all of them.
+	CommunityAccessMode SNMPCommunityAccessMode
+	// CommunityStrings shall contain the SNMP community strings.
+	CommunityStrings []SNMPCommunity
+	// EnableSNMPv1 shall indicate whether SNMPv1 is enabled.
+	EnableSNMPv1 bool
+	// EnableSNMPv2c shall indicate whether SNMPv2c is enabled.
+	EnableSNMPv2c bool
+	// EnableSNMPv3 shall indicate whether SNMPv3 is enabled.
+	EnableSNMPv3 bool
+	// EncryptionProtocol shall contain the encryption protocol of SNMPv3,
+	// CBC_DES or CFB128_AES128, or Account to use the one of each account.
+	EncryptionProtocol SNMPEncryptionProtocols
+	// EngineID shall contain the SNMPv3 engine identifier.
+	EngineID SNMPEngineID `json:"EngineId"`
+	// HideCommunityStrings shall indicate whether the community strings are
+	// reported as null.
+	HideCommunityStrings bool
+	// TrapPort shall contain the port the SNMP traps are sent to.
+	TrapPort int
+}
+
+// HTTPSProtocol shall describe the HTTPS settings of a manager.
+type HTTPSProtocol struct {
+	ProtocolSettings
+	// certificates shall contain a link to a resource collection of type
+	// CertificateCollection.
+	certificates string
+}
+
+// UnmarshalJSON unmarshals a HTTPSProtocol object from the raw JSON.
+func (https *HTTPSProtocol) UnmarshalJSON(b []byte) error {
+	var t struct {
+		ProtocolSettings
+		Certificates common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	https.ProtocolSettings = t.ProtocolSettings
+	https.certificates = string(t.Certificates)
+
+	return nil
+}
+
+// Certificates gets the certificates the manager serves HTTPS with.
+func (https *HTTPSProtocol) Certificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, https.certificates)
+}
+
+// ManagerNetworkProtocol shall represent the network service settings for
+// the manager.
+type ManagerNetworkProtocol struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// DHCP shall contain the DHCP protocol settings for the manager.
+	DHCP ProtocolSettings
+	// DHCPv6 shall contain the DHCPv6 protocol settings for the manager.
+	DHCPv6 ProtocolSettings
+	// Description provides a description of this resource.
+	Description string
+	// FQDN shall contain the fully qualified domain name for the manager.
+	FQDN string
+	// HTTP shall contain the HTTP protocol settings for the manager.
+	HTTP ProtocolSettings
+	// HTTPS shall contain the HTTPS/SSL protocol settings for this manager.
+	HTTPS HTTPSProtocol
+	// HostName shall contain the host name without any domain information.
+	HostName string
+	// IPMI shall contain the IPMI over LAN protocol settings for the manager.
+	IPMI ProtocolSettings
+	// KVMIP shall contain the KVM-IP protocol settings for the manager.
+	KVMIP ProtocolSettings
+	// NTP shall contain the NTP protocol settings for the manager.
+	NTP NTPProtocol
+	// SNMP shall contain the SNMP settings for this manager.
+	SNMP SNMPProtocol
+	// SSDP shall contain the SSDP protocol settings for this manager.
+	SSDP ProtocolSettings
+	// SSH shall contain the SSH protocol settings for the manager.
+	SSH ProtocolSettings
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Telnet shall contain the Telnet protocol settings for this manager.
+	Telnet ProtocolSettings
+	// VirtualMedia shall contain the virtual media protocol settings for
+	// this manager.
+	VirtualMedia ProtocolSettings
+	// supportsNTP tells whether the service reports NTP settings.
+	supportsNTP bool
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (managernetworkprotocol *ManagerNetworkProtocol) GetRawData() []byte {
+	return managernetworkprotocol.rawData
+}
+
+// UnmarshalJSON unmarshals a ManagerNetworkProtocol object from the raw JSON.
+func (managernetworkprotocol *ManagerNetworkProtocol) UnmarshalJSON(b []byte) error {
+	type temp ManagerNetworkProtocol
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	var settings struct {
+		NTP json.RawMessage
+	}
+	err = json.Unmarshal(b, &settings)
+	if err != nil {
+		return err
+	}
+
+	*managernetworkprotocol = ManagerNetworkProtocol(t.temp)
+	managernetworkprotocol.supportsNTP = len(settings.NTP) > 0 && string(settings.NTP) != "null"
+
+	// This is a read/write object, so we need to save the raw object data for later
+	managernetworkprotocol.rawData = b
+	managernetworkprotocol.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the manager network protocol to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (managernetworkprotocol ManagerNetworkProtocol) MarshalJSON() ([]byte, error) {
+	type temp ManagerNetworkProtocol
+	return common.MarshalWithRawData(managernetworkprotocol.rawData, temp(managernetworkprotocol))
+}
+
+// Update commits updates to this object's properties to the running system.
+// The SNMP community strings are sent whole if one of them changes, so the
+// hidden ones have to be set again with them.
+func (managernetworkprotocol *ManagerNetworkProtocol) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(ManagerNetworkProtocol)
+	original.UnmarshalJSON(managernetworkprotocol.rawData)
+
+	readWriteFields := []string{
+		"HostName",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(managernetworkprotocol).Elem()
+
+	// The protocols are nested objects, which the generic update skips, so
+	// their writable properties are added to the payload.
+	nested := make(map[string]interface{})
+	protocols := map[string][2]ProtocolSettings{
+		"DHCP":         {original.DHCP, managernetworkprotocol.DHCP},
+		"DHCPv6":       {original.DHCPv6, managernetworkprotocol.DHCPv6},
+		"HTTP":         {original.HTTP, managernetworkprotocol.HTTP},
+		"HTTPS":        {original.HTTPS.ProtocolSettings, managernetworkprotocol.HTTPS.ProtocolSettings},
+		"IPMI":         {original.IPMI, managernetworkprotocol.IPMI},
+		"KVMIP":        {original.KVMIP, managernetworkprotocol.KVMIP},
+		"NTP":          {original.NTP.ProtocolSettings, managernetworkprotocol.NTP.ProtocolSettings},
+		"SNMP":         {original.SNMP.ProtocolSettings, managernetworkprotocol.SNMP.ProtocolSettings},
+		"SSDP":         {original.SSDP, managernetworkprotocol.SSDP},
+		"SSH":          {original.SSH, managernetworkprotocol.SSH},
+		"Telnet":       {original.Telnet, managernetworkprotocol.Telnet},
+		"VirtualMedia": {original.VirtualMedia, managernetworkprotocol.VirtualMedia},
+	}
+	for name, settings := range protocols {
+		if changes := changedFields(reflect.ValueOf(settings[0]), reflect.ValueOf(settings[1])); len(changes) > 0 {
+			nested[name] = changes
+		}
+	}
+
+	ntp := changedFields(
+		reflect.ValueOf(original.NTP), reflect.ValueOf(managernetworkprotocol.NTP),
+		"NTPServers")
+	snmp := changedFields(
+		reflect.ValueOf(original.SNMP), reflect.ValueOf(managernetworkprotocol.SNMP),
+		"AuthenticationProtocol", "CommunityAccessMode", "CommunityStrings",
+		"EnableSNMPv1", "EnableSNMPv2c", "EnableSNMPv3", "EncryptionProtocol",
+		"HideCommunityStrings", "TrapPort")
+	for name, changes := range map[string]map[string]interface{}{"NTP": ntp, "SNMP": snmp} {
+		if len(changes) == 0 {
+			continue
+		}
+		if protocol, ok := nested[name].(map[string]interface{}); ok {
+			for key, value := range changes {
+				protocol[key] = value
+			}
+			continue
+		}
+		nested[name] = changes
+	}
+
+	return managernetworkprotocol.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
+}
+
+// SetNTP enables or disables NTP and sets the NTP servers of the manager.
+// The servers are left unchanged if ntpServers is nil.
+func (managernetworkprotocol *ManagerNetworkProtocol) SetNTP(enabled bool, ntpServers []string) error {
+	ntp := map[string]interface{}{
+		"ProtocolEnabled": enabled,
+	}
+	if ntpServers != nil {
+		ntp["NTPServers"] = ntpServers
+	}
+
+	_, err := managernetworkprotocol.Client.Patch(managernetworkprotocol.ODataID, map[string]interface{}{
+		"NTP": ntp,
+	})
+	if err != nil {
+		return err
+	}
+
+	managernetworkprotocol.NTP.ProtocolEnabled = enabled
+	if ntpServers != nil {
+		managernetworkprotocol.NTP.NTPServers = ntpServers
+	}
+
+	return nil
+}
+
+// GetManagerNetworkProtocol will get a ManagerNetworkProtocol instance from
+// the service.
+func GetManagerNetworkProtocol(c common.Client, uri string) (*ManagerNetworkProtocol, error) {
+	var managernetworkprotocol ManagerNetworkProtocol
+	err := common.FetchResource(c, uri, &managernetworkprotocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &managernetworkprotocol, nil
+}
@@ -0,0 +1,201 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var managerNetworkProtocolBody = `{
+		"@odata.context": "/redfish/v1/$metadata#ManagerNetworkProtocol.ManagerNetworkProtocol",
+		"@odata.id": "/redfish/v1/Managers/BMC-1/NetworkProtocol",
+		"@odata.type": "#ManagerNetworkProtocol.v1_5_0.ManagerNetworkProtocol",
+		"Id": "NetworkProtocol",
+		"Name": "Manager Network Protocol",
+		"Description": "Manager Network Service Status",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"HostName": "web483-bmc",
+		"FQDN": "web483-bmc.dmtf.org",
+		"HTTP": {
+			"ProtocolEnabled": true,
+			"Port": 80
+		},
+		"HTTPS": {
+			"ProtocolEnabled": true,
+			"Port": 443,
+			"Certificates": {
+				"@odata.id": "/redfish/v1/Managers/BMC-1/NetworkProtocol/HTTPS/Certificates"
+			}
+		},
+		"IPMI": {
+			"ProtocolEnabled": true,
+			"Port": 623
+		},
+		"SSH": {
+			"ProtocolEnabled": true,
+			"Port": 22
+		},
+		"NTP": {
+			"ProtocolEnabled": true,
+			"Port": 123,
+			"NTPServers": [
+				"pool.ntp.org"
+			]
+		},
+		"SNMP": {
+			"ProtocolEnabled": true,
+			"Port": 161,
+			"AuthenticationProtocol": "CommunityString",
+			"CommunityStrings": [
+				{
+					"AccessMode": "Limited",
+					"CommunityString": null,
+					"Name": "public"
+				}
+			],
+			"EnableSNMPv1": false,
+			"EnableSNMPv2c": true,
+			"EnableSNMPv3": false,
+			"EngineId": {
+				"PrivateEnterpriseId": "80001F8880"
+			},
+			"HideCommunityStrings": true,
+			"TrapPort": 162
+		}
+	}`
+
+// TestManagerNetworkProtocol tests the parsing of ManagerNetworkProtocol objects.
+func TestManagerNetworkProtocol(t *testing.T) {
+	var result ManagerNetworkProtocol
+	err := json.NewDecoder(strings.NewReader(managerNetworkProtocolBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.HostName != "web483-bmc" {
+		t.Errorf("Received invalid host name: %s", result.HostName)
+	}
+
+	if result.HTTPS.Port != 443 || !result.HTTPS.ProtocolEnabled {
+		t.Errorf("Received invalid HTTPS settings: %v", result.HTTPS)
+	}
+
+	if result.HTTPS.certificates != "/redfish/v1/Managers/BMC-1/NetworkProtocol/HTTPS/Certificates" {
+		t.Errorf("Received invalid HTTPS certificates link: %s", result.HTTPS.certificates)
+	}
+
+	if result.IPMI.Port != 623 {
+		t.Errorf("Received invalid IPMI port: %d", result.IPMI.Port)
+	}
+
+	if !result.supportsNTP || len(result.NTP.NTPServers) != 1 {
+		t.Errorf("Received invalid NTP settings: %v", result.NTP)
+	}
+
+	if result.Telnet.ProtocolEnabled {
+		t.Error("Telnet should not be enabled")
+	}
+
+	if result.SNMP.Port != 161 || result.SNMP.TrapPort != 162 || !result.SNMP.EnableSNMPv2c {
+		t.Errorf("Received invalid SNMP settings: %v", result.SNMP)
+	}
+
+	if len(result.SNMP.CommunityStrings) != 1 || result.SNMP.CommunityStrings[0].AccessMode != LimitedSNMPCommunityAccessMode {
+		t.Errorf("Received invalid SNMP community strings: %v", result.SNMP.CommunityStrings)
+	}
+
+	if result.SNMP.EngineID.PrivateEnterpriseID != "80001F8880" {
+		t.Errorf("Received invalid SNMP engine ID: %v", result.SNMP.EngineID)
+	}
+}
+
+// TestManagerNetworkProtocolUpdate tests the Update call.
+func TestManagerNetworkProtocolUpdate(t *testing.T) {
+	var result ManagerNetworkProtocol
+	err := json.NewDecoder(strings.NewReader(managerNetworkProtocolBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.IPMI.ProtocolEnabled = false
+	result.SNMP.ProtocolEnabled = false
+	result.SNMP.EnableSNMPv3 = true
+	result.SNMP.AuthenticationProtocol = AccountSNMPAuthenticationMode
+	result.SNMP.TrapPort = 1162
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "IPMI:map[ProtocolEnabled:false]") {
+		t.Errorf("Unexpected IPMI update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "SNMP:map[AuthenticationProtocol:Account EnableSNMPv3:true ProtocolEnabled:false TrapPort:1162]") {
+		t.Errorf("Unexpected SNMP update payload: %s", calls[0].Payload)
+	}
+
+	if strings.Contains(calls[0].Payload, "HTTP") || strings.Contains(calls[0].Payload, "CommunityStrings") {
+		t.Errorf("Unexpected unchanged settings in update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestManagerNetworkProtocolSetNTP tests the SetNTP call.
+func TestManagerNetworkProtocolSetNTP(t *testing.T) {
+	var result ManagerNetworkProtocol
+	err := json.NewDecoder(strings.NewReader(managerNetworkProtocolBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetNTP(true, []string{"ntp1.example.com", "ntp2.example.com"})
+	if err != nil {
+		t.Errorf("Error making SetNTP call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "NTPServers:[ntp1.example.com ntp2.example.com]") {
+		t.Errorf("Unexpected NTP servers payload: %s", calls[0].Payload)
+	}
+
+	if len(result.NTP.NTPServers) != 2 {
+		t.Errorf("NTP servers should be updated: %v", result.NTP.NTPServers)
+	}
+
+	testClient.Reset()
+	err = result.SetNTP(false, nil)
+	if err != nil {
+		t.Errorf("Error making SetNTP call: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if strings.Contains(calls[0].Payload, "NTPServers") {
+		t.Errorf("NTP servers should be left as is: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,236 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// MediaControllerType is the type of a media controller.
+type MediaControllerType string
+
+const (
+	// MemoryMediaControllerType shall indicate the media controller is for
+	// memory.
+	MemoryMediaControllerType MediaControllerType = "Memory"
+)
+
+// MediaController shall be used to represent a media controller, such as the
+// controller of the memory media of a disaggregated memory appliance, in a
+// Redfish implementation.
+type MediaController struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the media controller.
+	Manufacturer string
+	// MediaControllerType shall contain the type of media controller.
+	MediaControllerType MediaControllerType
+	// Model shall contain the manufacturer-provided model information of
+	// this media controller.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// media controller.
+	PartNumber string
+	// SKU shall contain the SKU number for this media controller.
+	SKU string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the media controller.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UUID shall contain a universal unique identifier number for the media
+	// controller.
+	UUID string
+	// environmentMetrics is the link to the environment metrics of this media
+	// controller.
+	environmentMetrics string
+	// ports shall be a link to a resource collection of type PortCollection.
+	ports string
+	// endpoints are the endpoints associated with this media controller.
+	endpoints []string
+	// EndpointsCount is the number of endpoints.
+	EndpointsCount int
+	// memoryDomains are the memory domains associated with this media
+	// controller.
+	memoryDomains []string
+	// MemoryDomainsCount is the number of memory domains.
+	MemoryDomainsCount int
+	// resetTarget is the URL to send Reset actions to.
+	resetTarget string
+	// SupportedResetTypes, if provided, is the reset types this media
+	// controller supports.
+	SupportedResetTypes []ResetType
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (mediacontroller *MediaController) GetRawData() []byte {
+	return mediacontroller.rawData
+}
+
+// UnmarshalJSON unmarshals a MediaController object from the raw JSON.
+func (mediacontroller *MediaController) UnmarshalJSON(b []byte) error {
+	type temp MediaController
+	type Actions struct {
+		Reset struct {
+			AllowedResetTypes []ResetType `json:"ResetType@Redfish.AllowableValues"`
+			Target            string
+		} `json:"#MediaController.Reset"`
+	}
+	var t struct {
+		temp
+		EnvironmentMetrics common.Link
+		Ports              common.Link
+		Links              struct {
+			Endpoints          common.Links
+			EndpointsCount     int `json:"Endpoints@odata.count"`
+			MemoryDomains      common.Links
+			MemoryDomainsCount int `json:"MemoryDomains@odata.count"`
+		}
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*mediacontroller = MediaController(t.temp)
+
+	// Extract the links to other entities for later
+	mediacontroller.environmentMetrics = string(t.EnvironmentMetrics)
+	mediacontroller.ports = string(t.Ports)
+	mediacontroller.endpoints = t.Links.Endpoints.ToStrings()
+	mediacontroller.EndpointsCount = t.Links.EndpointsCount
+	mediacontroller.memoryDomains = t.Links.MemoryDomains.ToStrings()
+	mediacontroller.MemoryDomainsCount = t.Links.MemoryDomainsCount
+	mediacontroller.resetTarget = t.Actions.Reset.Target
+	mediacontroller.SupportedResetTypes = t.Actions.Reset.AllowedResetTypes
+
+	mediacontroller.rawData = b
+	mediacontroller.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the media controller to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (mediacontroller MediaController) MarshalJSON() ([]byte, error) {
+	type temp MediaController
+	return common.MarshalWithRawData(mediacontroller.rawData, temp(mediacontroller))
+}
+
+// EnvironmentMetrics gets the environment metrics, such as the power
+// consumption, of the media controller.
+func (mediacontroller *MediaController) EnvironmentMetrics() (*EnvironmentMetrics, error) {
+	if mediacontroller.environmentMetrics == "" {
+		return nil, nil
+	}
+
+	return GetEnvironmentMetrics(mediacontroller.Client, mediacontroller.environmentMetrics)
+}
+
+// Ports gets the ports of this media controller.
+func (mediacontroller *MediaController) Ports() ([]*Port, error) {
+	return ListReferencedPorts(mediacontroller.Client, mediacontroller.ports)
+}
+
+// Endpoints gets the endpoints associated with this media controller.
+func (mediacontroller *MediaController) Endpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+	for _, endpointLink := range mediacontroller.endpoints {
+		endpoint, err := GetEndpoint(mediacontroller.Client, endpointLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, endpoint)
+	}
+
+	return result, nil
+}
+
+// MemoryDomains gets the memory domains associated with this media
+// controller, that is the memory the controller attaches.
+func (mediacontroller *MediaController) MemoryDomains() ([]*MemoryDomain, error) {
+	var result []*MemoryDomain
+	for _, memorydomainLink := range mediacontroller.memoryDomains {
+		memorydomain, err := GetMemoryDomain(mediacontroller.Client, memorydomainLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, memorydomain)
+	}
+
+	return result, nil
+}
+
+// Reset shall reset the media controller.
+func (mediacontroller *MediaController) Reset(resetType ResetType) error {
+	err := ValidateResetType(resetType, mediacontroller.SupportedResetTypes, "media controller")
+	if err != nil {
+		return err
+	}
+
+	type temp struct {
+		ResetType ResetType
+	}
+	t := temp{
+		ResetType: resetType,
+	}
+
+	_, err = mediacontroller.Client.Post(mediacontroller.resetTarget, t)
+	return err
+}
+
+// GetMediaController will get a MediaController instance from the service.
+func GetMediaController(c common.Client, uri string) (*MediaController, error) {
+	var mediacontroller MediaController
+	err := common.FetchResource(c, uri, &mediacontroller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mediacontroller, nil
+}
+
+// ListReferencedMediaControllers gets the collection of MediaController from
+// a provided reference.
+func ListReferencedMediaControllers(c common.Client, link string) ([]*MediaController, error) {
+	var result []*MediaController
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, mediacontrollerLink := range links.ItemLinks {
+		mediacontroller := new(MediaController)
+		expanded, err := links.DecodeMember(c, mediacontrollerLink, mediacontroller)
+		if err == nil && !expanded {
+			mediacontroller, err = GetMediaController(c, mediacontrollerLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, mediacontroller)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,126 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var mediaControllerBody = `{
+		"@odata.type": "#MediaController.v1_3_0.MediaController",
+		"@odata.id": "/redfish/v1/Chassis/MemoryAppliance/MediaControllers/MediaController1",
+		"Id": "MediaController1",
+		"Name": "Media Controller 1",
+		"MediaControllerType": "Memory",
+		"Manufacturer": "Contoso",
+		"Model": "Contoso MediaController",
+		"SerialNumber": "2M220100SL",
+		"PartNumber": "23456-2",
+		"UUID": "41784113-ed6b-2284-1414-916520dc1dd1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Ports": {
+			"@odata.id": "/redfish/v1/Chassis/MemoryAppliance/MediaControllers/MediaController1/Ports"
+		},
+		"EnvironmentMetrics": {
+			"@odata.id": "/redfish/v1/Chassis/MemoryAppliance/MediaControllers/MediaController1/EnvironmentMetrics"
+		},
+		"Links": {
+			"Endpoints": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/GenZ/Endpoints/1"
+				}
+			],
+			"Endpoints@odata.count": 1,
+			"MemoryDomains": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/MemoryAppliance/MemoryDomains/1"
+				},
+				{
+					"@odata.id": "/redfish/v1/Chassis/MemoryAppliance/MemoryDomains/2"
+				}
+			],
+			"MemoryDomains@odata.count": 2
+		},
+		"Actions": {
+			"#MediaController.Reset": {
+				"target": "/redfish/v1/Chassis/MemoryAppliance/MediaControllers/MediaController1/Actions/MediaController.Reset",
+				"ResetType@Redfish.AllowableValues": ["ForceRestart", "GracefulRestart"]
+			}
+		}
+	}`
+
+// TestMediaController tests the parsing of MediaController objects.
+func TestMediaController(t *testing.T) {
+	var result MediaController
+	err := json.NewDecoder(strings.NewReader(mediaControllerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "MediaController1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MediaControllerType != MemoryMediaControllerType {
+		t.Errorf("Invalid media controller type: %s", result.MediaControllerType)
+	}
+
+	if result.ports != "/redfish/v1/Chassis/MemoryAppliance/MediaControllers/MediaController1/Ports" {
+		t.Errorf("Invalid ports link: %s", result.ports)
+	}
+
+	if len(result.endpoints) != 1 || result.EndpointsCount != 1 {
+		t.Errorf("Invalid endpoint links: %v", result.endpoints)
+	}
+
+	if len(result.memoryDomains) != 2 || result.MemoryDomainsCount != 2 {
+		t.Errorf("Invalid memory domain links: %v", result.memoryDomains)
+	}
+
+	if len(result.SupportedResetTypes) != 2 {
+		t.Errorf("Invalid reset types: %v", result.SupportedResetTypes)
+	}
+}
+
+// TestMediaControllerReset tests the Reset call.
+func TestMediaControllerReset(t *testing.T) {
+	var result MediaController
+	err := json.NewDecoder(strings.NewReader(mediaControllerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(ForceOnResetType)
+	if err == nil {
+		t.Errorf("Unsupported reset types should be refused")
+	}
+
+	err = result.Reset(ForceRestartResetType)
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || !strings.HasSuffix(calls[0].URL, "/Actions/MediaController.Reset") {
+		t.Errorf("Unexpected Reset calls: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "ForceRestart") {
+		t.Errorf("Unexpected Reset payload: %s", calls[0].Payload)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -352,6 +351,10 @@ func (memory *Memory) UnmarshalJSON(b []byte) error {
 		Links    links
 		Assembly common.Link
 		Metrics  common.Link
+
+		// Some implementations send these with the wrong JSON type
+		CapacityMiB       common.Int
+		OperatingSpeedMhz common.Int
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -360,6 +363,8 @@ func (memory *Memory) UnmarshalJSON(b []byte) error {
 	}
 
 	*memory = Memory(t.temp)
+	memory.CapacityMiB = int(t.CapacityMiB)
+	memory.OperatingSpeedMhz = int(t.OperatingSpeedMhz)
 
 	// Extract the links to other entities for later
 	memory.assembly = string(t.Assembly)
@@ -368,10 +373,18 @@ func (memory *Memory) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	memory.rawData = b
+	memory.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the memory to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (memory Memory) MarshalJSON() ([]byte, error) {
+	type temp Memory
+	return common.MarshalWithRawData(memory.rawData, temp(memory))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (memory *Memory) Update() error {
 
@@ -392,25 +405,12 @@ func (memory *Memory) Update() error {
 
 // GetMemory will get a Memory instance from the service.
 func GetMemory(c common.Client, uri string) (*Memory, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var memory Memory
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &memory)
+	err := common.FetchResource(c, uri, &memory)
 	if err != nil {
 		return nil, err
 	}
 
-	memory.rawData = rawData
-	memory.SetClient(c)
 	return &memory, nil
 }
 
@@ -428,7 +428,11 @@ func ListReferencedMemorys(c common.Client, link string) ([]*Memory, error) {
 	}
 
 	for _, memoryLink := range links.ItemLinks {
-		memory, err := GetMemory(c, memoryLink)
+		memory := new(Memory)
+		expanded, err := links.DecodeMember(c, memoryLink, memory)
+		if err == nil && !expanded {
+			memory, err = GetMemory(c, memoryLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -65,31 +64,27 @@ func (memorydomain *MemoryDomain) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*memorydomain = MemoryDomain(t.temp)
 	memorydomain.memoryChunks = string(t.MemoryChunks)
+	memorydomain.rawData = b
+	memorydomain.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the memory domain to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (memorydomain MemoryDomain) MarshalJSON() ([]byte, error) {
+	type temp MemoryDomain
+	return common.MarshalWithRawData(memorydomain.rawData, temp(memorydomain))
+}
+
 // GetMemoryDomain will get a MemoryDomain instance from the service.
 func GetMemoryDomain(c common.Client, uri string) (*MemoryDomain, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var memoryDomain MemoryDomain
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &memoryDomain)
+	err := common.FetchResource(c, uri, &memoryDomain)
 	if err != nil {
 		return nil, err
 	}
 
-	memoryDomain.rawData = rawData
-	memoryDomain.SetClient(c)
 	return &memoryDomain, nil
 }
 
@@ -107,7 +102,11 @@ func ListReferencedMemoryDomains(c common.Client, link string) ([]*MemoryDomain,
 	}
 
 	for _, memorydomainLink := range links.ItemLinks {
-		memorydomain, err := GetMemoryDomain(c, memorydomainLink)
+		memorydomain := new(MemoryDomain)
+		expanded, err := links.DecodeMember(c, memorydomainLink, memorydomain)
+		if err == nil && !expanded {
+			memorydomain, err = GetMemoryDomain(c, memorydomainLink)
+		}
 		if err != nil {
 			return result, err
 		}
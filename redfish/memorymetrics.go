@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -110,27 +109,40 @@ func (memorymetrics *MemoryMetrics) GetRawData() []byte {
 	return memorymetrics.rawData
 }
 
-// GetMemoryMetrics will get a MemoryMetrics instance from the service.
-func GetMemoryMetrics(c common.Client, uri string) (*MemoryMetrics, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// UnmarshalJSON unmarshals a MemoryMetrics object from the raw JSON.
+func (memorymetrics *MemoryMetrics) UnmarshalJSON(b []byte) error {
+	type temp MemoryMetrics
+	var t struct {
+		temp
 	}
-	defer resp.Body.Close()
 
-	var memoryMetrics MemoryMetrics
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = json.Unmarshal(rawData, &memoryMetrics)
+	*memorymetrics = MemoryMetrics(t.temp)
+	memorymetrics.rawData = b
+	memorymetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the memory metrics to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (memorymetrics MemoryMetrics) MarshalJSON() ([]byte, error) {
+	type temp MemoryMetrics
+	return common.MarshalWithRawData(memorymetrics.rawData, temp(memorymetrics))
+}
+
+// GetMemoryMetrics will get a MemoryMetrics instance from the service.
+func GetMemoryMetrics(c common.Client, uri string) (*MemoryMetrics, error) {
+	var memoryMetrics MemoryMetrics
+	err := common.FetchResource(c, uri, &memoryMetrics)
 	if err != nil {
 		return nil, err
 	}
 
-	memoryMetrics.rawData = rawData
-	memoryMetrics.SetClient(c)
 	return &memoryMetrics, nil
 }
 
@@ -148,7 +160,11 @@ func ListReferencedMemoryMetricss(c common.Client, link string) ([]*MemoryMetric
 	}
 
 	for _, memorymetricsLink := range links.ItemLinks {
-		memorymetrics, err := GetMemoryMetrics(c, memorymetricsLink)
+		memorymetrics := new(MemoryMetrics)
+		expanded, err := links.DecodeMember(c, memorymetricsLink, memorymetrics)
+		if err == nil && !expanded {
+			memorymetrics, err = GetMemoryMetrics(c, memorymetricsLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,141 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// MessageRegistryMessage shall describe a message of a message registry.
+type MessageRegistryMessage struct {
+	// Description shall indicate how and when the message is returned by the
+	// Redfish service.
+	Description string
+	// Message shall contain the message to display. If a %integer is
+	// included in part of the string, it shall represent a string
+	// substitution for any MessageArgs that accompany the message, in order.
+	Message string
+	// MessageSeverity shall contain the severity of the message.
+	MessageSeverity common.Health
+	// NumberOfArgs shall contain the number of arguments that are
+	// substituted in the locations marked by %<integer> in the message.
+	NumberOfArgs int
+	// ParamTypes shall contain an ordered array of argument data types that
+	// match the data types of the MessageArgs.
+	ParamTypes []string
+	// Resolution shall contain a description of how to resolve the problem
+	// that the message reports.
+	Resolution string
+	// Severity shall contain the severity of the condition resulting in the
+	// message, as defined in the Status clause of the Redfish Specification.
+	Severity string
+}
+
+// MessageRegistry shall be the definition of a set of messages a Redfish
+// service can return, such as in log entries and events.
+type MessageRegistry struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Language shall contain an RFC5646-conformant language code.
+	Language string
+	// Messages shall contain the messages of the registry, keyed by their
+	// message key.
+	Messages map[string]MessageRegistryMessage
+	// OwningEntity shall represent the publisher of this message registry.
+	OwningEntity string
+	// RegistryPrefix shall contain the Redfish Specification-defined prefix
+	// used in forming and decoding MessageIds that uniquely identifies all
+	// messages that belong to this message registry.
+	RegistryPrefix string
+	// RegistryVersion shall contain the version of this message registry.
+	RegistryVersion string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (messageregistry *MessageRegistry) GetRawData() []byte {
+	return messageregistry.rawData
+}
+
+// UnmarshalJSON unmarshals a MessageRegistry object from the raw JSON.
+func (messageregistry *MessageRegistry) UnmarshalJSON(b []byte) error {
+	type temp MessageRegistry
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*messageregistry = MessageRegistry(t.temp)
+	messageregistry.rawData = b
+	messageregistry.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the message registry to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (messageregistry MessageRegistry) MarshalJSON() ([]byte, error) {
+	type temp MessageRegistry
+	return common.MarshalWithRawData(messageregistry.rawData, temp(messageregistry))
+}
+
+// GetMessageRegistry will get a MessageRegistry instance from the service.
+func GetMessageRegistry(c common.Client, uri string) (*MessageRegistry, error) {
+	var messageregistry MessageRegistry
+	err := common.FetchResource(c, uri, &messageregistry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messageregistry, nil
+}
+
+// Message gets the registry message of a MessageId, such as
+// "Base.1.8.Success", or nil if the MessageId is not from this registry.
+func (messageregistry *MessageRegistry) Message(messageID string) *MessageRegistryMessage {
+	parts := strings.Split(messageID, ".")
+	if len(parts) < 2 || parts[0] != messageregistry.RegistryPrefix {
+		return nil
+	}
+
+	message, ok := messageregistry.Messages[parts[len(parts)-1]]
+	if !ok {
+		return nil
+	}
+
+	return &message
+}
+
+// ResolveMessage gets the text of a MessageId with its arguments substituted,
+// or false if the MessageId is not from this registry.
+func (messageregistry *MessageRegistry) ResolveMessage(messageID string, args []string) (string, bool) {
+	message := messageregistry.Message(messageID)
+	if message == nil {
+		return "", false
+	}
+
+	// Substitute the highest arguments first so that %1 does not match %10
+	text := message.Message
+	for i := len(args); i > 0; i-- {
+		text = strings.Replace(text, "%"+strconv.Itoa(i), args[i-1], -1)
+	}
+
+	return text, true
+}
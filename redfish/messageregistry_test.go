@@ -0,0 +1,89 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var messageRegistryBody = `{
+		"@odata.type": "#MessageRegistry.v1_4_1.MessageRegistry",
+		"Id": "Base.1.8.0",
+		"Name": "Base Message Registry",
+		"Language": "en",
+		"RegistryPrefix": "Base",
+		"RegistryVersion": "1.8.0",
+		"OwningEntity": "DMTF",
+		"Messages": {
+			"PropertyValueModified": {
+				"Description": "Indicates that a property was given the correct value type but the value of that property was not supported.",
+				"Message": "The property %1 was assigned the value %2.",
+				"MessageSeverity": "Warning",
+				"NumberOfArgs": 2,
+				"ParamTypes": ["string", "string"],
+				"Resolution": "No resolution is required."
+			},
+			"Success": {
+				"Message": "Successfully Completed Request",
+				"MessageSeverity": "OK",
+				"NumberOfArgs": 0,
+				"Resolution": "None"
+			}
+		}
+	}`
+
+// TestMessageRegistry tests the parsing of MessageRegistry objects.
+func TestMessageRegistry(t *testing.T) {
+	var result MessageRegistry
+	err := json.NewDecoder(strings.NewReader(messageRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Base.1.8.0" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.RegistryPrefix != "Base" {
+		t.Errorf("Invalid RegistryPrefix: %s", result.RegistryPrefix)
+	}
+
+	if len(result.Messages) != 2 {
+		t.Errorf("Expected 2 messages, got %d", len(result.Messages))
+	}
+
+	message := result.Message("Base.1.8.PropertyValueModified")
+	if message == nil || message.NumberOfArgs != 2 || message.Resolution != "No resolution is required." {
+		t.Errorf("Invalid PropertyValueModified message: %v", message)
+	}
+
+	if result.Message("Other.1.0.Success") != nil {
+		t.Error("Messages of other registries should not be found")
+	}
+}
+
+// TestMessageRegistryResolveMessage tests substituting the arguments of a
+// message.
+func TestMessageRegistryResolveMessage(t *testing.T) {
+	var result MessageRegistry
+	err := json.NewDecoder(strings.NewReader(messageRegistryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	text, ok := result.ResolveMessage("Base.1.8.PropertyValueModified", []string{"AssetTag", "Rack4"})
+	if !ok || text != "The property AssetTag was assigned the value Rack4." {
+		t.Errorf("Unexpected resolved message: %s", text)
+	}
+
+	_, ok = result.ResolveMessage("Base.1.8.Unknown", nil)
+	if ok {
+		t.Error("Unknown messages should not be resolved")
+	}
+}
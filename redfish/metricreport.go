@@ -0,0 +1,151 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// MetricValue shall contain properties that capture a metric value and other
+// associated information.
+type MetricValue struct {
+	// MetricDefinition shall be the URI of the MetricDefinition of the metric.
+	MetricDefinition string
+	// MetricID shall be the same as the Id property of the source metric
+	// within the associated MetricDefinition.
+	MetricID string `json:"MetricId"`
+	// MetricProperty shall be a URI fragment of the property that is the
+	// source of the metric value, such as
+	// /redfish/v1/Chassis/1/Sensors/Temp1#/Reading.
+	MetricProperty string
+	// MetricValue shall be the metric value, as a string.
+	MetricValue string
+	// Timestamp shall be the time when the metric value was obtained.
+	Timestamp string
+}
+
+// UnmarshalJSON unmarshals a MetricValue object from the raw JSON.
+func (metricvalue *MetricValue) UnmarshalJSON(b []byte) error {
+	type temp MetricValue
+	var t struct {
+		temp
+		MetricDefinition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricvalue = MetricValue(t.temp)
+	metricvalue.MetricDefinition = string(t.MetricDefinition)
+
+	return nil
+}
+
+// MetricReport shall contain a set of metric values, as generated according
+// to a MetricReportDefinition. Services send metric reports to the event
+// destinations subscribed with the MetricReport event format.
+type MetricReport struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Context shall contain a client supplied context for the event
+	// destination to which this metric report is sent.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// MetricReportDefinition shall be the URI of the MetricReportDefinition
+	// the report was generated from.
+	MetricReportDefinition string
+	// MetricValues shall be the metric values of the report.
+	MetricValues []MetricValue
+	// ReportSequence shall contain a sequence identifier of the report, which
+	// the service increments for each report of a definition.
+	ReportSequence string
+	// Timestamp shall be the time when the metric report was generated.
+	Timestamp string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (metricreport *MetricReport) GetRawData() []byte {
+	return metricreport.rawData
+}
+
+// UnmarshalJSON unmarshals a MetricReport object from the raw JSON.
+func (metricreport *MetricReport) UnmarshalJSON(b []byte) error {
+	type temp MetricReport
+	var t struct {
+		temp
+		MetricReportDefinition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricreport = MetricReport(t.temp)
+	metricreport.MetricReportDefinition = string(t.MetricReportDefinition)
+	metricreport.rawData = b
+	metricreport.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the metric report to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (metricreport MetricReport) MarshalJSON() ([]byte, error) {
+	type temp MetricReport
+	return common.MarshalWithRawData(metricreport.rawData, temp(metricreport))
+}
+
+// GetMetricReport will get a MetricReport instance from the service.
+func GetMetricReport(c common.Client, uri string) (*MetricReport, error) {
+	var metricreport MetricReport
+	err := common.FetchResource(c, uri, &metricreport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricreport, nil
+}
+
+// ListReferencedMetricReports gets the collection of MetricReport from
+// a provided reference.
+func ListReferencedMetricReports(c common.Client, link string) ([]*MetricReport, error) {
+	var result []*MetricReport
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, metricreportLink := range links.ItemLinks {
+		metricreport := new(MetricReport)
+		expanded, err := links.DecodeMember(c, metricreportLink, metricreport)
+		if err == nil && !expanded {
+			metricreport, err = GetMetricReport(c, metricreportLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, metricreport)
+	}
+
+	return result, nil
+}
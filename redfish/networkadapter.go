@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -243,30 +242,27 @@ func (networkadapter *NetworkAdapter) UnmarshalJSON(b []byte) error {
 	networkadapter.networkPorts = string(t.NetworkPorts)
 	networkadapter.resetSettingsToDefaultTarget = t.Actions.ResetSettingsToDefault.Target
 
+	networkadapter.rawData = b
+	networkadapter.SetActions(common.ParseActions(b))
+
 	return nil
 }
 
+// MarshalJSON marshals the network adapter to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (networkadapter NetworkAdapter) MarshalJSON() ([]byte, error) {
+	type temp NetworkAdapter
+	return common.MarshalWithRawData(networkadapter.rawData, temp(networkadapter))
+}
+
 // GetNetworkAdapter will get a NetworkAdapter instance from the Redfish service.
 func GetNetworkAdapter(c common.Client, uri string) (*NetworkAdapter, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var networkAdapter NetworkAdapter
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &networkAdapter)
+	err := common.FetchResource(c, uri, &networkAdapter)
 	if err != nil {
 		return nil, err
 	}
 
-	networkAdapter.rawData = rawData
-	networkAdapter.SetClient(c)
 	return &networkAdapter, nil
 }
 
@@ -279,7 +275,11 @@ func ListReferencedNetworkAdapter(c common.Client, link string) ([]*NetworkAdapt
 	}
 
 	for _, networkAdapterLink := range links.ItemLinks {
-		networkAdapter, err := GetNetworkAdapter(c, networkAdapterLink)
+		networkAdapter := new(NetworkAdapter)
+		expanded, err := links.DecodeMember(c, networkAdapterLink, networkAdapter)
+		if err == nil && !expanded {
+			networkAdapter, err = GetNetworkAdapter(c, networkAdapterLink)
+		}
 		if err != nil {
 			return result, err
 		}
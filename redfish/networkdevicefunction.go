@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -330,10 +329,18 @@ func (networkdevicefunction *NetworkDeviceFunction) UnmarshalJSON(b []byte) erro
 
 	// This is a read/write object, so we need to save the raw object data for later
 	networkdevicefunction.rawData = b
+	networkdevicefunction.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the network device function to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (networkdevicefunction NetworkDeviceFunction) MarshalJSON() ([]byte, error) {
+	type temp NetworkDeviceFunction
+	return common.MarshalWithRawData(networkdevicefunction.rawData, temp(networkdevicefunction))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (networkdevicefunction *NetworkDeviceFunction) Update() error {
 
@@ -356,25 +363,12 @@ func (networkdevicefunction *NetworkDeviceFunction) Update() error {
 
 // GetNetworkDeviceFunction will get a NetworkDeviceFunction instance from the service.
 func GetNetworkDeviceFunction(c common.Client, uri string) (*NetworkDeviceFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var networkDeviceFunction NetworkDeviceFunction
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &networkDeviceFunction)
+	err := common.FetchResource(c, uri, &networkDeviceFunction)
 	if err != nil {
 		return nil, err
 	}
 
-	networkDeviceFunction.rawData = rawData
-	networkDeviceFunction.SetClient(c)
 	return &networkDeviceFunction, nil
 }
 
@@ -392,7 +386,11 @@ func ListReferencedNetworkDeviceFunctions(c common.Client, link string) ([]*Netw
 	}
 
 	for _, networkdevicefunctionLink := range links.ItemLinks {
-		networkdevicefunction, err := GetNetworkDeviceFunction(c, networkdevicefunctionLink)
+		networkdevicefunction := new(NetworkDeviceFunction)
+		expanded, err := links.DecodeMember(c, networkdevicefunctionLink, networkdevicefunction)
+		if err == nil && !expanded {
+			networkdevicefunction, err = GetNetworkDeviceFunction(c, networkdevicefunctionLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -42,6 +41,11 @@ type NetworkInterface struct {
 	networkDeviceFunctions string
 	// NetworkPorts shall be a link to a collection of type NetworkPortCollection.
 	networkPorts string
+	// Redundancy is used to show how this network interface is grouped with
+	// other network interfaces to form redundancy sets.
+	Redundancy []Redundancy
+	// RedundancyCount is the number of Redundancy objects.
+	RedundancyCount int `json:"Redundancy@odata.count"`
 	// Status shall contain any status or health properties of the resource.
 	Status common.Status
 	// rawData holds the original serialized JSON
@@ -73,31 +77,27 @@ func (networkinterface *NetworkInterface) UnmarshalJSON(b []byte) error {
 	networkinterface.networkAdapter = string(t.Links.NetworkAdapter)
 	networkinterface.networkDeviceFunctions = string(t.NetworkDeviceFunctions)
 	networkinterface.networkPorts = string(t.NetworkPorts)
+	networkinterface.rawData = b
+	networkinterface.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the network interface to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (networkinterface NetworkInterface) MarshalJSON() ([]byte, error) {
+	type temp NetworkInterface
+	return common.MarshalWithRawData(networkinterface.rawData, temp(networkinterface))
+}
+
 // GetNetworkInterface will get a NetworkInterface instance from the service.
 func GetNetworkInterface(c common.Client, uri string) (*NetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var networkInterface NetworkInterface
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &networkInterface)
+	err := common.FetchResource(c, uri, &networkInterface)
 	if err != nil {
 		return nil, err
 	}
 
-	networkInterface.rawData = rawData
-	networkInterface.SetClient(c)
 	return &networkInterface, nil
 }
 
@@ -115,7 +115,11 @@ func ListReferencedNetworkInterfaces(c common.Client, link string) ([]*NetworkIn
 	}
 
 	for _, networkinterfaceLink := range links.ItemLinks {
-		networkinterface, err := GetNetworkInterface(c, networkinterfaceLink)
+		networkinterface := new(NetworkInterface)
+		expanded, err := links.DecodeMember(c, networkinterfaceLink, networkinterface)
+		if err == nil && !expanded {
+			networkinterface, err = GetNetworkInterface(c, networkinterfaceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -227,10 +226,18 @@ func (networkport *NetworkPort) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	networkport.rawData = b
+	networkport.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the network port to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (networkport NetworkPort) MarshalJSON() ([]byte, error) {
+	type temp NetworkPort
+	return common.MarshalWithRawData(networkport.rawData, temp(networkport))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (networkport *NetworkPort) Update() error {
 
@@ -255,25 +262,12 @@ func (networkport *NetworkPort) Update() error {
 
 // GetNetworkPort will get a NetworkPort instance from the service.
 func GetNetworkPort(c common.Client, uri string) (*NetworkPort, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var networkPort NetworkPort
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &networkPort)
+	err := common.FetchResource(c, uri, &networkPort)
 	if err != nil {
 		return nil, err
 	}
 
-	networkPort.rawData = rawData
-	networkPort.SetClient(c)
 	return &networkPort, nil
 }
 
@@ -291,7 +285,11 @@ func ListReferencedNetworkPorts(c common.Client, link string) ([]*NetworkPort, e
 	}
 
 	for _, networkportLink := range links.ItemLinks {
-		networkport, err := GetNetworkPort(c, networkportLink)
+		networkport := new(NetworkPort)
+		expanded, err := links.DecodeMember(c, networkportLink, networkport)
+		if err == nil && !expanded {
+			networkport, err = GetNetworkPort(c, networkportLink)
+		}
 		if err != nil {
 			return result, err
 		}
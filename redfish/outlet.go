@@ -0,0 +1,345 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ReceptacleType is the type of receptacle of an outlet.
+type ReceptacleType string
+
+const (
+	// NEMA515RReceptacleType NEMA 5-15R (120V; 15A).
+	NEMA515RReceptacleType ReceptacleType = "NEMA_5_15R"
+	// NEMA520RReceptacleType NEMA 5-20R (120V; 20A).
+	NEMA520RReceptacleType ReceptacleType = "NEMA_5_20R"
+	// NEMAL520RReceptacleType NEMA L5-20R (120V; 20A).
+	NEMAL520RReceptacleType ReceptacleType = "NEMA_L5_20R"
+	// NEMAL530RReceptacleType NEMA L5-30R (120V; 30A).
+	NEMAL530RReceptacleType ReceptacleType = "NEMA_L5_30R"
+	// NEMAL620RReceptacleType NEMA L6-20R (250V; 20A).
+	NEMAL620RReceptacleType ReceptacleType = "NEMA_L6_20R"
+	// NEMAL630RReceptacleType NEMA L6-30R (250V; 30A).
+	NEMAL630RReceptacleType ReceptacleType = "NEMA_L6_30R"
+	// IEC60320C13ReceptacleType IEC C13 (250V; 10A or 15A).
+	IEC60320C13ReceptacleType ReceptacleType = "IEC_60320_C13"
+	// IEC60320C19ReceptacleType IEC C19 (250V; 16A or 20A).
+	IEC60320C19ReceptacleType ReceptacleType = "IEC_60320_C19"
+	// CEE7TypeEReceptacleType French and Belgian Type E (250V; 16A).
+	CEE7TypeEReceptacleType ReceptacleType = "CEE_7_Type_E"
+	// CEE7TypeFReceptacleType Schuko Type F (250V; 16A).
+	CEE7TypeFReceptacleType ReceptacleType = "CEE_7_Type_F"
+	// SEV1011TYPE12ReceptacleType SEV 1011 Type 12 (250V; 10A).
+	SEV1011TYPE12ReceptacleType ReceptacleType = "SEV_1011_TYPE_12"
+	// SEV1011TYPE23ReceptacleType SEV 1011 Type 23 (250V; 16A).
+	SEV1011TYPE23ReceptacleType ReceptacleType = "SEV_1011_TYPE_23"
+	// BS1363TypeGReceptacleType BS 1363 Type G (250V; 13A).
+	BS1363TypeGReceptacleType ReceptacleType = "BS_1363_Type_G"
+)
+
+// Outlet shall be used to represent an electrical outlet for a Redfish
+// implementation.
+type Outlet struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CurrentAmps shall contain the current, in ampere units, for this
+	// single phase outlet.
+	CurrentAmps SensorCurrentExcerpt
+	// Description provides a description of this resource.
+	Description string
+	// ElectricalConsumerNames shall contain an array of user-assigned
+	// identifying strings that describe the functions or devices that this
+	// outlet supplies power to.
+	ElectricalConsumerNames []string
+	// ElectricalContext shall contain the combination of current-carrying
+	// conductors that distribute power.
+	ElectricalContext ElectricalContext
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this outlet.
+	EnergykWh SensorEnergykWhExcerpt
+	// FrequencyHz shall contain the frequency, in hertz units, for this
+	// outlet.
+	FrequencyHz SensorExcerpt
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// NominalVoltage shall contain the nominal voltage for this outlet.
+	NominalVoltage NominalVoltageType
+	// OutletType shall contain the type of physical receptacle used for this
+	// outlet.
+	OutletType ReceptacleType
+	// PhaseWiringType shall contain the number of ungrounded
+	// current-carrying conductors (phases) and the total number of
+	// conductors (wires).
+	PhaseWiringType PhaseWiringType
+	// PolyPhaseCurrentAmps shall contain the current sensors for this
+	// outlet. For single phase outlets this property shall be absent.
+	PolyPhaseCurrentAmps CurrentSensors
+	// PolyPhaseVoltage shall contain the voltage sensors for this outlet.
+	// For single phase outlets this property shall be absent.
+	PolyPhaseVoltage VoltageSensors
+	// PowerControlLocked shall indicate whether requests to the PowerControl
+	// action are locked.
+	PowerControlLocked bool
+	// PowerCycleDelaySeconds shall contain the number of seconds to delay
+	// power on after a PowerControl action to cycle power.
+	PowerCycleDelaySeconds float32
+	// PowerEnabled shall indicate the power enable state of the outlet.
+	PowerEnabled bool
+	// PowerLoadPercent shall contain the power load, in percent units, for
+	// this outlet that represents the Total ElectricalContext for this
+	// outlet.
+	PowerLoadPercent SensorExcerpt
+	// PowerOffDelaySeconds shall contain the number of seconds to delay
+	// power off after a PowerControl action.
+	PowerOffDelaySeconds float32
+	// PowerOnDelaySeconds shall contain the number of seconds to delay power
+	// up after a power cycle or a PowerControl action.
+	PowerOnDelaySeconds float32
+	// PowerRestoreDelaySeconds shall contain the number of seconds to delay
+	// power on after a power fault.
+	PowerRestoreDelaySeconds float32
+	// PowerRestorePolicy shall contain the desired PowerState of the outlet
+	// when power is applied.
+	PowerRestorePolicy PowerRestorePolicyTypes
+	// PowerState shall contain the power state of the outlet.
+	PowerState PowerState
+	// PowerStateInTransition shall indicate whether the PowerState property
+	// will undergo a transition between on and off states due to a
+	// configured delay.
+	PowerStateInTransition bool
+	// PowerWatts shall contain the total power, in watt units, for this
+	// outlet that represents the Total ElectricalContext sensor when
+	// multiple power sensors exist.
+	PowerWatts SensorPowerExcerpt
+	// RatedCurrentAmps shall contain the rated maximum current for this
+	// outlet, in ampere units, after any required de-rating.
+	RatedCurrentAmps float32
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Voltage shall contain the voltage, in volt units, for this single
+	// phase outlet.
+	Voltage SensorVoltageExcerpt
+	// VoltageType shall contain the type of voltage applied to the outlet.
+	VoltageType VoltageType
+	// branchCircuit is the branch circuit that supplies power to this
+	// outlet.
+	branchCircuit string
+	// chassis are the chassis connected to this outlet.
+	chassis []string
+	// distributionCircuits are the circuits powered by this outlet.
+	distributionCircuits []string
+	// powerSupplies are the power supplies connected to this outlet.
+	powerSupplies []string
+	// powerControlTarget is the URL to send PowerControl actions to.
+	powerControlTarget string
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (outlet *Outlet) GetRawData() []byte {
+	return outlet.rawData
+}
+
+// UnmarshalJSON unmarshals an Outlet object from the raw JSON.
+func (outlet *Outlet) UnmarshalJSON(b []byte) error {
+	type temp Outlet
+	type Actions struct {
+		PowerControl struct {
+			Target string
+		} `json:"#Outlet.PowerControl"`
+		ResetMetrics struct {
+			Target string
+		} `json:"#Outlet.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+		Links   struct {
+			BranchCircuit        common.Link
+			Chassis              common.Links
+			DistributionCircuits common.Links
+			PowerSupplies        common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*outlet = Outlet(t.temp)
+
+	// Extract the links to other entities for later
+	outlet.branchCircuit = string(t.Links.BranchCircuit)
+	outlet.chassis = t.Links.Chassis.ToStrings()
+	outlet.distributionCircuits = t.Links.DistributionCircuits.ToStrings()
+	outlet.powerSupplies = t.Links.PowerSupplies.ToStrings()
+	outlet.powerControlTarget = t.Actions.PowerControl.Target
+	outlet.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	outlet.rawData = b
+	outlet.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the outlet to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (outlet Outlet) MarshalJSON() ([]byte, error) {
+	type temp Outlet
+	return common.MarshalWithRawData(outlet.rawData, temp(outlet))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (outlet *Outlet) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Outlet)
+	original.UnmarshalJSON(outlet.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+		"PowerCycleDelaySeconds",
+		"PowerOffDelaySeconds",
+		"PowerOnDelaySeconds",
+		"PowerRestoreDelaySeconds",
+		"PowerRestorePolicy",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(outlet).Elem()
+
+	return outlet.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// BranchCircuit gets the branch circuit that supplies power to this outlet.
+func (outlet *Outlet) BranchCircuit() (*Circuit, error) {
+	if outlet.branchCircuit == "" {
+		return nil, nil
+	}
+
+	return GetCircuit(outlet.Client, outlet.branchCircuit)
+}
+
+// Chassis gets the chassis connected to this outlet.
+func (outlet *Outlet) Chassis() ([]*Chassis, error) {
+	var result []*Chassis
+	for _, chassisLink := range outlet.chassis {
+		chassis, err := GetChassis(outlet.Client, chassisLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, chassis)
+	}
+
+	return result, nil
+}
+
+// DistributionCircuits gets the circuits powered by this outlet.
+func (outlet *Outlet) DistributionCircuits() ([]*Circuit, error) {
+	var result []*Circuit
+	for _, circuitLink := range outlet.distributionCircuits {
+		circuit, err := GetCircuit(outlet.Client, circuitLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, circuit)
+	}
+
+	return result, nil
+}
+
+// PowerSupplies gets the URIs of the power supplies connected to this
+// outlet.
+func (outlet *Outlet) PowerSupplies() []string {
+	return outlet.powerSupplies
+}
+
+// PowerControl turns the outlet on or off, or cycles its power.
+func (outlet *Outlet) PowerControl(powerState PowerState) error {
+	if outlet.powerControlTarget == "" {
+		return fmt.Errorf("PowerControl is not supported by this outlet")
+	}
+
+	type temp struct {
+		PowerState PowerState
+	}
+	t := temp{
+		PowerState: powerState,
+	}
+
+	_, err := outlet.Client.Post(outlet.powerControlTarget, t)
+	return err
+}
+
+// ResetMetrics resets the summary metrics related to this outlet.
+func (outlet *Outlet) ResetMetrics() error {
+	if outlet.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this outlet")
+	}
+
+	_, err := outlet.Client.Post(outlet.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// GetOutlet will get an Outlet instance from the service.
+func GetOutlet(c common.Client, uri string) (*Outlet, error) {
+	var outlet Outlet
+	err := common.FetchResource(c, uri, &outlet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outlet, nil
+}
+
+// ListReferencedOutlets gets the collection of Outlet from
+// a provided reference.
+func ListReferencedOutlets(c common.Client, link string) ([]*Outlet, error) {
+	var result []*Outlet
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, outletLink := range links.ItemLinks {
+		outlet := new(Outlet)
+		expanded, err := links.DecodeMember(c, outletLink, outlet)
+		if err == nil && !expanded {
+			outlet, err = GetOutlet(c, outletLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, outlet)
+	}
+
+	return result, nil
+}
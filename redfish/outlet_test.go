@@ -0,0 +1,159 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var outletBody = `{
+		"@odata.type": "#Outlet.v1_4_0.Outlet",
+		"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1",
+		"Id": "A1",
+		"Name": "Outlet A1, Branch Circuit A",
+		"Status": {
+			"Health": "OK",
+			"State": "Enabled"
+		},
+		"PhaseWiringType": "OnePhase3Wire",
+		"VoltageType": "AC",
+		"OutletType": "NEMA_5_20R",
+		"RatedCurrentAmps": 20,
+		"NominalVoltage": "AC120V",
+		"PowerOnDelaySeconds": 4,
+		"PowerOffDelaySeconds": 0,
+		"PowerState": "On",
+		"PowerEnabled": true,
+		"Voltage": {
+			"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/VoltageA1",
+			"Reading": 117.5
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/PowerA1",
+			"Reading": 412.36,
+			"ApparentVA": 412.36,
+			"ReactiveVAR": 0,
+			"PowerFactor": 1
+		},
+		"CurrentAmps": {
+			"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/CurrentA1",
+			"Reading": 3.51
+		},
+		"EnergykWh": {
+			"DataSourceUri": "/redfish/v1/PowerEquipment/RackPDUs/1/Sensors/EnergyA1",
+			"Reading": 36166
+		},
+		"Links": {
+			"Chassis": [{
+				"@odata.id": "/redfish/v1/Chassis/1"
+			}]
+		},
+		"Actions": {
+			"#Outlet.PowerControl": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1/Outlet.PowerControl"
+			},
+			"#Outlet.ResetMetrics": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1/Outlet.ResetMetrics"
+			}
+		}
+	}`
+
+// TestOutlet tests the parsing of Outlet objects.
+func TestOutlet(t *testing.T) {
+	var result Outlet
+	err := json.NewDecoder(strings.NewReader(outletBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "A1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.OutletType != NEMA520RReceptacleType {
+		t.Errorf("Invalid outlet type: %s", result.OutletType)
+	}
+
+	if result.NominalVoltage != AC120VNominalVoltageType {
+		t.Errorf("Invalid nominal voltage: %s", result.NominalVoltage)
+	}
+
+	if result.PowerState != OnPowerState {
+		t.Errorf("Invalid power state: %s", result.PowerState)
+	}
+
+	if result.PowerWatts.Reading != 412.36 {
+		t.Errorf("Invalid power reading: %f", result.PowerWatts.Reading)
+	}
+
+	if len(result.chassis) != 1 {
+		t.Errorf("Invalid chassis links: %v", result.chassis)
+	}
+
+	if result.powerControlTarget != "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1/Outlet.PowerControl" {
+		t.Errorf("Invalid PowerControl target: %s", result.powerControlTarget)
+	}
+}
+
+// TestOutletUpdate tests the Update call.
+func TestOutletUpdate(t *testing.T) {
+	var result Outlet
+	err := json.NewDecoder(strings.NewReader(outletBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.PowerOnDelaySeconds = 10
+	result.UserLabel = "Top of rack switch"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "PowerOnDelaySeconds:10") {
+		t.Errorf("Unexpected PowerOnDelaySeconds update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "UserLabel:Top of rack switch") {
+		t.Errorf("Unexpected UserLabel update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestOutletPowerControl tests the PowerControl action.
+func TestOutletPowerControl(t *testing.T) {
+	var result Outlet
+	err := json.NewDecoder(strings.NewReader(outletBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.PowerControl(PowerCyclePowerState)
+
+	if err != nil {
+		t.Errorf("Error making PowerControl call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{PowerCycle}" {
+		t.Errorf("Unexpected PowerControl payload: %s", calls[0].Payload)
+	}
+}
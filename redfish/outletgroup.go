@@ -0,0 +1,237 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// OutletGroup shall be used to represent an electrical outlet group for a
+// Redfish implementation.
+type OutletGroup struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CreatedBy shall contain the name of the person or application that
+	// created this outlet group.
+	CreatedBy string
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this outlet group.
+	EnergykWh SensorEnergykWhExcerpt
+	// PowerControlLocked shall indicate whether requests to the PowerControl
+	// action are locked.
+	PowerControlLocked bool
+	// PowerCycleDelaySeconds shall contain the number of seconds to delay
+	// power on after a PowerControl action to cycle power.
+	PowerCycleDelaySeconds float32
+	// PowerEnabled shall indicate the power enable state of the outlet
+	// group.
+	PowerEnabled bool
+	// PowerOffDelaySeconds shall contain the number of seconds to delay
+	// power off after a PowerControl action.
+	PowerOffDelaySeconds float32
+	// PowerOnDelaySeconds shall contain the number of seconds to delay power
+	// up after a power cycle or a PowerControl action.
+	PowerOnDelaySeconds float32
+	// PowerRestoreDelaySeconds shall contain the number of seconds to delay
+	// power on after a power fault.
+	PowerRestoreDelaySeconds float32
+	// PowerRestorePolicy shall contain the desired PowerState of the outlet
+	// group when power is applied.
+	PowerRestorePolicy PowerRestorePolicyTypes
+	// PowerState shall contain the power state of the outlet group.
+	PowerState PowerState
+	// PowerStateInTransition shall indicate whether the PowerState property
+	// will undergo a transition between on and off states due to a
+	// configured delay.
+	PowerStateInTransition bool
+	// PowerWatts shall contain the total power, in watt units, for this
+	// outlet group.
+	PowerWatts SensorPowerExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// outlets are the outlets in this outlet group.
+	outlets []string
+	// powerControlTarget is the URL to send PowerControl actions to.
+	powerControlTarget string
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (outletgroup *OutletGroup) GetRawData() []byte {
+	return outletgroup.rawData
+}
+
+// UnmarshalJSON unmarshals an OutletGroup object from the raw JSON.
+func (outletgroup *OutletGroup) UnmarshalJSON(b []byte) error {
+	type temp OutletGroup
+	type Actions struct {
+		PowerControl struct {
+			Target string
+		} `json:"#OutletGroup.PowerControl"`
+		ResetMetrics struct {
+			Target string
+		} `json:"#OutletGroup.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+		Links   struct {
+			Outlets common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*outletgroup = OutletGroup(t.temp)
+
+	// Extract the links to other entities for later
+	outletgroup.outlets = t.Links.Outlets.ToStrings()
+	outletgroup.powerControlTarget = t.Actions.PowerControl.Target
+	outletgroup.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	outletgroup.rawData = b
+	outletgroup.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the outlet group to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (outletgroup OutletGroup) MarshalJSON() ([]byte, error) {
+	type temp OutletGroup
+	return common.MarshalWithRawData(outletgroup.rawData, temp(outletgroup))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (outletgroup *OutletGroup) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(OutletGroup)
+	original.UnmarshalJSON(outletgroup.rawData)
+
+	readWriteFields := []string{
+		"PowerCycleDelaySeconds",
+		"PowerOffDelaySeconds",
+		"PowerOnDelaySeconds",
+		"PowerRestoreDelaySeconds",
+		"PowerRestorePolicy",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(outletgroup).Elem()
+
+	return outletgroup.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Outlets gets the outlets in this outlet group.
+func (outletgroup *OutletGroup) Outlets() ([]*Outlet, error) {
+	var result []*Outlet
+	for _, outletLink := range outletgroup.outlets {
+		outlet, err := GetOutlet(outletgroup.Client, outletLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, outlet)
+	}
+
+	return result, nil
+}
+
+// PowerControl turns all outlets in this group on, off or cycles their
+// power together.
+func (outletgroup *OutletGroup) PowerControl(powerState PowerState) error {
+	if outletgroup.powerControlTarget == "" {
+		return fmt.Errorf("PowerControl is not supported by this outlet group")
+	}
+
+	type temp struct {
+		PowerState PowerState
+	}
+	t := temp{
+		PowerState: powerState,
+	}
+
+	_, err := outletgroup.Client.Post(outletgroup.powerControlTarget, t)
+	return err
+}
+
+// PowerCycle cycles the power of all outlets in this group.
+func (outletgroup *OutletGroup) PowerCycle() error {
+	return outletgroup.PowerControl(PowerCyclePowerState)
+}
+
+// ResetMetrics resets the summary metrics related to this outlet group.
+func (outletgroup *OutletGroup) ResetMetrics() error {
+	if outletgroup.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this outlet group")
+	}
+
+	_, err := outletgroup.Client.Post(outletgroup.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// Delete removes the outlet group from the service.
+func (outletgroup *OutletGroup) Delete() error {
+	return outletgroup.Client.Delete(outletgroup.ODataID)
+}
+
+// GetOutletGroup will get an OutletGroup instance from the service.
+func GetOutletGroup(c common.Client, uri string) (*OutletGroup, error) {
+	var outletgroup OutletGroup
+	err := common.FetchResource(c, uri, &outletgroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outletgroup, nil
+}
+
+// ListReferencedOutletGroups gets the collection of OutletGroup from
+// a provided reference.
+func ListReferencedOutletGroups(c common.Client, link string) ([]*OutletGroup, error) {
+	var result []*OutletGroup
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, outletgroupLink := range links.ItemLinks {
+		outletgroup := new(OutletGroup)
+		expanded, err := links.DecodeMember(c, outletgroupLink, outletgroup)
+		if err == nil && !expanded {
+			outletgroup, err = GetOutletGroup(c, outletgroupLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, outletgroup)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var outletGroupBody = `{
+		"@odata.type": "#OutletGroup.v1_1_0.OutletGroup",
+		"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/OutletGroups/Rack5Storage",
+		"Id": "Rack5Storage",
+		"Name": "Rack5Storage",
+		"CreatedBy": "Bob",
+		"PowerOnDelaySeconds": 4,
+		"PowerOffDelaySeconds": 0,
+		"PowerState": "On",
+		"PowerEnabled": true,
+		"PowerWatts": {
+			"Reading": 412.36
+		},
+		"Links": {
+			"Outlets": [
+				{
+					"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A1"
+				},
+				{
+					"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1/Outlets/A2"
+				}
+			]
+		},
+		"Actions": {
+			"#OutletGroup.PowerControl": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/OutletGroups/Rack5Storage/OutletGroup.PowerControl"
+			},
+			"#OutletGroup.ResetMetrics": {
+				"target": "/redfish/v1/PowerEquipment/RackPDUs/1/OutletGroups/Rack5Storage/OutletGroup.ResetMetrics"
+			}
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestOutletGroup tests the parsing of OutletGroup objects.
+func TestOutletGroup(t *testing.T) {
+	var result OutletGroup
+	err := json.NewDecoder(strings.NewReader(outletGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Rack5Storage" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CreatedBy != "Bob" {
+		t.Errorf("Invalid created by: %s", result.CreatedBy)
+	}
+
+	if len(result.outlets) != 2 {
+		t.Errorf("Invalid number of outlets: %d", len(result.outlets))
+	}
+
+	if result.powerControlTarget != "/redfish/v1/PowerEquipment/RackPDUs/1/OutletGroups/Rack5Storage/OutletGroup.PowerControl" {
+		t.Errorf("Invalid PowerControl target: %s", result.powerControlTarget)
+	}
+}
+
+// TestOutletGroupUpdate tests the Update call.
+func TestOutletGroupUpdate(t *testing.T) {
+	var result OutletGroup
+	err := json.NewDecoder(strings.NewReader(outletGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.PowerOffDelaySeconds = 2
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "PowerOffDelaySeconds:2") {
+		t.Errorf("Unexpected PowerOffDelaySeconds update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestOutletGroupPowerCycle tests the PowerCycle call.
+func TestOutletGroupPowerCycle(t *testing.T) {
+	var result OutletGroup
+	err := json.NewDecoder(strings.NewReader(outletGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.PowerCycle()
+
+	if err != nil {
+		t.Errorf("Error making PowerCycle call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{PowerCycle}" {
+		t.Errorf("Unexpected PowerCycle payload: %s", calls[0].Payload)
+	}
+}
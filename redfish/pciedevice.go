@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -41,6 +40,41 @@ const (
 	Gen5PCIeTypes PCIeTypes = "Gen5"
 )
 
+// CXLDeviceType is the type of a CXL device.
+type CXLDeviceType string
+
+const (
+	// Type1CXLDeviceType shall indicate a CXL Specification-defined Type 1
+	// device, such as an accelerator without memory.
+	Type1CXLDeviceType CXLDeviceType = "Type1"
+	// Type2CXLDeviceType shall indicate a CXL Specification-defined Type 2
+	// device, such as an accelerator with memory.
+	Type2CXLDeviceType CXLDeviceType = "Type2"
+	// Type3CXLDeviceType shall indicate a CXL Specification-defined Type 3
+	// device, a memory expander.
+	Type3CXLDeviceType CXLDeviceType = "Type3"
+)
+
+// CXLDevice shall contain the CXL-specific properties of a PCIe device.
+type CXLDevice struct {
+	// DeviceType shall contain the CXL Specification-defined class of the
+	// device.
+	DeviceType CXLDeviceType
+	// EgressPortCongestionSupported shall indicate whether the device
+	// supports the CXL Specification-defined 'Egress Port Congestion'
+	// mechanism.
+	EgressPortCongestionSupported bool
+	// MaxNumberLogicalDevices shall contain the maximum number of logical
+	// devices supported by this CXL device.
+	MaxNumberLogicalDevices int
+	// ThroughputReductionSupported shall indicate whether the device supports
+	// the CXL Specification-defined 'Temporary Throughput Reduction'
+	// mechanism.
+	ThroughputReductionSupported bool
+	// Timestamp shall contain the timestamp set on the CXL device.
+	Timestamp string
+}
+
 // PCIeDevice is used to represent a PCIeDevice attached to a System.
 type PCIeDevice struct {
 	common.Entity
@@ -55,6 +89,12 @@ type PCIeDevice struct {
 	assembly string
 	// AssetTag is used to track the PCIe device for inventory purposes.
 	AssetTag string
+	// CXLDevice shall contain the CXL-specific properties of this PCIe
+	// device, for CXL devices such as memory expanders and accelerators.
+	CXLDevice CXLDevice
+	// cxlLogicalDevices is the link to the collection of CXL logical devices
+	// of this PCIe device.
+	cxlLogicalDevices string
 	// Description provides a description of this resource.
 	Description string
 	// DeviceType shall be the device type of the PCIe device such as
@@ -111,8 +151,9 @@ func (pciedevice *PCIeDevice) UnmarshalJSON(b []byte) error {
 	}
 	var t struct {
 		temp
-		Assembly common.Link
-		Links    links
+		Assembly          common.Link
+		CXLLogicalDevices common.Link
+		Links             links
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -124,6 +165,7 @@ func (pciedevice *PCIeDevice) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	pciedevice.assembly = string(t.Assembly)
+	pciedevice.cxlLogicalDevices = string(t.CXLLogicalDevices)
 	pciedevice.chassis = t.Links.Chassis.ToStrings()
 	pciedevice.ChassisCount = t.Links.ChassisCount
 	pciedevice.pcieFunctions = t.Links.PCIeFunctions.ToStrings()
@@ -131,10 +173,18 @@ func (pciedevice *PCIeDevice) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	pciedevice.rawData = b
+	pciedevice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the PCIe device to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (pciedevice PCIeDevice) MarshalJSON() ([]byte, error) {
+	type temp PCIeDevice
+	return common.MarshalWithRawData(pciedevice.rawData, temp(pciedevice))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (pciedevice *PCIeDevice) Update() error {
 
@@ -155,25 +205,12 @@ func (pciedevice *PCIeDevice) Update() error {
 
 // GetPCIeDevice will get a PCIeDevice instance from the service.
 func GetPCIeDevice(c common.Client, uri string) (*PCIeDevice, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var pcieDevice PCIeDevice
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &pcieDevice)
+	err := common.FetchResource(c, uri, &pcieDevice)
 	if err != nil {
 		return nil, err
 	}
 
-	pcieDevice.rawData = rawData
-	pcieDevice.SetClient(c)
 	return &pcieDevice, nil
 }
 
@@ -191,7 +228,11 @@ func ListReferencedPCIeDevices(c common.Client, link string) ([]*PCIeDevice, err
 	}
 
 	for _, pciedeviceLink := range links.ItemLinks {
-		pciedevice, err := GetPCIeDevice(c, pciedeviceLink)
+		pciedevice := new(PCIeDevice)
+		expanded, err := links.DecodeMember(c, pciedeviceLink, pciedevice)
+		if err == nil && !expanded {
+			pciedevice, err = GetPCIeDevice(c, pciedeviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -223,6 +264,12 @@ func (pciedevice *PCIeDevice) Assembly() (*Assembly, error) {
 	return GetAssembly(pciedevice.Client, pciedevice.assembly)
 }
 
+// CXLLogicalDevices gets the CXL logical devices of this PCIe device, such as
+// the logical devices of a multi-logical-device memory expander.
+func (pciedevice *PCIeDevice) CXLLogicalDevices() ([]*CXLLogicalDevice, error) {
+	return ListReferencedCXLLogicalDevices(pciedevice.Client, pciedevice.cxlLogicalDevices)
+}
+
 // Chassis gets the chassis in which the PCIe device is contained.
 func (pciedevice *PCIeDevice) Chassis() ([]*Chassis, error) {
 	var result []*Chassis
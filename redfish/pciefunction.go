@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -183,31 +182,27 @@ func (pciefunction *PCIeFunction) UnmarshalJSON(b []byte) error {
 	pciefunction.pcieDevice = string(t.Links.PCIeDevice)
 	pciefunction.storageControllers = t.Links.StorageControllers.ToStrings()
 	pciefunction.StorageControllersCount = t.Links.StorageControllersCount
+	pciefunction.rawData = b
+	pciefunction.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the PCIe function to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (pciefunction PCIeFunction) MarshalJSON() ([]byte, error) {
+	type temp PCIeFunction
+	return common.MarshalWithRawData(pciefunction.rawData, temp(pciefunction))
+}
+
 // GetPCIeFunction will get a PCIeFunction instance from the service.
 func GetPCIeFunction(c common.Client, uri string) (*PCIeFunction, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var pcieFunction PCIeFunction
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &pcieFunction)
+	err := common.FetchResource(c, uri, &pcieFunction)
 	if err != nil {
 		return nil, err
 	}
 
-	pcieFunction.rawData = rawData
-	pcieFunction.SetClient(c)
 	return &pcieFunction, nil
 }
 
@@ -225,7 +220,11 @@ func ListReferencedPCIeFunctions(c common.Client, link string) ([]*PCIeFunction,
 	}
 
 	for _, pciefunctionLink := range links.ItemLinks {
-		pciefunction, err := GetPCIeFunction(c, pciefunctionLink)
+		pciefunction := new(PCIeFunction)
+		expanded, err := links.DecodeMember(c, pciefunctionLink, pciefunction)
+		if err == nil && !expanded {
+			pciefunction, err = GetPCIeFunction(c, pciefunctionLink)
+		}
 		if err != nil {
 			return result, err
 		}
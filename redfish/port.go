@@ -0,0 +1,316 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// LinkState is the desired link state of a port.
+type LinkState string
+
+const (
+	// EnabledLinkState shall indicate the link is enabled and available for
+	// communication.
+	EnabledLinkState LinkState = "Enabled"
+	// DisabledLinkState shall indicate the link is disabled and not
+	// available for communication.
+	DisabledLinkState LinkState = "Disabled"
+)
+
+// PortType is the type of a port.
+type PortType string
+
+const (
+	// UpstreamPortPortType This port connects to a host device.
+	UpstreamPortPortType PortType = "UpstreamPort"
+	// DownstreamPortPortType This port connects to a target device.
+	DownstreamPortPortType PortType = "DownstreamPort"
+	// InterswitchPortPortType This port connects to another switch.
+	InterswitchPortPortType PortType = "InterswitchPort"
+	// ManagementPortPortType This port connects to a switch manager.
+	ManagementPortPortType PortType = "ManagementPort"
+	// BidirectionalPortPortType This port connects to any type of device.
+	BidirectionalPortPortType PortType = "BidirectionalPort"
+	// UnconfiguredPortPortType This port has not yet been configured.
+	UnconfiguredPortPortType PortType = "UnconfiguredPort"
+)
+
+// ConnectedDeviceMode is the CXL mode of the device connected to a port.
+type ConnectedDeviceMode string
+
+const (
+	// DisconnectedConnectedDeviceMode shall indicate no device is connected.
+	DisconnectedConnectedDeviceMode ConnectedDeviceMode = "Disconnected"
+	// RCDConnectedDeviceMode shall indicate a CXL restricted device is
+	// connected.
+	RCDConnectedDeviceMode ConnectedDeviceMode = "RCD"
+	// CXL68BFlitAndVHConnectedDeviceMode shall indicate the device uses the
+	// CXL 68B flit and virtual hierarchy mode.
+	CXL68BFlitAndVHConnectedDeviceMode ConnectedDeviceMode = "CXL68BFlitAndVH"
+	// Standard256BFlitConnectedDeviceMode shall indicate the device uses the
+	// standard 256B flit mode.
+	Standard256BFlitConnectedDeviceMode ConnectedDeviceMode = "Standard256BFlit"
+	// CXLLatencyOptimized256BFlitConnectedDeviceMode shall indicate the
+	// device uses the CXL latency optimized 256B flit mode.
+	CXLLatencyOptimized256BFlitConnectedDeviceMode ConnectedDeviceMode = "CXLLatencyOptimized256BFlit"
+	// PBRConnectedDeviceMode shall indicate the device uses the port-based
+	// routing mode.
+	PBRConnectedDeviceMode ConnectedDeviceMode = "PBR"
+)
+
+// ConnectedDeviceType is the CXL type of the device connected to a port.
+type ConnectedDeviceType string
+
+const (
+	// NoneConnectedDeviceType shall indicate no device is detected.
+	NoneConnectedDeviceType ConnectedDeviceType = "None"
+	// PCIeDeviceConnectedDeviceType shall indicate a PCIe device is
+	// connected.
+	PCIeDeviceConnectedDeviceType ConnectedDeviceType = "PCIeDevice"
+	// Type1ConnectedDeviceType shall indicate a CXL Type 1 device is
+	// connected.
+	Type1ConnectedDeviceType ConnectedDeviceType = "Type1"
+	// Type2ConnectedDeviceType shall indicate a CXL Type 2 device is
+	// connected.
+	Type2ConnectedDeviceType ConnectedDeviceType = "Type2"
+	// Type3SLDConnectedDeviceType shall indicate a CXL Type 3 single logical
+	// device is connected.
+	Type3SLDConnectedDeviceType ConnectedDeviceType = "Type3SLD"
+	// Type3MLDConnectedDeviceType shall indicate a CXL Type 3 multi-logical
+	// device is connected.
+	Type3MLDConnectedDeviceType ConnectedDeviceType = "Type3MLD"
+)
+
+// CXLCongestion shall contain the CXL congestion properties of a port.
+type CXLCongestion struct {
+	// BackpressureSampleInterval shall contain the interval, in nanoseconds,
+	// of the CXL Specification-defined 'Egress Port Congestion' sampling.
+	BackpressureSampleInterval int
+	// CompletionCollectionInterval shall contain the interval, in
+	// nanoseconds, of the CXL Specification-defined 'Completion Counting'.
+	CompletionCollectionInterval int
+	// CongestionTelemetryEnabled shall indicate whether the congestion
+	// telemetry collection is enabled for this port.
+	CongestionTelemetryEnabled bool
+	// EgressModeratePercentage shall contain the threshold, in percent, for
+	// moderate egress port congestion.
+	EgressModeratePercentage int
+	// EgressSeverePercentage shall contain the threshold, in percent, for
+	// severe egress port congestion.
+	EgressSeverePercentage int
+	// MaxSustainedRecoveryInterval shall contain the interval, in
+	// nanoseconds, that the port remains in a congested state before it
+	// recovers.
+	MaxSustainedRecoveryInterval int
+}
+
+// PortCXL shall contain the CXL-specific properties of a port.
+type PortCXL struct {
+	// Congestion shall contain the CXL congestion properties for this port.
+	Congestion CXLCongestion
+	// ConnectedDeviceMode shall contain the CXL mode of the connected
+	// device.
+	ConnectedDeviceMode ConnectedDeviceMode
+	// ConnectedDeviceType shall contain the CXL type of the connected device.
+	ConnectedDeviceType ConnectedDeviceType
+	// MaxLogicalDeviceCount shall contain the maximum number of logical
+	// devices supported by the connected device.
+	MaxLogicalDeviceCount int
+	// QoSTelemetryCapabilities shall contain the quality of service
+	// telemetry capabilities for this port.
+	QoSTelemetryCapabilities QoSTelemetryCapabilities
+	// TemporaryThroughputReductionEnabled shall indicate whether the CXL
+	// Specification-defined 'Temporary Throughput Reduction' mechanism is
+	// enabled on this port.
+	TemporaryThroughputReductionEnabled bool
+}
+
+// Port shall represent a simple port for a Redfish implementation, such as
+// a USB, switch or controller port.
+type Port struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CXL shall contain the CXL-specific properties of this port, for the
+	// ports of CXL switches and devices.
+	CXL PortCXL
+	// CurrentSpeedGbps shall contain the speed of this port currently
+	// negotiated and running.
+	CurrentSpeedGbps float32
+	// Description provides a description of this resource.
+	Description string
+	// InterfaceEnabled shall indicate whether the port is enabled.
+	InterfaceEnabled bool
+	// LinkState shall contain the desired link state for this interface.
+	LinkState LinkState
+	// LinkStatus shall contain the link status for this interface.
+	LinkStatus LinkStatus
+	// Location shall contain location information of the associated port.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// MaxSpeedGbps shall contain the maximum speed of which this port is
+	// capable of being configured.
+	MaxSpeedGbps float32
+	// PortID shall contain the name of the port as indicated on the device
+	// containing the port.
+	PortID string `json:"PortId"`
+	// PortMedium shall contain the physical connection medium for this port.
+	PortMedium string
+	// PortProtocol shall contain the protocol being sent over this port.
+	PortProtocol common.Protocol
+	// PortType shall contain the port type for this port.
+	PortType PortType
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Width shall contain the number of physical transport links that this
+	// port contains.
+	Width int
+	// associatedEndpoints are the endpoints that connect through this port.
+	associatedEndpoints []string
+	// connectedPorts are the remote device ports connected to this port.
+	connectedPorts []string
+	// connectedSwitches are the switches connected to this port.
+	connectedSwitches []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (port *Port) GetRawData() []byte {
+	return port.rawData
+}
+
+// UnmarshalJSON unmarshals a Port object from the raw JSON.
+func (port *Port) UnmarshalJSON(b []byte) error {
+	type temp Port
+	var t struct {
+		temp
+		Links struct {
+			AssociatedEndpoints common.Links
+			ConnectedPorts      common.Links
+			ConnectedSwitches   common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*port = Port(t.temp)
+
+	// Extract the links to other entities for later
+	port.associatedEndpoints = t.Links.AssociatedEndpoints.ToStrings()
+	port.connectedPorts = t.Links.ConnectedPorts.ToStrings()
+	port.connectedSwitches = t.Links.ConnectedSwitches.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	port.rawData = b
+	port.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the port to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (port Port) MarshalJSON() ([]byte, error) {
+	type temp Port
+	return common.MarshalWithRawData(port.rawData, temp(port))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (port *Port) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Port)
+	original.UnmarshalJSON(port.rawData)
+
+	readWriteFields := []string{
+		"InterfaceEnabled",
+		"LinkState",
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(port).Elem()
+
+	return port.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// AssociatedEndpoints gets the endpoints that connect through this port.
+func (port *Port) AssociatedEndpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+	for _, endpointLink := range port.associatedEndpoints {
+		endpoint, err := GetEndpoint(port.Client, endpointLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, endpoint)
+	}
+
+	return result, nil
+}
+
+// ConnectedPorts gets the URIs of the remote device ports, such as the
+// ports of a connected USB device, connected to this port.
+func (port *Port) ConnectedPorts() []string {
+	return port.connectedPorts
+}
+
+// ConnectedSwitches gets the URIs of the switches connected to this port.
+func (port *Port) ConnectedSwitches() []string {
+	return port.connectedSwitches
+}
+
+// GetPort will get a Port instance from the service.
+func GetPort(c common.Client, uri string) (*Port, error) {
+	var port Port
+	err := common.FetchResource(c, uri, &port)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port, nil
+}
+
+// ListReferencedPorts gets the collection of Port from
+// a provided reference.
+func ListReferencedPorts(c common.Client, link string) ([]*Port, error) {
+	var result []*Port
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, portLink := range links.ItemLinks {
+		port := new(Port)
+		expanded, err := links.DecodeMember(c, portLink, port)
+		if err == nil && !expanded {
+			port, err = GetPort(c, portLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, port)
+	}
+
+	return result, nil
+}
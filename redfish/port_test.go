@@ -0,0 +1,145 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var portBody = `{
+		"@odata.type": "#Port.v1_4_0.Port",
+		"@odata.id": "/redfish/v1/Systems/1/USBControllers/FrontPanel/Ports/1",
+		"Id": "1",
+		"Name": "Front Panel USB Port 1",
+		"PortId": "1",
+		"PortProtocol": "USB",
+		"PortType": "DownstreamPort",
+		"InterfaceEnabled": true,
+		"LinkStatus": "LinkUp",
+		"CurrentSpeedGbps": 5,
+		"MaxSpeedGbps": 10,
+		"Location": {
+			"PartLocation": {
+				"ServiceLabel": "Front USB 1",
+				"LocationType": "Connector"
+			}
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"ConnectedPorts": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/Keyboard/Ports/1"
+				}
+			]
+		}
+	}`
+
+// TestPort tests the parsing of Port objects.
+func TestPort(t *testing.T) {
+	var result Port
+	err := json.NewDecoder(strings.NewReader(portBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.PortProtocol != common.USBProtocol {
+		t.Errorf("Invalid port protocol: %s", result.PortProtocol)
+	}
+
+	if result.PortType != DownstreamPortPortType {
+		t.Errorf("Invalid port type: %s", result.PortType)
+	}
+
+	if !result.InterfaceEnabled {
+		t.Error("InterfaceEnabled should be true")
+	}
+
+	if result.LinkStatus != LinkUpLinkStatus {
+		t.Errorf("Invalid link status: %s", result.LinkStatus)
+	}
+
+	if len(result.ConnectedPorts()) != 1 {
+		t.Errorf("Invalid connected ports: %v", result.ConnectedPorts())
+	}
+}
+
+// TestPortUpdate tests the Update call.
+func TestPortUpdate(t *testing.T) {
+	var result Port
+	err := json.NewDecoder(strings.NewReader(portBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.InterfaceEnabled = false
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "InterfaceEnabled:false") {
+		t.Errorf("Unexpected InterfaceEnabled update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestPortCXL tests the parsing of the CXL properties of ports.
+func TestPortCXL(t *testing.T) {
+	var result Port
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Fabrics/CXL/Switches/CXL/Ports/D1",
+		"Id": "D1",
+		"PortProtocol": "CXL",
+		"PortType": "DownstreamPort",
+		"CXL": {
+			"ConnectedDeviceMode": "CXL68BFlitAndVH",
+			"ConnectedDeviceType": "Type3MLD",
+			"MaxLogicalDeviceCount": 4,
+			"Congestion": {
+				"CongestionTelemetryEnabled": true,
+				"EgressModeratePercentage": 50,
+				"EgressSeverePercentage": 80
+			},
+			"QoSTelemetryCapabilities": {
+				"EgressPortBackpressureSupported": true
+			}
+		}
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.CXL.ConnectedDeviceType != Type3MLDConnectedDeviceType ||
+		result.CXL.ConnectedDeviceMode != CXL68BFlitAndVHConnectedDeviceMode {
+		t.Errorf("Invalid connected device: %v", result.CXL)
+	}
+
+	if result.CXL.MaxLogicalDeviceCount != 4 || result.CXL.Congestion.EgressSeverePercentage != 80 {
+		t.Errorf("Invalid CXL properties: %v", result.CXL)
+	}
+
+	if !result.CXL.QoSTelemetryCapabilities.EgressPortBackpressureSupported {
+		t.Error("EgressPortBackpressureSupported should be true")
+	}
+}
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -156,26 +156,156 @@ func (power *Power) GetRawData() []byte {
 
 // GetPower will get a Power instance from the service.
 func GetPower(c common.Client, uri string) (*Power, error) {
-	resp, err := c.Get(uri)
+	var power Power
+	err := common.FetchResource(c, uri, &power)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var power Power
-	rawData, err := ioutil.ReadAll(resp.Body)
+	for i := range power.PowerSupplies {
+		power.PowerSupplies[i].SetClient(c)
+	}
+	return &power, nil
+}
+
+// UnmarshalJSON unmarshals a Power object from the raw JSON.
+func (power *Power) UnmarshalJSON(b []byte) error {
+	type temp Power
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	*power = Power(t.temp)
+
+	for i := range power.PowerSupplies {
+		resolveRedundancies(power.PowerSupplies[i].Redundancy, power.Redundancy)
 	}
 
-	err = json.Unmarshal(rawData, &power)
+	// This is a read/write object, so we need to save the raw object data for later
+	power.rawData = b
+	power.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (power Power) MarshalJSON() ([]byte, error) {
+	type temp Power
+	return common.MarshalWithRawData(power.rawData, temp(power))
+}
+
+// Update commits updates to the power limit of the PowerControl entries of
+// this object to the running system.
+func (power *Power) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Power)
+	err := json.Unmarshal(power.rawData, original)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	power.rawData = rawData
-	power.SetClient(c)
-	return &power, nil
+	if len(original.PowerControl) != len(power.PowerControl) {
+		return fmt.Errorf("PowerControl entries cannot be added or removed")
+	}
+
+	// Array members are patched positionally, an empty object leaves the
+	// member unchanged.
+	changed := false
+	controls := make([]interface{}, len(power.PowerControl))
+	for i := range power.PowerControl {
+		limit := changedFields(
+			reflect.ValueOf(original.PowerControl[i].PowerLimit),
+			reflect.ValueOf(power.PowerControl[i].PowerLimit),
+			"CorrectionInMs",
+			"LimitException",
+			"LimitInWatts")
+		if len(limit) == 0 {
+			controls[i] = struct{}{}
+			continue
+		}
+		controls[i] = map[string]interface{}{"PowerLimit": limit}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	type temp struct {
+		PowerControl []interface{}
+	}
+	t := temp{
+		PowerControl: controls,
+	}
+
+	_, err = power.Client.Patch(power.ODataID, t)
+	return err
+}
+
+// SetPowerLimit configures the power limit of the first PowerControl entry
+// of this object. Only the non-zero fields of limit are applied, use
+// DisablePowerLimit to remove the limit.
+func (power *Power) SetPowerLimit(limit PowerLimit) error {
+	if len(power.PowerControl) == 0 {
+		return fmt.Errorf("power limiting is not supported by this resource")
+	}
+
+	current := &power.PowerControl[0].PowerLimit
+	if limit.CorrectionInMs != 0 {
+		current.CorrectionInMs = limit.CorrectionInMs
+	}
+	if limit.LimitException != "" {
+		current.LimitException = limit.LimitException
+	}
+	if limit.LimitInWatts != 0 {
+		current.LimitInWatts = limit.LimitInWatts
+	}
+
+	return power.Update()
+}
+
+// DisablePowerLimit disables power capping on the first PowerControl entry
+// of this object by setting LimitInWatts to null. The LimitException is
+// cleared as well, since it has no meaning without a limit.
+func (power *Power) DisablePowerLimit() error {
+	if len(power.PowerControl) == 0 {
+		return fmt.Errorf("power limiting is not supported by this resource")
+	}
+
+	controls := make([]interface{}, len(power.PowerControl))
+	for i := range controls {
+		controls[i] = struct{}{}
+	}
+	controls[0] = map[string]interface{}{
+		"PowerLimit": map[string]interface{}{
+			"LimitException": nil,
+			"LimitInWatts":   nil,
+		},
+	}
+
+	type temp struct {
+		PowerControl []interface{}
+	}
+	t := temp{
+		PowerControl: controls,
+	}
+
+	_, err := power.Client.Patch(power.ODataID, t)
+	if err != nil {
+		return err
+	}
+
+	power.PowerControl[0].PowerLimit.LimitException = ""
+	power.PowerControl[0].PowerLimit.LimitInWatts = 0
+	return nil
 }
 
 // ListReferencedPowers gets the collection of Power from
@@ -239,6 +369,50 @@ type PowerControl struct {
 	// Status shall contain any status or health properties
 	// of the resource.
 	Status common.Status
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powerControl *PowerControl) GetRawData() []byte {
+	return powerControl.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerControl object from the raw JSON.
+func (powerControl *PowerControl) UnmarshalJSON(b []byte) error {
+	type temp PowerControl
+	var t struct {
+		temp
+		// Some implementations send these with the wrong JSON type
+		PowerAllocatedWatts common.Float32
+		PowerAvailableWatts common.Float32
+		PowerCapacityWatts  common.Float32
+		PowerConsumedWatts  common.Float32
+		PowerRequestedWatts common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerControl = PowerControl(t.temp)
+	powerControl.PowerAllocatedWatts = float32(t.PowerAllocatedWatts)
+	powerControl.PowerAvailableWatts = float32(t.PowerAvailableWatts)
+	powerControl.PowerCapacityWatts = float32(t.PowerCapacityWatts)
+	powerControl.PowerConsumedWatts = float32(t.PowerConsumedWatts)
+	powerControl.PowerRequestedWatts = float32(t.PowerRequestedWatts)
+	powerControl.rawData = b
+	powerControl.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power control to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (powerControl PowerControl) MarshalJSON() ([]byte, error) {
+	type temp PowerControl
+	return common.MarshalWithRawData(powerControl.rawData, temp(powerControl))
 }
 
 // PowerLimit shall contain power limit status and
@@ -281,6 +455,30 @@ type PowerMetric struct {
 	MinConsumedWatts float32
 }
 
+// UnmarshalJSON unmarshals a PowerMetric object from the raw JSON.
+func (powerMetric *PowerMetric) UnmarshalJSON(b []byte) error {
+	type temp PowerMetric
+	var t struct {
+		temp
+		// Some implementations send these with the wrong JSON type
+		AverageConsumedWatts common.Float32
+		MaxConsumedWatts     common.Float32
+		MinConsumedWatts     common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerMetric = PowerMetric(t.temp)
+	powerMetric.AverageConsumedWatts = float32(t.AverageConsumedWatts)
+	powerMetric.MaxConsumedWatts = float32(t.MaxConsumedWatts)
+	powerMetric.MinConsumedWatts = float32(t.MinConsumedWatts)
+
+	return nil
+}
+
 // PowerSupply is Details of a power supplies associated with this system
 // or device.
 type PowerSupply struct {
@@ -380,6 +578,14 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		Assembly common.Link
+
+		// Some implementations send these with the wrong JSON type
+		EfficiencyPercent    common.Int
+		LastPowerOutputWatts common.Float32
+		LineInputVoltage     common.Float32
+		PowerCapacityWatts   common.Float32
+		PowerInputWatts      common.Float32
+		PowerOutputWatts     common.Float32
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -389,14 +595,28 @@ func (powersupply *PowerSupply) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*powersupply = PowerSupply(t.temp)
+	powersupply.EfficiencyPercent = int(t.EfficiencyPercent)
+	powersupply.LastPowerOutputWatts = float32(t.LastPowerOutputWatts)
+	powersupply.LineInputVoltage = float32(t.LineInputVoltage)
+	powersupply.PowerCapacityWatts = float32(t.PowerCapacityWatts)
+	powersupply.PowerInputWatts = float32(t.PowerInputWatts)
+	powersupply.PowerOutputWatts = float32(t.PowerOutputWatts)
 	powersupply.assembly = string(t.Assembly)
 
 	// This is a read/write object, so we need to save the raw object data for later
 	powersupply.rawData = b
+	powersupply.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the power supply to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (powersupply PowerSupply) MarshalJSON() ([]byte, error) {
+	type temp PowerSupply
+	return common.MarshalWithRawData(powersupply.rawData, temp(powersupply))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (powersupply *PowerSupply) Update() error {
 
@@ -415,6 +635,15 @@ func (powersupply *PowerSupply) Update() error {
 	return powersupply.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
+// Assembly gets the assembly for this power supply.
+func (powersupply *PowerSupply) Assembly() (*Assembly, error) {
+	if powersupply.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(powersupply.Client, powersupply.assembly)
+}
+
 // Voltage is a voltage representation.
 type Voltage struct {
 	common.Entity
@@ -468,4 +697,52 @@ type Voltage struct {
 	// the present reading is above the normal range but is not critical.
 	// Units shall use the same units as the related ReadingVolts property.
 	UpperThresholdNonCritical float32
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (voltage *Voltage) GetRawData() []byte {
+	return voltage.rawData
+}
+
+// UnmarshalJSON unmarshals a Voltage object from the raw JSON.
+func (voltage *Voltage) UnmarshalJSON(b []byte) error {
+	type temp Voltage
+	var t struct {
+		temp
+		// Some implementations send these with the wrong JSON type
+		LowerThresholdCritical    common.Float32
+		LowerThresholdFatal       common.Float32
+		LowerThresholdNonCritical common.Float32
+		ReadingVolts              common.Float32
+		UpperThresholdCritical    common.Float32
+		UpperThresholdFatal       common.Float32
+		UpperThresholdNonCritical common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*voltage = Voltage(t.temp)
+	voltage.LowerThresholdCritical = float32(t.LowerThresholdCritical)
+	voltage.LowerThresholdFatal = float32(t.LowerThresholdFatal)
+	voltage.LowerThresholdNonCritical = float32(t.LowerThresholdNonCritical)
+	voltage.ReadingVolts = float32(t.ReadingVolts)
+	voltage.UpperThresholdCritical = float32(t.UpperThresholdCritical)
+	voltage.UpperThresholdFatal = float32(t.UpperThresholdFatal)
+	voltage.UpperThresholdNonCritical = float32(t.UpperThresholdNonCritical)
+	voltage.rawData = b
+	voltage.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the voltage to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (voltage Voltage) MarshalJSON() ([]byte, error) {
+	type temp Voltage
+	return common.MarshalWithRawData(voltage.rawData, temp(voltage))
 }
@@ -140,6 +140,10 @@ func TestPower(t *testing.T) {
 		t.Errorf("Received invalid ID: %s", result.ID)
 	}
 
+	if len(result.PowerControl) == 0 || len(result.PowerControl[0].GetRawData()) == 0 {
+		t.Error("The raw JSON of the power control should be kept")
+	}
+
 	if result.Name != "PowerOne" {
 		t.Errorf("Received invalid name: %s", result.Name)
 	}
@@ -161,7 +165,184 @@ func TestPower(t *testing.T) {
 			result.PowerSupplies[0].IndicatorLED)
 	}
 
+	if result.PowerSupplies[0].assembly != "/redfish/v1/Assembly/1" {
+		t.Errorf("Invalid PowerSupply assembly link: %s",
+			result.PowerSupplies[0].assembly)
+	}
+
 	if result.Voltages[0].MaxReadingRange != 10 {
 		t.Errorf("Invalid MaxReadingRange: %f", result.Voltages[0].MaxReadingRange)
 	}
 }
+
+var powerLimitBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerControl": [{
+			"MemberId": "0",
+			"PowerLimit": {
+				"CorrectionInMs": 1000,
+				"LimitException": "NoAction",
+				"LimitInWatts": 800
+			}
+		}, {
+			"MemberId": "1",
+			"PowerLimit": {
+				"LimitInWatts": 200
+			}
+		}]
+	}`
+
+// TestPowerUpdate tests the Update call.
+func TestPowerUpdate(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerLimitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result.rawData = []byte(powerLimitBody)
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.PowerControl[1].PowerLimit.LimitInWatts = 250
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{[{} map[PowerLimit:map[LimitInWatts:250]]]}" {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestPowerSetPowerLimit tests configuring the power limit.
+func TestPowerSetPowerLimit(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerLimitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result.rawData = []byte(powerLimitBody)
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetPowerLimit(PowerLimit{
+		LimitException: LogEventOnlyPowerLimitException,
+		LimitInWatts:   500,
+	})
+
+	if err != nil {
+		t.Errorf("Error setting power limit: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{[map[PowerLimit:map[LimitException:LogEventOnly LimitInWatts:500]] {}]}" {
+		t.Errorf("Unexpected power limit payload: %s", calls[0].Payload)
+	}
+
+	if result.PowerControl[0].PowerLimit.CorrectionInMs != 1000 {
+		t.Errorf("CorrectionInMs should not be changed: %d", result.PowerControl[0].PowerLimit.CorrectionInMs)
+	}
+}
+
+// TestPowerDisablePowerLimit tests clearing the power limit.
+func TestPowerDisablePowerLimit(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerLimitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	result.rawData = []byte(powerLimitBody)
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.DisablePowerLimit()
+
+	if err != nil {
+		t.Errorf("Error disabling power limit: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "{[map[PowerLimit:map[LimitException:<nil> LimitInWatts:<nil>]] {}]}" {
+		t.Errorf("Unexpected power limit payload: %s", calls[0].Payload)
+	}
+
+	if result.PowerControl[0].PowerLimit.LimitInWatts != 0 {
+		t.Errorf("LimitInWatts should be cleared: %f", result.PowerControl[0].PowerLimit.LimitInWatts)
+	}
+
+	if result.PowerControl[0].PowerLimit.CorrectionInMs != 1000 {
+		t.Errorf("CorrectionInMs should not be changed: %d", result.PowerControl[0].PowerLimit.CorrectionInMs)
+	}
+}
+
+var powerRedundancyBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Power",
+		"Id": "Power",
+		"PowerSupplies": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0",
+			"MemberId": "0",
+			"Redundancy": [{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0"
+			}]
+		}, {
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1",
+			"MemberId": "1",
+			"Redundancy": [{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0"
+			}]
+		}],
+		"Redundancy": [{
+			"@odata.id": "/redfish/v1/Chassis/1/Power#/Redundancy/0",
+			"MemberId": "0",
+			"Name": "PowerSupply Redundancy Group 1",
+			"Mode": "N+m",
+			"MaxNumSupported": 2,
+			"MinNumNeeded": 1,
+			"RedundancySet": [{
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/0"
+			}, {
+				"@odata.id": "/redfish/v1/Chassis/1/Power#/PowerSupplies/1"
+			}],
+			"Status": {
+				"State": "Enabled",
+				"Health": "OK"
+			}
+		}]
+	}`
+
+// TestPowerSupplyRedundancy tests that power supply redundancy references
+// are resolved to their redundancy group.
+func TestPowerSupplyRedundancy(t *testing.T) {
+	var result Power
+	err := json.NewDecoder(strings.NewReader(powerRedundancyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	redundancy := result.PowerSupplies[1].Redundancy[0]
+
+	if redundancy.Mode != NMRedundancyMode {
+		t.Errorf("Invalid redundancy mode: %s", redundancy.Mode)
+	}
+
+	if len(redundancy.RedundancySet()) != 2 {
+		t.Errorf("Invalid redundancy set: %v", redundancy.RedundancySet())
+	}
+
+	if !redundancy.FaultTolerant() {
+		t.Error("Power supplies should be fault tolerant")
+	}
+}
@@ -0,0 +1,593 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerEquipmentType is the type of power distribution equipment.
+type PowerEquipmentType string
+
+const (
+	// RackPDUPowerEquipmentType A power distribution unit providing outlets
+	// for a rack or similar quantity of devices.
+	RackPDUPowerEquipmentType PowerEquipmentType = "RackPDU"
+	// FloorPDUPowerEquipmentType A power distribution unit providing feeder
+	// circuits for further power distribution.
+	FloorPDUPowerEquipmentType PowerEquipmentType = "FloorPDU"
+	// ManualTransferSwitchPowerEquipmentType A manual power transfer switch.
+	ManualTransferSwitchPowerEquipmentType PowerEquipmentType = "ManualTransferSwitch"
+	// AutomaticTransferSwitchPowerEquipmentType An automatic power transfer
+	// switch.
+	AutomaticTransferSwitchPowerEquipmentType PowerEquipmentType = "AutomaticTransferSwitch"
+	// SwitchgearPowerEquipmentType Electrical switchgear.
+	SwitchgearPowerEquipmentType PowerEquipmentType = "Switchgear"
+	// PowerShelfPowerEquipmentType A power shelf.
+	PowerShelfPowerEquipmentType PowerEquipmentType = "PowerShelf"
+	// BusPowerEquipmentType An electrical bus.
+	BusPowerEquipmentType PowerEquipmentType = "Bus"
+	// BatteryShelfPowerEquipmentType A battery shelf or battery-backed unit
+	// (BBU).
+	BatteryShelfPowerEquipmentType PowerEquipmentType = "BatteryShelf"
+)
+
+// TransferSensitivityType is the sensitivity used to detect a power failure
+// of the active mains.
+type TransferSensitivityType string
+
+const (
+	// HighTransferSensitivityType High sensitivity for initiating a
+	// transfer.
+	HighTransferSensitivityType TransferSensitivityType = "High"
+	// MediumTransferSensitivityType Medium sensitivity for initiating a
+	// transfer.
+	MediumTransferSensitivityType TransferSensitivityType = "Medium"
+	// LowTransferSensitivityType Low sensitivity for initiating a transfer.
+	LowTransferSensitivityType TransferSensitivityType = "Low"
+)
+
+// TransferConfiguration shall contain the configuration information
+// regarding an automatic transfer switch function for this resource.
+type TransferConfiguration struct {
+	// ActiveMainsID shall contain the mains circuit that is switched on and
+	// qualified to supply power to the output circuit.
+	ActiveMainsID string `json:"ActiveMainsId"`
+	// AutoTransferEnabled shall indicate if the qualified alternate mains
+	// circuit is automatically switched on when the preferred mains circuit
+	// becomes unqualified and is automatically switched off.
+	AutoTransferEnabled bool
+	// ClosedTransitionAllowed shall indicate if a make-before-break switching
+	// sequence of the mains circuits is permitted when they are both
+	// qualified and in synchronization.
+	ClosedTransitionAllowed bool
+	// ClosedTransitionTimeoutSeconds shall contain the time in seconds to
+	// wait for a closed transition to occur.
+	ClosedTransitionTimeoutSeconds int
+	// PreferredMainsID shall contain the preferred source for mains
+	// circuit to this equipment.
+	PreferredMainsID string `json:"PreferredMainsId"`
+	// RetransferDelaySeconds shall contain the time in seconds to delay the
+	// automatic transfer from the alternate mains circuit back to the
+	// preferred mains circuit.
+	RetransferDelaySeconds int
+	// RetransferEnabled shall indicate if the automatic transfer is
+	// permitted from the alternate mains circuit back to the preferred mains
+	// circuit after the preferred mains circuit is qualified again.
+	RetransferEnabled bool
+	// TransferDelaySeconds shall contain the time in seconds to delay the
+	// automatic transfer from the preferred mains circuit to the alternate
+	// mains circuit when the preferred mains circuit is disqualified.
+	TransferDelaySeconds int
+	// TransferInhibit shall indicate if any transfer is inhibited.
+	TransferInhibit bool
+}
+
+// TransferCriteria shall contain the criteria for initiating a transfer
+// within an automatic transfer switch.
+type TransferCriteria struct {
+	// OverNominalFrequencyHz shall contain the frequency in hertz over the
+	// nominal value that satisfies a criterion for transfer.
+	OverNominalFrequencyHz float32
+	// OverVoltageRMSPercentage shall contain the positive percentage of
+	// voltage RMS over the nominal value that satisfies a criterion for
+	// transfer.
+	OverVoltageRMSPercentage float32
+	// TransferSensitivity shall contain the setting that adjusts the
+	// analytical sensitivity of the detection of a quality loss event in the
+	// active mains circuit.
+	TransferSensitivity TransferSensitivityType
+	// UnderNominalFrequencyHz shall contain the frequency in hertz under the
+	// nominal value that satisfies a criterion for transfer.
+	UnderNominalFrequencyHz float32
+	// UnderVoltageRMSPercentage shall contain the negative percentage of
+	// voltage RMS under the nominal value that satisfies a criterion for
+	// transfer.
+	UnderVoltageRMSPercentage float32
+}
+
+// PowerDistribution shall be used to represent a power distribution
+// component or unit, such as a rack PDU, floor PDU or transfer switch, for a
+// Redfish implementation.
+type PowerDistribution struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag shall contain the user-assigned asset tag, which is an
+	// identifying string that tracks the equipment for inventory purposes.
+	AssetTag string
+	// Description provides a description of this resource.
+	Description string
+	// EquipmentType shall contain the type of equipment this resource
+	// represents.
+	EquipmentType PowerEquipmentType
+	// FirmwareVersion shall contain a string describing the firmware version
+	// of this equipment as provided by the manufacturer.
+	FirmwareVersion string
+	// Location shall contain location information of the associated
+	// equipment.
+	Location common.Location
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the equipment.
+	Manufacturer string
+	// Model shall contain the manufacturer-provided model information of
+	// this equipment.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// equipment.
+	PartNumber string
+	// ProductionDate shall contain the date of production or manufacture for
+	// this equipment.
+	ProductionDate string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the equipment.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TransferConfiguration shall contain the configuration information
+	// regarding an automatic transfer switch function for this resource.
+	TransferConfiguration TransferConfiguration
+	// TransferCriteria shall contain the criteria used to initiate a transfer
+	// between the mains circuits.
+	TransferCriteria TransferCriteria
+	// UUID shall contain the universal unique identifier number for this
+	// equipment.
+	UUID string
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Version shall contain the hardware version of this equipment as
+	// determined by the vendor or supplier.
+	Version string
+	// branches shall be a link to a resource collection of type
+	// CircuitCollection that contains the branch circuits.
+	branches string
+	// feeders shall be a link to a resource collection of type
+	// CircuitCollection that contains the feeder circuits.
+	feeders string
+	// mains shall be a link to a resource collection of type
+	// CircuitCollection that contains the power input circuits.
+	mains string
+	// subfeeds shall be a link to a resource collection of type
+	// CircuitCollection that contains the subfeed circuits.
+	subfeeds string
+	// metrics shall be a link to a resource of type PowerDistributionMetrics.
+	metrics string
+	// outlets shall be a link to a resource collection of type
+	// OutletCollection.
+	outlets string
+	// outletGroups shall be a link to a resource collection of type
+	// OutletGroupCollection.
+	outletGroups string
+	// powerSupplies shall be a link to a resource collection of type
+	// PowerSupplyCollection.
+	powerSupplies string
+	// chassis are the chassis that contain this equipment.
+	chassis []string
+	// facility is the facility that contains this equipment.
+	facility string
+	// managedBy are the managers that manage this equipment.
+	managedBy []string
+	// transferControlTarget is the URL to send TransferControl actions to.
+	transferControlTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powerdistribution *PowerDistribution) GetRawData() []byte {
+	return powerdistribution.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerDistribution object from the raw JSON.
+func (powerdistribution *PowerDistribution) UnmarshalJSON(b []byte) error {
+	type temp PowerDistribution
+	type Actions struct {
+		TransferControl struct {
+			Target string
+		} `json:"#PowerDistribution.TransferControl"`
+	}
+	var t struct {
+		temp
+		Actions       Actions
+		Branches      common.Link
+		Feeders       common.Link
+		Mains         common.Link
+		Metrics       common.Link
+		Outlets       common.Link
+		OutletGroups  common.Link
+		PowerSupplies common.Link
+		Subfeeds      common.Link
+		Links         struct {
+			Chassis   common.Links
+			Facility  common.Link
+			ManagedBy common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerdistribution = PowerDistribution(t.temp)
+
+	// Extract the links to other entities for later
+	powerdistribution.branches = string(t.Branches)
+	powerdistribution.feeders = string(t.Feeders)
+	powerdistribution.mains = string(t.Mains)
+	powerdistribution.subfeeds = string(t.Subfeeds)
+	powerdistribution.metrics = string(t.Metrics)
+	powerdistribution.outlets = string(t.Outlets)
+	powerdistribution.outletGroups = string(t.OutletGroups)
+	powerdistribution.powerSupplies = string(t.PowerSupplies)
+	powerdistribution.chassis = t.Links.Chassis.ToStrings()
+	powerdistribution.facility = string(t.Links.Facility)
+	powerdistribution.managedBy = t.Links.ManagedBy.ToStrings()
+	powerdistribution.transferControlTarget = t.Actions.TransferControl.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	powerdistribution.rawData = b
+	powerdistribution.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power distribution to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (powerdistribution PowerDistribution) MarshalJSON() ([]byte, error) {
+	type temp PowerDistribution
+	return common.MarshalWithRawData(powerdistribution.rawData, temp(powerdistribution))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (powerdistribution *PowerDistribution) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(PowerDistribution)
+	original.UnmarshalJSON(powerdistribution.rawData)
+
+	readWriteFields := []string{
+		"AssetTag",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(powerdistribution).Elem()
+
+	err := powerdistribution.Entity.Update(originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	payload := make(map[string]interface{})
+	configuration := changedFields(
+		reflect.ValueOf(original.TransferConfiguration),
+		reflect.ValueOf(powerdistribution.TransferConfiguration),
+		"AutoTransferEnabled",
+		"ClosedTransitionAllowed",
+		"ClosedTransitionTimeoutSeconds",
+		"PreferredMainsID",
+		"RetransferDelaySeconds",
+		"RetransferEnabled",
+		"TransferDelaySeconds",
+		"TransferInhibit")
+	if len(configuration) > 0 {
+		if id, ok := configuration["PreferredMainsID"]; ok {
+			delete(configuration, "PreferredMainsID")
+			configuration["PreferredMainsId"] = id
+		}
+		payload["TransferConfiguration"] = configuration
+	}
+
+	criteria := changedFields(
+		reflect.ValueOf(original.TransferCriteria),
+		reflect.ValueOf(powerdistribution.TransferCriteria))
+	if len(criteria) > 0 {
+		payload["TransferCriteria"] = criteria
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+
+	_, err = powerdistribution.Client.Patch(powerdistribution.ODataID, payload)
+	return err
+}
+
+// Branches gets the branch circuits of this equipment.
+func (powerdistribution *PowerDistribution) Branches() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.branches)
+}
+
+// Feeders gets the feeder circuits of this equipment.
+func (powerdistribution *PowerDistribution) Feeders() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.feeders)
+}
+
+// Mains gets the power input circuits of this equipment.
+func (powerdistribution *PowerDistribution) Mains() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.mains)
+}
+
+// Subfeeds gets the subfeed circuits of this equipment.
+func (powerdistribution *PowerDistribution) Subfeeds() ([]*Circuit, error) {
+	return ListReferencedCircuits(powerdistribution.Client, powerdistribution.subfeeds)
+}
+
+// Metrics gets the summary metrics of this equipment.
+func (powerdistribution *PowerDistribution) Metrics() (*PowerDistributionMetrics, error) {
+	if powerdistribution.metrics == "" {
+		return nil, nil
+	}
+
+	return GetPowerDistributionMetrics(powerdistribution.Client, powerdistribution.metrics)
+}
+
+// Outlets gets the outlets of this equipment.
+func (powerdistribution *PowerDistribution) Outlets() ([]*Outlet, error) {
+	return ListReferencedOutlets(powerdistribution.Client, powerdistribution.outlets)
+}
+
+// OutletGroups gets the outlet groups of this equipment.
+func (powerdistribution *PowerDistribution) OutletGroups() ([]*OutletGroup, error) {
+	return ListReferencedOutletGroups(powerdistribution.Client, powerdistribution.outletGroups)
+}
+
+// CreateOutletGroup creates a new outlet group containing the outlets at the
+// provided URIs, so they can be switched together.
+func (powerdistribution *PowerDistribution) CreateOutletGroup(name string, outlets []string) error {
+	if powerdistribution.outletGroups == "" {
+		return fmt.Errorf("this equipment does not support outlet groups")
+	}
+
+	type outlet struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		Name  string
+		Links struct {
+			Outlets []outlet
+		}
+	}
+	t := temp{
+		Name: name,
+	}
+	for _, uri := range outlets {
+		t.Links.Outlets = append(t.Links.Outlets, outlet{ODataID: uri})
+	}
+
+	_, err := powerdistribution.Client.Post(powerdistribution.outletGroups, t)
+	return err
+}
+
+// PowerSupplies gets the URI of the collection of power supplies of this
+// equipment.
+func (powerdistribution *PowerDistribution) PowerSupplies() string {
+	return powerdistribution.powerSupplies
+}
+
+// Chassis gets the chassis that contain this equipment.
+func (powerdistribution *PowerDistribution) Chassis() ([]*Chassis, error) {
+	var result []*Chassis
+	for _, chassisLink := range powerdistribution.chassis {
+		chassis, err := GetChassis(powerdistribution.Client, chassisLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, chassis)
+	}
+
+	return result, nil
+}
+
+// Facility gets the facility that contains this equipment.
+func (powerdistribution *PowerDistribution) Facility() (*Facility, error) {
+	if powerdistribution.facility == "" {
+		return nil, nil
+	}
+
+	return GetFacility(powerdistribution.Client, powerdistribution.facility)
+}
+
+// ManagedBy gets the managers that manage this equipment.
+func (powerdistribution *PowerDistribution) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+	for _, managerLink := range powerdistribution.managedBy {
+		manager, err := GetManager(powerdistribution.Client, managerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// TransferControl transfers power input from the existing mains circuit to
+// the alternative mains circuit.
+func (powerdistribution *PowerDistribution) TransferControl() error {
+	if powerdistribution.transferControlTarget == "" {
+		return fmt.Errorf("TransferControl is not supported by this equipment")
+	}
+
+	_, err := powerdistribution.Client.Post(powerdistribution.transferControlTarget, struct{}{})
+	return err
+}
+
+// GetPowerDistribution will get a PowerDistribution instance from the service.
+func GetPowerDistribution(c common.Client, uri string) (*PowerDistribution, error) {
+	var powerdistribution PowerDistribution
+	err := common.FetchResource(c, uri, &powerdistribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &powerdistribution, nil
+}
+
+// ListReferencedPowerDistributions gets the collection of PowerDistribution
+// from a provided reference.
+func ListReferencedPowerDistributions(c common.Client, link string) ([]*PowerDistribution, error) {
+	var result []*PowerDistribution
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, powerdistributionLink := range links.ItemLinks {
+		powerdistribution := new(PowerDistribution)
+		expanded, err := links.DecodeMember(c, powerdistributionLink, powerdistribution)
+		if err == nil && !expanded {
+			powerdistribution, err = GetPowerDistribution(c, powerdistributionLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, powerdistribution)
+	}
+
+	return result, nil
+}
+
+// getPowerDistributions gets the PowerDistribution instances of a list of
+// links.
+func getPowerDistributions(c common.Client, links []string) ([]*PowerDistribution, error) {
+	var result []*PowerDistribution
+	for _, powerdistributionLink := range links {
+		powerdistribution, err := GetPowerDistribution(c, powerdistributionLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, powerdistribution)
+	}
+
+	return result, nil
+}
+
+// PowerDistributionMetrics shall contain the summary metrics of a power
+// distribution component or unit.
+type PowerDistributionMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this unit that represents the Total ElectricalContext sensor when
+	// multiple energy sensors exist.
+	EnergykWh SensorEnergykWhExcerpt
+	// PowerLoadPercent shall contain the power load, in percent units, for
+	// this unit.
+	PowerLoadPercent SensorExcerpt
+	// PowerWatts shall contain the total power, in watt units, for this unit
+	// that represents the Total ElectricalContext sensor when multiple power
+	// sensors exist.
+	PowerWatts SensorPowerExcerpt
+	// resetMetricsTarget is the URL to send ResetMetrics actions to.
+	resetMetricsTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powerdistributionmetrics *PowerDistributionMetrics) GetRawData() []byte {
+	return powerdistributionmetrics.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerDistributionMetrics object from the raw JSON.
+func (powerdistributionmetrics *PowerDistributionMetrics) UnmarshalJSON(b []byte) error {
+	type temp PowerDistributionMetrics
+	type Actions struct {
+		ResetMetrics struct {
+			Target string
+		} `json:"#PowerDistributionMetrics.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerdistributionmetrics = PowerDistributionMetrics(t.temp)
+	powerdistributionmetrics.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	powerdistributionmetrics.rawData = b
+	powerdistributionmetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power distribution metrics to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (powerdistributionmetrics PowerDistributionMetrics) MarshalJSON() ([]byte, error) {
+	type temp PowerDistributionMetrics
+	return common.MarshalWithRawData(powerdistributionmetrics.rawData, temp(powerdistributionmetrics))
+}
+
+// ResetMetrics resets the summary metrics related to this equipment.
+func (powerdistributionmetrics *PowerDistributionMetrics) ResetMetrics() error {
+	if powerdistributionmetrics.resetMetricsTarget == "" {
+		return fmt.Errorf("ResetMetrics is not supported by this equipment")
+	}
+
+	_, err := powerdistributionmetrics.Client.Post(powerdistributionmetrics.resetMetricsTarget, struct{}{})
+	return err
+}
+
+// GetPowerDistributionMetrics will get a PowerDistributionMetrics instance
+// from the service.
+func GetPowerDistributionMetrics(c common.Client, uri string) (*PowerDistributionMetrics, error) {
+	var powerdistributionmetrics PowerDistributionMetrics
+	err := common.FetchResource(c, uri, &powerdistributionmetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &powerdistributionmetrics, nil
+}
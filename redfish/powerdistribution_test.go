@@ -0,0 +1,180 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var powerDistributionBody = `{
+		"@odata.type": "#PowerDistribution.v1_3_0.PowerDistribution",
+		"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1",
+		"Id": "1",
+		"EquipmentType": "AutomaticTransferSwitch",
+		"Name": "Transfer Switch",
+		"FirmwareVersion": "1.03b",
+		"Manufacturer": "Contoso",
+		"Model": "ZAP4000",
+		"SerialNumber": "29347ZT536",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"TransferConfiguration": {
+			"ActiveMainsId": "A",
+			"AutoTransferEnabled": true,
+			"PreferredMainsId": "A",
+			"RetransferDelaySeconds": 60,
+			"RetransferEnabled": true,
+			"TransferDelaySeconds": 2,
+			"TransferInhibit": false
+		},
+		"TransferCriteria": {
+			"TransferSensitivity": "Medium"
+		},
+		"Mains": {
+			"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1/Mains"
+		},
+		"Metrics": {
+			"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1/Metrics"
+		},
+		"Outlets": {
+			"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets"
+		},
+		"OutletGroups": {
+			"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches/1/OutletGroups"
+		},
+		"Links": {
+			"Chassis": [{
+				"@odata.id": "/redfish/v1/Chassis/TransferSwitch"
+			}],
+			"ManagedBy": [{
+				"@odata.id": "/redfish/v1/Managers/BMC"
+			}]
+		},
+		"Actions": {
+			"#PowerDistribution.TransferControl": {
+				"target": "/redfish/v1/PowerEquipment/TransferSwitches/1/PowerDistribution.TransferControl"
+			}
+		}
+	}`
+
+// TestPowerDistribution tests the parsing of PowerDistribution objects.
+func TestPowerDistribution(t *testing.T) {
+	var result PowerDistribution
+	err := json.NewDecoder(strings.NewReader(powerDistributionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.EquipmentType != AutomaticTransferSwitchPowerEquipmentType {
+		t.Errorf("Invalid equipment type: %s", result.EquipmentType)
+	}
+
+	if result.TransferConfiguration.ActiveMainsID != "A" {
+		t.Errorf("Invalid active mains: %s", result.TransferConfiguration.ActiveMainsID)
+	}
+
+	if result.TransferCriteria.TransferSensitivity != MediumTransferSensitivityType {
+		t.Errorf("Invalid transfer sensitivity: %s", result.TransferCriteria.TransferSensitivity)
+	}
+
+	if result.mains != "/redfish/v1/PowerEquipment/TransferSwitches/1/Mains" {
+		t.Errorf("Invalid mains link: %s", result.mains)
+	}
+
+	if result.metrics != "/redfish/v1/PowerEquipment/TransferSwitches/1/Metrics" {
+		t.Errorf("Invalid metrics link: %s", result.metrics)
+	}
+
+	if result.outlets != "/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets" {
+		t.Errorf("Invalid outlets link: %s", result.outlets)
+	}
+
+	if result.outletGroups != "/redfish/v1/PowerEquipment/TransferSwitches/1/OutletGroups" {
+		t.Errorf("Invalid outlet groups link: %s", result.outletGroups)
+	}
+
+	if len(result.managedBy) != 1 {
+		t.Errorf("Invalid managed by links: %v", result.managedBy)
+	}
+
+	if result.transferControlTarget != "/redfish/v1/PowerEquipment/TransferSwitches/1/PowerDistribution.TransferControl" {
+		t.Errorf("Invalid TransferControl target: %s", result.transferControlTarget)
+	}
+}
+
+// TestPowerDistributionUpdate tests the Update call.
+func TestPowerDistributionUpdate(t *testing.T) {
+	var result PowerDistribution
+	err := json.NewDecoder(strings.NewReader(powerDistributionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.AssetTag = "PDU-42"
+	result.TransferConfiguration.PreferredMainsID = "B"
+	result.TransferCriteria.TransferSensitivity = HighTransferSensitivityType
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "AssetTag:PDU-42") {
+		t.Errorf("Unexpected AssetTag update payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "map[TransferConfiguration:map[PreferredMainsId:B] TransferCriteria:map[TransferSensitivity:High]]" {
+		t.Errorf("Unexpected transfer update payload: %s", calls[1].Payload)
+	}
+}
+
+// TestPowerDistributionCreateOutletGroup tests the CreateOutletGroup call.
+func TestPowerDistributionCreateOutletGroup(t *testing.T) {
+	var result PowerDistribution
+	err := json.NewDecoder(strings.NewReader(powerDistributionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateOutletGroup("Storage", []string{
+		"/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets/A1",
+		"/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets/A2",
+	})
+
+	if err != nil {
+		t.Errorf("Error making CreateOutletGroup call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/PowerEquipment/TransferSwitches/1/OutletGroups" {
+		t.Errorf("Unexpected CreateOutletGroup URL: %s", calls[0].URL)
+	}
+
+	if calls[0].Payload != "{Storage {[{/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets/A1} {/redfish/v1/PowerEquipment/TransferSwitches/1/Outlets/A2}]}}" {
+		t.Errorf("Unexpected CreateOutletGroup payload: %s", calls[0].Payload)
+	}
+}
@@ -0,0 +1,178 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerDomain shall be used to represent a DCIM power domain for a Redfish
+// implementation, a grouping of the power distribution equipment that shares
+// a power source.
+type PowerDomain struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// electricalBuses are the electrical buses in this power domain.
+	electricalBuses []string
+	// floorPDUs are the floor power distribution units in this power domain.
+	floorPDUs []string
+	// managedBy are the managers that manage this power domain.
+	managedBy []string
+	// powerShelves are the power shelves in this power domain.
+	powerShelves []string
+	// rackPDUs are the rack power distribution units in this power domain.
+	rackPDUs []string
+	// switchgear are the switchgear in this power domain.
+	switchgear []string
+	// transferSwitches are the transfer switches in this power domain.
+	transferSwitches []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powerdomain *PowerDomain) GetRawData() []byte {
+	return powerdomain.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerDomain object from the raw JSON.
+func (powerdomain *PowerDomain) UnmarshalJSON(b []byte) error {
+	type temp PowerDomain
+	var t struct {
+		temp
+		Links struct {
+			ElectricalBuses  common.Links
+			FloorPDUs        common.Links
+			ManagedBy        common.Links
+			PowerShelves     common.Links
+			RackPDUs         common.Links
+			Switchgear       common.Links
+			TransferSwitches common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerdomain = PowerDomain(t.temp)
+
+	// Extract the links to other entities for later
+	powerdomain.electricalBuses = t.Links.ElectricalBuses.ToStrings()
+	powerdomain.floorPDUs = t.Links.FloorPDUs.ToStrings()
+	powerdomain.managedBy = t.Links.ManagedBy.ToStrings()
+	powerdomain.powerShelves = t.Links.PowerShelves.ToStrings()
+	powerdomain.rackPDUs = t.Links.RackPDUs.ToStrings()
+	powerdomain.switchgear = t.Links.Switchgear.ToStrings()
+	powerdomain.transferSwitches = t.Links.TransferSwitches.ToStrings()
+
+	powerdomain.rawData = b
+	powerdomain.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power domain to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (powerdomain PowerDomain) MarshalJSON() ([]byte, error) {
+	type temp PowerDomain
+	return common.MarshalWithRawData(powerdomain.rawData, temp(powerdomain))
+}
+
+// ElectricalBuses gets the electrical buses in this power domain.
+func (powerdomain *PowerDomain) ElectricalBuses() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.electricalBuses)
+}
+
+// FloorPDUs gets the floor power distribution units in this power domain.
+func (powerdomain *PowerDomain) FloorPDUs() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.floorPDUs)
+}
+
+// ManagedBy gets the managers that manage this power domain.
+func (powerdomain *PowerDomain) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+	for _, managerLink := range powerdomain.managedBy {
+		manager, err := GetManager(powerdomain.Client, managerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// PowerShelves gets the power shelves in this power domain.
+func (powerdomain *PowerDomain) PowerShelves() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.powerShelves)
+}
+
+// RackPDUs gets the rack power distribution units in this power domain.
+func (powerdomain *PowerDomain) RackPDUs() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.rackPDUs)
+}
+
+// Switchgear gets the switchgear in this power domain.
+func (powerdomain *PowerDomain) Switchgear() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.switchgear)
+}
+
+// TransferSwitches gets the transfer switches in this power domain.
+func (powerdomain *PowerDomain) TransferSwitches() ([]*PowerDistribution, error) {
+	return getPowerDistributions(powerdomain.Client, powerdomain.transferSwitches)
+}
+
+// GetPowerDomain will get a PowerDomain instance from the service.
+func GetPowerDomain(c common.Client, uri string) (*PowerDomain, error) {
+	var powerdomain PowerDomain
+	err := common.FetchResource(c, uri, &powerdomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &powerdomain, nil
+}
+
+// ListReferencedPowerDomains gets the collection of PowerDomain from
+// a provided reference.
+func ListReferencedPowerDomains(c common.Client, link string) ([]*PowerDomain, error) {
+	var result []*PowerDomain
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, powerdomainLink := range links.ItemLinks {
+		powerdomain := new(PowerDomain)
+		expanded, err := links.DecodeMember(c, powerdomainLink, powerdomain)
+		if err == nil && !expanded {
+			powerdomain, err = GetPowerDomain(c, powerdomainLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, powerdomain)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var powerDomainBody = `{
+		"@odata.type": "#PowerDomain.v1_2_0.PowerDomain",
+		"@odata.id": "/redfish/v1/Facilities/Room237/PowerDomains/Row1",
+		"Id": "Row1",
+		"Name": "Row #1 Domain",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"FloorPDUs": [{
+				"@odata.id": "/redfish/v1/PowerEquipment/FloorPDUs/1"
+			}],
+			"RackPDUs": [{
+				"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs/1"
+			}],
+			"ManagedBy": [{
+				"@odata.id": "/redfish/v1/Managers/BMC"
+			}]
+		}
+	}`
+
+// TestPowerDomain tests the parsing of PowerDomain objects.
+func TestPowerDomain(t *testing.T) {
+	var result PowerDomain
+	err := json.NewDecoder(strings.NewReader(powerDomainBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Row1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if len(result.floorPDUs) != 1 || result.floorPDUs[0] != "/redfish/v1/PowerEquipment/FloorPDUs/1" {
+		t.Errorf("Invalid floor PDU links: %v", result.floorPDUs)
+	}
+
+	if len(result.rackPDUs) != 1 {
+		t.Errorf("Invalid rack PDU links: %v", result.rackPDUs)
+	}
+
+	if len(result.managedBy) != 1 {
+		t.Errorf("Invalid managed by links: %v", result.managedBy)
+	}
+}
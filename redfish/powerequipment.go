@@ -0,0 +1,181 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerEquipment shall be used to represent the set of power equipment for
+// a Redfish implementation.
+type PowerEquipment struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// electricalBuses shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the electrical buses.
+	electricalBuses string
+	// floorPDUs shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the floor power
+	// distribution units.
+	floorPDUs string
+	// powerShelves shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the power shelves.
+	powerShelves string
+	// rackPDUs shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the rack power distribution
+	// units.
+	rackPDUs string
+	// switchgear shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the switchgear.
+	switchgear string
+	// transferSwitches shall be a link to a resource collection of type
+	// PowerDistributionCollection that contains the transfer switches.
+	transferSwitches string
+	// managedBy are the managers that manage this power equipment.
+	managedBy []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powerequipment *PowerEquipment) GetRawData() []byte {
+	return powerequipment.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerEquipment object from the raw JSON.
+func (powerequipment *PowerEquipment) UnmarshalJSON(b []byte) error {
+	type temp PowerEquipment
+	var t struct {
+		temp
+		ElectricalBuses  common.Link
+		FloorPDUs        common.Link
+		PowerShelves     common.Link
+		RackPDUs         common.Link
+		Switchgear       common.Link
+		TransferSwitches common.Link
+		Links            struct {
+			ManagedBy common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powerequipment = PowerEquipment(t.temp)
+
+	// Extract the links to other entities for later
+	powerequipment.electricalBuses = string(t.ElectricalBuses)
+	powerequipment.floorPDUs = string(t.FloorPDUs)
+	powerequipment.powerShelves = string(t.PowerShelves)
+	powerequipment.rackPDUs = string(t.RackPDUs)
+	powerequipment.switchgear = string(t.Switchgear)
+	powerequipment.transferSwitches = string(t.TransferSwitches)
+	powerequipment.managedBy = t.Links.ManagedBy.ToStrings()
+
+	powerequipment.rawData = b
+	powerequipment.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power equipment to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (powerequipment PowerEquipment) MarshalJSON() ([]byte, error) {
+	type temp PowerEquipment
+	return common.MarshalWithRawData(powerequipment.rawData, temp(powerequipment))
+}
+
+// ElectricalBuses gets the electrical buses.
+func (powerequipment *PowerEquipment) ElectricalBuses() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.electricalBuses)
+}
+
+// FloorPDUs gets the floor power distribution units.
+func (powerequipment *PowerEquipment) FloorPDUs() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.floorPDUs)
+}
+
+// PowerShelves gets the power shelves.
+func (powerequipment *PowerEquipment) PowerShelves() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.powerShelves)
+}
+
+// RackPDUs gets the rack power distribution units.
+func (powerequipment *PowerEquipment) RackPDUs() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.rackPDUs)
+}
+
+// Switchgear gets the switchgear.
+func (powerequipment *PowerEquipment) Switchgear() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.switchgear)
+}
+
+// TransferSwitches gets the transfer switches.
+func (powerequipment *PowerEquipment) TransferSwitches() ([]*PowerDistribution, error) {
+	return ListReferencedPowerDistributions(powerequipment.Client, powerequipment.transferSwitches)
+}
+
+// PowerDistributions gets all the power distribution equipment of every
+// kind, from the switchgear down to the rack PDUs and power shelves, so the
+// power chain can be walked from a single list.
+func (powerequipment *PowerEquipment) PowerDistributions() ([]*PowerDistribution, error) {
+	var result []*PowerDistribution
+	collections := []string{
+		powerequipment.switchgear,
+		powerequipment.transferSwitches,
+		powerequipment.electricalBuses,
+		powerequipment.floorPDUs,
+		powerequipment.rackPDUs,
+		powerequipment.powerShelves,
+	}
+	for _, collection := range collections {
+		powerdistributions, err := ListReferencedPowerDistributions(powerequipment.Client, collection)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, powerdistributions...)
+	}
+
+	return result, nil
+}
+
+// ManagedBy gets the managers that manage this power equipment.
+func (powerequipment *PowerEquipment) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+	for _, managerLink := range powerequipment.managedBy {
+		manager, err := GetManager(powerequipment.Client, managerLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, manager)
+	}
+
+	return result, nil
+}
+
+// GetPowerEquipment will get a PowerEquipment instance from the service.
+func GetPowerEquipment(c common.Client, uri string) (*PowerEquipment, error) {
+	var powerequipment PowerEquipment
+	err := common.FetchResource(c, uri, &powerequipment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &powerequipment, nil
+}
@@ -0,0 +1,89 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var powerEquipmentBody = `{
+		"@odata.type": "#PowerEquipment.v1_1_0.PowerEquipment",
+		"@odata.id": "/redfish/v1/PowerEquipment",
+		"Id": "PowerEquipment",
+		"Name": "DCIM Power Equipment",
+		"Status": {
+			"State": "Enabled",
+			"HealthRollup": "OK"
+		},
+		"FloorPDUs": {
+			"@odata.id": "/redfish/v1/PowerEquipment/FloorPDUs"
+		},
+		"RackPDUs": {
+			"@odata.id": "/redfish/v1/PowerEquipment/RackPDUs"
+		},
+		"TransferSwitches": {
+			"@odata.id": "/redfish/v1/PowerEquipment/TransferSwitches"
+		},
+		"Switchgear": {
+			"@odata.id": "/redfish/v1/PowerEquipment/Switchgear"
+		},
+		"ElectricalBuses": {
+			"@odata.id": "/redfish/v1/PowerEquipment/ElectricalBuses"
+		},
+		"PowerShelves": {
+			"@odata.id": "/redfish/v1/PowerEquipment/PowerShelves"
+		},
+		"Links": {
+			"ManagedBy": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC"
+				}
+			]
+		}
+	}`
+
+// TestPowerEquipment tests the parsing of PowerEquipment objects.
+func TestPowerEquipment(t *testing.T) {
+	var result PowerEquipment
+	err := json.NewDecoder(strings.NewReader(powerEquipmentBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerEquipment" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.floorPDUs != "/redfish/v1/PowerEquipment/FloorPDUs" {
+		t.Errorf("Invalid floor PDUs link: %s", result.floorPDUs)
+	}
+
+	if result.rackPDUs != "/redfish/v1/PowerEquipment/RackPDUs" {
+		t.Errorf("Invalid rack PDUs link: %s", result.rackPDUs)
+	}
+
+	if result.transferSwitches != "/redfish/v1/PowerEquipment/TransferSwitches" {
+		t.Errorf("Invalid transfer switches link: %s", result.transferSwitches)
+	}
+
+	if result.switchgear != "/redfish/v1/PowerEquipment/Switchgear" {
+		t.Errorf("Invalid switchgear link: %s", result.switchgear)
+	}
+
+	if result.electricalBuses != "/redfish/v1/PowerEquipment/ElectricalBuses" {
+		t.Errorf("Invalid electrical buses link: %s", result.electricalBuses)
+	}
+
+	if result.powerShelves != "/redfish/v1/PowerEquipment/PowerShelves" {
+		t.Errorf("Invalid power shelves link: %s", result.powerShelves)
+	}
+
+	if len(result.managedBy) != 1 {
+		t.Errorf("Invalid managed by links: %v", result.managedBy)
+	}
+}
@@ -0,0 +1,146 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerReadingSource is the resource a PowerReading was read from.
+type PowerReadingSource string
+
+const (
+	// EnvironmentMetricsPowerReadingSource is a reading from the
+	// EnvironmentMetrics of a chassis, with the PowerSubsystem for the
+	// capacity and the allocation.
+	EnvironmentMetricsPowerReadingSource PowerReadingSource = "EnvironmentMetrics"
+	// PowerPowerReadingSource is a reading from the deprecated Power resource
+	// of a chassis.
+	PowerPowerReadingSource PowerReadingSource = "Power"
+)
+
+// PowerReading is the power consumption of a chassis, whichever of the power
+// schemas its service implements. Values the service does not report are
+// zero.
+type PowerReading struct {
+	// Source is the resource the reading was read from.
+	Source PowerReadingSource
+	// ConsumedWatts is the power being consumed.
+	ConsumedWatts float32
+	// AverageConsumedWatts, MinConsumedWatts and MaxConsumedWatts are the
+	// statistics of the consumption over the last IntervalInMin minutes,
+	// which only the Power resource reports.
+	AverageConsumedWatts float32
+	MinConsumedWatts     float32
+	MaxConsumedWatts     float32
+	IntervalInMin        int
+	// EnergykWh is the energy consumed since the counter was last reset.
+	EnergykWh float32
+	// LimitWatts is the power limit, zero if none is set.
+	LimitWatts float32
+	// CapacityWatts is the power that the power supplies can provide.
+	CapacityWatts float32
+	// AllocatedWatts is the power allocated to the chassis.
+	AllocatedWatts float32
+}
+
+// PowerReading gets the power consumption of the chassis. The
+// EnvironmentMetrics and PowerSubsystem resources are used if the chassis
+// reports its consumption there, and the deprecated Power resource otherwise.
+// Services that implement EnvironmentMetrics without PowerWatts are read from
+// Power as well, if they have it.
+func (chassis *Chassis) PowerReading() (*PowerReading, error) {
+	metrics, err := chassis.EnvironmentMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	var reading *PowerReading
+	if metrics != nil {
+		reading = &PowerReading{
+			Source:        EnvironmentMetricsPowerReadingSource,
+			ConsumedWatts: metrics.PowerWatts.Reading,
+			EnergykWh:     metrics.EnergykWh.Reading,
+			LimitWatts:    metrics.PowerLimitWatts.SetPoint,
+		}
+		if reading.EnergykWh == 0 && metrics.EnergyJoules.Reading != 0 {
+			reading.EnergykWh = metrics.EnergyJoules.Reading / 3.6e6
+		}
+
+		subsystem, err := chassis.PowerSubsystem()
+		if err != nil {
+			return nil, err
+		}
+		if subsystem != nil {
+			reading.CapacityWatts = subsystem.CapacityWatts
+			reading.AllocatedWatts = subsystem.Allocation.AllocatedWatts
+		}
+
+		if reportsPowerWatts(metrics) {
+			return reading, nil
+		}
+	}
+
+	power, err := chassis.Power()
+	if err != nil {
+		return nil, err
+	}
+
+	if power == nil || len(power.PowerControl) == 0 {
+		if reading != nil {
+			return reading, nil
+		}
+		return nil, fmt.Errorf("chassis %s does not report its power consumption", chassis.ID)
+	}
+
+	// The first entry is the one for the whole chassis
+	control := power.PowerControl[0]
+
+	return &PowerReading{
+		Source:               PowerPowerReadingSource,
+		ConsumedWatts:        control.PowerConsumedWatts,
+		AverageConsumedWatts: control.PowerMetrics.AverageConsumedWatts,
+		MinConsumedWatts:     control.PowerMetrics.MinConsumedWatts,
+		MaxConsumedWatts:     control.PowerMetrics.MaxConsumedWatts,
+		IntervalInMin:        control.PowerMetrics.IntervalInMin,
+		LimitWatts:           control.PowerLimit.LimitInWatts,
+		CapacityWatts:        control.PowerCapacityWatts,
+		AllocatedWatts:       control.PowerAllocatedWatts,
+	}, nil
+}
+
+// reportsPowerWatts checks whether environment metrics have a PowerWatts
+// reading, which a zero value can not tell apart from a missing one.
+func reportsPowerWatts(metrics *EnvironmentMetrics) bool {
+	var t struct {
+		PowerWatts *struct {
+			Reading *common.Float32
+		}
+	}
+
+	err := json.Unmarshal(metrics.rawData, &t)
+	return err == nil && t.PowerWatts != nil && t.PowerWatts.Reading != nil
+}
+
+// PowerReading gets the power consumption of the system, read from the first
+// chassis containing the system that reports its consumption.
+func (computersystem *ComputerSystem) PowerReading() (*PowerReading, error) {
+	chassis, err := computersystem.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range chassis {
+		if c.environmentMetrics == "" && c.power == "" {
+			continue
+		}
+		return c.PowerReading()
+	}
+
+	return nil, fmt.Errorf("system %s is not in a chassis that reports its power consumption", computersystem.ID)
+}
@@ -0,0 +1,188 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// resourceClient is a test client that answers GET requests with the bodies
+// of a set of resources, keyed by URI.
+type resourceClient struct {
+	common.TestClient
+	resources map[string]string
+}
+
+// Get records the call and returns the body of the resource.
+func (c *resourceClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+
+	body, ok := c.resources[url]
+	if !ok {
+		return nil, fmt.Errorf("404: %s not found", url)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+// TestChassisPowerReadingEnvironmentMetrics tests reading the power of a
+// chassis implementing the EnvironmentMetrics and PowerSubsystem schemas.
+func TestChassisPowerReadingEnvironmentMetrics(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/EnvironmentMetrics": environmentMetricsBody,
+		"/redfish/v1/Chassis/1U/PowerSubsystem": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem",
+			"Id": "PowerSubsystem",
+			"CapacityWatts": 1600,
+			"Allocation": {
+				"AllocatedWatts": 800
+			}
+		}`,
+	}}
+
+	chassis := &Chassis{
+		environmentMetrics: "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+		powerSubsystem:     "/redfish/v1/Chassis/1U/PowerSubsystem",
+		power:              "/redfish/v1/Chassis/1U/Power",
+	}
+	chassis.SetClient(testClient)
+
+	reading, err := chassis.PowerReading()
+	if err != nil {
+		t.Fatalf("Error reading power: %s", err)
+	}
+
+	if reading.Source != EnvironmentMetricsPowerReadingSource {
+		t.Errorf("Invalid reading source: %s", reading.Source)
+	}
+
+	if reading.ConsumedWatts != 374.2 || reading.EnergykWh != 1261.2 || reading.LimitWatts != 500 {
+		t.Errorf("Invalid reading: %v", reading)
+	}
+
+	if reading.CapacityWatts != 1600 || reading.AllocatedWatts != 800 {
+		t.Errorf("Invalid capacity or allocation: %v", reading)
+	}
+}
+
+// TestChassisPowerReadingFallback tests reading the power of a chassis whose
+// EnvironmentMetrics do not report PowerWatts from its Power resource.
+func TestChassisPowerReadingFallback(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/EnvironmentMetrics": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+			"Id": "EnvironmentMetrics",
+			"TemperatureCelsius": {
+				"Reading": 26
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/Power": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power",
+			"Id": "Power",
+			"PowerControl": [
+				{
+					"MemberId": "0",
+					"PowerConsumedWatts": 344
+				}
+			]
+		}`,
+	}}
+
+	chassis := &Chassis{
+		environmentMetrics: "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+		power:              "/redfish/v1/Chassis/1U/Power",
+	}
+	chassis.SetClient(testClient)
+
+	reading, err := chassis.PowerReading()
+	if err != nil {
+		t.Fatalf("Error reading power: %s", err)
+	}
+
+	if reading.Source != PowerPowerReadingSource || reading.ConsumedWatts != 344 {
+		t.Errorf("Invalid reading: %v", reading)
+	}
+
+	chassis.power = ""
+	reading, err = chassis.PowerReading()
+	if err != nil {
+		t.Fatalf("Error reading power: %s", err)
+	}
+
+	if reading.Source != EnvironmentMetricsPowerReadingSource {
+		t.Errorf("Invalid reading source without Power: %s", reading.Source)
+	}
+}
+
+// TestComputerSystemPowerReading tests reading the power of a system whose
+// chassis only implements the Power schema.
+func TestComputerSystemPowerReading(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U": `{
+			"@odata.id": "/redfish/v1/Chassis/1U",
+			"Id": "1U",
+			"Power": {
+				"@odata.id": "/redfish/v1/Chassis/1U/Power"
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/Power": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/Power",
+			"Id": "Power",
+			"PowerControl": [
+				{
+					"MemberId": "0",
+					"PowerConsumedWatts": "344",
+					"PowerCapacityWatts": 800,
+					"PowerMetrics": {
+						"IntervalInMin": 30,
+						"MinConsumedWatts": 271,
+						"MaxConsumedWatts": 489,
+						"AverageConsumedWatts": 319
+					},
+					"PowerLimit": {
+						"LimitInWatts": 500
+					}
+				}
+			]
+		}`,
+	}}
+
+	system := &ComputerSystem{chassis: []string{"/redfish/v1/Chassis/1U"}}
+	system.ID = "1"
+	system.SetClient(testClient)
+
+	reading, err := system.PowerReading()
+	if err != nil {
+		t.Fatalf("Error reading power: %s", err)
+	}
+
+	if reading.Source != PowerPowerReadingSource {
+		t.Errorf("Invalid reading source: %s", reading.Source)
+	}
+
+	if reading.ConsumedWatts != 344 || reading.AverageConsumedWatts != 319 || reading.IntervalInMin != 30 {
+		t.Errorf("Invalid reading: %v", reading)
+	}
+
+	if reading.LimitWatts != 500 || reading.CapacityWatts != 800 {
+		t.Errorf("Invalid limit or capacity: %v", reading)
+	}
+
+	system.chassis = nil
+	_, err = system.PowerReading()
+	if err == nil {
+		t.Error("Reading the power of a system without chassis should fail")
+	}
+}
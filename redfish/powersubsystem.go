@@ -0,0 +1,113 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PowerAllocation shall contain the set of properties describing the
+// allocation of power for a subsystem.
+type PowerAllocation struct {
+	// AllocatedWatts shall contain the total amount of power, in watt units,
+	// that has been allocated or budgeted to this subsystem.
+	AllocatedWatts float32
+	// RequestedWatts shall contain the amount of power, in watt units, that
+	// the subsystem currently requests to be budgeted for future use.
+	RequestedWatts float32
+}
+
+// PowerSubsystem shall describe the power subsystem for a Redfish
+// implementation.
+type PowerSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Allocation shall contain the set of properties describing the
+	// allocation of power for this subsystem.
+	Allocation PowerAllocation
+	// batteries shall be a link to a resource collection of type
+	// BatteryCollection.
+	batteries string
+	// CapacityWatts shall represent the total power capacity that can be
+	// allocated to this subsystem.
+	CapacityWatts float32
+	// Description provides a description of this resource.
+	Description string
+	// powerSupplies shall be a link to a resource collection of type
+	// PowerSupplyCollection.
+	powerSupplies string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (powersubsystem *PowerSubsystem) GetRawData() []byte {
+	return powersubsystem.rawData
+}
+
+// UnmarshalJSON unmarshals a PowerSubsystem object from the raw JSON.
+func (powersubsystem *PowerSubsystem) UnmarshalJSON(b []byte) error {
+	type temp PowerSubsystem
+	var t struct {
+		temp
+		Batteries     common.Link
+		PowerSupplies common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*powersubsystem = PowerSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	powersubsystem.batteries = string(t.Batteries)
+	powersubsystem.powerSupplies = string(t.PowerSupplies)
+
+	powersubsystem.rawData = b
+	powersubsystem.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the power subsystem to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (powersubsystem PowerSubsystem) MarshalJSON() ([]byte, error) {
+	type temp PowerSubsystem
+	return common.MarshalWithRawData(powersubsystem.rawData, temp(powersubsystem))
+}
+
+// GetPowerSubsystem will get a PowerSubsystem instance from the service.
+func GetPowerSubsystem(c common.Client, uri string) (*PowerSubsystem, error) {
+	var powersubsystem PowerSubsystem
+	err := common.FetchResource(c, uri, &powersubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	return &powersubsystem, nil
+}
+
+// Batteries gets the batteries of this power subsystem.
+func (powersubsystem *PowerSubsystem) Batteries() ([]*Battery, error) {
+	return ListReferencedBatteries(powersubsystem.Client, powersubsystem.batteries)
+}
+
+// PowerSupplies gets the URI of the collection of power supplies of this
+// subsystem.
+func (powersubsystem *PowerSubsystem) PowerSupplies() string {
+	return powersubsystem.powerSupplies
+}
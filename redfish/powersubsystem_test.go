@@ -0,0 +1,56 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var powerSubsystemBody = `{
+		"@odata.type": "#PowerSubsystem.v1_1_0.PowerSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem",
+		"Id": "PowerSubsystem",
+		"Name": "Power Subsystem for Chassis",
+		"CapacityWatts": 2000,
+		"Allocation": {
+			"RequestedWatts": 1500,
+			"AllocatedWatts": 1200
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Batteries": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries"
+		}
+	}`
+
+// TestPowerSubsystem tests the parsing of PowerSubsystem objects.
+func TestPowerSubsystem(t *testing.T) {
+	var result PowerSubsystem
+	err := json.NewDecoder(strings.NewReader(powerSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerSubsystem" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CapacityWatts != 2000 {
+		t.Errorf("Invalid capacity: %f", result.CapacityWatts)
+	}
+
+	if result.Allocation.AllocatedWatts != 1200 {
+		t.Errorf("Invalid allocated watts: %f", result.Allocation.AllocatedWatts)
+	}
+
+	if result.batteries != "/redfish/v1/Chassis/1U/PowerSubsystem/Batteries" {
+		t.Errorf("Invalid batteries link: %s", result.batteries)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -396,6 +395,11 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 			PCIeFunctions            common.Links
 			PCIeFunctionsCount       int `json:"PCIeFunctions@odata.count"`
 		}
+
+		// Some implementations send these with the wrong JSON type
+		MaxSpeedMHz  common.Int
+		TotalCores   common.Int
+		TotalThreads common.Int
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -404,6 +408,9 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 	}
 
 	*processor = Processor(t.temp)
+	processor.MaxSpeedMHz = int(t.MaxSpeedMHz)
+	processor.TotalCores = int(t.TotalCores)
+	processor.TotalThreads = int(t.TotalThreads)
 
 	// Extract the links to other entities for later
 	processor.accelerationFunctions = string(t.AccelerationFunctions)
@@ -418,31 +425,27 @@ func (processor *Processor) UnmarshalJSON(b []byte) error {
 	processor.pcieFunctions = t.Links.PCIeFunctions.ToStrings()
 	processor.PCIeFunctionsCount = t.Links.PCIeFunctionsCount
 	processor.metrics = string(t.Metrics)
+	processor.rawData = b
+	processor.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the processor to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (processor Processor) MarshalJSON() ([]byte, error) {
+	type temp Processor
+	return common.MarshalWithRawData(processor.rawData, temp(processor))
+}
+
 // GetProcessor will get a Processor instance from the system
 func GetProcessor(c common.Client, uri string) (*Processor, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var processor Processor
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &processor)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &processor)
-	if err != nil {
-		return nil, err
-	}
-
-	processor.rawData = rawData
-	processor.SetClient(c)
 	return &processor, nil
 }
 
@@ -455,7 +458,11 @@ func ListReferencedProcessors(c common.Client, link string) ([]*Processor, error
 	}
 
 	for _, processorLink := range links.ItemLinks {
-		processor, err := GetProcessor(c, processorLink)
+		processor := new(Processor)
+		expanded, err := links.DecodeMember(c, processorLink, processor)
+		if err == nil && !expanded {
+			processor, err = GetProcessor(c, processorLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -465,6 +472,24 @@ func ListReferencedProcessors(c common.Client, link string) ([]*Processor, error
 	return result, nil
 }
 
+// Assembly gets the assembly for this processor.
+func (processor *Processor) Assembly() (*Assembly, error) {
+	if processor.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(processor.Client, processor.assembly)
+}
+
+// Metrics gets the metrics associated with this processor.
+func (processor *Processor) Metrics() (*ProcessorMetrics, error) {
+	if processor.metrics == "" {
+		return nil, nil
+	}
+
+	return GetProcessorMetrics(processor.Client, processor.metrics)
+}
+
 // ProcessorID shall contain identification information for a processor.
 type ProcessorID struct {
 	// EffectiveFamily shall indicate the effective Family
@@ -0,0 +1,129 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// CoreMetrics shall contain the processor core metrics.
+type CoreMetrics struct {
+	// CoreID shall contain the identifier of the core within the processor.
+	CoreID string `json:"CoreId"`
+	// CorrectableCoreErrorCount shall contain the number of correctable
+	// core errors, such as TLB or cache errors.
+	CorrectableCoreErrorCount int
+	// Description provides a description of this resource.
+	Description string
+	// UncorrectableCoreErrorCount shall contain the number of uncorrectable
+	// core errors, such as TLB or cache errors.
+	UncorrectableCoreErrorCount int
+}
+
+// ProcessorMetrics shall contain the processor metrics for a single
+// processor in a Redfish implementation, or the summary of all processors of
+// a system when linked from its ProcessorSummary.
+type ProcessorMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// BandwidthPercent shall contain the CPU bandwidth as a percentage.
+	BandwidthPercent float32
+	// ConsumedPowerWatt shall contain the power, in watts, that the
+	// processor has consumed.
+	ConsumedPowerWatt float32
+	// CoreMetrics shall contain the processor core metrics.
+	CoreMetrics []CoreMetrics
+	// CorrectableCoreErrorCount shall contain the number of correctable
+	// core errors.
+	CorrectableCoreErrorCount int
+	// CorrectableOtherErrorCount shall contain the number of correctable
+	// errors of all other components.
+	CorrectableOtherErrorCount int
+	// Description provides a description of this resource.
+	Description string
+	// FrequencyRatio shall contain the frequency relative to the nominal
+	// processor frequency ratio of this processor.
+	FrequencyRatio float32
+	// KernelPercent shall contain total percentage of time the processor has
+	// spent in kernel mode.
+	KernelPercent float32
+	// LocalMemoryBandwidthBytes shall contain the local memory bandwidth
+	// usage in bytes.
+	LocalMemoryBandwidthBytes int
+	// OperatingSpeedMHz shall contain the operating speed of the processor
+	// in MHz.
+	OperatingSpeedMHz int
+	// RemoteMemoryBandwidthBytes shall contain the remote memory bandwidth
+	// usage in bytes.
+	RemoteMemoryBandwidthBytes int
+	// TemperatureCelsius shall contain the temperature, in Celsius, of the
+	// processor.
+	TemperatureCelsius float32
+	// ThrottlingCelsius shall contain the CPU margin to throttle based on an
+	// offset between the maximum temperature in which the processor can
+	// operate, and the processor's current temperature.
+	ThrottlingCelsius float32
+	// UncorrectableCoreErrorCount shall contain the number of uncorrectable
+	// core errors.
+	UncorrectableCoreErrorCount int
+	// UncorrectableOtherErrorCount shall contain the number of uncorrectable
+	// errors of all other components.
+	UncorrectableOtherErrorCount int
+	// UserPercent shall contain total percentage of time the processor has
+	// spent in user mode.
+	UserPercent float32
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (processormetrics *ProcessorMetrics) GetRawData() []byte {
+	return processormetrics.rawData
+}
+
+// UnmarshalJSON unmarshals a ProcessorMetrics object from the raw JSON.
+func (processormetrics *ProcessorMetrics) UnmarshalJSON(b []byte) error {
+	type temp ProcessorMetrics
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*processormetrics = ProcessorMetrics(t.temp)
+	processormetrics.rawData = b
+	processormetrics.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the processor metrics to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (processormetrics ProcessorMetrics) MarshalJSON() ([]byte, error) {
+	type temp ProcessorMetrics
+	return common.MarshalWithRawData(processormetrics.rawData, temp(processormetrics))
+}
+
+// GetProcessorMetrics will get a ProcessorMetrics instance from the service.
+func GetProcessorMetrics(c common.Client, uri string) (*ProcessorMetrics, error) {
+	var processormetrics ProcessorMetrics
+	err := common.FetchResource(c, uri, &processormetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &processormetrics, nil
+}
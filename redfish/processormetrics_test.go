@@ -0,0 +1,55 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var processorMetricsBody = `{
+		"@odata.type": "#ProcessorMetrics.v1_0_0.ProcessorMetrics",
+		"@odata.id": "/redfish/v1/Systems/1/ProcessorSummary/ProcessorMetrics",
+		"Id": "Metrics",
+		"Name": "Processor Summary Metrics",
+		"BandwidthPercent": 62,
+		"OperatingSpeedMHz": 2400,
+		"TemperatureCelsius": 58,
+		"KernelPercent": 12,
+		"UserPercent": 40,
+		"CoreMetrics": [
+			{
+				"CoreId": "core0",
+				"CorrectableCoreErrorCount": 2
+			}
+		]
+	}`
+
+// TestProcessorMetrics tests the parsing of ProcessorMetrics objects.
+func TestProcessorMetrics(t *testing.T) {
+	var result ProcessorMetrics
+	err := json.NewDecoder(strings.NewReader(processorMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Metrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.BandwidthPercent != 62 {
+		t.Errorf("Invalid bandwidth percent: %f", result.BandwidthPercent)
+	}
+
+	if result.OperatingSpeedMHz != 2400 {
+		t.Errorf("Invalid operating speed: %d", result.OperatingSpeedMHz)
+	}
+
+	if len(result.CoreMetrics) != 1 || result.CoreMetrics[0].CorrectableCoreErrorCount != 2 {
+		t.Errorf("Invalid core metrics: %v", result.CoreMetrics)
+	}
+}
@@ -0,0 +1,214 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// PumpType is the type of a pump.
+type PumpType string
+
+const (
+	// LiquidPumpType A water or liquid pump.
+	LiquidPumpType PumpType = "Liquid"
+	// CompressorPumpType A compressor.
+	CompressorPumpType PumpType = "Compressor"
+)
+
+// SensorPumpExcerpt shall contain a pump speed sensor reading excerpt.
+type SensorPumpExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+	// SpeedRPM shall contain a reading of the rotational speed of the
+	// device in revolutions per minute (RPM) units.
+	SpeedRPM float32
+}
+
+// Pump shall describe a pump unit for a cooling loop or similar device for a
+// Redfish implementation.
+type Pump struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FirmwareVersion shall contain a string describing the firmware version
+	// of this pump as provided by the manufacturer.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain the location information of this pump.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the pump.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this pump.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this pump.
+	PartNumber string
+	// ProductionDate shall contain the date of production or manufacture for
+	// this pump.
+	ProductionDate string
+	// PumpSpeedPercent shall contain the current speed, in percent units,
+	// of this pump.
+	PumpSpeedPercent SensorPumpExcerpt
+	// PumpType shall contain the type of pump represented by this resource.
+	PumpType PumpType
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this pump.
+	SerialNumber string
+	// ServiceHours shall contain the number of hours of service that the
+	// pump has provided.
+	ServiceHours float32
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this pump.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// Version shall contain the hardware version of this pump as determined
+	// by the vendor or supplier.
+	Version string
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// filters shall be a link to a resource collection of type
+	// FilterCollection.
+	filters string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (pump *Pump) GetRawData() []byte {
+	return pump.rawData
+}
+
+// UnmarshalJSON unmarshals a Pump object from the raw JSON.
+func (pump *Pump) UnmarshalJSON(b []byte) error {
+	type temp Pump
+	var t struct {
+		temp
+		Assembly common.Link
+		Filters  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*pump = Pump(t.temp)
+
+	// Extract the links to other entities for later
+	pump.assembly = string(t.Assembly)
+	pump.filters = string(t.Filters)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	pump.rawData = b
+	pump.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the pump to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (pump Pump) MarshalJSON() ([]byte, error) {
+	type temp Pump
+	return common.MarshalWithRawData(pump.rawData, temp(pump))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (pump *Pump) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Pump)
+	original.UnmarshalJSON(pump.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+		"ServiceHours",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(pump).Elem()
+
+	return pump.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Assembly gets the assembly for this pump.
+func (pump *Pump) Assembly() (*Assembly, error) {
+	if pump.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(pump.Client, pump.assembly)
+}
+
+// Filters gets the filters of this pump.
+func (pump *Pump) Filters() ([]*Filter, error) {
+	return ListReferencedFilters(pump.Client, pump.filters)
+}
+
+// GetPump will get a Pump instance from the service.
+func GetPump(c common.Client, uri string) (*Pump, error) {
+	var pump Pump
+	err := common.FetchResource(c, uri, &pump)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pump, nil
+}
+
+// ListReferencedPumps gets the collection of Pump from
+// a provided reference.
+func ListReferencedPumps(c common.Client, link string) ([]*Pump, error) {
+	var result []*Pump
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pumpLink := range links.ItemLinks {
+		pump := new(Pump)
+		expanded, err := links.DecodeMember(c, pumpLink, pump)
+		if err == nil && !expanded {
+			pump, err = GetPump(c, pumpLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, pump)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var pumpBody = `{
+		"@odata.type": "#Pump.v1_0_0.Pump",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Pumps/1",
+		"Id": "1",
+		"Name": "Pump 1",
+		"PumpType": "Liquid",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"ServiceHours": 3525,
+		"PumpSpeedPercent": {
+			"Reading": 62,
+			"SpeedRPM": 1800
+		},
+		"Filters": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Pumps/1/Filters"
+		}
+	}`
+
+// TestPump tests the parsing of Pump objects.
+func TestPump(t *testing.T) {
+	var result Pump
+	err := json.NewDecoder(strings.NewReader(pumpBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.PumpType != LiquidPumpType {
+		t.Errorf("Invalid pump type: %s", result.PumpType)
+	}
+
+	if result.PumpSpeedPercent.SpeedRPM != 1800 {
+		t.Errorf("Invalid pump speed: %f", result.PumpSpeedPercent.SpeedRPM)
+	}
+
+	if result.filters != "/redfish/v1/ThermalEquipment/CDUs/1/Pumps/1/Filters" {
+		t.Errorf("Invalid filters link: %s", result.filters)
+	}
+}
+
+// TestPumpUpdate tests the Update call.
+func TestPumpUpdate(t *testing.T) {
+	var result Pump
+	err := json.NewDecoder(strings.NewReader(pumpBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.ServiceHours = 0
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "ServiceHours:0") {
+		t.Errorf("Unexpected ServiceHours update payload: %s", calls[0].Payload)
+	}
+}
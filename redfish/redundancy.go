@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -94,10 +93,18 @@ func (redundancy *Redundancy) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	redundancy.rawData = b
+	redundancy.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the redundancy to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (redundancy Redundancy) MarshalJSON() ([]byte, error) {
+	type temp Redundancy
+	return common.MarshalWithRawData(redundancy.rawData, temp(redundancy))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (redundancy *Redundancy) Update() error {
 
@@ -117,27 +124,62 @@ func (redundancy *Redundancy) Update() error {
 	return redundancy.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
-// GetRedundancy will get a Redundancy instance from the service.
-func GetRedundancy(c common.Client, uri string) (*Redundancy, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// RedundancySet gets the identifiers of the components that are part of this
+// redundancy set.
+func (redundancy *Redundancy) RedundancySet() []string {
+	return redundancy.redundancySet
+}
+
+// FaultTolerant reports whether the redundancy group can lose a member and
+// still operate in its redundancy mode, such as a healthy N+1 group of power
+// supplies. Groups that do not report their members are not considered
+// fault tolerant.
+func (redundancy *Redundancy) FaultTolerant() bool {
+	if redundancy.Mode == "" || redundancy.Mode == NotRedundantRedundancyMode {
+		return false
 	}
-	defer resp.Body.Close()
 
-	var redundancy Redundancy
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if redundancy.Status.State != "" && redundancy.Status.State != common.EnabledState {
+		return false
+	}
+
+	if redundancy.Status.Health != "" && redundancy.Status.Health != common.OKHealth {
+		return false
 	}
 
-	err = json.Unmarshal(rawData, &redundancy)
+	members := len(redundancy.redundancySet)
+	if members == 0 {
+		members = redundancy.RedundancySetCount
+	}
+
+	return members > redundancy.MinNumNeeded
+}
+
+// resolveRedundancies replaces the references to redundancy groups, as used
+// by power supplies and fans, by the groups they reference.
+func resolveRedundancies(references []Redundancy, groups []Redundancy) {
+	for i := range references {
+		if references[i].Mode != "" {
+			continue
+		}
+
+		for j := range groups {
+			if groups[j].ODataID != "" && groups[j].ODataID == references[i].ODataID {
+				references[i] = groups[j]
+				break
+			}
+		}
+	}
+}
+
+// GetRedundancy will get a Redundancy instance from the service.
+func GetRedundancy(c common.Client, uri string) (*Redundancy, error) {
+	var redundancy Redundancy
+	err := common.FetchResource(c, uri, &redundancy)
 	if err != nil {
 		return nil, err
 	}
 
-	redundancy.rawData = rawData
-	redundancy.SetClient(c)
 	return &redundancy, nil
 }
 
@@ -155,7 +197,11 @@ func ListReferencedRedundancies(c common.Client, link string) ([]*Redundancy, er
 	}
 
 	for _, redundancyLink := range links.ItemLinks {
-		redundancy, err := GetRedundancy(c, redundancyLink)
+		redundancy := new(Redundancy)
+		expanded, err := links.DecodeMember(c, redundancyLink, redundancy)
+		if err == nil && !expanded {
+			redundancy, err = GetRedundancy(c, redundancyLink)
+		}
 		if err != nil {
 			return result, err
 		}
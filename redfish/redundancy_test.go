@@ -88,3 +88,27 @@ func TestRedundancyUpdate(t *testing.T) {
 		t.Errorf("Unexpected update for RedundancyEnabled in payload: %s", calls[0].Payload)
 	}
 }
+
+// TestRedundancyFaultTolerant tests the evaluation of fault tolerance.
+func TestRedundancyFaultTolerant(t *testing.T) {
+	var result Redundancy
+	err := json.NewDecoder(strings.NewReader(redundancyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.FaultTolerant() {
+		t.Error("Group without spare members should not be fault tolerant")
+	}
+
+	result.MinNumNeeded = 1
+	if !result.FaultTolerant() {
+		t.Error("N+1 group should be fault tolerant")
+	}
+
+	result.Status.Health = common.WarningHealth
+	if result.FaultTolerant() {
+		t.Error("Unhealthy group should not be fault tolerant")
+	}
+}
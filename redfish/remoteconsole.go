@@ -0,0 +1,164 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// dellKVMSessionType is the session type iDRAC creates KVM sessions for
+// with the GetKVMSession action.
+const dellKVMSessionType = "ssl_cert.txt"
+
+// RemoteConsoleOptions controls Manager.RemoteConsole.
+type RemoteConsoleOptions struct {
+	// Endpoint is the URL of the service, such as "https://bmc1.example.com",
+	// which the console URLs of iDRAC, iLO and XCC are made from.
+	Endpoint string
+	// Username is the user name of the client, which iDRAC opens the
+	// console for.
+	Username string
+	// SessionToken is the X-Auth-Token of the session of the client, which
+	// iLO takes as the session key of the console.
+	SessionToken string
+}
+
+// RemoteConsole is a graphical remote console of a manager, which a user
+// opens in a browser.
+type RemoteConsole struct {
+	// URL is the URL to open the console with.
+	URL string
+	// Username and Password are the one-time credentials of the console,
+	// also passed in URL, if the service issues them.
+	Username string
+	Password string
+	// Vendor is the vendor whose OEM endpoint provided the console, or empty
+	// if the manager advertises its console URL.
+	Vendor string
+}
+
+// RemoteConsole gets a URL to open the graphical remote console (KVM) of the
+// manager with, such as for an "open console" button.
+//
+// Redfish reports the graphical console in GraphicalConsole, but not how it
+// is opened, so the console is opened through the OEM endpoints of iDRAC,
+// which issues one-time credentials, iLO, which takes the session of the
+// client, and XCC, which opens it in its own web session. The URLs the
+// manager advertises, see ConsoleURLs, are used otherwise.
+func (manager *Manager) RemoteConsole(options RemoteConsoleOptions) (*RemoteConsole, error) {
+	if !manager.GraphicalConsole.ServiceEnabled && len(manager.GraphicalConsole.ConnectTypesSupported) > 0 {
+		return nil, fmt.Errorf("the graphical console of manager %s is disabled", manager.ID)
+	}
+
+	var t struct {
+		Oem struct {
+			Hpe    json.RawMessage
+			Hp     json.RawMessage
+			Lenovo json.RawMessage
+		}
+		Links struct {
+			Oem struct {
+				Dell struct {
+					DelliDRACCardService common.Link
+				}
+			}
+		}
+	}
+	if len(manager.rawData) > 0 {
+		err := json.Unmarshal(manager.rawData, &t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := strings.TrimSuffix(options.Endpoint, "/")
+	switch {
+	case t.Links.Oem.Dell.DelliDRACCardService != "":
+		return manager.dellRemoteConsole(string(t.Links.Oem.Dell.DelliDRACCardService), endpoint, options)
+	case len(t.Oem.Hpe) > 0 || len(t.Oem.Hp) > 0:
+		if endpoint == "" || options.SessionToken == "" {
+			return nil, fmt.Errorf("the endpoint and session token are required to open the iLO console")
+		}
+		return &RemoteConsole{
+			URL:    endpoint + "/irc.html?sessionKey=" + url.QueryEscape(options.SessionToken),
+			Vendor: "Hpe",
+		}, nil
+	case len(t.Oem.Lenovo) > 0 && endpoint != "":
+		return &RemoteConsole{
+			URL:    endpoint + "/#/remoteControl",
+			Vendor: "Lenovo",
+		}, nil
+	}
+
+	urls := manager.ConsoleURLs()
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("manager %s does not advertise how to open its graphical console", manager.ID)
+	}
+
+	return &RemoteConsole{URL: urls[0]}, nil
+}
+
+// dellRemoteConsole gets a KVM session from the iDRAC card service.
+func (manager *Manager) dellRemoteConsole(cardService, endpoint string, options RemoteConsoleOptions) (*RemoteConsole, error) {
+	if endpoint == "" || options.Username == "" {
+		return nil, fmt.Errorf("the endpoint and user name are required to open the iDRAC console")
+	}
+
+	resp, err := manager.Client.Get(cardService)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var service struct {
+		Actions struct {
+			GetKVMSession struct {
+				Target string
+			} `json:"#DelliDRACCardService.GetKVMSession"`
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&service)
+	if err != nil {
+		return nil, err
+	}
+	if service.Actions.GetKVMSession.Target == "" {
+		return nil, fmt.Errorf("this iDRAC does not issue KVM sessions")
+	}
+
+	type temp struct {
+		SessionTypeName string
+	}
+	resp, err = manager.Client.Post(service.Actions.GetKVMSession.Target, temp{SessionTypeName: dellKVMSessionType})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var session struct {
+		TempUsername string
+		TempPassword string
+	}
+	err = json.NewDecoder(resp.Body).Decode(&session)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("username", options.Username)
+	query.Set("tempUsername", session.TempUsername)
+	query.Set("tempPassword", session.TempPassword)
+
+	return &RemoteConsole{
+		URL:      endpoint + "/console?" + query.Encode(),
+		Username: session.TempUsername,
+		Password: session.TempPassword,
+		Vendor:   "Dell",
+	}, nil
+}
@@ -0,0 +1,202 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ReservoirType is the type of a reservoir.
+type ReservoirType string
+
+const (
+	// ReserveReservoirType A reservoir providing reserve fluid capacity.
+	ReserveReservoirType ReservoirType = "Reserve"
+	// OverflowReservoirType An overflow reservoir for excess fluid.
+	OverflowReservoirType ReservoirType = "Overflow"
+	// InlineReservoirType An inline or integrated reservoir.
+	InlineReservoirType ReservoirType = "Inline"
+	// ImmersionReservoirType An immersion cooling tank.
+	ImmersionReservoirType ReservoirType = "Immersion"
+)
+
+// Reservoir shall describe a reservoir unit for a cooling system or similar
+// device for a Redfish implementation.
+type Reservoir struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CapacityLiters shall contain the capacity of the reservoir in liter
+	// units.
+	CapacityLiters float32
+	// Coolant shall contain details regarding the coolant contained or used
+	// by this reservoir.
+	Coolant Coolant
+	// Description provides a description of this resource.
+	Description string
+	// FluidLevelPercent shall contain the amount of fluid capacity, in
+	// percent units, filled in this reservoir.
+	FluidLevelPercent SensorExcerpt
+	// FluidLevelStatus shall indicate the status of the fluid level in this
+	// reservoir.
+	FluidLevelStatus common.Health
+	// InternalPressurekPa shall contain the internal pressure, in kilopascal
+	// units, as measured in this reservoir.
+	InternalPressurekPa SensorExcerpt
+	// Location shall contain the location information of this reservoir.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the reservoir.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this reservoir.
+	Model string
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this reservoir.
+	PartNumber string
+	// ReservoirType shall contain the type of reservoir represented by this
+	// resource.
+	ReservoirType ReservoirType
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this reservoir.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this reservoir.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// UserLabel shall contain a user-assigned label used to identify this
+	// resource.
+	UserLabel string
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// filters shall be a link to a resource collection of type
+	// FilterCollection.
+	filters string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (reservoir *Reservoir) GetRawData() []byte {
+	return reservoir.rawData
+}
+
+// UnmarshalJSON unmarshals a Reservoir object from the raw JSON.
+func (reservoir *Reservoir) UnmarshalJSON(b []byte) error {
+	type temp Reservoir
+	var t struct {
+		temp
+		Assembly common.Link
+		Filters  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*reservoir = Reservoir(t.temp)
+
+	// Extract the links to other entities for later
+	reservoir.assembly = string(t.Assembly)
+	reservoir.filters = string(t.Filters)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	reservoir.rawData = b
+	reservoir.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the reservoir to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (reservoir Reservoir) MarshalJSON() ([]byte, error) {
+	type temp Reservoir
+	return common.MarshalWithRawData(reservoir.rawData, temp(reservoir))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (reservoir *Reservoir) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Reservoir)
+	original.UnmarshalJSON(reservoir.rawData)
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+		"UserLabel",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(reservoir).Elem()
+
+	return reservoir.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Assembly gets the assembly for this reservoir.
+func (reservoir *Reservoir) Assembly() (*Assembly, error) {
+	if reservoir.assembly == "" {
+		return nil, nil
+	}
+
+	return GetAssembly(reservoir.Client, reservoir.assembly)
+}
+
+// Filters gets the filters of this reservoir.
+func (reservoir *Reservoir) Filters() ([]*Filter, error) {
+	return ListReferencedFilters(reservoir.Client, reservoir.filters)
+}
+
+// GetReservoir will get a Reservoir instance from the service.
+func GetReservoir(c common.Client, uri string) (*Reservoir, error) {
+	var reservoir Reservoir
+	err := common.FetchResource(c, uri, &reservoir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservoir, nil
+}
+
+// ListReferencedReservoirs gets the collection of Reservoir from
+// a provided reference.
+func ListReferencedReservoirs(c common.Client, link string) ([]*Reservoir, error) {
+	var result []*Reservoir
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, reservoirLink := range links.ItemLinks {
+		reservoir := new(Reservoir)
+		expanded, err := links.DecodeMember(c, reservoirLink, reservoir)
+		if err == nil && !expanded {
+			reservoir, err = GetReservoir(c, reservoirLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, reservoir)
+	}
+
+	return result, nil
+}
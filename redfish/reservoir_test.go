@@ -0,0 +1,56 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var reservoirBody = `{
+		"@odata.type": "#Reservoir.v1_0_0.Reservoir",
+		"@odata.id": "/redfish/v1/ThermalEquipment/CDUs/1/Reservoirs/1",
+		"Id": "1",
+		"Name": "Cooling Loop Reservoir",
+		"ReservoirType": "Inline",
+		"CapacityLiters": 10,
+		"FluidLevelStatus": "OK",
+		"FluidLevelPercent": {
+			"Reading": 64.5
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestReservoir tests the parsing of Reservoir objects.
+func TestReservoir(t *testing.T) {
+	var result Reservoir
+	err := json.NewDecoder(strings.NewReader(reservoirBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ReservoirType != InlineReservoirType {
+		t.Errorf("Invalid reservoir type: %s", result.ReservoirType)
+	}
+
+	if result.FluidLevelStatus != common.OKHealth {
+		t.Errorf("Invalid fluid level status: %s", result.FluidLevelStatus)
+	}
+
+	if result.FluidLevelPercent.Reading != 64.5 {
+		t.Errorf("Invalid fluid level: %f", result.FluidLevelPercent.Reading)
+	}
+}
@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -88,10 +87,18 @@ func (role *Role) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	role.rawData = b
+	role.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the role to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (role Role) MarshalJSON() ([]byte, error) {
+	type temp Role
+	return common.MarshalWithRawData(role.rawData, temp(role))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (role *Role) Update() error {
 
@@ -113,25 +120,12 @@ func (role *Role) Update() error {
 
 // GetRole will get a Role instance from the service.
 func GetRole(c common.Client, uri string) (*Role, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var role Role
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &role)
+	err := common.FetchResource(c, uri, &role)
 	if err != nil {
 		return nil, err
 	}
 
-	role.rawData = rawData
-	role.SetClient(c)
 	return &role, nil
 }
 
@@ -149,7 +143,11 @@ func ListReferencedRoles(c common.Client, link string) ([]*Role, error) {
 	}
 
 	for _, roleLink := range links.ItemLinks {
-		role, err := GetRole(c, roleLink)
+		role := new(Role)
+		expanded, err := links.DecodeMember(c, roleLink, role)
+		if err == nil && !expanded {
+			role, err = GetRole(c, roleLink)
+		}
 		if err != nil {
 			return result, err
 		}
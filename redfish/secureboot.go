@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -110,10 +109,18 @@ func (secureboot *SecureBoot) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	secureboot.rawData = b
+	secureboot.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the secure boot to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (secureboot SecureBoot) MarshalJSON() ([]byte, error) {
+	type temp SecureBoot
+	return common.MarshalWithRawData(secureboot.rawData, temp(secureboot))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (secureboot *SecureBoot) Update() error {
 
@@ -134,25 +141,12 @@ func (secureboot *SecureBoot) Update() error {
 
 // GetSecureBoot will get a SecureBoot instance from the service.
 func GetSecureBoot(c common.Client, uri string) (*SecureBoot, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var secureBoot SecureBoot
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &secureBoot)
+	err := common.FetchResource(c, uri, &secureBoot)
 	if err != nil {
 		return nil, err
 	}
 
-	secureBoot.rawData = rawData
-	secureBoot.SetClient(c)
 	return &secureBoot, nil
 }
 
@@ -170,7 +164,11 @@ func ListReferencedSecureBoots(c common.Client, link string) ([]*SecureBoot, err
 	}
 
 	for _, securebootLink := range links.ItemLinks {
-		secureboot, err := GetSecureBoot(c, securebootLink)
+		secureboot := new(SecureBoot)
+		expanded, err := links.DecodeMember(c, securebootLink, secureboot)
+		if err == nil && !expanded {
+			secureboot, err = GetSecureBoot(c, securebootLink)
+		}
 		if err != nil {
 			return result, err
 		}
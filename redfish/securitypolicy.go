@@ -0,0 +1,314 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SPDMAlgorithmSet shall contain SPDM algorithm settings.
+type SPDMAlgorithmSet struct {
+	// AEAD shall contain the AEAD algorithms. The allowable values for this
+	// property shall be the AEAD algorithm names found in the 'AlgorithmSelect'
+	// field of the 'KEY_EXCHANGE' request message in DSP0274 and all
+	// capabilities, or 'ALL' to indicate all AEAD algorithms.
+	AEAD []string
+	// BaseAsym shall contain the asymmetric signature algorithms. The
+	// allowable values for this property shall be the asymmetric algorithm
+	// names found in the 'BaseAsymAlgo' field of the 'NEGOTIATE_ALGORITHMS'
+	// request message in DSP0274, or 'ALL' to indicate all asymmetric
+	// signature algorithms.
+	BaseAsym []string
+	// BaseHash shall contain the hash algorithms. The allowable values for
+	// this property shall be the hash algorithm names found in the
+	// 'BaseHashAlgo' field of the 'NEGOTIATE_ALGORITHMS' request message in
+	// DSP0274, or 'ALL' to indicate all hash algorithms.
+	BaseHash []string
+}
+
+// SPDMParameterSet shall contain SPDM policy settings.
+type SPDMParameterSet struct {
+	// Algorithms shall contain the SPDM algorithms.
+	Algorithms SPDMAlgorithmSet
+	// Versions shall contain the SPDM versions, such as '1.1', or 'ALL' to
+	// indicate all versions.
+	Versions []string
+}
+
+// SPDMPolicy shall contain SPDM policy settings for the manager acting as
+// an SPDM requester, which it uses to authenticate the devices it manages.
+type SPDMPolicy struct {
+	// AllowExtendedAlgorithms shall indicate whether the manager is allowed
+	// to use extended algorithms.
+	AllowExtendedAlgorithms bool
+	// Allowed shall contain the SPDM policy settings that are allowed, such
+	// as the allowable SPDM versions and algorithms.
+	Allowed SPDMParameterSet
+	// Denied shall contain the SPDM policy settings that are prohibited,
+	// such as the prohibited SPDM versions and algorithms.
+	Denied SPDMParameterSet
+	// Enabled shall indicate whether SPDM communication with devices as
+	// defined in DSP0274 is enabled.
+	Enabled bool
+	// SecureSessionEnabled shall indicate whether SPDM secure sessions with
+	// devices as defined in DSP0274 are enabled.
+	SecureSessionEnabled bool
+	// VerifyCertificate shall indicate whether the manager will verify the
+	// certificate of the SPDM endpoint.
+	VerifyCertificate bool
+	// revokedCertificates shall contain a link to a resource collection of
+	// type CertificateCollection.
+	revokedCertificates string
+	// trustedCertificates shall contain a link to a resource collection of
+	// type CertificateCollection.
+	trustedCertificates string
+}
+
+// UnmarshalJSON unmarshals a SPDMPolicy object from the raw JSON.
+func (spdm *SPDMPolicy) UnmarshalJSON(b []byte) error {
+	type temp SPDMPolicy
+	var t struct {
+		temp
+		RevokedCertificates common.Link
+		TrustedCertificates common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*spdm = SPDMPolicy(t.temp)
+	spdm.revokedCertificates = string(t.RevokedCertificates)
+	spdm.trustedCertificates = string(t.TrustedCertificates)
+
+	return nil
+}
+
+// RevokedCertificates gets the certificates that the manager refuses when it
+// authenticates SPDM endpoints.
+func (spdm *SPDMPolicy) RevokedCertificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, spdm.revokedCertificates)
+}
+
+// TrustedCertificates gets the certificates that the manager trusts when it
+// authenticates SPDM endpoints.
+func (spdm *SPDMPolicy) TrustedCertificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, spdm.trustedCertificates)
+}
+
+// TLSParameterSet shall contain TLS policy settings.
+type TLSParameterSet struct {
+	// CipherSuites shall contain the TLS cipher suites, as named in the IANA
+	// TLS Cipher Suites registry, such as 'TLS_AES_256_GCM_SHA384', or 'ALL'
+	// to indicate all cipher suites.
+	CipherSuites []string
+	// SignatureAlgorithms shall contain the TLS signature algorithms, as
+	// named in the IANA TLS SignatureScheme registry, such as
+	// 'ecdsa_secp384r1_sha384', or 'ALL' to indicate all signature algorithms.
+	SignatureAlgorithms []string
+	// Versions shall contain the TLS versions, such as '1.2' or '1.3', or
+	// 'ALL' to indicate all versions.
+	Versions []string
+}
+
+// TLSPolicy shall contain TLS policy settings for the manager acting as a
+// TLS client or server.
+type TLSPolicy struct {
+	// Allowed shall contain the TLS policy settings that are allowed, such
+	// as the allowable TLS versions and cipher suites.
+	Allowed TLSParameterSet
+	// Denied shall contain the TLS policy settings that are prohibited, such
+	// as the prohibited TLS versions and cipher suites.
+	Denied TLSParameterSet
+	// VerifyCertificate shall indicate whether the manager will verify the
+	// certificate of the remote TLS endpoint.
+	VerifyCertificate bool
+	// revokedCertificates shall contain a link to a resource collection of
+	// type CertificateCollection.
+	revokedCertificates string
+	// trustedCertificates shall contain a link to a resource collection of
+	// type CertificateCollection.
+	trustedCertificates string
+}
+
+// UnmarshalJSON unmarshals a TLSPolicy object from the raw JSON.
+func (tls *TLSPolicy) UnmarshalJSON(b []byte) error {
+	type temp TLSPolicy
+	var t struct {
+		temp
+		RevokedCertificates common.Link
+		TrustedCertificates common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*tls = TLSPolicy(t.temp)
+	tls.revokedCertificates = string(t.RevokedCertificates)
+	tls.trustedCertificates = string(t.TrustedCertificates)
+
+	return nil
+}
+
+// RevokedCertificates gets the certificates that the manager refuses from
+// remote TLS endpoints.
+func (tls *TLSPolicy) RevokedCertificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, tls.revokedCertificates)
+}
+
+// TrustedCertificates gets the certificates that the manager trusts from
+// remote TLS endpoints.
+func (tls *TLSPolicy) TrustedCertificates(c common.Client) ([]*Certificate, error) {
+	return ListReferencedCertificates(c, tls.trustedCertificates)
+}
+
+// TLSCommunication shall contain the TLS policy settings of the manager.
+type TLSCommunication struct {
+	// Client shall contain the policy requirements and usage for TLS
+	// connections where the manager acts as a TLS client.
+	Client TLSPolicy
+	// Server shall contain the policy requirements and usage for TLS
+	// connections where the manager acts as a TLS server.
+	Server TLSPolicy
+}
+
+// SecurityPolicy shall represent configurable security-related policies
+// managed by a manager, such as the TLS versions and cipher suites it
+// accepts. All security parameters in this resource shall apply to the
+// manager and the resources it manages.
+type SecurityPolicy struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// OverrideParentManager shall indicate whether this security policy
+	// overrides the security policy of the managers of the parent managers,
+	// such as the enclosure manager of a blade BMC.
+	OverrideParentManager bool
+	// SPDM shall contain the policy requirements for SPDM communication and
+	// usage.
+	SPDM SPDMPolicy
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TLS shall contain the policy requirements for TLS communication and
+	// usage.
+	TLS TLSCommunication
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (securitypolicy *SecurityPolicy) GetRawData() []byte {
+	return securitypolicy.rawData
+}
+
+// UnmarshalJSON unmarshals a SecurityPolicy object from the raw JSON.
+func (securitypolicy *SecurityPolicy) UnmarshalJSON(b []byte) error {
+	type temp SecurityPolicy
+	var t temp
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*securitypolicy = SecurityPolicy(t)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	securitypolicy.rawData = b
+	securitypolicy.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the security policy to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (securitypolicy SecurityPolicy) MarshalJSON() ([]byte, error) {
+	type temp SecurityPolicy
+	return common.MarshalWithRawData(securitypolicy.rawData, temp(securitypolicy))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (securitypolicy *SecurityPolicy) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(SecurityPolicy)
+	original.UnmarshalJSON(securitypolicy.rawData)
+
+	readWriteFields := []string{
+		"OverrideParentManager",
+		"SPDM",
+		"TLS",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(securitypolicy).Elem()
+
+	// The SPDM and TLS policies are nested objects, which the generic update
+	// skips, so their changed properties are added to the payload. Only the
+	// changed lists of the parameter sets are sent, so the lists that are
+	// left alone are not replaced.
+	nested := make(map[string]interface{})
+	spdm := changedFields(
+		reflect.ValueOf(original.SPDM), reflect.ValueOf(securitypolicy.SPDM),
+		"AllowExtendedAlgorithms", "Enabled", "SecureSessionEnabled", "VerifyCertificate")
+	addChangedFields(spdm, "Allowed", spdmParameterSetChanges(original.SPDM.Allowed, securitypolicy.SPDM.Allowed))
+	addChangedFields(spdm, "Denied", spdmParameterSetChanges(original.SPDM.Denied, securitypolicy.SPDM.Denied))
+	addChangedFields(nested, "SPDM", spdm)
+
+	tls := make(map[string]interface{})
+	addChangedFields(tls, "Client", tlsPolicyChanges(original.TLS.Client, securitypolicy.TLS.Client))
+	addChangedFields(tls, "Server", tlsPolicyChanges(original.TLS.Server, securitypolicy.TLS.Server))
+	addChangedFields(nested, "TLS", tls)
+
+	return securitypolicy.Entity.UpdateWithNested(originalElement, currentElement, readWriteFields, nested)
+}
+
+// addChangedFields adds the changes of a nested object to the changes of its
+// parent, if there are any.
+func addChangedFields(changes map[string]interface{}, name string, nested map[string]interface{}) {
+	if len(nested) > 0 {
+		changes[name] = nested
+	}
+}
+
+// spdmParameterSetChanges gets the changes of an SPDM parameter set.
+func spdmParameterSetChanges(original, current SPDMParameterSet) map[string]interface{} {
+	changes := changedFields(reflect.ValueOf(original), reflect.ValueOf(current), "Versions")
+	addChangedFields(changes, "Algorithms", changedFields(reflect.ValueOf(original.Algorithms), reflect.ValueOf(current.Algorithms)))
+	return changes
+}
+
+// tlsPolicyChanges gets the changes of a TLS policy.
+func tlsPolicyChanges(original, current TLSPolicy) map[string]interface{} {
+	changes := changedFields(reflect.ValueOf(original), reflect.ValueOf(current), "VerifyCertificate")
+	addChangedFields(changes, "Allowed", changedFields(reflect.ValueOf(original.Allowed), reflect.ValueOf(current.Allowed)))
+	addChangedFields(changes, "Denied", changedFields(reflect.ValueOf(original.Denied), reflect.ValueOf(current.Denied)))
+	return changes
+}
+
+// GetSecurityPolicy will get a SecurityPolicy instance from the service.
+func GetSecurityPolicy(c common.Client, uri string) (*SecurityPolicy, error) {
+	var securitypolicy SecurityPolicy
+	err := common.FetchResource(c, uri, &securitypolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &securitypolicy, nil
+}
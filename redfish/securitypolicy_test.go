@@ -0,0 +1,158 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var securityPolicyBody = `{
+		"@odata.type": "#SecurityPolicy.v1_0_2.SecurityPolicy",
+		"@odata.id": "/redfish/v1/Managers/BMC/SecurityPolicy",
+		"Id": "SecurityPolicy",
+		"Name": "BMC Security Policy",
+		"OverrideParentManager": false,
+		"SPDM": {
+			"Enabled": true,
+			"SecureSessionEnabled": true,
+			"VerifyCertificate": true,
+			"TrustedCertificates": {
+				"@odata.id": "/redfish/v1/Managers/BMC/SecurityPolicy/SPDM/TrustedCertificates"
+			},
+			"Allowed": {
+				"Versions": ["1.1", "1.2"],
+				"Algorithms": {
+					"AEAD": ["AES-256-GCM"],
+					"BaseAsym": ["TPM_ALG_ECDSA_ECC_NIST_P384"],
+					"BaseHash": ["TPM_ALG_SHA_384"]
+				}
+			},
+			"Denied": {
+				"Versions": ["1.0"]
+			}
+		},
+		"TLS": {
+			"Server": {
+				"VerifyCertificate": false,
+				"Allowed": {
+					"Versions": ["1.2", "1.3"],
+					"CipherSuites": ["TLS_AES_256_GCM_SHA384", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"]
+				},
+				"Denied": {
+					"Versions": ["1.0", "1.1"]
+				}
+			},
+			"Client": {
+				"VerifyCertificate": true,
+				"TrustedCertificates": {
+					"@odata.id": "/redfish/v1/Managers/BMC/SecurityPolicy/TLS/Client/TrustedCertificates"
+				}
+			}
+		}
+	}`
+
+// TestSecurityPolicy tests the parsing of SecurityPolicy objects.
+func TestSecurityPolicy(t *testing.T) {
+	var result SecurityPolicy
+	err := json.NewDecoder(strings.NewReader(securityPolicyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "SecurityPolicy" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if !result.SPDM.Enabled || len(result.SPDM.Allowed.Versions) != 2 || len(result.SPDM.Allowed.Algorithms.BaseHash) != 1 {
+		t.Errorf("Invalid SPDM policy: %+v", result.SPDM)
+	}
+
+	if result.SPDM.trustedCertificates != "/redfish/v1/Managers/BMC/SecurityPolicy/SPDM/TrustedCertificates" {
+		t.Errorf("Invalid SPDM trusted certificates link: %s", result.SPDM.trustedCertificates)
+	}
+
+	if len(result.TLS.Server.Allowed.CipherSuites) != 2 || result.TLS.Server.Denied.Versions[1] != "1.1" {
+		t.Errorf("Invalid TLS server policy: %+v", result.TLS.Server)
+	}
+
+	if !result.TLS.Client.VerifyCertificate ||
+		result.TLS.Client.trustedCertificates != "/redfish/v1/Managers/BMC/SecurityPolicy/TLS/Client/TrustedCertificates" {
+		t.Errorf("Invalid TLS client policy: %+v", result.TLS.Client)
+	}
+}
+
+// TestSecurityPolicyUpdate tests the Update call.
+func TestSecurityPolicyUpdate(t *testing.T) {
+	var result SecurityPolicy
+	err := json.NewDecoder(strings.NewReader(securityPolicyBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.TLS.Server.Allowed.Versions = []string{"1.3"}
+	result.TLS.Server.Denied.Versions = []string{"1.0", "1.1", "1.2"}
+	result.SPDM.VerifyCertificate = false
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "TLS:map[Server:map[Allowed:map[Versions:[1.3]] Denied:map[Versions:[1.0 1.1 1.2]]]]") {
+		t.Errorf("Unexpected TLS update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "SPDM:map[VerifyCertificate:false]") {
+		t.Errorf("Unexpected SPDM update payload: %s", calls[0].Payload)
+	}
+
+	if strings.Contains(calls[0].Payload, "CipherSuites") {
+		t.Errorf("Unchanged cipher suites should not be sent: %s", calls[0].Payload)
+	}
+}
+
+// TestManagerSecurityPolicy tests getting the security policy of a manager.
+func TestManagerSecurityPolicy(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Managers/BMC/SecurityPolicy": securityPolicyBody,
+	}}
+
+	var manager Manager
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Managers/BMC",
+		"Id": "BMC",
+		"SecurityPolicy": {
+			"@odata.id": "/redfish/v1/Managers/BMC/SecurityPolicy"
+		}
+	}`)).Decode(&manager)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+	manager.SetClient(testClient)
+
+	policy, err := manager.SecurityPolicy()
+	if err != nil {
+		t.Fatalf("Error getting security policy: %s", err)
+	}
+
+	if policy.ID != "SecurityPolicy" {
+		t.Errorf("Invalid security policy: %s", policy.ID)
+	}
+}
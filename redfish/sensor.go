@@ -0,0 +1,321 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ReadingType is the type of the readings of a sensor.
+type ReadingType string
+
+const (
+	// TemperatureReadingType indicates a temperature, in degree Celsius.
+	TemperatureReadingType ReadingType = "Temperature"
+	// HumidityReadingType indicates a relative humidity, in percent.
+	HumidityReadingType ReadingType = "Humidity"
+	// PowerReadingType indicates the arithmetic mean of product terms of
+	// instantaneous voltage and current values, in watts.
+	PowerReadingType ReadingType = "Power"
+	// EnergykWhReadingType indicates the energy, in kilowatt-hours.
+	EnergykWhReadingType ReadingType = "EnergykWh"
+	// EnergyJoulesReadingType indicates the energy, in joules.
+	EnergyJoulesReadingType ReadingType = "EnergyJoules"
+	// VoltageReadingType indicates a voltage, in volts.
+	VoltageReadingType ReadingType = "Voltage"
+	// CurrentReadingType indicates a current, in amperes.
+	CurrentReadingType ReadingType = "Current"
+	// FrequencyReadingType indicates a frequency, in hertz.
+	FrequencyReadingType ReadingType = "Frequency"
+	// PressureReadingType indicates a pressure, in pascals.
+	PressureReadingType ReadingType = "Pressure"
+	// RotationalReadingType indicates a rotational speed, in revolutions per
+	// minute.
+	RotationalReadingType ReadingType = "Rotational"
+	// AirFlowReadingType indicates an air flow, in cubic feet per minute.
+	AirFlowReadingType ReadingType = "AirFlow"
+	// LiquidFlowReadingType indicates a liquid flow, in liters per second.
+	LiquidFlowReadingType ReadingType = "LiquidFlow"
+	// PercentReadingType indicates a percentage, such as the speed of a fan.
+	PercentReadingType ReadingType = "Percent"
+)
+
+// Threshold shall contain the properties of a sensor threshold.
+type Threshold struct {
+	// Activation shall indicate the direction of crossing of the reading
+	// for this sensor that activates the threshold.
+	Activation string
+	// DwellTime shall indicate the duration the sensor value must violate
+	// the threshold before the threshold is activated.
+	DwellTime common.Duration
+	// Reading shall indicate the reading for this sensor that activates the
+	// threshold. The value of the property shall use the same units as the
+	// Reading property.
+	Reading float32
+}
+
+// UnmarshalJSON unmarshals a Threshold object from the raw JSON.
+func (threshold *Threshold) UnmarshalJSON(b []byte) error {
+	type temp Threshold
+	var t struct {
+		temp
+		// Some implementations send the reading with the wrong JSON type
+		Reading common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*threshold = Threshold(t.temp)
+	threshold.Reading = float32(t.Reading)
+
+	return nil
+}
+
+// Thresholds shall contain the set of thresholds that derive a sensor's
+// health and operational range.
+type Thresholds struct {
+	// LowerCaution shall contain the value at which the reading is below
+	// normal range.
+	LowerCaution Threshold
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerFatal shall contain the value at which the reading is below
+	// normal range and fatal.
+	LowerFatal Threshold
+	// UpperCaution shall contain the value at which the reading is above
+	// normal range.
+	UpperCaution Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperFatal shall contain the value at which the reading is above
+	// normal range and fatal.
+	UpperFatal Threshold
+}
+
+// Sensor shall represent a sensor, such as a temperature or a fan speed
+// sensor, of a Redfish implementation.
+type Sensor struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DeviceName shall contain the name of the device associated with the
+	// sensor, such as the name of a DIMM.
+	DeviceName string
+	// Location shall indicate the location information for this sensor.
+	Location common.Location
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+	// PhysicalSubContext shall contain a description of the usage or
+	// sub-region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalSubContext string
+	// Reading shall contain the sensor value.
+	Reading float32
+	// ReadingRangeMax shall indicate the maximum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMax float32
+	// ReadingRangeMin shall indicate the minimum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMin float32
+	// ReadingTime shall contain the date and time that the reading data was
+	// acquired from the sensor.
+	ReadingTime common.DateTime
+	// ReadingType shall contain the type of the sensor.
+	ReadingType ReadingType
+	// ReadingUnits shall contain the units of the sensor's reading and
+	// thresholds, in UCUM case sensitive format, such as "Cel" or "RPM".
+	ReadingUnits string
+	// SpeedRPM shall contain a reading of the rotational speed of the device,
+	// in revolutions per minute, for sensors of type Percent that measure
+	// the speed of a fan.
+	SpeedRPM float32
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Thresholds shall contain the set of thresholds that derive a sensor's
+	// health and operational range.
+	Thresholds Thresholds
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (sensor *Sensor) GetRawData() []byte {
+	return sensor.rawData
+}
+
+// UnmarshalJSON unmarshals a Sensor object from the raw JSON.
+func (sensor *Sensor) UnmarshalJSON(b []byte) error {
+	type temp Sensor
+	var t struct {
+		temp
+		// Some implementations send these with the wrong JSON type
+		Reading         common.Float32
+		ReadingRangeMax common.Float32
+		ReadingRangeMin common.Float32
+		SpeedRPM        common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*sensor = Sensor(t.temp)
+	sensor.Reading = float32(t.Reading)
+	sensor.ReadingRangeMax = float32(t.ReadingRangeMax)
+	sensor.ReadingRangeMin = float32(t.ReadingRangeMin)
+	sensor.SpeedRPM = float32(t.SpeedRPM)
+
+	sensor.rawData = b
+	sensor.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the sensor to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (sensor Sensor) MarshalJSON() ([]byte, error) {
+	type temp Sensor
+	return common.MarshalWithRawData(sensor.rawData, temp(sensor))
+}
+
+// GetSensor will get a Sensor instance from the service.
+func GetSensor(c common.Client, uri string) (*Sensor, error) {
+	var sensor Sensor
+	err := common.FetchResource(c, uri, &sensor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sensor, nil
+}
+
+// ListReferencedSensors gets the collection of Sensor from
+// a provided reference.
+func ListReferencedSensors(c common.Client, link string) ([]*Sensor, error) {
+	var result []*Sensor
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, sensorLink := range links.ItemLinks {
+		sensor := new(Sensor)
+		expanded, err := links.DecodeMember(c, sensorLink, sensor)
+		if err == nil && !expanded {
+			sensor, err = GetSensor(c, sensorLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, sensor)
+	}
+
+	return result, nil
+}
+
+// SensorExcerpt shall contain a sensor reading excerpt, providing the
+// reading of a sensor without its full set of properties.
+type SensorExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+}
+
+// SensorCurrentExcerpt shall contain a current sensor reading excerpt.
+type SensorCurrentExcerpt struct {
+	// CrestFactor shall contain the ratio of the peak measurement divided by
+	// the RMS measurement and calculated over same N line cycles.
+	CrestFactor float32
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+	// THDPercent shall contain the total harmonic distortion of the Reading
+	// property in percent units.
+	THDPercent float32
+}
+
+// SensorEnergykWhExcerpt shall contain an energy sensor reading excerpt.
+type SensorEnergykWhExcerpt struct {
+	// ApparentkVAh shall contain the apparent energy, in kilovolt-ampere-hour
+	// units, for an electrical energy measurement.
+	ApparentkVAh float32
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// LifetimeReading shall contain the total accumulation of the Reading
+	// property over the sensor's lifetime.
+	LifetimeReading float32
+	// ReactivekVARh shall contain the reactive energy, in
+	// kilovolt-ampere-hours (reactive) units, for an electrical energy
+	// measurement.
+	ReactivekVARh float32
+	// Reading shall contain the sensor value.
+	Reading float32
+	// SensorResetTime shall contain the date and time when the time-based
+	// properties were last reset.
+	SensorResetTime string
+}
+
+// SensorPowerExcerpt shall contain a power sensor reading excerpt.
+type SensorPowerExcerpt struct {
+	// ApparentVA shall contain the product of voltage (RMS) multiplied by
+	// current (RMS) for a circuit.
+	ApparentVA float32
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// PhaseAngleDegrees shall contain the phase angle, in degree units,
+	// between the current and voltage waveforms for an electrical measurement.
+	PhaseAngleDegrees float32
+	// PowerFactor shall identify the quotient of real power (W) and apparent
+	// power (VA) for a circuit.
+	PowerFactor float32
+	// ReactiveVAR shall contain the arithmetic mean of product terms of
+	// instantaneous voltage and quadrature current measurements calculated
+	// over an integer number of line cycles for a circuit.
+	ReactiveVAR float32
+	// Reading shall contain the sensor value.
+	Reading float32
+}
+
+// SensorVoltageExcerpt shall contain a voltage sensor reading excerpt.
+type SensorVoltageExcerpt struct {
+	// CrestFactor shall contain the ratio of the peak measurement divided by
+	// the RMS measurement and calculated over same N line cycles.
+	CrestFactor float32
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// source of the excerpt contained within this copy.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the sensor value.
+	Reading float32
+	// THDPercent shall contain the total harmonic distortion of the Reading
+	// property in percent units.
+	THDPercent float32
+}
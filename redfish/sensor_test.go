@@ -0,0 +1,72 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var sensorBody = `{
+		"@odata.type": "#Sensor.v1_7_0.Sensor",
+		"@odata.id": "/redfish/v1/Chassis/1U/Sensors/CPU1Temp",
+		"Id": "CPU1Temp",
+		"Name": "CPU 1 Temperature",
+		"ReadingType": "Temperature",
+		"Reading": 44,
+		"ReadingUnits": "Cel",
+		"ReadingRangeMin": 0,
+		"ReadingRangeMax": 110,
+		"ReadingTime": "2023-04-20T15:30:00Z",
+		"PhysicalContext": "CPU",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Thresholds": {
+			"UpperCaution": {
+				"Reading": 80,
+				"Activation": "Increasing"
+			},
+			"UpperCritical": {
+				"Reading": 95,
+				"Activation": "Increasing",
+				"DwellTime": "PT10S"
+			}
+		}
+	}`
+
+// TestSensor tests the parsing of Sensor objects.
+func TestSensor(t *testing.T) {
+	var result Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CPU1Temp" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ReadingType != TemperatureReadingType || result.Reading != 44 {
+		t.Errorf("Invalid reading: %s %f", result.ReadingType, result.Reading)
+	}
+
+	if result.PhysicalContext != common.CPUPhysicalContext {
+		t.Errorf("Invalid physical context: %s", result.PhysicalContext)
+	}
+
+	if result.Thresholds.UpperCritical.Reading != 95 || result.Thresholds.UpperCritical.DwellTime != "PT10S" {
+		t.Errorf("Invalid upper critical threshold: %v", result.Thresholds.UpperCritical)
+	}
+
+	if result.ReadingTime.String() != "2023-04-20T15:30:00Z" {
+		t.Errorf("Invalid reading time: %s", result.ReadingTime)
+	}
+}
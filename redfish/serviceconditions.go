@@ -0,0 +1,114 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ServiceConditions shall be used to represent the overall conditions present
+// in a service for a Redfish implementation.
+type ServiceConditions struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Conditions shall represent a roll-up of the active conditions requiring
+	// attention in resources of this Redfish service. The service may roll up
+	// any number of conditions originating from resources in the service,
+	// using the 'ConditionInRelatedResource' message from the Base Message
+	// Registry.
+	Conditions []common.Condition
+	// Description provides a description of this resource.
+	Description string
+	// HealthRollup shall contain the highest severity of any messages
+	// included in the Conditions property.
+	HealthRollup common.Health
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (serviceconditions *ServiceConditions) GetRawData() []byte {
+	return serviceconditions.rawData
+}
+
+// UnmarshalJSON unmarshals a ServiceConditions object from the raw JSON.
+func (serviceconditions *ServiceConditions) UnmarshalJSON(b []byte) error {
+	type temp ServiceConditions
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*serviceconditions = ServiceConditions(t.temp)
+	serviceconditions.rawData = b
+	serviceconditions.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the service conditions to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (serviceconditions ServiceConditions) MarshalJSON() ([]byte, error) {
+	type temp ServiceConditions
+	return common.MarshalWithRawData(serviceconditions.rawData, temp(serviceconditions))
+}
+
+// Healthy tells whether none of the conditions of the service is a warning
+// or critical.
+func (serviceconditions *ServiceConditions) Healthy() bool {
+	health := serviceconditions.HealthRollup
+	for i := range serviceconditions.Conditions {
+		health = worstHealth(health, serviceconditions.Conditions[i].Severity)
+	}
+	return health != common.WarningHealth && health != common.CriticalHealth
+}
+
+// RollUpManagerConditions builds the service conditions from the conditions
+// in the status of managers, with the highest severity of their conditions
+// and health as HealthRollup, for services that predate ServiceConditions.
+func RollUpManagerConditions(managers []*Manager) *ServiceConditions {
+	result := &ServiceConditions{HealthRollup: common.OKHealth}
+	for _, manager := range managers {
+		result.HealthRollup = worstHealth(result.HealthRollup, manager.Status.Health)
+		for i := range manager.Status.Conditions {
+			result.Conditions = append(result.Conditions, manager.Status.Conditions[i])
+			result.HealthRollup = worstHealth(result.HealthRollup, manager.Status.Conditions[i].Severity)
+		}
+	}
+	return result
+}
+
+// worstHealth gets the most severe of two healths.
+func worstHealth(a, b common.Health) common.Health {
+	severity := map[common.Health]int{common.OKHealth: 1, common.WarningHealth: 2, common.CriticalHealth: 3}
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+// GetServiceConditions will get a ServiceConditions instance from the
+// service.
+func GetServiceConditions(c common.Client, uri string) (*ServiceConditions, error) {
+	var serviceconditions ServiceConditions
+	err := common.FetchResource(c, uri, &serviceconditions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceconditions, nil
+}
@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var serviceConditionsBody = `{
+		"@odata.type": "#ServiceConditions.v1_0_0.ServiceConditions",
+		"@odata.id": "/redfish/v1/ServiceConditions",
+		"Id": "ServiceConditions",
+		"Name": "Redfish Service Conditions",
+		"HealthRollup": "Warning",
+		"Conditions": [
+			{
+				"MessageId": "Base.1.9.ConditionInRelatedResource",
+				"Message": "One or more conditions exist in a related resource.",
+				"Severity": "Warning",
+				"Timestamp": "2020-11-08T12:25:00-05:00",
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Chassis/1U/Sensors/PS1Temp"
+				}
+			}
+		]
+	}`
+
+// TestServiceConditions tests the parsing of ServiceConditions objects.
+func TestServiceConditions(t *testing.T) {
+	var result ServiceConditions
+	err := json.NewDecoder(strings.NewReader(serviceConditionsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.HealthRollup != common.WarningHealth {
+		t.Errorf("Invalid health roll-up: %s", result.HealthRollup)
+	}
+
+	if len(result.Conditions) != 1 || result.Conditions[0].OriginOfCondition != "/redfish/v1/Chassis/1U/Sensors/PS1Temp" {
+		t.Errorf("Invalid conditions: %v", result.Conditions)
+	}
+
+	if result.Healthy() {
+		t.Error("A service with a warning should not be healthy")
+	}
+}
+
+// TestRollUpManagerConditions tests rolling up the conditions of managers.
+func TestRollUpManagerConditions(t *testing.T) {
+	healthy := &Manager{}
+	healthy.Status.Health = common.OKHealth
+
+	degraded := &Manager{}
+	degraded.Status.Health = common.OKHealth
+	degraded.Status.Conditions = []common.Condition{
+		{MessageID: "Base.1.9.ConditionInRelatedResource", Severity: common.CriticalHealth},
+	}
+
+	result := RollUpManagerConditions([]*Manager{healthy})
+	if !result.Healthy() || result.HealthRollup != common.OKHealth {
+		t.Errorf("Healthy managers should roll up as healthy: %v", result)
+	}
+
+	result = RollUpManagerConditions([]*Manager{healthy, degraded})
+	if result.Healthy() || result.HealthRollup != common.CriticalHealth || len(result.Conditions) != 1 {
+		t.Errorf("Invalid roll-up of a critical condition: %v", result)
+	}
+}
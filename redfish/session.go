@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
@@ -73,6 +74,32 @@ func (session *Session) GetRawData() []byte {
 	return session.rawData
 }
 
+// UnmarshalJSON unmarshals a Session object from the raw JSON.
+func (session *Session) UnmarshalJSON(b []byte) error {
+	type temp Session
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*session = Session(t.temp)
+	session.rawData = b
+	session.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the session to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (session Session) MarshalJSON() ([]byte, error) {
+	type temp Session
+	return common.MarshalWithRawData(session.rawData, temp(session))
+}
+
 // AuthToken contains the authentication and session information.
 type AuthToken struct {
 	Token     string
@@ -80,6 +107,55 @@ type AuthToken struct {
 	Username  string
 	Password  string
 	BasicAuth bool
+	// PasswordChangeRequired is set when the service created the session
+	// but requires the password of the account to be changed first; the
+	// session then only allows changing the password.
+	PasswordChangeRequired bool
+	// AccountURI is the URI of the account whose password has to be
+	// changed, if the service provided it.
+	AccountURI string
+}
+
+// ErrorPasswordChangeRequired is returned when the service requires the
+// password of the account to be changed before it grants access, such as on
+// the first login to a factory-default BMC. Change it with
+// ManagerAccount.ChangePassword.
+type ErrorPasswordChangeRequired struct {
+	// AccountURI is the URI of the account whose password has to be
+	// changed, if the service provided it.
+	AccountURI string
+	// Err is the error response of the service, if any.
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrorPasswordChangeRequired) Error() string {
+	if e.AccountURI == "" {
+		return "the password of the account must be changed before access is granted"
+	}
+	return fmt.Sprintf("the password of the account %s must be changed before access is granted", e.AccountURI)
+}
+
+// Unwrap gets the error response of the service.
+func (e ErrorPasswordChangeRequired) Unwrap() error {
+	return e.Err
+}
+
+// ParsePasswordChangeRequired checks whether a response body has the
+// PasswordChangeRequired message of the Base registry, and gets it as an
+// error wrapping err.
+func ParsePasswordChangeRequired(body []byte, err error) (ErrorPasswordChangeRequired, bool) {
+	message := common.FindMessage(common.ParseMessages(body), "PasswordChangeRequired")
+	if message == nil {
+		return ErrorPasswordChangeRequired{}, false
+	}
+
+	// The argument is the URI of the account to PATCH
+	result := ErrorPasswordChangeRequired{Err: err}
+	if len(message.MessageArgs) > 0 {
+		result.AccountURI = message.MessageArgs[0]
+	}
+	return result, true
 }
 
 type authPayload struct {
@@ -104,7 +180,19 @@ func CreateSession(c common.Client, uri string, username string, password string
 	auth.Token = resp.Header.Get("X-Auth-Token")
 	auth.Session = resp.Header.Get("Location")
 
-	return auth, err
+	// The body is not decoded with common.DecodeResource: it is only searched
+	// for the password change message, and services answer with the session,
+	// an extended info message or nothing at all
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return auth, err
+	}
+	if required, ok := ParsePasswordChangeRequired(body, nil); ok {
+		auth.PasswordChangeRequired = true
+		auth.AccountURI = required.AccountURI
+	}
+
+	return auth, nil
 }
 
 // DeleteSession deletes a session using the location as argument
@@ -114,24 +202,12 @@ func DeleteSession(c common.Client, url string) (err error) {
 
 // GetSession will get a Session instance from the Redfish service.
 func GetSession(c common.Client, uri string) (*Session, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var session Session
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := common.FetchResource(c, uri, &session)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(rawData, &session)
-	if err != nil {
-		return nil, err
-	}
-
-	session.rawData = rawData
 	return &session, nil
 }
 
@@ -144,7 +220,11 @@ func ListReferencedSessions(c common.Client, link string) ([]*Session, error) {
 	}
 
 	for _, sLink := range links.ItemLinks {
-		s, err := GetSession(c, sLink)
+		s := new(Session)
+		expanded, err := links.DecodeMember(c, sLink, s)
+		if err == nil && !expanded {
+			s, err = GetSession(c, sLink)
+		}
 		if err != nil {
 			return result, err
 		}
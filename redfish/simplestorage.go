@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -82,31 +81,27 @@ func (simplestorage *SimpleStorage) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*simplestorage = SimpleStorage(t.temp)
 	simplestorage.chassis = string(t.Links.Chassis)
+	simplestorage.rawData = b
+	simplestorage.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the simple storage to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (simplestorage SimpleStorage) MarshalJSON() ([]byte, error) {
+	type temp SimpleStorage
+	return common.MarshalWithRawData(simplestorage.rawData, temp(simplestorage))
+}
+
 // GetSimpleStorage will get a SimpleStorage instance from the service.
 func GetSimpleStorage(c common.Client, uri string) (*SimpleStorage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var simpleStorage SimpleStorage
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &simpleStorage)
+	err := common.FetchResource(c, uri, &simpleStorage)
 	if err != nil {
 		return nil, err
 	}
 
-	simpleStorage.rawData = rawData
-	simpleStorage.SetClient(c)
 	return &simpleStorage, nil
 }
 
@@ -124,7 +119,11 @@ func ListReferencedSimpleStorages(c common.Client, link string) ([]*SimpleStorag
 	}
 
 	for _, simplestorageLink := range links.ItemLinks {
-		simplestorage, err := GetSimpleStorage(c, simplestorageLink)
+		simplestorage := new(SimpleStorage)
+		expanded, err := links.DecodeMember(c, simplestorageLink, simplestorage)
+		if err == nil && !expanded {
+			simplestorage, err = GetSimpleStorage(c, simplestorageLink)
+		}
 		if err != nil {
 			return result, err
 		}
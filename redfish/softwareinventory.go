@@ -0,0 +1,120 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SoftwareInventory shall represent a single software component that this
+// Redfish service manages, such as the firmware of a device.
+type SoftwareInventory struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// LowestSupportedVersion shall represent the lowest supported version of
+	// this software.
+	LowestSupportedVersion string
+	// Manufacturer shall represent the name of the manufacturer or producer
+	// of this software.
+	Manufacturer string
+	// ReleaseDate shall contain the date of release or production for this
+	// software.
+	ReleaseDate common.DateTime
+	// SoftwareID shall represent an implementation-specific label that
+	// identifies this software.
+	SoftwareID string `json:"SoftwareId"`
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Updateable shall indicate whether the Update Service can update this
+	// software.
+	Updateable bool
+	// Version shall contain the version of this software.
+	Version string
+	// WriteProtected shall indicate whether the software image can be
+	// overwritten.
+	WriteProtected bool
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (softwareinventory *SoftwareInventory) GetRawData() []byte {
+	return softwareinventory.rawData
+}
+
+// UnmarshalJSON unmarshals a SoftwareInventory object from the raw JSON.
+func (softwareinventory *SoftwareInventory) UnmarshalJSON(b []byte) error {
+	type temp SoftwareInventory
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*softwareinventory = SoftwareInventory(t.temp)
+	softwareinventory.rawData = b
+	softwareinventory.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the software inventory to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (softwareinventory SoftwareInventory) MarshalJSON() ([]byte, error) {
+	type temp SoftwareInventory
+	return common.MarshalWithRawData(softwareinventory.rawData, temp(softwareinventory))
+}
+
+// GetSoftwareInventory will get a SoftwareInventory instance from the service.
+func GetSoftwareInventory(c common.Client, uri string) (*SoftwareInventory, error) {
+	var softwareinventory SoftwareInventory
+	err := common.FetchResource(c, uri, &softwareinventory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &softwareinventory, nil
+}
+
+// ListReferencedSoftwareInventories gets the collection of SoftwareInventory
+// from a provided reference.
+func ListReferencedSoftwareInventories(c common.Client, link string) ([]*SoftwareInventory, error) {
+	var result []*SoftwareInventory
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, softwareinventoryLink := range links.ItemLinks {
+		softwareinventory := new(SoftwareInventory)
+		expanded, err := links.DecodeMember(c, softwareinventoryLink, softwareinventory)
+		if err == nil && !expanded {
+			softwareinventory, err = GetSoftwareInventory(c, softwareinventoryLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, softwareinventory)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var softwareInventoryBody = `{
+		"@odata.type": "#SoftwareInventory.v1_2_0.SoftwareInventory",
+		"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC",
+		"Id": "BMC",
+		"Name": "Contoso BMC Firmware",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Updateable": true,
+		"Manufacturer": "Contoso",
+		"ReleaseDate": "2017-08-22T12:00:00",
+		"Version": "1.45.455b66-rev4",
+		"SoftwareId": "1624A9DF-5E13-47FC-874A-DF3AFF143089",
+		"LowestSupportedVersion": "1.30.367a12-rev1"
+	}`
+
+// TestSoftwareInventory tests the parsing of SoftwareInventory objects.
+func TestSoftwareInventory(t *testing.T) {
+	var result SoftwareInventory
+	err := json.NewDecoder(strings.NewReader(softwareInventoryBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "BMC" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Version != "1.45.455b66-rev4" {
+		t.Errorf("Invalid version: %s", result.Version)
+	}
+
+	if result.SoftwareID != "1624A9DF-5E13-47FC-874A-DF3AFF143089" {
+		t.Errorf("Invalid software ID: %s", result.SoftwareID)
+	}
+
+	if !result.Updateable {
+		t.Error("The firmware should be updateable")
+	}
+}
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -27,6 +27,32 @@ type CacheSummary struct {
 	TotalCacheSizeMiB int
 }
 
+// EncryptionMode is the encryption mode of a storage subsystem.
+type EncryptionMode string
+
+const (
+	// DisabledEncryptionMode shall indicate that encryption is disabled on
+	// the storage subsystem.
+	DisabledEncryptionMode EncryptionMode = "Disabled"
+	// UseExternalKeyEncryptionMode shall indicate that the storage subsystem
+	// uses one or more external keys for encryption.
+	UseExternalKeyEncryptionMode EncryptionMode = "UseExternalKey"
+	// UseLocalKeyEncryptionMode shall indicate that the storage subsystem
+	// uses a local key for encryption.
+	UseLocalKeyEncryptionMode EncryptionMode = "UseLocalKey"
+	// PasswordOnlyEncryptionMode shall indicate that the storage subsystem
+	// uses a password, but no encryption key, to unlock the controller.
+	PasswordOnlyEncryptionMode EncryptionMode = "PasswordOnly"
+	// PasswordWithExternalKeyEncryptionMode shall indicate that the storage
+	// subsystem uses a password to unlock the controller and one or more
+	// external keys for encryption.
+	PasswordWithExternalKeyEncryptionMode EncryptionMode = "PasswordWithExternalKey"
+	// PasswordWithLocalKeyEncryptionMode shall indicate that the storage
+	// subsystem uses a password to unlock the controller and a local key for
+	// encryption.
+	PasswordWithLocalKeyEncryptionMode EncryptionMode = "PasswordWithLocalKey"
+)
+
 // Storage is used to represent resources that represent a storage
 // subsystem in the Redfish specification.
 type Storage struct {
@@ -45,6 +71,13 @@ type Storage struct {
 	drives []string
 	// DrivesCount is the number of drives.
 	DrivesCount int `json:"Drives@odata.count"`
+	// EncryptionMode shall contain the encryption mode of this storage
+	// subsystem.
+	EncryptionMode EncryptionMode
+	// LocalEncryptionKeyIdentifier shall contain the local encryption key
+	// identifier used by the storage subsystem when EncryptionMode contains
+	// UseLocalKey or PasswordWithLocalKey.
+	LocalEncryptionKeyIdentifier string
 	// Redundancy shall contain redundancy information for the storage subsystem.
 	Redundancy []Redundancy
 	// RedundancyCount is the number of Redundancy objects.
@@ -59,6 +92,9 @@ type Storage struct {
 	// Volumes is a collection that indicates all the volumes produced by the
 	// storage controllers that this resource represents.
 	volumes string
+	// controllers shall be a link to a resource collection of type
+	// StorageControllerCollection.
+	controllers string
 	// Enclosures shall reference a resource of type Chassis that represents the
 	// physical containers attached to this resource.
 	enclosures []string
@@ -66,6 +102,9 @@ type Storage struct {
 	EnclosuresCount int
 	// setEncryptionKeyTarget is the URL to send SetEncryptionKey requests.
 	setEncryptionKeyTarget string
+	// setControllerPasswordTarget is the URL to send SetControllerPassword
+	// requests.
+	setControllerPasswordTarget string
 	// rawData holds the original serialized JSON
 	rawData []byte
 }
@@ -83,16 +122,20 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 		EnclosuresCount int `json:"Enclosures@odata.count"`
 	}
 	type actions struct {
+		SetControllerPassword struct {
+			Target string
+		} `json:"#Storage.SetControllerPassword"`
 		SetEncryptionKey struct {
 			Target string
 		} `json:"#Storage.SetEncryptionKey"`
 	}
 	var t struct {
 		temp
-		Links   links
-		Drives  common.Links
-		Volumes common.Link
-		Actions actions
+		Links       links
+		Controllers common.Link
+		Drives      common.Links
+		Volumes     common.Link
+		Actions     actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -107,32 +150,50 @@ func (storage *Storage) UnmarshalJSON(b []byte) error {
 	storage.EnclosuresCount = t.Links.EnclosuresCount
 	storage.drives = t.Drives.ToStrings()
 	storage.volumes = string(t.Volumes)
+	storage.controllers = string(t.Controllers)
+	storage.setControllerPasswordTarget = t.Actions.SetControllerPassword.Target
 	storage.setEncryptionKeyTarget = t.Actions.SetEncryptionKey.Target
 
+	// This is a read/write object, so we need to save the raw object data for later
+	storage.rawData = b
+	storage.SetActions(common.ParseActions(b))
+
 	return nil
 }
 
-// GetStorage will get a Storage instance from the service.
-func GetStorage(c common.Client, uri string) (*Storage, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// MarshalJSON marshals the storage to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (storage Storage) MarshalJSON() ([]byte, error) {
+	type temp Storage
+	return common.MarshalWithRawData(storage.rawData, temp(storage))
+}
 
-	var storage Storage
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// Update commits updates to this object's properties to the running system.
+func (storage *Storage) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Storage)
+	original.UnmarshalJSON(storage.rawData)
+
+	readWriteFields := []string{
+		"EncryptionMode",
 	}
 
-	err = json.Unmarshal(rawData, &storage)
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(storage).Elem()
+
+	return storage.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetStorage will get a Storage instance from the service.
+func GetStorage(c common.Client, uri string) (*Storage, error) {
+	var storage Storage
+	err := common.FetchResource(c, uri, &storage)
 	if err != nil {
 		return nil, err
 	}
 
-	storage.rawData = rawData
-	storage.SetClient(c)
 	return &storage, nil
 }
 
@@ -150,7 +211,11 @@ func ListReferencedStorages(c common.Client, link string) ([]*Storage, error) {
 	}
 
 	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorage(c, storageLink)
+		storage := new(Storage)
+		expanded, err := links.DecodeMember(c, storageLink, storage)
+		if err == nil && !expanded {
+			storage, err = GetStorage(c, storageLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -192,17 +257,249 @@ func (storage *Storage) Volumes() ([]*Volume, error) {
 	return ListReferencedVolumes(storage.Client, storage.volumes)
 }
 
+// VolumeDrives gets the drives that back the volume. The links of the volume
+// are used when the service provides them, otherwise the drives of this
+// storage subsystem that link back to the volume are returned.
+func (storage *Storage) VolumeDrives(volume *Volume) ([]*Drive, error) {
+	if len(volume.drives) > 0 {
+		return volume.Drives()
+	}
+
+	drives, err := storage.Drives()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Drive
+	for _, drive := range drives {
+		for _, volumeLink := range drive.volumes {
+			if volumeLink == volume.ODataID {
+				result = append(result, drive)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DriveVolumes gets the volumes the drive is a member of. The links of the
+// drive are used when the service provides them, otherwise the volumes of
+// this storage subsystem that link to the drive are returned.
+func (storage *Storage) DriveVolumes(drive *Drive) ([]*Volume, error) {
+	if len(drive.volumes) > 0 {
+		return drive.Volumes()
+	}
+
+	volumes, err := storage.Volumes()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Volume
+	for _, volume := range volumes {
+		for _, driveLink := range volume.drives {
+			if driveLink == drive.ODataID {
+				result = append(result, volume)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// VolumeCapabilities gets the capabilities the service advertises for
+// creating volumes in this storage subsystem.
+func (storage *Storage) VolumeCapabilities() ([]*VolumeCapabilities, error) {
+	return ListVolumeCollectionCapabilities(storage.Client, storage.volumes)
+}
+
+// Controllers gets the storage controllers in the StorageControllerCollection
+// of this storage subsystem. Services that only embed their controllers
+// expose them through StorageControllers instead.
+func (storage *Storage) Controllers() ([]*StorageController, error) {
+	return ListReferencedStorageControllers(storage.Client, storage.controllers)
+}
+
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
 func (storage *Storage) SetEncryptionKey(key string) error {
+	return storage.ChangeEncryptionKey("", key, "")
+}
+
+// ChangeEncryptionKey shall replace the local encryption key of the storage
+// subsystem. The currentKey is required by services that already have a key
+// set, and keyIdentifier names the new key when the service supports it.
+func (storage *Storage) ChangeEncryptionKey(currentKey, key, keyIdentifier string) error {
+	if storage.setEncryptionKeyTarget == "" {
+		return fmt.Errorf("SetEncryptionKey is not supported by this storage subsystem")
+	}
+
 	type temp struct {
-		EncryptionKey string
+		CurrentEncryptionKey    string `json:",omitempty"`
+		EncryptionKey           string
+		EncryptionKeyIdentifier string `json:",omitempty"`
+	}
+	t := temp{
+		CurrentEncryptionKey:    currentKey,
+		EncryptionKey:           key,
+		EncryptionKeyIdentifier: keyIdentifier,
 	}
-	t := temp{EncryptionKey: key}
 
 	_, err := storage.Client.Post(storage.setEncryptionKeyTarget, t)
 	return err
 }
 
+// SetControllerPassword shall set the controller password used to unlock the
+// storage controllers of this subsystem. The securityKey is required by
+// services whose EncryptionMode uses a local key.
+func (storage *Storage) SetControllerPassword(currentPassword, newPassword, securityKey string) error {
+	if storage.setControllerPasswordTarget == "" {
+		return fmt.Errorf("SetControllerPassword is not supported by this storage subsystem")
+	}
+
+	type temp struct {
+		CurrentPassword string `json:",omitempty"`
+		NewPassword     string
+		SecurityKey     string `json:",omitempty"`
+	}
+	t := temp{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+		SecurityKey:     securityKey,
+	}
+
+	_, err := storage.Client.Post(storage.setControllerPasswordTarget, t)
+	return err
+}
+
+// ANAAccessState is the asymmetric namespace access state of a namespace
+// through an NVMe controller.
+type ANAAccessState string
+
+const (
+	// OptimizedANAAccessState shall indicate each namespace in this ANA group
+	// is in the ANA Optimized state.
+	OptimizedANAAccessState ANAAccessState = "Optimized"
+	// NonOptimizedANAAccessState shall indicate each namespace in this ANA
+	// group is in the ANA Non-Optimized state.
+	NonOptimizedANAAccessState ANAAccessState = "NonOptimized"
+	// InaccessibleANAAccessState shall indicate each namespace in this ANA
+	// group is in the ANA Inaccessible state.
+	InaccessibleANAAccessState ANAAccessState = "Inaccessible"
+	// PersistentLossANAAccessState shall indicate each namespace in this ANA
+	// group is in the ANA Persistent Loss state.
+	PersistentLossANAAccessState ANAAccessState = "PersistentLoss"
+)
+
+// NVMeControllerType is the type of an NVMe controller.
+type NVMeControllerType string
+
+const (
+	// AdminNVMeControllerType shall indicate the NVMe controller is an
+	// administrative controller.
+	AdminNVMeControllerType NVMeControllerType = "Admin"
+	// DiscoveryNVMeControllerType shall indicate the NVMe controller is a
+	// discovery controller.
+	DiscoveryNVMeControllerType NVMeControllerType = "Discovery"
+	// IONVMeControllerType shall indicate the NVMe controller is an IO
+	// controller.
+	IONVMeControllerType NVMeControllerType = "IO"
+)
+
+// ANACharacteristics shall contain the ANA characteristics and volume
+// information for a storage controller.
+type ANACharacteristics struct {
+	// AccessState shall contain the reported ANA state of the namespace
+	// referenced by the Volume property.
+	AccessState ANAAccessState
+	// Volume shall contain a link to a resource of type Volume.
+	Volume common.Link
+}
+
+// NVMeControllerAttributes shall contain NVMe controller attributes for a
+// storage controller.
+type NVMeControllerAttributes struct {
+	// ReportsNamespaceGranularity shall indicate whether or not the controller
+	// supports reporting of Namespace Granularity.
+	ReportsNamespaceGranularity bool
+	// ReportsUUIDList shall indicate whether or not the controller supports
+	// reporting of a UUID list.
+	ReportsUUIDList bool
+	// Supports128BitHostID shall indicate whether or not the controller
+	// supports a 128-bit Host Identifier.
+	Supports128BitHostID bool `json:"Supports128BitHostId"`
+	// SupportsEnduranceGroups shall indicate whether or not the controller
+	// supports Endurance Groups.
+	SupportsEnduranceGroups bool
+	// SupportsExceedingPowerOfNonOperationalState shall indicate whether or
+	// not the controller supports exceeding Power of Non-Operational State in
+	// order to execute controller initiated background operations in a
+	// non-operational power state.
+	SupportsExceedingPowerOfNonOperationalState bool
+	// SupportsNVMSets shall indicate whether or not the controller supports
+	// NVM Sets.
+	SupportsNVMSets bool
+	// SupportsPredictableLatencyMode shall indicate whether or not the
+	// controller supports Predictable Latency Mode.
+	SupportsPredictableLatencyMode bool
+	// SupportsReadRecoveryLevels shall indicate whether or not the controller
+	// supports Read Recovery Levels.
+	SupportsReadRecoveryLevels bool
+	// SupportsReservations shall indicate if the controller supports
+	// reservations.
+	SupportsReservations bool
+	// SupportsSQAssociations shall indicate whether or not the controller
+	// supports SQ Associations.
+	SupportsSQAssociations bool
+	// SupportsTrafficBasedKeepAlive shall indicate whether or not the
+	// controller supports restarting the Keep Alive Timer if traffic is
+	// processed from an admin command or IO during a Keep Alive Timeout
+	// interval.
+	SupportsTrafficBasedKeepAlive bool
+}
+
+// NVMeSMARTCriticalWarnings shall contain the NVMe SMART Critical Warnings
+// for a storage controller.
+type NVMeSMARTCriticalWarnings struct {
+	// MediaInReadOnly shall indicate the media has been placed in read only
+	// mode.
+	MediaInReadOnly bool
+	// OverallSubsystemDegraded shall indicate that the NVM subsystem
+	// reliability has been compromised.
+	OverallSubsystemDegraded bool
+	// PMRUnreliable shall indicate that the Persistent Memory Region has
+	// become unreliable.
+	PMRUnreliable bool
+	// PowerBackupFailed shall indicate that the volatile memory backup device
+	// has failed.
+	PowerBackupFailed bool
+	// SpareCapacityWornOut shall indicate that the available spare capacity
+	// has fallen below the threshold.
+	SpareCapacityWornOut bool
+}
+
+// NVMeControllerProperties shall contain NVMe related properties for a
+// storage controller.
+type NVMeControllerProperties struct {
+	// ANACharacteristics shall contain the ANA characteristics and volume
+	// information for a storage controller.
+	ANACharacteristics []ANACharacteristics
+	// ControllerType shall contain the type of NVMe controller.
+	ControllerType NVMeControllerType
+	// MaxQueueSize shall contain the maximum individual queue entry size
+	// supported per queue.
+	MaxQueueSize int
+	// NVMeControllerAttributes shall contain NVMe controller attributes.
+	NVMeControllerAttributes NVMeControllerAttributes
+	// NVMeSMARTCriticalWarnings shall contain the NVMe SMART Critical
+	// Warnings for this storage controller.
+	NVMeSMARTCriticalWarnings NVMeSMARTCriticalWarnings
+	// NVMeVersion shall contain the version of the NVMe Base Specification
+	// supported.
+	NVMeVersion string
+}
+
 // StorageController is used to represent a resource that represents a
 // storage controller in the Redfish specification.
 type StorageController struct {
@@ -235,6 +532,9 @@ type StorageController struct {
 	// Model shall be the name by which the manufacturer generally refers to the
 	// storage controller.
 	Model string
+	// NVMeControllerProperties shall contain NVMe related properties for this
+	// storage controller.
+	NVMeControllerProperties NVMeControllerProperties
 	// PCIeInterface is used to connect this PCIe-based controller to its host.
 	PCIeInterface PCIeInterface
 	// PartNumber shall be a part number assigned by the organization that is
@@ -261,6 +561,9 @@ type StorageController struct {
 	// SupportedRAIDTypes shall contain all the RAIDType values supported by the
 	// current resource.
 	SupportedRAIDTypes []RAIDType
+	// attachedVolumes shall be a reference to the volumes that are attached
+	// to this NVMe controller.
+	attachedVolumes []string
 	// Endpoints shall be a reference to the resources that this controller is
 	// associated with and shall reference a resource of type Endpoint.
 	endpoints []string
@@ -276,10 +579,16 @@ type StorageController struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (storagecontroller *StorageController) GetRawData() []byte {
+	return storagecontroller.rawData
+}
+
 // UnmarshalJSON unmarshals a StorageController object from the raw JSON.
 func (storagecontroller *StorageController) UnmarshalJSON(b []byte) error {
 	type temp StorageController
 	type links struct {
+		AttachedVolumes      common.Links
 		Endpoints            common.Links
 		EndpointsCount       int `json:"Endpoints@odata.count"`
 		StorageServices      common.Links
@@ -300,17 +609,26 @@ func (storagecontroller *StorageController) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	storagecontroller.assembly = string(t.Assembly)
-	storagecontroller.endpoints = t.Links.StorageServices.ToStrings()
+	storagecontroller.attachedVolumes = t.Links.AttachedVolumes.ToStrings()
+	storagecontroller.endpoints = t.Links.Endpoints.ToStrings()
 	storagecontroller.EndpointsCount = t.Links.EndpointsCount
 	storagecontroller.storageServices = t.Links.StorageServices.ToStrings()
 	storagecontroller.StorageServicesCount = t.Links.StorageServicesCount
 
 	// This is a read/write object, so we need to save the raw object data for later
 	storagecontroller.rawData = b
+	storagecontroller.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the storage controller to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (storagecontroller StorageController) MarshalJSON() ([]byte, error) {
+	type temp StorageController
+	return common.MarshalWithRawData(storagecontroller.rawData, temp(storagecontroller))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (storagecontroller *StorageController) Update() error {
 
@@ -331,19 +649,12 @@ func (storagecontroller *StorageController) Update() error {
 
 // GetStorageController will get a Storage controller instance from the service.
 func GetStorageController(c common.Client, uri string) (*StorageController, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var storage StorageController
-	err = json.NewDecoder(resp.Body).Decode(&storage)
+	err := common.FetchResource(c, uri, &storage)
 	if err != nil {
 		return nil, err
 	}
 
-	storage.SetClient(c)
 	return &storage, nil
 }
 
@@ -361,7 +672,11 @@ func ListReferencedStorageControllers(c common.Client, link string) ([]*StorageC
 	}
 
 	for _, storageLink := range links.ItemLinks {
-		storage, err := GetStorageController(c, storageLink)
+		storage := new(StorageController)
+		expanded, err := links.DecodeMember(c, storageLink, storage)
+		if err == nil && !expanded {
+			storage, err = GetStorageController(c, storageLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -379,6 +694,59 @@ func (storagecontroller *StorageController) Assembly() (*Assembly, error) {
 	return GetAssembly(storagecontroller.Client, storagecontroller.assembly)
 }
 
+// SupportsRAIDType checks whether this controller can create volumes of the
+// RAID type.
+func (storagecontroller *StorageController) SupportsRAIDType(raidType RAIDType) bool {
+	for _, supported := range storagecontroller.SupportedRAIDTypes {
+		if supported == raidType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRAIDLayout checks that this controller supports the RAID type and
+// that enough drives are provided for it, so a layout can be rejected before
+// a volume creation request is sent.
+func (storagecontroller *StorageController) ValidateRAIDLayout(raidType RAIDType, driveCount int) error {
+	if !storagecontroller.SupportsRAIDType(raidType) {
+		return fmt.Errorf("RAID type %s is not supported by this storage controller, supported types are %v",
+			raidType, storagecontroller.SupportedRAIDTypes)
+	}
+
+	if minimum := raidType.MinimumDrives(); driveCount < minimum {
+		return fmt.Errorf("RAID type %s requires at least %d drives, got %d", raidType, minimum, driveCount)
+	}
+
+	return nil
+}
+
+// AttachedVolumes gets the volumes attached to this NVMe controller.
+func (storagecontroller *StorageController) AttachedVolumes() ([]*Volume, error) {
+	var result []*Volume
+	for _, volumeLink := range storagecontroller.attachedVolumes {
+		volume, err := GetVolume(storagecontroller.Client, volumeLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, volume)
+	}
+	return result, nil
+}
+
+// ANAStates gets the asymmetric namespace access state of each volume
+// reported by this NVMe controller, keyed by the volume URI.
+func (storagecontroller *StorageController) ANAStates() map[string]ANAAccessState {
+	result := make(map[string]ANAAccessState)
+	for _, ana := range storagecontroller.NVMeControllerProperties.ANACharacteristics {
+		if ana.Volume == "" {
+			continue
+		}
+		result[string(ana.Volume)] = ana.AccessState
+	}
+	return result
+}
+
 // Endpoints gets the storage controller's endpoints.
 func (storagecontroller *StorageController) Endpoints() ([]*Endpoint, error) {
 	var result []*Endpoint
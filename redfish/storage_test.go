@@ -77,6 +77,9 @@ var storageBody = `{
 					"@odata.id": "/redfish/v1/Endpoints/1"
 				}],
 				"Endpoints@odata.count": 1,
+				"AttachedVolumes": [{
+					"@odata.id": "/redfish/v1/Volumes/1"
+				}],
 				"PCIeFunctions": [{
 					"@odata.id": "/redfish/v1/Functions/1"
 				}],
@@ -87,6 +90,31 @@ var storageBody = `{
 				"StorageServices@odata.count": 1
 			},
 			"Location": {},
+			"NVMeControllerProperties": {
+				"ANACharacteristics": [{
+						"AccessState": "Optimized",
+						"Volume": {
+							"@odata.id": "/redfish/v1/Volumes/1"
+						}
+					},
+					{
+						"AccessState": "Inaccessible",
+						"Volume": {
+							"@odata.id": "/redfish/v1/Volumes/2"
+						}
+					}
+				],
+				"ControllerType": "IO",
+				"MaxQueueSize": 2048,
+				"NVMeControllerAttributes": {
+					"Supports128BitHostId": true,
+					"SupportsReservations": true
+				},
+				"NVMeSMARTCriticalWarnings": {
+					"SpareCapacityWornOut": false
+				},
+				"NVMeVersion": "1.4"
+			},
 			"Manufacturer": "Acme Storage",
 			"MemberId": "SS1",
 			"Model": "Model One",
@@ -117,7 +145,15 @@ var storageBody = `{
 		"Volumes": {
 			"@odata.id": "/redfish/v1/Volumes/1"
 		},
+		"Controllers": {
+			"@odata.id": "/redfish/v1/Storage/Controllers"
+		},
+		"EncryptionMode": "UseLocalKey",
+		"LocalEncryptionKeyIdentifier": "Key1",
 		"Actions": {
+			"#Storage.SetControllerPassword": {
+				"target": "/redfish/v1/Storage/Actions/Storage.SetControllerPassword"
+			},
 			"#Storage.SetEncryptionKey": {
 				"target": "/redfish/v1/Storage/Actions/Storage.SetEncryptionKey"
 			}
@@ -157,6 +193,67 @@ func TestStorage(t *testing.T) {
 	if result.setEncryptionKeyTarget != "/redfish/v1/Storage/Actions/Storage.SetEncryptionKey" {
 		t.Errorf("Invalid SetEncryptionKey target: %s", result.setEncryptionKeyTarget)
 	}
+
+	if result.controllers != "/redfish/v1/Storage/Controllers" {
+		t.Errorf("Invalid controllers link: %s", result.controllers)
+	}
+
+	controller := result.StorageControllers[0]
+	if len(controller.endpoints) != 1 || controller.endpoints[0] != "/redfish/v1/Endpoints/1" {
+		t.Errorf("Invalid endpoints: %v", controller.endpoints)
+	}
+
+	if len(controller.attachedVolumes) != 1 {
+		t.Errorf("Unexpected number of attached volumes: %d", len(controller.attachedVolumes))
+	}
+}
+
+// TestStorageControllerNVMe tests the parsing of NVMe controller properties.
+func TestStorageControllerNVMe(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	nvme := result.StorageControllers[0].NVMeControllerProperties
+
+	if nvme.ControllerType != IONVMeControllerType {
+		t.Errorf("Invalid ControllerType: %s", nvme.ControllerType)
+	}
+
+	if nvme.MaxQueueSize != 2048 {
+		t.Errorf("Invalid MaxQueueSize: %d", nvme.MaxQueueSize)
+	}
+
+	if !nvme.NVMeControllerAttributes.Supports128BitHostID {
+		t.Error("Supports128BitHostID should be true")
+	}
+
+	controller := result.StorageControllers[0]
+
+	if err := controller.ValidateRAIDLayout(RAID10RAIDType, 4); err != nil {
+		t.Errorf("Unexpected RAID layout error: %s", err)
+	}
+
+	if controller.ValidateRAIDLayout(RAID10RAIDType, 2) == nil {
+		t.Error("RAID10 with 2 drives should fail validation")
+	}
+
+	if controller.ValidateRAIDLayout(RAID50RAIDType, 6) == nil {
+		t.Error("Unsupported RAID50 should fail validation")
+	}
+
+	states := result.StorageControllers[0].ANAStates()
+
+	if states["/redfish/v1/Volumes/1"] != OptimizedANAAccessState {
+		t.Errorf("Invalid ANA state for volume 1: %s", states["/redfish/v1/Volumes/1"])
+	}
+
+	if states["/redfish/v1/Volumes/2"] != InaccessibleANAAccessState {
+		t.Errorf("Invalid ANA state for volume 2: %s", states["/redfish/v1/Volumes/2"])
+	}
 }
 
 // TestStorageControllerUpdate tests the Update call.
@@ -188,3 +285,67 @@ func TestStorageControllerUpdate(t *testing.T) {
 		t.Errorf("Unexpected AssetTag update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStorageEncryption tests the encryption mode and key management calls.
+func TestStorageEncryption(t *testing.T) {
+	var result Storage
+	err := json.NewDecoder(strings.NewReader(storageBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.EncryptionMode != UseLocalKeyEncryptionMode {
+		t.Errorf("Invalid EncryptionMode: %s", result.EncryptionMode)
+	}
+
+	if result.LocalEncryptionKeyIdentifier != "Key1" {
+		t.Errorf("Invalid LocalEncryptionKeyIdentifier: %s", result.LocalEncryptionKeyIdentifier)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SetEncryptionKey("secret")
+	if err != nil {
+		t.Errorf("Error making SetEncryptionKey call: %s", err)
+	}
+
+	err = result.ChangeEncryptionKey("secret", "newsecret", "Key2")
+	if err != nil {
+		t.Errorf("Error making ChangeEncryptionKey call: %s", err)
+	}
+
+	err = result.SetControllerPassword("", "password", "newsecret")
+	if err != nil {
+		t.Errorf("Error making SetControllerPassword call: %s", err)
+	}
+
+	result.EncryptionMode = PasswordWithLocalKeyEncryptionMode
+	err = result.Update()
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 4 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[0].Payload != "{ secret }" {
+		t.Errorf("Unexpected SetEncryptionKey payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "{secret newsecret Key2}" {
+		t.Errorf("Unexpected ChangeEncryptionKey payload: %s", calls[1].Payload)
+	}
+
+	if calls[2].URL != "/redfish/v1/Storage/Actions/Storage.SetControllerPassword" {
+		t.Errorf("Unexpected SetControllerPassword URL: %s", calls[2].URL)
+	}
+
+	if !strings.Contains(calls[3].Payload, "EncryptionMode:PasswordWithLocalKey") {
+		t.Errorf("Unexpected EncryptionMode update payload: %s", calls[3].Payload)
+	}
+}
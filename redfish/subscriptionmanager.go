@@ -0,0 +1,166 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SubscriptionManagerOptions controls a SubscriptionManager.
+type SubscriptionManagerOptions struct {
+	// Subscription holds the properties of the subscription to keep. Its
+	// Destination and Context tell the subscription apart from the others of
+	// the service.
+	Subscription EventSubscriptionParameters
+	// CheckInterval is the time between the checks that the subscription
+	// still exists. It defaults to one minute.
+	CheckInterval time.Duration
+	// Heartbeat, if set, sends a test event with this message after each
+	// successful check, so the listener can tell the delivery still works.
+	Heartbeat string
+	// OnCreate, if set, is called with the URI of the subscription each time
+	// it is created, including when it is created again after the service
+	// dropped it.
+	OnCreate func(uri string)
+	// OnError, if set, is called with the errors of the checks run by Run.
+	// The checks are retried at the next interval.
+	OnError func(err error)
+}
+
+// SubscriptionManager keeps an event subscription alive. Some services drop
+// their subscriptions when they reboot or after delivery failures; the
+// manager periodically checks that the subscription still exists and creates
+// it again when it is gone.
+type SubscriptionManager struct {
+	eventService *EventService
+	options      SubscriptionManagerOptions
+
+	lock sync.Mutex
+	uri  string
+}
+
+// NewSubscriptionManager creates a manager for a subscription of an event
+// service. Nothing is sent to the service until Ensure or Run is called.
+func NewSubscriptionManager(eventService *EventService, options SubscriptionManagerOptions) *SubscriptionManager {
+	if options.CheckInterval <= 0 {
+		options.CheckInterval = time.Minute
+	}
+	if options.Subscription.Protocol == "" {
+		options.Subscription.Protocol = RedfishEventDestinationProtocol
+	}
+
+	return &SubscriptionManager{
+		eventService: eventService,
+		options:      options,
+	}
+}
+
+// URI gets the URI of the subscription, or an empty string if it has not
+// been created yet.
+func (manager *SubscriptionManager) URI() string {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	return manager.uri
+}
+
+// Ensure checks that the subscription exists and creates it if it does not.
+// An existing subscription of the service with the same destination and
+// context is adopted rather than duplicated. It reports whether the
+// subscription was created.
+func (manager *SubscriptionManager) Ensure() (bool, error) {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	if manager.uri != "" {
+		_, err := GetEventDestination(manager.eventService.Client, manager.uri)
+		if err == nil {
+			return false, manager.heartbeat()
+		}
+	}
+
+	// The subscription is unknown or could not be read, look it up
+	subscriptions, err := manager.eventService.Subscriptions()
+	if err != nil {
+		// The service is likely unavailable, such as during a reboot; the
+		// subscription may still exist
+		return false, err
+	}
+
+	for _, subscription := range subscriptions {
+		if manager.matches(subscription) {
+			manager.uri = subscription.ODataID
+			return false, manager.heartbeat()
+		}
+	}
+
+	uri, err := manager.eventService.CreateEventSubscription(manager.options.Subscription)
+	if err != nil {
+		return false, err
+	}
+	manager.uri = uri
+
+	if manager.options.OnCreate != nil {
+		manager.options.OnCreate(uri)
+	}
+
+	return true, nil
+}
+
+// Run keeps the subscription alive until the context is done, checking it
+// every CheckInterval. The subscription is not deleted when Run returns; call
+// Delete for that.
+func (manager *SubscriptionManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(manager.options.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := manager.Ensure()
+		if err != nil && manager.options.OnError != nil {
+			manager.options.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Delete deletes the subscription from the service, if it was created.
+func (manager *SubscriptionManager) Delete() error {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+
+	if manager.uri == "" {
+		return nil
+	}
+
+	err := manager.eventService.DeleteEventSubscription(manager.uri)
+	if err != nil {
+		return err
+	}
+	manager.uri = ""
+
+	return nil
+}
+
+// matches tells whether a subscription of the service is the managed one.
+func (manager *SubscriptionManager) matches(subscription *EventDestination) bool {
+	return subscription.Destination == manager.options.Subscription.Destination &&
+		subscription.Context == manager.options.Subscription.Context
+}
+
+// heartbeat sends the heartbeat test event, if enabled.
+func (manager *SubscriptionManager) heartbeat() error {
+	if manager.options.Heartbeat == "" {
+		return nil
+	}
+
+	return manager.eventService.SubmitTestEvent(manager.options.Heartbeat)
+}
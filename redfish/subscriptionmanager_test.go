@@ -0,0 +1,205 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// subscriptionClient is a test client that keeps a collection of event
+// subscriptions, like a service that can drop them when it reboots.
+type subscriptionClient struct {
+	common.TestClient
+	subscriptions map[string]string
+	created       int
+}
+
+func (c *subscriptionClient) response(status int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// Get records the call and returns the collection or a subscription.
+func (c *subscriptionClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+
+	if url == "/redfish/v1/EventService/Subscriptions" {
+		var members []string
+		for uri := range c.subscriptions {
+			members = append(members, fmt.Sprintf(`{"@odata.id": "%s"}`, uri))
+		}
+		return c.response(http.StatusOK, http.Header{}, fmt.Sprintf(`{"Members": [%s], "Members@odata.count": %d}`, strings.Join(members, ","), len(members))), nil
+	}
+
+	destination, ok := c.subscriptions[url]
+	if !ok {
+		return nil, fmt.Errorf("404: %s not found", url)
+	}
+
+	return c.response(http.StatusOK, http.Header{}, fmt.Sprintf(`{
+		"@odata.id": "%s",
+		"Destination": "%s",
+		"Context": "manager",
+		"Protocol": "Redfish"
+	}`, url, destination)), nil
+}
+
+// Post records the call and creates a subscription.
+func (c *subscriptionClient) Post(url string, payload interface{}) (*http.Response, error) {
+	c.TestClient.Post(url, payload)
+
+	if url != "/redfish/v1/EventService/Subscriptions" {
+		return c.response(http.StatusNoContent, http.Header{}, ""), nil
+	}
+
+	c.created++
+	uri := fmt.Sprintf("/redfish/v1/EventService/Subscriptions/%d", c.created)
+	c.subscriptions[uri] = payload.(EventSubscriptionParameters).Destination
+
+	return c.response(http.StatusCreated, http.Header{"Location": {"https://bmc" + uri}}, ""), nil
+}
+
+// TestSubscriptionManagerEnsure tests keeping a subscription alive.
+func TestSubscriptionManagerEnsure(t *testing.T) {
+	testClient := &subscriptionClient{subscriptions: map[string]string{}}
+
+	eventService := &EventService{subscriptions: "/redfish/v1/EventService/Subscriptions"}
+	eventService.SetClient(testClient)
+
+	manager := NewSubscriptionManager(eventService, SubscriptionManagerOptions{
+		Subscription: EventSubscriptionParameters{
+			Destination: "https://listener/events",
+			Context:     "manager",
+		},
+	})
+
+	created, err := manager.Ensure()
+	if err != nil || !created {
+		t.Fatalf("Subscription not created: %t %v", created, err)
+	}
+
+	if manager.URI() != "/redfish/v1/EventService/Subscriptions/1" {
+		t.Errorf("Invalid subscription URI: %s", manager.URI())
+	}
+
+	created, err = manager.Ensure()
+	if err != nil || created {
+		t.Errorf("Existing subscription created again: %t %v", created, err)
+	}
+
+	// The service drops the subscription
+	delete(testClient.subscriptions, manager.URI())
+
+	created, err = manager.Ensure()
+	if err != nil || !created {
+		t.Fatalf("Dropped subscription not created again: %t %v", created, err)
+	}
+
+	if manager.URI() != "/redfish/v1/EventService/Subscriptions/2" {
+		t.Errorf("Invalid subscription URI: %s", manager.URI())
+	}
+
+	if testClient.created != 2 {
+		t.Errorf("Invalid number of created subscriptions: %d", testClient.created)
+	}
+}
+
+// TestSubscriptionManagerAdopt tests that an existing subscription with the
+// same destination and context is not duplicated.
+func TestSubscriptionManagerAdopt(t *testing.T) {
+	testClient := &subscriptionClient{subscriptions: map[string]string{
+		"/redfish/v1/EventService/Subscriptions/7": "https://listener/events",
+	}}
+
+	eventService := &EventService{subscriptions: "/redfish/v1/EventService/Subscriptions"}
+	eventService.SetClient(testClient)
+
+	manager := NewSubscriptionManager(eventService, SubscriptionManagerOptions{
+		Subscription: EventSubscriptionParameters{
+			Destination: "https://listener/events",
+			Context:     "manager",
+		},
+	})
+
+	created, err := manager.Ensure()
+	if err != nil || created {
+		t.Errorf("Existing subscription not adopted: %t %v", created, err)
+	}
+
+	if manager.URI() != "/redfish/v1/EventService/Subscriptions/7" {
+		t.Errorf("Invalid subscription URI: %s", manager.URI())
+	}
+}
+
+// TestSubscriptionManagerRun tests the checks and heartbeats of Run.
+func TestSubscriptionManagerRun(t *testing.T) {
+	testClient := &subscriptionClient{subscriptions: map[string]string{}}
+
+	eventService := &EventService{
+		subscriptions:         "/redfish/v1/EventService/Subscriptions",
+		submitTestEventTarget: "/redfish/v1/EventService/Actions/EventService.SubmitTestEvent",
+	}
+	eventService.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var uris []string
+	manager := NewSubscriptionManager(eventService, SubscriptionManagerOptions{
+		Subscription: EventSubscriptionParameters{
+			Destination: "https://listener/events",
+			Context:     "manager",
+		},
+		CheckInterval: time.Millisecond,
+		Heartbeat:     "heartbeat",
+		OnCreate: func(uri string) {
+			uris = append(uris, uri)
+		},
+		OnError: func(err error) {
+			t.Errorf("Error checking subscription: %s", err)
+		},
+	})
+
+	errs := make(chan error)
+	go func() {
+		errs <- manager.Run(ctx)
+	}()
+
+	// Wait for a few heartbeats, the calls are made with the manager locked
+	deadline := time.After(5 * time.Second)
+	for checks := 0; checks < 2; {
+		select {
+		case <-deadline:
+			t.Fatal("Timed out waiting for heartbeats")
+		case <-time.After(time.Millisecond):
+		}
+		manager.lock.Lock()
+		checks = 0
+		for _, call := range testClient.CapturedCalls() {
+			if call.Action == "POST" && strings.Contains(call.URL, "SubmitTestEvent") {
+				checks++
+			}
+		}
+		manager.lock.Unlock()
+	}
+
+	cancel()
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("Invalid Run error: %v", err)
+	}
+
+	if len(uris) != 1 || uris[0] != "/redfish/v1/EventService/Subscriptions/1" {
+		t.Errorf("Invalid created subscriptions: %v", uris)
+	}
+}
@@ -5,8 +5,12 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -83,15 +87,17 @@ type Task struct {
 	// Description provides a description of this resource.
 	Description string
 	// EndTime shall indicate the time the task was completed.
-	EndTime string
+	EndTime common.DateTime
+	// EstimatedDuration shall indicate the estimated total time needed to
+	// complete the task.
+	EstimatedDuration common.Duration
 	// HidePayload shall be set to True if the Payload object shall not be
 	// returned on GET operations, and set to False if the contents can be
 	// returned normally. If this property is not specified when the Task is
 	// created, the default value shall be False.
 	HidePayload bool
-	// messages shall be an array of messages associated with the task.
-	// TODO: Add processing of messages.
-	messages []string
+	// Messages shall be an array of messages associated with the task.
+	Messages []common.Message
 	// Payload shall contain information detailing the HTTP and JSON payload
 	// information for executing this task. This object shall not be included in
 	// the response if the HidePayload property is set to True.
@@ -101,7 +107,7 @@ type Task struct {
 	// value shall be zero.
 	PercentComplete int
 	// StartTime shall indicate the time the task was started.
-	StartTime string
+	StartTime common.DateTime
 	// TaskMonitor shall contain a URI to Task Monitor as defined in the Redfish
 	// Specification.
 	TaskMonitor string
@@ -146,7 +152,6 @@ func (task *Task) UnmarshalJSON(b []byte) error {
 	type temp Task
 	var t struct {
 		temp
-		Messages common.Links
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -154,34 +159,28 @@ func (task *Task) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	// Extract the links to other entities for later
 	*task = Task(t.temp)
-	task.messages = t.Messages.ToStrings()
+	task.rawData = b
+	task.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the task to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (task Task) MarshalJSON() ([]byte, error) {
+	type temp Task
+	return common.MarshalWithRawData(task.rawData, temp(task))
+}
+
 // GetTask will get a Task instance from the service.
 func GetTask(c common.Client, uri string) (*Task, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var task Task
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &task)
+	err := common.FetchResource(c, uri, &task)
 	if err != nil {
 		return nil, err
 	}
 
-	task.rawData = rawData
-	task.SetClient(c)
 	return &task, nil
 }
 
@@ -199,7 +198,11 @@ func ListReferencedTasks(c common.Client, link string) ([]*Task, error) {
 	}
 
 	for _, taskLink := range links.ItemLinks {
-		task, err := GetTask(c, taskLink)
+		task := new(Task)
+		expanded, err := links.DecodeMember(c, taskLink, task)
+		if err == nil && !expanded {
+			task, err = GetTask(c, taskLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -208,3 +211,203 @@ func ListReferencedTasks(c common.Client, link string) ([]*Task, error) {
 
 	return result, nil
 }
+
+// Finished tells whether the task is in a final state: completed, killed,
+// cancelled or completed with errors.
+func (taskState TaskState) Finished() bool {
+	switch taskState {
+	case CompletedTaskState, KilledTaskState, ExceptionTaskState, CancelledTaskState:
+		return true
+	}
+	return false
+}
+
+// TaskWaitOptions controls how Task.Wait polls a task.
+type TaskWaitOptions struct {
+	// Interval is the time between the first polls. It defaults to 5
+	// seconds.
+	Interval time.Duration
+	// MaxInterval is the longest time between polls. The interval doubles
+	// each time the task is polled without progress, up to MaxInterval. It
+	// defaults to one minute.
+	MaxInterval time.Duration
+	// Progress, if set, is called with the polled task each time its state,
+	// percent complete or messages change.
+	Progress func(task *Task)
+}
+
+// Wait polls the task until it reaches a final state and returns it, with its
+// messages. The task is read from its URI, or from its task monitor if the
+// task has no URI; a task monitor that no longer answers 202 Accepted means
+// the operation is done. The Retry-After header of the service is honored.
+// Wait returns the last task read and the error of the context if the context
+// is done first. A task that finished with errors is not an error; check its
+// TaskState and TaskStatus.
+func (task *Task) Wait(ctx context.Context, options TaskWaitOptions) (*Task, error) {
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Second
+	}
+	if options.MaxInterval <= 0 {
+		options.MaxInterval = time.Minute
+	}
+	if options.MaxInterval < options.Interval {
+		options.MaxInterval = options.Interval
+	}
+
+	current := task
+	interval := options.Interval
+	for !current.TaskState.Finished() {
+		select {
+		case <-ctx.Done():
+			return current, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		polled, retryAfter, err := current.poll()
+		if err != nil {
+			return current, err
+		}
+
+		if taskProgressed(current, polled) {
+			interval = options.Interval
+			if options.Progress != nil {
+				options.Progress(polled)
+			}
+		} else {
+			interval *= 2
+			if interval > options.MaxInterval {
+				interval = options.MaxInterval
+			}
+		}
+		if retryAfter > 0 {
+			interval = retryAfter
+		}
+
+		current = polled
+	}
+
+	return current, nil
+}
+
+// poll reads the current state of the task. It also returns the time the
+// service asked to wait before the next poll, if any.
+func (task *Task) poll() (*Task, time.Duration, error) {
+	uri := task.ODataID
+	monitor := uri == ""
+	if monitor {
+		uri = task.TaskMonitor
+	}
+	if uri == "" {
+		return nil, 0, fmt.Errorf("task has no URI or task monitor to poll")
+	}
+
+	resp, err := task.Client.Get(uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	// The response is decoded here rather than with FetchResource, as its
+	// status and Retry-After header tell how the poll went
+	var polled Task
+	err = common.DecodeResource(task.Client, resp, uri, &polled)
+	if monitor && resp.StatusCode != http.StatusAccepted && (err != nil || polled.TaskState == "") {
+		// Once the operation is done the task monitor answers with the
+		// response of the operation, which is not a task
+		polled = Task{
+			Entity:          task.Entity,
+			PercentComplete: 100,
+			TaskMonitor:     task.TaskMonitor,
+			TaskState:       CompletedTaskState,
+			TaskStatus:      task.TaskStatus,
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if polled.TaskMonitor == "" {
+		polled.TaskMonitor = task.TaskMonitor
+	}
+	if polled.ODataID == "" && !monitor {
+		polled.ODataID = task.ODataID
+	}
+	polled.SetClient(task.Client)
+
+	return &polled, retryAfter, nil
+}
+
+// taskFromResponse gets the task of an operation from the response of the
+// request that started it: the task the service returned, or a task to poll
+// through the task monitor of a 202 Accepted response. An operation the
+// service completed at once gets a completed task. The body of the response
+// is closed.
+func taskFromResponse(c common.Client, resp *http.Response) (*Task, error) {
+	task := &Task{TaskState: CompletedTaskState, PercentComplete: 100}
+	task.SetClient(c)
+	if resp == nil {
+		return task, nil
+	}
+	defer resp.Body.Close()
+
+	// Any body that is not a task, such as the empty body of a 202
+	// Accepted, leaves the task to the task monitor, so the decoding error
+	// and the URI it would name are not needed
+	var returned Task
+	if common.DecodeResource(c, resp, "", &returned) == nil && returned.TaskState != "" {
+		task = &returned
+	} else if resp.StatusCode == http.StatusAccepted {
+		task.TaskState = NewTaskState
+		task.PercentComplete = 0
+	}
+
+	if resp.StatusCode == http.StatusAccepted && task.TaskMonitor == "" {
+		task.TaskMonitor = resp.Header.Get("Location")
+	}
+	if !task.TaskState.Finished() && task.ODataID == "" && task.TaskMonitor == "" {
+		return nil, fmt.Errorf("the service did not return the task of the operation")
+	}
+
+	return task, nil
+}
+
+// taskProgressed tells whether a task changed between two polls.
+func taskProgressed(previous, current *Task) bool {
+	if previous.TaskState != current.TaskState ||
+		previous.PercentComplete != current.PercentComplete ||
+		len(previous.Messages) != len(current.Messages) {
+		return true
+	}
+
+	for i := range previous.Messages {
+		if previous.Messages[i].MessageID != current.Messages[i].MessageID ||
+			previous.Messages[i].Message != current.Messages[i].Message {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or
+// an HTTP date. It returns zero if the header is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
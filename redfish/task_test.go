@@ -5,9 +5,14 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -49,6 +54,10 @@ func TestTask(t *testing.T) {
 		t.Errorf("Received invalid ID: %s", result.ID)
 	}
 
+	if !strings.Contains(string(result.GetRawData()), `"Id": "Task-1"`) {
+		t.Errorf("The raw JSON should be kept: %s", result.GetRawData())
+	}
+
 	if result.Name != "TaskOne" {
 		t.Errorf("Received invalid name: %s", result.Name)
 	}
@@ -65,3 +74,146 @@ func TestTask(t *testing.T) {
 		t.Errorf("Invalid TaskStatus: %s", result.TaskStatus)
 	}
 }
+
+// taskPollClient is a test client that answers GET requests with a sequence
+// of task bodies, repeating the last one.
+type taskPollClient struct {
+	common.TestClient
+	bodies     []string
+	retryAfter string
+}
+
+// Get records the call and returns the next task body.
+func (c *taskPollClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+	body := c.bodies[0]
+	if len(c.bodies) > 1 {
+		c.bodies = c.bodies[1:]
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Retry-After": {c.retryAfter}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func taskPollBody(state string, percent int, messages string) string {
+	return fmt.Sprintf(`{
+		"@odata.id": "/redfish/v1/TaskService/Tasks/1",
+		"Id": "1",
+		"TaskState": "%s",
+		"TaskStatus": "OK",
+		"PercentComplete": %d,
+		"Messages": [%s]
+	}`, state, percent, messages)
+}
+
+// TestTaskWait tests polling a task until it finishes.
+func TestTaskWait(t *testing.T) {
+	testClient := &taskPollClient{
+		bodies: []string{
+			taskPollBody("Running", 10, ""),
+			taskPollBody("Running", 10, ""),
+			taskPollBody("Running", 60, ""),
+			taskPollBody("Completed", 100, `{"MessageId": "Base.1.8.Success", "Message": "Successfully Completed Request"}`),
+		},
+	}
+
+	task := &Task{TaskState: NewTaskState}
+	task.ODataID = "/redfish/v1/TaskService/Tasks/1"
+	task.SetClient(testClient)
+
+	var progress []int
+	result, err := task.Wait(context.Background(), TaskWaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(task *Task) {
+			progress = append(progress, task.PercentComplete)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Error waiting for task: %s", err)
+	}
+
+	if result.TaskState != CompletedTaskState || len(result.Messages) != 1 ||
+		result.Messages[0].MessageID != "Base.1.8.Success" {
+		t.Errorf("Invalid final task: %+v", result)
+	}
+
+	if fmt.Sprint(progress) != "[10 60 100]" {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+
+	if len(testClient.CapturedCalls()) != 4 {
+		t.Errorf("Expected 4 polls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestTaskWaitContext tests that waiting stops with the context.
+func TestTaskWaitContext(t *testing.T) {
+	testClient := &taskPollClient{
+		bodies:     []string{taskPollBody("Running", 10, "")},
+		retryAfter: "1",
+	}
+
+	task := &Task{TaskState: RunningTaskState}
+	task.ODataID = "/redfish/v1/TaskService/Tasks/1"
+	task.SetClient(testClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := task.Wait(ctx, TaskWaitOptions{Interval: time.Millisecond})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the context deadline: %v", err)
+	}
+
+	if result.PercentComplete != 10 {
+		t.Errorf("The last polled task should be returned: %+v", result)
+	}
+
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Errorf("The Retry-After header should have delayed the next poll: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestTaskWaitMonitor tests polling the task monitor of a task.
+func TestTaskWaitMonitor(t *testing.T) {
+	testClient := &taskPollClient{
+		bodies: []string{`{"@odata.id": "/redfish/v1/Systems/1/Volumes/2", "Id": "2"}`},
+	}
+
+	task := &Task{TaskState: RunningTaskState, TaskMonitor: "/redfish/v1/TaskService/TaskMonitors/1"}
+	task.SetClient(testClient)
+
+	result, err := task.Wait(context.Background(), TaskWaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Error waiting for task: %s", err)
+	}
+
+	if result.TaskState != CompletedTaskState || result.PercentComplete != 100 {
+		t.Errorf("The operation response should complete the task: %+v", result)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/TaskService/TaskMonitors/1" {
+		t.Errorf("Unexpected polls: %v", calls)
+	}
+}
+
+// TestParseRetryAfter tests parsing Retry-After headers.
+func TestParseRetryAfter(t *testing.T) {
+	if parseRetryAfter("120") != 2*time.Minute {
+		t.Error("Invalid Retry-After seconds")
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait := parseRetryAfter(date); wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("Invalid Retry-After date: %s", wait)
+	}
+
+	if parseRetryAfter("") != 0 || parseRetryAfter("soon") != 0 {
+		t.Error("Invalid Retry-After headers should be ignored")
+	}
+}
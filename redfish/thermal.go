@@ -6,7 +6,8 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"reflect"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -72,6 +73,10 @@ type Fan struct {
 	// Model shall contain the model information as defined by the manufacturer
 	// for the associated fan.
 	Model string
+	// Oem shall contain the OEM extensions. All values for properties that
+	// this object contains shall conform to the Redfish Specification
+	// described requirements.
+	Oem json.RawMessage
 	// PartNumber shall contain the part number as defined by the manufacturer
 	// for the associated fan.
 	PartNumber string
@@ -110,6 +115,16 @@ type Fan struct {
 	// range but is not critical. The units shall be the same units as the
 	// related Reading property.
 	UpperThresholdNonCritical float32
+	// writeableProperties are the properties the implementation reports as
+	// writable through the Redfish.WriteableProperties annotation.
+	writeableProperties []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (fan *Fan) GetRawData() []byte {
+	return fan.rawData
 }
 
 // UnmarshalJSON unmarshals a Fan object from the raw JSON.
@@ -117,8 +132,18 @@ func (fan *Fan) UnmarshalJSON(b []byte) error {
 	type temp Fan
 	var t struct {
 		temp
-		FanName  string
-		Assembly common.Link
+		FanName             string
+		Assembly            common.Link
+		WriteableProperties []string `json:"@Redfish.WriteableProperties"`
+
+		// Some implementations send these with the wrong JSON type
+		LowerThresholdCritical    common.Float32
+		LowerThresholdFatal       common.Float32
+		LowerThresholdNonCritical common.Float32
+		Reading                   common.Float32
+		UpperThresholdCritical    common.Float32
+		UpperThresholdFatal       common.Float32
+		UpperThresholdNonCritical common.Float32
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -128,36 +153,40 @@ func (fan *Fan) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*fan = Fan(t.temp)
+	fan.LowerThresholdCritical = float32(t.LowerThresholdCritical)
+	fan.LowerThresholdFatal = float32(t.LowerThresholdFatal)
+	fan.LowerThresholdNonCritical = float32(t.LowerThresholdNonCritical)
+	fan.Reading = float32(t.Reading)
+	fan.UpperThresholdCritical = float32(t.UpperThresholdCritical)
+	fan.UpperThresholdFatal = float32(t.UpperThresholdFatal)
+	fan.UpperThresholdNonCritical = float32(t.UpperThresholdNonCritical)
 	fan.assembly = string(t.Assembly)
+	fan.writeableProperties = t.WriteableProperties
 
 	if t.FanName != "" {
 		fan.Name = t.FanName
 	}
+	fan.rawData = b
+	fan.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
-// TODO: Decide if it's worth adding a Client object to this non-Entity object.
-// // Assembly gets the assembly object for this fan.
-// func (fan *Fan) Assembly() (*Assembly, error) {
-// 	if fan.assembly == "" {
-// 		return nil, nil
-// 	}
-
-// 	resp, err := fan.Client.Get(fan.assembly)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer resp.Body.Close()
+// MarshalJSON marshals the fan to JSON, keeping the properties of the raw JSON
+// that are not modeled.
+func (fan Fan) MarshalJSON() ([]byte, error) {
+	type temp Fan
+	return common.MarshalWithRawData(fan.rawData, temp(fan))
+}
 
-// 	var assembly Assembly
-// 	err = json.NewDecoder(resp.Body).Decode(&assembly)
-// 	if err != nil {
-// 		return nil, err
-// 	}
+// Assembly gets the assembly object for this fan.
+func (fan *Fan) Assembly() (*Assembly, error) {
+	if fan.assembly == "" {
+		return nil, nil
+	}
 
-// 	return &assembly, nil
-// }
+	return GetAssembly(fan.Client, fan.assembly)
+}
 
 // Temperature is
 type Temperature struct {
@@ -252,6 +281,64 @@ type Temperature struct {
 	// UpperThresholdNonCritical, UpperThresholdCritical, or
 	// UpperThresholdFatal, unless set by a user.
 	UpperThresholdUser float32
+	// writeableProperties are the properties the implementation reports as
+	// writable through the Redfish.WriteableProperties annotation.
+	writeableProperties []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (temperature *Temperature) GetRawData() []byte {
+	return temperature.rawData
+}
+
+// UnmarshalJSON unmarshals a Temperature object from the raw JSON.
+func (temperature *Temperature) UnmarshalJSON(b []byte) error {
+	type temp Temperature
+	var t struct {
+		temp
+		WriteableProperties []string `json:"@Redfish.WriteableProperties"`
+
+		// Some implementations send these with the wrong JSON type
+		LowerThresholdCritical    common.Float32
+		LowerThresholdFatal       common.Float32
+		LowerThresholdNonCritical common.Float32
+		LowerThresholdUser        common.Float32
+		ReadingCelsius            common.Float32
+		UpperThresholdCritical    common.Float32
+		UpperThresholdFatal       common.Float32
+		UpperThresholdNonCritical common.Float32
+		UpperThresholdUser        common.Float32
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*temperature = Temperature(t.temp)
+	temperature.LowerThresholdCritical = float32(t.LowerThresholdCritical)
+	temperature.LowerThresholdFatal = float32(t.LowerThresholdFatal)
+	temperature.LowerThresholdNonCritical = float32(t.LowerThresholdNonCritical)
+	temperature.LowerThresholdUser = float32(t.LowerThresholdUser)
+	temperature.ReadingCelsius = float32(t.ReadingCelsius)
+	temperature.UpperThresholdCritical = float32(t.UpperThresholdCritical)
+	temperature.UpperThresholdFatal = float32(t.UpperThresholdFatal)
+	temperature.UpperThresholdNonCritical = float32(t.UpperThresholdNonCritical)
+	temperature.UpperThresholdUser = float32(t.UpperThresholdUser)
+	temperature.writeableProperties = t.WriteableProperties
+	temperature.rawData = b
+	temperature.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the temperature to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (temperature Temperature) MarshalJSON() ([]byte, error) {
+	type temp Temperature
+	return common.MarshalWithRawData(temperature.rawData, temp(temperature))
 }
 
 // Thermal is used to represent a thermal metrics resource for a Redfish
@@ -307,52 +394,145 @@ func (thermal *Thermal) UnmarshalJSON(b []byte) error {
 
 	*thermal = Thermal(t.temp)
 
+	for i := range thermal.Fans {
+		resolveRedundancies(thermal.Fans[i].Redundancy, thermal.Redundancy)
+	}
+
 	// This is a read/write object, so we need to save the raw object data for later
 	thermal.rawData = b
+	thermal.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
-// // Update commits updates to this object's properties to the running system.
-// func (thermal *Thermal) Update() error {
+// MarshalJSON marshals the thermal to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (thermal Thermal) MarshalJSON() ([]byte, error) {
+	type temp Thermal
+	return common.MarshalWithRawData(thermal.rawData, temp(thermal))
+}
+
+// Update commits updates to the Fans and Temperatures of this object to the
+// running system. Fans and temperature sensors can only be updated where the
+// schema allows it and, if the implementation reports its writable
+// properties, where the implementation allows it.
+func (thermal *Thermal) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Thermal)
+	err := original.UnmarshalJSON(thermal.rawData)
+	if err != nil {
+		return err
+	}
+
+	if len(original.Fans) != len(thermal.Fans) ||
+		len(original.Temperatures) != len(thermal.Temperatures) {
+		return fmt.Errorf("Fans and Temperatures entries cannot be added or removed")
+	}
 
-// 	// Get a representation of the object's original state so we can find what
-// 	// to update.
-// 	original := new(Thermal)
-// 	original.UnmarshalJSON(thermal.rawData)
+	payload := make(map[string]interface{})
 
-// 	readWriteFields := []string{
-// 		"Fans",
-// 		"Temperatures",
-// 	}
+	fanFields := []string{
+		"IndicatorLED",
+		"Oem",
+	}
+	fans := make([]interface{}, len(thermal.Fans))
+	changed := false
+	for i := range thermal.Fans {
+		update, err := thermalMemberUpdate(
+			reflect.ValueOf(original.Fans[i]),
+			reflect.ValueOf(thermal.Fans[i]),
+			fanFields,
+			original.Fans[i].writeableProperties)
+		if err != nil {
+			return err
+		}
+		fans[i] = update
+		changed = changed || len(update) > 0
+	}
+	if changed {
+		payload["Fans"] = fans
+	}
 
-// 	originalElement := reflect.ValueOf(original).Elem()
-// 	currentElement := reflect.ValueOf(thermal).Elem()
+	temperatureFields := []string{
+		"LowerThresholdUser",
+		"UpperThresholdUser",
+	}
+	temperatures := make([]interface{}, len(thermal.Temperatures))
+	changed = false
+	for i := range thermal.Temperatures {
+		update, err := thermalMemberUpdate(
+			reflect.ValueOf(original.Temperatures[i]),
+			reflect.ValueOf(thermal.Temperatures[i]),
+			temperatureFields,
+			original.Temperatures[i].writeableProperties)
+		if err != nil {
+			return err
+		}
+		temperatures[i] = update
+		changed = changed || len(update) > 0
+	}
+	if changed {
+		payload["Temperatures"] = temperatures
+	}
 
-// 	return thermal.Entity.Update(originalElement, currentElement, readWriteFields)
-// }
+	if len(payload) == 0 {
+		return nil
+	}
 
-// GetThermal will get a Thermal instance from the service.
-func GetThermal(c common.Client, uri string) (*Thermal, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+	_, err = thermal.Client.Patch(thermal.ODataID, payload)
+	return err
+}
+
+// thermalMemberUpdate gets the changed properties of a Fans or Temperatures
+// member. An error is returned if a changed property is not part of
+// readWriteFields or, when the implementation reports them, of its writeable
+// properties.
+func thermalMemberUpdate(original reflect.Value, current reflect.Value, readWriteFields []string, writeable []string) (map[string]interface{}, error) {
+	var names []string
+	for i := 0; i < original.NumField(); i++ {
+		field := original.Type().Field(i)
+		if field.Anonymous || field.PkgPath != "" {
+			continue
+		}
+		names = append(names, field.Name)
 	}
-	defer resp.Body.Close()
 
-	var thermal Thermal
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	update := changedFields(original, current, names...)
+	for field := range update {
+		if !containsString(readWriteFields, field) {
+			return nil, fmt.Errorf("%s field is read only", field)
+		}
+		if len(writeable) > 0 && !containsString(writeable, field) {
+			return nil, fmt.Errorf("%s field is read only on this implementation", field)
+		}
+	}
+
+	return update, nil
+}
+
+// containsString reports whether value is part of values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
+}
 
-	err = json.Unmarshal(rawData, &thermal)
+// GetThermal will get a Thermal instance from the service.
+func GetThermal(c common.Client, uri string) (*Thermal, error) {
+	var thermal Thermal
+	err := common.FetchResource(c, uri, &thermal)
 	if err != nil {
 		return nil, err
 	}
 
-	thermal.rawData = rawData
-	thermal.SetClient(c)
+	for i := range thermal.Fans {
+		thermal.Fans[i].SetClient(c)
+	}
 	return &thermal, nil
 }
 
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/LRichi/WBfish/common"
 )
 
 var thermalBody = `{
@@ -113,3 +115,116 @@ func TestThermal(t *testing.T) {
 		t.Errorf("Invalid fan name: %s", result.Fans[0].Name)
 	}
 }
+
+var thermalUpdateBody = `{
+		"@odata.id": "/redfish/v1/Chassis/1/Thermal",
+		"Id": "Thermal",
+		"Fans": [{
+			"MemberId": "0",
+			"Name": "Fan 0",
+			"IndicatorLED": "Off",
+			"Reading": 4000
+		}],
+		"Temperatures": [{
+			"MemberID": "0",
+			"Name": "Inlet",
+			"UpperThresholdUser": 40
+		}, {
+			"MemberID": "1",
+			"Name": "CPU",
+			"@Redfish.WriteableProperties": ["LowerThresholdUser"],
+			"UpperThresholdUser": 90
+		}]
+	}`
+
+// TestThermalUpdate tests the Update call.
+func TestThermalUpdate(t *testing.T) {
+	var result Thermal
+	err := json.NewDecoder(strings.NewReader(thermalUpdateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Fans[0].IndicatorLED = common.BlinkingIndicatorLED
+	result.Temperatures[0].UpperThresholdUser = 35
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Payload != "map[Fans:[map[IndicatorLED:Blinking]] Temperatures:[map[UpperThresholdUser:35] map[]]]" {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestThermalUpdateReadOnly tests that read only properties are detected
+// before anything is sent to the service.
+func TestThermalUpdateReadOnly(t *testing.T) {
+	var result Thermal
+	err := json.NewDecoder(strings.NewReader(thermalUpdateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Fans[0].Reading = 8000
+	if err := result.Update(); err == nil {
+		t.Error("Expected an error updating a fan reading")
+	}
+
+	result.Fans[0].Reading = 4000
+	result.Temperatures[1].UpperThresholdUser = 80
+	if err := result.Update(); err == nil {
+		t.Error("Expected an error updating a property the implementation reports read only")
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestThermalQuirkyTypes tests reading readings some implementations send as
+// strings.
+func TestThermalQuirkyTypes(t *testing.T) {
+	body := `{
+		"@odata.id": "/redfish/v1/Chassis/1/Thermal",
+		"Id": "Thermal",
+		"Fans": [{
+			"MemberId": "0",
+			"Name": "Fan 0",
+			"Reading": "4200",
+			"LowerThresholdCritical": null
+		}],
+		"Temperatures": [{
+			"MemberId": "0",
+			"Name": "Inlet",
+			"ReadingCelsius": "24.5",
+			"UpperThresholdCritical": "N/A"
+		}]
+	}`
+
+	var result Thermal
+	err := json.NewDecoder(strings.NewReader(body)).Decode(&result)
+
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if result.Fans[0].Reading != 4200 {
+		t.Errorf("Invalid fan reading: %v", result.Fans[0].Reading)
+	}
+
+	if result.Temperatures[0].ReadingCelsius != 24.5 || result.Temperatures[0].UpperThresholdCritical != 0 {
+		t.Errorf("Invalid temperature: %+v", result.Temperatures[0])
+	}
+}
@@ -0,0 +1,115 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ThermalEquipment shall be used to represent the set of cooling equipment
+// for a Redfish implementation.
+type ThermalEquipment struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// cdus shall be a link to a resource collection of type
+	// CoolingUnitCollection that contains the coolant distribution units.
+	cdus string
+	// coolingLoops shall be a link to a resource collection of type
+	// CoolingLoopCollection.
+	coolingLoops string
+	// heatExchangers shall be a link to a resource collection of type
+	// CoolingUnitCollection that contains the heat exchanger units.
+	heatExchangers string
+	// immersionUnits shall be a link to a resource collection of type
+	// CoolingUnitCollection that contains the immersion cooling units.
+	immersionUnits string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (thermalequipment *ThermalEquipment) GetRawData() []byte {
+	return thermalequipment.rawData
+}
+
+// UnmarshalJSON unmarshals a ThermalEquipment object from the raw JSON.
+func (thermalequipment *ThermalEquipment) UnmarshalJSON(b []byte) error {
+	type temp ThermalEquipment
+	var t struct {
+		temp
+		CDUs           common.Link
+		CoolingLoops   common.Link
+		HeatExchangers common.Link
+		ImmersionUnits common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*thermalequipment = ThermalEquipment(t.temp)
+
+	// Extract the links to other entities for later
+	thermalequipment.cdus = string(t.CDUs)
+	thermalequipment.coolingLoops = string(t.CoolingLoops)
+	thermalequipment.heatExchangers = string(t.HeatExchangers)
+	thermalequipment.immersionUnits = string(t.ImmersionUnits)
+
+	thermalequipment.rawData = b
+	thermalequipment.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the thermal equipment to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (thermalequipment ThermalEquipment) MarshalJSON() ([]byte, error) {
+	type temp ThermalEquipment
+	return common.MarshalWithRawData(thermalequipment.rawData, temp(thermalequipment))
+}
+
+// CDUs gets the coolant distribution units.
+func (thermalequipment *ThermalEquipment) CDUs() ([]*CoolingUnit, error) {
+	return ListReferencedCoolingUnits(thermalequipment.Client, thermalequipment.cdus)
+}
+
+// CoolingLoops gets the cooling loops.
+func (thermalequipment *ThermalEquipment) CoolingLoops() ([]*CoolingLoop, error) {
+	return ListReferencedCoolingLoops(thermalequipment.Client, thermalequipment.coolingLoops)
+}
+
+// HeatExchangers gets the heat exchanger units.
+func (thermalequipment *ThermalEquipment) HeatExchangers() ([]*CoolingUnit, error) {
+	return ListReferencedCoolingUnits(thermalequipment.Client, thermalequipment.heatExchangers)
+}
+
+// ImmersionUnits gets the immersion cooling units.
+func (thermalequipment *ThermalEquipment) ImmersionUnits() ([]*CoolingUnit, error) {
+	return ListReferencedCoolingUnits(thermalequipment.Client, thermalequipment.immersionUnits)
+}
+
+// GetThermalEquipment will get a ThermalEquipment instance from the service.
+func GetThermalEquipment(c common.Client, uri string) (*ThermalEquipment, error) {
+	var thermalequipment ThermalEquipment
+	err := common.FetchResource(c, uri, &thermalequipment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &thermalequipment, nil
+}
@@ -0,0 +1,54 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var thermalEquipmentBody = `{
+		"@odata.type": "#ThermalEquipment.v1_0_0.ThermalEquipment",
+		"@odata.id": "/redfish/v1/ThermalEquipment",
+		"Id": "ThermalEquipment",
+		"Name": "Cooling Equipment",
+		"Status": {
+			"State": "Enabled",
+			"HealthRollup": "OK"
+		},
+		"CDUs": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CDUs"
+		},
+		"CoolingLoops": {
+			"@odata.id": "/redfish/v1/ThermalEquipment/CoolingLoops"
+		}
+	}`
+
+// TestThermalEquipment tests the parsing of ThermalEquipment objects.
+func TestThermalEquipment(t *testing.T) {
+	var result ThermalEquipment
+	err := json.NewDecoder(strings.NewReader(thermalEquipmentBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "ThermalEquipment" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.cdus != "/redfish/v1/ThermalEquipment/CDUs" {
+		t.Errorf("Invalid CDUs link: %s", result.cdus)
+	}
+
+	if result.coolingLoops != "/redfish/v1/ThermalEquipment/CoolingLoops" {
+		t.Errorf("Invalid cooling loops link: %s", result.coolingLoops)
+	}
+
+	if result.immersionUnits != "" {
+		t.Errorf("Invalid immersion units link: %s", result.immersionUnits)
+	}
+}
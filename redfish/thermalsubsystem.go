@@ -0,0 +1,146 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ThermalSubsystem shall describe the thermal management subsystem of a
+// chassis for a Redfish implementation.
+type ThermalSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// fans shall be a link to a resource collection of type FanCollection.
+	fans string
+	// filters shall be a link to a resource collection of type
+	// FilterCollection.
+	filters string
+	// heaters shall be a link to a resource collection of type
+	// HeaterCollection.
+	heaters string
+	// leakDetection shall be a link to a resource of type LeakDetection.
+	leakDetection string
+	// pumps shall be a link to a resource collection of type
+	// PumpCollection.
+	pumps string
+	// reservoirs shall be a link to a resource collection of type
+	// ReservoirCollection.
+	reservoirs string
+	// thermalMetrics shall be a link to a resource of type ThermalMetrics.
+	thermalMetrics string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (thermalsubsystem *ThermalSubsystem) GetRawData() []byte {
+	return thermalsubsystem.rawData
+}
+
+// UnmarshalJSON unmarshals a ThermalSubsystem object from the raw JSON.
+func (thermalsubsystem *ThermalSubsystem) UnmarshalJSON(b []byte) error {
+	type temp ThermalSubsystem
+	var t struct {
+		temp
+		Fans           common.Link
+		Filters        common.Link
+		Heaters        common.Link
+		LeakDetection  common.Link
+		Pumps          common.Link
+		Reservoirs     common.Link
+		ThermalMetrics common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*thermalsubsystem = ThermalSubsystem(t.temp)
+
+	// Extract the links to other entities for later
+	thermalsubsystem.fans = string(t.Fans)
+	thermalsubsystem.filters = string(t.Filters)
+	thermalsubsystem.heaters = string(t.Heaters)
+	thermalsubsystem.leakDetection = string(t.LeakDetection)
+	thermalsubsystem.pumps = string(t.Pumps)
+	thermalsubsystem.reservoirs = string(t.Reservoirs)
+	thermalsubsystem.thermalMetrics = string(t.ThermalMetrics)
+
+	thermalsubsystem.rawData = b
+	thermalsubsystem.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the thermal subsystem to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (thermalsubsystem ThermalSubsystem) MarshalJSON() ([]byte, error) {
+	type temp ThermalSubsystem
+	return common.MarshalWithRawData(thermalsubsystem.rawData, temp(thermalsubsystem))
+}
+
+// Fans gets the URI of the collection of fans of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Fans() string {
+	return thermalsubsystem.fans
+}
+
+// Filters gets the filters of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Filters() ([]*Filter, error) {
+	return ListReferencedFilters(thermalsubsystem.Client, thermalsubsystem.filters)
+}
+
+// Heaters gets the heaters of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Heaters() ([]*Heater, error) {
+	return ListReferencedHeaters(thermalsubsystem.Client, thermalsubsystem.heaters)
+}
+
+// LeakDetection gets the leak detection of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) LeakDetection() (*LeakDetection, error) {
+	if thermalsubsystem.leakDetection == "" {
+		return nil, nil
+	}
+
+	return GetLeakDetection(thermalsubsystem.Client, thermalsubsystem.leakDetection)
+}
+
+// Pumps gets the pumps of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Pumps() ([]*Pump, error) {
+	return ListReferencedPumps(thermalsubsystem.Client, thermalsubsystem.pumps)
+}
+
+// Reservoirs gets the reservoirs of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Reservoirs() ([]*Reservoir, error) {
+	return ListReferencedReservoirs(thermalsubsystem.Client, thermalsubsystem.reservoirs)
+}
+
+// ThermalMetrics gets the URI of the thermal metrics of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) ThermalMetrics() string {
+	return thermalsubsystem.thermalMetrics
+}
+
+// GetThermalSubsystem will get a ThermalSubsystem instance from the service.
+func GetThermalSubsystem(c common.Client, uri string) (*ThermalSubsystem, error) {
+	var thermalsubsystem ThermalSubsystem
+	err := common.FetchResource(c, uri, &thermalsubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	return &thermalsubsystem, nil
+}
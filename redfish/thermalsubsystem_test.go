@@ -0,0 +1,64 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var thermalSubsystemBody = `{
+		"@odata.type": "#ThermalSubsystem.v1_1_0.ThermalSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem",
+		"Id": "ThermalSubsystem",
+		"Name": "Thermal Subsystem for Chassis",
+		"Fans": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans"
+		},
+		"Heaters": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters"
+		},
+		"LeakDetection": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/LeakDetection"
+		},
+		"ThermalMetrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/ThermalMetrics"
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestThermalSubsystem tests the parsing of ThermalSubsystem objects.
+func TestThermalSubsystem(t *testing.T) {
+	var result ThermalSubsystem
+	err := json.NewDecoder(strings.NewReader(thermalSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "ThermalSubsystem" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Fans() != "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans" {
+		t.Errorf("Invalid fans link: %s", result.Fans())
+	}
+
+	if result.heaters != "/redfish/v1/Chassis/1U/ThermalSubsystem/Heaters" {
+		t.Errorf("Invalid heaters link: %s", result.heaters)
+	}
+
+	if result.leakDetection != "/redfish/v1/Chassis/1U/ThermalSubsystem/LeakDetection" {
+		t.Errorf("Invalid leak detection link: %s", result.leakDetection)
+	}
+
+	if result.ThermalMetrics() != "/redfish/v1/Chassis/1U/ThermalSubsystem/ThermalMetrics" {
+		t.Errorf("Invalid thermal metrics link: %s", result.ThermalMetrics())
+	}
+}
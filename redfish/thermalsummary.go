@@ -0,0 +1,290 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ThermalSummarySource is the resource a ThermalSummary was read from.
+type ThermalSummarySource string
+
+const (
+	// SensorsThermalSummarySource is a summary read from the Sensors of a
+	// chassis, which services implementing ThermalSubsystem report their
+	// readings through.
+	SensorsThermalSummarySource ThermalSummarySource = "Sensors"
+	// ThermalThermalSummarySource is a summary read from the deprecated
+	// Thermal resource of a chassis.
+	ThermalThermalSummarySource ThermalSummarySource = "Thermal"
+)
+
+// ThermalReading is the reading of a temperature or fan sensor, whichever of
+// the thermal schemas its service implements. Thresholds the service does not
+// report are zero.
+type ThermalReading struct {
+	// URI is the URI of the sensor, or of the Thermal array element for
+	// readings from the Thermal resource.
+	URI string
+	// Name is the name of the sensor.
+	Name string
+	// PhysicalContext is the area or device the sensor measures.
+	PhysicalContext string
+	// Reading is the value of the sensor.
+	Reading float32
+	// Units are the units of the reading and thresholds, in UCUM format:
+	// "Cel" for temperatures, "RPM" or "%" for fans.
+	Units string
+	// LowerCaution and UpperCaution are the bounds of the normal range.
+	LowerCaution float32
+	UpperCaution float32
+	// LowerCritical and UpperCritical are the bounds of the range that is
+	// not yet fatal.
+	LowerCritical float32
+	UpperCritical float32
+	// LowerFatal and UpperFatal are the bounds of the range that is fatal.
+	LowerFatal float32
+	UpperFatal float32
+	// Health is the health of the sensor.
+	Health common.Health
+	// State is the state of the sensor, which is absent or disabled for
+	// sensors that do not read a value.
+	State common.State
+}
+
+// ThermalSummary holds the temperature and fan readings of a chassis.
+type ThermalSummary struct {
+	// Source is the resource the summary was read from. A summary read from
+	// the Sensors may have its fans read from the ThermalSubsystem, and the
+	// temperatures or fans the Sensors lack read from Thermal; the URI of
+	// each reading tells where it was read from.
+	Source ThermalSummarySource
+	// Temperatures are the readings of the temperature sensors.
+	Temperatures []ThermalReading
+	// Fans are the readings of the fan speed sensors.
+	Fans []ThermalReading
+}
+
+// ThermalSummary gets the temperature and fan readings of the chassis. The
+// Sensors are used if the chassis has them, with the fans of the
+// ThermalSubsystem if there are no fan sensors, and the deprecated Thermal
+// resource for the readings that are still missing.
+func (chassis *Chassis) ThermalSummary() (*ThermalSummary, error) {
+	var summary *ThermalSummary
+	if chassis.sensors != "" {
+		sensors, err := chassis.Sensors()
+		if err != nil {
+			return nil, err
+		}
+
+		summary = sensorsThermalSummary(sensors)
+		if len(summary.Fans) == 0 {
+			summary.Fans, err = chassis.thermalSubsystemFans()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(summary.Temperatures) != 0 && len(summary.Fans) != 0 {
+			return summary, nil
+		}
+	}
+
+	thermal, err := chassis.Thermal()
+	if err != nil {
+		return nil, err
+	}
+
+	if thermal == nil {
+		if summary != nil {
+			return summary, nil
+		}
+		return nil, fmt.Errorf("chassis %s does not report its temperatures", chassis.ID)
+	}
+
+	fallback := thermalThermalSummary(thermal)
+	if summary == nil || (len(summary.Temperatures) == 0 && len(summary.Fans) == 0) {
+		return fallback, nil
+	}
+
+	if len(summary.Temperatures) == 0 {
+		summary.Temperatures = fallback.Temperatures
+	}
+	if len(summary.Fans) == 0 {
+		summary.Fans = fallback.Fans
+	}
+
+	return summary, nil
+}
+
+// subsystemFan is the part of a fan of a ThermalSubsystem that is summarized.
+type subsystemFan struct {
+	common.Entity
+	// SpeedPercent is the fan speed, as a percentage of its maximum.
+	SpeedPercent struct {
+		Reading common.Float32
+	}
+	// Status is the status of the fan.
+	Status common.Status
+}
+
+// thermalSubsystemFans gets the readings of the fans of the ThermalSubsystem
+// of the chassis, which has the fans of services that do not report fan
+// speeds through the Sensors.
+func (chassis *Chassis) thermalSubsystemFans() ([]ThermalReading, error) {
+	subsystem, err := chassis.ThermalSubsystem()
+	if err != nil || subsystem == nil || subsystem.Fans() == "" {
+		return nil, err
+	}
+
+	links, err := common.GetCollection(chassis.Client, subsystem.Fans())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ThermalReading
+	for _, fanLink := range links.ItemLinks {
+		fan := new(subsystemFan)
+		expanded, err := links.DecodeMember(chassis.Client, fanLink, fan)
+		if err == nil && !expanded {
+			err = getSubsystemFan(chassis.Client, fanLink, fan)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		uri := fan.ODataID
+		if uri == "" {
+			uri = fanLink
+		}
+		result = append(result, ThermalReading{
+			URI:             uri,
+			Name:            fan.Name,
+			PhysicalContext: string(common.FanPhysicalContext),
+			Reading:         float32(fan.SpeedPercent.Reading),
+			Units:           "%",
+			Health:          fan.Status.Health,
+			State:           fan.Status.State,
+		})
+	}
+
+	return result, nil
+}
+
+// getSubsystemFan reads a fan of a ThermalSubsystem.
+func getSubsystemFan(c common.Client, uri string, fan *subsystemFan) error {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(fan)
+}
+
+// sensorsThermalSummary gets the summary of the temperature and fan sensors.
+func sensorsThermalSummary(sensors []*Sensor) *ThermalSummary {
+	summary := &ThermalSummary{Source: SensorsThermalSummarySource}
+
+	for _, sensor := range sensors {
+		reading := ThermalReading{
+			URI:             sensor.ODataID,
+			Name:            sensor.Name,
+			PhysicalContext: string(sensor.PhysicalContext),
+			Reading:         sensor.Reading,
+			Units:           sensor.ReadingUnits,
+			LowerCaution:    sensor.Thresholds.LowerCaution.Reading,
+			UpperCaution:    sensor.Thresholds.UpperCaution.Reading,
+			LowerCritical:   sensor.Thresholds.LowerCritical.Reading,
+			UpperCritical:   sensor.Thresholds.UpperCritical.Reading,
+			LowerFatal:      sensor.Thresholds.LowerFatal.Reading,
+			UpperFatal:      sensor.Thresholds.UpperFatal.Reading,
+			Health:          sensor.Status.Health,
+			State:           sensor.Status.State,
+		}
+
+		switch {
+		case sensor.ReadingType == TemperatureReadingType:
+			if reading.Units == "" {
+				reading.Units = "Cel"
+			}
+			summary.Temperatures = append(summary.Temperatures, reading)
+		case sensor.ReadingType == RotationalReadingType:
+			if reading.Units == "" {
+				reading.Units = "RPM"
+			}
+			summary.Fans = append(summary.Fans, reading)
+		case sensor.ReadingType == PercentReadingType && sensor.PhysicalContext == common.FanPhysicalContext:
+			if reading.Units == "" {
+				reading.Units = "%"
+			}
+			summary.Fans = append(summary.Fans, reading)
+		}
+	}
+
+	return summary
+}
+
+// thermalThermalSummary gets the summary of the Thermal resource.
+func thermalThermalSummary(thermal *Thermal) *ThermalSummary {
+	summary := &ThermalSummary{Source: ThermalThermalSummarySource}
+
+	for i := range thermal.Temperatures {
+		temperature := &thermal.Temperatures[i]
+		summary.Temperatures = append(summary.Temperatures, ThermalReading{
+			URI:             thermalMemberURI(thermal, temperature.ODataID, "Temperatures", i),
+			Name:            temperature.Name,
+			PhysicalContext: temperature.PhysicalContext,
+			Reading:         temperature.ReadingCelsius,
+			Units:           "Cel",
+			LowerCaution:    temperature.LowerThresholdNonCritical,
+			UpperCaution:    temperature.UpperThresholdNonCritical,
+			LowerCritical:   temperature.LowerThresholdCritical,
+			UpperCritical:   temperature.UpperThresholdCritical,
+			LowerFatal:      temperature.LowerThresholdFatal,
+			UpperFatal:      temperature.UpperThresholdFatal,
+			Health:          temperature.Status.Health,
+			State:           temperature.Status.State,
+		})
+	}
+
+	for i := range thermal.Fans {
+		fan := &thermal.Fans[i]
+		units := "RPM"
+		if fan.ReadingUnits == PercentReadingUnits {
+			units = "%"
+		}
+		summary.Fans = append(summary.Fans, ThermalReading{
+			URI:             thermalMemberURI(thermal, fan.ODataID, "Fans", i),
+			Name:            fan.Name,
+			PhysicalContext: fan.PhysicalContext,
+			Reading:         fan.Reading,
+			Units:           units,
+			LowerCaution:    fan.LowerThresholdNonCritical,
+			UpperCaution:    fan.UpperThresholdNonCritical,
+			LowerCritical:   fan.LowerThresholdCritical,
+			UpperCritical:   fan.UpperThresholdCritical,
+			LowerFatal:      fan.LowerThresholdFatal,
+			UpperFatal:      fan.UpperThresholdFatal,
+			Health:          fan.Status.Health,
+			State:           fan.Status.State,
+		})
+	}
+
+	return summary
+}
+
+// thermalMemberURI gets the URI of a Fans or Temperatures element, which
+// services do not always provide.
+func thermalMemberURI(thermal *Thermal, uri, property string, index int) string {
+	if uri != "" {
+		return uri
+	}
+
+	return fmt.Sprintf("%s#/%s/%d", thermal.ODataID, property, index)
+}
@@ -0,0 +1,174 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TestChassisThermalSummarySensors tests summarizing the Sensors of a chassis.
+func TestChassisThermalSummarySensors(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/Sensors": fmt.Sprintf(`{
+			"Members": [
+				%s,
+				{
+					"@odata.id": "/redfish/v1/Chassis/1U/Sensors/Fan1",
+					"Id": "Fan1",
+					"Name": "Fan 1",
+					"ReadingType": "Percent",
+					"PhysicalContext": "Fan",
+					"Reading": 45,
+					"SpeedRPM": 3400,
+					"Status": {"State": "Enabled", "Health": "OK"}
+				},
+				{
+					"@odata.id": "/redfish/v1/Chassis/1U/Sensors/TotalPower",
+					"Id": "TotalPower",
+					"ReadingType": "Power",
+					"Reading": 374
+				}
+			],
+			"Members@odata.count": 3
+		}`, sensorBody),
+	}}
+
+	chassis := &Chassis{
+		sensors: "/redfish/v1/Chassis/1U/Sensors",
+		thermal: "/redfish/v1/Chassis/1U/Thermal",
+	}
+	chassis.SetClient(testClient)
+
+	summary, err := chassis.ThermalSummary()
+	if err != nil {
+		t.Fatalf("Error reading thermal summary: %s", err)
+	}
+
+	if summary.Source != SensorsThermalSummarySource {
+		t.Errorf("Invalid summary source: %s", summary.Source)
+	}
+
+	if len(summary.Temperatures) != 1 || len(summary.Fans) != 1 {
+		t.Fatalf("Invalid summary: %v", summary)
+	}
+
+	temperature := summary.Temperatures[0]
+	if temperature.Reading != 44 || temperature.Units != "Cel" || temperature.UpperCritical != 95 {
+		t.Errorf("Invalid temperature: %v", temperature)
+	}
+
+	fan := summary.Fans[0]
+	if fan.Name != "Fan 1" || fan.Reading != 45 || fan.Units != "%" || fan.Health != common.OKHealth {
+		t.Errorf("Invalid fan: %v", fan)
+	}
+}
+
+// TestChassisThermalSummaryThermal tests summarizing the Thermal resource of
+// a chassis that has no Sensors.
+func TestChassisThermalSummaryThermal(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Thermal": thermalBody,
+	}}
+
+	chassis := &Chassis{thermal: "/redfish/v1/Thermal"}
+	chassis.SetClient(testClient)
+
+	summary, err := chassis.ThermalSummary()
+	if err != nil {
+		t.Fatalf("Error reading thermal summary: %s", err)
+	}
+
+	if summary.Source != ThermalThermalSummarySource {
+		t.Errorf("Invalid summary source: %s", summary.Source)
+	}
+
+	if len(summary.Temperatures) != 1 || len(summary.Fans) != 1 {
+		t.Fatalf("Invalid summary: %v", summary)
+	}
+
+	temperature := summary.Temperatures[0]
+	if temperature.URI != "/redfish/v1/Temp" || temperature.Reading != 32 || temperature.UpperCaution != 9998 {
+		t.Errorf("Invalid temperature: %v", temperature)
+	}
+
+	fan := summary.Fans[0]
+	if fan.URI != "/redfish/v1/Thermal#/Fans/0" || fan.Reading != 1000 || fan.Units != "RPM" || fan.LowerCritical != 10 {
+		t.Errorf("Invalid fan: %v", fan)
+	}
+}
+
+// TestChassisThermalSummaryFallback tests summarizing a chassis whose Sensors
+// do not have all the readings.
+func TestChassisThermalSummaryFallback(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Chassis/1U/Sensors": fmt.Sprintf(`{
+			"Members": [%s],
+			"Members@odata.count": 1
+		}`, sensorBody),
+		"/redfish/v1/Chassis/1U/ThermalSubsystem": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem",
+			"Id": "ThermalSubsystem",
+			"Fans": {
+				"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans"
+			}
+		}`,
+		"/redfish/v1/Chassis/1U/ThermalSubsystem/Fans": `{
+			"Members": [
+				{"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1"}
+			],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1": `{
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1",
+			"Id": "Bay1",
+			"Name": "Fan Bay 1",
+			"SpeedPercent": {
+				"Reading": "62",
+				"SpeedRPM": 5100
+			},
+			"Status": {"State": "Enabled", "Health": "OK"}
+		}`,
+		"/redfish/v1/Chassis/1U/Sensors/Empty": `{
+			"Members": [],
+			"Members@odata.count": 0
+		}`,
+		"/redfish/v1/Thermal": thermalBody,
+	}}
+
+	chassis := &Chassis{
+		sensors:          "/redfish/v1/Chassis/1U/Sensors",
+		thermalSubsystem: "/redfish/v1/Chassis/1U/ThermalSubsystem",
+		thermal:          "/redfish/v1/Thermal",
+	}
+	chassis.SetClient(testClient)
+
+	summary, err := chassis.ThermalSummary()
+	if err != nil {
+		t.Fatalf("Error reading thermal summary: %s", err)
+	}
+
+	if summary.Source != SensorsThermalSummarySource || len(summary.Temperatures) != 1 || len(summary.Fans) != 1 {
+		t.Fatalf("Invalid summary: %v", summary)
+	}
+
+	fan := summary.Fans[0]
+	if fan.URI != "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1" || fan.Reading != 62 || fan.Units != "%" {
+		t.Errorf("Invalid fan: %v", fan)
+	}
+
+	chassis.sensors = "/redfish/v1/Chassis/1U/Sensors/Empty"
+	chassis.thermalSubsystem = ""
+	summary, err = chassis.ThermalSummary()
+	if err != nil {
+		t.Fatalf("Error reading thermal summary: %s", err)
+	}
+
+	if summary.Source != ThermalThermalSummarySource || len(summary.Temperatures) == 0 || len(summary.Fans) == 0 {
+		t.Errorf("Chassis without thermal sensors should be read from Thermal: %v", summary)
+	}
+}
@@ -0,0 +1,231 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// dellNTPServerAttributes are the iDRAC attributes holding the NTP servers.
+var dellNTPServerAttributes = []string{
+	"NTPConfigGroup.1.NTP1",
+	"NTPConfigGroup.1.NTP2",
+	"NTPConfigGroup.1.NTP3",
+}
+
+// timeZoneOffsetPattern matches a UTC offset, as DateTimeLocalOffset has it.
+var timeZoneOffsetPattern = regexp.MustCompile(`^[+-]\d{2}:\d{2}$`)
+
+// managerTimeSettings are where a manager takes its time settings, as found
+// in its raw data.
+type managerTimeSettings struct {
+	// timeZoneName tells whether the manager has the TimeZoneName property.
+	timeZoneName bool
+	// hpeDateTimeService is the link to the iLO DateTime service.
+	hpeDateTimeService string
+	// dellAttributes is the link to the iDRAC attributes.
+	dellAttributes string
+}
+
+// SetTimeConfiguration sets the NTP servers and time zone of the manager.
+// NTP is enabled with ntpServers, disabled if ntpServers is empty, and left
+// as is if it is nil. The time zone is either a name from the IANA time
+// zone database, such as "Europe/Paris", or a UTC offset, such as "+01:00";
+// it is left as is if it is empty.
+//
+// The settings go where the manager takes them: the NTP settings of its
+// network protocols, its TimeZoneName or DateTimeLocalOffset, or the OEM
+// settings of iLO and iDRAC, which do not take the standard ones. On
+// managers that only have DateTimeLocalOffset, a time zone name is set as
+// its current offset, which does not follow daylight saving time changes.
+func (manager *Manager) SetTimeConfiguration(ntpServers []string, timezone string) error {
+	settings, err := manager.timeSettings()
+	if err != nil {
+		return err
+	}
+
+	if ntpServers != nil {
+		err = manager.setNTPServers(settings, ntpServers)
+		if err != nil {
+			return err
+		}
+	}
+
+	if timezone != "" {
+		err = manager.setTimeZone(settings, timezone)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// timeSettings finds where the manager takes its time settings.
+func (manager *Manager) timeSettings() (managerTimeSettings, error) {
+	var settings managerTimeSettings
+	if len(manager.rawData) == 0 {
+		return settings, nil
+	}
+
+	type hpeLinks struct {
+		Links struct {
+			DateTimeService common.Link
+		}
+	}
+	var t struct {
+		TimeZoneName *string
+		Oem          struct {
+			Hpe hpeLinks
+			Hp  hpeLinks
+		}
+		Links struct {
+			Oem struct {
+				Dell struct {
+					DellAttributes common.Links
+				}
+			}
+		}
+	}
+	err := json.Unmarshal(manager.rawData, &t)
+	if err != nil {
+		return settings, err
+	}
+
+	settings.timeZoneName = t.TimeZoneName != nil
+	settings.hpeDateTimeService = string(t.Oem.Hpe.Links.DateTimeService)
+	if settings.hpeDateTimeService == "" {
+		settings.hpeDateTimeService = string(t.Oem.Hp.Links.DateTimeService)
+	}
+
+	// iDRAC lists the attributes of the manager along with the ones of the
+	// system and lifecycle controller
+	for _, attributes := range t.Links.Oem.Dell.DellAttributes.ToStrings() {
+		if settings.dellAttributes == "" || path.Base(attributes) == manager.ID {
+			settings.dellAttributes = attributes
+		}
+	}
+
+	return settings, nil
+}
+
+// setNTPServers sets the NTP servers of the manager.
+func (manager *Manager) setNTPServers(settings managerTimeSettings, ntpServers []string) error {
+	if settings.hpeDateTimeService != "" {
+		// iLO only uses its static NTP servers if the DHCP ones are disabled
+		// on its ethernet interfaces.
+		_, err := manager.Client.Patch(settings.hpeDateTimeService, map[string]interface{}{
+			"StaticNTPServers": ntpServers,
+		})
+		return err
+	}
+
+	if manager.networkProtocol != "" {
+		networkProtocol, err := manager.NetworkProtocol()
+		if err != nil {
+			return err
+		}
+		if networkProtocol.supportsNTP {
+			return networkProtocol.SetNTP(len(ntpServers) > 0, ntpServers)
+		}
+	}
+
+	if settings.dellAttributes != "" {
+		if len(ntpServers) > len(dellNTPServerAttributes) {
+			return fmt.Errorf("manager %s takes at most %d NTP servers", manager.ID, len(dellNTPServerAttributes))
+		}
+
+		attributes := map[string]interface{}{
+			"NTPConfigGroup.1.NTPEnable": "Disabled",
+		}
+		if len(ntpServers) > 0 {
+			attributes["NTPConfigGroup.1.NTPEnable"] = "Enabled"
+		}
+		for i, attribute := range dellNTPServerAttributes {
+			server := ""
+			if i < len(ntpServers) {
+				server = ntpServers[i]
+			}
+			attributes[attribute] = server
+		}
+
+		_, err := manager.Client.Patch(settings.dellAttributes, map[string]interface{}{
+			"Attributes": attributes,
+		})
+		return err
+	}
+
+	return fmt.Errorf("manager %s does not support NTP configuration", manager.ID)
+}
+
+// setTimeZone sets the time zone of the manager.
+func (manager *Manager) setTimeZone(settings managerTimeSettings, timezone string) error {
+	isOffset := timeZoneOffsetPattern.MatchString(timezone)
+
+	var uri string
+	var payload map[string]interface{}
+	switch {
+	case !isOffset && settings.hpeDateTimeService != "":
+		uri = settings.hpeDateTimeService
+		payload = map[string]interface{}{
+			"TimeZone": map[string]interface{}{"Name": timezone},
+		}
+	case !isOffset && settings.dellAttributes != "":
+		uri = settings.dellAttributes
+		payload = map[string]interface{}{
+			"Attributes": map[string]interface{}{"Time.1.Timezone": timezone},
+		}
+	case !isOffset && settings.timeZoneName:
+		uri = manager.ODataID
+		payload = map[string]interface{}{"TimeZoneName": timezone}
+	default:
+		offset, err := timeZoneOffset(timezone)
+		if err != nil {
+			return err
+		}
+
+		_, err = manager.Client.Patch(manager.ODataID, map[string]interface{}{
+			"DateTimeLocalOffset": offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		manager.DateTimeLocalOffset = offset
+		return nil
+	}
+
+	_, err := manager.Client.Patch(uri, payload)
+	if err != nil {
+		return err
+	}
+
+	if uri == manager.ODataID {
+		manager.TimeZoneName = timezone
+	}
+
+	return nil
+}
+
+// timeZoneOffset gets the current UTC offset of a time zone, as
+// DateTimeLocalOffset has it.
+func timeZoneOffset(timezone string) (string, error) {
+	if timeZoneOffsetPattern.MatchString(timezone) {
+		return timezone, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone '%s': %s", timezone, err)
+	}
+
+	return time.Now().In(location).Format("-07:00"), nil
+}
@@ -0,0 +1,117 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// tpmClearAttribute is a BIOS attribute, and the value to set it to, that a
+// vendor uses to request the trusted module be cleared on the next boot.
+type tpmClearAttribute struct {
+	Name  string
+	Value string
+}
+
+// tpmClearAttributes are the BIOS attributes known to request a TPM clear,
+// in the order they are tried.
+var tpmClearAttributes = []tpmClearAttribute{
+	// HPE
+	{Name: "TpmOperation", Value: "Clear"},
+	// Dell
+	{Name: "TpmClear", Value: "Yes"},
+	// Lenovo
+	{Name: "TrustedComputingGroup_DeviceOperation", Value: "Clear"},
+}
+
+// TrustedModule gets the first trusted module of the system that is
+// present, or nil if the system does not report one.
+func (computersystem *ComputerSystem) TrustedModule() *TrustedModules {
+	for i := range computersystem.TrustedModules {
+		if computersystem.TrustedModules[i].Status.State != common.AbsentState {
+			return &computersystem.TrustedModules[i]
+		}
+	}
+
+	return nil
+}
+
+// ClearTPM requests that the trusted module of the system be cleared. The
+// standard schema has no action for this, so an OEM action advertised by the
+// system is used when one exists, and otherwise one of the BIOS attributes
+// vendors commonly use for the purpose is set. A BIOS based clear takes
+// effect on the next system reset and may require physical presence
+// confirmation depending on the platform policy.
+func (computersystem *ComputerSystem) ClearTPM() error {
+	if target := oemTPMClearTarget(computersystem.rawData); target != "" {
+		_, err := computersystem.Client.Post(target, struct{}{})
+		return err
+	}
+
+	bios, err := computersystem.Bios()
+	if err != nil {
+		return err
+	}
+	if bios == nil {
+		return fmt.Errorf("this system does not support clearing the TPM")
+	}
+
+	for _, attribute := range tpmClearAttributes {
+		if _, ok := bios.Attributes[attribute.Name]; ok {
+			return bios.UpdateBiosAttributes(BiosAttributes{attribute.Name: attribute.Value})
+		}
+	}
+
+	return fmt.Errorf("this system does not support clearing the TPM")
+}
+
+// oemTPMClearTarget looks in the OEM actions of a raw resource payload for an
+// action that clears the TPM and returns its target.
+func oemTPMClearTarget(rawData []byte) string {
+	var t struct {
+		Actions struct {
+			Oem map[string]json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(rawData, &t); err != nil {
+		return ""
+	}
+
+	return findTPMClearTarget(t.Actions.Oem)
+}
+
+// findTPMClearTarget searches, depth first, OEM actions that may be nested
+// under a vendor name.
+func findTPMClearTarget(actions map[string]json.RawMessage) string {
+	for name, raw := range actions {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "#") {
+			if !strings.Contains(lower, "tpm") || !strings.Contains(lower, "clear") {
+				continue
+			}
+
+			var action struct {
+				Target string
+			}
+			if err := json.Unmarshal(raw, &action); err == nil && action.Target != "" {
+				return action.Target
+			}
+			continue
+		}
+
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &nested); err == nil {
+			if target := findTPMClearTarget(nested); target != "" {
+				return target
+			}
+		}
+	}
+
+	return ""
+}
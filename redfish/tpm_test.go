@@ -0,0 +1,156 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var tpmSystemBody = `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"Bios": {
+			"@odata.id": "/redfish/v1/Systems/1/Bios"
+		},
+		"TrustedModules": [
+			{
+				"FirmwareVersion": "7.2.2.0",
+				"InterfaceType": "TPM2_0",
+				"Status": {
+					"State": "Enabled",
+					"Health": "OK"
+				}
+			}
+		]
+	}`
+
+var tpmOemSystemBody = `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"Actions": {
+			"Oem": {
+				"Contoso": {
+					"#ContosoComputerSystem.ClearTpm": {
+						"target": "/redfish/v1/Systems/1/Actions/Oem/ContosoComputerSystem.ClearTpm"
+					}
+				}
+			}
+		}
+	}`
+
+// tpmBiosClient is a test client that answers GET requests with a BIOS
+// resource exposing an HPE style TPM operation attribute.
+type tpmBiosClient struct {
+	common.TestClient
+}
+
+// Get records the call and returns the BIOS resource.
+func (c *tpmBiosClient) Get(url string) (*http.Response, error) {
+	c.TestClient.Get(url)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(strings.NewReader(`{
+			"@odata.id": "/redfish/v1/Systems/1/Bios",
+			"Id": "Bios",
+			"Attributes": {
+				"TpmOperation": "NoAction"
+			},
+			"@Redfish.Settings": {
+				"SettingsObject": {
+					"@odata.id": "/redfish/v1/Systems/1/Bios/Settings"
+				}
+			}
+		}`)),
+	}, nil
+}
+
+// TestComputerSystemTrustedModule tests the parsing of trusted modules.
+func TestComputerSystemTrustedModule(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(tpmSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	module := result.TrustedModule()
+	if module == nil {
+		t.Fatal("Expected a trusted module")
+	}
+
+	if module.InterfaceType != TPM2_0InterfaceType {
+		t.Errorf("Invalid interface type: %s", module.InterfaceType)
+	}
+
+	if module.FirmwareVersion != "7.2.2.0" {
+		t.Errorf("Invalid firmware version: %s", module.FirmwareVersion)
+	}
+}
+
+// TestComputerSystemClearTPMBios tests clearing the TPM through a BIOS
+// attribute.
+func TestComputerSystemClearTPMBios(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(tpmSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &tpmBiosClient{}
+	result.SetClient(testClient)
+
+	err = result.ClearTPM()
+	if err != nil {
+		t.Errorf("Error making ClearTPM call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if calls[1].URL != "/redfish/v1/Systems/1/Bios/Settings" {
+		t.Errorf("Unexpected ClearTPM settings target: %s", calls[1].URL)
+	}
+
+	if calls[1].Payload != "{map[TpmOperation:Clear]}" {
+		t.Errorf("Unexpected ClearTPM payload: %s", calls[1].Payload)
+	}
+}
+
+// TestComputerSystemClearTPMOem tests clearing the TPM through an OEM action.
+func TestComputerSystemClearTPMOem(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(tpmOemSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ClearTPM()
+	if err != nil {
+		t.Errorf("Error making ClearTPM call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].Action != "POST" ||
+		calls[0].URL != "/redfish/v1/Systems/1/Actions/Oem/ContosoComputerSystem.ClearTpm" {
+		t.Errorf("Unexpected ClearTPM call: %v", calls)
+	}
+}
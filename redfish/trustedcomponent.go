@@ -0,0 +1,240 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TrustedComponentType is the type of a trusted component.
+type TrustedComponentType string
+
+const (
+	// DiscreteTrustedComponentType shall indicate that the entity has a
+	// well-defined physical boundary within the chassis.
+	DiscreteTrustedComponentType TrustedComponentType = "Discrete"
+	// IntegratedTrustedComponentType shall indicate that the entity is
+	// integrated into another device.
+	IntegratedTrustedComponentType TrustedComponentType = "Integrated"
+)
+
+// TrustedComponentTPM shall contain TPM-specific information for a trusted
+// component.
+type TrustedComponentTPM struct {
+	// CapabilitiesVendorID shall contain an ASCII string of the 4-byte TCG
+	// capabilities vendor ID for this trusted component.
+	CapabilitiesVendorID string
+	// HardwareInterfaceVendorID shall contain the TCG-defined 'TPM Hardware
+	// Interface Vendor ID' for this trusted component.
+	HardwareInterfaceVendorID string
+}
+
+// TrustedComponent shall represent a trusted component in a Redfish
+// implementation, such as a root of trust or a TPM.
+type TrustedComponent struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// certificates shall be a link to a resource collection of type
+	// CertificateCollection that contains the device identity certificates
+	// of the trusted component.
+	certificates string
+	// Description provides a description of this resource.
+	Description string
+	// FirmwareVersion shall contain a version number associated with the
+	// active software image on the trusted component.
+	FirmwareVersion string
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the trusted component.
+	Manufacturer string
+	// Model shall contain the name by which the manufacturer generally
+	// refers to the trusted component.
+	Model string
+	// PartNumber shall contain a part number assigned by the organization
+	// that is responsible for producing or manufacturing the trusted
+	// component.
+	PartNumber string
+	// SKU shall contain the stock-keeping unit number for this trusted
+	// component.
+	SKU string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the trusted component.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TPM shall contain TPM-specific information for this trusted component.
+	TPM TrustedComponentTPM
+	// TrustedComponentType shall contain the type of trusted component.
+	TrustedComponentType TrustedComponentType
+	// UUID shall contain a universally unique identifier number for the
+	// trusted component.
+	UUID string
+	// activeSoftwareImage is the software inventory resource that represents
+	// the active firmware image for this trusted component.
+	activeSoftwareImage string
+	// componentIntegrity are the ComponentIntegrity resources for which
+	// this trusted component is responsible.
+	componentIntegrity []string
+	// componentsProtected are the resources that this trusted component
+	// protects.
+	componentsProtected []string
+	// integratedInto is the resource to which this trusted component is
+	// physically integrated.
+	integratedInto string
+	// owner is the resource that owns this trusted component.
+	owner string
+	// softwareImages are the software inventory resources that represent the
+	// firmware images that apply to this trusted component.
+	softwareImages []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (trustedcomponent *TrustedComponent) GetRawData() []byte {
+	return trustedcomponent.rawData
+}
+
+// UnmarshalJSON unmarshals a TrustedComponent object from the raw JSON.
+func (trustedcomponent *TrustedComponent) UnmarshalJSON(b []byte) error {
+	type temp TrustedComponent
+	var t struct {
+		temp
+		Certificates common.Link
+		Links        struct {
+			ActiveSoftwareImage common.Link
+			ComponentIntegrity  common.Links
+			ComponentsProtected common.Links
+			IntegratedInto      common.Link
+			Owner               common.Link
+			SoftwareImages      common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*trustedcomponent = TrustedComponent(t.temp)
+
+	// Extract the links to other entities for later
+	trustedcomponent.certificates = string(t.Certificates)
+	trustedcomponent.activeSoftwareImage = string(t.Links.ActiveSoftwareImage)
+	trustedcomponent.componentIntegrity = t.Links.ComponentIntegrity.ToStrings()
+	trustedcomponent.componentsProtected = t.Links.ComponentsProtected.ToStrings()
+	trustedcomponent.integratedInto = string(t.Links.IntegratedInto)
+	trustedcomponent.owner = string(t.Links.Owner)
+	trustedcomponent.softwareImages = t.Links.SoftwareImages.ToStrings()
+
+	trustedcomponent.rawData = b
+	trustedcomponent.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the trusted component to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (trustedcomponent TrustedComponent) MarshalJSON() ([]byte, error) {
+	type temp TrustedComponent
+	return common.MarshalWithRawData(trustedcomponent.rawData, temp(trustedcomponent))
+}
+
+// Certificates gets the URI of the collection of device identity
+// certificates of this trusted component.
+func (trustedcomponent *TrustedComponent) Certificates() string {
+	return trustedcomponent.certificates
+}
+
+// ActiveSoftwareImage gets the URI of the software inventory resource that
+// represents the active firmware image for this trusted component.
+func (trustedcomponent *TrustedComponent) ActiveSoftwareImage() string {
+	return trustedcomponent.activeSoftwareImage
+}
+
+// SoftwareImages gets the URIs of the software inventory resources that
+// represent the firmware images that apply to this trusted component.
+func (trustedcomponent *TrustedComponent) SoftwareImages() []string {
+	return trustedcomponent.softwareImages
+}
+
+// ComponentIntegrity gets the integrity information for which this trusted
+// component is responsible.
+func (trustedcomponent *TrustedComponent) ComponentIntegrity() ([]*ComponentIntegrity, error) {
+	var result []*ComponentIntegrity
+	for _, uri := range trustedcomponent.componentIntegrity {
+		componentintegrity, err := GetComponentIntegrity(trustedcomponent.Client, uri)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, componentintegrity)
+	}
+
+	return result, nil
+}
+
+// ComponentsProtected gets the URIs of the resources that this trusted
+// component protects.
+func (trustedcomponent *TrustedComponent) ComponentsProtected() []string {
+	return trustedcomponent.componentsProtected
+}
+
+// IntegratedInto gets the URI of the resource to which this trusted component
+// is physically integrated.
+func (trustedcomponent *TrustedComponent) IntegratedInto() string {
+	return trustedcomponent.integratedInto
+}
+
+// Owner gets the URI of the resource that owns this trusted component, such
+// as the manager or device it acts as the root of trust for.
+func (trustedcomponent *TrustedComponent) Owner() string {
+	return trustedcomponent.owner
+}
+
+// GetTrustedComponent will get a TrustedComponent instance from the service.
+func GetTrustedComponent(c common.Client, uri string) (*TrustedComponent, error) {
+	var trustedcomponent TrustedComponent
+	err := common.FetchResource(c, uri, &trustedcomponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trustedcomponent, nil
+}
+
+// ListReferencedTrustedComponents gets the collection of TrustedComponent from
+// a provided reference.
+func ListReferencedTrustedComponents(c common.Client, link string) ([]*TrustedComponent, error) {
+	var result []*TrustedComponent
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, trustedcomponentLink := range links.ItemLinks {
+		trustedcomponent := new(TrustedComponent)
+		expanded, err := links.DecodeMember(c, trustedcomponentLink, trustedcomponent)
+		if err == nil && !expanded {
+			trustedcomponent, err = GetTrustedComponent(c, trustedcomponentLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, trustedcomponent)
+	}
+
+	return result, nil
+}
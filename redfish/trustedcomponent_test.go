@@ -0,0 +1,94 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var trustedComponentBody = `{
+		"@odata.type": "#TrustedComponent.v1_0_0.TrustedComponent",
+		"@odata.id": "/redfish/v1/Chassis/1/TrustedComponents/RoT",
+		"Id": "RoT",
+		"Name": "Platform Root of Trust",
+		"TrustedComponentType": "Discrete",
+		"Manufacturer": "Contoso",
+		"Model": "RoT-2000",
+		"SerialNumber": "RT9281A",
+		"FirmwareVersion": "1.2.3",
+		"UUID": "652bd2ba-7a9e-4a6c-b161-ce1ae31b2bc5",
+		"Certificates": {
+			"@odata.id": "/redfish/v1/Chassis/1/TrustedComponents/RoT/Certificates"
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"ActiveSoftwareImage": {
+				"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/RoT"
+			},
+			"ComponentIntegrity": [
+				{
+					"@odata.id": "/redfish/v1/ComponentIntegrity/RoT"
+				}
+			],
+			"ComponentsProtected": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC"
+				},
+				{
+					"@odata.id": "/redfish/v1/Systems/1"
+				}
+			],
+			"Owner": {
+				"@odata.id": "/redfish/v1/Chassis/1"
+			}
+		}
+	}`
+
+// TestTrustedComponent tests the parsing of TrustedComponent objects.
+func TestTrustedComponent(t *testing.T) {
+	var result TrustedComponent
+	err := json.NewDecoder(strings.NewReader(trustedComponentBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "RoT" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.TrustedComponentType != DiscreteTrustedComponentType {
+		t.Errorf("Invalid trusted component type: %s", result.TrustedComponentType)
+	}
+
+	if result.FirmwareVersion != "1.2.3" {
+		t.Errorf("Invalid firmware version: %s", result.FirmwareVersion)
+	}
+
+	if result.Certificates() != "/redfish/v1/Chassis/1/TrustedComponents/RoT/Certificates" {
+		t.Errorf("Invalid certificates link: %s", result.Certificates())
+	}
+
+	if result.ActiveSoftwareImage() != "/redfish/v1/UpdateService/FirmwareInventory/RoT" {
+		t.Errorf("Invalid active software image: %s", result.ActiveSoftwareImage())
+	}
+
+	if len(result.componentIntegrity) != 1 {
+		t.Errorf("Invalid component integrity links: %v", result.componentIntegrity)
+	}
+
+	if len(result.ComponentsProtected()) != 2 {
+		t.Errorf("Invalid components protected: %v", result.ComponentsProtected())
+	}
+
+	if result.Owner() != "/redfish/v1/Chassis/1" {
+		t.Errorf("Invalid owner: %s", result.Owner())
+	}
+}
@@ -0,0 +1,178 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// SimpleUpdateParameters are the parameters of the UpdateService.SimpleUpdate
+// action.
+type SimpleUpdateParameters struct {
+	// ImageURI shall contain an RFC3986-defined URI that links to a software
+	// image that the update service retrieves to install software in that
+	// image.
+	ImageURI string
+	// Password shall contain the password to access the URI specified by
+	// the ImageURI parameter.
+	Password string `json:",omitempty"`
+	// Targets shall contain an array of URIs that indicate where to apply
+	// the update image.
+	Targets []string `json:",omitempty"`
+	// TransferProtocol shall contain the network protocol that the update
+	// service uses to retrieve the software image.
+	TransferProtocol TransferProtocolType `json:",omitempty"`
+	// Username shall contain the username to access the URI specified by
+	// the ImageURI parameter.
+	Username string `json:",omitempty"`
+	// OperationApplyTime is when the service applies the image, such as
+	// Immediate or OnReset. The service default is used if unset.
+	OperationApplyTime common.OperationApplyTime `json:"@Redfish.OperationApplyTime,omitempty"`
+}
+
+// UpdateService shall represent an update service and the properties that
+// affect the service itself for a Redfish implementation.
+type UpdateService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// firmwareInventory shall contain a link to a resource collection of type
+	// SoftwareInventoryCollection for the firmware.
+	firmwareInventory string
+	// HTTPPushURI shall contain a URI at which the update service supports
+	// an HTTP or HTTPS POST of a software image for the purpose of installing
+	// software contained within the image.
+	HTTPPushURI string `json:"HttpPushUri"`
+	// MultipartHTTPPushURI shall contain a URI used to perform a Redfish
+	// Specification-defined multipart HTTP or HTTPS POST of a software image
+	// for the purpose of installing software contained within the image.
+	MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	// MaxImageSizeBytes shall indicate the maximum size of the software
+	// update image that clients can send to this update service.
+	MaxImageSizeBytes int
+	// ServiceEnabled shall indicate whether this service is enabled.
+	ServiceEnabled bool
+	// softwareInventory shall contain a link to a resource collection of type
+	// SoftwareInventoryCollection for the software.
+	softwareInventory string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TransferProtocols are the network protocols the service allows for the
+	// SimpleUpdate action.
+	TransferProtocols []TransferProtocolType
+	// simpleUpdateTarget is the URL to send SimpleUpdate actions to.
+	simpleUpdateTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (updateservice *UpdateService) GetRawData() []byte {
+	return updateservice.rawData
+}
+
+// UnmarshalJSON unmarshals a UpdateService object from the raw JSON.
+func (updateservice *UpdateService) UnmarshalJSON(b []byte) error {
+	type temp UpdateService
+	type Actions struct {
+		SimpleUpdate struct {
+			Target            string
+			TransferProtocols []TransferProtocolType `json:"TransferProtocol@Redfish.AllowableValues"`
+		} `json:"#UpdateService.SimpleUpdate"`
+	}
+	var t struct {
+		temp
+		FirmwareInventory common.Link
+		SoftwareInventory common.Link
+		Actions           Actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*updateservice = UpdateService(t.temp)
+
+	// Extract the links to other entities for later
+	updateservice.firmwareInventory = string(t.FirmwareInventory)
+	updateservice.softwareInventory = string(t.SoftwareInventory)
+	updateservice.simpleUpdateTarget = t.Actions.SimpleUpdate.Target
+	updateservice.TransferProtocols = t.Actions.SimpleUpdate.TransferProtocols
+
+	// This is a read/write object, so we need to save the raw object data for later
+	updateservice.rawData = b
+	updateservice.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the update service to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (updateservice UpdateService) MarshalJSON() ([]byte, error) {
+	type temp UpdateService
+	return common.MarshalWithRawData(updateservice.rawData, temp(updateservice))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (updateservice *UpdateService) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(UpdateService)
+	original.UnmarshalJSON(updateservice.rawData)
+
+	readWriteFields := []string{
+		"ServiceEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(updateservice).Elem()
+
+	return updateservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetUpdateService will get a UpdateService instance from the service.
+func GetUpdateService(c common.Client, uri string) (*UpdateService, error) {
+	var updateservice UpdateService
+	err := common.FetchResource(c, uri, &updateservice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updateservice, nil
+}
+
+// FirmwareInventory gets the firmware that this update service can update.
+func (updateservice *UpdateService) FirmwareInventory() ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventories(updateservice.Client, updateservice.firmwareInventory)
+}
+
+// SoftwareInventory gets the software that this update service can update.
+func (updateservice *UpdateService) SoftwareInventory() ([]*SoftwareInventory, error) {
+	return ListReferencedSoftwareInventories(updateservice.Client, updateservice.softwareInventory)
+}
+
+// SimpleUpdate shall update installed software components by using a
+// software image file located at an ImageURI parameter-specified URI.
+func (updateservice *UpdateService) SimpleUpdate(parameters *SimpleUpdateParameters) error {
+	if updateservice.simpleUpdateTarget == "" {
+		return fmt.Errorf("SimpleUpdate is not supported by this update service")
+	}
+
+	_, err := updateservice.Client.Post(updateservice.simpleUpdateTarget, parameters)
+	return err
+}
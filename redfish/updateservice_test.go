@@ -0,0 +1,262 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var updateServiceBody = `{
+		"@odata.type": "#UpdateService.v1_8_0.UpdateService",
+		"@odata.id": "/redfish/v1/UpdateService",
+		"Id": "UpdateService",
+		"Name": "Update Service",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"ServiceEnabled": true,
+		"HttpPushUri": "/redfish/v1/UpdateService/update",
+		"MultipartHttpPushUri": "/redfish/v1/UpdateService/upload",
+		"MaxImageSizeBytes": 104857600,
+		"FirmwareInventory": {
+			"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory"
+		},
+		"SoftwareInventory": {
+			"@odata.id": "/redfish/v1/UpdateService/SoftwareInventory"
+		},
+		"Actions": {
+			"#UpdateService.SimpleUpdate": {
+				"target": "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate",
+				"TransferProtocol@Redfish.AllowableValues": [
+					"HTTP",
+					"HTTPS"
+				]
+			}
+		}
+	}`
+
+// TestUpdateService tests the parsing of UpdateService objects.
+func TestUpdateService(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(updateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "UpdateService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.HTTPPushURI != "/redfish/v1/UpdateService/update" {
+		t.Errorf("Invalid HTTP push URI: %s", result.HTTPPushURI)
+	}
+
+	if result.MultipartHTTPPushURI != "/redfish/v1/UpdateService/upload" {
+		t.Errorf("Invalid multipart HTTP push URI: %s", result.MultipartHTTPPushURI)
+	}
+
+	if result.firmwareInventory != "/redfish/v1/UpdateService/FirmwareInventory" {
+		t.Errorf("Invalid firmware inventory link: %s", result.firmwareInventory)
+	}
+
+	if result.simpleUpdateTarget != "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" {
+		t.Errorf("Invalid SimpleUpdate target: %s", result.simpleUpdateTarget)
+	}
+
+	if len(result.TransferProtocols) != 2 || result.TransferProtocols[1] != HTTPSTransferProtocolType {
+		t.Errorf("Invalid transfer protocols: %v", result.TransferProtocols)
+	}
+}
+
+// TestUpdateServiceSimpleUpdate tests the SimpleUpdate call.
+func TestUpdateServiceSimpleUpdate(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(updateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SimpleUpdate(&SimpleUpdateParameters{
+		ImageURI:         "https://images.example.com/bmc.bin",
+		TransferProtocol: HTTPSTransferProtocolType,
+	})
+	if err != nil {
+		t.Errorf("Error making SimpleUpdate call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "https://images.example.com/bmc.bin") {
+		t.Errorf("Unexpected SimpleUpdate payload: %s", calls[0].Payload)
+	}
+}
+
+var firmwareInventoryBodies = map[string]string{
+	"/redfish/v1/UpdateService/FirmwareInventory": `{
+		"Members": [
+			{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC"}
+		],
+		"Members@odata.count": 1
+	}`,
+	"/redfish/v1/UpdateService/FirmwareInventory/BMC": `{
+		"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC",
+		"Id": "BMC",
+		"Version": "1.45.455b66-rev4"
+	}`,
+}
+
+// updatingClient is a test client whose firmware inventory reports a new
+// version of the BMC firmware once an image is sent.
+type updatingClient struct {
+	resourceClient
+	version string
+}
+
+// newUpdatingClient makes a client whose BMC firmware is updated to version.
+func newUpdatingClient(version string) *updatingClient {
+	resources := make(map[string]string)
+	for uri, body := range firmwareInventoryBodies {
+		resources[uri] = body
+	}
+
+	return &updatingClient{resourceClient: resourceClient{resources: resources}, version: version}
+}
+
+// update makes the inventory report the new version.
+func (c *updatingClient) update() {
+	uri := "/redfish/v1/UpdateService/FirmwareInventory/BMC"
+	c.resources[uri] = strings.Replace(firmwareInventoryBodies[uri], "1.45.455b66-rev4", c.version, 1)
+}
+
+// Post records the call and updates the firmware.
+func (c *updatingClient) Post(url string, payload interface{}) (*http.Response, error) {
+	c.update()
+	return c.resourceClient.Post(url, payload)
+}
+
+// PostMultipart records the call and updates the firmware.
+func (c *updatingClient) PostMultipart(url string, parts []common.MultipartPart) (*http.Response, error) {
+	c.update()
+	return c.resourceClient.PostMultipart(url, parts)
+}
+
+// TestUpdateServiceUpdateFirmware tests updating firmware from an image URI.
+func TestUpdateServiceUpdateFirmware(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(updateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &resourceClient{resources: firmwareInventoryBodies}
+	result.SetClient(testClient)
+
+	options := FirmwareUpdateOptions{
+		Targets:         []string{"/redfish/v1/UpdateService/FirmwareInventory/BMC"},
+		ApplyTime:       common.OnResetOperationApplyTime,
+		ExpectedVersion: "1.45.455b66-rev4",
+		Wait:            TaskWaitOptions{Interval: time.Millisecond},
+		RebootTimeout:   10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	task, err := result.UpdateFirmware(ctx, "https://images.example.com/bmc.bin", options)
+	if err != nil {
+		t.Fatalf("Error updating firmware: %s", err)
+	}
+
+	if task.TaskState != CompletedTaskState {
+		t.Errorf("Invalid task state: %s", task.TaskState)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" ||
+		!strings.Contains(calls[0].Payload, "https://images.example.com/bmc.bin") ||
+		!strings.Contains(calls[0].Payload, "FirmwareInventory/BMC") ||
+		!strings.Contains(calls[0].Payload, "OnReset") {
+		t.Errorf("Unexpected SimpleUpdate call: %v", calls[0])
+	}
+
+	options.ExpectedVersion = "1.46"
+	_, err = result.UpdateFirmware(ctx, "https://images.example.com/bmc.bin", options)
+	if err == nil || !strings.Contains(err.Error(), "1.46") {
+		t.Errorf("Expected an error about the missing version: %v", err)
+	}
+}
+
+// TestUpdateServiceUpdateFirmwarePush tests updating firmware by pushing a
+// local image.
+func TestUpdateServiceUpdateFirmwarePush(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(updateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	image, err := ioutil.TempFile("", "firmware")
+	if err != nil {
+		t.Fatalf("Error creating image: %s", err)
+	}
+	defer os.Remove(image.Name())
+	image.Close()
+
+	testClient := newUpdatingClient("1.46")
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	options := FirmwareUpdateOptions{
+		ExpectedVersion: "1.46",
+		Wait:            TaskWaitOptions{Interval: time.Millisecond},
+		RebootTimeout:   10 * time.Millisecond,
+	}
+	_, err = result.UpdateFirmware(ctx, image.Name(), options)
+	if err != nil {
+		t.Fatalf("Error updating firmware: %s", err)
+	}
+
+	var pushed bool
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == "POST" {
+			pushed = call.URL == "/redfish/v1/UpdateService/upload" && call.Payload == "[UpdateParameters UpdateFile]"
+		}
+	}
+	if !pushed {
+		t.Errorf("Unexpected push calls: %v", testClient.CapturedCalls())
+	}
+
+	// Without targets, a version the inventory already reported does not
+	// tell the update is done
+	result.SetClient(newUpdatingClient("1.45.455b66-rev4"))
+	options.ExpectedVersion = "1.45.455b66-rev4"
+	_, err = result.UpdateFirmware(ctx, image.Name(), options)
+	if err == nil {
+		t.Errorf("An unchanged version should not be taken for the update")
+	}
+}
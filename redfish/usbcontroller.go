@@ -0,0 +1,162 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// USBController shall represent a USB controller in a Redfish
+// implementation.
+type USBController struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the USB controller.
+	Manufacturer string
+	// Model shall contain the manufacturer-provided model information of
+	// this USB controller.
+	Model string
+	// PartNumber shall contain the manufacturer-provided part number for the
+	// USB controller.
+	PartNumber string
+	// ports shall be a link to a resource collection of type PortCollection.
+	ports string
+	// SKU shall contain the SKU number for this USB controller.
+	SKU string
+	// SerialNumber shall contain a manufacturer-allocated number that
+	// identifies the USB controller.
+	SerialNumber string
+	// SparePartNumber shall contain the spare part number of the USB
+	// controller.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// pcieDevice is the PCIe device that represents this USB controller.
+	pcieDevice string
+	// processors are the processors that can utilize this USB controller.
+	processors []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (usbcontroller *USBController) GetRawData() []byte {
+	return usbcontroller.rawData
+}
+
+// UnmarshalJSON unmarshals a USBController object from the raw JSON.
+func (usbcontroller *USBController) UnmarshalJSON(b []byte) error {
+	type temp USBController
+	var t struct {
+		temp
+		Ports common.Link
+		Links struct {
+			PCIeDevice common.Link
+			Processors common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*usbcontroller = USBController(t.temp)
+
+	// Extract the links to other entities for later
+	usbcontroller.ports = string(t.Ports)
+	usbcontroller.pcieDevice = string(t.Links.PCIeDevice)
+	usbcontroller.processors = t.Links.Processors.ToStrings()
+
+	usbcontroller.rawData = b
+	usbcontroller.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the USB controller to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (usbcontroller USBController) MarshalJSON() ([]byte, error) {
+	type temp USBController
+	return common.MarshalWithRawData(usbcontroller.rawData, temp(usbcontroller))
+}
+
+// Ports gets the ports of this USB controller.
+func (usbcontroller *USBController) Ports() ([]*Port, error) {
+	return ListReferencedPorts(usbcontroller.Client, usbcontroller.ports)
+}
+
+// PCIeDevice gets the PCIe device that represents this USB controller.
+func (usbcontroller *USBController) PCIeDevice() (*PCIeDevice, error) {
+	if usbcontroller.pcieDevice == "" {
+		return nil, nil
+	}
+
+	return GetPCIeDevice(usbcontroller.Client, usbcontroller.pcieDevice)
+}
+
+// Processors gets the processors that can utilize this USB controller.
+func (usbcontroller *USBController) Processors() ([]*Processor, error) {
+	var result []*Processor
+	for _, processorLink := range usbcontroller.processors {
+		processor, err := GetProcessor(usbcontroller.Client, processorLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, processor)
+	}
+
+	return result, nil
+}
+
+// GetUSBController will get a USBController instance from the service.
+func GetUSBController(c common.Client, uri string) (*USBController, error) {
+	var usbcontroller USBController
+	err := common.FetchResource(c, uri, &usbcontroller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &usbcontroller, nil
+}
+
+// ListReferencedUSBControllers gets the collection of USBController from
+// a provided reference.
+func ListReferencedUSBControllers(c common.Client, link string) ([]*USBController, error) {
+	var result []*USBController
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, usbcontrollerLink := range links.ItemLinks {
+		usbcontroller := new(USBController)
+		expanded, err := links.DecodeMember(c, usbcontrollerLink, usbcontroller)
+		if err == nil && !expanded {
+			usbcontroller, err = GetUSBController(c, usbcontrollerLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, usbcontroller)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var usbControllerBody = `{
+		"@odata.type": "#USBController.v1_0_0.USBController",
+		"@odata.id": "/redfish/v1/Systems/1/USBControllers/FrontPanel",
+		"Id": "FrontPanel",
+		"Name": "Front Panel USB Controller",
+		"Manufacturer": "Contoso",
+		"Model": "USBv3 Controller",
+		"PartNumber": "USB-3-1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Ports": {
+			"@odata.id": "/redfish/v1/Systems/1/USBControllers/FrontPanel/Ports"
+		},
+		"Links": {
+			"PCIeDevice": {
+				"@odata.id": "/redfish/v1/Chassis/1/PCIeDevices/USB"
+			},
+			"Processors": [
+				{
+					"@odata.id": "/redfish/v1/Systems/1/Processors/CPU1"
+				}
+			]
+		}
+	}`
+
+// TestUSBController tests the parsing of USBController objects.
+func TestUSBController(t *testing.T) {
+	var result USBController
+	err := json.NewDecoder(strings.NewReader(usbControllerBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "FrontPanel" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Manufacturer != "Contoso" {
+		t.Errorf("Invalid manufacturer: %s", result.Manufacturer)
+	}
+
+	if result.ports != "/redfish/v1/Systems/1/USBControllers/FrontPanel/Ports" {
+		t.Errorf("Invalid ports link: %s", result.ports)
+	}
+
+	if result.pcieDevice != "/redfish/v1/Chassis/1/PCIeDevices/USB" {
+		t.Errorf("Invalid PCIe device link: %s", result.pcieDevice)
+	}
+
+	if len(result.processors) != 1 {
+		t.Errorf("Invalid processor links: %v", result.processors)
+	}
+}
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -45,48 +45,124 @@ type VirtualMedia struct {
 	WriteProtected      bool                        `json:"WriteProtected"` // WriteProtected ...
 	Inserted            bool                        `json:"Inserted"`       // Inserted status of connect image
 	SupportedMediaTypes []VirtualMediaType          `json:"MediaTypes"`     // MediaTypes allowed media types
+	insertMediaTarget   string                      // insertMediaTarget is the URL to send InsertMedia actions to.
+	ejectMediaTarget    string                      // ejectMediaTarget is the URL to send EjectMedia actions to.
 	rawData             []byte                      // rawData holds the original serialized JSON
 }
 
+// VirtualMediaInsertParameters are the parameters of the
+// VirtualMedia.InsertMedia action.
+type VirtualMediaInsertParameters struct {
+	// Image shall contain an URI to the media to attach to the virtual media.
+	Image string
+	// Inserted shall indicate whether the image is treated as inserted upon
+	// completion of the action. The service defaults it to true.
+	Inserted *bool `json:",omitempty"`
+	// Password shall contain the password to access the URI specified by
+	// the Image parameter.
+	Password string `json:",omitempty"`
+	// TransferProtocolType shall contain the network protocol to use with
+	// the URI specified by the Image parameter.
+	TransferProtocolType TransferProtocolType `json:",omitempty"`
+	// UserName shall contain the username to access the URI specified by
+	// the Image parameter.
+	UserName string `json:",omitempty"`
+	// WriteProtected shall indicate whether the remote media is treated as
+	// write-protected. The service defaults it to true.
+	WriteProtected *bool `json:",omitempty"`
+}
+
 // GetRawData get raw data json
 func (virtualMedia *VirtualMedia) GetRawData() []byte {
 	return virtualMedia.rawData
 }
 
-// GetVirtualMedia will get a VirtualMedia instance from the service.
-func GetVirtualMedia(c common.Client, uri string) (*VirtualMedia, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// UnmarshalJSON unmarshals a VirtualMedia object from the raw JSON.
+func (virtualMedia *VirtualMedia) UnmarshalJSON(b []byte) error {
+	type temp VirtualMedia
+	type Actions struct {
+		InsertMedia struct {
+			Target string
+		} `json:"#VirtualMedia.InsertMedia"`
+		EjectMedia struct {
+			Target string
+		} `json:"#VirtualMedia.EjectMedia"`
+	}
+	var t struct {
+		temp
+		Actions Actions
 	}
-	defer resp.Body.Close()
 
-	var virtualMedia VirtualMedia
-	rawData, err := ioutil.ReadAll(resp.Body)
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	*virtualMedia = VirtualMedia(t.temp)
+	virtualMedia.insertMediaTarget = t.Actions.InsertMedia.Target
+	virtualMedia.ejectMediaTarget = t.Actions.EjectMedia.Target
+	virtualMedia.rawData = b
+	virtualMedia.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the virtual media to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (virtualMedia VirtualMedia) MarshalJSON() ([]byte, error) {
+	type temp VirtualMedia
+	return common.MarshalWithRawData(virtualMedia.rawData, temp(virtualMedia))
+}
+
+// InsertMedia shall attach remote media to the virtual media.
+func (virtualMedia *VirtualMedia) InsertMedia(parameters *VirtualMediaInsertParameters) error {
+	if virtualMedia.insertMediaTarget == "" {
+		return fmt.Errorf("InsertMedia is not supported by this virtual media")
 	}
 
-	err = json.Unmarshal(rawData, &virtualMedia)
+	_, err := virtualMedia.Client.Post(virtualMedia.insertMediaTarget, parameters)
+	return err
+}
+
+// EjectMedia shall detach the remote media from the virtual media.
+func (virtualMedia *VirtualMedia) EjectMedia() error {
+	if virtualMedia.ejectMediaTarget == "" {
+		return fmt.Errorf("EjectMedia is not supported by this virtual media")
+	}
+
+	_, err := virtualMedia.Client.Post(virtualMedia.ejectMediaTarget, struct{}{})
+	return err
+}
+
+// GetVirtualMedia will get a VirtualMedia instance from the service.
+func GetVirtualMedia(c common.Client, uri string) (*VirtualMedia, error) {
+	var virtualMedia VirtualMedia
+	err := common.FetchResource(c, uri, &virtualMedia)
 	if err != nil {
 		return nil, err
 	}
 
-	virtualMedia.rawData = rawData
-	virtualMedia.SetClient(c)
 	return &virtualMedia, nil
 }
 
 // ListReferencedVirtualMedia gets the collection of VirtualMedia
 func ListReferencedVirtualMedia(c common.Client, link string) ([]*VirtualMedia, error) {
 	var result []*VirtualMedia
+	if link == "" {
+		return result, nil
+	}
+
 	links, err := common.GetCollection(c, link)
 	if err != nil {
 		return result, err
 	}
 
 	for _, virtualMediaLink := range links.ItemLinks {
-		virtualMedia, err := GetVirtualMedia(c, virtualMediaLink)
+		virtualMedia := new(VirtualMedia)
+		expanded, err := links.DecodeMember(c, virtualMediaLink, virtualMedia)
+		if err == nil && !expanded {
+			virtualMedia, err = GetVirtualMedia(c, virtualMediaLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -5,11 +5,13 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/LRichi/WBfish/common"
 )
 
 const virtualMediaBody = `{
@@ -28,7 +30,15 @@ const virtualMediaBody = `{
 	  "MediaTypes": [
 		"CD",
 		"DVD"
-	  ]
+	  ],
+	  "Actions": {
+		"#VirtualMedia.EjectMedia": {
+		  "target": "/redfish/v1/Managers/1/VirtualMedia/EXT1/Actions/VirtualMedia.EjectMedia"
+		},
+		"#VirtualMedia.InsertMedia": {
+		  "target": "/redfish/v1/Managers/1/VirtualMedia/EXT1/Actions/VirtualMedia.InsertMedia"
+		}
+	  }
 	}`
 
 // TestVirtualMediaCollection tests the parsing of VirtualMediaCollection objects.
@@ -36,19 +46,303 @@ func TestVirtualMedia(t *testing.T) {
 	var result VirtualMedia
 	err := json.NewDecoder(strings.NewReader(virtualMediaBody)).Decode(&result)
 
-	assert.Nil(t, err, err)
-	assert.Equalf(t, result.ODataID, "/redfish/v1/Managers/1/VirtualMedia/EXT1", "Received invalid ODataID: %s", result.ODataID)
-	assert.Equalf(t, result.ODataContext, "/redfish/v1/$metadata#VirtualMedia.VirtualMedia", "Received invalid ODataContext: %s", result.ODataContext)
-	assert.Equalf(t, result.ODataEtag, "5fb9f3ba323469f34cf349a889ff49cf", "Received invalid ODataEtag: %s", result.ODataEtag)
-	assert.Equalf(t, result.ODataType, "#VirtualMedia.v1_3_0.VirtualMedia", "Received invalid ODataType: %s", result.ODataType)
-	assert.Equalf(t, result.ID, "EXT1", "Received invalid ID: %s", result.ID)
-	assert.Equalf(t, result.Name, "VirtualMedia", "Received invalid Name: %s", result.Name)
-	assert.Equalf(t, result.Description, "This resource shall be used to represent a virtual media service for a Redfish implementation.", "Received invalid Description: %s", result.Description)
-	assert.Equalf(t, result.ConnectedVia, VirtualMediaConnectedMethod("URI"), "Received invalid ConnectedVia: %s", result.ConnectedVia)
-	assert.Equalf(t, result.Image, "http://192.168.1.2/Core-current.iso", "Received invalid Image: %s", result.Image)
-	assert.Equalf(t, result.ImageName, "Core-current.iso", "Received invalid ImageName: %s", result.ImageName)
-	assert.Equalf(t, result.WriteProtected, true, "Received invalid WriteProtected: %b", result.WriteProtected)
-	assert.Equalf(t, result.Inserted, true, "Received invalid Inserted: %b", result.Inserted)
-	assert.Equalf(t, len(result.SupportedMediaTypes), 2, "Received invalid SupportedMediaTypes: %d", len(result.SupportedMediaTypes))
-	assert.Equalf(t, len(result.rawData) > 0, true, "Raw data not equal: %s", result.rawData)
+	if err != nil {
+		t.Fatalf("Error decoding JSON: %s", err)
+	}
+
+	if result.ODataID != "/redfish/v1/Managers/1/VirtualMedia/EXT1" {
+		t.Errorf("Received invalid ODataID: %s", result.ODataID)
+	}
+
+	if result.ODataContext != "/redfish/v1/$metadata#VirtualMedia.VirtualMedia" {
+		t.Errorf("Received invalid ODataContext: %s", result.ODataContext)
+	}
+
+	if result.ODataEtag != "5fb9f3ba323469f34cf349a889ff49cf" {
+		t.Errorf("Received invalid ODataEtag: %s", result.ODataEtag)
+	}
+
+	if result.ODataType != "#VirtualMedia.v1_3_0.VirtualMedia" {
+		t.Errorf("Received invalid ODataType: %s", result.ODataType)
+	}
+
+	if result.ID != "EXT1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.Name != "VirtualMedia" {
+		t.Errorf("Received invalid Name: %s", result.Name)
+	}
+
+	if result.Description != "This resource shall be used to represent a virtual media service for a Redfish implementation." {
+		t.Errorf("Received invalid Description: %s", result.Description)
+	}
+
+	if result.ConnectedVia != VirtualMediaConnectedMethod("URI") {
+		t.Errorf("Received invalid ConnectedVia: %s", result.ConnectedVia)
+	}
+
+	if result.Image != "http://192.168.1.2/Core-current.iso" {
+		t.Errorf("Received invalid Image: %s", result.Image)
+	}
+
+	if result.ImageName != "Core-current.iso" {
+		t.Errorf("Received invalid ImageName: %s", result.ImageName)
+	}
+
+	if !result.WriteProtected {
+		t.Errorf("Received invalid WriteProtected: %t", result.WriteProtected)
+	}
+
+	if !result.Inserted {
+		t.Errorf("Received invalid Inserted: %t", result.Inserted)
+	}
+
+	if len(result.SupportedMediaTypes) != 2 {
+		t.Errorf("Received invalid SupportedMediaTypes: %d", len(result.SupportedMediaTypes))
+	}
+
+	if len(result.rawData) == 0 {
+		t.Errorf("Raw data not equal: %s", result.rawData)
+	}
+
+	if result.insertMediaTarget != "/redfish/v1/Managers/1/VirtualMedia/EXT1/Actions/VirtualMedia.InsertMedia" {
+		t.Errorf("Invalid InsertMedia target: %s", result.insertMediaTarget)
+	}
+
+	if result.ejectMediaTarget != "/redfish/v1/Managers/1/VirtualMedia/EXT1/Actions/VirtualMedia.EjectMedia" {
+		t.Errorf("Invalid EjectMedia target: %s", result.ejectMediaTarget)
+	}
+}
+
+// TestVirtualMediaActions tests the InsertMedia and EjectMedia calls.
+func TestVirtualMediaActions(t *testing.T) {
+	var result VirtualMedia
+	err := json.NewDecoder(strings.NewReader(virtualMediaBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.InsertMedia(&VirtualMediaInsertParameters{Image: "http://images.example.com/install.iso"})
+	if err != nil {
+		t.Errorf("Error making InsertMedia call: %s", err)
+	}
+
+	err = result.EjectMedia()
+	if err != nil {
+		t.Errorf("Error making EjectMedia call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls to be made, captured: %v", calls)
+	}
+
+	if !strings.HasSuffix(calls[0].URL, "VirtualMedia.InsertMedia") ||
+		!strings.Contains(calls[0].Payload, "http://images.example.com/install.iso") {
+		t.Errorf("Unexpected InsertMedia call: %v", calls[0])
+	}
+
+	if !strings.HasSuffix(calls[1].URL, "VirtualMedia.EjectMedia") {
+		t.Errorf("Unexpected EjectMedia call: %s", calls[1].URL)
+	}
+}
+
+var virtualMediaBootBody = `{
+	  "@odata.id": "/redfish/v1/Systems/1/VirtualMedia/CD1",
+	  "Id": "CD1",
+	  "Inserted": false,
+	  "MediaTypes": ["CD", "DVD"],
+	  "Oem": {
+		"Hpe": {
+		  "BootOnNextServerReset": false
+		}
+	  },
+	  "Actions": {
+		"#VirtualMedia.InsertMedia": {
+		  "target": "/redfish/v1/Systems/1/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia"
+		},
+		"#VirtualMedia.EjectMedia": {
+		  "target": "/redfish/v1/Systems/1/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia"
+		}
+	  }
+	}`
+
+// TestComputerSystemBootFromVirtualMedia tests booting a system from an
+// image inserted in its virtual media.
+func TestComputerSystemBootFromVirtualMedia(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/1/VirtualMedia": `{
+			"@odata.id": "/redfish/v1/Systems/1/VirtualMedia",
+			"Members": [{"@odata.id": "/redfish/v1/Systems/1/VirtualMedia/CD1"}],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Systems/1/VirtualMedia/CD1": virtualMediaBootBody,
+	}}
+	result.SetClient(testClient)
+	result.virtualMedia = "/redfish/v1/Systems/1/VirtualMedia"
+
+	_, err = result.BootFromVirtualMedia(VirtualMediaBootOptions{})
+	if err == nil {
+		t.Error("Booting from virtual media without an image should fail")
+	}
+
+	virtualMedia, err := result.BootFromVirtualMedia(VirtualMediaBootOptions{
+		Media: VirtualMediaInsertParameters{Image: "http://192.168.1.2/install.iso"},
+	})
+	if err != nil {
+		t.Fatalf("Error booting from virtual media: %s", err)
+	}
+
+	if !virtualMedia.Inserted || virtualMedia.Image != "http://192.168.1.2/install.iso" {
+		t.Errorf("Virtual media should have the image inserted: %v", virtualMedia.Image)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 6 {
+		t.Fatalf("Expected the media lookup, insert, OEM knob, boot override and reset: %v", calls)
+	}
+
+	if calls[2].URL != "/redfish/v1/Systems/1/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia" ||
+		!strings.Contains(calls[2].Payload, "http://192.168.1.2/install.iso") {
+		t.Errorf("Unexpected insert media call: %v", calls[2])
+	}
+
+	if calls[3].URL != "/redfish/v1/Systems/1/VirtualMedia/CD1" ||
+		calls[3].Payload != "map[Oem:map[Hpe:map[BootOnNextServerReset:true]]]" {
+		t.Errorf("Unexpected OEM boot once call: %v", calls[3])
+	}
+
+	if !strings.Contains(calls[4].Payload, "Once  Cd") {
+		t.Errorf("Unexpected boot override payload: %s", calls[4].Payload)
+	}
+
+	if calls[5].URL != result.resetTarget || calls[5].Payload != "{ForceRestart}" {
+		t.Errorf("Unexpected reset call: %v", calls[5])
+	}
+
+	testClient.Reset()
+	testClient.resources["/redfish/v1/Systems/1/VirtualMedia/CD1"] = strings.Replace(
+		virtualMediaBootBody, `"Inserted": false`, `"Inserted": true`, 1)
+	_, err = result.BootFromVirtualMedia(VirtualMediaBootOptions{
+		Media: VirtualMediaInsertParameters{Image: "http://192.168.1.2/install.iso"},
+	})
+	if err == nil {
+		t.Error("Booting from virtual media that has media inserted should fail")
+	}
+}
+
+// TestComputerSystemBootFromManagerVirtualMedia tests booting a system whose
+// own virtual media has no CD or DVD drive from the one of its manager.
+func TestComputerSystemBootFromManagerVirtualMedia(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	managerCD := strings.Replace(virtualMediaBootBody, "/redfish/v1/Systems/1/", "/redfish/v1/Managers/BMC-1/", -1)
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/1/VirtualMedia": `{
+			"@odata.id": "/redfish/v1/Systems/1/VirtualMedia",
+			"Members": [{"@odata.id": "/redfish/v1/Systems/1/VirtualMedia/USB1"}],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Systems/1/VirtualMedia/USB1": strings.Replace(strings.Replace(virtualMediaBootBody,
+			`["CD", "DVD"]`, `["USBStick"]`, 1), "CD1", "USB1", -1),
+		"/redfish/v1/Managers/BMC-1": `{
+			"@odata.id": "/redfish/v1/Managers/BMC-1",
+			"Id": "BMC-1",
+			"VirtualMedia": {"@odata.id": "/redfish/v1/Managers/BMC-1/VirtualMedia"}
+		}`,
+		"/redfish/v1/Managers/BMC-1/VirtualMedia": `{
+			"@odata.id": "/redfish/v1/Managers/BMC-1/VirtualMedia",
+			"Members": [{"@odata.id": "/redfish/v1/Managers/BMC-1/VirtualMedia/CD1"}],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Managers/BMC-1/VirtualMedia/CD1": managerCD,
+	}}
+	result.SetClient(testClient)
+	result.virtualMedia = "/redfish/v1/Systems/1/VirtualMedia"
+
+	virtualMedia, err := result.BootFromVirtualMedia(VirtualMediaBootOptions{
+		Media: VirtualMediaInsertParameters{Image: "http://192.168.1.2/install.iso"},
+	})
+	if err != nil {
+		t.Fatalf("Error booting from virtual media: %s", err)
+	}
+
+	if virtualMedia.ODataID != "/redfish/v1/Managers/BMC-1/VirtualMedia/CD1" {
+		t.Errorf("The CD drive of the manager should be used: %s", virtualMedia.ODataID)
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if strings.Contains(call.URL, "USB1/Actions") {
+			t.Errorf("The USB stick should not be used: %v", call)
+		}
+	}
+}
+
+// TestComputerSystemEjectAfterBoot tests ejecting the image a system booted
+// from.
+func TestComputerSystemEjectAfterBoot(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	var virtualMedia VirtualMedia
+	err = json.NewDecoder(strings.NewReader(strings.Replace(virtualMediaBootBody,
+		`"Inserted": false`, `"Inserted": true`, 1))).Decode(&virtualMedia)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	// The boot override is still pending
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/System-1": computerSystemBody,
+	}}
+	result.SetClient(testClient)
+	virtualMedia.SetClient(testClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = result.EjectAfterBoot(ctx, &virtualMedia, time.Millisecond)
+	if err != context.DeadlineExceeded || !virtualMedia.Inserted {
+		t.Errorf("The image should stay inserted until the system boots: %v", err)
+	}
+
+	// The system booted from the media
+	testClient.Reset()
+	testClient.resources["/redfish/v1/Systems/System-1"] = strings.Replace(computerSystemBody,
+		`"BootSourceOverrideEnabled": "Once"`, `"BootSourceOverrideEnabled": "Disabled"`, 1)
+	err = result.EjectAfterBoot(context.Background(), &virtualMedia, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Error ejecting media: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].URL != "/redfish/v1/Systems/1/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia" {
+		t.Errorf("Unexpected calls: %v", calls)
+	}
+
+	if virtualMedia.Inserted || virtualMedia.Image != "" {
+		t.Errorf("The image should be ejected: %v %s", virtualMedia.Inserted, virtualMedia.Image)
+	}
 }
@@ -0,0 +1,219 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// VirtualMediaBootOptions controls ComputerSystem.BootFromVirtualMedia.
+type VirtualMediaBootOptions struct {
+	// Media are the parameters of the InsertMedia action, such as the URI of
+	// the image to boot.
+	Media VirtualMediaInsertParameters
+	// ResetType is the reset to boot the system with. It defaults to
+	// ForceRestart, or On if the system is off.
+	ResetType ResetType
+}
+
+// virtualMediaBootOnceVendors are the OEM objects of the vendors whose
+// virtual media has a "boot from this media once" knob, which they require
+// on top of, or instead of, the Cd boot source override.
+var virtualMediaBootOnceVendors = []string{"Hpe", "Hp"}
+
+// BootFromVirtualMedia inserts an image in a virtual CD or DVD drive of the
+// system, sets the boot override to boot from it once and resets the
+// system. An empty CD or DVD drive of the system is used if it has one, else
+// one of the managers of the system. It returns the virtual media the image
+// was inserted in, which should be ejected once the system has booted, such
+// as with EjectAfterBoot.
+//
+// A virtual media with an OEM "boot on next server reset" knob, such as the
+// one of HPE iLO, has it set as well. If setting the boot override or
+// resetting the system fails, the image is ejected again.
+func (computersystem *ComputerSystem) BootFromVirtualMedia(options VirtualMediaBootOptions) (*VirtualMedia, error) {
+	if options.Media.Image == "" {
+		return nil, fmt.Errorf("an image is required to boot from virtual media")
+	}
+
+	resetType := options.ResetType
+	if resetType == "" {
+		resetType = ForceRestartResetType
+	}
+
+	virtualMedia, err := computersystem.bootVirtualMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	media := options.Media
+	err = virtualMedia.InsertMedia(&media)
+	if err != nil {
+		return nil, err
+	}
+	virtualMedia.Image = media.Image
+	virtualMedia.Inserted = true
+
+	err = virtualMedia.setBootOnce()
+	if err == nil {
+		err = computersystem.BootOnce(CdBootSourceOverrideTarget, resetType)
+	}
+	if err != nil {
+		if ejectErr := virtualMedia.EjectMedia(); ejectErr == nil {
+			virtualMedia.Image = ""
+			virtualMedia.Inserted = false
+		}
+		return nil, err
+	}
+
+	return virtualMedia, nil
+}
+
+// bootVirtualMedia finds a virtual CD or DVD drive of the system, or of its
+// managers, that is empty and can have an image inserted.
+func (computersystem *ComputerSystem) bootVirtualMedia() (*VirtualMedia, error) {
+	media, err := computersystem.VirtualMedia()
+	if err != nil {
+		return nil, err
+	}
+
+	virtualMedia, found := emptyOpticalMedia(media)
+	if virtualMedia != nil {
+		return virtualMedia, nil
+	}
+
+	// The system only has other media, such as USB sticks or floppies, or
+	// its drives are in use
+	managers, err := computersystem.ManagedBy()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manager := range managers {
+		managerMedia, err := manager.VirtualMedia()
+		if err != nil {
+			return nil, err
+		}
+
+		virtualMedia, managerFound := emptyOpticalMedia(managerMedia)
+		if virtualMedia != nil {
+			return virtualMedia, nil
+		}
+		found = found || managerFound
+	}
+
+	if found {
+		return nil, fmt.Errorf("all the virtual CD and DVD drives of system %s have media inserted", computersystem.ID)
+	}
+	return nil, fmt.Errorf("system %s does not have a virtual CD or DVD drive", computersystem.ID)
+}
+
+// emptyOpticalMedia finds an empty virtual CD or DVD drive that can have an
+// image inserted, and tells whether there are any such drives at all.
+func emptyOpticalMedia(media []*VirtualMedia) (*VirtualMedia, bool) {
+	found := false
+	for _, virtualMedia := range media {
+		if virtualMedia.insertMediaTarget == "" || !virtualMedia.supportsOpticalMedia() {
+			continue
+		}
+		found = true
+		if !virtualMedia.Inserted {
+			return virtualMedia, true
+		}
+	}
+
+	return nil, found
+}
+
+// EjectAfterBoot waits for the system to boot from the virtual media that
+// BootFromVirtualMedia inserted an image in, then ejects the image. The
+// system is polled every interval, five seconds if unset, until its boot
+// override is no longer Once, as services reset it once the system booted
+// from it. If ctx ends first, its error is returned and the image is left
+// inserted.
+func (computersystem *ComputerSystem) EjectAfterBoot(ctx context.Context, virtualMedia *VirtualMedia, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		system, err := GetComputerSystem(computersystem.Client, computersystem.ODataID)
+		if err != nil {
+			return err
+		}
+		if system.Boot.BootSourceOverrideEnabled != OnceBootSourceOverrideEnabled {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	err := virtualMedia.EjectMedia()
+	if err != nil {
+		return err
+	}
+	virtualMedia.Image = ""
+	virtualMedia.Inserted = false
+
+	return nil
+}
+
+// supportsOpticalMedia tells whether CD or DVD images can be inserted in the
+// virtual media. A virtual media not reporting its media types is assumed to
+// support them.
+func (virtualMedia *VirtualMedia) supportsOpticalMedia() bool {
+	if len(virtualMedia.SupportedMediaTypes) == 0 {
+		return true
+	}
+
+	for _, mediaType := range virtualMedia.SupportedMediaTypes {
+		if mediaType == CdVirtualMediaType || mediaType == DvdVirtualMediaType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setBootOnce sets the OEM "boot on next server reset" knob of the virtual
+// media, if it has one.
+func (virtualMedia *VirtualMedia) setBootOnce() error {
+	if len(virtualMedia.rawData) == 0 {
+		return nil
+	}
+
+	var t struct {
+		Oem map[string]struct {
+			BootOnNextServerReset *bool
+		}
+	}
+	err := json.Unmarshal(virtualMedia.rawData, &t)
+	if err != nil {
+		return err
+	}
+
+	for _, vendor := range virtualMediaBootOnceVendors {
+		if t.Oem[vendor].BootOnNextServerReset == nil {
+			continue
+		}
+
+		payload := map[string]interface{}{
+			"Oem": map[string]interface{}{
+				vendor: map[string]interface{}{"BootOnNextServerReset": true},
+			},
+		}
+		_, err = virtualMedia.Client.Patch(virtualMedia.ODataID, payload)
+		return err
+	}
+
+	return nil
+}
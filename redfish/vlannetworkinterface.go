@@ -6,7 +6,6 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -39,7 +38,7 @@ type VLanNetworkInterface struct {
 	// VLANID is used to indicate the VLAN identifier for this VLAN.
 	VLANID int16 `json:"VLANId"`
 	// rawData holds the original serialized JSON
-	rawData             []byte
+	rawData []byte
 }
 
 // GetRawData get raw data json
@@ -63,10 +62,18 @@ func (vlannetworkinterface *VLanNetworkInterface) UnmarshalJSON(b []byte) error
 
 	// This is a read/write object, so we need to save the raw object data for later
 	vlannetworkinterface.rawData = b
+	vlannetworkinterface.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the VLAN network interface to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (vlannetworkinterface VLanNetworkInterface) MarshalJSON() ([]byte, error) {
+	type temp VLanNetworkInterface
+	return common.MarshalWithRawData(vlannetworkinterface.rawData, temp(vlannetworkinterface))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (vlannetworkinterface *VLanNetworkInterface) Update() error {
 
@@ -88,25 +95,12 @@ func (vlannetworkinterface *VLanNetworkInterface) Update() error {
 
 // GetVLanNetworkInterface will get a VLanNetworkInterface instance from the service.
 func GetVLanNetworkInterface(c common.Client, uri string) (*VLanNetworkInterface, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var vlanNetworkInterface VLanNetworkInterface
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &vlanNetworkInterface)
+	err := common.FetchResource(c, uri, &vlanNetworkInterface)
 	if err != nil {
 		return nil, err
 	}
 
-	vlanNetworkInterface.rawData = rawData
-	vlanNetworkInterface.SetClient(c)
 	return &vlanNetworkInterface, nil
 }
 
@@ -124,7 +118,11 @@ func ListReferencedVLanNetworkInterfaces(c common.Client, link string) ([]*VLanN
 	}
 
 	for _, vlannetworkinterfaceLink := range links.ItemLinks {
-		vlannetworkinterface, err := GetVLanNetworkInterface(c, vlannetworkinterfaceLink)
+		vlannetworkinterface := new(VLanNetworkInterface)
+		expanded, err := links.DecodeMember(c, vlannetworkinterfaceLink, vlannetworkinterface)
+		if err == nil && !expanded {
+			vlannetworkinterface, err = GetVLanNetworkInterface(c, vlannetworkinterfaceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -6,7 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
 
 	"github.com/LRichi/WBfish/common"
 )
@@ -153,6 +153,109 @@ const (
 	SpannedStripesWithParityVolumeType VolumeType = "SpannedStripesWithParity"
 )
 
+// LBAFormatType is the LBA format type of an NVMe namespace.
+type LBAFormatType string
+
+const (
+	// LBAFormat0LBAFormatType LBAFormat0 is a required LBA format.
+	LBAFormat0LBAFormatType LBAFormatType = "LBAFormat0"
+	// LBAFormat1LBAFormatType LBAFormat1 is an optional LBA format.
+	LBAFormat1LBAFormatType LBAFormatType = "LBAFormat1"
+	// LBAFormat2LBAFormatType LBAFormat2 is an optional LBA format.
+	LBAFormat2LBAFormatType LBAFormatType = "LBAFormat2"
+	// LBAFormat3LBAFormatType LBAFormat3 is an optional LBA format.
+	LBAFormat3LBAFormatType LBAFormatType = "LBAFormat3"
+	// LBAFormat4LBAFormatType LBAFormat4 is an optional LBA format.
+	LBAFormat4LBAFormatType LBAFormatType = "LBAFormat4"
+	// LBAFormat5LBAFormatType LBAFormat5 is an optional LBA format.
+	LBAFormat5LBAFormatType LBAFormatType = "LBAFormat5"
+	// LBAFormat6LBAFormatType LBAFormat6 is an optional LBA format.
+	LBAFormat6LBAFormatType LBAFormatType = "LBAFormat6"
+	// LBAFormat7LBAFormatType LBAFormat7 is an optional LBA format.
+	LBAFormat7LBAFormatType LBAFormatType = "LBAFormat7"
+	// LBAFormat8LBAFormatType LBAFormat8 is an optional LBA format.
+	LBAFormat8LBAFormatType LBAFormatType = "LBAFormat8"
+	// LBAFormat9LBAFormatType LBAFormat9 is an optional LBA format.
+	LBAFormat9LBAFormatType LBAFormatType = "LBAFormat9"
+	// LBAFormat10LBAFormatType LBAFormat10 is an optional LBA format.
+	LBAFormat10LBAFormatType LBAFormatType = "LBAFormat10"
+	// LBAFormat11LBAFormatType LBAFormat11 is an optional LBA format.
+	LBAFormat11LBAFormatType LBAFormatType = "LBAFormat11"
+	// LBAFormat12LBAFormatType LBAFormat12 is an optional LBA format.
+	LBAFormat12LBAFormatType LBAFormatType = "LBAFormat12"
+	// LBAFormat13LBAFormatType LBAFormat13 is an optional LBA format.
+	LBAFormat13LBAFormatType LBAFormatType = "LBAFormat13"
+	// LBAFormat14LBAFormatType LBAFormat14 is an optional LBA format.
+	LBAFormat14LBAFormatType LBAFormatType = "LBAFormat14"
+	// LBAFormat15LBAFormatType LBAFormat15 is an optional LBA format.
+	LBAFormat15LBAFormatType LBAFormatType = "LBAFormat15"
+)
+
+// LBAFormat shall describe the LBA format for a namespace.
+type LBAFormat struct {
+	// LBADataSizeBytes shall be the LBA data size reported in bytes.
+	LBADataSizeBytes int
+	// LBAFormatType shall be the LBA format type.
+	LBAFormatType LBAFormatType
+	// LBAMetadataSizeBytes shall be the LBA metadata size reported in bytes.
+	LBAMetadataSizeBytes int
+	// RelativePerformance shall be the LBA relative performance type.
+	RelativePerformance string
+}
+
+// NVMeNamespaceFeatures shall describe the namespace features of an NVMe
+// namespace.
+type NVMeNamespaceFeatures struct {
+	// SupportsAtomicTransactionSize shall indicate whether or not the NVM
+	// fields for Namespace preferred write granularity (NPWG), write
+	// alignment (NPWA), deallocate granularity (NPDG), deallocate alignment
+	// (NPDA) and optimal write size (NOWS) are defined for this namespace.
+	SupportsAtomicTransactionSize bool
+	// SupportsDeallocatedOrUnwrittenLBError shall indicate that the
+	// controller supports deallocated or unwritten logical block error for
+	// this namespace.
+	SupportsDeallocatedOrUnwrittenLBError bool
+	// SupportsIOPerformanceHints shall indicate that the Namespace Atomic
+	// Write Unit Normal (NAWUN), Namespace Atomic Write Unit Power Fail
+	// (NAWUPF), and Namespace Atomic Compare and Write Unit (NACWU) fields
+	// are defined for this namespace.
+	SupportsIOPerformanceHints bool
+	// SupportsNGUIDReuse shall indicate that the namespace supports the use
+	// of an NGUID (namespace globally unique identifier) value.
+	SupportsNGUIDReuse bool
+	// SupportsThinProvisioning shall indicate whether or not the NVMe
+	// Namespace supports thin provisioning.
+	SupportsThinProvisioning bool
+}
+
+// NVMeNamespaceProperties shall contain properties to use when the volume
+// represents an NVMe namespace.
+type NVMeNamespaceProperties struct {
+	// FormattedLBASize shall contain the LBA data size and metadata size
+	// combination that the namespace has been formatted with.
+	FormattedLBASize string
+	// IsShareable shall indicate whether the namespace is shareable.
+	IsShareable bool
+	// LBAFormat shall describe the current LBA format ID and corresponding
+	// detailed properties.
+	LBAFormat LBAFormat
+	// MetadataTransferredAtEndOfDataLBA shall indicate whether or not the
+	// metadata is transferred at the end of the LBA creating an extended data
+	// LBA.
+	MetadataTransferredAtEndOfDataLBA bool
+	// NamespaceFeatures shall contain a set of Namespace Features.
+	NamespaceFeatures NVMeNamespaceFeatures
+	// NamespaceID shall contain the NVMe Namespace Identifier for this
+	// namespace.
+	NamespaceID string `json:"NamespaceId"`
+	// NumberLBAFormats shall contain the number of LBA data size and metadata
+	// size combinations supported by this namespace.
+	NumberLBAFormats int
+	// NVMeVersion shall contain the version of the NVMe Base Specification
+	// supported.
+	NVMeVersion string
+}
+
 // Volume is used to represent a volume, virtual disk, logical disk, LUN,
 // or other logical storage for a Redfish implementation.
 type Volume struct {
@@ -187,10 +290,16 @@ type Volume struct {
 	// performing IO on this volume. For logical disks, this is the stripe size.
 	// For physical disks, this describes the physical sector size.
 	OptimumIOSizeBytes int
+	// NVMeNamespaceProperties shall contain properties to use when the volume
+	// represents an NVMe namespace.
+	NVMeNamespaceProperties NVMeNamespaceProperties
 	// DrivesCount is the number of associated drives.
 	DrivesCount int
 	// drives contains references to associated drives.
 	drives []string
+	// dedicatedSpareDrives contains references to the drives assigned as
+	// dedicated spares of this volume.
+	dedicatedSpareDrives []string
 	// rawData holds the original serialized JSON
 	rawData []byte
 }
@@ -204,8 +313,9 @@ func (volume *Volume) GetRawData() []byte {
 func (volume *Volume) UnmarshalJSON(b []byte) error {
 	type temp Volume
 	type links struct {
-		DriveCount int `json:"Drives@odata.count"`
-		Drives     common.Links
+		DedicatedSpareDrives common.Links
+		DriveCount           int `json:"Drives@odata.count"`
+		Drives               common.Links
 	}
 	var t struct {
 		temp
@@ -220,33 +330,31 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	*volume = Volume(t.temp)
 
 	// Extract the links to other entities for later
-	volume.DrivesCount = t.DrivesCount
+	volume.DrivesCount = t.Links.DriveCount
 	volume.drives = t.Links.Drives.ToStrings()
+	volume.dedicatedSpareDrives = t.Links.DedicatedSpareDrives.ToStrings()
+
+	volume.rawData = b
+	volume.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the volume to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (volume Volume) MarshalJSON() ([]byte, error) {
+	type temp Volume
+	return common.MarshalWithRawData(volume.rawData, temp(volume))
+}
+
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var volume Volume
-	rawData, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(rawData, &volume)
+	err := common.FetchResource(c, uri, &volume)
 	if err != nil {
 		return nil, err
 	}
 
-	volume.rawData = rawData
-	volume.SetClient(c)
 	return &volume, nil
 }
 
@@ -263,7 +371,11 @@ func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
 	}
 
 	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
+		volume := new(Volume)
+		expanded, err := links.DecodeMember(c, volumeLink, volume)
+		if err == nil && !expanded {
+			volume, err = GetVolume(c, volumeLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -287,3 +399,72 @@ func (volume *Volume) Drives() ([]*Drive, error) {
 
 	return result, nil
 }
+
+// DedicatedSpareDrives references the Drives that are assigned as dedicated
+// spares of this volume.
+func (volume *Volume) DedicatedSpareDrives() ([]*Drive, error) {
+	var result []*Drive
+
+	for _, driveLink := range volume.dedicatedSpareDrives {
+		drive, err := GetDrive(volume.Client, driveLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, drive)
+	}
+
+	return result, nil
+}
+
+// AssignDedicatedSpare assigns the drive at driveURI as a dedicated spare of
+// this volume.
+func (volume *Volume) AssignDedicatedSpare(driveURI string) error {
+	for _, spare := range volume.dedicatedSpareDrives {
+		if spare == driveURI {
+			return nil
+		}
+	}
+
+	spares := append([]string{}, volume.dedicatedSpareDrives...)
+	return volume.setDedicatedSpareDrives(append(spares, driveURI))
+}
+
+// UnassignDedicatedSpare removes the drive at driveURI from the dedicated
+// spares of this volume.
+func (volume *Volume) UnassignDedicatedSpare(driveURI string) error {
+	spares := []string{}
+	for _, spare := range volume.dedicatedSpareDrives {
+		if spare != driveURI {
+			spares = append(spares, spare)
+		}
+	}
+
+	if len(spares) == len(volume.dedicatedSpareDrives) {
+		return fmt.Errorf("drive %s is not a dedicated spare of this volume", driveURI)
+	}
+
+	return volume.setDedicatedSpareDrives(spares)
+}
+
+// setDedicatedSpareDrives replaces the dedicated spares of this volume.
+func (volume *Volume) setDedicatedSpareDrives(driveURIs []string) error {
+	type link struct {
+		ODataID string `json:"@odata.id"`
+	}
+	type temp struct {
+		Links struct {
+			DedicatedSpareDrives []link
+		}
+	}
+	var t temp
+	t.Links.DedicatedSpareDrives = []link{}
+	for _, uri := range driveURIs {
+		t.Links.DedicatedSpareDrives = append(t.Links.DedicatedSpareDrives, link{ODataID: uri})
+	}
+
+	_, err := volume.Client.Patch(volume.ODataID, t)
+	if err == nil {
+		volume.dedicatedSpareDrives = driveURIs
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var volumeBody = `{
+		"@odata.context": "/redfish/v1/$metadata#Volume.Volume",
+		"@odata.type": "#Volume.v1_6_0.Volume",
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes/1",
+		"Id": "1",
+		"Name": "Namespace 1",
+		"CapacityBytes": 107374182400,
+		"BlockSizeBytes": 4096,
+		"NVMeNamespaceProperties": {
+			"FormattedLBASize": "LBAFormat0",
+			"IsShareable": true,
+			"LBAFormat": {
+				"LBADataSizeBytes": 4096,
+				"LBAFormatType": "LBAFormat0",
+				"LBAMetadataSizeBytes": 0,
+				"RelativePerformance": "Best"
+			},
+			"MetadataTransferredAtEndOfDataLBA": false,
+			"NamespaceFeatures": {
+				"SupportsThinProvisioning": true,
+				"SupportsNGUIDReuse": true
+			},
+			"NamespaceId": "0x1",
+			"NumberLBAFormats": 2,
+			"NVMeVersion": "1.4"
+		},
+		"Links": {
+			"DedicatedSpareDrives": [{
+				"@odata.id": "/redfish/v1/Chassis/1/Drives/5"
+			}],
+			"Drives": [{
+				"@odata.id": "/redfish/v1/Chassis/1/Drives/1"
+			}],
+			"Drives@odata.count": 1
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestVolume tests the parsing of Volume objects.
+func TestVolume(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.DrivesCount != 1 {
+		t.Errorf("Invalid DrivesCount: %d", result.DrivesCount)
+	}
+
+	if len(result.drives) != 1 {
+		t.Errorf("Unexpected number of drives: %d", len(result.drives))
+	}
+
+	if len(result.dedicatedSpareDrives) != 1 {
+		t.Errorf("Unexpected number of dedicated spares: %d", len(result.dedicatedSpareDrives))
+	}
+
+	nvme := result.NVMeNamespaceProperties
+
+	if nvme.NamespaceID != "0x1" {
+		t.Errorf("Invalid NamespaceID: %s", nvme.NamespaceID)
+	}
+
+	if nvme.LBAFormat.LBAFormatType != LBAFormat0LBAFormatType {
+		t.Errorf("Invalid LBAFormatType: %s", nvme.LBAFormat.LBAFormatType)
+	}
+
+	if nvme.LBAFormat.LBADataSizeBytes != 4096 {
+		t.Errorf("Invalid LBADataSizeBytes: %d", nvme.LBAFormat.LBADataSizeBytes)
+	}
+
+	if !nvme.NamespaceFeatures.SupportsThinProvisioning {
+		t.Error("SupportsThinProvisioning should be true")
+	}
+
+	if !nvme.IsShareable {
+		t.Error("IsShareable should be true")
+	}
+}
+
+// TestVolumeDedicatedSpares tests assigning and removing dedicated spares.
+func TestVolumeDedicatedSpares(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.AssignDedicatedSpare("/redfish/v1/Chassis/1/Drives/6")
+	if err != nil {
+		t.Errorf("Error making AssignDedicatedSpare call: %s", err)
+	}
+
+	err = result.UnassignDedicatedSpare("/redfish/v1/Chassis/1/Drives/5")
+	if err != nil {
+		t.Errorf("Error making UnassignDedicatedSpare call: %s", err)
+	}
+
+	if result.UnassignDedicatedSpare("/redfish/v1/Chassis/1/Drives/5") == nil {
+		t.Error("Unassigning a drive that is not a spare should fail")
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[0].Action != "PATH" || calls[0].URL != "/redfish/v1/Systems/1/Storage/1/Volumes/1" {
+		t.Errorf("Unexpected AssignDedicatedSpare call: %s %s", calls[0].Action, calls[0].URL)
+	}
+
+	if calls[1].Payload != "{{[{/redfish/v1/Chassis/1/Drives/6}]}}" {
+		t.Errorf("Unexpected UnassignDedicatedSpare payload: %s", calls[1].Payload)
+	}
+}
+
+var volumeCapabilitiesBody = `{
+		"@odata.type": "#Volume.v1_6_0.Volume",
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes/Capabilities",
+		"Id": "Capabilities",
+		"Name": "Capabilities for the volume collection",
+		"Name@Redfish.RequiredOnCreate": true,
+		"CapacityBytes@Redfish.RequiredOnCreate": true,
+		"CapacityBytes@Redfish.AllowableNumbers": ["1073741824:1099511627776"],
+		"RAIDType@Redfish.RequiredOnCreate": true,
+		"RAIDType@Redfish.AllowableValues": ["RAID0", "RAID1", "RAID5"],
+		"StripSizeBytes@Redfish.AllowableNumbers": ["65536:65536:1048576"],
+		"Links": {
+			"Drives@Redfish.RequiredOnCreate": true
+		}
+	}`
+
+// TestVolumeCapabilities tests the parsing and use of volume capabilities.
+func TestVolumeCapabilities(t *testing.T) {
+	var result VolumeCapabilities
+	err := json.NewDecoder(strings.NewReader(volumeCapabilitiesBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.RAIDTypes) != 3 {
+		t.Errorf("Unexpected number of RAID types: %d", len(result.RAIDTypes))
+	}
+
+	required := strings.Join(result.RequiredOnCreate, ",")
+	if required != "CapacityBytes,Name,RAIDType,Links/Drives" {
+		t.Errorf("Unexpected required properties: %s", required)
+	}
+
+	if !result.SupportsRAIDType(RAID5RAIDType) || result.SupportsRAIDType(RAID6RAIDType) {
+		t.Error("Unexpected RAID type support")
+	}
+
+	if !result.SupportsStripSize(131072) || result.SupportsStripSize(100000) {
+		t.Error("Unexpected strip size support")
+	}
+
+	if result.SupportsStripSize(2097152) {
+		t.Error("Strip size above the range should not be supported")
+	}
+
+	err = result.Validate(RAID1RAIDType, 65536, 10737418240)
+	if err != nil {
+		t.Errorf("Unexpected validation error: %s", err)
+	}
+
+	err = result.Validate(RAID1RAIDType, 0, 1024)
+	if err == nil {
+		t.Error("Capacity below the range should fail validation")
+	}
+}
+
+// TestRAIDTypeMinimumDrives tests the minimum drive counts of RAID types.
+func TestRAIDTypeMinimumDrives(t *testing.T) {
+	if RAID5RAIDType.MinimumDrives() != 3 {
+		t.Errorf("Invalid RAID5 minimum: %d", RAID5RAIDType.MinimumDrives())
+	}
+
+	if RAIDType("Unknown").MinimumDrives() != 0 {
+		t.Errorf("Invalid unknown minimum: %d", RAIDType("Unknown").MinimumDrives())
+	}
+}
@@ -0,0 +1,266 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// raidMinimumDrives is the smallest number of drives each RAID type can be
+// built from.
+var raidMinimumDrives = map[RAIDType]int{
+	RAID0RAIDType:        1,
+	RAID1RAIDType:        2,
+	RAID3RAIDType:        3,
+	RAID4RAIDType:        3,
+	RAID5RAIDType:        3,
+	RAID6RAIDType:        4,
+	RAID10RAIDType:       4,
+	RAID01RAIDType:       4,
+	RAID6TPRAIDType:      5,
+	RAID1ERAIDType:       3,
+	RAID50RAIDType:       6,
+	RAID60RAIDType:       8,
+	RAID00RAIDType:       2,
+	RAID10ERAIDType:      4,
+	RAID1TripleRAIDType:  3,
+	RAID10TripleRAIDType: 6,
+}
+
+// MinimumDrives returns the smallest number of drives a volume of this RAID
+// type can be built from, or 0 if the RAID type is not known.
+func (raidType RAIDType) MinimumDrives() int {
+	return raidMinimumDrives[raidType]
+}
+
+// VolumeCapabilities shall describe the properties a service accepts when
+// creating a volume in a volume collection. It is read from the capabilities
+// object the collection advertises in its Redfish.CollectionCapabilities
+// annotation.
+type VolumeCapabilities struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// CapacityBytes shall contain the allowable numbers for the CapacityBytes
+	// property, as a list of values or lower:upper and
+	// lower:increment:upper ranges.
+	CapacityBytes []string `json:"CapacityBytes@Redfish.AllowableNumbers"`
+	// EncryptionTypes shall contain the encryption types a new volume may use.
+	EncryptionTypes []EncryptionTypes `json:"EncryptionTypes@Redfish.AllowableValues"`
+	// RAIDTypes shall contain the RAID types a new volume may use.
+	RAIDTypes []RAIDType `json:"RAIDType@Redfish.AllowableValues"`
+	// StripSizeBytes shall contain the allowable numbers for the
+	// StripSizeBytes property, as a list of values or lower:upper and
+	// lower:increment:upper ranges.
+	StripSizeBytes []string `json:"StripSizeBytes@Redfish.AllowableNumbers"`
+	// VolumeTypes shall contain the volume types a new volume may use.
+	VolumeTypes []VolumeType `json:"VolumeType@Redfish.AllowableValues"`
+	// RequiredOnCreate are the properties that shall be provided when
+	// creating a volume. Properties under Links are prefixed with "Links/".
+	RequiredOnCreate []string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (volumecapabilities *VolumeCapabilities) GetRawData() []byte {
+	return volumecapabilities.rawData
+}
+
+// UnmarshalJSON unmarshals a VolumeCapabilities object from the raw JSON.
+func (volumecapabilities *VolumeCapabilities) UnmarshalJSON(b []byte) error {
+	type temp VolumeCapabilities
+	var t struct {
+		temp
+		Links map[string]json.RawMessage
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*volumecapabilities = VolumeCapabilities(t.temp)
+
+	var properties map[string]json.RawMessage
+	err = json.Unmarshal(b, &properties)
+	if err != nil {
+		return err
+	}
+
+	volumecapabilities.RequiredOnCreate = requiredOnCreate(properties, "")
+	volumecapabilities.RequiredOnCreate = append(volumecapabilities.RequiredOnCreate,
+		requiredOnCreate(t.Links, "Links/")...)
+
+	volumecapabilities.rawData = b
+	volumecapabilities.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the volume capabilities to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (volumecapabilities VolumeCapabilities) MarshalJSON() ([]byte, error) {
+	type temp VolumeCapabilities
+	return common.MarshalWithRawData(volumecapabilities.rawData, temp(volumecapabilities))
+}
+
+// requiredOnCreate gets the names of the properties annotated as required on
+// create.
+func requiredOnCreate(properties map[string]json.RawMessage, prefix string) []string {
+	var result []string
+	for key, value := range properties {
+		if !strings.HasSuffix(key, "@Redfish.RequiredOnCreate") {
+			continue
+		}
+
+		var required bool
+		if json.Unmarshal(value, &required) == nil && required {
+			result = append(result, prefix+strings.TrimSuffix(key, "@Redfish.RequiredOnCreate"))
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// GetVolumeCapabilities will get a VolumeCapabilities instance from the
+// service.
+func GetVolumeCapabilities(c common.Client, uri string) (*VolumeCapabilities, error) {
+	var volumecapabilities VolumeCapabilities
+	err := common.FetchResource(c, uri, &volumecapabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volumecapabilities, nil
+}
+
+// ListVolumeCollectionCapabilities gets the volume creation capabilities
+// advertised by the volume collection at link.
+func ListVolumeCollectionCapabilities(c common.Client, link string) ([]*VolumeCapabilities, error) {
+	var result []*VolumeCapabilities
+	if link == "" {
+		return result, nil
+	}
+
+	collection, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, capability := range collection.Capabilities {
+		if capability.CapabilitiesObject == "" ||
+			(capability.UseCase != "" && capability.UseCase != common.VolumeCreationCapabilitiesUseCase) {
+			continue
+		}
+
+		volumecapabilities, err := GetVolumeCapabilities(c, capability.CapabilitiesObject)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, volumecapabilities)
+	}
+
+	return result, nil
+}
+
+// SupportsRAIDType checks whether a new volume may use the RAID type. A
+// service that does not list the allowable RAID types is assumed to accept
+// any of them.
+func (volumecapabilities *VolumeCapabilities) SupportsRAIDType(raidType RAIDType) bool {
+	if len(volumecapabilities.RAIDTypes) == 0 {
+		return true
+	}
+
+	for _, allowed := range volumecapabilities.RAIDTypes {
+		if allowed == raidType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SupportsStripSize checks whether a new volume may use the strip size.
+func (volumecapabilities *VolumeCapabilities) SupportsStripSize(stripSizeBytes int64) bool {
+	return allowableNumber(volumecapabilities.StripSizeBytes, stripSizeBytes)
+}
+
+// SupportsCapacity checks whether a new volume may have the capacity.
+func (volumecapabilities *VolumeCapabilities) SupportsCapacity(capacityBytes int64) bool {
+	return allowableNumber(volumecapabilities.CapacityBytes, capacityBytes)
+}
+
+// Validate checks a volume layout against these capabilities before it is
+// sent to the service. A zero strip size or capacity is not checked.
+func (volumecapabilities *VolumeCapabilities) Validate(raidType RAIDType, stripSizeBytes int64, capacityBytes int64) error {
+	if raidType != "" && !volumecapabilities.SupportsRAIDType(raidType) {
+		return fmt.Errorf("RAID type %s is not supported, allowed types are %v",
+			raidType, volumecapabilities.RAIDTypes)
+	}
+
+	if stripSizeBytes != 0 && !volumecapabilities.SupportsStripSize(stripSizeBytes) {
+		return fmt.Errorf("strip size %d is not supported, allowed sizes are %v",
+			stripSizeBytes, volumecapabilities.StripSizeBytes)
+	}
+
+	if capacityBytes != 0 && !volumecapabilities.SupportsCapacity(capacityBytes) {
+		return fmt.Errorf("capacity %d is not supported, allowed capacities are %v",
+			capacityBytes, volumecapabilities.CapacityBytes)
+	}
+
+	return nil
+}
+
+// allowableNumber checks a value against Redfish.AllowableNumbers entries,
+// which are single values or lower:upper and lower:increment:upper ranges.
+// An empty list allows any value.
+func allowableNumber(allowable []string, value int64) bool {
+	if len(allowable) == 0 {
+		return true
+	}
+
+	for _, entry := range allowable {
+		var bounds []int64
+		for _, part := range strings.Split(entry, ":") {
+			number, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil {
+				bounds = nil
+				break
+			}
+			bounds = append(bounds, number)
+		}
+
+		switch len(bounds) {
+		case 1:
+			if value == bounds[0] {
+				return true
+			}
+		case 2:
+			if value >= bounds[0] && value <= bounds[1] {
+				return true
+			}
+		case 3:
+			if value >= bounds[0] && value <= bounds[2] &&
+				(bounds[1] <= 0 || (value-bounds[0])%bounds[1] == 0) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
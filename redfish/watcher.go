@@ -0,0 +1,474 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// WatcherOptions controls a Watcher.
+type WatcherOptions struct {
+	// Interval is the time between the polls of the watched resources. It
+	// defaults to 30 seconds.
+	Interval time.Duration
+	// IgnoreProperties are the JSON pointers of the properties whose changes
+	// are not reported, such as /DateTime for managers. The @odata.etag of
+	// resources is always ignored.
+	IgnoreProperties []string
+	// OnChange, if set, is called with the changes of each watched resource
+	// or collection that changed since the previous poll.
+	OnChange func(changes *ChangeSet)
+	// OnError, if set, is called with the error of each poll run by Run that
+	// could not read a resource. The resource is polled again at the next
+	// interval.
+	OnError func(err error)
+}
+
+// PowerStateChange is a change of the PowerState of a resource, such as a
+// system that was powered off.
+type PowerStateChange struct {
+	// Old is the power state of the previous poll.
+	Old PowerState
+	// New is the current power state.
+	New PowerState
+}
+
+// HealthChange is a change of the Status.Health of a resource, such as a
+// drive that failed.
+type HealthChange struct {
+	// Old is the health of the previous poll.
+	Old common.Health
+	// New is the current health.
+	New common.Health
+}
+
+// PropertyChange is a change of a property of a resource.
+type PropertyChange struct {
+	// Path is the JSON pointer of the property, such as /Status/State.
+	Path string
+	// Old is the JSON value of the previous poll, nil if the property was
+	// absent.
+	Old interface{}
+	// New is the current JSON value, nil if the property is gone.
+	New interface{}
+}
+
+// ChangeSet holds the changes of a watched resource or collection between
+// two polls.
+type ChangeSet struct {
+	// URI is the URI of the resource or collection.
+	URI string
+	// PowerState, if set, is the change of the PowerState of the resource.
+	PowerState *PowerStateChange
+	// Health, if set, is the change of the Status.Health of the resource.
+	Health *HealthChange
+	// Properties holds the changes of every property of the resource,
+	// including the power state and health, by JSON pointer.
+	Properties []PropertyChange
+	// AddedMembers are the URIs of the members added to the collection.
+	AddedMembers []string
+	// RemovedMembers are the URIs of the members removed from the
+	// collection.
+	RemovedMembers []string
+	// LogEntries are the entries added to a collection of log entries.
+	LogEntries []*LogEntry
+}
+
+// empty tells whether the change set holds no change.
+func (changes *ChangeSet) empty() bool {
+	return len(changes.Properties) == 0 && len(changes.AddedMembers) == 0 && len(changes.RemovedMembers) == 0
+}
+
+// watched is a watched resource or collection, with its snapshot of the
+// previous poll.
+type watched struct {
+	// members tells whether the members of the collection are watched too.
+	members bool
+	// read tells whether the resource was read once, so there is a
+	// snapshot.
+	read bool
+	// collection tells whether the resource is a collection.
+	collection bool
+	// logEntries tells whether the collection is a collection of log
+	// entries.
+	logEntries bool
+	// properties is the JSON document of the resource.
+	properties map[string]interface{}
+	// memberLinks are the members of the collection, in order.
+	memberLinks []string
+}
+
+// Watcher polls resources and collections at an interval and reports what
+// changed since the previous poll, such as a power state, a health or new
+// log entries, for agents that cannot subscribe to events.
+type Watcher struct {
+	client  common.Client
+	options WatcherOptions
+
+	lock    sync.Mutex
+	order   []string
+	watched map[string]*watched
+	// memberOf holds the collections watching their members, by member URI.
+	memberOf map[string]string
+}
+
+// NewWatcher creates a watcher reading resources with a client. Nothing is
+// read until Poll or Run is called.
+func NewWatcher(c common.Client, options WatcherOptions) *Watcher {
+	if options.Interval <= 0 {
+		options.Interval = 30 * time.Second
+	}
+
+	return &Watcher{
+		client:   c,
+		options:  options,
+		watched:  make(map[string]*watched),
+		memberOf: make(map[string]string),
+	}
+}
+
+// Watch adds a resource or a collection to watch. The properties of
+// resources are compared from poll to poll; the members of collections are,
+// and the new members of log entry collections are read as log entries.
+func (watcher *Watcher) Watch(uri string) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	delete(watcher.memberOf, uri)
+	watcher.add(uri, false)
+}
+
+// WatchMembers adds a collection to watch along with each of its members,
+// such as the drives of a storage to be told when one fails. Members added to
+// the collection are watched from the poll they appear in.
+func (watcher *Watcher) WatchMembers(uri string) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	delete(watcher.memberOf, uri)
+	watcher.add(uri, true)
+}
+
+// Unwatch stops watching a resource or collection, and the members of a
+// collection added with WatchMembers.
+func (watcher *Watcher) Unwatch(uri string) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	if w, ok := watcher.watched[uri]; ok && w.members {
+		for _, member := range w.memberLinks {
+			watcher.removeMember(uri, member)
+		}
+	}
+	watcher.remove(uri)
+}
+
+// Poll reads the watched resources once, calls OnChange with the change sets
+// of the ones that changed and returns them. The first poll only takes the
+// snapshot that the next ones are compared to. Resources that cannot be read
+// keep their previous snapshot; the first error is returned once all the
+// resources were polled. OnChange is called once the poll is done, so it may
+// add or remove watched resources.
+func (watcher *Watcher) Poll() ([]*ChangeSet, error) {
+	result, err := watcher.pollAll()
+
+	if watcher.options.OnChange != nil {
+		for _, changes := range result {
+			watcher.options.OnChange(changes)
+		}
+	}
+
+	return result, err
+}
+
+// pollAll polls every watched resource.
+func (watcher *Watcher) pollAll() ([]*ChangeSet, error) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	var result []*ChangeSet
+	var firstErr error
+	// Members added during the poll are polled in the same pass, and the
+	// removed ones are not
+	polled := make(map[string]bool)
+	for {
+		uri := ""
+		for _, watchedURI := range watcher.order {
+			if !polled[watchedURI] {
+				uri = watchedURI
+				break
+			}
+		}
+		if uri == "" {
+			break
+		}
+		polled[uri] = true
+
+		changes, err := watcher.poll(uri, watcher.watched[uri])
+		if err != nil {
+			err = fmt.Errorf("watching %s: %w", uri, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if changes != nil {
+			result = append(result, changes)
+		}
+	}
+
+	return result, firstErr
+}
+
+// Run polls the watched resources every Interval until the context is done.
+// The errors of the polls are passed to OnError.
+func (watcher *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(watcher.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := watcher.Poll()
+		if err != nil && watcher.options.OnError != nil {
+			watcher.options.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// add adds a watched resource, keeping the snapshot of one already watched.
+func (watcher *Watcher) add(uri string, members bool) {
+	if w, ok := watcher.watched[uri]; ok {
+		w.members = w.members || members
+		return
+	}
+
+	watcher.order = append(watcher.order, uri)
+	watcher.watched[uri] = &watched{members: members}
+}
+
+// remove stops watching a resource.
+func (watcher *Watcher) remove(uri string) {
+	if _, ok := watcher.watched[uri]; !ok {
+		return
+	}
+
+	delete(watcher.watched, uri)
+	for i, watchedURI := range watcher.order {
+		if watchedURI == uri {
+			watcher.order = append(watcher.order[:i], watcher.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// removeMember stops watching a member of a collection, unless it is watched
+// on its own.
+func (watcher *Watcher) removeMember(collection, member string) {
+	if watcher.memberOf[member] != collection {
+		return
+	}
+
+	delete(watcher.memberOf, member)
+	watcher.remove(member)
+}
+
+// poll reads a watched resource and compares it with its snapshot. It
+// returns nil if the resource is read for the first time or did not change.
+func (watcher *Watcher) poll(uri string, w *watched) (*ChangeSet, error) {
+	var document watchedDocument
+	err := common.FetchResource(watcher.client, uri, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if !w.collection {
+		if document.collection == nil {
+			changes := &ChangeSet{URI: uri}
+			if w.read {
+				watcher.diffProperties(changes, "", w.properties, document.properties)
+			}
+			w.properties = document.properties
+			w.read = true
+
+			return watcher.changeSet(changes), nil
+		}
+
+		w.collection = true
+		odataType, _ := document.properties["@odata.type"].(string)
+		w.logEntries = strings.HasPrefix(odataType, "#LogEntryCollection.")
+	}
+
+	// A collection that lost its members property has no members
+	collection := document.collection
+	if collection == nil {
+		collection = &common.Collection{}
+	}
+	err = collection.ReadNextPages(watcher.client, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &ChangeSet{URI: uri}
+	if w.read {
+		previous := make(map[string]bool)
+		for _, member := range w.memberLinks {
+			previous[member] = true
+		}
+		current := make(map[string]bool)
+		for _, member := range collection.ItemLinks {
+			current[member] = true
+			if !previous[member] {
+				changes.AddedMembers = append(changes.AddedMembers, member)
+			}
+		}
+		for _, member := range w.memberLinks {
+			if !current[member] {
+				changes.RemovedMembers = append(changes.RemovedMembers, member)
+			}
+		}
+	}
+
+	if w.logEntries {
+		for _, member := range changes.AddedMembers {
+			entry := new(LogEntry)
+			expanded, err := collection.DecodeMember(watcher.client, member, entry)
+			if err == nil && !expanded {
+				entry, err = GetLogEntry(watcher.client, member)
+			}
+			if err != nil {
+				return nil, err
+			}
+			changes.LogEntries = append(changes.LogEntries, entry)
+		}
+	}
+
+	if w.members {
+		for _, member := range changes.RemovedMembers {
+			watcher.removeMember(uri, member)
+		}
+		for _, member := range collection.ItemLinks {
+			if _, ok := watcher.watched[member]; !ok {
+				watcher.memberOf[member] = uri
+				watcher.add(member, false)
+			}
+		}
+	}
+
+	w.memberLinks = collection.ItemLinks
+	w.read = true
+
+	return watcher.changeSet(changes), nil
+}
+
+// changeSet returns the change set, or nil if it holds no change.
+func (watcher *Watcher) changeSet(changes *ChangeSet) *ChangeSet {
+	if changes.empty() {
+		return nil
+	}
+
+	return changes
+}
+
+// watchedDocument is a resource as the watcher reads it: its JSON document
+// and, if it has members, its first page as a collection.
+type watchedDocument struct {
+	properties map[string]interface{}
+	collection *common.Collection
+}
+
+// UnmarshalJSON unmarshals the document and, for collections, their members.
+func (document *watchedDocument) UnmarshalJSON(b []byte) error {
+	err := json.Unmarshal(b, &document.properties)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := document.properties["Members"]; !ok {
+		return nil
+	}
+
+	document.collection = new(common.Collection)
+	return json.Unmarshal(b, document.collection)
+}
+
+// SetClient does nothing, the watcher keeps the client documents are read
+// with. It lets documents be read with common.FetchResource.
+func (document *watchedDocument) SetClient(common.Client) {}
+
+// diffProperties adds the changes between two JSON objects to the change
+// set. Objects are compared property by property; other values, including
+// arrays, are compared whole.
+func (watcher *Watcher) diffProperties(changes *ChangeSet, path string, old, current map[string]interface{}) {
+	names := make(map[string]bool)
+	for name := range old {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		property := path + "/" + strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+		if name == "@odata.etag" || watcher.ignored(property) {
+			continue
+		}
+
+		oldValue, currentValue := old[name], current[name]
+		oldObject, oldIsObject := oldValue.(map[string]interface{})
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		if oldIsObject && currentIsObject {
+			watcher.diffProperties(changes, property, oldObject, currentObject)
+			continue
+		}
+		if reflect.DeepEqual(oldValue, currentValue) {
+			continue
+		}
+
+		changes.Properties = append(changes.Properties, PropertyChange{
+			Path: property,
+			Old:  oldValue,
+			New:  currentValue,
+		})
+
+		oldString, _ := oldValue.(string)
+		currentString, _ := currentValue.(string)
+		switch property {
+		case "/PowerState":
+			changes.PowerState = &PowerStateChange{Old: PowerState(oldString), New: PowerState(currentString)}
+		case "/Status/Health":
+			changes.Health = &HealthChange{Old: common.Health(oldString), New: common.Health(currentString)}
+		}
+	}
+}
+
+// ignored tells whether the changes of a property are not reported.
+func (watcher *Watcher) ignored(property string) bool {
+	for _, ignored := range watcher.options.IgnoreProperties {
+		if property == ignored {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// TestWatcherResource tests reporting the changes of a resource.
+func TestWatcherResource(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Systems/1": `{
+			"@odata.id": "/redfish/v1/Systems/1",
+			"@odata.etag": "W/\"1\"",
+			"Id": "1",
+			"PowerState": "On",
+			"Status": {"State": "Enabled", "Health": "OK"},
+			"Oem": {"Contoso": {"Uptime": 10}}
+		}`,
+	}}
+
+	var notified []*ChangeSet
+	watcher := NewWatcher(testClient, WatcherOptions{
+		IgnoreProperties: []string{"/Oem/Contoso/Uptime"},
+		OnChange:         func(changes *ChangeSet) { notified = append(notified, changes) },
+	})
+	watcher.Watch("/redfish/v1/Systems/1")
+
+	changes, err := watcher.Poll()
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("The first poll should only take a snapshot: %v %v", changes, err)
+	}
+
+	testClient.resources["/redfish/v1/Systems/1"] = `{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"@odata.etag": "W/\"2\"",
+		"Id": "1",
+		"PowerState": "Off",
+		"Status": {"State": "Enabled", "Health": "Critical"},
+		"Oem": {"Contoso": {"Uptime": 20}}
+	}`
+	changes, err = watcher.Poll()
+	if err != nil {
+		t.Fatalf("Error polling: %s", err)
+	}
+
+	if len(changes) != 1 || len(notified) != 1 || changes[0].URI != "/redfish/v1/Systems/1" {
+		t.Fatalf("Invalid change sets: %v", changes)
+	}
+
+	if changes[0].PowerState == nil || changes[0].PowerState.Old != OnPowerState || changes[0].PowerState.New != OffPowerState {
+		t.Errorf("Invalid power state change: %v", changes[0].PowerState)
+	}
+
+	if changes[0].Health == nil || changes[0].Health.New != common.CriticalHealth {
+		t.Errorf("Invalid health change: %v", changes[0].Health)
+	}
+
+	if len(changes[0].Properties) != 2 || changes[0].Properties[0].Path != "/PowerState" || changes[0].Properties[1].Path != "/Status/Health" {
+		t.Errorf("Invalid property changes: %v", changes[0].Properties)
+	}
+
+	changes, err = watcher.Poll()
+	if err != nil || len(changes) != 0 {
+		t.Errorf("Unchanged resources should not be reported: %v %v", changes, err)
+	}
+
+	delete(testClient.resources, "/redfish/v1/Systems/1")
+	_, err = watcher.Poll()
+	if err == nil {
+		t.Errorf("Resources that cannot be read should be reported")
+	}
+}
+
+// TestWatcherLogEntries tests reporting the new entries of a log.
+func TestWatcherLogEntries(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Managers/BMC/LogServices/Log/Entries": `{
+			"@odata.type": "#LogEntryCollection.LogEntryCollection",
+			"Members": [
+				{"@odata.id": "/redfish/v1/Managers/BMC/LogServices/Log/Entries/1"}
+			],
+			"Members@odata.count": 1
+		}`,
+	}}
+
+	watcher := NewWatcher(testClient, WatcherOptions{})
+	watcher.Watch("/redfish/v1/Managers/BMC/LogServices/Log/Entries")
+
+	_, err := watcher.Poll()
+	if err != nil {
+		t.Fatalf("Error polling: %s", err)
+	}
+
+	testClient.resources["/redfish/v1/Managers/BMC/LogServices/Log/Entries"] = `{
+		"@odata.type": "#LogEntryCollection.LogEntryCollection",
+		"Members": [
+			{"@odata.id": "/redfish/v1/Managers/BMC/LogServices/Log/Entries/1"},
+			{
+				"@odata.id": "/redfish/v1/Managers/BMC/LogServices/Log/Entries/2",
+				"Id": "2",
+				"Message": "Login failure storm detected",
+				"Severity": "Warning"
+			}
+		],
+		"Members@odata.count": 2
+	}`
+	changes, err := watcher.Poll()
+	if err != nil {
+		t.Fatalf("Error polling: %s", err)
+	}
+
+	if len(changes) != 1 || len(changes[0].AddedMembers) != 1 || len(changes[0].LogEntries) != 1 {
+		t.Fatalf("Invalid change sets: %v", changes)
+	}
+
+	if changes[0].LogEntries[0].Message != "Login failure storm detected" {
+		t.Errorf("Invalid log entry: %v", changes[0].LogEntries[0])
+	}
+}
+
+// TestWatcherMembers tests watching the members of a collection, such as
+// the drives of a storage.
+func TestWatcherMembers(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{
+		"/redfish/v1/Drives": `{
+			"Members": [
+				{"@odata.id": "/redfish/v1/Drives/0"}
+			],
+			"Members@odata.count": 1
+		}`,
+		"/redfish/v1/Drives/0": `{"@odata.id": "/redfish/v1/Drives/0", "Status": {"Health": "OK"}}`,
+		"/redfish/v1/Drives/1": `{"@odata.id": "/redfish/v1/Drives/1", "Status": {"Health": "OK"}}`,
+	}}
+
+	watcher := NewWatcher(testClient, WatcherOptions{})
+	watcher.WatchMembers("/redfish/v1/Drives")
+
+	_, err := watcher.Poll()
+	if err != nil {
+		t.Fatalf("Error polling: %s", err)
+	}
+
+	gets := 0
+	for _, call := range testClient.CapturedCalls() {
+		if call.URL == "/redfish/v1/Drives" {
+			gets++
+		}
+	}
+	if gets != 1 {
+		t.Errorf("The collection should be got once per poll, got %d times", gets)
+	}
+
+	testClient.resources["/redfish/v1/Drives"] = `{
+		"Members": [
+			{"@odata.id": "/redfish/v1/Drives/0"},
+			{"@odata.id": "/redfish/v1/Drives/1"}
+		],
+		"Members@odata.count": 2
+	}`
+	testClient.resources["/redfish/v1/Drives/0"] = `{"@odata.id": "/redfish/v1/Drives/0", "Status": {"Health": "Critical"}}`
+	changes, err := watcher.Poll()
+	if err != nil {
+		t.Fatalf("Error polling: %s", err)
+	}
+
+	if len(changes) != 2 || changes[0].URI != "/redfish/v1/Drives" || len(changes[0].AddedMembers) != 1 {
+		t.Fatalf("Invalid change sets: %v", changes)
+	}
+	if changes[1].URI != "/redfish/v1/Drives/0" || changes[1].Health == nil || changes[1].Health.New != common.CriticalHealth {
+		t.Errorf("Invalid drive change set: %+v", changes[1])
+	}
+
+	// The new drive is watched from the poll it appeared in
+	testClient.resources["/redfish/v1/Drives/1"] = `{"@odata.id": "/redfish/v1/Drives/1", "Status": {"Health": "Warning"}}`
+	changes, err = watcher.Poll()
+	if err != nil || len(changes) != 1 || changes[0].URI != "/redfish/v1/Drives/1" {
+		t.Errorf("Invalid change sets of the new drive: %v %v", changes, err)
+	}
+
+	watcher.Unwatch("/redfish/v1/Drives")
+	changes, err = watcher.Poll()
+	if err != nil || len(changes) != 0 || len(watcher.order) != 0 {
+		t.Errorf("The members should not be watched anymore: %v %v", watcher.order, err)
+	}
+}
+
+// TestWatcherRun tests polling until the context is done.
+func TestWatcherRun(t *testing.T) {
+	testClient := &resourceClient{resources: map[string]string{}}
+
+	errors := 0
+	watcher := NewWatcher(testClient, WatcherOptions{
+		Interval: 10 * time.Millisecond,
+		OnError:  func(err error) { errors++ },
+	})
+	watcher.Watch("/redfish/v1/Systems/1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := watcher.Run(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Run should end with the context: %v", err)
+	}
+
+	if errors < 2 {
+		t.Errorf("The errors of each poll should be reported: %d", errors)
+	}
+}
@@ -0,0 +1,95 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfish
+
+import (
+	"sync"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// serviceCache holds the sub-services and collections a service root got,
+// by URI.
+type serviceCache struct {
+	lock      sync.Mutex
+	resources map[string]interface{}
+}
+
+// EnableCache makes the service keep the sub-services and collections it
+// gets, such as AccountService() or Systems(), and return them again rather
+// than getting them from the service on every call. The cached objects are
+// shared by the callers, and are only refreshed once InvalidateCache is
+// called. Tasks and sessions, which come and go, are not cached.
+//
+// EnableCache, DisableCache and InvalidateCache may be called while the
+// service is in use.
+func (serviceroot *Service) EnableCache() {
+	for {
+		current := serviceroot.cache.Load()
+		if cache, _ := current.(*serviceCache); cache != nil {
+			return
+		}
+
+		cache := &serviceCache{resources: make(map[string]interface{})}
+		if serviceroot.cache.CompareAndSwap(current, cache) {
+			return
+		}
+	}
+}
+
+// DisableCache stops caching the sub-services and collections of the service
+// and drops the cached ones.
+func (serviceroot *Service) DisableCache() {
+	serviceroot.cache.Store((*serviceCache)(nil))
+}
+
+// InvalidateCache drops the cached sub-services and collections of the
+// service, so they are got again from the service on their next call. It
+// does nothing if caching is not enabled.
+func (serviceroot *Service) InvalidateCache() {
+	cache := serviceroot.serviceCache()
+	if cache == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.resources = make(map[string]interface{})
+}
+
+// serviceCache gets the cache of the service, or nil if caching is not
+// enabled.
+func (serviceroot *Service) serviceCache() *serviceCache {
+	cache, _ := serviceroot.cache.Load().(*serviceCache)
+	return cache
+}
+
+// cachedResource gets the resource at uri with get, such as
+// redfish.GetAccountService, or the cached one if caching is enabled and it
+// was got before. Resources are only cached when get succeeds.
+func cachedResource[T any](serviceroot *Service, uri string, get func(common.Client, string) (T, error)) (T, error) {
+	cache := serviceroot.serviceCache()
+	if cache == nil || uri == "" {
+		return get(serviceroot.Client, uri)
+	}
+
+	cache.lock.Lock()
+	resource, ok := cache.resources[uri].(T)
+	cache.lock.Unlock()
+	if ok {
+		return resource, nil
+	}
+
+	resource, err := get(serviceroot.Client, uri)
+	if err != nil {
+		return resource, err
+	}
+
+	cache.lock.Lock()
+	cache.resources[uri] = resource
+	cache.lock.Unlock()
+
+	return resource, nil
+}
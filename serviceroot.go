@@ -6,7 +6,7 @@ package wbfish
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"sync/atomic"
 
 	"github.com/LRichi/WBfish/common"
 	"github.com/LRichi/WBfish/redfish"
@@ -38,9 +38,26 @@ type Expand struct {
 	NoLinks bool
 }
 
+// DeepOperations shall contain information about deep operations that the
+// service supports.
+type DeepOperations struct {
+	// DeepPATCH shall indicate whether this service supports the Redfish
+	// Specification-defined deep PATCH operation.
+	DeepPATCH bool
+	// DeepPOST shall indicate whether this service supports the Redfish
+	// Specification-defined deep POST operation.
+	DeepPOST bool
+	// MaxLevels shall contain the maximum levels of resources allowed in deep
+	// operations.
+	MaxLevels int
+}
+
 // ProtocolFeaturesSupported contains information about protocol features
 // supported by the service.
 type ProtocolFeaturesSupported struct {
+	// DeepOperations shall contain information about deep operations that the
+	// service supports.
+	DeepOperations DeepOperations
 	// ExcerptQuery shall be a boolean indicating whether this service supports
 	// the use of the 'excerpt' query parameter as described by the
 	// specification.
@@ -85,6 +102,9 @@ type Service struct {
 	// CompositionService shall only contain a reference to a resource that
 	// complies to the CompositionService schema.
 	compositionService string
+	// ComponentIntegrity shall contain a link to a resource collection of type
+	// ComponentIntegrityCollection.
+	componentIntegrity string
 	// Description provides a description of this resource.
 	Description string
 	// EventService shall only contain a reference to a resource that complies
@@ -92,6 +112,9 @@ type Service struct {
 	eventService string
 	// Fabrics shall contain references to all Fabric instances.
 	fabrics string
+	// Facilities shall contain a link to a resource collection of type
+	// FacilityCollection.
+	facilities string
 	// JobService shall only contain a reference to a resource that conforms to
 	// the JobService schema.
 	jobService string
@@ -99,6 +122,11 @@ type Service struct {
 	// that comply to the SchemaFile schema where the files are Json-Schema
 	// files.
 	jsonSchemas string
+	// KeyService shall contain a link to a resource of type KeyService.
+	keyService string
+	// LicenseService shall contain a link to a resource of type
+	// LicenseService.
+	licenseService string
 	// Managers shall only contain a reference to a collection of resources that
 	// comply to the Managers schema.
 	managers string
@@ -113,6 +141,9 @@ type Service struct {
 	// majorversion.minorversion.errata in compliance with Protocol Version
 	// section of the Redfish specification.
 	RedfishVersion string
+	// PowerEquipment shall contain a link to a resource of type
+	// PowerEquipment.
+	powerEquipment string
 	// Registries shall contain a reference to Message Registry.
 	registries string
 	// ResourceBlocks shall contain references to all Resource Block instances.
@@ -120,6 +151,9 @@ type Service struct {
 	// SessionService shall only contain a reference to a resource that complies
 	// to the SessionService schema.
 	sessionService string
+	// serviceConditions shall contain a link to a resource of type
+	// ServiceConditions.
+	serviceConditions string
 	// StorageServices shall contain references to all StorageService instances.
 	storageServices string
 	// StorageSystems shall contain computer systems that act as storage
@@ -129,6 +163,9 @@ type Service struct {
 	// Systems shall only contain a reference to a collection of resources that
 	// comply to the Systems schema.
 	systems string
+	// ThermalEquipment shall contain a link to a resource of type
+	// ThermalEquipment.
+	thermalEquipment string
 	// Tasks shall only contain a reference to a resource that complies to the
 	// TaskService schema.
 	tasks string
@@ -150,6 +187,9 @@ type Service struct {
 	Vendor string
 	// Sessions shall contain the link to a collection of Sessions.
 	sessions string
+	// cache holds the *serviceCache of the sub-services and collections got,
+	// if caching is enabled with EnableCache.
+	cache atomic.Value
 	// rawData raw data json
 	rawData []byte
 }
@@ -175,12 +215,19 @@ func (serviceroot *Service) UnmarshalJSON(b []byte) error {
 		Registries         common.Link
 		Systems            common.Link
 		CompositionService common.Link
+		ComponentIntegrity common.Link
 		Fabrics            common.Link
+		Facilities         common.Link
 		JobService         common.Link
 		JSONSchemas        common.Link `json:"JsonSchemas"`
+		KeyService         common.Link
+		LicenseService     common.Link
+		PowerEquipment     common.Link
 		ResourceBlocks     common.Link
 		SessionService     common.Link
+		ServiceConditions  common.Link
 		TelemetryService   common.Link
+		ThermalEquipment   common.Link
 		UpdateService      common.Link
 		Links              struct {
 			Sessions common.Link
@@ -206,59 +253,100 @@ func (serviceroot *Service) UnmarshalJSON(b []byte) error {
 	serviceroot.registries = string(t.Registries)
 	serviceroot.systems = string(t.Systems)
 	serviceroot.compositionService = string(t.CompositionService)
+	serviceroot.componentIntegrity = string(t.ComponentIntegrity)
 	serviceroot.fabrics = string(t.Fabrics)
+	serviceroot.facilities = string(t.Facilities)
 	serviceroot.jobService = string(t.JobService)
 	serviceroot.jsonSchemas = string(t.JSONSchemas)
+	serviceroot.keyService = string(t.KeyService)
+	serviceroot.licenseService = string(t.LicenseService)
+	serviceroot.powerEquipment = string(t.PowerEquipment)
 	serviceroot.resourceBlocks = string(t.ResourceBlocks)
 	serviceroot.sessionService = string(t.SessionService)
+	serviceroot.serviceConditions = string(t.ServiceConditions)
 	serviceroot.telemetryService = string(t.TelemetryService)
+	serviceroot.thermalEquipment = string(t.ThermalEquipment)
 	serviceroot.updateService = string(t.UpdateService)
+	serviceroot.rawData = b
+	serviceroot.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the service root to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (serviceroot Service) MarshalJSON() ([]byte, error) {
+	type temp Service
+	return common.MarshalWithRawData(serviceroot.rawData, temp(serviceroot))
+}
+
 // ServiceRoot will get a Service instance from the service.
 func ServiceRoot(c common.Client) (*Service, error) {
-	resp, err := c.Get(common.DefaultServiceRoot)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var serviceroot Service
-
-	serviceroot.rawData, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(serviceroot.rawData, &serviceroot)
+	err := common.FetchResource(c, common.DefaultServiceRoot, &serviceroot)
 	if err != nil {
 		return nil, err
 	}
 
-	serviceroot.SetClient(c)
 	return &serviceroot, nil
 }
 
 // Chassis gets the chassis instances managed by this service.
 func (serviceroot *Service) Chassis() ([]*redfish.Chassis, error) {
+	return cachedResource(serviceroot, serviceroot.chassis, redfish.ListReferencedChassis)
+}
+
+// ChassisExpanded gets the chassis instances managed by this service like
+// Chassis, in a single request if the service supports $expand. The chassis
+// the service does not expand, or all of them if it does not support
+// $expand, are got one by one. The chassis are not cached.
+func (serviceroot *Service) ChassisExpanded() ([]*redfish.Chassis, error) {
+	if query := serviceroot.expandQuery(); query != "" && serviceroot.chassis != "" {
+		chassis, err := redfish.ListReferencedChassis(serviceroot.Client, serviceroot.chassis+query)
+		if err == nil {
+			return chassis, nil
+		}
+	}
+
 	return redfish.ListReferencedChassis(serviceroot.Client, serviceroot.chassis)
 }
 
+// expandQuery gets the query that expands the members of a collection, as
+// the service supports it, or an empty string if it does not support
+// $expand.
+func (serviceroot *Service) expandQuery() string {
+	expand := serviceroot.ProtocolFeaturesSupported.ExpandQuery
+
+	var query string
+	switch {
+	case expand.NoLinks:
+		query = "?$expand=."
+	case expand.ExpandAll:
+		query = "?$expand=*"
+	default:
+		return ""
+	}
+
+	if expand.Levels {
+		query += "($levels=1)"
+	}
+
+	return query
+}
+
 // Managers gets the manager instances of this service.
 func (serviceroot *Service) Managers() ([]*redfish.Manager, error) {
-	return redfish.ListReferencedManagers(serviceroot.Client, serviceroot.managers)
+	return cachedResource(serviceroot, serviceroot.managers, redfish.ListReferencedManagers)
 }
 
 // StorageSystems gets the storage system instances managed by this service.
 func (serviceroot *Service) StorageSystems() ([]*swordfish.StorageSystem, error) {
-	return swordfish.ListReferencedStorageSystems(serviceroot.Client, serviceroot.storageSystems)
+	return cachedResource(serviceroot, serviceroot.storageSystems, swordfish.ListReferencedStorageSystems)
 }
 
 // StorageServices gets the Swordfish storage services
 func (serviceroot *Service) StorageServices() ([]*swordfish.StorageService, error) {
-	return swordfish.ListReferencedStorageServices(serviceroot.Client, serviceroot.storageServices)
+	return cachedResource(serviceroot, serviceroot.storageServices, swordfish.ListReferencedStorageServices)
 }
 
 // Tasks gets the system's tasks
@@ -283,20 +371,96 @@ func (serviceroot *Service) DeleteSession(url string) error {
 
 // AccountService gets the Redfish AccountService
 func (serviceroot *Service) AccountService() (*redfish.AccountService, error) {
-	return redfish.GetAccountService(serviceroot.Client, serviceroot.accountService)
+	return cachedResource(serviceroot, serviceroot.accountService, redfish.GetAccountService)
 }
 
 // EventService gets the Redfish EventService
 func (serviceroot *Service) EventService() (*redfish.EventService, error) {
-	return redfish.GetEventService(serviceroot.Client, serviceroot.eventService)
+	return cachedResource(serviceroot, serviceroot.eventService, redfish.GetEventService)
 }
 
 // Systems get the system instances from the service
 func (serviceroot *Service) Systems() ([]*redfish.ComputerSystem, error) {
+	return cachedResource(serviceroot, serviceroot.systems, redfish.ListReferencedComputerSystems)
+}
+
+// SystemsExpanded gets the system instances from the service like Systems,
+// in a single request if the service supports $expand. The systems the
+// service does not expand, or all of them if it does not support $expand,
+// are got one by one. The systems are not cached.
+func (serviceroot *Service) SystemsExpanded() ([]*redfish.ComputerSystem, error) {
+	if query := serviceroot.expandQuery(); query != "" && serviceroot.systems != "" {
+		systems, err := redfish.ListReferencedComputerSystems(serviceroot.Client, serviceroot.systems+query)
+		if err == nil {
+			return systems, nil
+		}
+	}
+
 	return redfish.ListReferencedComputerSystems(serviceroot.Client, serviceroot.systems)
 }
 
 // CompositionService gets the composition service instance
 func (serviceroot *Service) CompositionService() (*redfish.CompositionService, error) {
-	return redfish.GetCompositionService(serviceroot.Client, serviceroot.compositionService)
+	return cachedResource(serviceroot, serviceroot.compositionService, redfish.GetCompositionService)
+}
+
+// LicenseService gets the Redfish LicenseService
+func (serviceroot *Service) LicenseService() (*redfish.LicenseService, error) {
+	return cachedResource(serviceroot, serviceroot.licenseService, redfish.GetLicenseService)
+}
+
+// UpdateService gets the Redfish UpdateService
+func (serviceroot *Service) UpdateService() (*redfish.UpdateService, error) {
+	return cachedResource(serviceroot, serviceroot.updateService, redfish.GetUpdateService)
+}
+
+// CertificateService gets the Redfish CertificateService
+func (serviceroot *Service) CertificateService() (*redfish.CertificateService, error) {
+	return cachedResource(serviceroot, serviceroot.certificateService, redfish.GetCertificateService)
+}
+
+// KeyService gets the Redfish KeyService
+func (serviceroot *Service) KeyService() (*redfish.KeyService, error) {
+	return cachedResource(serviceroot, serviceroot.keyService, redfish.GetKeyService)
+}
+
+// Facilities gets the facilities, such as rooms or buildings, available on
+// this service
+func (serviceroot *Service) Facilities() ([]*redfish.Facility, error) {
+	return cachedResource(serviceroot, serviceroot.facilities, redfish.ListReferencedFacilities)
+}
+
+// PowerEquipment gets the power equipment, such as power distribution units
+// and transfer switches, available on this service
+func (serviceroot *Service) PowerEquipment() (*redfish.PowerEquipment, error) {
+	return cachedResource(serviceroot, serviceroot.powerEquipment, redfish.GetPowerEquipment)
+}
+
+// ThermalEquipment gets the cooling equipment, such as coolant distribution
+// units, available on this service
+func (serviceroot *Service) ThermalEquipment() (*redfish.ThermalEquipment, error) {
+	return cachedResource(serviceroot, serviceroot.thermalEquipment, redfish.GetThermalEquipment)
+}
+
+// ComponentIntegrity gets the integrity information of the components
+// available on this service
+func (serviceroot *Service) ComponentIntegrity() ([]*redfish.ComponentIntegrity, error) {
+	return cachedResource(serviceroot, serviceroot.componentIntegrity, redfish.ListReferencedComponentIntegrities)
+}
+
+// ServiceConditions gets the roll-up of the conditions requiring attention
+// in the service, to tell whether anything is wrong with it in a single
+// call. On services that predate ServiceConditions, the conditions are
+// rolled up from the status of the managers instead. The conditions are not
+// cached.
+func (serviceroot *Service) ServiceConditions() (*redfish.ServiceConditions, error) {
+	if serviceroot.serviceConditions != "" {
+		return redfish.GetServiceConditions(serviceroot.Client, serviceroot.serviceConditions)
+	}
+
+	managers, err := serviceroot.Managers()
+	if err != nil {
+		return nil, err
+	}
+	return redfish.RollUpManagerConditions(managers), nil
 }
@@ -30,18 +30,30 @@ var serviceRootBody = strings.NewReader(
 		"CompositionService": {
 			"@odata.id": "/redfish/v1/Compositions"
 		},
+		"ComponentIntegrity": {
+			"@odata.id": "/redfish/v1/ComponentIntegrity"
+		},
 		"EventService": {
 			"@odata.id": "/redfish/v1/Events"
 		},
 		"Fabrics": {
 			"@odata.id": "/redfish/v1/Fabrics"
 		},
+		"Facilities": {
+			"@odata.id": "/redfish/v1/Facilities"
+		},
 		"JobService": {
 			"@odata.id": "/redfish/v1/Jobs"
 		},
 		"JsonSchemas": {
 			"@odata.id": "/redfish/v1/JsonSchemas"
 		},
+		"KeyService": {
+			"@odata.id": "/redfish/v1/KeyService"
+		},
+		"LicenseService": {
+			"@odata.id": "/redfish/v1/LicenseService"
+		},
 		"Links": {
 			"Sessions": {
 				"@odata.id": "/redfish/v1/Sessions"
@@ -77,6 +89,9 @@ var serviceRootBody = strings.NewReader(
 		"StorageServices": {
 			"@odata.id": "/redfish/v1/StorageServices"
 		},
+		"PowerEquipment": {
+			"@odata.id": "/redfish/v1/PowerEquipment"
+		},
 		"StorageSystems": {
 			"@odata.id": "/redfish/v1/StorageSystems"
 		},
@@ -89,6 +104,9 @@ var serviceRootBody = strings.NewReader(
 		"TelemetryService": {
 			"@odata.id": "/redfish/v1/TelemetryService"
 		},
+		"ThermalEquipment": {
+			"@odata.id": "/redfish/v1/ThermalEquipment"
+		},
 		"UUID": "ae058175-af1d-40fe-ad5b-c1ab79de2c65",
 		"UpdateService": {
 			"@odata.id": "/redfish/v1/UpdateService"
@@ -129,6 +147,10 @@ func TestServiceRoot(t *testing.T) {
 		t.Errorf("Invalid CompositionService link: %s", result.compositionService)
 	}
 
+	if result.componentIntegrity != "/redfish/v1/ComponentIntegrity" {
+		t.Errorf("Invalid ComponentIntegrity link: %s", result.componentIntegrity)
+	}
+
 	if result.eventService != "/redfish/v1/Events" {
 		t.Errorf("Invalid EventService link: %s", result.eventService)
 	}
@@ -189,6 +211,26 @@ func TestServiceRoot(t *testing.T) {
 		t.Errorf("Invalid TelemetryService link: %s", result.telemetryService)
 	}
 
+	if result.powerEquipment != "/redfish/v1/PowerEquipment" {
+		t.Errorf("Invalid PowerEquipment link: %s", result.powerEquipment)
+	}
+
+	if result.thermalEquipment != "/redfish/v1/ThermalEquipment" {
+		t.Errorf("Invalid ThermalEquipment link: %s", result.thermalEquipment)
+	}
+
+	if result.keyService != "/redfish/v1/KeyService" {
+		t.Errorf("Invalid KeyService link: %s", result.keyService)
+	}
+
+	if result.facilities != "/redfish/v1/Facilities" {
+		t.Errorf("Invalid Facilities link: %s", result.facilities)
+	}
+
+	if result.licenseService != "/redfish/v1/LicenseService" {
+		t.Errorf("Invalid LicenseService link: %s", result.licenseService)
+	}
+
 	if result.updateService != "/redfish/v1/UpdateService" {
 		t.Errorf("Invalid UpdateService link: %s", result.updateService)
 	}
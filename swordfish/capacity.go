@@ -86,6 +86,13 @@ type CapacitySource struct {
 	// ProvidingVolumes if present, the value shall be a reference to a
 	// contributing volume or volumes.
 	providingVolumes string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (capacitysource *CapacitySource) GetRawData() []byte {
+	return capacitysource.rawData
 }
 
 // UnmarshalJSON unmarshals a CapacitySource object from the raw JSON.
@@ -115,25 +122,27 @@ func (capacitysource *CapacitySource) UnmarshalJSON(b []byte) error {
 	capacitysource.providingMemoryChunks = string(t.ProvidingMemoryChunks)
 	capacitysource.providingPools = string(t.ProvidingPools)
 	capacitysource.providingVolumes = string(t.ProvidingVolumes)
+	capacitysource.rawData = b
+	capacitysource.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the capacity source to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (capacitysource CapacitySource) MarshalJSON() ([]byte, error) {
+	type temp CapacitySource
+	return common.MarshalWithRawData(capacitysource.rawData, temp(capacitysource))
+}
+
 // GetCapacitySource will get a CapacitySource instance from the service.
 func GetCapacitySource(c common.Client, uri string) (*CapacitySource, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var capacitysource CapacitySource
-	err = json.NewDecoder(resp.Body).Decode(&capacitysource)
+	err := common.FetchResource(c, uri, &capacitysource)
 	if err != nil {
 		return nil, err
 	}
 
-	capacitysource.SetClient(c)
 	return &capacitysource, nil
 }
 
@@ -151,7 +160,11 @@ func ListReferencedCapacitySources(c common.Client, link string) ([]*CapacitySou
 	}
 
 	for _, capSourceLink := range links.ItemLinks {
-		capSource, err := GetCapacitySource(c, capSourceLink)
+		capSource := new(CapacitySource)
+		expanded, err := links.DecodeMember(c, capSourceLink, capSource)
+		if err == nil && !expanded {
+			capSource, err = GetCapacitySource(c, capSourceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -191,3 +204,77 @@ func (capacitysource *CapacitySource) ProvidingPools() ([]*StoragePool, error) {
 func (capacitysource *CapacitySource) ProvidingVolumes() ([]*Volume, error) {
 	return ListReferencedVolumes(capacitysource.Client, capacitysource.providingVolumes)
 }
+
+// odataID is a reference to another resource in a request body.
+type odataID struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// newODataID returns a reference to the resource at uri, or nil if uri is
+// empty so the reference can be omitted from a request body.
+func newODataID(uri string) *odataID {
+	if uri == "" {
+		return nil
+	}
+
+	return &odataID{ODataID: uri}
+}
+
+// odataIDMembers is a set of references to other resources in a request body.
+type odataIDMembers struct {
+	Members []odataID
+}
+
+// newODataIDMembers returns references to the resources at uris, or nil if
+// there are none.
+func newODataIDMembers(uris []string) *odataIDMembers {
+	if len(uris) == 0 {
+		return nil
+	}
+
+	result := &odataIDMembers{}
+	for _, uri := range uris {
+		result.Members = append(result.Members, odataID{ODataID: uri})
+	}
+
+	return result
+}
+
+// capacityPayload is the capacity requested when creating a resource.
+type capacityPayload struct {
+	Data struct {
+		AllocatedBytes int64
+	}
+}
+
+// newCapacityPayload returns the capacity to request for allocatedBytes, or
+// nil if the service should choose the capacity.
+func newCapacityPayload(allocatedBytes int64) *capacityPayload {
+	if allocatedBytes <= 0 {
+		return nil
+	}
+
+	result := &capacityPayload{}
+	result.Data.AllocatedBytes = allocatedBytes
+	return result
+}
+
+// capacitySourcePayload is the source of the capacity requested when
+// creating a resource.
+type capacitySourcePayload struct {
+	ProvidingDrives *odataIDMembers `json:",omitempty"`
+	ProvidingPools  *odataIDMembers `json:",omitempty"`
+}
+
+// newCapacitySourcesPayload returns the capacity sources for the provided
+// drives and pools, or nil if the service should choose the sources.
+func newCapacitySourcesPayload(drives []string, pools []string) []capacitySourcePayload {
+	if len(drives) == 0 && len(pools) == 0 {
+		return nil
+	}
+
+	return []capacitySourcePayload{{
+		ProvidingDrives: newODataIDMembers(drives),
+		ProvidingPools:  newODataIDMembers(pools),
+	}}
+}
@@ -57,18 +57,42 @@ type ClassOfService struct {
 	IOPerformanceLinesOfServiceCount int `json:"IOPerformanceLinesOfService@odata.count"`
 	// Identifier shall be unique within the managed ecosystem.
 	Identifier common.Identifier
+	// dataProtectionLines are the data protection lines of service embedded
+	// in this class of service.
+	dataProtectionLines []*DataProtectionLineOfService
+	// dataSecurityLines are the data security lines of service embedded in
+	// this class of service.
+	dataSecurityLines []*DataSecurityLineOfService
+	// dataStorageLines are the data storage lines of service embedded in
+	// this class of service.
+	dataStorageLines []*DataStorageLineOfService
+	// ioConnectivityLines are the IO connectivity lines of service embedded
+	// in this class of service.
+	ioConnectivityLines []*IOConnectivityLineOfService
+	// ioPerformanceLines are the IO performance lines of service embedded in
+	// this class of service.
+	ioPerformanceLines []*IOPerformanceLineOfService
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// UnmarshalJSON unmarshals a ClassOfService object from the raw JSON.
+// GetRawData get raw data json
+func (classofservice *ClassOfService) GetRawData() []byte {
+	return classofservice.rawData
+}
+
+// UnmarshalJSON unmarshals a ClassOfService object from the raw JSON. Older
+// services reference each line of service, newer ones embed them in the class
+// of service; both forms are accepted.
 func (classofservice *ClassOfService) UnmarshalJSON(b []byte) error {
 	type temp ClassOfService
 	var t struct {
 		temp
-		DataProtectionLinesOfService common.Links
-		DataSecurityLinesOfService   common.Links
-		DataStorageLinesOfService    common.Links
-		IOConnectivityLinesOfService common.Links
-		IOPerformanceLinesOfService  common.Links
+		DataProtectionLinesOfService []json.RawMessage
+		DataSecurityLinesOfService   []json.RawMessage
+		DataStorageLinesOfService    []json.RawMessage
+		IOConnectivityLinesOfService []json.RawMessage
+		IOPerformanceLinesOfService  []json.RawMessage
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -78,30 +102,101 @@ func (classofservice *ClassOfService) UnmarshalJSON(b []byte) error {
 
 	// Extract the links to other entities for later
 	*classofservice = ClassOfService(t.temp)
-	classofservice.dataProtectionLinesOfService = t.DataProtectionLinesOfService.ToStrings()
-	classofservice.dataSecurityLinesOfService = t.DataSecurityLinesOfService.ToStrings()
-	classofservice.dataStorageLinesOfService = t.DataStorageLinesOfService.ToStrings()
-	classofservice.ioConnectivityLinesOfService = t.IOConnectivityLinesOfService.ToStrings()
-	classofservice.ioPerformanceLinesOfService = t.IOPerformanceLinesOfService.ToStrings()
+	for _, raw := range t.DataProtectionLinesOfService {
+		if link, ok := lineOfServiceReference(raw); ok {
+			classofservice.dataProtectionLinesOfService = append(classofservice.dataProtectionLinesOfService, link)
+			continue
+		}
+		var los DataProtectionLineOfService
+		if err := json.Unmarshal(raw, &los); err != nil {
+			return err
+		}
+		classofservice.dataProtectionLines = append(classofservice.dataProtectionLines, &los)
+	}
+	for _, raw := range t.DataSecurityLinesOfService {
+		if link, ok := lineOfServiceReference(raw); ok {
+			classofservice.dataSecurityLinesOfService = append(classofservice.dataSecurityLinesOfService, link)
+			continue
+		}
+		var los DataSecurityLineOfService
+		if err := json.Unmarshal(raw, &los); err != nil {
+			return err
+		}
+		classofservice.dataSecurityLines = append(classofservice.dataSecurityLines, &los)
+	}
+	for _, raw := range t.DataStorageLinesOfService {
+		if link, ok := lineOfServiceReference(raw); ok {
+			classofservice.dataStorageLinesOfService = append(classofservice.dataStorageLinesOfService, link)
+			continue
+		}
+		var los DataStorageLineOfService
+		if err := json.Unmarshal(raw, &los); err != nil {
+			return err
+		}
+		classofservice.dataStorageLines = append(classofservice.dataStorageLines, &los)
+	}
+	for _, raw := range t.IOConnectivityLinesOfService {
+		if link, ok := lineOfServiceReference(raw); ok {
+			classofservice.ioConnectivityLinesOfService = append(classofservice.ioConnectivityLinesOfService, link)
+			continue
+		}
+		var los IOConnectivityLineOfService
+		if err := json.Unmarshal(raw, &los); err != nil {
+			return err
+		}
+		classofservice.ioConnectivityLines = append(classofservice.ioConnectivityLines, &los)
+	}
+	for _, raw := range t.IOPerformanceLinesOfService {
+		if link, ok := lineOfServiceReference(raw); ok {
+			classofservice.ioPerformanceLinesOfService = append(classofservice.ioPerformanceLinesOfService, link)
+			continue
+		}
+		var los IOPerformanceLineOfService
+		if err := json.Unmarshal(raw, &los); err != nil {
+			return err
+		}
+		classofservice.ioPerformanceLines = append(classofservice.ioPerformanceLines, &los)
+	}
+	classofservice.rawData = b
+	classofservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
-// GetClassOfService will get a ClassOfService instance from the service.
-func GetClassOfService(c common.Client, uri string) (*ClassOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// MarshalJSON marshals the class of service to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (classofservice ClassOfService) MarshalJSON() ([]byte, error) {
+	type temp ClassOfService
+	return common.MarshalWithRawData(classofservice.rawData, temp(classofservice))
+}
+
+// lineOfServiceReference returns the URI of a line of service if raw is only
+// a reference to it rather than the embedded line of service.
+func lineOfServiceReference(raw json.RawMessage) (string, bool) {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &properties); err != nil {
+		return "", false
 	}
-	defer resp.Body.Close()
 
+	var link common.Link
+	if len(properties) != 1 || properties["@odata.id"] == nil {
+		return "", false
+	}
+	if err := json.Unmarshal(raw, &link); err != nil {
+		return "", false
+	}
+
+	return string(link), true
+}
+
+// GetClassOfService will get a ClassOfService instance from the service.
+func GetClassOfService(c common.Client, uri string) (*ClassOfService, error) {
 	var classofservice ClassOfService
-	err = json.NewDecoder(resp.Body).Decode(&classofservice)
+	err := common.FetchResource(c, uri, &classofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	classofservice.SetClient(c)
 	return &classofservice, nil
 }
 
@@ -119,7 +214,11 @@ func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfServ
 	}
 
 	for _, classofserviceLink := range links.ItemLinks {
-		classofservice, err := GetClassOfService(c, classofserviceLink)
+		classofservice := new(ClassOfService)
+		expanded, err := links.DecodeMember(c, classofserviceLink, classofservice)
+		if err == nil && !expanded {
+			classofservice, err = GetClassOfService(c, classofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -132,11 +231,11 @@ func ListReferencedClassOfServices(c common.Client, link string) ([]*ClassOfServ
 // DataProtectionLinesOfServices gets the DataProtectionLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataProtectionLinesOfServices() ([]*DataProtectionLineOfService, error) {
-	var result []*DataProtectionLineOfService
+	result := append([]*DataProtectionLineOfService{}, classofservice.dataProtectionLines...)
 	for _, dpLosLink := range classofservice.dataProtectionLinesOfService {
 		dpLos, err := GetDataProtectionLineOfService(classofservice.Client, dpLosLink)
 		if err != nil {
-			return result, nil
+			return result, err
 		}
 		result = append(result, dpLos)
 	}
@@ -146,11 +245,11 @@ func (classofservice *ClassOfService) DataProtectionLinesOfServices() ([]*DataPr
 // DataSecurityLinesOfServices gets the DataSecurityLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataSecurityLinesOfServices() ([]*DataSecurityLineOfService, error) {
-	var result []*DataSecurityLineOfService
+	result := append([]*DataSecurityLineOfService{}, classofservice.dataSecurityLines...)
 	for _, dsLosLink := range classofservice.dataSecurityLinesOfService {
 		dsLos, err := GetDataSecurityLineOfService(classofservice.Client, dsLosLink)
 		if err != nil {
-			return result, nil
+			return result, err
 		}
 		result = append(result, dsLos)
 	}
@@ -160,11 +259,11 @@ func (classofservice *ClassOfService) DataSecurityLinesOfServices() ([]*DataSecu
 // DataStorageLinesOfServices gets the DataStorageLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) DataStorageLinesOfServices() ([]*DataStorageLineOfService, error) {
-	var result []*DataStorageLineOfService
+	result := append([]*DataStorageLineOfService{}, classofservice.dataStorageLines...)
 	for _, dsLosLink := range classofservice.dataStorageLinesOfService {
 		dsLos, err := GetDataStorageLineOfService(classofservice.Client, dsLosLink)
 		if err != nil {
-			return result, nil
+			return result, err
 		}
 		result = append(result, dsLos)
 	}
@@ -174,11 +273,11 @@ func (classofservice *ClassOfService) DataStorageLinesOfServices() ([]*DataStora
 // IOConnectivityLinesOfServices gets the IOConnectivityLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) IOConnectivityLinesOfServices() ([]*IOConnectivityLineOfService, error) {
-	var result []*IOConnectivityLineOfService
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
+	result := append([]*IOConnectivityLineOfService{}, classofservice.ioConnectivityLines...)
+	for _, ioLosLink := range classofservice.ioConnectivityLinesOfService {
 		ioLos, err := GetIOConnectivityLineOfService(classofservice.Client, ioLosLink)
 		if err != nil {
-			return result, nil
+			return result, err
 		}
 		result = append(result, ioLos)
 	}
@@ -188,11 +287,11 @@ func (classofservice *ClassOfService) IOConnectivityLinesOfServices() ([]*IOConn
 // IOPerformanceLinesOfServices gets the IOPerformanceLinesOfService that are
 // part of this ClassOfService.
 func (classofservice *ClassOfService) IOPerformanceLinesOfServices() ([]*IOPerformanceLineOfService, error) {
-	var result []*IOPerformanceLineOfService
-	for _, ioLosLink := range classofservice.dataSecurityLinesOfService {
+	result := append([]*IOPerformanceLineOfService{}, classofservice.ioPerformanceLines...)
+	for _, ioLosLink := range classofservice.ioPerformanceLinesOfService {
 		ioLos, err := GetIOPerformanceLineOfService(classofservice.Client, ioLosLink)
 		if err != nil {
-			return result, nil
+			return result, err
 		}
 		result = append(result, ioLos)
 	}
@@ -72,3 +72,63 @@ func TestClassOfService(t *testing.T) {
 		t.Errorf("Invalid DataProtectionLineOfService link: %s", result.dataProtectionLinesOfService[0])
 	}
 }
+
+var embeddedClassOfServiceBody = `{
+		"@odata.type": "#ClassOfService.v1_2_0.ClassOfService",
+		"@odata.id": "/redfish/v1/StorageServices/1/ClassesOfService/Gold",
+		"Id": "Gold",
+		"Name": "Gold",
+		"DataStorageLinesOfService": [
+			{
+				"@odata.id": "/redfish/v1/StorageServices/1/ClassesOfService/Gold#/DataStorageLinesOfService/0",
+				"Id": "0",
+				"Name": "Gold Storage",
+				"IsSpaceEfficient": true,
+				"ProvisioningPolicy": "Thin",
+				"RecoverableCapacitySourceCount": 2
+			}
+		],
+		"IOPerformanceLinesOfService": [
+			{
+				"@odata.id": "/redfish/v1/StorageServices/1/ClassesOfService/Gold#/IOPerformanceLinesOfService/0",
+				"Id": "0",
+				"Name": "Gold Performance",
+				"AverageIOOperationLatencyMicroseconds": 500,
+				"IOOperationsPerSecondIsLimited": true,
+				"MaxIOOperationsPerSecondPerTerabyte": 2000
+			}
+		]
+	}`
+
+// TestClassOfServiceEmbeddedLinesOfService tests the parsing of lines of
+// service embedded in a ClassOfService.
+func TestClassOfServiceEmbeddedLinesOfService(t *testing.T) {
+	var result ClassOfService
+	err := json.NewDecoder(strings.NewReader(embeddedClassOfServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if len(result.dataStorageLinesOfService) != 0 {
+		t.Errorf("Embedded lines of service should not be links: %v", result.dataStorageLinesOfService)
+	}
+
+	storage, err := result.DataStorageLinesOfServices()
+	if err != nil {
+		t.Errorf("Error getting data storage lines of service: %s", err)
+	}
+
+	if len(storage) != 1 || storage[0].ProvisioningPolicy != ThinProvisioningPolicy {
+		t.Errorf("Invalid data storage lines of service: %v", storage)
+	}
+
+	performance, err := result.IOPerformanceLinesOfServices()
+	if err != nil {
+		t.Errorf("Error getting IO performance lines of service: %s", err)
+	}
+
+	if len(performance) != 1 || performance[0].AverageIOOperationLatencyMicroseconds != 500 {
+		t.Errorf("Invalid IO performance lines of service: %v", performance)
+	}
+}
@@ -0,0 +1,497 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package swordfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+// ApplicationConsistencyMethod is the method used to ensure consistency of
+// the volumes in a consistency group.
+type ApplicationConsistencyMethod string
+
+const (
+	// HotStandbyApplicationConsistencyMethod shall indicate that the
+	// consistency method is supported by a hot standby application.
+	HotStandbyApplicationConsistencyMethod ApplicationConsistencyMethod = "HotStandby"
+	// OtherApplicationConsistencyMethod shall indicate that the consistency
+	// method is not one of the defined values.
+	OtherApplicationConsistencyMethod ApplicationConsistencyMethod = "Other"
+	// VASAApplicationConsistencyMethod shall indicate that the consistency
+	// method is supported by VMware vStorage APIs for Storage Awareness.
+	VASAApplicationConsistencyMethod ApplicationConsistencyMethod = "VASA"
+	// VDIApplicationConsistencyMethod shall indicate that the consistency
+	// method is supported by the Microsoft Virtual Device Interface.
+	VDIApplicationConsistencyMethod ApplicationConsistencyMethod = "VDI"
+	// VSSApplicationConsistencyMethod shall indicate that the consistency
+	// method is supported by the Microsoft Volume Shadow Copy Service.
+	VSSApplicationConsistencyMethod ApplicationConsistencyMethod = "VSS"
+)
+
+// GroupConsistencyType is the consistency guarantee provided for the volumes
+// of a consistency group.
+type GroupConsistencyType string
+
+const (
+	// CrashConsistentGroupConsistencyType shall indicate that the requested
+	// consistency is of type crash consistent.
+	CrashConsistentGroupConsistencyType GroupConsistencyType = "CrashConsistent"
+	// ApplicationConsistentGroupConsistencyType shall indicate that the
+	// requested consistency is of type application consistent.
+	ApplicationConsistentGroupConsistencyType GroupConsistencyType = "ApplicationConsistent"
+)
+
+// ConsistencyGroup is a collection of volumes that are managed as a unit so
+// that operations such as snapshots and replication are applied to all of
+// them at a consistent point in time.
+type ConsistencyGroup struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataEtag is the odata etag.
+	ODataEtag string `json:"@odata.etag"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ConsistencyMethod shall set the consistency method used by this group.
+	ConsistencyMethod ApplicationConsistencyMethod
+	// ConsistencyType shall set the consistency type used by this group.
+	ConsistencyType GroupConsistencyType
+	// Description provides a description of this resource.
+	Description string
+	// IsConsistent shall be set to true when the consistency group is in a
+	// consistent state.
+	IsConsistent bool
+	// RemoteReplicaTargets shall reference the URIs to the remote target
+	// replicas that are sourced by this replica. Remote indicates that the
+	// replica is managed by a separate Swordfish service instance.
+	RemoteReplicaTargets []string
+	// ReplicaInfo shall describe the replication relationship between this
+	// consistency group and a corresponding source consistency group.
+	ReplicaInfo ReplicaInfo
+	// ReplicaTargetsCount is the number of replica targets.
+	ReplicaTargetsCount int `json:"ReplicaTargets@odata.count"`
+	// Status is the status of this group.
+	Status common.Status
+	// VolumesCount is the number of volumes in this group.
+	VolumesCount int `json:"Volumes@odata.count"`
+	// replicaTargets shall reference the target replicas that are sourced by
+	// this replica.
+	replicaTargets []string
+	// volumes is the set of volumes that are members of this group.
+	volumes []string
+	// assignReplicaTargetTarget is the URL to send AssignReplicaTarget requests.
+	assignReplicaTargetTarget string
+	// createReplicaTargetTarget is the URL to send CreateReplicaTarget requests.
+	createReplicaTargetTarget string
+	// removeReplicaRelationshipTarget is the URL to send RemoveReplicaRelationship requests.
+	removeReplicaRelationshipTarget string
+	// resumeReplicationTarget is the URL to send ResumeReplication requests.
+	resumeReplicationTarget string
+	// reverseReplicationRelationshipTarget is the URL to send
+	// ReverseReplicationRelationship requests.
+	reverseReplicationRelationshipTarget string
+	// splitReplicationTarget is the URL to send SplitReplication requests.
+	splitReplicationTarget string
+	// suspendReplicationTarget is the URL to send SuspendReplication requests.
+	suspendReplicationTarget string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (consistencygroup *ConsistencyGroup) GetRawData() []byte {
+	return consistencygroup.rawData
+}
+
+// UnmarshalJSON unmarshals a ConsistencyGroup object from the raw JSON.
+func (consistencygroup *ConsistencyGroup) UnmarshalJSON(b []byte) error {
+	type temp ConsistencyGroup
+	type actions struct {
+		AssignReplicaTarget struct {
+			Target string
+		} `json:"#ConsistencyGroup.AssignReplicaTarget"`
+		CreateReplicaTarget struct {
+			Target string
+		} `json:"#ConsistencyGroup.CreateReplicaTarget"`
+		RemoveReplicaRelationship struct {
+			Target string
+		} `json:"#ConsistencyGroup.RemoveReplicaRelationship"`
+		ResumeReplication struct {
+			Target string
+		} `json:"#ConsistencyGroup.ResumeReplication"`
+		ReverseReplicationRelationship struct {
+			Target string
+		} `json:"#ConsistencyGroup.ReverseReplicationRelationship"`
+		SplitReplication struct {
+			Target string
+		} `json:"#ConsistencyGroup.SplitReplication"`
+		SuspendReplication struct {
+			Target string
+		} `json:"#ConsistencyGroup.SuspendReplication"`
+	}
+	var t struct {
+		temp
+		ReplicaTargets common.Links
+		Volumes        common.Links
+		Actions        actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*consistencygroup = ConsistencyGroup(t.temp)
+	consistencygroup.replicaTargets = t.ReplicaTargets.ToStrings()
+	consistencygroup.volumes = t.Volumes.ToStrings()
+	consistencygroup.assignReplicaTargetTarget = t.Actions.AssignReplicaTarget.Target
+	consistencygroup.createReplicaTargetTarget = t.Actions.CreateReplicaTarget.Target
+	consistencygroup.removeReplicaRelationshipTarget = t.Actions.RemoveReplicaRelationship.Target
+	consistencygroup.resumeReplicationTarget = t.Actions.ResumeReplication.Target
+	consistencygroup.reverseReplicationRelationshipTarget = t.Actions.ReverseReplicationRelationship.Target
+	consistencygroup.splitReplicationTarget = t.Actions.SplitReplication.Target
+	consistencygroup.suspendReplicationTarget = t.Actions.SuspendReplication.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	consistencygroup.rawData = b
+	consistencygroup.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the consistency group to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (consistencygroup ConsistencyGroup) MarshalJSON() ([]byte, error) {
+	type temp ConsistencyGroup
+	return common.MarshalWithRawData(consistencygroup.rawData, temp(consistencygroup))
+}
+
+// Update commits updates to this object's properties to the running system.
+func (consistencygroup *ConsistencyGroup) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(ConsistencyGroup)
+	original.UnmarshalJSON(consistencygroup.rawData)
+
+	readWriteFields := []string{
+		"ConsistencyMethod",
+		"ConsistencyType",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(consistencygroup).Elem()
+
+	return consistencygroup.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetConsistencyGroup will get a ConsistencyGroup instance from the service.
+func GetConsistencyGroup(c common.Client, uri string) (*ConsistencyGroup, error) {
+	var consistencygroup ConsistencyGroup
+	err := common.FetchResource(c, uri, &consistencygroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consistencygroup, nil
+}
+
+// ListReferencedConsistencyGroups gets the collection of ConsistencyGroup from
+// a provided reference.
+func ListReferencedConsistencyGroups(c common.Client, link string) ([]*ConsistencyGroup, error) {
+	var result []*ConsistencyGroup
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, consistencygroupLink := range links.ItemLinks {
+		consistencygroup := new(ConsistencyGroup)
+		expanded, err := links.DecodeMember(c, consistencygroupLink, consistencygroup)
+		if err == nil && !expanded {
+			consistencygroup, err = GetConsistencyGroup(c, consistencygroupLink)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, consistencygroup)
+	}
+
+	return result, nil
+}
+
+// ReplicaTargets gets the target replica consistency groups that are sourced
+// by this group.
+func (consistencygroup *ConsistencyGroup) ReplicaTargets() ([]*ConsistencyGroup, error) {
+	var result []*ConsistencyGroup
+	for _, cgLink := range consistencygroup.replicaTargets {
+		cg, err := GetConsistencyGroup(consistencygroup.Client, cgLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, cg)
+	}
+
+	return result, nil
+}
+
+// VolumeURIs gets the URIs of the volumes that are members of this group.
+func (consistencygroup *ConsistencyGroup) VolumeURIs() []string {
+	return consistencygroup.volumes
+}
+
+// Volumes gets the volumes that are members of this group.
+func (consistencygroup *ConsistencyGroup) Volumes() ([]*Volume, error) {
+	var result []*Volume
+	for _, volumeLink := range consistencygroup.volumes {
+		volume, err := GetVolume(consistencygroup.Client, volumeLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, volume)
+	}
+
+	return result, nil
+}
+
+// SetVolumes replaces the members of this group with the volumes at
+// volumeURIs.
+func (consistencygroup *ConsistencyGroup) SetVolumes(volumeURIs []string) error {
+	type temp struct {
+		Volumes []odataID
+	}
+	t := temp{Volumes: []odataID{}}
+	for _, uri := range volumeURIs {
+		t.Volumes = append(t.Volumes, odataID{ODataID: uri})
+	}
+
+	_, err := consistencygroup.Client.Patch(consistencygroup.ODataID, t)
+	if err == nil {
+		consistencygroup.volumes = volumeURIs
+		consistencygroup.VolumesCount = len(volumeURIs)
+	}
+	return err
+}
+
+// AddVolume adds the volume at volumeURI to the members of this group.
+func (consistencygroup *ConsistencyGroup) AddVolume(volumeURI string) error {
+	for _, uri := range consistencygroup.volumes {
+		if uri == volumeURI {
+			return nil
+		}
+	}
+
+	volumes := append([]string{}, consistencygroup.volumes...)
+	return consistencygroup.SetVolumes(append(volumes, volumeURI))
+}
+
+// RemoveVolume removes the volume at volumeURI from the members of this group.
+func (consistencygroup *ConsistencyGroup) RemoveVolume(volumeURI string) error {
+	volumes := []string{}
+	for _, uri := range consistencygroup.volumes {
+		if uri != volumeURI {
+			volumes = append(volumes, uri)
+		}
+	}
+
+	if len(volumes) == len(consistencygroup.volumes) {
+		return nil
+	}
+
+	return consistencygroup.SetVolumes(volumes)
+}
+
+// Delete removes this consistency group from the service. The member volumes
+// are not deleted.
+func (consistencygroup *ConsistencyGroup) Delete() error {
+	return consistencygroup.Client.Delete(consistencygroup.ODataID)
+}
+
+// AssignReplicaTarget is used to establish a replication relationship by
+// assigning an existing consistency group to serve as a target replica for
+// this source consistency group.
+func (consistencygroup *ConsistencyGroup) AssignReplicaTarget(
+	replicaType ReplicaType, updateMode ReplicaUpdateMode, targetGroupODataID string) error {
+
+	if consistencygroup.assignReplicaTargetTarget == "" {
+		return fmt.Errorf("AssignReplicaTarget action is not supported by this consistency group")
+	}
+
+	// Define this action's parameters
+	type temp struct {
+		ReplicaType            ReplicaType
+		ReplicaUpdateMode      ReplicaUpdateMode
+		TargetConsistencyGroup string
+	}
+
+	// Set the values for the action arguments
+	t := temp{
+		ReplicaType:            replicaType,
+		ReplicaUpdateMode:      updateMode,
+		TargetConsistencyGroup: targetGroupODataID,
+	}
+
+	_, err := consistencygroup.Client.Post(consistencygroup.assignReplicaTargetTarget, t)
+	return err
+}
+
+// CreateReplicaTarget is used to create a new consistency group, with a
+// replica of each member volume allocated from the storage pool at
+// targetStoragePoolODataID. Using SnapshotReplicaType takes a crash or
+// application consistent snapshot of all the member volumes at once.
+func (consistencygroup *ConsistencyGroup) CreateReplicaTarget(
+	replicaType ReplicaType, updateMode ReplicaUpdateMode, targetStoragePoolODataID string, groupName string) error {
+
+	if consistencygroup.createReplicaTargetTarget == "" {
+		return fmt.Errorf("CreateReplicaTarget action is not supported by this consistency group")
+	}
+
+	// Define this action's parameters
+	type temp struct {
+		ConsistencyGroupName string `json:",omitempty"`
+		ReplicaType          ReplicaType
+		ReplicaUpdateMode    ReplicaUpdateMode
+		TargetStoragePool    string
+	}
+
+	// Set the values for the action arguments
+	t := temp{
+		ConsistencyGroupName: groupName,
+		ReplicaType:          replicaType,
+		ReplicaUpdateMode:    updateMode,
+		TargetStoragePool:    targetStoragePoolODataID,
+	}
+
+	_, err := consistencygroup.Client.Post(consistencygroup.createReplicaTargetTarget, t)
+	return err
+}
+
+// RemoveReplicaRelationship is used to disable data synchronization between
+// this source and the target consistency group, remove the replication
+// relationship, and optionally delete the target consistency group.
+func (consistencygroup *ConsistencyGroup) RemoveReplicaRelationship(deleteTarget bool, targetGroupODataID string) error {
+	if consistencygroup.removeReplicaRelationshipTarget == "" {
+		return fmt.Errorf("RemoveReplicaRelationship action is not supported by this consistency group")
+	}
+
+	// Define this action's parameters
+	type temp struct {
+		DeleteTargetConsistencyGroup bool
+		TargetConsistencyGroup       string
+	}
+
+	// Set the values for the action arguments
+	t := temp{
+		DeleteTargetConsistencyGroup: deleteTarget,
+		TargetConsistencyGroup:       targetGroupODataID,
+	}
+
+	_, err := consistencygroup.Client.Post(consistencygroup.removeReplicaRelationshipTarget, t)
+	return err
+}
+
+// replicationAction posts a replication action that only takes the target
+// consistency group.
+func (consistencygroup *ConsistencyGroup) replicationAction(name string, target string, targetGroupODataID string) error {
+	if target == "" {
+		return fmt.Errorf("%s action is not supported by this consistency group", name)
+	}
+
+	// Define this action's parameters
+	type temp struct {
+		TargetConsistencyGroup string
+	}
+
+	// Set the values for the action arguments
+	t := temp{TargetConsistencyGroup: targetGroupODataID}
+
+	_, err := consistencygroup.Client.Post(target, t)
+	return err
+}
+
+// ResumeReplication is used to resume the active data synchronization between
+// this source and the target consistency group, without otherwise altering the
+// replication relationship.
+func (consistencygroup *ConsistencyGroup) ResumeReplication(targetGroupODataID string) error {
+	return consistencygroup.replicationAction("ResumeReplication",
+		consistencygroup.resumeReplicationTarget, targetGroupODataID)
+}
+
+// ReverseReplicationRelationship is used to reverse the replication
+// relationship between this source and the target consistency group.
+func (consistencygroup *ConsistencyGroup) ReverseReplicationRelationship(targetGroupODataID string) error {
+	return consistencygroup.replicationAction("ReverseReplicationRelationship",
+		consistencygroup.reverseReplicationRelationshipTarget, targetGroupODataID)
+}
+
+// SplitReplication is used to split the replication relationship and suspend
+// data synchronization between this source and the target consistency group.
+func (consistencygroup *ConsistencyGroup) SplitReplication(targetGroupODataID string) error {
+	return consistencygroup.replicationAction("SplitReplication",
+		consistencygroup.splitReplicationTarget, targetGroupODataID)
+}
+
+// SuspendReplication is used to suspend active data synchronization between
+// this source and the target consistency group, without otherwise altering the
+// replication relationship.
+func (consistencygroup *ConsistencyGroup) SuspendReplication(targetGroupODataID string) error {
+	return consistencygroup.replicationAction("SuspendReplication",
+		consistencygroup.suspendReplicationTarget, targetGroupODataID)
+}
+
+// ConsistencyGroupCreateParameters are the properties used to create a new
+// consistency group.
+type ConsistencyGroupCreateParameters struct {
+	// Name is the name of the new consistency group.
+	Name string
+	// ConsistencyMethod is the consistency method the group uses.
+	ConsistencyMethod ApplicationConsistencyMethod
+	// ConsistencyType is the consistency type the group uses.
+	ConsistencyType GroupConsistencyType
+	// Volumes are the URIs of the volumes that are members of the group.
+	Volumes []string
+}
+
+// createPayload builds the body used to create a consistency group in a
+// collection.
+func (parameters *ConsistencyGroupCreateParameters) createPayload() interface{} {
+	type temp struct {
+		Name              string
+		ConsistencyMethod ApplicationConsistencyMethod `json:",omitempty"`
+		ConsistencyType   GroupConsistencyType         `json:",omitempty"`
+		Volumes           []odataID                    `json:",omitempty"`
+	}
+	t := temp{
+		Name:              parameters.Name,
+		ConsistencyMethod: parameters.ConsistencyMethod,
+		ConsistencyType:   parameters.ConsistencyType,
+	}
+	for _, uri := range parameters.Volumes {
+		t.Volumes = append(t.Volumes, odataID{ODataID: uri})
+	}
+
+	return t
+}
+
+// CreateConsistencyGroup creates a new consistency group in the provided
+// consistency group collection.
+func CreateConsistencyGroup(c common.Client, collection string, parameters *ConsistencyGroupCreateParameters) error {
+	if collection == "" {
+		return fmt.Errorf("a consistency group collection is required to create a consistency group")
+	}
+
+	_, err := c.Post(collection, parameters.createPayload())
+	return err
+}
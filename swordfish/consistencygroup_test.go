@@ -0,0 +1,193 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package swordfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish/common"
+)
+
+var consistencyGroupBody = `{
+		"@odata.context": "/redfish/v1/$metadata#ConsistencyGroup.ConsistencyGroup",
+		"@odata.type": "#ConsistencyGroup.v1_0_0.ConsistencyGroup",
+		"@odata.id": "/redfish/v1/StorageServices/1/ConsistencyGroups/CG1",
+		"Id": "CG1",
+		"Name": "Database",
+		"Description": "Database volumes",
+		"ConsistencyMethod": "VSS",
+		"ConsistencyType": "ApplicationConsistent",
+		"IsConsistent": true,
+		"ReplicaTargets": [{
+			"@odata.id": "/redfish/v1/StorageServices/2/ConsistencyGroups/CG1"
+		}],
+		"ReplicaTargets@odata.count": 1,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Volumes": [{
+				"@odata.id": "/redfish/v1/StorageServices/1/Volumes/Data"
+			},
+			{
+				"@odata.id": "/redfish/v1/StorageServices/1/Volumes/Log"
+			}
+		],
+		"Volumes@odata.count": 2,
+		"Actions": {
+			"#ConsistencyGroup.CreateReplicaTarget": {
+				"target": "/redfish/v1/StorageServices/1/ConsistencyGroups/CG1/Actions/ConsistencyGroup.CreateReplicaTarget"
+			},
+			"#ConsistencyGroup.SuspendReplication": {
+				"target": "/redfish/v1/StorageServices/1/ConsistencyGroups/CG1/Actions/ConsistencyGroup.SuspendReplication"
+			}
+		}
+	}`
+
+// TestConsistencyGroup tests the parsing of ConsistencyGroup objects.
+func TestConsistencyGroup(t *testing.T) {
+	var result ConsistencyGroup
+	err := json.NewDecoder(strings.NewReader(consistencyGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CG1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ConsistencyMethod != VSSApplicationConsistencyMethod {
+		t.Errorf("Invalid ConsistencyMethod: %s", result.ConsistencyMethod)
+	}
+
+	if result.ConsistencyType != ApplicationConsistentGroupConsistencyType {
+		t.Errorf("Invalid ConsistencyType: %s", result.ConsistencyType)
+	}
+
+	if !result.IsConsistent {
+		t.Error("IsConsistent should be true")
+	}
+
+	if len(result.VolumeURIs()) != 2 || result.VolumesCount != 2 {
+		t.Errorf("Unexpected volumes: %v", result.VolumeURIs())
+	}
+
+	if len(result.replicaTargets) != 1 {
+		t.Errorf("Unexpected number of replica targets: %d", len(result.replicaTargets))
+	}
+
+	if result.suspendReplicationTarget == "" {
+		t.Error("Missing SuspendReplication target")
+	}
+}
+
+// TestConsistencyGroupVolumes tests changing the members of a consistency group.
+func TestConsistencyGroupVolumes(t *testing.T) {
+	var result ConsistencyGroup
+	err := json.NewDecoder(strings.NewReader(consistencyGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.AddVolume("/redfish/v1/StorageServices/1/Volumes/Temp")
+	if err != nil {
+		t.Errorf("Error making AddVolume call: %s", err)
+	}
+
+	err = result.RemoveVolume("/redfish/v1/StorageServices/1/Volumes/Log")
+	if err != nil {
+		t.Errorf("Error making RemoveVolume call: %s", err)
+	}
+
+	// Removing a volume that is not a member does not call the service.
+	err = result.RemoveVolume("/redfish/v1/StorageServices/1/Volumes/Log")
+	if err != nil {
+		t.Errorf("Error making RemoveVolume call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[1].Payload != "{[{/redfish/v1/StorageServices/1/Volumes/Data} {/redfish/v1/StorageServices/1/Volumes/Temp}]}" {
+		t.Errorf("Unexpected RemoveVolume payload: %s", calls[1].Payload)
+	}
+
+	if result.VolumesCount != 2 {
+		t.Errorf("Invalid VolumesCount: %d", result.VolumesCount)
+	}
+}
+
+// TestConsistencyGroupReplication tests the replication actions.
+func TestConsistencyGroupReplication(t *testing.T) {
+	var result ConsistencyGroup
+	err := json.NewDecoder(strings.NewReader(consistencyGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateReplicaTarget(SnapshotReplicaType, AsynchronousReplicaUpdateMode,
+		"/redfish/v1/StorageServices/1/StoragePools/Snapshots", "Nightly")
+	if err != nil {
+		t.Errorf("Error making CreateReplicaTarget call: %s", err)
+	}
+
+	err = result.SuspendReplication("/redfish/v1/StorageServices/2/ConsistencyGroups/CG1")
+	if err != nil {
+		t.Errorf("Error making SuspendReplication call: %s", err)
+	}
+
+	if result.ResumeReplication("/redfish/v1/StorageServices/2/ConsistencyGroups/CG1") == nil {
+		t.Error("ResumeReplication should fail without an action target")
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[0].Payload != "{Nightly Snapshot Asynchronous /redfish/v1/StorageServices/1/StoragePools/Snapshots}" {
+		t.Errorf("Unexpected CreateReplicaTarget payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "{/redfish/v1/StorageServices/2/ConsistencyGroups/CG1}" {
+		t.Errorf("Unexpected SuspendReplication payload: %s", calls[1].Payload)
+	}
+}
+
+// TestConsistencyGroupCreatePayload tests the body used to create consistency
+// groups.
+func TestConsistencyGroupCreatePayload(t *testing.T) {
+	parameters := ConsistencyGroupCreateParameters{
+		Name:            "Database",
+		ConsistencyType: CrashConsistentGroupConsistencyType,
+		Volumes:         []string{"/redfish/v1/StorageServices/1/Volumes/Data"},
+	}
+
+	payload, err := json.Marshal(parameters.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	expected := `{"Name":"Database","ConsistencyType":"CrashConsistent",` +
+		`"Volumes":[{"@odata.id":"/redfish/v1/StorageServices/1/Volumes/Data"}]}`
+	if string(payload) != expected {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+}
@@ -40,7 +40,7 @@ type DataProtectionLineOfService struct {
 	// the maximum time over which source data may be lost on failure. In the
 	// case that IsIsolated = false, failure of the domain is not a
 	// consideration.
-	RecoveryPointObjectiveTime string
+	RecoveryPointObjectiveTime common.Duration
 	// RecoveryTimeObjective shall be an enumeration that
 	// indicates the maximum time required to access an alternate replica. In
 	// the case that IsIsolated = false, failure of the domain is not a
@@ -59,23 +59,49 @@ type DataProtectionLineOfService struct {
 	// Schedule if a replica is made periodically, the value shall define
 	// the schedule.
 	Schedule common.Schedule
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// GetDataProtectionLineOfService will get a DataProtectionLineOfService instance from the service.
-func GetDataProtectionLineOfService(c common.Client, uri string) (*DataProtectionLineOfService, error) {
-	resp, err := c.Get(uri)
+// GetRawData get raw data json
+func (dataProtectionLineOfService *DataProtectionLineOfService) GetRawData() []byte {
+	return dataProtectionLineOfService.rawData
+}
+
+// UnmarshalJSON unmarshals a DataProtectionLineOfService object from the raw JSON.
+func (dataProtectionLineOfService *DataProtectionLineOfService) UnmarshalJSON(b []byte) error {
+	type temp DataProtectionLineOfService
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
+	*dataProtectionLineOfService = DataProtectionLineOfService(t.temp)
+	dataProtectionLineOfService.rawData = b
+	dataProtectionLineOfService.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the data protection line of service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (dataProtectionLineOfService DataProtectionLineOfService) MarshalJSON() ([]byte, error) {
+	type temp DataProtectionLineOfService
+	return common.MarshalWithRawData(dataProtectionLineOfService.rawData, temp(dataProtectionLineOfService))
+}
+
+// GetDataProtectionLineOfService will get a DataProtectionLineOfService instance from the service.
+func GetDataProtectionLineOfService(c common.Client, uri string) (*DataProtectionLineOfService, error) {
 	var dataprotectionlineofservice DataProtectionLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionlineofservice)
+	err := common.FetchResource(c, uri, &dataprotectionlineofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	dataprotectionlineofservice.SetClient(c)
 	return &dataprotectionlineofservice, nil
 }
 
@@ -93,7 +119,11 @@ func ListReferencedDataProtectionLineOfServices(c common.Client, link string) ([
 	}
 
 	for _, dataprotectionlineofserviceLink := range links.ItemLinks {
-		dataprotectionlineofservice, err := GetDataProtectionLineOfService(c, dataprotectionlineofserviceLink)
+		dataprotectionlineofservice := new(DataProtectionLineOfService)
+		expanded, err := links.DecodeMember(c, dataprotectionlineofserviceLink, dataprotectionlineofservice)
+		if err == nil && !expanded {
+			dataprotectionlineofservice, err = GetDataProtectionLineOfService(c, dataprotectionlineofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
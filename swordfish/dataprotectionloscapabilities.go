@@ -116,6 +116,11 @@ type DataProtectionLoSCapabilities struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) GetRawData() []byte {
+	return dataprotectionloscapabilities.rawData
+}
+
 // UnmarshalJSON unmarshals a DataProtectionLoSCapabilities object from the raw JSON.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) UnmarshalJSON(b []byte) error {
 	type temp DataProtectionLoSCapabilities
@@ -147,10 +152,18 @@ func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) UnmarshalJSO
 
 	// This is a read/write object, so we need to save the raw object data for later
 	dataprotectionloscapabilities.rawData = b
+	dataprotectionloscapabilities.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the data protection LoS capabilities to JSON, keeping
+// the properties of the raw JSON that are not modeled.
+func (dataprotectionloscapabilities DataProtectionLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp DataProtectionLoSCapabilities
+	return common.MarshalWithRawData(dataprotectionloscapabilities.rawData, temp(dataprotectionloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update() error {
 
@@ -177,19 +190,12 @@ func (dataprotectionloscapabilities *DataProtectionLoSCapabilities) Update() err
 
 // GetDataProtectionLoSCapabilities will get a DataProtectionLoSCapabilities instance from the service.
 func GetDataProtectionLoSCapabilities(c common.Client, uri string) (*DataProtectionLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var dataprotectionloscapabilities DataProtectionLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&dataprotectionloscapabilities)
+	err := common.FetchResource(c, uri, &dataprotectionloscapabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	dataprotectionloscapabilities.SetClient(c)
 	return &dataprotectionloscapabilities, nil
 }
 
@@ -207,7 +213,11 @@ func ListReferencedDataProtectionLoSCapabilities(c common.Client, link string) (
 	}
 
 	for _, dataprotectionloscapabilitiesLink := range links.ItemLinks {
-		dataprotectionloscapabilities, err := GetDataProtectionLoSCapabilities(c, dataprotectionloscapabilitiesLink)
+		dataprotectionloscapabilities := new(DataProtectionLoSCapabilities)
+		expanded, err := links.DecodeMember(c, dataprotectionloscapabilitiesLink, dataprotectionloscapabilities)
+		if err == nil && !expanded {
+			dataprotectionloscapabilities, err = GetDataProtectionLoSCapabilities(c, dataprotectionloscapabilitiesLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -45,23 +45,49 @@ type DataSecurityLineOfService struct {
 	// UserAuthenticationType shall specify the
 	// authentication type for users (or programs).
 	UserAuthenticationType AuthenticationType
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// GetDataSecurityLineOfService will get a DataSecurityLineOfService instance from the service.
-func GetDataSecurityLineOfService(c common.Client, uri string) (*DataSecurityLineOfService, error) {
-	resp, err := c.Get(uri)
+// GetRawData get raw data json
+func (dataSecurityLineOfService *DataSecurityLineOfService) GetRawData() []byte {
+	return dataSecurityLineOfService.rawData
+}
+
+// UnmarshalJSON unmarshals a DataSecurityLineOfService object from the raw JSON.
+func (dataSecurityLineOfService *DataSecurityLineOfService) UnmarshalJSON(b []byte) error {
+	type temp DataSecurityLineOfService
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
+	*dataSecurityLineOfService = DataSecurityLineOfService(t.temp)
+	dataSecurityLineOfService.rawData = b
+	dataSecurityLineOfService.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the data security line of service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (dataSecurityLineOfService DataSecurityLineOfService) MarshalJSON() ([]byte, error) {
+	type temp DataSecurityLineOfService
+	return common.MarshalWithRawData(dataSecurityLineOfService.rawData, temp(dataSecurityLineOfService))
+}
+
+// GetDataSecurityLineOfService will get a DataSecurityLineOfService instance from the service.
+func GetDataSecurityLineOfService(c common.Client, uri string) (*DataSecurityLineOfService, error) {
 	var datasecuritylineofservice DataSecurityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datasecuritylineofservice)
+	err := common.FetchResource(c, uri, &datasecuritylineofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	datasecuritylineofservice.SetClient(c)
 	return &datasecuritylineofservice, nil
 }
 
@@ -79,7 +105,11 @@ func ListReferencedDataSecurityLineOfServices(c common.Client, link string) ([]*
 	}
 
 	for _, datasecuritylineofserviceLink := range links.ItemLinks {
-		datasecuritylineofservice, err := GetDataSecurityLineOfService(c, datasecuritylineofserviceLink)
+		datasecuritylineofservice := new(DataSecurityLineOfService)
+		expanded, err := links.DecodeMember(c, datasecuritylineofserviceLink, datasecuritylineofservice)
+		if err == nil && !expanded {
+			datasecuritylineofservice, err = GetDataSecurityLineOfService(c, datasecuritylineofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
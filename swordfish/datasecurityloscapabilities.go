@@ -153,27 +153,31 @@ type DataSecurityLoSCapabilities struct {
 	rawData []byte
 }
 
-// // UnmarshalJSON unmarshals a DataSecurityLoSCapabilities object from the raw JSON.
-// func (datasecurityloscapabilities *DataSecurityLoSCapabilities) UnmarshalJSON(b []byte) error {
-// 	type temp DataSecurityLoSCapabilities
-// 	var t struct {
-// 		temp
-// 	}
+// GetRawData get raw data json
+func (datasecurityloscapabilities *DataSecurityLoSCapabilities) GetRawData() []byte {
+	return datasecurityloscapabilities.rawData
+}
 
-// 	err := json.Unmarshal(b, &t)
-// 	if err != nil {
-// 		return err
-// 	}
+// UnmarshalJSON unmarshals a DataSecurityLoSCapabilities object from the raw JSON.
+func (datasecurityloscapabilities *DataSecurityLoSCapabilities) UnmarshalJSON(b []byte) error {
+	type temp DataSecurityLoSCapabilities
+	var t struct {
+		temp
+	}
 
-// 	*datasecurityloscapabilities = DataSecurityLoSCapabilities(t.temp)
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
 
-// 	// Extract the links to other entities for later
+	*datasecurityloscapabilities = DataSecurityLoSCapabilities(t.temp)
 
-// 	// This is a read/write object, so we need to save the raw object data for later
-// 	datasecurityloscapabilities.rawData = b
+	// This is a read/write object, so we need to save the raw object data for later
+	datasecurityloscapabilities.rawData = b
+	datasecurityloscapabilities.SetActions(common.ParseActions(b))
 
-// 	return nil
-// }
+	return nil
+}
 
 // // Update commits updates to this object's properties to the running system.
 // func (datasecurityloscapabilities *DataSecurityLoSCapabilities) Update() error {
@@ -203,22 +207,22 @@ type DataSecurityLoSCapabilities struct {
 
 // GetDataSecurityLoSCapabilities will get a DataSecurityLoSCapabilities instance from the service.
 func GetDataSecurityLoSCapabilities(c common.Client, uri string) (*DataSecurityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var datasecurityloscapabilities DataSecurityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datasecurityloscapabilities)
+	err := common.FetchResource(c, uri, &datasecurityloscapabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	datasecurityloscapabilities.SetClient(c)
 	return &datasecurityloscapabilities, nil
 }
 
+// MarshalJSON marshals the data security LoS capabilities to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (datasecurityloscapabilities DataSecurityLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp DataSecurityLoSCapabilities
+	return common.MarshalWithRawData(datasecurityloscapabilities.rawData, temp(datasecurityloscapabilities))
+}
+
 // ListReferencedDataSecurityLoSCapabilities gets the collection of DataSecurityLoSCapabilities from
 // a provided reference.
 func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]*DataSecurityLoSCapabilities, error) {
@@ -233,7 +237,11 @@ func ListReferencedDataSecurityLoSCapabilities(c common.Client, link string) ([]
 	}
 
 	for _, datasecurityloscapabilitiesLink := range links.ItemLinks {
-		datasecurityloscapabilities, err := GetDataSecurityLoSCapabilities(c, datasecurityloscapabilitiesLink)
+		datasecurityloscapabilities := new(DataSecurityLoSCapabilities)
+		expanded, err := links.DecodeMember(c, datasecurityloscapabilitiesLink, datasecurityloscapabilities)
+		if err == nil && !expanded {
+			datasecurityloscapabilities, err = GetDataSecurityLoSCapabilities(c, datasecurityloscapabilitiesLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -78,6 +78,10 @@ func TestDataSecurityLoSCapabilities(t *testing.T) {
 		t.Errorf("Received invalid name: %s", result.Name)
 	}
 
+	if len(result.GetRawData()) == 0 {
+		t.Error("The raw JSON should be kept")
+	}
+
 	if result.SupportedAntivirusScanPolicies[0] != OnFirstReadAntiVirusScanTrigger {
 		t.Errorf("Invalid SupportedAntivirusScanPolicy: %s",
 			result.SupportedAntivirusScanPolicies[0])
@@ -49,6 +49,13 @@ type DataStorageLineOfService struct {
 	// 'offline'. The expectation is that the services required to implement
 	// this capability are part of the advertising system.
 	RecoveryTimeObjectives RecoveryAccessScope
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (datastoragelineofservice *DataStorageLineOfService) GetRawData() []byte {
+	return datastoragelineofservice.rawData
 }
 
 // UnmarshalJSON unmarshals a DataStorageLineOfService object from the raw JSON.
@@ -66,25 +73,27 @@ func (datastoragelineofservice *DataStorageLineOfService) UnmarshalJSON(b []byte
 	*datastoragelineofservice = DataStorageLineOfService(t.temp)
 
 	// Extract the links to other entities for later
+	datastoragelineofservice.rawData = b
+	datastoragelineofservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the data storage line of service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (datastoragelineofservice DataStorageLineOfService) MarshalJSON() ([]byte, error) {
+	type temp DataStorageLineOfService
+	return common.MarshalWithRawData(datastoragelineofservice.rawData, temp(datastoragelineofservice))
+}
+
 // GetDataStorageLineOfService will get a DataStorageLineOfService instance from the service.
 func GetDataStorageLineOfService(c common.Client, uri string) (*DataStorageLineOfService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var datastoragelineofservice DataStorageLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&datastoragelineofservice)
+	err := common.FetchResource(c, uri, &datastoragelineofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	datastoragelineofservice.SetClient(c)
 	return &datastoragelineofservice, nil
 }
 
@@ -102,7 +111,11 @@ func ListReferencedDataStorageLineOfServices(c common.Client, link string) ([]*D
 	}
 
 	for _, datastoragelineofserviceLink := range links.ItemLinks {
-		datastoragelineofservice, err := GetDataStorageLineOfService(c, datastoragelineofserviceLink)
+		datastoragelineofservice := new(DataStorageLineOfService)
+		expanded, err := links.DecodeMember(c, datastoragelineofserviceLink, datastoragelineofservice)
+		if err == nil && !expanded {
+			datastoragelineofservice, err = GetDataStorageLineOfService(c, datastoragelineofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
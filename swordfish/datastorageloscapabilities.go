@@ -83,6 +83,11 @@ type DataStorageLoSCapabilities struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (datastorageloscapabilities *DataStorageLoSCapabilities) GetRawData() []byte {
+	return datastorageloscapabilities.rawData
+}
+
 // UnmarshalJSON unmarshals a DataStorageLoSCapabilities object from the raw JSON.
 func (datastorageloscapabilities *DataStorageLoSCapabilities) UnmarshalJSON(b []byte) error {
 	type temp DataStorageLoSCapabilities
@@ -101,10 +106,18 @@ func (datastorageloscapabilities *DataStorageLoSCapabilities) UnmarshalJSON(b []
 
 	// This is a read/write object, so we need to save the raw object data for later
 	datastorageloscapabilities.rawData = b
+	datastorageloscapabilities.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the data storage LoS capabilities to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (datastorageloscapabilities DataStorageLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp DataStorageLoSCapabilities
+	return common.MarshalWithRawData(datastorageloscapabilities.rawData, temp(datastorageloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (datastorageloscapabilities *DataStorageLoSCapabilities) Update() error {
 
@@ -130,19 +143,12 @@ func (datastorageloscapabilities *DataStorageLoSCapabilities) Update() error {
 
 // GetDataStorageLoSCapabilities will get a DataStorageLoSCapabilities instance from the service.
 func GetDataStorageLoSCapabilities(c common.Client, uri string) (*DataStorageLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var datastorageloscapabilities DataStorageLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&datastorageloscapabilities)
+	err := common.FetchResource(c, uri, &datastorageloscapabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	datastorageloscapabilities.SetClient(c)
 	return &datastorageloscapabilities, nil
 }
 
@@ -160,7 +166,11 @@ func ListReferencedDataStorageLoSCapabilities(c common.Client, link string) ([]*
 	}
 
 	for _, datastorageloscapabilitiesLink := range links.ItemLinks {
-		datastorageloscapabilities, err := GetDataStorageLoSCapabilities(c, datastorageloscapabilitiesLink)
+		datastorageloscapabilities := new(DataStorageLoSCapabilities)
+		expanded, err := links.DecodeMember(c, datastorageloscapabilitiesLink, datastorageloscapabilities)
+		if err == nil && !expanded {
+			datastorageloscapabilities, err = GetDataStorageLoSCapabilities(c, datastorageloscapabilitiesLink)
+		}
 		if err != nil {
 			return result, err
 		}
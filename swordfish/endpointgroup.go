@@ -80,6 +80,11 @@ type EndpointGroup struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (endpointgroup *EndpointGroup) GetRawData() []byte {
+	return endpointgroup.rawData
+}
+
 // UnmarshalJSON unmarshals a EndpointGroup object from the raw JSON.
 func (endpointgroup *EndpointGroup) UnmarshalJSON(b []byte) error {
 	type temp EndpointGroup
@@ -102,10 +107,18 @@ func (endpointgroup *EndpointGroup) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	endpointgroup.rawData = b
+	endpointgroup.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the endpoint group to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (endpointgroup EndpointGroup) MarshalJSON() ([]byte, error) {
+	type temp EndpointGroup
+	return common.MarshalWithRawData(endpointgroup.rawData, temp(endpointgroup))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (endpointgroup *EndpointGroup) Update() error {
 
@@ -130,19 +143,12 @@ func (endpointgroup *EndpointGroup) Update() error {
 
 // GetEndpointGroup will get a EndpointGroup instance from the service.
 func GetEndpointGroup(c common.Client, uri string) (*EndpointGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var endpointgroup EndpointGroup
-	err = json.NewDecoder(resp.Body).Decode(&endpointgroup)
+	err := common.FetchResource(c, uri, &endpointgroup)
 	if err != nil {
 		return nil, err
 	}
 
-	endpointgroup.SetClient(c)
 	return &endpointgroup, nil
 }
 
@@ -160,7 +166,11 @@ func ListReferencedEndpointGroups(c common.Client, link string) ([]*EndpointGrou
 	}
 
 	for _, endpointgroupLink := range links.ItemLinks {
-		endpointgroup, err := GetEndpointGroup(c, endpointgroupLink)
+		endpointgroup := new(EndpointGroup)
+		expanded, err := links.DecodeMember(c, endpointgroupLink, endpointgroup)
+		if err == nil && !expanded {
+			endpointgroup, err = GetEndpointGroup(c, endpointgroupLink)
+		}
 		if err != nil {
 			return result, err
 		}
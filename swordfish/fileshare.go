@@ -105,6 +105,11 @@ type FileShare struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (fileshare *FileShare) GetRawData() []byte {
+	return fileshare.rawData
+}
+
 // UnmarshalJSON unmarshals a FileShare object from the raw JSON.
 func (fileshare *FileShare) UnmarshalJSON(b []byte) error {
 	type temp FileShare
@@ -131,10 +136,18 @@ func (fileshare *FileShare) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	fileshare.rawData = b
+	fileshare.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the file share to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (fileshare FileShare) MarshalJSON() ([]byte, error) {
+	type temp FileShare
+	return common.MarshalWithRawData(fileshare.rawData, temp(fileshare))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (fileshare *FileShare) Update() error {
 
@@ -145,32 +158,94 @@ func (fileshare *FileShare) Update() error {
 
 	readWriteFields := []string{
 		"CASupported",
+		"ExecuteSupport",
 		"FileShareQuotaType",
 		"FileShareTotalQuotaBytes",
-		"LowSpaceWarningThresholdPercents",
+		"RootAccess",
+		"WritePolicy",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(fileshare).Elem()
 
-	return fileshare.Entity.Update(originalElement, currentElement, readWriteFields)
+	err := fileshare.Entity.Update(originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	return patchChangedFields(&fileshare.Entity, originalElement, currentElement,
+		"DefaultAccessCapabilities",
+		"FileSharingProtocols",
+		"LowSpaceWarningThresholdPercents",
+	)
 }
 
-// GetFileShare will get a FileShare instance from the service.
-func GetFileShare(c common.Client, uri string) (*FileShare, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// patchChangedFields sends the named fields, such as arrays, that the
+// generic Entity.Update does not handle if their values have changed.
+func patchChangedFields(entity *common.Entity, original reflect.Value, current reflect.Value, names ...string) error {
+	payload := make(map[string]interface{})
+	for _, name := range names {
+		currentValue := current.FieldByName(name).Interface()
+		if !reflect.DeepEqual(original.FieldByName(name).Interface(), currentValue) {
+			payload[name] = currentValue
+		}
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+
+	_, err := entity.Client.Patch(entity.ODataID, payload)
+	return err
+}
+
+// createPayload builds the body used to create this file share in a
+// collection.
+func (fileshare *FileShare) createPayload() interface{} {
+	type temp struct {
+		Name                             string                    `json:",omitempty"`
+		CASupported                      bool                      `json:",omitempty"`
+		DefaultAccessCapabilities        []StorageAccessCapability `json:",omitempty"`
+		ExecuteSupport                   bool                      `json:",omitempty"`
+		FileSharePath                    string
+		FileShareQuotaType               QuotaType `json:",omitempty"`
+		FileShareTotalQuotaBytes         int64     `json:",omitempty"`
+		FileSharingProtocols             []FileProtocol
+		LowSpaceWarningThresholdPercents []int             `json:",omitempty"`
+		RootAccess                       bool              `json:",omitempty"`
+		WritePolicy                      ReplicaUpdateMode `json:",omitempty"`
+	}
+	t := temp{
+		Name:                             fileshare.Name,
+		CASupported:                      fileshare.CASupported,
+		DefaultAccessCapabilities:        fileshare.DefaultAccessCapabilities,
+		ExecuteSupport:                   fileshare.ExecuteSupport,
+		FileSharePath:                    fileshare.FileSharePath,
+		FileShareQuotaType:               fileshare.FileShareQuotaType,
+		FileShareTotalQuotaBytes:         fileshare.FileShareTotalQuotaBytes,
+		FileSharingProtocols:             fileshare.FileSharingProtocols,
+		LowSpaceWarningThresholdPercents: fileshare.LowSpaceWarningThresholdPercents,
+		RootAccess:                       fileshare.RootAccess,
+		WritePolicy:                      fileshare.WritePolicy,
 	}
-	defer resp.Body.Close()
 
+	return t
+}
+
+// Delete removes the file share, ending its export. The data in the file
+// system is not removed.
+func (fileshare *FileShare) Delete() error {
+	return fileshare.Client.Delete(fileshare.ODataID)
+}
+
+// GetFileShare will get a FileShare instance from the service.
+func GetFileShare(c common.Client, uri string) (*FileShare, error) {
 	var fileshare FileShare
-	err = json.NewDecoder(resp.Body).Decode(&fileshare)
+	err := common.FetchResource(c, uri, &fileshare)
 	if err != nil {
 		return nil, err
 	}
 
-	fileshare.SetClient(c)
 	return &fileshare, nil
 }
 
@@ -188,7 +263,11 @@ func ListReferencedFileShares(c common.Client, link string) ([]*FileShare, error
 	}
 
 	for _, fileshareLink := range links.ItemLinks {
-		fileshare, err := GetFileShare(c, fileshareLink)
+		fileshare := new(FileShare)
+		expanded, err := links.DecodeMember(c, fileshareLink, fileshare)
+		if err == nil && !expanded {
+			fileshare, err = GetFileShare(c, fileshareLink)
+		}
 		if err != nil {
 			return result, err
 		}
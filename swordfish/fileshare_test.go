@@ -163,3 +163,81 @@ func TestFileShareUpdate(t *testing.T) {
 		t.Errorf("Unexpected FileShareTotalQuotaBytes update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestFileShareUpdateArrays tests the Update call of array properties.
+func TestFileShareUpdateArrays(t *testing.T) {
+	var result FileShare
+	err := json.NewDecoder(strings.NewReader(fileShareBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.RootAccess = true
+	result.FileSharingProtocols = []FileProtocol{NFSv41FileProtocol}
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 2 {
+		t.Fatalf("Unexpected number of Update calls: %d", len(calls))
+	}
+
+	if calls[0].Payload != "map[RootAccess:true]" {
+		t.Errorf("Unexpected RootAccess update payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].Payload != "map[FileSharingProtocols:[NFSv4_1]]" {
+		t.Errorf("Unexpected FileSharingProtocols update payload: %s", calls[1].Payload)
+	}
+}
+
+// TestFileShareCreatePayload tests the body used to create file shares.
+func TestFileShareCreatePayload(t *testing.T) {
+	fileshare := FileShare{
+		FileSharePath:        "/exports/home",
+		FileSharingProtocols: []FileProtocol{NFSv3FileProtocol},
+		RootAccess:           true,
+	}
+
+	payload, err := json.Marshal(fileshare.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	if string(payload) != `{"FileSharePath":"/exports/home","FileSharingProtocols":["NFSv3"],"RootAccess":true}` {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+}
+
+// TestFileShareDelete tests the Delete call.
+func TestFileShareDelete(t *testing.T) {
+	var result FileShare
+	err := json.NewDecoder(strings.NewReader(fileShareBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Delete()
+
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != "DELETE" || calls[0].URL != "/redfish/v1/FileShare" {
+		t.Errorf("Unexpected Delete call: %s %s", calls[0].Action, calls[0].URL)
+	}
+}
@@ -6,6 +6,7 @@ package swordfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -186,6 +187,11 @@ type FileSystem struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (filesystem *FileSystem) GetRawData() []byte {
+	return filesystem.rawData
+}
+
 // UnmarshalJSON unmarshals a FileSystem object from the raw JSON.
 func (filesystem *FileSystem) UnmarshalJSON(b []byte) error {
 	type temp FileSystem
@@ -226,10 +232,18 @@ func (filesystem *FileSystem) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	filesystem.rawData = b
+	filesystem.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the file system to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (filesystem FileSystem) MarshalJSON() ([]byte, error) {
+	type temp FileSystem
+	return common.MarshalWithRawData(filesystem.rawData, temp(filesystem))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (filesystem *FileSystem) Update() error {
 
@@ -254,24 +268,110 @@ func (filesystem *FileSystem) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(filesystem).Elem()
 
-	return filesystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	err := filesystem.Entity.Update(originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	return patchChangedFields(&filesystem.Entity, originalElement, currentElement,
+		"AccessCapabilities",
+		"CharacterCodeSet",
+		"LowSpaceWarningThresholdPercents",
+	)
 }
 
-// GetFileSystem will get a FileSystem instance from the service.
-func GetFileSystem(c common.Client, uri string) (*FileSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
+// Delete removes the file system, and any data it contains, from the
+// service.
+func (filesystem *FileSystem) Delete() error {
+	return filesystem.Client.Delete(filesystem.ODataID)
+}
+
+// CreateFileShare exports a new file share from this file system. The
+// FileSharePath and FileSharingProtocols of the share shall be set.
+func (filesystem *FileSystem) CreateFileShare(fileshare *FileShare) error {
+	if filesystem.exportedShares == "" {
+		return fmt.Errorf("this file system does not support exporting file shares")
 	}
-	defer resp.Body.Close()
 
+	_, err := filesystem.Client.Post(filesystem.exportedShares, fileshare.createPayload())
+	return err
+}
+
+// FileSystemCreateParameters are the properties used to create a new file
+// system.
+type FileSystemCreateParameters struct {
+	// Name is the name of the new file system.
+	Name string
+	// AllocatedBytes is the amount of capacity, in bytes, to allocate to the
+	// file system.
+	AllocatedBytes int64
+	// ProvidingPools are the URIs of the storage pools that provide the
+	// capacity of the file system.
+	ProvidingPools []string
+	// ClassOfService is the URI of the class of service of the file system.
+	ClassOfService string
+	// AccessCapabilities are the IO access capabilities of the file system.
+	AccessCapabilities []StorageAccessCapability
+	// CasePreserved requests that the case of file names is preserved.
+	CasePreserved bool
+	// CaseSensitive requests case sensitive file names.
+	CaseSensitive bool
+	// CharacterCodeSet are the character sets or encodings supported by the
+	// file system.
+	CharacterCodeSet []CharacterCodeSet
+}
+
+// createPayload builds the body used to create a file system in a
+// collection.
+func (parameters *FileSystemCreateParameters) createPayload() interface{} {
+	type links struct {
+		ClassOfService *odataID `json:",omitempty"`
+	}
+	type temp struct {
+		Name               string
+		AccessCapabilities []StorageAccessCapability `json:",omitempty"`
+		Capacity           *capacityPayload          `json:",omitempty"`
+		CapacitySources    []capacitySourcePayload   `json:",omitempty"`
+		CasePreserved      bool                      `json:",omitempty"`
+		CaseSensitive      bool                      `json:",omitempty"`
+		CharacterCodeSet   []CharacterCodeSet        `json:",omitempty"`
+		Links              *links                    `json:",omitempty"`
+	}
+	t := temp{
+		Name:               parameters.Name,
+		AccessCapabilities: parameters.AccessCapabilities,
+		Capacity:           newCapacityPayload(parameters.AllocatedBytes),
+		CapacitySources:    newCapacitySourcesPayload(nil, parameters.ProvidingPools),
+		CasePreserved:      parameters.CasePreserved,
+		CaseSensitive:      parameters.CaseSensitive,
+		CharacterCodeSet:   parameters.CharacterCodeSet,
+	}
+	if parameters.ClassOfService != "" {
+		t.Links = &links{ClassOfService: newODataID(parameters.ClassOfService)}
+	}
+
+	return t
+}
+
+// CreateFileSystem creates a new file system in the provided file system
+// collection.
+func CreateFileSystem(c common.Client, collection string, parameters *FileSystemCreateParameters) error {
+	if collection == "" {
+		return fmt.Errorf("a file system collection is required to create a file system")
+	}
+
+	_, err := c.Post(collection, parameters.createPayload())
+	return err
+}
+
+// GetFileSystem will get a FileSystem instance from the service.
+func GetFileSystem(c common.Client, uri string) (*FileSystem, error) {
 	var filesystem FileSystem
-	err = json.NewDecoder(resp.Body).Decode(&filesystem)
+	err := common.FetchResource(c, uri, &filesystem)
 	if err != nil {
 		return nil, err
 	}
 
-	filesystem.SetClient(c)
 	return &filesystem, nil
 }
 
@@ -289,7 +389,11 @@ func ListReferencedFileSystems(c common.Client, link string) ([]*FileSystem, err
 	}
 
 	for _, filesystemLink := range links.ItemLinks {
-		filesystem, err := GetFileSystem(c, filesystemLink)
+		filesystem := new(FileSystem)
+		expanded, err := links.DecodeMember(c, filesystemLink, filesystem)
+		if err == nil && !expanded {
+			filesystem, err = GetFileSystem(c, filesystemLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -214,3 +214,54 @@ func TestFileSystemUpdate(t *testing.T) {
 		t.Errorf("Unexpected MaxFileNameLengthBytes update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestFileSystemCreateFileShare tests the CreateFileShare call.
+func TestFileSystemCreateFileShare(t *testing.T) {
+	var result FileSystem
+	err := json.NewDecoder(strings.NewReader(fileSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateFileShare(&FileShare{
+		FileSharePath:        "/exports/home",
+		FileSharingProtocols: []FileProtocol{NFSv3FileProtocol},
+	})
+
+	if err != nil {
+		t.Errorf("Error making CreateFileShare call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != "POST" || calls[0].URL != result.exportedShares {
+		t.Errorf("Unexpected CreateFileShare call: %s %s", calls[0].Action, calls[0].URL)
+	}
+}
+
+// TestFileSystemCreatePayload tests the body used to create file systems.
+func TestFileSystemCreatePayload(t *testing.T) {
+	parameters := FileSystemCreateParameters{
+		Name:           "FS1",
+		AllocatedBytes: 1073741824,
+		ProvidingPools: []string{"/redfish/v1/StorageServices/1/StoragePools/1"},
+		ClassOfService: "/redfish/v1/StorageServices/1/ClassesOfService/Gold",
+		CaseSensitive:  true,
+	}
+
+	payload, err := json.Marshal(parameters.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	expected := `{"Name":"FS1","Capacity":{"Data":{"AllocatedBytes":1073741824}},` +
+		`"CapacitySources":[{"ProvidingPools":{"Members":[{"@odata.id":"/redfish/v1/StorageServices/1/StoragePools/1"}]}}],` +
+		`"CaseSensitive":true,"Links":{"ClassOfService":{"@odata.id":"/redfish/v1/StorageServices/1/ClassesOfService/Gold"}}}`
+	if string(payload) != expected {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+}
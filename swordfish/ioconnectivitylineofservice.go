@@ -35,23 +35,49 @@ type IOConnectivityLineOfService struct {
 	// MaxIOPS shall be the maximum IOs per second that the connection shall
 	// allow for the selected access protocol.
 	MaxIOPS int
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// GetIOConnectivityLineOfService will get a IOConnectivityLineOfService instance from the service.
-func GetIOConnectivityLineOfService(c common.Client, uri string) (*IOConnectivityLineOfService, error) {
-	resp, err := c.Get(uri)
+// GetRawData get raw data json
+func (iOConnectivityLineOfService *IOConnectivityLineOfService) GetRawData() []byte {
+	return iOConnectivityLineOfService.rawData
+}
+
+// UnmarshalJSON unmarshals a IOConnectivityLineOfService object from the raw JSON.
+func (iOConnectivityLineOfService *IOConnectivityLineOfService) UnmarshalJSON(b []byte) error {
+	type temp IOConnectivityLineOfService
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
+	*iOConnectivityLineOfService = IOConnectivityLineOfService(t.temp)
+	iOConnectivityLineOfService.rawData = b
+	iOConnectivityLineOfService.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the IO connectivity line of service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (iOConnectivityLineOfService IOConnectivityLineOfService) MarshalJSON() ([]byte, error) {
+	type temp IOConnectivityLineOfService
+	return common.MarshalWithRawData(iOConnectivityLineOfService.rawData, temp(iOConnectivityLineOfService))
+}
+
+// GetIOConnectivityLineOfService will get a IOConnectivityLineOfService instance from the service.
+func GetIOConnectivityLineOfService(c common.Client, uri string) (*IOConnectivityLineOfService, error) {
 	var ioconnectivitylineofservice IOConnectivityLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivitylineofservice)
+	err := common.FetchResource(c, uri, &ioconnectivitylineofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	ioconnectivitylineofservice.SetClient(c)
 	return &ioconnectivitylineofservice, nil
 }
 
@@ -69,7 +95,11 @@ func ListReferencedIOConnectivityLineOfServices(c common.Client, link string) ([
 	}
 
 	for _, ioconnectivitylineofserviceLink := range links.ItemLinks {
-		ioconnectivitylineofservice, err := GetIOConnectivityLineOfService(c, ioconnectivitylineofserviceLink)
+		ioconnectivitylineofservice := new(IOConnectivityLineOfService)
+		expanded, err := links.DecodeMember(c, ioconnectivitylineofserviceLink, ioconnectivitylineofservice)
+		if err == nil && !expanded {
+			ioconnectivitylineofservice, err = GetIOConnectivityLineOfService(c, ioconnectivitylineofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
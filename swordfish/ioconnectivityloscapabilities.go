@@ -44,6 +44,11 @@ type IOConnectivityLoSCapabilities struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) GetRawData() []byte {
+	return ioconnectivityloscapabilities.rawData
+}
+
 // UnmarshalJSON unmarshals a IOConnectivityLoSCapabilities object from the raw JSON.
 func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) UnmarshalJSON(b []byte) error {
 	type temp IOConnectivityLoSCapabilities
@@ -62,10 +67,18 @@ func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) UnmarshalJSO
 
 	// This is a read/write object, so we need to save the raw object data for later
 	ioconnectivityloscapabilities.rawData = b
+	ioconnectivityloscapabilities.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the IO connectivity LoS capabilities to JSON, keeping
+// the properties of the raw JSON that are not modeled.
+func (ioconnectivityloscapabilities IOConnectivityLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp IOConnectivityLoSCapabilities
+	return common.MarshalWithRawData(ioconnectivityloscapabilities.rawData, temp(ioconnectivityloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update() error {
 
@@ -90,19 +103,12 @@ func (ioconnectivityloscapabilities *IOConnectivityLoSCapabilities) Update() err
 // GetIOConnectivityLoSCapabilities will get a IOConnectivityLoSCapabilities
 // instance from the service.
 func GetIOConnectivityLoSCapabilities(c common.Client, uri string) (*IOConnectivityLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var ioconnectivityloscapabilities IOConnectivityLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioconnectivityloscapabilities)
+	err := common.FetchResource(c, uri, &ioconnectivityloscapabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	ioconnectivityloscapabilities.SetClient(c)
 	return &ioconnectivityloscapabilities, nil
 }
 
@@ -120,7 +126,11 @@ func ListReferencedIOConnectivityLoSCapabilitiess(c common.Client, link string)
 	}
 
 	for _, ioconnectivityloscapabilitiesLink := range links.ItemLinks {
-		ioconnectivityloscapabilities, err := GetIOConnectivityLoSCapabilities(c, ioconnectivityloscapabilitiesLink)
+		ioconnectivityloscapabilities := new(IOConnectivityLoSCapabilities)
+		expanded, err := links.DecodeMember(c, ioconnectivityloscapabilitiesLink, ioconnectivityloscapabilities)
+		if err == nil && !expanded {
+			ioconnectivityloscapabilities, err = GetIOConnectivityLoSCapabilities(c, ioconnectivityloscapabilitiesLink)
+		}
 		if err != nil {
 			return result, err
 		}
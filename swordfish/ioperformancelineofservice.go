@@ -45,24 +45,50 @@ type IOPerformanceLineOfService struct {
 	MaxIOOperationsPerSecondPerTerabyte int
 	// SamplePeriod shall be an ISO 8601 duration specifying the
 	// sampling period over which average values are calculated.
-	SamplePeriod string
+	SamplePeriod common.Duration
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// GetIOPerformanceLineOfService will get a IOPerformanceLineOfService instance from the service.
-func GetIOPerformanceLineOfService(c common.Client, uri string) (*IOPerformanceLineOfService, error) {
-	resp, err := c.Get(uri)
+// GetRawData get raw data json
+func (iOPerformanceLineOfService *IOPerformanceLineOfService) GetRawData() []byte {
+	return iOPerformanceLineOfService.rawData
+}
+
+// UnmarshalJSON unmarshals a IOPerformanceLineOfService object from the raw JSON.
+func (iOPerformanceLineOfService *IOPerformanceLineOfService) UnmarshalJSON(b []byte) error {
+	type temp IOPerformanceLineOfService
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
+	*iOPerformanceLineOfService = IOPerformanceLineOfService(t.temp)
+	iOPerformanceLineOfService.rawData = b
+	iOPerformanceLineOfService.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the IO performance line of service to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (iOPerformanceLineOfService IOPerformanceLineOfService) MarshalJSON() ([]byte, error) {
+	type temp IOPerformanceLineOfService
+	return common.MarshalWithRawData(iOPerformanceLineOfService.rawData, temp(iOPerformanceLineOfService))
+}
+
+// GetIOPerformanceLineOfService will get a IOPerformanceLineOfService instance from the service.
+func GetIOPerformanceLineOfService(c common.Client, uri string) (*IOPerformanceLineOfService, error) {
 	var ioperformancelineofservice IOPerformanceLineOfService
-	err = json.NewDecoder(resp.Body).Decode(&ioperformancelineofservice)
+	err := common.FetchResource(c, uri, &ioperformancelineofservice)
 	if err != nil {
 		return nil, err
 	}
 
-	ioperformancelineofservice.SetClient(c)
 	return &ioperformancelineofservice, nil
 }
 
@@ -80,7 +106,11 @@ func ListReferencedIOPerformanceLineOfServices(c common.Client, link string) ([]
 	}
 
 	for _, ioperformancelineofserviceLink := range links.ItemLinks {
-		ioperformancelineofservice, err := GetIOPerformanceLineOfService(c, ioperformancelineofserviceLink)
+		ioperformancelineofservice := new(IOPerformanceLineOfService)
+		expanded, err := links.DecodeMember(c, ioperformancelineofserviceLink, ioperformancelineofservice)
+		if err == nil && !expanded {
+			ioperformancelineofservice, err = GetIOPerformanceLineOfService(c, ioperformancelineofserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -54,10 +54,10 @@ type IOPerformanceLoSCapabilities struct {
 	Identifier common.Identifier
 	// MaxSamplePeriod shall be an ISO 8601 duration specifying the maximum
 	// sampling period over which average values are calculated.
-	MaxSamplePeriod string
+	MaxSamplePeriod common.Duration
 	// MinSamplePeriod shall be an ISO 8601 duration specifying the minimum
 	// sampling period over which average values are calculated.
-	MinSamplePeriod string
+	MinSamplePeriod common.Duration
 	// MinSupportedIoOperationLatencyMicroseconds shall be the minimum supported
 	// average IO latency in microseconds calculated over the SamplePeriod
 	MinSupportedIoOperationLatencyMicroseconds int
@@ -72,6 +72,11 @@ type IOPerformanceLoSCapabilities struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) GetRawData() []byte {
+	return ioperformanceloscapabilities.rawData
+}
+
 // UnmarshalJSON unmarshals a IOPerformanceLoSCapabilities object from the raw JSON.
 func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) UnmarshalJSON(b []byte) error {
 	type temp IOPerformanceLoSCapabilities
@@ -90,10 +95,18 @@ func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) UnmarshalJSON(
 
 	// This is a read/write object, so we need to save the raw object data for later
 	ioperformanceloscapabilities.rawData = b
+	ioperformanceloscapabilities.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the IO performance LoS capabilities to JSON, keeping the
+// properties of the raw JSON that are not modeled.
+func (ioperformanceloscapabilities IOPerformanceLoSCapabilities) MarshalJSON() ([]byte, error) {
+	type temp IOPerformanceLoSCapabilities
+	return common.MarshalWithRawData(ioperformanceloscapabilities.rawData, temp(ioperformanceloscapabilities))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update() error {
 
@@ -118,19 +131,12 @@ func (ioperformanceloscapabilities *IOPerformanceLoSCapabilities) Update() error
 
 // GetIOPerformanceLoSCapabilities will get a IOPerformanceLoSCapabilities instance from the service.
 func GetIOPerformanceLoSCapabilities(c common.Client, uri string) (*IOPerformanceLoSCapabilities, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var ioperformanceloscapabilities IOPerformanceLoSCapabilities
-	err = json.NewDecoder(resp.Body).Decode(&ioperformanceloscapabilities)
+	err := common.FetchResource(c, uri, &ioperformanceloscapabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	ioperformanceloscapabilities.SetClient(c)
 	return &ioperformanceloscapabilities, nil
 }
 
@@ -148,7 +154,11 @@ func ListReferencedIOPerformanceLoSCapabilitiess(c common.Client, link string) (
 	}
 
 	for _, ioperformanceloscapabilitiesLink := range links.ItemLinks {
-		ioperformanceloscapabilities, err := GetIOPerformanceLoSCapabilities(c, ioperformanceloscapabilitiesLink)
+		ioperformanceloscapabilities := new(IOPerformanceLoSCapabilities)
+		expanded, err := links.DecodeMember(c, ioperformanceloscapabilitiesLink, ioperformanceloscapabilities)
+		if err == nil && !expanded {
+			ioperformanceloscapabilities, err = GetIOPerformanceLoSCapabilities(c, ioperformanceloscapabilitiesLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -173,7 +183,7 @@ type IOWorkloadComponent struct {
 	// shall specify the expected length of time that this component is
 	// applied to the workload. This attribute shall be specified if a
 	// schedule is specified and otherwise shall not be specified.
-	Duration string
+	Duration common.Duration
 	// IOAccessPattern is The enumeration literal shall be the expected
 	// access pattern.
 	IOAccessPattern IOAccessPattern
@@ -4,12 +4,18 @@
 
 package swordfish
 
+import (
+	"encoding/json"
+
+	"github.com/LRichi/WBfish/common"
+)
+
 // IOStatistics is used to represent the IO statistics of the requested
 // object.
 type IOStatistics struct {
 	// NonIORequestTime shall be an ISO 8601 conformant duration describing the
 	// time that the resource is busy processing non IO requests.
-	NonIORequestTime string
+	NonIORequestTime common.Duration
 	// NonIORequests shall represent the total count from the time of last reset
 	// or wrap of non IO requests.
 	NonIORequests int64
@@ -21,7 +27,7 @@ type IOStatistics struct {
 	ReadIOKiBytes int64
 	// ReadIORequestTime shall be an ISO 8601 conformant duration describing the
 	// time that the resource is busy processing read requests.
-	ReadIORequestTime string
+	ReadIORequestTime common.Duration
 	// ReadIORequests shall represent the total count from the time of last
 	// reset or wrap of read IO requests satisfied from either media or memory
 	// (i.e. from a storage device or from a cache).
@@ -34,8 +40,70 @@ type IOStatistics struct {
 	WriteIOKiBytes int64
 	// WriteIORequestTime shall be an ISO 8601 conformant duration describing
 	// the time that the resource is busy processing write requests.
-	WriteIORequestTime string
+	WriteIORequestTime common.Duration
 	// WriteIORequests shall represent the total count from the time of last
 	// reset or wrap of write IO requests.
 	WriteIORequests int64
 }
+
+// TotalIORequests returns the number of read and write IO requests.
+func (iostatistics *IOStatistics) TotalIORequests() int64 {
+	return iostatistics.ReadIORequests + iostatistics.WriteIORequests
+}
+
+// ReadHitRatio returns the fraction of read IO requests that were satisfied
+// from memory, or 0 if there were no reads.
+func (iostatistics *IOStatistics) ReadHitRatio() float64 {
+	if iostatistics.ReadIORequests == 0 {
+		return 0
+	}
+	return float64(iostatistics.ReadHitIORequests) / float64(iostatistics.ReadIORequests)
+}
+
+// Delta returns the counters accumulated between the previous sample and this
+// one. Counters that went backwards because the statistics were reset or
+// wrapped are reported from zero. The request times are not counters and are
+// copied from this sample.
+func (iostatistics *IOStatistics) Delta(previous *IOStatistics) IOStatistics {
+	delta := func(current, previous int64) int64 {
+		if current < previous {
+			return current
+		}
+		return current - previous
+	}
+
+	return IOStatistics{
+		NonIORequestTime:   iostatistics.NonIORequestTime,
+		NonIORequests:      delta(iostatistics.NonIORequests, previous.NonIORequests),
+		ReadHitIORequests:  delta(iostatistics.ReadHitIORequests, previous.ReadHitIORequests),
+		ReadIOKiBytes:      delta(iostatistics.ReadIOKiBytes, previous.ReadIOKiBytes),
+		ReadIORequestTime:  iostatistics.ReadIORequestTime,
+		ReadIORequests:     delta(iostatistics.ReadIORequests, previous.ReadIORequests),
+		WriteHitIORequests: delta(iostatistics.WriteHitIORequests, previous.WriteHitIORequests),
+		WriteIOKiBytes:     delta(iostatistics.WriteIOKiBytes, previous.WriteIOKiBytes),
+		WriteIORequestTime: iostatistics.WriteIORequestTime,
+		WriteIORequests:    delta(iostatistics.WriteIORequests, previous.WriteIORequests),
+	}
+}
+
+// GetIOStatistics gets only the IO statistics of the resource at uri, such as
+// a volume, storage pool, file system or storage service. It is meant to be
+// called repeatedly when monitoring performance, without keeping the rest of
+// the resource.
+func GetIOStatistics(c common.Client, uri string) (*IOStatistics, error) {
+	resp, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var t struct {
+		IOStatistics IOStatistics
+	}
+	err = json.NewDecoder(resp.Body).Decode(&t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t.IOStatistics, nil
+}
@@ -0,0 +1,73 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package swordfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestVolumeIOStatistics tests the parsing of the IO statistics of a volume.
+func TestVolumeIOStatistics(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.IOStatistics.ReadIORequests != 5000 {
+		t.Errorf("Invalid ReadIORequests: %d", result.IOStatistics.ReadIORequests)
+	}
+
+	if result.IOStatistics.TotalIORequests() != 10000 {
+		t.Errorf("Invalid TotalIORequests: %d", result.IOStatistics.TotalIORequests())
+	}
+
+	if result.IOStatistics.ReadHitRatio() != 0.1 {
+		t.Errorf("Invalid ReadHitRatio: %f", result.IOStatistics.ReadHitRatio())
+	}
+}
+
+// TestIOStatisticsDelta tests the difference between two samples.
+func TestIOStatisticsDelta(t *testing.T) {
+	previous := IOStatistics{
+		ReadIORequests:  100,
+		ReadIOKiBytes:   4096,
+		WriteIORequests: 50,
+		NonIORequests:   900,
+	}
+	current := IOStatistics{
+		ReadIORequests:     150,
+		ReadIOKiBytes:      8192,
+		WriteIORequests:    75,
+		NonIORequests:      10,
+		WriteIORequestTime: "PT5S",
+	}
+
+	delta := current.Delta(&previous)
+
+	if delta.ReadIORequests != 50 {
+		t.Errorf("Invalid ReadIORequests delta: %d", delta.ReadIORequests)
+	}
+
+	if delta.ReadIOKiBytes != 4096 {
+		t.Errorf("Invalid ReadIOKiBytes delta: %d", delta.ReadIOKiBytes)
+	}
+
+	if delta.WriteIORequests != 25 {
+		t.Errorf("Invalid WriteIORequests delta: %d", delta.WriteIORequests)
+	}
+
+	// The counter was reset between the samples.
+	if delta.NonIORequests != 10 {
+		t.Errorf("Invalid NonIORequests delta: %d", delta.NonIORequests)
+	}
+
+	if delta.WriteIORequestTime != "PT5S" {
+		t.Errorf("Invalid WriteIORequestTime: %s", delta.WriteIORequestTime)
+	}
+}
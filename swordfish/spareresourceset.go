@@ -31,10 +31,10 @@ type SpareResourceSet struct {
 	ResourceType string
 	// TimeToProvision is the amount of time needed to make an on-hand resource
 	// available as a spare.
-	TimeToProvision string
+	TimeToProvision common.Duration
 	// TimeToReplenish is the amount of time to needed replenish consumed on-hand
 	// resources.
-	TimeToReplenish string
+	TimeToReplenish common.Duration
 	// OnHandSparesCount is the number of on hand spares.
 	OnHandSparesCount int `json:"OnHandSpares@odata.count"`
 	// ReplacementSpareSetsCount is the number of replacement spare sets.
@@ -48,6 +48,11 @@ type SpareResourceSet struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (spareresourceset *SpareResourceSet) GetRawData() []byte {
+	return spareresourceset.rawData
+}
+
 // UnmarshalJSON unmarshals a SpareResourceSet object from the raw JSON.
 func (spareresourceset *SpareResourceSet) UnmarshalJSON(b []byte) error {
 	type temp SpareResourceSet
@@ -81,10 +86,18 @@ func (spareresourceset *SpareResourceSet) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	spareresourceset.rawData = b
+	spareresourceset.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the spare resource set to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (spareresourceset SpareResourceSet) MarshalJSON() ([]byte, error) {
+	type temp SpareResourceSet
+	return common.MarshalWithRawData(spareresourceset.rawData, temp(spareresourceset))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (spareresourceset *SpareResourceSet) Update() error {
 
@@ -108,19 +121,12 @@ func (spareresourceset *SpareResourceSet) Update() error {
 
 // GetSpareResourceSet will get a SpareResourceSet instance from the service.
 func GetSpareResourceSet(c common.Client, uri string) (*SpareResourceSet, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var spareresourceset SpareResourceSet
-	err = json.NewDecoder(resp.Body).Decode(&spareresourceset)
+	err := common.FetchResource(c, uri, &spareresourceset)
 	if err != nil {
 		return nil, err
 	}
 
-	spareresourceset.SetClient(c)
 	return &spareresourceset, nil
 }
 
@@ -138,7 +144,11 @@ func ListReferencedSpareResourceSets(c common.Client, link string) ([]*SpareReso
 	}
 
 	for _, spareresourcesetLink := range links.ItemLinks {
-		spareresourceset, err := GetSpareResourceSet(c, spareresourcesetLink)
+		spareresourceset := new(SpareResourceSet)
+		expanded, err := links.DecodeMember(c, spareresourcesetLink, spareresourceset)
+		if err == nil && !expanded {
+			spareresourceset, err = GetSpareResourceSet(c, spareresourcesetLink)
+		}
 		if err != nil {
 			return result, err
 		}
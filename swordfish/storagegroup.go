@@ -6,6 +6,7 @@ package swordfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/common"
@@ -138,6 +139,11 @@ type StorageGroup struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (storagegroup *StorageGroup) GetRawData() []byte {
+	return storagegroup.rawData
+}
+
 // UnmarshalJSON unmarshals a StorageGroup object from the raw JSON.
 func (storagegroup *StorageGroup) UnmarshalJSON(b []byte) error {
 	type temp StorageGroup
@@ -159,6 +165,7 @@ func (storagegroup *StorageGroup) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		Links                links
+		ReplicaTargets       common.Links
 		ServerEndpointGroups common.Links
 		Actions              actions
 	}
@@ -175,15 +182,25 @@ func (storagegroup *StorageGroup) UnmarshalJSON(b []byte) error {
 	storagegroup.classOfService = string(t.Links.ClassOfService)
 	storagegroup.parentStorageGroups = t.Links.ParentStorageGroups.ToStrings()
 	storagegroup.ParentStorageGroupsCount = t.Links.ParentStorageGroupsCount
+	storagegroup.replicaTargets = t.ReplicaTargets.ToStrings()
+	storagegroup.serverEndpointGroups = t.ServerEndpointGroups.ToStrings()
 	storagegroup.exposeVolumesTarget = t.Actions.ExposeVolumes.Target
 	storagegroup.hideVolumesTarget = t.Actions.HideVolumes.Target
 
 	// This is a read/write object, so we need to save the raw object data for later
 	storagegroup.rawData = b
+	storagegroup.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the storage group to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (storagegroup StorageGroup) MarshalJSON() ([]byte, error) {
+	type temp StorageGroup
+	return common.MarshalWithRawData(storagegroup.rawData, temp(storagegroup))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (storagegroup *StorageGroup) Update() error {
 
@@ -203,24 +220,22 @@ func (storagegroup *StorageGroup) Update() error {
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(storagegroup).Elem()
 
-	return storagegroup.Entity.Update(originalElement, currentElement, readWriteFields)
+	err := storagegroup.Entity.Update(originalElement, currentElement, readWriteFields)
+	if err != nil {
+		return err
+	}
+
+	return patchChangedFields(&storagegroup.Entity, originalElement, currentElement, "MappedVolumes")
 }
 
 // GetStorageGroup will get a StorageGroup instance from the service.
 func GetStorageGroup(c common.Client, uri string) (*StorageGroup, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var storagegroup StorageGroup
-	err = json.NewDecoder(resp.Body).Decode(&storagegroup)
+	err := common.FetchResource(c, uri, &storagegroup)
 	if err != nil {
 		return nil, err
 	}
 
-	storagegroup.SetClient(c)
 	return &storagegroup, nil
 }
 
@@ -238,7 +253,11 @@ func ListReferencedStorageGroups(c common.Client, link string) ([]*StorageGroup,
 	}
 
 	for _, storagegroupLink := range links.ItemLinks {
-		storagegroup, err := GetStorageGroup(c, storagegroupLink)
+		storagegroup := new(StorageGroup)
+		expanded, err := links.DecodeMember(c, storagegroupLink, storagegroup)
+		if err == nil && !expanded {
+			storagegroup, err = GetStorageGroup(c, storagegroupLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -285,7 +304,67 @@ func (storagegroup *StorageGroup) ClassOfService() (*ClassOfService, error) {
 	return GetClassOfService(storagegroup.Client, storagegroup.classOfService)
 }
 
-//MappedVolume is an exposed volume mapping.
+// ServerEndpointGroups gets the server-side endpoint groups the storage of
+// this group is exposed through.
+func (storagegroup *StorageGroup) ServerEndpointGroups() ([]*EndpointGroup, error) {
+	var result []*EndpointGroup
+	for _, egLink := range storagegroup.serverEndpointGroups {
+		eg, err := GetEndpointGroup(storagegroup.Client, egLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, eg)
+	}
+
+	return result, nil
+}
+
+// Volumes gets the volumes mapped by this storage group.
+func (storagegroup *StorageGroup) Volumes() ([]*Volume, error) {
+	var result []*Volume
+	for _, mapped := range storagegroup.MappedVolumes {
+		if mapped.Volume == "" {
+			continue
+		}
+		volume, err := GetVolume(storagegroup.Client, string(mapped.Volume))
+		if err != nil {
+			return result, err
+		}
+		result = append(result, volume)
+	}
+
+	return result, nil
+}
+
+// AddMappedVolume maps the volume at volumeURI into this storage group as
+// the provided logical unit number. The change is sent to the service on the
+// next call to Update.
+func (storagegroup *StorageGroup) AddMappedVolume(volumeURI string, logicalUnitNumber int) {
+	storagegroup.RemoveMappedVolume(volumeURI)
+	storagegroup.MappedVolumes = append(storagegroup.MappedVolumes, MappedVolume{
+		LogicalUnitNumber: logicalUnitNumber,
+		Volume:            common.Link(volumeURI),
+	})
+}
+
+// RemoveMappedVolume removes the mapping of the volume at volumeURI from this
+// storage group. The change is sent to the service on the next call to Update.
+func (storagegroup *StorageGroup) RemoveMappedVolume(volumeURI string) {
+	mappedVolumes := []MappedVolume{}
+	for _, mapped := range storagegroup.MappedVolumes {
+		if string(mapped.Volume) != volumeURI {
+			mappedVolumes = append(mappedVolumes, mapped)
+		}
+	}
+	storagegroup.MappedVolumes = mappedVolumes
+}
+
+// Delete removes this storage group from the service.
+func (storagegroup *StorageGroup) Delete() error {
+	return storagegroup.Client.Delete(storagegroup.ODataID)
+}
+
+// MappedVolume is an exposed volume mapping.
 type MappedVolume struct {
 	// LogicalUnitNumber is the value is a SCSI Logical Unit Number for the Volume.
 	LogicalUnitNumber int
@@ -293,11 +372,29 @@ type MappedVolume struct {
 	Volume common.Link
 }
 
+// MarshalJSON marshals a MappedVolume with its volume as a reference object.
+func (mappedvolume MappedVolume) MarshalJSON() ([]byte, error) {
+	type temp struct {
+		LogicalUnitNumber int
+		Volume            *odataID `json:",omitempty"`
+	}
+	t := temp{
+		LogicalUnitNumber: mappedvolume.LogicalUnitNumber,
+		Volume:            newODataID(string(mappedvolume.Volume)),
+	}
+
+	return json.Marshal(t)
+}
+
 // ExposeVolumes exposes the storage of this group via the target endpoints
 // named in the ServerEndpointGroups to the initiator endpoints named in the
 // ClientEndpointGroups.  The property VolumesAreExposed shall be set to true
 // when this action is completed.
 func (storagegroup *StorageGroup) ExposeVolumes() error {
+	if storagegroup.exposeVolumesTarget == "" {
+		return fmt.Errorf("ExposeVolumes is not supported by this storage group")
+	}
+
 	_, err := storagegroup.Client.Post(storagegroup.exposeVolumesTarget, nil)
 	if err == nil {
 		// Only set to exposed if no error. Calling expose when already exposed
@@ -311,9 +408,72 @@ func (storagegroup *StorageGroup) ExposeVolumes() error {
 // named in the ClientEndpointGroups. The property VolumesAreExposed shall be
 // set to false when this action is completed.
 func (storagegroup *StorageGroup) HideVolumes() error {
+	if storagegroup.hideVolumesTarget == "" {
+		return fmt.Errorf("HideVolumes is not supported by this storage group")
+	}
+
 	_, err := storagegroup.Client.Post(storagegroup.hideVolumesTarget, nil)
 	if err == nil {
 		storagegroup.VolumesAreExposed = false
 	}
 	return err
 }
+
+// StorageGroupCreateParameters are the properties used to create a new
+// storage group.
+type StorageGroupCreateParameters struct {
+	// Name is the name of the new storage group.
+	Name string
+	// AccessState is the access state of the volumes through the group.
+	AccessState AccessState
+	// AuthenticationMethod is the authentication the endpoints of the group
+	// use.
+	AuthenticationMethod AuthenticationMethod
+	// ClientEndpointGroups are the URIs of the endpoint groups of the hosts
+	// allowed to access the volumes.
+	ClientEndpointGroups []string
+	// MappedVolumes are the volumes exposed by the group and their logical
+	// unit numbers.
+	MappedVolumes []MappedVolume
+	// ServerEndpointGroups are the URIs of the endpoint groups the volumes
+	// are exposed through.
+	ServerEndpointGroups []string
+}
+
+// createPayload builds the body used to create a storage group in a
+// collection.
+func (parameters *StorageGroupCreateParameters) createPayload() interface{} {
+	type temp struct {
+		Name                 string
+		AccessState          AccessState          `json:",omitempty"`
+		AuthenticationMethod AuthenticationMethod `json:",omitempty"`
+		ClientEndpointGroups []odataID            `json:",omitempty"`
+		MappedVolumes        []MappedVolume       `json:",omitempty"`
+		ServerEndpointGroups []odataID            `json:",omitempty"`
+	}
+	t := temp{
+		Name:                 parameters.Name,
+		AccessState:          parameters.AccessState,
+		AuthenticationMethod: parameters.AuthenticationMethod,
+		MappedVolumes:        parameters.MappedVolumes,
+	}
+	for _, uri := range parameters.ClientEndpointGroups {
+		t.ClientEndpointGroups = append(t.ClientEndpointGroups, odataID{ODataID: uri})
+	}
+	for _, uri := range parameters.ServerEndpointGroups {
+		t.ServerEndpointGroups = append(t.ServerEndpointGroups, odataID{ODataID: uri})
+	}
+
+	return t
+}
+
+// CreateStorageGroup creates a new storage group in the provided storage
+// group collection.
+func CreateStorageGroup(c common.Client, collection string, parameters *StorageGroupCreateParameters) error {
+	if collection == "" {
+		return fmt.Errorf("a storage group collection is required to create a storage group")
+	}
+
+	_, err := c.Post(collection, parameters.createPayload())
+	return err
+}
@@ -114,6 +114,10 @@ func TestStorageGroup(t *testing.T) {
 	if result.hideVolumesTarget != "/redfish/v1/StorageGroup/Actions/StorageGroup.HideVolumes" {
 		t.Errorf("Invalid HideVolumes target: %s", result.hideVolumesTarget)
 	}
+
+	if len(result.serverEndpointGroups) != 1 || result.serverEndpointGroups[0] != "/redfish/v1/Server/1/Endpoints" {
+		t.Errorf("Invalid server endpoint groups: %v", result.serverEndpointGroups)
+	}
 }
 
 // TestStorageGroupUpdate tests the Update call.
@@ -151,3 +155,111 @@ func TestStorageGroupUpdate(t *testing.T) {
 		t.Errorf("Unexpected VolumeAreExposed update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStorageGroupMappedVolumes tests updating the volumes mapped by a storage
+// group.
+func TestStorageGroupMappedVolumes(t *testing.T) {
+	var result StorageGroup
+	err := json.NewDecoder(strings.NewReader(storageGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.AddMappedVolume("/redfish/v1/Volume/2", 2)
+	result.RemoveMappedVolume("/redfish/v1/Volume/1")
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[0].Payload != "map[MappedVolumes:[{2 /redfish/v1/Volume/2}]]" {
+		t.Errorf("Unexpected MappedVolumes update payload: %s", calls[0].Payload)
+	}
+
+	payload, err := json.Marshal(result.MappedVolumes)
+	if err != nil {
+		t.Errorf("Error encoding mapped volumes: %s", err)
+	}
+
+	if string(payload) != `[{"LogicalUnitNumber":2,"Volume":{"@odata.id":"/redfish/v1/Volume/2"}}]` {
+		t.Errorf("Unexpected mapped volumes encoding: %s", payload)
+	}
+}
+
+// TestStorageGroupExposeVolumes tests the ExposeVolumes and HideVolumes calls.
+func TestStorageGroupExposeVolumes(t *testing.T) {
+	var result StorageGroup
+	err := json.NewDecoder(strings.NewReader(storageGroupBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.HideVolumes()
+	if err != nil {
+		t.Errorf("Error making HideVolumes call: %s", err)
+	}
+
+	if result.VolumesAreExposed {
+		t.Error("VolumesAreExposed should be false after HideVolumes")
+	}
+
+	err = result.ExposeVolumes()
+	if err != nil {
+		t.Errorf("Error making ExposeVolumes call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[1].URL != "/redfish/v1/StorageGroup/Actions/StorageGroup.ExposeVolumes" {
+		t.Errorf("Unexpected ExposeVolumes URL: %s", calls[1].URL)
+	}
+
+	result.exposeVolumesTarget = ""
+	if result.ExposeVolumes() == nil {
+		t.Error("ExposeVolumes should fail without an action target")
+	}
+}
+
+// TestStorageGroupCreatePayload tests the body used to create storage groups.
+func TestStorageGroupCreatePayload(t *testing.T) {
+	parameters := StorageGroupCreateParameters{
+		Name: "Hosts",
+		MappedVolumes: []MappedVolume{
+			{LogicalUnitNumber: 0, Volume: "/redfish/v1/Volumes/1"},
+		},
+		ClientEndpointGroups: []string{"/redfish/v1/EndpointGroups/Initiators"},
+		ServerEndpointGroups: []string{"/redfish/v1/EndpointGroups/Targets"},
+	}
+
+	payload, err := json.Marshal(parameters.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	expected := `{"Name":"Hosts",` +
+		`"ClientEndpointGroups":[{"@odata.id":"/redfish/v1/EndpointGroups/Initiators"}],` +
+		`"MappedVolumes":[{"LogicalUnitNumber":0,"Volume":{"@odata.id":"/redfish/v1/Volumes/1"}}],` +
+		`"ServerEndpointGroups":[{"@odata.id":"/redfish/v1/EndpointGroups/Targets"}]}`
+	if string(payload) != expected {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+
+	if CreateStorageGroup(&common.TestClient{}, "", &parameters) == nil {
+		t.Error("CreateStorageGroup should fail without a collection")
+	}
+}
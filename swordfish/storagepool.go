@@ -6,6 +6,7 @@ package swordfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/LRichi/WBfish/redfish"
@@ -48,9 +49,15 @@ type StoragePool struct {
 	// Compressed shall contain a boolean indicator if the StoragePool is
 	// currently utilizing compression or not.
 	Compressed bool
+	// CompressionEnabled shall indicate whether compression is enabled on
+	// the storage pool.
+	CompressionEnabled bool
 	// Deduplicted shall contain a boolean indicator if the StoragePool is
 	// currently utilizing deduplication or not.
 	Deduplicated bool
+	// DeduplicationEnabled shall indicate whether deduplication is enabled
+	// on the storage pool.
+	DeduplicationEnabled bool
 	// DefaultClassOfService is used.
 	defaultClassOfService string
 	// Description provides a description of this resource.
@@ -58,6 +65,9 @@ type StoragePool struct {
 	// Encrypted shall contain a boolean indicator if the
 	// StoragePool is currently utilizing encryption or not.
 	Encrypted bool
+	// EncryptionEnabled shall indicate whether encryption is enabled on the
+	// storage pool.
+	EncryptionEnabled bool
 	// IOStatistics is the value shall represent IO statistics for this
 	// StoragePool.
 	IOStatistics IOStatistics
@@ -97,10 +107,18 @@ type StoragePool struct {
 	spareResourceSets []string
 	// SpareResourceSetsCount is the number of spare resource sets.
 	SpareResourceSetsCount int
+	// SupportedProvisioningPolicies shall specify all supported storage
+	// allocation policies for the storage pool.
+	SupportedProvisioningPolicies []ProvisioningPolicy
 	// rawData holds the original serialized JSON so we can compare updates.
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (storagepool *StoragePool) GetRawData() []byte {
+	return storagepool.rawData
+}
+
 // UnmarshalJSON unmarshals a StoragePool object from the raw JSON.
 func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 	type temp StoragePool
@@ -115,7 +133,7 @@ func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 		Links                 links
 		AllocatedPools        common.Link
 		AllocatedVolumes      common.Link
-		CapacitySource        common.Links
+		CapacitySources       common.Links
 		ClassesOfService      common.Link
 		DefaultClassOfService common.Link
 	}
@@ -134,16 +152,24 @@ func (storagepool *StoragePool) UnmarshalJSON(b []byte) error {
 	storagepool.SpareResourceSetsCount = t.Links.SpareResourceSetsCount
 	storagepool.allocatedPools = string(t.AllocatedPools)
 	storagepool.allocatedVolumes = string(t.AllocatedVolumes)
-	storagepool.capacitySources = t.CapacitySource.ToStrings()
+	storagepool.capacitySources = t.CapacitySources.ToStrings()
 	storagepool.classesOfService = string(t.ClassesOfService)
 	storagepool.defaultClassOfService = string(t.DefaultClassOfService)
 
 	// This is a read/write object, so we need to save the raw object data for later
 	storagepool.rawData = b
+	storagepool.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the storage pool to JSON, keeping the properties of the
+// raw JSON that are not modeled.
+func (storagepool StoragePool) MarshalJSON() ([]byte, error) {
+	type temp StoragePool
+	return common.MarshalWithRawData(storagepool.rawData, temp(storagepool))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (storagepool *StoragePool) Update() error {
 
@@ -156,9 +182,12 @@ func (storagepool *StoragePool) Update() error {
 		"CapacitySources",
 		"ClassesOfService",
 		"Compressed",
+		"CompressionEnabled",
 		"Deduplicated",
+		"DeduplicationEnabled",
 		"DefaultClassOfService",
 		"Encrypted",
+		"EncryptionEnabled",
 		"LowSpaceWarningThresholdPercents",
 		"RecoverableCapacitySourceCount",
 		"SupportedProvisioningPolicies",
@@ -170,21 +199,20 @@ func (storagepool *StoragePool) Update() error {
 	return storagepool.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
+// Delete removes the storage pool from the service. The volumes allocated from
+// the pool shall be deleted first.
+func (storagepool *StoragePool) Delete() error {
+	return storagepool.Client.Delete(storagepool.ODataID)
+}
+
 // GetStoragePool will get a StoragePool instance from the service.
 func GetStoragePool(c common.Client, uri string) (*StoragePool, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var storagepool StoragePool
-	err = json.NewDecoder(resp.Body).Decode(&storagepool)
+	err := common.FetchResource(c, uri, &storagepool)
 	if err != nil {
 		return nil, err
 	}
 
-	storagepool.SetClient(c)
 	return &storagepool, nil
 }
 
@@ -202,7 +230,11 @@ func ListReferencedStoragePools(c common.Client, link string) ([]*StoragePool, e
 	}
 
 	for _, storagepoolLink := range links.ItemLinks {
-		storagepool, err := GetStoragePool(c, storagepoolLink)
+		storagepool := new(StoragePool)
+		expanded, err := links.DecodeMember(c, storagepoolLink, storagepool)
+		if err == nil && !expanded {
+			storagepool, err = GetStoragePool(c, storagepoolLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -277,3 +309,65 @@ func (storagepool *StoragePool) DefaultClassOfService() (*ClassOfService, error)
 	}
 	return GetClassOfService(storagepool.Client, storagepool.defaultClassOfService)
 }
+
+// StoragePoolCreateParameters are the properties used to create a new
+// storage pool.
+type StoragePoolCreateParameters struct {
+	// Name is the name of the new storage pool.
+	Name string
+	// AllocatedBytes is the amount of capacity, in bytes, to allocate to the
+	// storage pool. If zero, the service chooses the capacity from the
+	// providing resources.
+	AllocatedBytes int64
+	// ProvidingDrives are the URIs of the drives that provide the capacity
+	// of the storage pool.
+	ProvidingDrives []string
+	// ProvidingPools are the URIs of the storage pools that provide the
+	// capacity of the storage pool.
+	ProvidingPools []string
+	// ClassOfService is the URI of the default class of service of the
+	// storage pool.
+	ClassOfService string
+	// Compressed requests compression on the storage pool.
+	Compressed bool
+	// Deduplicated requests deduplication on the storage pool.
+	Deduplicated bool
+	// Encrypted requests encryption on the storage pool.
+	Encrypted bool
+}
+
+// createPayload builds the body used to create a storage pool in a
+// collection.
+func (parameters *StoragePoolCreateParameters) createPayload() interface{} {
+	type temp struct {
+		Name                  string
+		Capacity              *capacityPayload        `json:",omitempty"`
+		CapacitySources       []capacitySourcePayload `json:",omitempty"`
+		DefaultClassOfService *odataID                `json:",omitempty"`
+		Compressed            bool                    `json:",omitempty"`
+		Deduplicated          bool                    `json:",omitempty"`
+		Encrypted             bool                    `json:",omitempty"`
+	}
+	t := temp{
+		Name:                  parameters.Name,
+		Capacity:              newCapacityPayload(parameters.AllocatedBytes),
+		CapacitySources:       newCapacitySourcesPayload(parameters.ProvidingDrives, parameters.ProvidingPools),
+		DefaultClassOfService: newODataID(parameters.ClassOfService),
+		Compressed:            parameters.Compressed,
+		Deduplicated:          parameters.Deduplicated,
+		Encrypted:             parameters.Encrypted,
+	}
+
+	return t
+}
+
+// CreateStoragePool creates a new storage pool in the provided storage pool
+// collection.
+func CreateStoragePool(c common.Client, collection string, parameters *StoragePoolCreateParameters) error {
+	if collection == "" {
+		return fmt.Errorf("a storage pool collection is required to create a storage pool")
+	}
+
+	_, err := c.Post(collection, parameters.createPayload())
+	return err
+}
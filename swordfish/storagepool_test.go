@@ -49,6 +49,10 @@ var storagePoolBody = `{
 		"ClassesOfService": {
 			"@odata.id": "/redfish/v1/ClassesOfService"
 		},
+		"CapacitySources": [{
+			"@odata.id": "/redfish/v1/StoragePool/CapacitySources/1"
+		}],
+		"CapacitySources@odata.count": 1,
 		"Compressed": true,
 		"Deduplicated": true,
 		"DefaultClassOfService": {
@@ -116,6 +120,10 @@ func TestStoragePool(t *testing.T) {
 	if result.MaxBlockSizeBytes != 2199023255600 {
 		t.Errorf("Invalid max block size: %d", result.MaxBlockSizeBytes)
 	}
+
+	if len(result.capacitySources) != 1 || result.CapacitySourcesCount != 1 {
+		t.Errorf("Invalid capacity sources: %v", result.capacitySources)
+	}
 }
 
 // TestStoragePoolUpdate tests the Update call.
@@ -158,3 +166,64 @@ func TestStoragePoolUpdate(t *testing.T) {
 		t.Errorf("Unexpected RecoverableCapacitySourceCount update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestStoragePoolDelete tests the Delete call.
+func TestStoragePoolDelete(t *testing.T) {
+	var result StoragePool
+	err := json.NewDecoder(strings.NewReader(storagePoolBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Delete()
+
+	if err != nil {
+		t.Errorf("Error making Delete call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != "DELETE" || calls[0].URL != "/redfish/v1/StoragePool" {
+		t.Errorf("Unexpected Delete call: %s %s", calls[0].Action, calls[0].URL)
+	}
+}
+
+// TestStoragePoolCreatePayload tests the body used to create storage pools.
+func TestStoragePoolCreatePayload(t *testing.T) {
+	parameters := StoragePoolCreateParameters{
+		Name:            "Pool1",
+		AllocatedBytes:  1099511627776,
+		ProvidingDrives: []string{"/redfish/v1/Drives/1", "/redfish/v1/Drives/2"},
+		ClassOfService:  "/redfish/v1/ClassesOfService/Gold",
+		Compressed:      true,
+	}
+
+	payload, err := json.Marshal(parameters.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	expected := `{"Name":"Pool1","Capacity":{"Data":{"AllocatedBytes":1099511627776}},` +
+		`"CapacitySources":[{"ProvidingDrives":{"Members":[{"@odata.id":"/redfish/v1/Drives/1"},{"@odata.id":"/redfish/v1/Drives/2"}]}}],` +
+		`"DefaultClassOfService":{"@odata.id":"/redfish/v1/ClassesOfService/Gold"},"Compressed":true}`
+	if string(payload) != expected {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+
+	testClient := &common.TestClient{}
+	err = CreateStoragePool(testClient, "/redfish/v1/StorageServices/1/StoragePools", &parameters)
+
+	if err != nil {
+		t.Errorf("Error making CreateStoragePool call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != "POST" || calls[0].URL != "/redfish/v1/StorageServices/1/StoragePools" {
+		t.Errorf("Unexpected CreateStoragePool call: %s %s", calls[0].Action, calls[0].URL)
+	}
+}
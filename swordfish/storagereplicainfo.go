@@ -351,6 +351,9 @@ type ReplicaInfo struct {
 	// ReplicaRecoveryMode shall specify whether
 	// the copy operation continues after a broken link is restored.
 	ReplicaRecoveryMode ReplicaRecoveryMode
+	// ReplicaRole shall specify whether the resource is a source of
+	// replication or the target of replication.
+	ReplicaRole ReplicaRole
 	// ReplicaSkewBytes is Applies to Adaptive mode and it describes maximum
 	// number of bytes the SyncedElement (target) can be out of sync. If the
 	// number of out-of-sync bytes exceeds the skew value, ReplicaUpdateMode
@@ -431,6 +434,11 @@ func (replicainfo *ReplicaInfo) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Replica gets the URI of the resource that is the source of this replica.
+func (replicainfo *ReplicaInfo) Replica() string {
+	return replicainfo.replica
+}
+
 // StorageReplicaInfo is
 type StorageReplicaInfo struct {
 	common.Entity
@@ -443,23 +451,49 @@ type StorageReplicaInfo struct {
 	ODataType string `json:"@odata.type"`
 	// Description provides a description of this resource.
 	Description string
+	// rawData holds the original serialized JSON
+	rawData []byte
 }
 
-// GetStorageReplicaInfo will get a StorageReplicaInfo instance from the service.
-func GetStorageReplicaInfo(c common.Client, uri string) (*StorageReplicaInfo, error) {
-	resp, err := c.Get(uri)
+// GetRawData get raw data json
+func (storageReplicaInfo *StorageReplicaInfo) GetRawData() []byte {
+	return storageReplicaInfo.rawData
+}
+
+// UnmarshalJSON unmarshals a StorageReplicaInfo object from the raw JSON.
+func (storageReplicaInfo *StorageReplicaInfo) UnmarshalJSON(b []byte) error {
+	type temp StorageReplicaInfo
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
+	*storageReplicaInfo = StorageReplicaInfo(t.temp)
+	storageReplicaInfo.rawData = b
+	storageReplicaInfo.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the storage replica info to JSON, keeping the properties
+// of the raw JSON that are not modeled.
+func (storageReplicaInfo StorageReplicaInfo) MarshalJSON() ([]byte, error) {
+	type temp StorageReplicaInfo
+	return common.MarshalWithRawData(storageReplicaInfo.rawData, temp(storageReplicaInfo))
+}
+
+// GetStorageReplicaInfo will get a StorageReplicaInfo instance from the service.
+func GetStorageReplicaInfo(c common.Client, uri string) (*StorageReplicaInfo, error) {
 	var storagereplicainfo StorageReplicaInfo
-	err = json.NewDecoder(resp.Body).Decode(&storagereplicainfo)
+	err := common.FetchResource(c, uri, &storagereplicainfo)
 	if err != nil {
 		return nil, err
 	}
 
-	storagereplicainfo.SetClient(c)
 	return &storagereplicainfo, nil
 }
 
@@ -477,7 +511,11 @@ func ListReferencedStorageReplicaInfos(c common.Client, link string) ([]*Storage
 	}
 
 	for _, storagereplicainfoLink := range links.ItemLinks {
-		storagereplicainfo, err := GetStorageReplicaInfo(c, storagereplicainfoLink)
+		storagereplicainfo := new(StorageReplicaInfo)
+		expanded, err := links.DecodeMember(c, storagereplicainfoLink, storagereplicainfo)
+		if err == nil && !expanded {
+			storagereplicainfo, err = GetStorageReplicaInfo(c, storagereplicainfoLink)
+		}
 		if err != nil {
 			return result, err
 		}
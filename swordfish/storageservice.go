@@ -6,6 +6,7 @@ package swordfish
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/LRichi/WBfish/common"
 	"github.com/LRichi/WBfish/redfish"
@@ -56,6 +57,8 @@ type StorageService struct {
 	// drives is a collection that indicates all the drives managed by this
 	// storage service.
 	drives string
+	// consistencyGroups shall reference a collection of ConsistencyGroups.
+	consistencyGroups string
 	// endpointGroups shall reference a collection of EndpointGroups.
 	endpointGroups string
 	// endpoints shall reference a collection of Endpoints managed by this service.
@@ -93,6 +96,13 @@ type StorageService struct {
 	volumes string
 	// setEncryptionKeyTarget is the URL to send SetEncryptionKey requests.
 	setEncryptionKeyTarget string
+	// rawData holds the original serialized JSON
+	rawData []byte
+}
+
+// GetRawData get raw data json
+func (storageservice *StorageService) GetRawData() []byte {
+	return storageservice.rawData
 }
 
 // UnmarshalJSON unmarshals a StorageService object from the raw JSON.
@@ -111,6 +121,7 @@ func (storageservice *StorageService) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		ClassesOfService              common.Link
+		ConsistencyGroups             common.Link
 		DataProtectionLoSCapabilities common.Link
 		DataSecurityLoSCapabilities   common.Link
 		DataStorageLoSCapabilities    common.Link
@@ -139,6 +150,7 @@ func (storageservice *StorageService) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*storageservice = StorageService(t.temp)
 	storageservice.classesOfService = string(t.ClassesOfService)
+	storageservice.consistencyGroups = string(t.ConsistencyGroups)
 	storageservice.dataProtectionLoSCapabilities = string(t.DataProtectionLoSCapabilities)
 	storageservice.dataSecurityLoSCapabilities = string(t.DataSecurityLoSCapabilities)
 	storageservice.dataStorageLoSCapabilities = string(t.DataStorageLoSCapabilities)
@@ -157,25 +169,27 @@ func (storageservice *StorageService) UnmarshalJSON(b []byte) error {
 	storageservice.hostingSystem = string(t.Links.HostingSystem)
 	storageservice.volumes = string(t.Volumes)
 	storageservice.setEncryptionKeyTarget = t.Actions.SetEncryptionKey.Target
+	storageservice.rawData = b
+	storageservice.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the storage service to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func (storageservice StorageService) MarshalJSON() ([]byte, error) {
+	type temp StorageService
+	return common.MarshalWithRawData(storageservice.rawData, temp(storageservice))
+}
+
 // GetStorageService will get a StorageService instance from the service.
 func GetStorageService(c common.Client, uri string) (*StorageService, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var storageservice StorageService
-	err = json.NewDecoder(resp.Body).Decode(&storageservice)
+	err := common.FetchResource(c, uri, &storageservice)
 	if err != nil {
 		return nil, err
 	}
 
-	storageservice.SetClient(c)
 	return &storageservice, nil
 }
 
@@ -183,13 +197,21 @@ func GetStorageService(c common.Client, uri string) (*StorageService, error) {
 // a provided reference.
 func ListReferencedStorageServices(c common.Client, link string) ([]*StorageService, error) {
 	var result []*StorageService
+	if link == "" {
+		return result, nil
+	}
+
 	links, err := common.GetCollection(c, link)
 	if err != nil {
 		return result, err
 	}
 
 	for _, storageserviceLink := range links.ItemLinks {
-		storageservice, err := GetStorageService(c, storageserviceLink)
+		storageservice := new(StorageService)
+		expanded, err := links.DecodeMember(c, storageserviceLink, storageservice)
+		if err == nil && !expanded {
+			storageservice, err = GetStorageService(c, storageserviceLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -204,6 +226,22 @@ func (storageservice *StorageService) ClassesOfService() ([]*ClassOfService, err
 	return ListReferencedClassOfServices(storageservice.Client, storageservice.classesOfService)
 }
 
+// ConsistencyGroups gets the consistency groups managed by this storage
+// service.
+func (storageservice *StorageService) ConsistencyGroups() ([]*ConsistencyGroup, error) {
+	return ListReferencedConsistencyGroups(storageservice.Client, storageservice.consistencyGroups)
+}
+
+// CreateConsistencyGroup creates a new consistency group in this storage
+// service.
+func (storageservice *StorageService) CreateConsistencyGroup(parameters *ConsistencyGroupCreateParameters) error {
+	if storageservice.consistencyGroups == "" {
+		return fmt.Errorf("this storage service does not support consistency groups")
+	}
+
+	return CreateConsistencyGroup(storageservice.Client, storageservice.consistencyGroups, parameters)
+}
+
 // DataProtectionLoSCapabilities gets the storage service's data protection
 // capabilities.
 func (storageservice *StorageService) DataProtectionLoSCapabilities() (*DataProtectionLoSCapabilities, error) {
@@ -261,6 +299,15 @@ func (storageservice *StorageService) FileSystems() ([]*FileSystem, error) {
 	return ListReferencedFileSystems(storageservice.Client, storageservice.fileSystems)
 }
 
+// CreateFileSystem creates a new file system in this storage service.
+func (storageservice *StorageService) CreateFileSystem(parameters *FileSystemCreateParameters) error {
+	if storageservice.fileSystems == "" {
+		return fmt.Errorf("this storage service does not support file systems")
+	}
+
+	return CreateFileSystem(storageservice.Client, storageservice.fileSystems, parameters)
+}
+
 // IOConnectivityLoSCapabilities references the IO connectivity capabilities of this service.
 func (storageservice *StorageService) IOConnectivityLoSCapabilities() (*IOConnectivityLoSCapabilities, error) {
 	if storageservice.ioConnectivityLoSCapabilities == "" {
@@ -271,7 +318,7 @@ func (storageservice *StorageService) IOConnectivityLoSCapabilities() (*IOConnec
 
 // IOPerformanceLoSCapabilities references the IO performance capabilities of this service.
 func (storageservice *StorageService) IOPerformanceLoSCapabilities() (*IOPerformanceLoSCapabilities, error) {
-	if storageservice.ioConnectivityLoSCapabilities == "" {
+	if storageservice.ioPerformanceLoSCapabilities == "" {
 		return nil, nil
 	}
 	return GetIOPerformanceLoSCapabilities(storageservice.Client, storageservice.ioPerformanceLoSCapabilities)
@@ -308,16 +355,42 @@ func (storageservice *StorageService) SpareResourceSets() ([]*SpareResourceSet,
 
 // StorageGroups gets the storage groups that are a part of this storage service.
 func (storageservice *StorageService) StorageGroups() ([]*StorageGroup, error) {
-	var result []*StorageGroup
-	for _, sgLink := range storageservice.spareResourceSets {
-		sg, err := GetStorageGroup(storageservice.Client, sgLink)
-		if err != nil {
-			return result, err
-		}
-		result = append(result, sg)
+	return ListReferencedStorageGroups(storageservice.Client, storageservice.storageGroups)
+}
+
+// CreateStorageGroup creates a new storage group in this storage service.
+func (storageservice *StorageService) CreateStorageGroup(parameters *StorageGroupCreateParameters) error {
+	if storageservice.storageGroups == "" {
+		return fmt.Errorf("this storage service does not support storage groups")
 	}
 
-	return result, nil
+	return CreateStorageGroup(storageservice.Client, storageservice.storageGroups, parameters)
+}
+
+// StoragePools gets the storage pools that are a part of this storage service.
+func (storageservice *StorageService) StoragePools() ([]*StoragePool, error) {
+	return ListReferencedStoragePools(storageservice.Client, storageservice.storagePools)
+}
+
+// CreateStoragePool creates a new storage pool in this storage service.
+func (storageservice *StorageService) CreateStoragePool(parameters *StoragePoolCreateParameters) error {
+	if storageservice.storagePools == "" {
+		return fmt.Errorf("this storage service does not support storage pools")
+	}
+
+	return CreateStoragePool(storageservice.Client, storageservice.storagePools, parameters)
+}
+
+// StorageSubsystems gets the storage subsystems managed by this storage
+// service.
+func (storageservice *StorageService) StorageSubsystems() ([]*redfish.Storage, error) {
+	return redfish.ListReferencedStorages(storageservice.Client, storageservice.storageSubsystems)
+}
+
+// HostingSystem gets the URI of the ComputerSystem or StorageController that
+// hosts this service.
+func (storageservice *StorageService) HostingSystem() string {
+	return storageservice.hostingSystem
 }
 
 // Volumes gets the volumes that are a part of this storage service.
@@ -325,8 +398,21 @@ func (storageservice *StorageService) Volumes() ([]*Volume, error) {
 	return ListReferencedVolumes(storageservice.Client, storageservice.volumes)
 }
 
+// CreateVolume creates a new volume in this storage service.
+func (storageservice *StorageService) CreateVolume(parameters *VolumeCreateParameters) error {
+	if storageservice.volumes == "" {
+		return fmt.Errorf("this storage service does not support volumes")
+	}
+
+	return CreateVolume(storageservice.Client, storageservice.volumes, parameters)
+}
+
 // SetEncryptionKey shall set the encryption key for the storage subsystem.
 func (storageservice *StorageService) SetEncryptionKey(key string) error {
+	if storageservice.setEncryptionKeyTarget == "" {
+		return fmt.Errorf("SetEncryptionKey is not supported by this storage service")
+	}
+
 	type temp struct {
 		EncryptionKey string
 	}
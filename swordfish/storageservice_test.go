@@ -21,6 +21,9 @@ var storageServiceBody = strings.NewReader(
 		"ClassesOfService": {
 			"@odata.id": "/redfish/v1/ClassesOfService"
 		},
+		"ConsistencyGroups": {
+			"@odata.id": "/redfish/v1/ConsistencyGroups"
+		},
 		"DataProtectionLoSCapabilities": {
 			"@odata.id": "/redfish/v1/DataProtectionLoSCapabilities/1"
 		},
@@ -73,12 +76,12 @@ var storageServiceBody = strings.NewReader(
 			"State": "Enabled",
 			"Health": "OK"
 		},
-		"StorageGroups": [{
-			"@odata.id": "/redfish/v1/StorageGroups/1"
-		}],
-		"StoragePools": [{
-			"@odata.id": "/redfish/v1/StoragePools/1"
-		}],
+		"StorageGroups": {
+			"@odata.id": "/redfish/v1/StorageGroups"
+		},
+		"StoragePools": {
+			"@odata.id": "/redfish/v1/StoragePools"
+		},
 		"StorageSubsystems": {
 			"@odata.id": "/redfish/v1/StorageSubsystems/1"
 		},
@@ -105,6 +108,10 @@ func TestStorageService(t *testing.T) {
 		t.Errorf("Received invalid ID: %s", result.ID)
 	}
 
+	if !strings.Contains(string(result.GetRawData()), `"Id": "StorageService-1"`) {
+		t.Errorf("The raw JSON should be kept: %s", result.GetRawData())
+	}
+
 	if result.Name != "StorageServiceOne" {
 		t.Errorf("Received invalid name: %s", result.Name)
 	}
@@ -113,6 +120,10 @@ func TestStorageService(t *testing.T) {
 		t.Errorf("Invalid ClassesOfService link: %s", result.classesOfService)
 	}
 
+	if result.consistencyGroups != "/redfish/v1/ConsistencyGroups" {
+		t.Errorf("Invalid ConsistencyGroups link: %s", result.consistencyGroups)
+	}
+
 	if result.endpointGroups != "/redfish/v1/EndpointGroups" {
 		t.Errorf("Invalid EndpointGroups link: %s", result.endpointGroups)
 	}
@@ -125,6 +136,22 @@ func TestStorageService(t *testing.T) {
 		t.Errorf("Invalid IOStats NonIORequests: %d", result.IOStatistics.NonIORequests)
 	}
 
+	if result.storageGroups != "/redfish/v1/StorageGroups" {
+		t.Errorf("Invalid storage groups collection link: %s", result.storageGroups)
+	}
+
+	if result.storagePools != "/redfish/v1/StoragePools" {
+		t.Errorf("Invalid storage pools collection link: %s", result.storagePools)
+	}
+
+	if result.storageSubsystems != "/redfish/v1/StorageSubsystems/1" {
+		t.Errorf("Invalid storage subsystems link: %s", result.storageSubsystems)
+	}
+
+	if result.HostingSystem() != "/redfish/v1/Hosts/1" {
+		t.Errorf("Invalid hosting system link: %s", result.HostingSystem())
+	}
+
 	if result.volumes != "/redfish/v1/Volumes/1" {
 		t.Errorf("Invalid volumes collection link: %s", result.volumes)
 	}
@@ -5,8 +5,6 @@
 package swordfish
 
 import (
-	"encoding/json"
-
 	"github.com/LRichi/WBfish/common"
 	"github.com/LRichi/WBfish/redfish"
 )
@@ -18,19 +16,12 @@ type StorageSystem struct {
 
 // GetStorageSystem will get a StorageSystem instance from the Swordfish service.
 func GetStorageSystem(c common.Client, uri string) (*StorageSystem, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var storageSystem StorageSystem
-	err = json.NewDecoder(resp.Body).Decode(&storageSystem)
+	err := common.FetchResource(c, uri, &storageSystem)
 	if err != nil {
 		return nil, err
 	}
 
-	storageSystem.SetClient(c)
 	return &storageSystem, nil
 }
 
@@ -43,7 +34,11 @@ func ListReferencedStorageSystems(c common.Client, link string) ([]*StorageSyste
 	}
 
 	for _, storageSystemLink := range links.ItemLinks {
-		storageSystem, err := GetStorageSystem(c, storageSystemLink)
+		storageSystem := new(StorageSystem)
+		expanded, err := links.DecodeMember(c, storageSystemLink, storageSystem)
+		if err == nil && !expanded {
+			storageSystem, err = GetStorageSystem(c, storageSystemLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -276,7 +276,7 @@ type Volume struct {
 	// EncryptionTypes is used by this Volume.
 	EncryptionTypes []redfish.EncryptionTypes
 	// IOStatistics shall represent IO statistics for this volume.
-	//IOStatistics IOStatistics
+	IOStatistics IOStatistics
 	// Identifiers shall contain a list of all known durable
 	// names for the associated volume.
 	Identifiers []common.Identifier
@@ -332,10 +332,10 @@ type Volume struct {
 	RemainingCapacityPercent int
 	// ReplicaInfo shall describe the replica relationship
 	// between this storage volume and a corresponding source volume.
-	//ReplicaInfo redfish.ReplicaInfo
+	ReplicaInfo ReplicaInfo
 	// ReplicaTargets shall reference the target replicas that
 	// are sourced by this replica.
-	ReplicaTargets []string
+	replicaTargets []string
 	// ReplicaTargets@odata.count is
 	ReplicaTargetsCount int `json:"ReplicaTargets@odata.count"`
 	// Status is
@@ -412,6 +412,11 @@ type Volume struct {
 	rawData []byte
 }
 
+// GetRawData get raw data json
+func (volume *Volume) GetRawData() []byte {
+	return volume.rawData
+}
+
 // UnmarshalJSON unmarshals a Volume object from the raw JSON.
 func (volume *Volume) UnmarshalJSON(b []byte) error {
 	type temp Volume
@@ -477,6 +482,7 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	var t struct {
 		temp
 		AllocatedPools common.Links
+		ReplicaTargets common.Links
 		StorageGroups  common.Links
 		Links          links
 		Actions        actions
@@ -490,6 +496,7 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	*volume = Volume(t.temp)
 	volume.allocatedPools = t.AllocatedPools.ToStrings()
+	volume.replicaTargets = t.ReplicaTargets.ToStrings()
 	volume.storageGroups = t.StorageGroups.ToStrings()
 	volume.classOfService = string(t.Links.ClassOfService)
 	volume.dedicatedSpareDrives = t.Links.DedicatedSpareDrives.ToStrings()
@@ -510,10 +517,18 @@ func (volume *Volume) UnmarshalJSON(b []byte) error {
 
 	// This is a read/write object, so we need to save the raw object data for later
 	volume.rawData = b
+	volume.SetActions(common.ParseActions(b))
 
 	return nil
 }
 
+// MarshalJSON marshals the volume to JSON, keeping the properties of the raw
+// JSON that are not modeled.
+func (volume Volume) MarshalJSON() ([]byte, error) {
+	type temp Volume
+	return common.MarshalWithRawData(volume.rawData, temp(volume))
+}
+
 // Update commits updates to this object's properties to the running system.
 func (volume *Volume) Update() error {
 
@@ -548,19 +563,12 @@ func (volume *Volume) Update() error {
 
 // GetVolume will get a Volume instance from the service.
 func GetVolume(c common.Client, uri string) (*Volume, error) {
-	resp, err := c.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var volume Volume
-	err = json.NewDecoder(resp.Body).Decode(&volume)
+	err := common.FetchResource(c, uri, &volume)
 	if err != nil {
 		return nil, err
 	}
 
-	volume.SetClient(c)
 	return &volume, nil
 }
 
@@ -577,7 +585,11 @@ func ListReferencedVolumes(c common.Client, link string) ([]*Volume, error) {
 	}
 
 	for _, volumeLink := range links.ItemLinks {
-		volume, err := GetVolume(c, volumeLink)
+		volume := new(Volume)
+		expanded, err := links.DecodeMember(c, volumeLink, volume)
+		if err == nil && !expanded {
+			volume, err = GetVolume(c, volumeLink)
+		}
 		if err != nil {
 			return result, err
 		}
@@ -664,6 +676,21 @@ func (volume *Volume) StoragePools() ([]*StoragePool, error) {
 	return result, nil
 }
 
+// ReplicaTargets gets the target replica volumes that are sourced by this
+// volume.
+func (volume *Volume) ReplicaTargets() ([]*Volume, error) {
+	var result []*Volume
+	for _, volumeLink := range volume.replicaTargets {
+		target, err := GetVolume(volume.Client, volumeLink)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, target)
+	}
+
+	return result, nil
+}
+
 // AssignReplicaTarget is used to establish a replication relationship by
 // assigning an existing volume to serve as a target replica for an existing
 // source volume.
@@ -693,6 +720,38 @@ func (volume *Volume) AssignReplicaTarget(
 	return err
 }
 
+// CreateReplicaTarget is used to create a new volume resource to provide
+// expanded data protection through a replica relationship with the specified
+// source volume. The new volume is allocated from the storage pool at
+// targetStoragePoolODataID and is named volumeName when it is not empty.
+func (volume *Volume) CreateReplicaTarget(
+	replicaType ReplicaType, updateMode ReplicaUpdateMode, targetStoragePoolODataID string, volumeName string) error {
+
+	// This action wasn't added until later revisions
+	if volume.createReplicaTargetTarget == "" {
+		return fmt.Errorf("CreateReplicaTarget action is not supported by this system")
+	}
+
+	// Define this action's parameters
+	type temp struct {
+		ReplicaType       ReplicaType
+		ReplicaUpdateMode ReplicaUpdateMode
+		TargetStoragePool string
+		VolumeName        string `json:",omitempty"`
+	}
+
+	// Set the values for the action arguments
+	t := temp{
+		ReplicaType:       replicaType,
+		ReplicaUpdateMode: updateMode,
+		TargetStoragePool: targetStoragePoolODataID,
+		VolumeName:        volumeName,
+	}
+
+	_, err := volume.Client.Post(volume.createReplicaTargetTarget, t)
+	return err
+}
+
 // CheckConsistency is used to force a check of the Volume's parity or redundant
 // data to ensure it matches calculated values.
 func (volume *Volume) CheckConsistency() error {
@@ -835,3 +894,56 @@ func (volume *Volume) SuspendReplication(targetVolumeODataID string) error {
 	_, err := volume.Client.Post(volume.suspendReplicationTarget, t)
 	return err
 }
+
+// VolumeCreateParameters are the properties used to create a new volume.
+type VolumeCreateParameters struct {
+	// Name is the name of the new volume.
+	Name string
+	// CapacityBytes is the size, in bytes, of the new volume.
+	CapacityBytes int64
+	// ClassOfService is the URI of the class of service the volume shall
+	// conform to. The service chooses how to provision the volume so its
+	// lines of service are met.
+	ClassOfService string
+	// ProvidingPools are the URIs of the storage pools that provide the
+	// capacity of the volume.
+	ProvidingPools []string
+	// RAIDType is the RAID type of the volume, if the service should not
+	// choose it from the class of service.
+	RAIDType RAIDType
+}
+
+// createPayload builds the body used to create a volume in a collection.
+func (parameters *VolumeCreateParameters) createPayload() interface{} {
+	type links struct {
+		ClassOfService *odataID `json:",omitempty"`
+	}
+	type temp struct {
+		Name            string
+		CapacityBytes   int64                   `json:",omitempty"`
+		CapacitySources []capacitySourcePayload `json:",omitempty"`
+		RAIDType        RAIDType                `json:",omitempty"`
+		Links           *links                  `json:",omitempty"`
+	}
+	t := temp{
+		Name:            parameters.Name,
+		CapacityBytes:   parameters.CapacityBytes,
+		CapacitySources: newCapacitySourcesPayload(nil, parameters.ProvidingPools),
+		RAIDType:        parameters.RAIDType,
+	}
+	if parameters.ClassOfService != "" {
+		t.Links = &links{ClassOfService: newODataID(parameters.ClassOfService)}
+	}
+
+	return t
+}
+
+// CreateVolume creates a new volume in the provided volume collection.
+func CreateVolume(c common.Client, collection string, parameters *VolumeCreateParameters) error {
+	if collection == "" {
+		return fmt.Errorf("a volume collection is required to create a volume")
+	}
+
+	_, err := c.Post(collection, parameters.createPayload())
+	return err
+}
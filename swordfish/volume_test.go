@@ -115,6 +115,20 @@ var volumeBody = `{
 		"WriteCacheState": "Protected",
 		"WriteHoleProtectionPolicy": "Off",
 		"RemainingCapacityPercent": 24,
+		"ReplicaInfo": {
+			"ConsistencyEnabled": true,
+			"Replica": {
+				"@odata.id": "/redfish/v1/Volumes/Source"
+			},
+			"ReplicaRole": "Target",
+			"ReplicaState": "Synchronized",
+			"ReplicaType": "Mirror",
+			"ReplicaUpdateMode": "Synchronous"
+		},
+		"ReplicaTargets": [{
+			"@odata.id": "/redfish/v1/Volumes/Target1"
+		}],
+		"ReplicaTargets@odata.count": 1,
 		"Status": {
 			"State": "Enabled",
 			"Health": "OK"
@@ -194,6 +208,22 @@ func TestVolume(t *testing.T) {
 		t.Errorf("Invalid StorageGroup link: %s", result.storageGroups[0])
 	}
 
+	if result.ReplicaInfo.Replica() != "/redfish/v1/Volumes/Source" {
+		t.Errorf("Invalid replica source: %s", result.ReplicaInfo.Replica())
+	}
+
+	if result.ReplicaInfo.ReplicaRole != TargetReplicaRole {
+		t.Errorf("Invalid ReplicaRole: %s", result.ReplicaInfo.ReplicaRole)
+	}
+
+	if result.ReplicaInfo.ReplicaState != SynchronizedReplicaState {
+		t.Errorf("Invalid ReplicaState: %s", result.ReplicaInfo.ReplicaState)
+	}
+
+	if len(result.replicaTargets) != 1 || result.ReplicaTargetsCount != 1 {
+		t.Errorf("Unexpected replica targets: %v", result.replicaTargets)
+	}
+
 	if result.assignReplicaTargetTarget != "/redfish/v1/Volume/Actions/Volume.AssignReplicaTarget" {
 		t.Errorf("Invalid AssignReplicaTarget target: %s", result.assignReplicaTargetTarget)
 	}
@@ -306,3 +336,92 @@ func TestVolumeUpdate(t *testing.T) {
 		t.Errorf("Unexpected WriteHoleProtectionPolicy update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestVolumeCreatePayload tests the body used to create volumes.
+func TestVolumeCreatePayload(t *testing.T) {
+	parameters := VolumeCreateParameters{
+		Name:           "Volume1",
+		CapacityBytes:  107374182400,
+		ClassOfService: "/redfish/v1/StorageServices/1/ClassesOfService/Gold",
+	}
+
+	payload, err := json.Marshal(parameters.createPayload())
+	if err != nil {
+		t.Errorf("Error encoding payload: %s", err)
+	}
+
+	expected := `{"Name":"Volume1","CapacityBytes":107374182400,` +
+		`"Links":{"ClassOfService":{"@odata.id":"/redfish/v1/StorageServices/1/ClassesOfService/Gold"}}}`
+	if string(payload) != expected {
+		t.Errorf("Unexpected create payload: %s", payload)
+	}
+
+	testClient := &common.TestClient{}
+	err = CreateVolume(testClient, "/redfish/v1/StorageServices/1/Volumes", &parameters)
+
+	if err != nil {
+		t.Errorf("Error making CreateVolume call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].Action != "POST" || calls[0].URL != "/redfish/v1/StorageServices/1/Volumes" {
+		t.Errorf("Unexpected CreateVolume call: %s %s", calls[0].Action, calls[0].URL)
+	}
+}
+
+// TestVolumeReplication tests the replication actions.
+func TestVolumeReplication(t *testing.T) {
+	var result Volume
+	err := json.NewDecoder(strings.NewReader(volumeBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.CreateReplicaTarget(MirrorReplicaType, AsynchronousReplicaUpdateMode,
+		"/redfish/v1/StoragePools/2", "Volume1Replica")
+	if err != nil {
+		t.Errorf("Error making CreateReplicaTarget call: %s", err)
+	}
+
+	err = result.SuspendReplication("/redfish/v1/Volumes/Target1")
+	if err != nil {
+		t.Errorf("Error making SuspendReplication call: %s", err)
+	}
+
+	err = result.ResumeReplication("/redfish/v1/Volumes/Target1")
+	if err != nil {
+		t.Errorf("Error making ResumeReplication call: %s", err)
+	}
+
+	err = result.SplitReplication("/redfish/v1/Volumes/Target1")
+	if err != nil {
+		t.Errorf("Error making SplitReplication call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 4 {
+		t.Errorf("Unexpected number of calls: %d", len(calls))
+	}
+
+	if calls[0].URL != "/redfish/v1/Volume/Actions/Volume.CreateReplicaTarget" {
+		t.Errorf("Unexpected CreateReplicaTarget URL: %s", calls[0].URL)
+	}
+
+	if calls[0].Payload != "{Mirror Asynchronous /redfish/v1/StoragePools/2 Volume1Replica}" {
+		t.Errorf("Unexpected CreateReplicaTarget payload: %s", calls[0].Payload)
+	}
+
+	if calls[1].URL != "/redfish/v1/Volume/Actions/Volume.SuspendReplication" {
+		t.Errorf("Unexpected SuspendReplication URL: %s", calls[1].URL)
+	}
+
+	if calls[3].Payload != "{/redfish/v1/Volumes/Target1}" {
+		t.Errorf("Unexpected SplitReplication payload: %s", calls[3].Payload)
+	}
+}
@@ -1,13 +1,12 @@
 #!/bin/bash
 # SPDX-License-Identifier: BSD-3-Clause
 
-# Generates a list of schema object from a given schema zip or directory then
-# generates go files based on the provided generate_from_schema.py tool and
-# accompanying source.tmpl file.
+# Fetches a given schema zip or directory then generates go files for all of
+# its objects with the schemagen tool. Run it with "go generate ./tools/..."
+# or directly, optionally with the schema document name.
 
-# Set correct name for python3 executable. Some platforms just call it python
-# while others call it python3.
-PYTHON="python"
+# Work from the tools directory, as go generate runs this from schemagen
+cd "$(dirname "$0")"
 
 # Find the schema document name by going here:
 #
@@ -22,21 +21,6 @@ if [[ "$#" -eq 1 ]]; then
     schemadoc="${1}"
 fi
 
-# Make sure we're running in a virtual environment
-if [[ -z "$VIRTUAL_ENV" ]]; then
-    if [[ ! -d ./env ]]; then
-        $PYTHON -m venv env
-    fi
-
-    if [[ -d ./env/Scripts ]]; then
-        # Windows
-        source ./env/Scripts/activate
-    else
-        source ./env/bin/activate
-    fi
-    $PYTHON -m pip install -r requirements.txt
-fi
-
 # See if we already have this locally or if we need to fetch it
 if [[ ! -d $schemadoc ]]; then
     if [[ ! -f "${schemadoc}.zip" ]]; then
@@ -49,32 +33,15 @@ if [[ ! -d $schemadoc ]]; then
     unzip "${schemadoc}.zip"
 fi
 
-# Generate the object list. Not elegant, but it works well enough for now.
+# The former Python generator, generate_from_schema.py with its source.tmpl
+# template and requirements.txt, is kept for generating single objects by
+# hand.
 #
-# SerialInterface and Switch still have some identifier issues to be worked out
-# Collection files, Schedule, Protocol are "common" and included differently
-# redfish-schema, odata and all the versions of each object aren't needed
-#
-# General process is get a list of the json-schema objects from the zip, drop
-# things we don't need/want, and clip the column we want generating a file of
-# object names we can use later.
-schema_paths=$(find "$schemadoc" -name *.json)
-schema_objects=$(find "$schemadoc" -name *json | cut -d '/' -f 3 |  cut -d . -f 1 | grep -v Collection |  grep -v "redfish-" | grep -v odata | grep -v Protocol | sort | uniq)
-
-# Now we're ready to generate the go source based on these files
-if [[ ! -d gofiles ]]; then
-    mkdir gofiles
-fi
-
-# Loop through each one and generate the raw source file
-for object in $schema_objects; do
-    echo "Processing object ${object}..."
-    $PYTHON generate_from_schema.py -l "${schemadoc}/json-schema" -t redfish $object -o "gofiles/${object}.go"
-done
-
-# Then clean them up
-echo "Running go fmt on generated files"
-go fmt ./gofiles/*.go
+# Generate the go source of every object of the schema. schemagen picks the
+# most recent version of each object and skips the collections and the
+# schema files that only hold shared definitions.
+echo "Generating go files..."
+go run ./schemagen -schemas "${schemadoc}/json-schema" -package redfish -o gofiles
 
 echo "Processing Complete"
-echo "(Ready for manual cleanup)"
+echo "(Generated files are in gofiles, compare them with the redfish package before copying)"
@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Command schemagen generates the resource structs and enums of the redfish
+// and swordfish packages from the published DMTF JSON schemas, such as the
+// json-schema directory of the DSP8010 bundle, so a new schema version can
+// be adopted without writing each resource by hand.
+//
+// Usage:
+//
+//	schemagen [flags] [object...]
+//
+// Each object, such as "Heater", is generated from its most recent
+// versioned schema into a file of its own, with the UnmarshalJSON
+// extracting its links and action targets, the accessors following its
+// links, and Update for its read-write properties. Without objects, every
+// resource of the schema directory is generated. gen_script.sh fetches a
+// bundle and runs schemagen on it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate bash ../gen_script.sh
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the flags and generates the objects.
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("schemagen", flag.ContinueOnError)
+	flags.SetOutput(out)
+	schemas := flags.String("schemas", "json-schema", "directory of the JSON schema files")
+	pkg := flags.String("package", "redfish", "Go package of the generated files")
+	output := flags.String("o", "gofiles", "directory to write the generated files to")
+	flags.Usage = func() {
+		fmt.Fprintf(out, "Usage: schemagen [flags] [object...]\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	objects := flags.Args()
+	all := len(objects) == 0
+	if all {
+		objects, err = schemaObjects(*schemas)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(*output, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		path, err := latestVersion(*schemas, object)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			if all {
+				// A schema file of shared definitions, such as Resource
+				continue
+			}
+			return fmt.Errorf("no versioned schema for %s in %s", object, *schemas)
+		}
+
+		source, err := generate(*pkg, object, path)
+		if err != nil {
+			return err
+		}
+
+		file := filepath.Join(*output, strings.ToLower(object)+".go")
+		err = os.WriteFile(file, source, 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s: %s\n", file, filepath.Base(path))
+	}
+
+	return nil
+}
+
+// generate generates the Go source of object from its versioned schema at
+// path.
+func generate(pkg, object, path string) ([]byte, error) {
+	s, err := loadSchema(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = source.Execute(&buf, newGeneration(pkg, object, s))
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid source generated for %s: %s", object, err)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,87 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLatestVersion tests finding the most recent versioned schema.
+func TestLatestVersion(t *testing.T) {
+	path, err := latestVersion("testdata", "Widget")
+	if err != nil {
+		t.Fatalf("Error finding the versioned schema: %s", err)
+	}
+
+	if filepath.Base(path) != "Widget.v1_10_0.json" {
+		t.Errorf("v1_10_0 should be more recent than v1_9_1: %s", path)
+	}
+}
+
+// TestGenerate tests generating the objects of a schema directory.
+func TestGenerate(t *testing.T) {
+	output := t.TempDir()
+	var out bytes.Buffer
+	err := run([]string{"-schemas", "testdata", "-o", output}, &out)
+	if err != nil {
+		t.Fatalf("Error generating: %s", err)
+	}
+
+	source, err := os.ReadFile(filepath.Join(output, "widget.go"))
+	if err != nil {
+		t.Fatalf("The widget should be generated: %s", err)
+	}
+
+	for _, expected := range []string{
+		"type WidgetMode string",
+		`ManualWidgetMode WidgetMode = "Manual"`,
+		`OneWidgetMode WidgetMode = "1"`,
+		"\tMode WidgetMode\n",
+		"\tSpeedRPM float32\n",
+		"\tReading SensorExcerpt\n",
+		"\tStatus common.Status\n",
+		"\tpcieDevice string\n",
+		"\tchassis []string\n",
+		"\tresetTarget string\n",
+		"widget.chassis = t.Links.Chassis.ToStrings()",
+		"widget.assembly = string(t.Assembly)",
+		"widget.resetTarget = t.Actions.Reset.Target",
+		"\t\t\"Mode\",\n\t\t\"SpeedRPM\",\n",
+		"func GetWidget(c common.Client, uri string) (*Widget, error) {",
+		"func (widget *Widget) Chassis() ([]*Chassis, error) {",
+		"return ListReferencedSensors(widget.Client, widget.sensors)",
+		"\tPeer common.Link\n",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("The generated source should have %q:\n%s", expected, source)
+		}
+	}
+
+	for _, unexpected := range []string{"OldSpeed", "Chassis@odata.count", "RelatedItem()", "type Reset "} {
+		if strings.Contains(string(source), unexpected) {
+			t.Errorf("The generated source should not have %q", unexpected)
+		}
+	}
+}
+
+// TestUnexported tests naming the unexported fields of links.
+func TestUnexported(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Assembly":      "assembly",
+		"PCIeDevices":   "pcieDevices",
+		"IPAddress":     "ipAddress",
+		"IPv6Addresses": "ipv6Addresses",
+		"ID":            "id",
+		"Switch":        "switchLink",
+	} {
+		if result := unexported(name); result != expected {
+			t.Errorf("Invalid unexported name of %s: %s", name, result)
+		}
+	}
+}
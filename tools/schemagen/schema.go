@@ -0,0 +1,741 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// commonTypes are the schema definitions that the common package models.
+var commonTypes = map[string]string{
+	"Identifier":      "common.Identifier",
+	"Location":        "common.Location",
+	"PhysicalContext": "common.PhysicalContext",
+	"Status":          "common.Status",
+}
+
+// stringTypes are the shared definitions that are strings.
+var stringTypes = map[string]bool{
+	"context":     true,
+	"Description": true,
+	"etag":        true,
+	"id":          true,
+	"Id":          true,
+	"Name":        true,
+	"type":        true,
+	"UUID":        true,
+}
+
+// commonDescriptions are the comments of the properties every resource has.
+var commonDescriptions = map[string]string{
+	"Description":    "// Description provides a description of this resource.",
+	"Status":         "// Status shall contain any status or health properties of the resource.",
+	"@odata.context": "// ODataContext is the odata context.",
+	"@odata.etag":    "// ODataEtag is the odata etag.",
+	"@odata.type":    "// ODataType is the odata type.",
+}
+
+// nameChanges are the property names that are not Go names as is.
+var nameChanges = map[string]string{
+	"@odata.context": "ODataContext",
+	"@odata.etag":    "ODataEtag",
+	"@odata.type":    "ODataType",
+	"Id":             "ID",
+}
+
+// numberWords are the names of the enum values that are a single digit.
+var numberWords = map[string]string{
+	"0": "Zero", "1": "One", "2": "Two", "3": "Three", "4": "Four",
+	"5": "Five", "6": "Six", "7": "Seven", "8": "Eight", "9": "Nine",
+}
+
+// entityMethods are the methods of common.Entity, which link accessors must
+// not shadow.
+var entityMethods = map[string]bool{
+	"ActionNames": true, "Actions": true, "ETag": true, "GetRawData": true,
+	"InvokeAction": true, "MarshalJSON": true, "SchemaVersion": true,
+	"SchemaVersionAtLeast": true, "SetActions": true, "SetClient": true,
+	"UnmarshalJSON": true, "Update": true, "UpdateWithNested": true,
+}
+
+// referencePattern splits a $ref into its schema file and definition.
+var referencePattern = regexp.MustCompile(`^(?:.*/)?(?:([A-Za-z0-9-]+?)(?:\.(v\d+_\d+_\d+))?\.json)?#/definitions/(\w+)$`)
+
+// versionPattern matches the version of a versioned schema file.
+var versionPattern = regexp.MustCompile(`\.v(\d+)_(\d+)_(\d+)\.json`)
+
+// schema is a JSON schema file, or one of its definitions or properties.
+type schema struct {
+	Ref                  string `json:"$ref"`
+	AnyOf                []*schema
+	Type                 interface{}
+	Items                *schema
+	Properties           map[string]*schema
+	Definitions          map[string]*schema
+	Enum                 []string
+	EnumDescriptions     map[string]string
+	EnumLongDescriptions map[string]string
+	Description          string
+	LongDescription      string
+	ReadOnly             *bool
+	Deprecated           string
+}
+
+// description gets the most detailed description of a schema.
+func (s *schema) description() string {
+	if s.LongDescription != "" {
+		return s.LongDescription
+	}
+	return s.Description
+}
+
+// types gets the JSON types of a schema, without null.
+func (s *schema) types() []string {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = append(types, t)
+	case []interface{}:
+		for _, kind := range t {
+			if kind, ok := kind.(string); ok && kind != "null" {
+				types = append(types, kind)
+			}
+		}
+	}
+	return types
+}
+
+// reference gets the $ref of a schema, including one in its anyOf.
+func (s *schema) reference() string {
+	if s.Ref != "" {
+		return s.Ref
+	}
+	for _, kind := range s.AnyOf {
+		if kind.Ref != "" {
+			return kind.Ref
+		}
+	}
+	return ""
+}
+
+// generation is a schema file to generate Go source from.
+type generation struct {
+	// Package is the Go package of the generated source.
+	Package string
+	// Object is the name of the resource of the schema.
+	Object string
+	// Objects are the structs of the definitions.
+	Objects []*object
+	// Enums are the enums of the definitions.
+	Enums []*enum
+	// JSON, Reflect, Common and Redfish tell whether the source uses the
+	// encoding/json, reflect, common and redfish packages.
+	JSON    bool
+	Reflect bool
+	Common  bool
+	Redfish bool
+}
+
+// object is the struct of an object definition.
+type object struct {
+	Name        string
+	Receiver    string
+	Description string
+	// Entity tells whether the object is a Redfish resource, with its own
+	// URI.
+	Entity bool
+	// Resource tells whether the object is the resource of the schema, which
+	// gets Get and ListReferenced functions.
+	Resource  bool
+	Fields    []*field
+	Links     []*link
+	Actions   []*action
+	ReadWrite []string
+}
+
+// field is an exported property of an object.
+type field struct {
+	Name        string
+	Type        string
+	Tag         string
+	Description string
+}
+
+// link is a link to other resources, kept unexported in the object and
+// followed by an accessor.
+type link struct {
+	// Property is the name of the property with the link.
+	Property string
+	// Field is the unexported field holding the URI of the link.
+	Field       string
+	Description string
+	// InLinks tells whether the link is in the Links property.
+	InLinks bool
+	// Many tells whether the property is an array of links.
+	Many bool
+	// Collection tells whether the link is to a collection of resources.
+	Collection bool
+	// Type is the type of the linked resources, or empty if the schema
+	// does not tell.
+	Type string
+	// Get is the function getting the linked resource, or the items of the
+	// linked collection.
+	Get string
+	// Accessor is the name of the method following the link, or empty if the
+	// link has none.
+	Accessor string
+	// Variable is the name of the variable holding the linked resources in
+	// the accessor.
+	Variable string
+}
+
+// action is an action of an object, which target is kept unexported.
+type action struct {
+	Name     string
+	Property string
+	Field    string
+}
+
+// enum is the type of an enum definition.
+type enum struct {
+	Name        string
+	Description string
+	Members     []*member
+}
+
+// member is a value of an enum.
+type member struct {
+	Name        string
+	Value       string
+	Description string
+}
+
+// loadSchema reads a schema file.
+func loadSchema(path string) (*schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s schema
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %s", path, err)
+	}
+	return &s, nil
+}
+
+// schemaObjects lists the resources of the schema files in dir.
+func schemaObjects(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []string
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if strings.Contains(name, ".") || strings.HasSuffix(name, "Collection") ||
+			strings.HasPrefix(name, "redfish-") || strings.HasPrefix(name, "odata") {
+			continue
+		}
+		objects = append(objects, name)
+	}
+	return objects, nil
+}
+
+// latestVersion finds the most recent versioned schema file of object in
+// dir, from the references of its unversioned schema file. It returns an
+// empty string if the object has no versioned schema, such as the schema
+// files that only hold shared definitions.
+func latestVersion(dir, object string) (string, error) {
+	base, err := loadSchema(filepath.Join(dir, object+".json"))
+	if err != nil {
+		return "", err
+	}
+
+	definition := base.Definitions[object]
+	if definition == nil {
+		return "", nil
+	}
+
+	latest := ""
+	var newest [3]int
+	for _, ref := range definition.AnyOf {
+		match := versionPattern.FindStringSubmatch(ref.Ref)
+		if match == nil {
+			continue
+		}
+
+		var version [3]int
+		for i := range version {
+			version[i], _ = strconv.Atoi(match[i+1])
+		}
+		if latest == "" || compareVersions(version, newest) > 0 {
+			latest = filepath.Base(strings.SplitN(ref.Ref, "#", 2)[0])
+			newest = version
+		}
+	}
+
+	if latest == "" {
+		return "", nil
+	}
+	return filepath.Join(dir, latest), nil
+}
+
+// compareVersions compares schema versions numerically, as v1_10_0 comes
+// after v1_9_0.
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+// newGeneration builds what to generate from the versioned schema of object.
+func newGeneration(pkg, object string, s *schema) *generation {
+	g := &generation{Package: pkg, Object: object}
+
+	actions := map[string]bool{}
+	if definition := s.Definitions["Actions"]; definition != nil {
+		for _, property := range definition.Properties {
+			if match := referencePattern.FindStringSubmatch(property.Ref); match != nil {
+				actions[match[3]] = true
+			}
+		}
+	}
+
+	var names []string
+	for name := range s.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition := s.Definitions[name]
+		switch {
+		case name == "Actions" || name == "Links" || name == "OemActions" || actions[name]:
+			// folded into the object holding them
+		case len(definition.Enum) > 0:
+			g.Enums = append(g.Enums, g.newEnum(name, definition))
+		case contains(definition.types(), "object"):
+			g.Objects = append(g.Objects, g.newObject(name, definition, s))
+		}
+	}
+
+	// The resource comes first, as in the hand written files
+	sort.SliceStable(g.Objects, func(i, j int) bool {
+		return g.Objects[i].Resource && !g.Objects[j].Resource
+	})
+
+	for _, o := range g.Objects {
+		g.JSON = g.JSON || o.Entity
+		g.Common = g.Common || o.Entity
+		for _, f := range o.Fields {
+			g.JSON = g.JSON || strings.Contains(f.Type, "json.")
+			g.Common = g.Common || strings.Contains(f.Type, "common.")
+		}
+	}
+
+	return g
+}
+
+// newEnum builds the type of an enum definition.
+func (g *generation) newEnum(name string, definition *schema) *enum {
+	e := &enum{
+		Name:        ident(name),
+		Description: comment(ident(name), definition.description(), "is"),
+	}
+
+	for _, value := range definition.Enum {
+		description := definition.EnumLongDescriptions[value]
+		if description == "" {
+			description = definition.EnumDescriptions[value]
+		}
+
+		memberName := ident(value) + e.Name
+		e.Members = append(e.Members, &member{
+			Name:        memberName,
+			Value:       value,
+			Description: comment(memberName, description, "means"),
+		})
+	}
+	return e
+}
+
+// newObject builds the struct of an object definition.
+func (g *generation) newObject(name string, definition *schema, s *schema) *object {
+	o := &object{
+		Name:     ident(name),
+		Receiver: strings.ToLower(ident(name)),
+		Resource: name == g.Object,
+	}
+	o.Description = comment(o.Name, definition.description(), "is")
+
+	for _, property := range []string{"@odata.id", "Id", "Name"} {
+		if definition.Properties[property] != nil {
+			o.Entity = true
+		}
+	}
+
+	var properties []string
+	for property := range definition.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		prop := definition.Properties[property]
+		switch {
+		case prop.Deprecated != "" || skipProperty(property):
+			continue
+		case property == "Actions":
+			if o.Entity {
+				o.Actions = g.objectActions(s)
+			}
+			continue
+		case property == "Links":
+			if o.Entity && s.Definitions["Links"] != nil {
+				o.Links = append(o.Links, g.objectLinks(o, s.Definitions["Links"], true)...)
+			}
+			continue
+		}
+
+		if o.Entity {
+			if l := g.newLink(o, property, prop, false); l != nil {
+				o.Links = append(o.Links, l)
+				continue
+			}
+		}
+
+		f := g.newField(property, prop)
+		o.Fields = append(o.Fields, f)
+		if prop.ReadOnly != nil && !*prop.ReadOnly && !strings.HasPrefix(property, "@") {
+			o.ReadWrite = append(o.ReadWrite, f.Name)
+		}
+	}
+
+	if o.Entity && len(o.ReadWrite) > 0 {
+		g.Reflect = true
+	}
+
+	// Accessors must not shadow fields nor each other
+	taken := map[string]bool{}
+	for _, f := range o.Fields {
+		taken[f.Name] = true
+	}
+	for _, l := range o.Links {
+		if l.Type == "" || entityMethods[l.Accessor] || taken[l.Accessor] {
+			l.Accessor = ""
+			continue
+		}
+		taken[l.Accessor] = true
+	}
+
+	return o
+}
+
+// objectActions lists the actions of the Actions definition.
+func (g *generation) objectActions(s *schema) []*action {
+	definition := s.Definitions["Actions"]
+	if definition == nil {
+		return nil
+	}
+
+	var actions []*action
+	for property, prop := range definition.Properties {
+		if !strings.HasPrefix(property, "#") {
+			continue
+		}
+		match := referencePattern.FindStringSubmatch(prop.Ref)
+		if match == nil {
+			continue
+		}
+
+		actions = append(actions, &action{
+			Name:     ident(match[3]),
+			Property: property,
+			Field:    unexported(ident(match[3])) + "Target",
+		})
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Name < actions[j].Name })
+	return actions
+}
+
+// objectLinks lists the links of the Links definition.
+func (g *generation) objectLinks(o *object, definition *schema, inLinks bool) []*link {
+	var names []string
+	for name := range definition.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var links []*link
+	for _, name := range names {
+		prop := definition.Properties[name]
+		if prop.Deprecated != "" || skipProperty(name) {
+			continue
+		}
+		if l := g.newLink(o, name, prop, inLinks); l != nil {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// newLink builds the link of a property, or returns nil if the property is
+// not a link to other resources.
+func (g *generation) newLink(o *object, name string, prop *schema, inLinks bool) *link {
+	l := &link{Property: ident(name), InLinks: inLinks}
+
+	ref := prop.reference()
+	if contains(prop.types(), "array") && prop.Items != nil {
+		l.Many = true
+		ref = prop.Items.reference()
+	}
+
+	match := referencePattern.FindStringSubmatch(ref)
+	if match == nil {
+		return nil
+	}
+	file, definition := match[1], match[3]
+	switch {
+	case file == "odata-v4" && definition == "idRef":
+		// The schema does not tell the type of the linked resources
+	case file != "" && file == definition:
+		l.Type = ident(definition)
+		if strings.HasSuffix(l.Type, "Collection") && !l.Many {
+			l.Collection = true
+			l.Type = strings.TrimSuffix(l.Type, "Collection")
+		}
+	default:
+		return nil
+	}
+
+	l.Field = unexported(l.Property)
+	l.Description = comment(l.Field, prop.description(), "is")
+	if l.Type != "" {
+		prefix := g.packagePrefix(ref)
+		l.Accessor = l.Property
+		l.Variable = unexported(l.Type)
+		if l.Variable == o.Receiver {
+			l.Variable = "linked" + l.Type
+		}
+		if l.Many || l.Collection {
+			l.Get = prefix + "ListReferenced" + plural(l.Type)
+		}
+		if !l.Collection {
+			l.Get = prefix + "Get" + l.Type
+		}
+		l.Type = prefix + l.Type
+	}
+	return l
+}
+
+// newField builds the exported field of a property.
+func (g *generation) newField(name string, prop *schema) *field {
+	f := &field{Name: ident(name), Type: g.propertyType(prop)}
+	if changed, ok := nameChanges[name]; ok {
+		f.Name = changed
+		if name != "Id" {
+			f.Tag = fmt.Sprintf("`json:\"%s\"`", name)
+		}
+	}
+
+	if description, ok := commonDescriptions[name]; ok {
+		f.Description = description
+	} else {
+		f.Description = comment(f.Name, prop.description(), "is")
+	}
+	return f
+}
+
+// propertyType gets the Go type of a property.
+func (g *generation) propertyType(prop *schema) string {
+	types := prop.types()
+	if contains(types, "array") && prop.Items != nil {
+		itemType := g.propertyType(prop.Items)
+		if itemType == "common.Link" {
+			return "common.Links"
+		}
+		return "[]" + itemType
+	}
+
+	if ref := prop.reference(); ref != "" {
+		match := referencePattern.FindStringSubmatch(ref)
+		if match == nil {
+			return "json.RawMessage"
+		}
+		file, definition := match[1], match[3]
+		switch {
+		case commonTypes[definition] != "":
+			return commonTypes[definition]
+		case stringTypes[definition]:
+			return "string"
+		case file == "odata-v4" && definition == "count":
+			return "int"
+		case file == "odata-v4" && definition == "idRef":
+			return "common.Link"
+		case file != "" && file == definition:
+			return "common.Link"
+		}
+		return g.packagePrefix(ref) + ident(definition)
+	}
+
+	switch {
+	case contains(types, "boolean"):
+		return "bool"
+	case contains(types, "integer"):
+		return "int"
+	case contains(types, "number"):
+		return "float32"
+	case contains(types, "string"):
+		return "string"
+	}
+	return "json.RawMessage"
+}
+
+// packagePrefix gets the package qualifier of the definitions a Swordfish
+// schema references from a Redfish one.
+func (g *generation) packagePrefix(ref string) string {
+	if g.Package != "redfish" && strings.Contains(ref, "/schemas/v1/") {
+		g.Redfish = true
+		return "redfish."
+	}
+	return ""
+}
+
+// skipProperty tells whether a property is not generated, as the common
+// package handles it or it is an annotation.
+func skipProperty(name string) bool {
+	switch name {
+	case "@odata.id", "Id", "Name", "Oem":
+		return true
+	}
+	return strings.Contains(name, "@odata.count") || strings.Contains(name, "@odata.navigationLink") ||
+		strings.HasPrefix(name, "@Redfish.") || strings.HasPrefix(name, "@Message.")
+}
+
+// ident gets a Go name from a schema name.
+func ident(name string) string {
+	if word, ok := numberWords[name]; ok {
+		return word
+	}
+
+	replacer := strings.NewReplacer("-", "_", " ", "", ":", "_", ".", "_", "/", "_div_", "+", "_plus_")
+	name = replacer.Replace(name)
+	if name == "" {
+		return name
+	}
+
+	runes := []rune(name)
+	if unicode.IsDigit(runes[0]) {
+		return "N" + name
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// unexported gets the unexported name of a Go name, lowering its leading
+// initialism: PCIeDevices gives pcieDevices and IPv6Addresses gives
+// ipv6Addresses.
+func unexported(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+
+	switch {
+	case upper == len(runes), upper <= 1:
+		upper = max(upper, 1)
+	case upper+1 < len(runes) && unicode.IsLower(runes[upper]) &&
+		(unicode.IsUpper(runes[upper+1]) || unicode.IsDigit(runes[upper+1])):
+		// an initialism ending with a lower case letter, such as PCIe or IPv6
+		upper++
+	case unicode.IsLower(runes[upper]):
+		// the last upper case letter starts the next word
+		upper--
+	}
+
+	name = strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+	if token.IsKeyword(name) {
+		name += "Link"
+	}
+	return name
+}
+
+// plural gets the plural of a resource name, as ListReferenced functions
+// have it.
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"):
+		return name
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ey"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
+// comment builds the doc comment of name from a schema description. The
+// schema descriptions such as "This property shall contain..." become "Name
+// shall contain...", the others are joined to name with verb, such as "Name
+// is the...".
+func comment(name, description, verb string) string {
+	description = strings.TrimSpace(description)
+	if index := strings.Index(description, "shall "); index >= 0 {
+		description = name + " " + description[index:]
+	} else if description != "" {
+		runes := []rune(description)
+		if len(runes) < 2 || !unicode.IsUpper(runes[1]) {
+			// Not an initialism, such as "The" or "Whether"
+			runes[0] = unicode.ToLower(runes[0])
+		}
+		description = name + " " + verb + " " + string(runes)
+	} else {
+		description = name + " is not described by the schema."
+	}
+
+	return wrap(description, 70)
+}
+
+// wrap wraps text in comment lines of at most width characters.
+func wrap(text string, width int) string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	lines = append(lines, line)
+	return "// " + strings.Join(lines, "\n// ")
+}
+
+// contains tells whether values has value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package main
+
+import (
+	"text/template"
+)
+
+// source is the template of a generated file, formatted with gofmt once
+// executed.
+var source = template.Must(template.New("source").Funcs(template.FuncMap{
+	"plural": plural,
+	"hasLinks": func(links []*link) bool {
+		for _, l := range links {
+			if l.InLinks {
+				return true
+			}
+		}
+		return false
+	},
+}).Parse(`//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package {{ .Package }}
+
+import (
+{{- if .JSON }}
+	"encoding/json"
+{{- end }}
+{{- if .Reflect }}
+	"reflect"
+{{- end }}
+{{- if .Common }}
+
+	"github.com/LRichi/WBfish/common"
+{{- end }}
+{{- if .Redfish }}
+	"github.com/LRichi/WBfish/redfish"
+{{- end }}
+)
+
+{{ range .Enums }}
+{{ .Description }}
+type {{ .Name }} string
+
+const (
+{{- $enum := . }}
+{{- range $i, $member := .Members }}
+{{- if $i }}
+{{ end }}
+	{{ .Description }}
+	{{ .Name }} {{ $enum.Name }} = "{{ .Value }}"
+{{- end }}
+)
+{{ end }}
+
+{{- range .Objects }}
+{{- $object := . }}
+{{ .Description }}
+type {{ .Name }} struct {
+{{ if .Entity }}	common.Entity
+
+{{ end }}
+{{- range .Fields }}	{{ .Description }}
+	{{ .Name }} {{ .Type }} {{ .Tag }}
+{{ end }}
+{{- range .Links }}	{{ .Description }}
+	{{ .Field }} {{ if .Many }}[]{{ end }}string
+{{ end }}
+{{- range .Actions }}	// {{ .Field }} is the URL to send {{ .Name }} actions to.
+	{{ .Field }} string
+{{ end }}
+{{- if .Entity }}	// rawData holds the original serialized JSON
+	rawData []byte
+{{ end }}}
+{{ if .Entity }}
+// GetRawData get raw data json
+func ({{ .Receiver }} *{{ .Name }}) GetRawData() []byte {
+	return {{ .Receiver }}.rawData
+}
+
+// UnmarshalJSON unmarshals a {{ .Name }} object from the raw JSON.
+func ({{ .Receiver }} *{{ .Name }}) UnmarshalJSON(b []byte) error {
+	type temp {{ .Name }}
+{{- if .Actions }}
+	type Actions struct {
+{{- range .Actions }}
+		{{ .Name }} struct {
+			Target string
+		} ` + "`" + `json:"{{ .Property }}"` + "`" + `
+{{- end }}
+	}
+{{- end }}
+	var t struct {
+		temp
+{{- range .Links }}
+{{- if not .InLinks }}
+		{{ .Property }} common.Link{{ if .Many }}s{{ end }}
+{{- end }}
+{{- end }}
+{{- if .Actions }}
+		Actions Actions
+{{- end }}
+{{- if hasLinks .Links }}
+		Links struct {
+{{- range .Links }}
+{{- if .InLinks }}
+			{{ .Property }} common.Link{{ if .Many }}s{{ end }}
+{{- end }}
+{{- end }}
+		}
+{{- end }}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*{{ .Receiver }} = {{ .Name }}(t.temp)
+{{- if .Links }}
+
+	// Extract the links to other entities for later
+{{- range .Links }}
+	{{ $object.Receiver }}.{{ .Field }} = {{ if .Many }}t.{{ if .InLinks }}Links.{{ end }}{{ .Property }}.ToStrings(){{ else }}string(t.{{ if .InLinks }}Links.{{ end }}{{ .Property }}){{ end }}
+{{- end }}
+{{- end }}
+{{- range .Actions }}
+	{{ $object.Receiver }}.{{ .Field }} = t.Actions.{{ .Name }}.Target
+{{- end }}
+
+	// This is a read/write object, so we need to save the raw object data for later
+	{{ .Receiver }}.rawData = b
+	{{ .Receiver }}.SetActions(common.ParseActions(b))
+
+	return nil
+}
+
+// MarshalJSON marshals the {{ .Receiver }} to JSON, keeping the properties of
+// the raw JSON that are not modeled.
+func ({{ .Receiver }} {{ .Name }}) MarshalJSON() ([]byte, error) {
+	type temp {{ .Name }}
+	return common.MarshalWithRawData({{ .Receiver }}.rawData, temp({{ .Receiver }}))
+}
+{{- if .ReadWrite }}
+
+// Update commits updates to this object's properties to the running system.
+func ({{ .Receiver }} *{{ .Name }}) Update() error {
+
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new({{ .Name }})
+	original.UnmarshalJSON({{ .Receiver }}.rawData)
+
+	readWriteFields := []string{
+{{- range .ReadWrite }}
+		"{{ . }}",
+{{- end }}
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf({{ .Receiver }}).Elem()
+
+	return {{ .Receiver }}.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+{{- end }}
+{{- if .Resource }}
+
+// Get{{ .Name }} will get a {{ .Name }} instance from the service.
+func Get{{ .Name }}(c common.Client, uri string) (*{{ .Name }}, error) {
+	var {{ .Receiver }} {{ .Name }}
+	err := common.FetchResource(c, uri, &{{ .Receiver }})
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{ .Receiver }}, nil
+}
+
+// ListReferenced{{ plural .Name }} gets the collection of {{ .Name }} from
+// a provided reference.
+func ListReferenced{{ plural .Name }}(c common.Client, link string) ([]*{{ .Name }}, error) {
+	var result []*{{ .Name }}
+	if link == "" {
+		return result, nil
+	}
+
+	links, err := common.GetCollection(c, link)
+	if err != nil {
+		return result, err
+	}
+
+	for _, {{ .Receiver }}Link := range links.ItemLinks {
+		{{ .Receiver }} := new({{ .Name }})
+		expanded, err := links.DecodeMember(c, {{ .Receiver }}Link, {{ .Receiver }})
+		if err == nil && !expanded {
+			{{ .Receiver }}, err = Get{{ .Name }}(c, {{ .Receiver }}Link)
+		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, {{ .Receiver }})
+	}
+
+	return result, nil
+}
+{{- end }}
+{{- range .Links }}
+{{- if .Accessor }}
+{{- if .Collection }}
+
+// {{ .Accessor }} gets the {{ .Type }} collection of this {{ $object.Receiver }}.
+func ({{ $object.Receiver }} *{{ $object.Name }}) {{ .Accessor }}() ([]*{{ .Type }}, error) {
+	return {{ .Get }}({{ $object.Receiver }}.Client, {{ $object.Receiver }}.{{ .Field }})
+}
+{{- else if .Many }}
+
+// {{ .Accessor }} gets the {{ .Type }} resources linked from this {{ $object.Receiver }}.
+func ({{ $object.Receiver }} *{{ $object.Name }}) {{ .Accessor }}() ([]*{{ .Type }}, error) {
+	var result []*{{ .Type }}
+	for _, {{ .Variable }}Link := range {{ $object.Receiver }}.{{ .Field }} {
+		{{ .Variable }}, err := {{ .Get }}({{ $object.Receiver }}.Client, {{ .Variable }}Link)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, {{ .Variable }})
+	}
+
+	return result, nil
+}
+{{- else }}
+
+// {{ .Accessor }} gets the {{ .Type }} linked from this {{ $object.Receiver }}.
+func ({{ $object.Receiver }} *{{ $object.Name }}) {{ .Accessor }}() (*{{ .Type }}, error) {
+	if {{ $object.Receiver }}.{{ .Field }} == "" {
+		return nil, nil
+	}
+
+	return {{ .Get }}({{ $object.Receiver }}.Client, {{ $object.Receiver }}.{{ .Field }})
+}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{ end }}
+`))
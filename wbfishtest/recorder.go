@@ -0,0 +1,288 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfishtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/common"
+)
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = common.Redacted
+
+// Interaction is a recorded request and the response of the service.
+type Interaction struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URL of the request.
+	URL string
+	// RequestBody is the JSON payload of the request, with secrets redacted.
+	RequestBody string `json:",omitempty"`
+	// Status is the HTTP status code of the response. It is 0 if the request
+	// failed without a response.
+	Status int
+	// Header holds the headers of the response, with secrets redacted.
+	Header http.Header `json:",omitempty"`
+	// ResponseBody is the body of the response, with secrets redacted.
+	ResponseBody string `json:",omitempty"`
+	// Error is the error of a request that failed without a response.
+	Error string `json:",omitempty"`
+}
+
+// fixture is the file format of recorded interactions.
+type fixture struct {
+	Interactions []Interaction
+}
+
+// Recorder is a client that records the requests sent through another client
+// and the responses of the service, to build test fixtures from the
+// behavior of real services.
+type Recorder struct {
+	client common.Client
+
+	lock         sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder creates a recorder sending its requests through client.
+func NewRecorder(client common.Client) *Recorder {
+	return &Recorder{client: client}
+}
+
+// Get performs a GET request and records it.
+func (recorder *Recorder) Get(url string) (*http.Response, error) {
+	resp, err := recorder.client.Get(url)
+	return recorder.record(http.MethodGet, url, nil, resp, err)
+}
+
+// Post performs a POST request and records it.
+func (recorder *Recorder) Post(url string, payload interface{}) (*http.Response, error) {
+	resp, err := recorder.client.Post(url, payload)
+	return recorder.record(http.MethodPost, url, payload, resp, err)
+}
+
+// Put performs a PUT request and records it.
+func (recorder *Recorder) Put(url string, payload interface{}) (*http.Response, error) {
+	resp, err := recorder.client.Put(url, payload)
+	return recorder.record(http.MethodPut, url, payload, resp, err)
+}
+
+// Patch performs a PATCH request and records it.
+func (recorder *Recorder) Patch(url string, payload interface{}) (*http.Response, error) {
+	resp, err := recorder.client.Patch(url, payload)
+	return recorder.record(http.MethodPatch, url, payload, resp, err)
+}
+
+// Delete performs a DELETE request and records it.
+func (recorder *Recorder) Delete(url string) error {
+	err := recorder.client.Delete(url)
+	_, err = recorder.record(http.MethodDelete, url, nil, nil, err)
+	return err
+}
+
+// Interactions gets the recorded interactions, in order.
+func (recorder *Recorder) Interactions() []Interaction {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	return append([]Interaction(nil), recorder.interactions...)
+}
+
+// Save writes the recorded interactions to a file, to be loaded with
+// LoadReplayer.
+func (recorder *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(fixture{Interactions: recorder.Interactions()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// record records an interaction. The body of the response is read and
+// replaced, so the caller can still read it.
+func (recorder *Recorder) record(method, url string, payload interface{}, resp *http.Response, err error) (*http.Response, error) {
+	interaction := Interaction{
+		Method: method,
+		URL:    common.RedactURI(url),
+	}
+
+	if payload != nil {
+		body, marshalErr := json.Marshal(payload)
+		if marshalErr == nil {
+			interaction.RequestBody = string(common.RedactBody(body))
+		}
+	}
+
+	var wrongResponse wbfish.ErrorWrongResponse
+	switch {
+	case errors.As(err, &wrongResponse):
+		interaction.Status = wrongResponse.Code
+		interaction.ResponseBody = string(common.RedactBody(wrongResponse.Payload))
+	case err != nil:
+		interaction.Error = common.RedactURI(err.Error())
+	case resp != nil:
+		interaction.Status = resp.StatusCode
+		interaction.Header = common.RedactHeader(resp.Header)
+		if resp.Body != nil {
+			body, readErr := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				return nil, readErr
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			interaction.ResponseBody = string(common.RedactBody(body))
+		}
+	default:
+		interaction.Status = http.StatusNoContent
+	}
+
+	recorder.lock.Lock()
+	recorder.interactions = append(recorder.interactions, interaction)
+	recorder.lock.Unlock()
+
+	return resp, err
+}
+
+// Replayer is a client that serves recorded interactions back instead of
+// connecting to a service. Requests are matched by method and URL, and
+// interactions with the same method and URL are served in the order they were
+// recorded; the last one is repeated once they are used up.
+type Replayer struct {
+	lock         sync.Mutex
+	interactions map[string][]Interaction
+	requests     []Interaction
+}
+
+// NewReplayer creates a replayer serving interactions.
+func NewReplayer(interactions []Interaction) *Replayer {
+	replayer := &Replayer{
+		interactions: make(map[string][]Interaction),
+	}
+	for _, interaction := range interactions {
+		key := interaction.Method + " " + interaction.URL
+		replayer.interactions[key] = append(replayer.interactions[key], interaction)
+	}
+
+	return replayer
+}
+
+// LoadReplayer creates a replayer serving the interactions saved to a file by
+// a Recorder.
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f fixture
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(f.Interactions), nil
+}
+
+// Get replays a GET request.
+func (replayer *Replayer) Get(url string) (*http.Response, error) {
+	return replayer.replay(http.MethodGet, url, nil)
+}
+
+// Post replays a POST request.
+func (replayer *Replayer) Post(url string, payload interface{}) (*http.Response, error) {
+	return replayer.replay(http.MethodPost, url, payload)
+}
+
+// Put replays a PUT request.
+func (replayer *Replayer) Put(url string, payload interface{}) (*http.Response, error) {
+	return replayer.replay(http.MethodPut, url, payload)
+}
+
+// Patch replays a PATCH request.
+func (replayer *Replayer) Patch(url string, payload interface{}) (*http.Response, error) {
+	return replayer.replay(http.MethodPatch, url, payload)
+}
+
+// Delete replays a DELETE request.
+func (replayer *Replayer) Delete(url string) error {
+	resp, err := replayer.replay(http.MethodDelete, url, nil)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+// Requests gets the requests made to the replayer, in order, with secrets
+// redacted from their payloads.
+func (replayer *Replayer) Requests() []Interaction {
+	replayer.lock.Lock()
+	defer replayer.lock.Unlock()
+
+	return append([]Interaction(nil), replayer.requests...)
+}
+
+// replay serves the next recorded interaction for a request.
+func (replayer *Replayer) replay(method, url string, payload interface{}) (*http.Response, error) {
+	// Session URIs are redacted when they are recorded, so requests are
+	// matched by their redacted URL.
+	request := Interaction{
+		Method: method,
+		URL:    common.RedactURI(url),
+	}
+	if payload != nil {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		request.RequestBody = string(common.RedactBody(body))
+	}
+
+	replayer.lock.Lock()
+	replayer.requests = append(replayer.requests, request)
+	key := method + " " + request.URL
+	queue := replayer.interactions[key]
+	if len(queue) == 0 {
+		replayer.lock.Unlock()
+		return nil, fmt.Errorf("no recorded interaction for %s", key)
+	}
+	interaction := queue[0]
+	if len(queue) > 1 {
+		replayer.interactions[key] = queue[1:]
+	}
+	replayer.lock.Unlock()
+
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+
+	if interaction.Status != 200 && interaction.Status != 201 && interaction.Status != 202 && interaction.Status != 204 {
+		return nil, wbfish.ErrorWrongResponse{
+			Code:    interaction.Status,
+			Payload: []byte(interaction.ResponseBody),
+		}
+	}
+
+	header := make(http.Header)
+	for key, values := range interaction.Header {
+		header[key] = append([]string(nil), values...)
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode: interaction.Status,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(interaction.ResponseBody)),
+	}, nil
+}
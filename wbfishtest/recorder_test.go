@@ -0,0 +1,201 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfishtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+// TestRecordReplay tests recording interactions with a service and serving
+// them back.
+func TestRecordReplay(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	recorder := NewRecorder(client)
+
+	system, err := redfish.GetComputerSystem(recorder, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+
+	auth, err := redfish.CreateSession(recorder, "/redfish/v1/SessionService/Sessions", "admin", "secret")
+	if err != nil {
+		t.Fatalf("Error creating session: %s", err)
+	}
+
+	if auth.Token == Redacted {
+		t.Error("The recorder should not change live responses")
+	}
+
+	_, err = redfish.GetComputerSystem(recorder, "/redfish/v1/Systems/2")
+	if err == nil {
+		t.Error("Missing resources should not be found")
+	}
+
+	interactions := recorder.Interactions()
+	if len(interactions) != 3 {
+		t.Fatalf("Expected three interactions, recorded: %v", interactions)
+	}
+
+	if strings.Contains(interactions[1].RequestBody, "secret") {
+		t.Errorf("The password should be redacted: %s", interactions[1].RequestBody)
+	}
+
+	if interactions[1].Header.Get("X-Auth-Token") != Redacted {
+		t.Errorf("The token should be redacted: %v", interactions[1].Header)
+	}
+
+	dir, err := ioutil.TempDir("", "wbfishtest")
+	if err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fixture.json")
+	err = recorder.Save(path)
+	if err != nil {
+		t.Fatalf("Error saving interactions: %s", err)
+	}
+
+	replayer, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatalf("Error loading interactions: %s", err)
+	}
+
+	replayed, err := redfish.GetComputerSystem(replayer, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error replaying system: %s", err)
+	}
+
+	if replayed.ID != system.ID || replayed.PowerState != system.PowerState {
+		t.Errorf("Unexpected replayed system: %s %s", replayed.ID, replayed.PowerState)
+	}
+
+	auth, err = redfish.CreateSession(replayer, "/redfish/v1/SessionService/Sessions", "admin", "secret")
+	if err != nil {
+		t.Fatalf("Error replaying session: %s", err)
+	}
+
+	if auth.Token != Redacted || auth.Session != "/redfish/v1/SessionService/Sessions/"+Redacted {
+		t.Errorf("Unexpected replayed session: %+v", auth)
+	}
+
+	_, err = redfish.GetComputerSystem(replayer, "/redfish/v1/Systems/2")
+	if wrong, ok := err.(wbfish.ErrorWrongResponse); !ok || wrong.Code != 404 {
+		t.Errorf("Expected the recorded 404 error, got: %v", err)
+	}
+
+	_, err = replayer.Get("/redfish/v1/Chassis")
+	if err == nil {
+		t.Error("Requests that were not recorded should fail")
+	}
+}
+
+// TestRecordKeys tests that keys and other credentials are redacted when
+// they are recorded.
+func TestRecordKeys(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	err := server.SetResourceJSON("/redfish/v1/KeyService", []byte(`{
+		"@odata.id": "/redfish/v1/KeyService",
+		"Id": "KeyService",
+		"Name": "Key Service",
+		"NVMeoFSecrets": {
+			"@odata.id": "/redfish/v1/KeyService/NVMeoFSecrets"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Error adding key service: %s", err)
+	}
+
+	err = server.SetResourceJSON("/redfish/v1/KeyService/NVMeoFSecrets", []byte(`{
+		"@odata.id": "/redfish/v1/KeyService/NVMeoFSecrets",
+		"Name": "NVMe-oF Secrets",
+		"Members@odata.count": 0,
+		"Members": []
+	}`))
+	if err != nil {
+		t.Fatalf("Error adding secrets: %s", err)
+	}
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	recorder := NewRecorder(client)
+
+	keyService, err := redfish.GetKeyService(recorder, "/redfish/v1/KeyService")
+	if err != nil {
+		t.Fatalf("Error getting key service: %s", err)
+	}
+
+	secret := "DHHC-1:00:ia6zGodOr4SEG0Zzaw398rpY0wqipUWj4jWjUh4HWUz6aQ2n:"
+	err = keyService.CreateNVMeoFSecret(&redfish.Key{KeyString: secret})
+	if err != nil {
+		t.Fatalf("Error creating secret: %s", err)
+	}
+
+	interactions := recorder.Interactions()
+	if len(interactions) != 2 {
+		t.Fatalf("Expected two interactions, recorded: %v", interactions)
+	}
+
+	created := interactions[1]
+	if created.Method != "POST" || !strings.Contains(created.RequestBody, Redacted) {
+		t.Errorf("Unexpected secret request: %+v", created)
+	}
+
+	if strings.Contains(created.RequestBody, secret) || strings.Contains(created.ResponseBody, secret) {
+		t.Errorf("The key should be redacted: %+v", created)
+	}
+}
+
+// TestDumpRedaction tests that the HTTP dumps of a client do not carry its
+// credentials, tokens or session URIs.
+func TestDumpRedaction(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client, err := wbfish.Connect(wbfish.ClientConfig{
+		Endpoint:   server.URL,
+		Username:   "admin",
+		Password:   "s3cretPassw0rd",
+		DumpWriter: &dump,
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	client.Logout()
+
+	text := dump.String()
+	if !strings.Contains(text, "POST /redfish/v1/SessionService/Sessions") {
+		t.Fatalf("The session should be dumped: %s", text)
+	}
+
+	if strings.Contains(text, "s3cretPassw0rd") {
+		t.Errorf("The password should be redacted: %s", text)
+	}
+
+	if !strings.Contains(text, "X-Auth-Token: "+Redacted) || strings.Contains(text, "/SessionService/Sessions/1") {
+		t.Errorf("The token and the session URI should be redacted: %s", text)
+	}
+}
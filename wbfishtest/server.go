@@ -0,0 +1,537 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package wbfishtest provides a mock Redfish service for testing code built
+// on the library without hardware.
+//
+// The server serves a tree of resources, loaded from a mockup directory or
+// given directly, over an httptest server. Without scripted behavior it acts
+// like a simple service: GET with $expand expands the members of a
+// collection, PATCH merges the payload into the resource, POST to a
+// collection creates a member, POST to an action target succeeds and DELETE
+// removes the resource. Every resource has an ETag that is checked against
+// If-Match and If-None-Match headers. Scripted handlers replace the default
+// behavior for a method and path, and NewTask simulates long running
+// operations.
+package wbfishtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/common"
+)
+
+// Request is a request received by the server.
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// Path is the path of the request, without a trailing slash.
+	Path string
+	// Query holds the query parameters of the request, such as $expand.
+	Query url.Values
+	// Header holds the headers of the request.
+	Header http.Header
+	// Body is the body of the request.
+	Body []byte
+}
+
+// Unmarshal unmarshals the body of the request.
+func (request *Request) Unmarshal(v interface{}) error {
+	return json.Unmarshal(request.Body, v)
+}
+
+// Response is the response of a scripted handler.
+type Response struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// Header holds headers to add to the response.
+	Header http.Header
+	// Body is marshaled to JSON as the body of the response, unless it is
+	// nil.
+	Body interface{}
+}
+
+// HandlerFunc is a scripted behavior of the server.
+type HandlerFunc func(server *Server, request *Request) *Response
+
+// Server is a mock Redfish service.
+type Server struct {
+	// URL is the base URL of the service, to connect clients to.
+	URL string
+
+	httpServer *httptest.Server
+
+	lock      sync.Mutex
+	resources map[string]map[string]interface{}
+	handlers  map[string]HandlerFunc
+	requests  []Request
+	tasks     map[string]*task
+	nextID    int
+}
+
+// NewServer starts a server serving the given resources, keyed by their URI.
+// The resources are JSON documents.
+func NewServer(resources map[string]string) (*Server, error) {
+	server := newServer()
+	for uri, document := range resources {
+		err := server.SetResourceJSON(uri, []byte(document))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	server.start()
+	return server, nil
+}
+
+// NewServerFromDirectory starts a server serving a mockup directory, as
+// written by the DMTF Redfish mockup creator, of index.json files. The
+// directory holds either the redfish/v1 tree or the contents of the service
+// root directly.
+func NewServerFromDirectory(dir string) (*Server, error) {
+	server := newServer()
+
+	root := dir
+	prefix := strings.TrimSuffix(common.DefaultServiceRoot, "/")
+	if _, err := os.Stat(filepath.Join(dir, "redfish", "v1", "index.json")); err == nil {
+		prefix = ""
+	}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "index.json" {
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		uri := prefix
+		if relative != "." {
+			uri += "/" + filepath.ToSlash(relative)
+		}
+
+		document, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return server.SetResourceJSON(uri, document)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server.start()
+	return server, nil
+}
+
+// newServer creates a server that is not started.
+func newServer() *Server {
+	return &Server{
+		resources: make(map[string]map[string]interface{}),
+		handlers:  make(map[string]HandlerFunc),
+		tasks:     make(map[string]*task),
+		nextID:    1,
+	}
+}
+
+// start starts serving requests.
+func (server *Server) start() {
+	server.httpServer = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	server.URL = server.httpServer.URL
+}
+
+// Close shuts down the server.
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// Connect creates an unauthenticated client connection to the server.
+func (server *Server) Connect() (*wbfish.APIClient, error) {
+	return wbfish.ConnectDefault(server.URL)
+}
+
+// Handle scripts the behavior of the server for a method and path, replacing
+// the default behavior.
+func (server *Server) Handle(method, path string, handler HandlerFunc) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.handlers[method+" "+normalizePath(path)] = handler
+}
+
+// Requests gets the requests received by the server, in order.
+func (server *Server) Requests() []Request {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return append([]Request(nil), server.requests...)
+}
+
+// Resource gets a copy of a resource, or nil if it does not exist.
+func (server *Server) Resource(uri string) map[string]interface{} {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	resource, ok := server.resources[normalizePath(uri)]
+	if !ok {
+		return nil
+	}
+
+	return copyObject(resource)
+}
+
+// SetResource adds or replaces a resource.
+func (server *Server) SetResource(uri string, resource map[string]interface{}) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.resources[normalizePath(uri)] = copyObject(resource)
+}
+
+// SetResourceJSON adds or replaces a resource from its JSON document.
+func (server *Server) SetResourceJSON(uri string, document []byte) error {
+	var resource map[string]interface{}
+	err := json.Unmarshal(document, &resource)
+	if err != nil {
+		return fmt.Errorf("invalid resource %s: %v", uri, err)
+	}
+
+	server.SetResource(uri, resource)
+	return nil
+}
+
+// ETag gets the ETag of a resource.
+func (server *Server) ETag(uri string) string {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return etag(server.resources[normalizePath(uri)])
+}
+
+// serveHTTP handles a request.
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request := &Request{
+		Method: r.Method,
+		Path:   normalizePath(r.URL.Path),
+		Query:  r.URL.Query(),
+		Header: r.Header,
+		Body:   body,
+	}
+
+	server.lock.Lock()
+	server.requests = append(server.requests, *request)
+	handler := server.handlers[request.Method+" "+request.Path]
+	server.lock.Unlock()
+
+	var response *Response
+	if handler != nil {
+		response = handler(server, request)
+	} else {
+		response = server.defaultHandler(request)
+	}
+
+	writeResponse(w, response)
+}
+
+// defaultHandler implements the behavior of the server for requests without
+// a scripted handler.
+func (server *Server) defaultHandler(request *Request) *Response {
+	var onComplete func()
+	defer func() {
+		if onComplete != nil {
+			onComplete()
+		}
+	}()
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	resource, exists := server.resources[request.Path]
+
+	if exists && request.Method != http.MethodGet && request.Method != http.MethodPost {
+		match := request.Header.Get("If-Match")
+		if match != "" && match != "*" && match != etag(resource) {
+			return errorResponse(http.StatusPreconditionFailed, "the resource has been changed")
+		}
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		if !exists {
+			return errorResponse(http.StatusNotFound, "the resource does not exist")
+		}
+		if t, ok := server.tasks[request.Path]; ok {
+			onComplete = t.poll(resource)
+		}
+
+		tag := etag(resource)
+		if request.Header.Get("If-None-Match") == tag {
+			return &Response{Status: http.StatusNotModified, Header: http.Header{"ETag": {tag}}}
+		}
+		resource = copyObject(resource)
+		if request.Query.Get("$expand") != "" {
+			server.expandMembers(resource)
+		}
+		return &Response{Status: http.StatusOK, Header: http.Header{"ETag": {tag}}, Body: resource}
+
+	case http.MethodPatch, http.MethodPut:
+		if !exists {
+			return errorResponse(http.StatusNotFound, "the resource does not exist")
+		}
+
+		var payload map[string]interface{}
+		err := request.Unmarshal(&payload)
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err.Error())
+		}
+
+		if request.Method == http.MethodPut {
+			payload["@odata.id"] = resource["@odata.id"]
+			resource = payload
+		} else {
+			mergePatch(resource, payload)
+		}
+		server.resources[request.Path] = resource
+		return &Response{Status: http.StatusOK, Header: http.Header{"ETag": {etag(resource)}}, Body: copyObject(resource)}
+
+	case http.MethodPost:
+		if exists {
+			if _, ok := resource["Members"]; ok {
+				return server.createMember(request, resource)
+			}
+		}
+		if strings.Contains(request.Path, "/Actions/") {
+			return &Response{Status: http.StatusNoContent}
+		}
+		return errorResponse(http.StatusMethodNotAllowed, "the resource does not support POST")
+
+	case http.MethodDelete:
+		if !exists {
+			return errorResponse(http.StatusNotFound, "the resource does not exist")
+		}
+		server.deleteResource(request.Path)
+		return &Response{Status: http.StatusNoContent}
+	}
+
+	return errorResponse(http.StatusMethodNotAllowed, "the method is not supported")
+}
+
+// createMember creates a member of a collection from the payload of a POST.
+// The caller holds the lock.
+func (server *Server) createMember(request *Request, collection map[string]interface{}) *Response {
+	var member map[string]interface{}
+	err := request.Unmarshal(&member)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	id, _ := member["Id"].(string)
+	for id == "" {
+		id = strconv.Itoa(server.nextID)
+		server.nextID++
+		if _, exists := server.resources[request.Path+"/"+id]; exists {
+			// Skip the IDs of the members the server was started with
+			id = ""
+		}
+	}
+	uri := request.Path + "/" + id
+	if _, exists := server.resources[uri]; exists {
+		return errorResponse(http.StatusConflict, "the resource already exists")
+	}
+
+	member["Id"] = id
+	member["@odata.id"] = uri
+	if _, ok := member["Name"]; !ok {
+		member["Name"] = id
+	}
+
+	header := http.Header{"Location": {uri}}
+	if strings.HasSuffix(request.Path, "/Sessions") {
+		// Sessions authenticate the client, so do not keep the password
+		delete(member, "Password")
+		header.Set("X-Auth-Token", fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(uri))))
+	}
+
+	server.resources[uri] = member
+	addMember(collection, uri)
+
+	header.Set("ETag", etag(member))
+	return &Response{Status: http.StatusCreated, Header: header, Body: copyObject(member)}
+}
+
+// deleteResource removes a resource and its references from collections. The
+// caller holds the lock.
+func (server *Server) deleteResource(uri string) {
+	delete(server.resources, uri)
+	delete(server.tasks, uri)
+
+	for _, resource := range server.resources {
+		members, ok := resource["Members"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var remaining []interface{}
+		for _, member := range members {
+			if link, ok := member.(map[string]interface{}); ok && link["@odata.id"] == uri {
+				continue
+			}
+			remaining = append(remaining, member)
+		}
+		if len(remaining) != len(members) {
+			if remaining == nil {
+				remaining = []interface{}{}
+			}
+			resource["Members"] = remaining
+			resource["Members@odata.count"] = len(remaining)
+		}
+	}
+}
+
+// addMember adds a link to the members of a collection.
+func addMember(collection map[string]interface{}, uri string) {
+	members, _ := collection["Members"].([]interface{})
+	members = append(members, map[string]interface{}{"@odata.id": uri})
+	collection["Members"] = members
+	collection["Members@odata.count"] = len(members)
+}
+
+// mergePatch applies a JSON merge patch to an object.
+func mergePatch(target, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		patchObject, isObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		if isObject && targetIsObject {
+			mergePatch(targetObject, patchObject)
+			continue
+		}
+
+		target[key] = value
+	}
+}
+
+// expandMembers replaces the member references of a collection with the
+// members, as $expand does.
+func (server *Server) expandMembers(collection map[string]interface{}) {
+	members, ok := collection["Members"].([]interface{})
+	if !ok {
+		return
+	}
+
+	for i, member := range members {
+		reference, _ := member.(map[string]interface{})
+		uri, _ := reference["@odata.id"].(string)
+		if resource, ok := server.resources[normalizePath(uri)]; ok {
+			members[i] = copyObject(resource)
+		}
+	}
+}
+
+// copyObject makes a deep copy of a JSON object.
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	if object == nil {
+		return nil
+	}
+
+	var result map[string]interface{}
+	data, _ := json.Marshal(object)
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+// etag computes the ETag of a resource from its content.
+func etag(resource map[string]interface{}) string {
+	data, _ := json.Marshal(resource)
+	return fmt.Sprintf("W/\"%08x\"", crc32.ChecksumIEEE(data))
+}
+
+// normalizePath removes the trailing slash of a path.
+func normalizePath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// errorResponse creates a response with a Redfish error body.
+func errorResponse(status int, message string) *Response {
+	return &Response{
+		Status: status,
+		Body: map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "Base.1.8.GeneralError",
+				"message": message,
+			},
+		},
+	}
+}
+
+// writeError writes a Redfish error.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeResponse(w, errorResponse(status, message))
+}
+
+// writeResponse writes a response.
+func writeResponse(w http.ResponseWriter, response *Response) {
+	if response == nil {
+		response = &Response{Status: http.StatusNoContent}
+	}
+
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if response.Body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(response.Body)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body.Reset()
+		fmt.Fprintf(&body, `{"error":{"code":"Base.1.8.InternalError","message":%q}}`, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,1662 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfishtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LRichi/WBfish"
+	"github.com/LRichi/WBfish/common"
+	"github.com/LRichi/WBfish/redfish"
+)
+
+var serviceRootBody = `{
+		"@odata.type": "#ServiceRoot.v1_5_0.ServiceRoot",
+		"@odata.id": "/redfish/v1",
+		"Id": "RootService",
+		"Name": "Root Service",
+		"RedfishVersion": "1.6.0",
+		"Systems": {
+			"@odata.id": "/redfish/v1/Systems"
+		},
+		"SessionService": {
+			"@odata.id": "/redfish/v1/SessionService"
+		},
+		"Links": {
+			"Sessions": {
+				"@odata.id": "/redfish/v1/SessionService/Sessions"
+			}
+		}
+	}`
+
+var systemsBody = `{
+		"@odata.type": "#ComputerSystemCollection.ComputerSystemCollection",
+		"@odata.id": "/redfish/v1/Systems",
+		"Name": "Computer System Collection",
+		"Members@odata.count": 1,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Systems/1"
+			}
+		]
+	}`
+
+var systemBody = `{
+		"@odata.type": "#ComputerSystem.v1_10_0.ComputerSystem",
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Name": "System",
+		"AssetTag": "",
+		"PowerState": "On",
+		"Boot": {
+			"BootSourceOverrideEnabled": "Disabled",
+			"BootSourceOverrideTarget": "None"
+		}
+	}`
+
+var sessionsBody = `{
+		"@odata.type": "#SessionCollection.SessionCollection",
+		"@odata.id": "/redfish/v1/SessionService/Sessions",
+		"Name": "Session Collection",
+		"Members@odata.count": 0,
+		"Members": []
+	}`
+
+func newTestServer(t *testing.T) *Server {
+	server, err := NewServer(map[string]string{
+		"/redfish/v1":                         serviceRootBody,
+		"/redfish/v1/Systems":                 systemsBody,
+		"/redfish/v1/Systems/1":               systemBody,
+		"/redfish/v1/SessionService/Sessions": sessionsBody,
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+
+	return server
+}
+
+// TestServerGet tests reading resources through the client.
+func TestServerGet(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systems, err := client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+
+	if len(systems) != 1 || systems[0].PowerState != redfish.OnPowerState {
+		t.Fatalf("Unexpected systems: %v", systems)
+	}
+
+	var raw map[string]interface{}
+	err = json.Unmarshal(systems[0].GetRawData(), &raw)
+	if err != nil || raw["@odata.id"] != "/redfish/v1/Systems/1" {
+		t.Errorf("The raw JSON of the system should be kept: %s", systems[0].GetRawData())
+	}
+
+	_, err = redfish.GetComputerSystem(client, "/redfish/v1/Systems/2")
+	if err == nil {
+		t.Error("Missing resources should not be found")
+	}
+}
+
+// TestServerMarshal tests that resources read through the client can be
+// marshaled back to JSON.
+func TestServerMarshal(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	data, err := json.Marshal(client.Service)
+	if err != nil {
+		t.Fatalf("Error marshaling the service root: %s", err)
+	}
+	if !strings.Contains(string(data), `"RedfishVersion":"1.6.0"`) {
+		t.Errorf("Unexpected service root JSON: %s", data)
+	}
+
+	systems, err := client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+
+	data, err = json.Marshal(systems[0])
+	if err != nil {
+		t.Fatalf("Error marshaling the system: %s", err)
+	}
+	if strings.Contains(string(data), `"Client"`) {
+		t.Errorf("The client should not be marshaled: %s", data)
+	}
+	if !strings.Contains(string(data), `"PowerState":"On"`) {
+		t.Errorf("Unexpected system JSON: %s", data)
+	}
+}
+
+// TestServerExpandedMembers tests that members a collection returns inline
+// are not read again.
+func TestServerExpandedMembers(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Systems", []byte(`{
+		"@odata.id": "/redfish/v1/Systems",
+		"Name": "Computer System Collection",
+		"Members@odata.count": 1,
+		"Members": [`+systemBody+`]
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systems, err := redfish.ListReferencedComputerSystems(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+
+	if len(systems) != 1 || systems[0].PowerState != redfish.OnPowerState {
+		t.Errorf("Unexpected systems: %v", systems)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Path == "/redfish/v1/Systems/1" {
+			t.Error("The expanded system should not have been read")
+		}
+	}
+}
+
+// TestServerPatch tests that PATCH requests change resources.
+func TestServerPatch(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	system, err := redfish.GetComputerSystem(client, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+
+	before := server.ETag("/redfish/v1/Systems/1")
+
+	system.AssetTag = "rack12-u4"
+	err = system.Update()
+	if err != nil {
+		t.Errorf("Error updating system: %s", err)
+	}
+
+	if server.Resource("/redfish/v1/Systems/1")["AssetTag"] != "rack12-u4" {
+		t.Errorf("System was not updated: %v", server.Resource("/redfish/v1/Systems/1"))
+	}
+
+	if server.ETag("/redfish/v1/Systems/1") == before {
+		t.Error("The ETag should change with the resource")
+	}
+
+	requests := server.Requests()
+	last := requests[len(requests)-1]
+	if last.Method != http.MethodPatch || last.Path != "/redfish/v1/Systems/1" {
+		t.Errorf("Unexpected last request: %s %s", last.Method, last.Path)
+	}
+}
+
+// TestServerETag tests that stale If-Match headers are rejected.
+func TestServerETag(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodDelete, server.URL+"/redfish/v1/Systems/1", nil)
+	request.Header.Set("If-Match", `W/"stale"`)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Error sending request: %s", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("Unexpected status: %d", response.StatusCode)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, server.URL+"/redfish/v1/Systems/1", nil)
+	request.Header.Set("If-None-Match", server.ETag("/redfish/v1/Systems/1"))
+	response, err = http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Error sending request: %s", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusNotModified {
+		t.Errorf("Unexpected status: %d", response.StatusCode)
+	}
+}
+
+// TestServerSessions tests creating and deleting members of a collection.
+func TestServerSessions(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	auth, err := client.Service.CreateSession("admin", "secret")
+	if err != nil {
+		t.Fatalf("Error creating session: %s", err)
+	}
+
+	if auth.Token == "" || auth.Session != "/redfish/v1/SessionService/Sessions/1" {
+		t.Errorf("Unexpected session: %+v", auth)
+	}
+
+	if _, ok := server.Resource(auth.Session)["Password"]; ok {
+		t.Error("The session should not keep the password")
+	}
+
+	err = client.Service.DeleteSession(auth.Session)
+	if err != nil {
+		t.Errorf("Error deleting session: %s", err)
+	}
+
+	if server.Resource(auth.Session) != nil {
+		t.Error("The session should have been deleted")
+	}
+
+	if server.Resource("/redfish/v1/SessionService/Sessions")["Members@odata.count"] != float64(0) {
+		t.Errorf("The session should have been removed from the collection: %v",
+			server.Resource("/redfish/v1/SessionService/Sessions"))
+	}
+}
+
+// TestServerTask tests scripted handlers returning simulated tasks.
+func TestServerTask(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	completed := false
+	server.Handle(http.MethodPost, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+		func(server *Server, request *Request) *Response {
+			return server.NewTask(2, func() {
+				server.SetResourceJSON("/redfish/v1/Systems/1", []byte(`{"@odata.id": "/redfish/v1/Systems/1", "PowerState": "Off"}`))
+				completed = true
+			})
+		})
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	response, err := client.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", map[string]string{"ResetType": "ForceOff"})
+	if err != nil {
+		t.Fatalf("Error posting reset: %s", err)
+	}
+	response.Body.Close()
+
+	location := response.Header.Get("Location")
+	if response.StatusCode != http.StatusAccepted || location == "" {
+		t.Fatalf("Unexpected reset response: %d %s", response.StatusCode, location)
+	}
+
+	task, err := redfish.GetTask(client, location)
+	if err != nil {
+		t.Fatalf("Error getting task: %s", err)
+	}
+
+	if task.TaskState != redfish.RunningTaskState || task.PercentComplete != 50 || completed {
+		t.Errorf("Task should be half done: %s %d", task.TaskState, task.PercentComplete)
+	}
+
+	task, err = redfish.GetTask(client, location)
+	if err != nil {
+		t.Fatalf("Error getting task: %s", err)
+	}
+
+	if task.TaskState != redfish.CompletedTaskState || !completed {
+		t.Errorf("Task should be completed: %s", task.TaskState)
+	}
+
+	if server.Resource("/redfish/v1/Systems/1")["PowerState"] != "Off" {
+		t.Error("The completion callback should have powered off the system")
+	}
+}
+
+// TestNewServerFromDirectory tests serving a mockup directory.
+func TestNewServerFromDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wbfishtest")
+	if err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"index.json":                         serviceRootBody,
+		"Systems/index.json":                 systemsBody,
+		"Systems/1/index.json":               systemBody,
+		"SessionService/Sessions/index.json": sessionsBody,
+	}
+	for name, document := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		err = ioutil.WriteFile(path, []byte(document), 0644)
+		if err != nil {
+			t.Fatalf("Error writing mockup: %s", err)
+		}
+	}
+
+	server, err := NewServerFromDirectory(dir)
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+
+	if server.Resource("/redfish/v1/Systems/1") == nil {
+		t.Error("The system should have been loaded from the mockup")
+	}
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systems, err := client.Service.Systems()
+	if err != nil || len(systems) != 1 {
+		t.Errorf("Unexpected systems: %v %v", systems, err)
+	}
+}
+
+// TestServerFirmwareUpdate tests pushing a firmware image with a multipart
+// request and waiting for the update.
+func TestServerFirmwareUpdate(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/UpdateService", []byte(`{
+		"@odata.id": "/redfish/v1/UpdateService",
+		"Id": "UpdateService",
+		"MultipartHttpPushUri": "/redfish/v1/UpdateService/upload",
+		"FirmwareInventory": {"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/UpdateService/FirmwareInventory", []byte(`{
+		"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory",
+		"Members": [{"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC"}],
+		"Members@odata.count": 1
+	}`))
+	server.SetResourceJSON("/redfish/v1/UpdateService/FirmwareInventory/BMC", []byte(`{
+		"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC",
+		"Id": "BMC",
+		"Version": "1.0"
+	}`))
+
+	var image, parameters string
+	server.Handle(http.MethodPost, "/redfish/v1/UpdateService/upload",
+		func(server *Server, request *Request) *Response {
+			_, params, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+			if err != nil {
+				return &Response{Status: http.StatusBadRequest}
+			}
+			form, err := multipart.NewReader(bytes.NewReader(request.Body), params["boundary"]).ReadForm(1 << 20)
+			if err != nil {
+				return &Response{Status: http.StatusBadRequest}
+			}
+			parameters = strings.Join(form.Value["UpdateParameters"], "")
+			if files := form.File["UpdateFile"]; len(files) == 1 {
+				file, _ := files[0].Open()
+				content, _ := ioutil.ReadAll(file)
+				image = files[0].Filename + ":" + string(content)
+			}
+
+			return server.NewTask(2, func() {
+				server.SetResourceJSON("/redfish/v1/UpdateService/FirmwareInventory/BMC", []byte(`{
+					"@odata.id": "/redfish/v1/UpdateService/FirmwareInventory/BMC",
+					"Id": "BMC",
+					"Version": "2.0"
+				}`))
+			})
+		})
+
+	dir, err := ioutil.TempDir("", "wbfishtest")
+	if err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "bmc.bin")
+	err = ioutil.WriteFile(path, []byte("firmware"), 0600)
+	if err != nil {
+		t.Fatalf("Error writing image: %s", err)
+	}
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	updateService, err := redfish.GetUpdateService(client, "/redfish/v1/UpdateService")
+	if err != nil {
+		t.Fatalf("Error getting update service: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	task, err := updateService.UpdateFirmware(ctx, path, redfish.FirmwareUpdateOptions{
+		ApplyTime:       common.ImmediateOperationApplyTime,
+		ExpectedVersion: "2.0",
+		Wait:            redfish.TaskWaitOptions{Interval: time.Millisecond},
+		RebootTimeout:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Error updating firmware: %s", err)
+	}
+
+	if task.TaskState != redfish.CompletedTaskState {
+		t.Errorf("Invalid task state: %s", task.TaskState)
+	}
+
+	if image != "bmc.bin:firmware" {
+		t.Errorf("Invalid pushed image: %s", image)
+	}
+
+	if !strings.Contains(parameters, `"@Redfish.OperationApplyTime":"Immediate"`) {
+		t.Errorf("Invalid update parameters: %s", parameters)
+	}
+}
+
+// TestServerEraseAllDrives tests erasing the drives of a system for
+// decommissioning.
+func TestServerEraseAllDrives(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Systems/1", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1",
+		"Id": "1",
+		"Storage": {"@odata.id": "/redfish/v1/Systems/1/Storage"},
+		"SimpleStorage": {"@odata.id": "/redfish/v1/Systems/1/SimpleStorage"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/Systems/1/Storage", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/Storage",
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/1/Storage/RAID"},
+			{"@odata.id": "/redfish/v1/Systems/1/Storage/NVMe"}
+		],
+		"Members@odata.count": 2
+	}`))
+	server.SetResourceJSON("/redfish/v1/Systems/1/Storage/RAID", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/RAID",
+		"Id": "RAID",
+		"Drives": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Drives/0"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Drives/1"}
+		]
+	}`))
+	server.SetResourceJSON("/redfish/v1/Systems/1/Storage/NVMe", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/Storage/NVMe",
+		"Id": "NVMe",
+		"Drives": [
+			{"@odata.id": "/redfish/v1/Chassis/1/Drives/1"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Drives/2"},
+			{"@odata.id": "/redfish/v1/Chassis/1/Drives/3"}
+		]
+	}`))
+	for i, serial := range []string{"S0", "S1", "S2"} {
+		server.SetResourceJSON(fmt.Sprintf("/redfish/v1/Chassis/1/Drives/%d", i), []byte(fmt.Sprintf(`{
+			"@odata.id": "/redfish/v1/Chassis/1/Drives/%d",
+			"Id": "%d",
+			"SerialNumber": "%s",
+			"Actions": {
+				"#Drive.SecureErase": {
+					"target": "/redfish/v1/Chassis/1/Drives/%d/Actions/Drive.SecureErase"
+				}
+			}
+		}`, i, i, serial, i)))
+	}
+	server.SetResourceJSON("/redfish/v1/Chassis/1/Drives/3", []byte(`{
+		"@odata.id": "/redfish/v1/Chassis/1/Drives/3",
+		"Id": "3",
+		"SerialNumber": "S3"
+	}`))
+	server.SetResourceJSON("/redfish/v1/Systems/1/SimpleStorage", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/SimpleStorage",
+		"Members": [{"@odata.id": "/redfish/v1/Systems/1/SimpleStorage/SATA"}],
+		"Members@odata.count": 1
+	}`))
+	server.SetResourceJSON("/redfish/v1/Systems/1/SimpleStorage/SATA", []byte(`{
+		"@odata.id": "/redfish/v1/Systems/1/SimpleStorage/SATA",
+		"Id": "SATA",
+		"Devices": [{"Model": "SATA SSD"}]
+	}`))
+
+	server.Handle(http.MethodPost, "/redfish/v1/Chassis/1/Drives/0/Actions/Drive.SecureErase",
+		func(server *Server, request *Request) *Response {
+			return server.NewTask(2, nil)
+		})
+	server.Handle(http.MethodPost, "/redfish/v1/Chassis/1/Drives/2/Actions/Drive.SecureErase",
+		func(server *Server, request *Request) *Response {
+			return errorResponse(http.StatusBadRequest, "the drive is locked")
+		})
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	system, err := redfish.GetComputerSystem(client, "/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting system: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	results, err := system.EraseAllDrives(ctx, redfish.DriveEraseOptions{
+		Wait: redfish.TaskWaitOptions{Interval: time.Millisecond},
+	})
+	if err == nil {
+		t.Error("Erasing should fail for the locked drive")
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("Expected a result for each of the 5 drives: %v", results)
+	}
+
+	if !results[0].Erased() || results[0].Task.TaskState != redfish.CompletedTaskState || results[0].SerialNumber != "S0" {
+		t.Errorf("Drive 0 should be erased through its task: %v", results[0])
+	}
+
+	if !results[1].Erased() {
+		t.Errorf("Drive 1 should be erased, once: %v", results[1])
+	}
+
+	if results[2].Erased() || results[2].Err == nil {
+		t.Errorf("Drive 2 should fail to be erased: %v", results[2])
+	}
+
+	if results[3].Supported || results[3].Erased() || results[3].Err != nil {
+		t.Errorf("Drive 3 does not support SecureErase: %v", results[3])
+	}
+
+	if results[4].Drive != "/redfish/v1/Systems/1/SimpleStorage/SATA#/Devices/0" || results[4].Supported || results[4].Model != "SATA SSD" {
+		t.Errorf("Unexpected SimpleStorage device result: %v", results[4])
+	}
+}
+
+// testCertificate makes a self-signed certificate for a common name.
+func testCertificate(t *testing.T, commonName string) (string, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %s", err)
+	}
+
+	certificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return certificatePEM, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// TestServerRotateCertificate tests replacing the HTTPS certificate of a
+// manager with a newly signed one.
+func TestServerRotateCertificate(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	const certificateURI = "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates/1"
+	oldPEM, oldCertificate := testCertificate(t, "old.bmc.example.com")
+	newPEM, newCertificate := testCertificate(t, "bmc.example.com")
+
+	// The HTTPS endpoint of the manager, serving its current certificate
+	var lock sync.Mutex
+	served := oldCertificate
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			return &served, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error listening: %s", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	setCertificate := func(certificatePEM string) {
+		document, _ := json.Marshal(map[string]interface{}{
+			"@odata.id":         certificateURI,
+			"Id":                "1",
+			"CertificateString": certificatePEM,
+			"CertificateType":   "PEM",
+		})
+		server.SetResourceJSON(certificateURI, document)
+	}
+	setCertificate(oldPEM)
+	server.SetResourceJSON("/redfish/v1/CertificateService", []byte(`{
+		"@odata.id": "/redfish/v1/CertificateService",
+		"Id": "CertificateService",
+		"Actions": {
+			"#CertificateService.GenerateCSR": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR"
+			},
+			"#CertificateService.ReplaceCertificate": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+			}
+		}
+	}`))
+
+	var csrRequest struct {
+		CommonName            string
+		CertificateCollection common.Link
+	}
+	server.Handle(http.MethodPost, "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR",
+		func(server *Server, request *Request) *Response {
+			request.Unmarshal(&csrRequest)
+			return &Response{Status: http.StatusOK, Body: map[string]interface{}{
+				"CSRString": "CSR for " + csrRequest.CommonName,
+				"CertificateCollection": map[string]interface{}{
+					"@odata.id": string(csrRequest.CertificateCollection),
+				},
+			}}
+		})
+
+	rollBack := false
+	server.Handle(http.MethodPost, "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate",
+		func(server *Server, request *Request) *Response {
+			var replace struct {
+				CertificateString string
+				CertificateURI    common.Link `json:"CertificateUri"`
+			}
+			request.Unmarshal(&replace)
+			if string(replace.CertificateURI) != certificateURI || replace.CertificateString != newPEM {
+				return &Response{Status: http.StatusBadRequest}
+			}
+
+			if !rollBack {
+				setCertificate(replace.CertificateString)
+				lock.Lock()
+				served = newCertificate
+				lock.Unlock()
+			}
+			return &Response{Status: http.StatusNoContent}
+		})
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	certificateService, err := redfish.GetCertificateService(client, "/redfish/v1/CertificateService")
+	if err != nil {
+		t.Fatalf("Error getting certificate service: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var csr string
+	options := redfish.CertificateRotationOptions{
+		Certificate: certificateURI,
+		CSR:         redfish.GenerateCSRParameters{CommonName: "bmc.example.com"},
+		Sign: func(request string) (string, error) {
+			csr = request
+			return newPEM, nil
+		},
+		Address:        listener.Addr().String(),
+		VerifyTimeout:  50 * time.Millisecond,
+		VerifyInterval: time.Millisecond,
+	}
+
+	rollBack = true
+	_, err = certificateService.RotateCertificate(ctx, options)
+	if err == nil || !strings.Contains(err.Error(), "previous certificate") {
+		t.Errorf("Rotation should fail when the previous certificate is still served: %v", err)
+	}
+
+	rollBack = false
+	certificate, err := certificateService.RotateCertificate(ctx, options)
+	if err != nil {
+		t.Fatalf("Error rotating certificate: %s", err)
+	}
+
+	if certificate.CertificateString != newPEM {
+		t.Errorf("Unexpected new certificate: %s", certificate.CertificateString)
+	}
+
+	if csr != "CSR for bmc.example.com" {
+		t.Errorf("Unexpected signed CSR: %s", csr)
+	}
+
+	if string(csrRequest.CertificateCollection) != "/redfish/v1/Managers/1/NetworkProtocol/HTTPS/Certificates" {
+		t.Errorf("The CSR should default to the collection of the certificate: %s", csrRequest.CertificateCollection)
+	}
+}
+
+// TestServerEnsureAccount tests provisioning an account through the accounts
+// collection.
+func TestServerEnsureAccount(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/AccountService", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Id": "AccountService",
+		"Accounts": {"@odata.id": "/redfish/v1/AccountService/Accounts"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/AccountService/Accounts", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService/Accounts",
+		"Members": [{"@odata.id": "/redfish/v1/AccountService/Accounts/1"}],
+		"Members@odata.count": 1
+	}`))
+	server.SetResourceJSON("/redfish/v1/AccountService/Accounts/1", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService/Accounts/1",
+		"Id": "1",
+		"UserName": "admin",
+		"RoleId": "Administrator",
+		"Enabled": true
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	accountService, err := redfish.GetAccountService(client, "/redfish/v1/AccountService")
+	if err != nil {
+		t.Fatalf("Error getting account service: %s", err)
+	}
+
+	changes, err := accountService.EnsureAccount("automation", "secret", "Operator")
+	if err != nil {
+		t.Fatalf("Error ensuring account: %s", err)
+	}
+
+	if !changes.Created || changes.Account.UserName != "automation" || changes.Account.RoleID != "Operator" {
+		t.Errorf("The account should be created: %v", changes)
+	}
+
+	changes, err = accountService.EnsureAccount("automation", "", "Operator")
+	if err != nil {
+		t.Fatalf("Error ensuring account: %s", err)
+	}
+
+	if changes.Changed() {
+		t.Errorf("An account that did not drift should not be changed: %v", changes)
+	}
+
+	account := server.Resource(changes.Account.ODataID)
+	account["Enabled"] = false
+	server.SetResource(changes.Account.ODataID, account)
+
+	changes, err = accountService.EnsureAccount("automation", "", "Administrator")
+	if err != nil {
+		t.Fatalf("Error ensuring account: %s", err)
+	}
+
+	if changes.Created || !changes.RoleChanged || !changes.Enabled || changes.PasswordSet {
+		t.Errorf("Unexpected account changes: %v", changes)
+	}
+
+	account = server.Resource(changes.Account.ODataID)
+	if account["RoleId"] != "Administrator" || account["Enabled"] != true {
+		t.Errorf("The account should be updated: %v", account)
+	}
+}
+
+// TestServerEnsureAccountSlots tests provisioning an account on a service
+// with a fixed set of account slots.
+func TestServerEnsureAccountSlots(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/AccountService", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Id": "AccountService",
+		"Accounts": {"@odata.id": "/redfish/v1/AccountService/Accounts"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/AccountService/Accounts", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService/Accounts",
+		"Members": [
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/1"},
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/2"},
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/3"}
+		],
+		"Members@odata.count": 3
+	}`))
+	for _, id := range []string{"1", "2", "3"} {
+		server.SetResourceJSON("/redfish/v1/AccountService/Accounts/"+id, []byte(`{
+			"@odata.id": "/redfish/v1/AccountService/Accounts/`+id+`",
+			"Id": "`+id+`",
+			"UserName": "",
+			"RoleId": "None",
+			"Enabled": false
+		}`))
+	}
+	server.Handle(http.MethodPost, "/redfish/v1/AccountService/Accounts",
+		func(server *Server, request *Request) *Response {
+			return errorResponse(http.StatusMethodNotAllowed, "accounts can not be created")
+		})
+	server.Handle(http.MethodPatch, "/redfish/v1/AccountService/Accounts/1",
+		func(server *Server, request *Request) *Response {
+			return errorResponse(http.StatusBadRequest, "the account is reserved")
+		})
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	accountService, err := redfish.GetAccountService(client, "/redfish/v1/AccountService")
+	if err != nil {
+		t.Fatalf("Error getting account service: %s", err)
+	}
+
+	changes, err := accountService.EnsureAccount("automation", "secret", "Operator")
+	if err != nil {
+		t.Fatalf("Error ensuring account: %s", err)
+	}
+
+	if !changes.Created || changes.Account.ID != "2" {
+		t.Errorf("The account should be created in the first free slot: %v", changes.Account.ID)
+	}
+
+	account := server.Resource("/redfish/v1/AccountService/Accounts/2")
+	if account["UserName"] != "automation" || account["RoleId"] != "Operator" || account["Enabled"] != true {
+		t.Errorf("Unexpected account slot: %v", account)
+	}
+}
+
+// TestServerConfigureLDAP tests configuring the LDAP account provider.
+func TestServerConfigureLDAP(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/AccountService", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Id": "AccountService",
+		"LDAP": {
+			"ServiceEnabled": false,
+			"ServiceAddresses": [],
+			"Certificates": {"@odata.id": "/redfish/v1/AccountService/LDAP/Certificates"}
+		}
+	}`))
+	server.SetResourceJSON("/redfish/v1/AccountService/LDAP/Certificates", []byte(`{
+		"@odata.id": "/redfish/v1/AccountService/LDAP/Certificates",
+		"Members": [],
+		"Members@odata.count": 0
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	accountService, err := redfish.GetAccountService(client, "/redfish/v1/AccountService")
+	if err != nil {
+		t.Fatalf("Error getting account service: %s", err)
+	}
+
+	caPEM, _ := testCertificate(t, "Example CA")
+	configuration := redfish.LDAPConfiguration{
+		Servers:      []string{"ldaps://ldap.example.com:636"},
+		BindDN:       "cn=bmc,dc=example,dc=com",
+		BindPassword: "secret",
+		BaseDNs:      []string{"dc=example,dc=com"},
+		RoleMappings: []redfish.RoleMapping{
+			{RemoteGroup: "bmc-admins", LocalRole: "Administrator"},
+		},
+		CACertificate: caPEM,
+	}
+	err = accountService.ConfigureLDAP(configuration)
+	if err != nil {
+		t.Fatalf("Error configuring LDAP: %s", err)
+	}
+
+	var changes []string
+	for _, request := range server.Requests() {
+		if request.Method != http.MethodGet {
+			changes = append(changes, request.Method+" "+request.Path+" "+string(request.Body))
+		}
+	}
+
+	if len(changes) != 4 {
+		t.Fatalf("Expected the settings, role mappings, certificate and enablement: %v", changes)
+	}
+
+	if !strings.Contains(changes[0], `"ServiceAddresses":["ldaps://ldap.example.com:636"]`) ||
+		!strings.Contains(changes[0], `"Username":"cn=bmc,dc=example,dc=com"`) ||
+		!strings.Contains(changes[0], `"BaseDistinguishedNames":["dc=example,dc=com"]`) {
+		t.Errorf("Unexpected LDAP settings: %s", changes[0])
+	}
+
+	if changes[1] != `PATCH /redfish/v1/AccountService {"LDAP":{"RemoteRoleMapping":[{"LocalRole":"Administrator","RemoteGroup":"bmc-admins"}]}}` {
+		t.Errorf("Unexpected role mappings: %s", changes[1])
+	}
+
+	if !strings.HasPrefix(changes[2], "POST /redfish/v1/AccountService/LDAP/Certificates") {
+		t.Errorf("Unexpected certificate upload: %s", changes[2])
+	}
+
+	if changes[3] != `PATCH /redfish/v1/AccountService {"LDAP":{"ServiceEnabled":true}}` {
+		t.Errorf("Unexpected enablement: %s", changes[3])
+	}
+
+	ldap := server.Resource("/redfish/v1/AccountService")["LDAP"].(map[string]interface{})
+	if ldap["ServiceEnabled"] != true {
+		t.Errorf("LDAP should be enabled: %v", ldap)
+	}
+
+	// The certificate is only uploaded once
+	accountService, err = redfish.GetAccountService(client, "/redfish/v1/AccountService")
+	if err != nil {
+		t.Fatalf("Error getting account service: %s", err)
+	}
+	err = accountService.ConfigureLDAP(configuration)
+	if err != nil {
+		t.Fatalf("Error configuring LDAP: %s", err)
+	}
+
+	certificates, err := accountService.LDAP.Certificates(client)
+	if err != nil || len(certificates) != 1 {
+		t.Errorf("Expected one LDAP certificate: %v %v", certificates, err)
+	}
+}
+
+// TestServerSetTimeConfiguration tests setting the time of a manager through
+// its standard settings.
+func TestServerSetTimeConfiguration(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Managers/1", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"DateTimeLocalOffset": "+00:00",
+		"TimeZoneName": "UTC",
+		"NetworkProtocol": {"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/Managers/1/NetworkProtocol", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol",
+		"Id": "NetworkProtocol",
+		"NTP": {"ProtocolEnabled": false, "NTPServers": []}
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	manager, err := redfish.GetManager(client, "/redfish/v1/Managers/1")
+	if err != nil {
+		t.Fatalf("Error getting manager: %s", err)
+	}
+
+	err = manager.SetTimeConfiguration([]string{"ntp.example.com"}, "Europe/Paris")
+	if err != nil {
+		t.Fatalf("Error setting the time configuration: %s", err)
+	}
+
+	ntp := server.Resource("/redfish/v1/Managers/1/NetworkProtocol")["NTP"].(map[string]interface{})
+	if ntp["ProtocolEnabled"] != true || fmt.Sprint(ntp["NTPServers"]) != "[ntp.example.com]" {
+		t.Errorf("NTP was not set: %v", ntp)
+	}
+
+	if server.Resource("/redfish/v1/Managers/1")["TimeZoneName"] != "Europe/Paris" {
+		t.Errorf("Time zone was not set: %v", server.Resource("/redfish/v1/Managers/1"))
+	}
+
+	// A UTC offset is set as DateTimeLocalOffset
+	err = manager.SetTimeConfiguration(nil, "+02:00")
+	if err != nil {
+		t.Fatalf("Error setting the time configuration: %s", err)
+	}
+
+	if server.Resource("/redfish/v1/Managers/1")["DateTimeLocalOffset"] != "+02:00" {
+		t.Errorf("Offset was not set: %v", server.Resource("/redfish/v1/Managers/1"))
+	}
+}
+
+// TestServerSetTimeConfigurationOEM tests setting the time of a manager that
+// takes it in OEM settings.
+func TestServerSetTimeConfigurationOEM(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Managers/1", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/1",
+		"Id": "1",
+		"Oem": {
+			"Hpe": {
+				"Links": {"DateTimeService": {"@odata.id": "/redfish/v1/Managers/1/DateTime"}}
+			}
+		}
+	}`))
+	server.SetResourceJSON("/redfish/v1/Managers/1/DateTime", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/1/DateTime",
+		"Id": "DateTime",
+		"StaticNTPServers": ["", ""],
+		"TimeZone": {"Name": "UTC"}
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	manager, err := redfish.GetManager(client, "/redfish/v1/Managers/1")
+	if err != nil {
+		t.Fatalf("Error getting manager: %s", err)
+	}
+
+	err = manager.SetTimeConfiguration([]string{"ntp.example.com"}, "America/Chicago")
+	if err != nil {
+		t.Fatalf("Error setting the time configuration: %s", err)
+	}
+
+	dateTime := server.Resource("/redfish/v1/Managers/1/DateTime")
+	if fmt.Sprint(dateTime["StaticNTPServers"]) != "[ntp.example.com]" {
+		t.Errorf("NTP servers were not set: %v", dateTime)
+	}
+
+	if dateTime["TimeZone"].(map[string]interface{})["Name"] != "America/Chicago" {
+		t.Errorf("Time zone was not set: %v", dateTime)
+	}
+
+	for _, request := range server.Requests() {
+		if request.Method == http.MethodPatch && request.Path == "/redfish/v1/Managers/1" {
+			t.Errorf("The standard settings should not be used: %s", request.Body)
+		}
+	}
+}
+
+// TestServerSNMPConfiguration tests enabling SNMP on a manager and sending
+// its traps to a trap receiver.
+func TestServerSNMPConfiguration(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Managers/1/NetworkProtocol", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/1/NetworkProtocol",
+		"Id": "NetworkProtocol",
+		"SNMP": {
+			"ProtocolEnabled": false,
+			"Port": 161,
+			"EnableSNMPv2c": false,
+			"CommunityStrings": [],
+			"TrapPort": 162
+		}
+	}`))
+	server.SetResourceJSON("/redfish/v1/EventService", []byte(`{
+		"@odata.id": "/redfish/v1/EventService",
+		"Id": "EventService",
+		"Subscriptions": {"@odata.id": "/redfish/v1/EventService/Subscriptions"}
+	}`))
+	server.SetResourceJSON("/redfish/v1/EventService/Subscriptions", []byte(`{
+		"@odata.id": "/redfish/v1/EventService/Subscriptions",
+		"Members": [],
+		"Members@odata.count": 0
+	}`))
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	networkProtocol, err := redfish.GetManagerNetworkProtocol(client, "/redfish/v1/Managers/1/NetworkProtocol")
+	if err != nil {
+		t.Fatalf("Error getting network protocol: %s", err)
+	}
+
+	networkProtocol.SNMP.ProtocolEnabled = true
+	networkProtocol.SNMP.EnableSNMPv2c = true
+	networkProtocol.SNMP.CommunityStrings = []redfish.SNMPCommunity{
+		{Name: "monitoring", CommunityString: "s3cret", AccessMode: redfish.LimitedSNMPCommunityAccessMode},
+	}
+	err = networkProtocol.Update()
+	if err != nil {
+		t.Fatalf("Error updating network protocol: %s", err)
+	}
+
+	snmp := server.Resource("/redfish/v1/Managers/1/NetworkProtocol")["SNMP"].(map[string]interface{})
+	if snmp["ProtocolEnabled"] != true || snmp["EnableSNMPv2c"] != true {
+		t.Errorf("SNMP was not enabled: %v", snmp)
+	}
+	if communities := snmp["CommunityStrings"].([]interface{}); len(communities) != 1 {
+		t.Errorf("Community strings were not set: %v", snmp)
+	}
+
+	eventService, err := redfish.GetEventService(client, "/redfish/v1/EventService")
+	if err != nil {
+		t.Fatalf("Error getting event service: %s", err)
+	}
+
+	uri, err := eventService.CreateEventSubscription(redfish.EventSubscriptionParameters{
+		Destination: "snmp://traps.example.com:162",
+		Protocol:    redfish.SNMPv2cEventDestinationProtocol,
+		SNMP:        &redfish.SNMPSettings{TrapCommunity: "s3cret"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating trap subscription: %s", err)
+	}
+
+	subscription := server.Resource(uri)
+	if subscription["SubscriptionType"] != "SNMPTrap" || subscription["Protocol"] != "SNMPv2c" {
+		t.Errorf("Unexpected trap subscription: %v", subscription)
+	}
+}
+
+// TestServerRemoteConsole tests getting a one-time iDRAC console session.
+func TestServerRemoteConsole(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResourceJSON("/redfish/v1/Managers/iDRAC.Embedded.1", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1",
+		"Id": "iDRAC.Embedded.1",
+		"GraphicalConsole": {"ServiceEnabled": true, "ConnectTypesSupported": ["KVMIP"]},
+		"Links": {
+			"Oem": {
+				"Dell": {
+					"DelliDRACCardService": {"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService"}
+				}
+			}
+		}
+	}`))
+	server.SetResourceJSON("/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService", []byte(`{
+		"@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService",
+		"Id": "DelliDRACCardService",
+		"Actions": {
+			"#DelliDRACCardService.GetKVMSession": {
+				"target": "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService/Actions/DelliDRACCardService.GetKVMSession"
+			}
+		}
+	}`))
+	server.Handle(http.MethodPost, "/redfish/v1/Managers/iDRAC.Embedded.1/Oem/Dell/DelliDRACCardService/Actions/DelliDRACCardService.GetKVMSession",
+		func(server *Server, request *Request) *Response {
+			return &Response{
+				Status: http.StatusOK,
+				Body:   map[string]string{"TempUsername": "kvm1", "TempPassword": "0neT1me"},
+			}
+		})
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	manager, err := redfish.GetManager(client, "/redfish/v1/Managers/iDRAC.Embedded.1")
+	if err != nil {
+		t.Fatalf("Error getting manager: %s", err)
+	}
+
+	console, err := manager.RemoteConsole(redfish.RemoteConsoleOptions{
+		Endpoint: server.URL,
+		Username: "root",
+	})
+	if err != nil {
+		t.Fatalf("Error getting remote console: %s", err)
+	}
+
+	if console.URL != server.URL+"/console?tempPassword=0neT1me&tempUsername=kvm1&username=root" {
+		t.Errorf("Unexpected console URL: %s", console.URL)
+	}
+	if console.Username != "kvm1" || console.Password != "0neT1me" || console.Vendor != "Dell" {
+		t.Errorf("Unexpected console credentials: %v", console)
+	}
+}
+
+// TestServerServiceCache tests caching the collections of the service root.
+func TestServerServiceCache(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systemGets := func() int {
+		count := 0
+		for _, request := range server.Requests() {
+			if request.Method == http.MethodGet && request.Path == "/redfish/v1/Systems/1" {
+				count++
+			}
+		}
+		return count
+	}
+
+	client.Service.EnableCache()
+	for i := 0; i < 3; i++ {
+		systems, err := client.Service.Systems()
+		if err != nil || len(systems) != 1 {
+			t.Fatalf("Error getting systems: %v %v", systems, err)
+		}
+	}
+	if systemGets() != 1 {
+		t.Errorf("Systems should be got once while cached, got %d times", systemGets())
+	}
+
+	client.Service.InvalidateCache()
+	_, err = client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	if systemGets() != 2 {
+		t.Errorf("Systems should be got again once invalidated, got %d times", systemGets())
+	}
+
+	client.Service.DisableCache()
+	_, err = client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	_, err = client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	if systemGets() != 4 {
+		t.Errorf("Systems should be got on every call without the cache, got %d times", systemGets())
+	}
+
+	// The cache can be switched while the service is in use
+	var wait sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			for j := 0; j < 10; j++ {
+				switch (i + j) % 4 {
+				case 0:
+					client.Service.EnableCache()
+				case 1:
+					client.Service.DisableCache()
+				case 2:
+					client.Service.InvalidateCache()
+				}
+				if _, err := client.Service.Systems(); err != nil {
+					t.Errorf("Error getting systems: %s", err)
+				}
+			}
+		}(i)
+	}
+	wait.Wait()
+}
+
+// TestServerSystemsExpanded tests getting the systems in one request.
+func TestServerSystemsExpanded(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	memberGets := func() int {
+		count := 0
+		for _, request := range server.Requests() {
+			if request.Method == http.MethodGet && request.Path == "/redfish/v1/Systems/1" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Without $expand, the systems are got one by one
+	systems, err := client.Service.SystemsExpanded()
+	if err != nil || len(systems) != 1 {
+		t.Fatalf("Error getting systems: %v %v", systems, err)
+	}
+	if memberGets() != 1 {
+		t.Errorf("The system should be got on its own, got %d times", memberGets())
+	}
+
+	var root map[string]interface{}
+	err = json.Unmarshal([]byte(serviceRootBody), &root)
+	if err != nil {
+		t.Fatalf("Error decoding service root: %s", err)
+	}
+	root["ProtocolFeaturesSupported"] = map[string]interface{}{
+		"ExpandQuery": map[string]interface{}{"ExpandAll": true, "Levels": true, "MaxLevels": 3, "NoLinks": true},
+	}
+	server.SetResource("/redfish/v1", root)
+
+	client, err = server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systems, err = client.Service.SystemsExpanded()
+	if err != nil || len(systems) != 1 {
+		t.Fatalf("Error getting systems: %v %v", systems, err)
+	}
+	if memberGets() != 1 {
+		t.Errorf("The system should be expanded, got %d times", memberGets())
+	}
+	if systems[0].ID != "1" {
+		t.Errorf("Unexpected expanded system: %v", systems[0])
+	}
+
+	var expand string
+	for _, request := range server.Requests() {
+		if request.Path == "/redfish/v1/Systems" {
+			expand = request.Query.Get("$expand")
+		}
+	}
+	if expand != ".($levels=1)" {
+		t.Errorf("Unexpected $expand query: %s", expand)
+	}
+}
+
+// TestServerCapabilities tests probing the resources and actions of the
+// service.
+func TestServerCapabilities(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	var system map[string]interface{}
+	err := json.Unmarshal([]byte(systemBody), &system)
+	if err != nil {
+		t.Fatalf("Error decoding system: %s", err)
+	}
+	system["Actions"] = map[string]interface{}{
+		"#ComputerSystem.Reset": map[string]interface{}{"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"},
+	}
+	server.SetResource("/redfish/v1/Systems/1", system)
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	for name, expected := range map[string]bool{
+		"Systems":          true,
+		"SessionService":   false,
+		"TelemetryService": false,
+		"RedfishVersion":   false,
+	} {
+		supported, err := client.Service.SupportsResource(name)
+		if err != nil || supported != expected {
+			t.Errorf("Unexpected support of %s: %t %v", name, supported, err)
+		}
+	}
+
+	for _, test := range []struct {
+		uri      string
+		action   string
+		expected bool
+	}{
+		{"/redfish/v1/Systems/1", "ComputerSystem.Reset", true},
+		{"/redfish/v1/Systems/1", "Reset", true},
+		{"/redfish/v1/Systems/1", "#ComputerSystem.Reset", true},
+		{"/redfish/v1/Systems/1", "SecureErase", false},
+		{"/redfish/v1/Systems/2", "Reset", false},
+	} {
+		supported, err := client.Service.SupportsAction(test.uri, test.action)
+		if err != nil || supported != test.expected {
+			t.Errorf("Unexpected support of %s on %s: %t %v", test.action, test.uri, supported, err)
+		}
+	}
+}
+
+// TestServerServiceConditions tests getting the conditions of the service.
+func TestServerServiceConditions(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	// Without ServiceConditions nor managers, nothing is wrong
+	conditions, err := client.Service.ServiceConditions()
+	if err != nil || !conditions.Healthy() {
+		t.Errorf("Unexpected conditions: %v %v", conditions, err)
+	}
+
+	var root map[string]interface{}
+	err = json.Unmarshal([]byte(serviceRootBody), &root)
+	if err != nil {
+		t.Fatalf("Error decoding service root: %s", err)
+	}
+	root["ServiceConditions"] = map[string]interface{}{"@odata.id": "/redfish/v1/ServiceConditions"}
+	server.SetResource("/redfish/v1", root)
+	err = server.SetResourceJSON("/redfish/v1/ServiceConditions", []byte(`{
+		"@odata.id": "/redfish/v1/ServiceConditions",
+		"Id": "ServiceConditions",
+		"HealthRollup": "Critical",
+		"Conditions": [{"MessageId": "Base.1.9.ConditionInRelatedResource", "Severity": "Critical"}]
+	}`))
+	if err != nil {
+		t.Fatalf("Error setting conditions: %s", err)
+	}
+
+	client, err = server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	conditions, err = client.Service.ServiceConditions()
+	if err != nil {
+		t.Fatalf("Error getting conditions: %s", err)
+	}
+	if conditions.Healthy() || len(conditions.Conditions) != 1 {
+		t.Errorf("Unexpected conditions: %v", conditions)
+	}
+}
+
+// TestServerDeepGet tests getting a storage subsystem and its volumes and
+// drives in one request.
+func TestServerDeepGet(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	err := server.SetResourceJSON("/redfish/v1/Systems/1/Storage/1", []byte(`{
+		"@odata.type": "#Storage.v1_8_0.Storage",
+		"@odata.id": "/redfish/v1/Systems/1/Storage/1",
+		"Id": "1",
+		"Name": "Storage",
+		"Drives": [
+			{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/0", "Id": "0", "CapacityBytes": 899527000000}
+		],
+		"Volumes": {
+			"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes",
+			"Members@odata.count": 1,
+			"Members": [
+				{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Volumes/1", "Id": "1", "CapacityBytes": 107374182400}
+			]
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Error setting storage: %s", err)
+	}
+
+	var root map[string]interface{}
+	err = json.Unmarshal([]byte(serviceRootBody), &root)
+	if err != nil {
+		t.Fatalf("Error decoding service root: %s", err)
+	}
+	root["ProtocolFeaturesSupported"] = map[string]interface{}{
+		"ExpandQuery":    map[string]interface{}{"Levels": true, "MaxLevels": 6, "NoLinks": true},
+		"DeepOperations": map[string]interface{}{"DeepPATCH": true, "MaxLevels": 3},
+	}
+	server.SetResource("/redfish/v1", root)
+
+	client, err := server.Connect()
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	deepClient, err := client.Service.DeepGet("/redfish/v1/Systems/1/Storage/1", 5)
+	if err != nil {
+		t.Fatalf("Error getting storage: %s", err)
+	}
+
+	storage, err := redfish.GetStorage(deepClient, "/redfish/v1/Systems/1/Storage/1")
+	if err != nil {
+		t.Fatalf("Error decoding storage: %s", err)
+	}
+	volumes, err := storage.Volumes()
+	if err != nil || len(volumes) != 1 || volumes[0].CapacityBytes != 107374182400 {
+		t.Errorf("Unexpected volumes: %v %v", volumes, err)
+	}
+	drives, err := storage.Drives()
+	if err != nil || len(drives) != 1 || drives[0].CapacityBytes != 899527000000 {
+		t.Errorf("Unexpected drives: %v %v", drives, err)
+	}
+
+	var storageRequests []Request
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request.Path, "/redfish/v1/Systems/1/Storage") {
+			storageRequests = append(storageRequests, request)
+		}
+	}
+	if len(storageRequests) != 1 || storageRequests[0].Query.Get("$expand") != ".($levels=3)" {
+		t.Errorf("The storage should be got in one capped request: %v", storageRequests)
+	}
+}
+
+// TestServerPasswordChangeRequired tests connecting to a service requiring
+// the password of the account to be changed on first login.
+func TestServerPasswordChangeRequired(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	server.SetResource("/redfish/v1/AccountService/Accounts/2", map[string]interface{}{
+		"@odata.id":              "/redfish/v1/AccountService/Accounts/2",
+		"Id":                     "2",
+		"UserName":               "root",
+		"PasswordChangeRequired": true,
+	})
+	password := "calvin"
+	server.Handle(http.MethodPost, "/redfish/v1/SessionService/Sessions", func(server *Server, request *Request) *Response {
+		var payload struct {
+			UserName string
+			Password string
+		}
+		_ = request.Unmarshal(&payload)
+		if payload.Password != password {
+			return errorResponse(http.StatusUnauthorized, "invalid credentials")
+		}
+
+		body := map[string]interface{}{"@odata.id": "/redfish/v1/SessionService/Sessions/1"}
+		if server.Resource("/redfish/v1/AccountService/Accounts/2")["PasswordChangeRequired"] == true {
+			body["@Message.ExtendedInfo"] = []interface{}{map[string]interface{}{
+				"MessageId":   "Base.1.8.PasswordChangeRequired",
+				"MessageArgs": []string{"/redfish/v1/AccountService/Accounts/2"},
+			}}
+		}
+		return &Response{
+			Status: http.StatusCreated,
+			Header: http.Header{
+				"Location":     {"/redfish/v1/SessionService/Sessions/1"},
+				"X-Auth-Token": {"token-" + password},
+			},
+			Body: body,
+		}
+	})
+	server.Handle(http.MethodPatch, "/redfish/v1/AccountService/Accounts/2", func(server *Server, request *Request) *Response {
+		var payload struct {
+			Password string
+		}
+		_ = request.Unmarshal(&payload)
+		password = payload.Password
+
+		account := server.Resource("/redfish/v1/AccountService/Accounts/2")
+		account["PasswordChangeRequired"] = false
+		server.SetResource("/redfish/v1/AccountService/Accounts/2", account)
+		return &Response{Status: http.StatusNoContent}
+	})
+
+	config := wbfish.ClientConfig{Endpoint: server.URL, Username: "root", Password: "calvin"}
+	_, err := wbfish.Connect(config)
+	var required redfish.ErrorPasswordChangeRequired
+	if !errors.As(err, &required) || required.AccountURI != "/redfish/v1/AccountService/Accounts/2" {
+		t.Fatalf("Connect should require the password to be changed: %v", err)
+	}
+
+	config.NewPassword = "n3wPassw0rd"
+	client, err := wbfish.Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting with a new password: %s", err)
+	}
+	if password != "n3wPassw0rd" {
+		t.Errorf("The password should be changed: %s", password)
+	}
+
+	// Requests denied until the password is changed are told apart
+	server.Handle(http.MethodGet, "/redfish/v1/Systems", func(server *Server, request *Request) *Response {
+		return &Response{
+			Status: http.StatusForbidden,
+			Body: map[string]interface{}{"error": map[string]interface{}{
+				"code": "Base.1.8.GeneralError",
+				"@Message.ExtendedInfo": []interface{}{map[string]interface{}{
+					"MessageId":   "Base.1.8.PasswordChangeRequired",
+					"MessageArgs": []string{"/redfish/v1/AccountService/Accounts/2"},
+				}},
+			}},
+		}
+	})
+	_, err = client.Service.Systems()
+	var wrongResponse wbfish.ErrorWrongResponse
+	if !errors.As(err, &required) || !errors.As(err, &wrongResponse) || wrongResponse.Code != http.StatusForbidden {
+		t.Errorf("Invalid error for a denied request: %v", err)
+	}
+}
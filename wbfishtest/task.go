@@ -0,0 +1,99 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package wbfishtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// tasksCollection is the URI of the collection simulated tasks are added to.
+const tasksCollection = "/redfish/v1/TaskService/Tasks"
+
+// task is a simulated long running operation.
+type task struct {
+	polls      int
+	remaining  int
+	onComplete func()
+}
+
+// poll advances the task each time it is read. It returns the completion
+// callback once the task finishes, to be called without holding the lock.
+func (t *task) poll(resource map[string]interface{}) func() {
+	if t.remaining <= 0 {
+		return nil
+	}
+
+	t.remaining--
+	resource["PercentComplete"] = 100 * (t.polls - t.remaining) / t.polls
+	if t.remaining > 0 {
+		return nil
+	}
+
+	resource["TaskState"] = "Completed"
+	resource["TaskStatus"] = "OK"
+	resource["Messages"] = []interface{}{
+		map[string]interface{}{
+			"MessageId": "Base.1.8.Success",
+			"Message":   "Successfully Completed Request",
+		},
+	}
+	return t.onComplete
+}
+
+// NewTask simulates a long running operation. It creates a running task in
+// the task service collection that completes after it has been read the
+// given number of times, calling onComplete if it is set, and returns the
+// 202 Accepted response pointing to the task. Scripted handlers return it
+// for operations that the service runs asynchronously.
+func (server *Server) NewTask(polls int, onComplete func()) *Response {
+	if polls < 1 {
+		polls = 1
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	collection, ok := server.resources[tasksCollection]
+	if !ok {
+		collection = map[string]interface{}{
+			"@odata.id":           tasksCollection,
+			"@odata.type":         "#TaskCollection.TaskCollection",
+			"Name":                "Task Collection",
+			"Members":             []interface{}{},
+			"Members@odata.count": 0,
+		}
+		server.resources[tasksCollection] = collection
+	}
+
+	id := strconv.Itoa(server.nextID)
+	server.nextID++
+	uri := tasksCollection + "/" + id
+
+	resource := map[string]interface{}{
+		"@odata.id":       uri,
+		"@odata.type":     "#Task.v1_4_3.Task",
+		"Id":              id,
+		"Name":            fmt.Sprintf("Task %s", id),
+		"TaskState":       "Running",
+		"TaskStatus":      "OK",
+		"PercentComplete": 0,
+		"TaskMonitor":     uri,
+	}
+	server.resources[uri] = resource
+	addMember(collection, uri)
+	server.tasks[uri] = &task{
+		polls:      polls,
+		remaining:  polls,
+		onComplete: onComplete,
+	}
+
+	return &Response{
+		Status: http.StatusAccepted,
+		Header: http.Header{"Location": {uri}},
+		Body:   copyObject(resource),
+	}
+}